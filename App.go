@@ -31,6 +31,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/devtron-labs/devtron/api/apiToken"
 	"github.com/devtron-labs/devtron/api/util"
 	"github.com/devtron-labs/devtron/otel"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
@@ -70,6 +71,7 @@ type App struct {
 	sessionManager2            *authMiddleware.SessionManager
 	OtelTracingService         *otel.OtelTracingServiceImpl
 	loggingMiddleware          util.LoggingMiddleware
+	apiTokenMiddleware         apiToken.ApiTokenMiddleware
 	pubSubClient               *pubsub.PubSubClientServiceImpl
 	workflowEventProcessorImpl *in.WorkflowEventProcessorImpl
 }
@@ -87,6 +89,7 @@ func NewApp(router *router.MuxRouter,
 	workflowEventProcessorImpl *in.WorkflowEventProcessorImpl,
 	enforcerV2 *casbinv2.SyncedEnforcer,
 	userService user.UserService,
+	apiTokenMiddleware apiToken.ApiTokenMiddleware,
 ) *App {
 	//check argo connection
 	//todo - check argo-cd version on acd integration installation
@@ -105,6 +108,7 @@ func NewApp(router *router.MuxRouter,
 		pubSubClient:               pubSubClient,
 		workflowEventProcessorImpl: workflowEventProcessorImpl,
 		userService:                userService,
+		apiTokenMiddleware:         apiTokenMiddleware,
 	}
 	return app
 }
@@ -122,6 +126,7 @@ func (app *App) Start() {
 
 	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: authMiddleware.Authorizer(app.sessionManager2, user.WhitelistChecker, app.userService.CheckUserStatusAndUpdateLoginAudit)(app.MuxRouter.Router)}
 	app.MuxRouter.Router.Use(app.loggingMiddleware.LoggingMiddleware)
+	app.MuxRouter.Router.Use(app.apiTokenMiddleware.ApiTokenMiddleware)
 	app.MuxRouter.Router.Use(middleware.PrometheusMiddleware)
 	app.MuxRouter.Router.Use(middlewares.Recovery)
 
@@ -36,6 +36,7 @@ import (
 	"github.com/devtron-labs/devtron/api/auth/authorisation/globalConfig"
 	"github.com/devtron-labs/devtron/api/auth/sso"
 	"github.com/devtron-labs/devtron/api/auth/user"
+	"github.com/devtron-labs/devtron/api/celExpression"
 	chartRepo "github.com/devtron-labs/devtron/api/chartRepo"
 	"github.com/devtron-labs/devtron/api/cluster"
 	"github.com/devtron-labs/devtron/api/connector"
@@ -78,6 +79,7 @@ import (
 	"github.com/devtron-labs/devtron/api/userResource"
 	util5 "github.com/devtron-labs/devtron/api/util"
 	webhookHelm "github.com/devtron-labs/devtron/api/webhook/helm"
+	"github.com/devtron-labs/devtron/api/workflowLogs"
 	"github.com/devtron-labs/devtron/cel"
 	"github.com/devtron-labs/devtron/client/argocdServer"
 	"github.com/devtron-labs/devtron/client/argocdServer/application"
@@ -175,6 +177,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/variables"
 	"github.com/devtron-labs/devtron/pkg/variables/parsers"
 	repository10 "github.com/devtron-labs/devtron/pkg/variables/repository"
+	"github.com/devtron-labs/devtron/pkg/variables/secretProvider"
 	workflow3 "github.com/devtron-labs/devtron/pkg/workflow"
 	"github.com/devtron-labs/devtron/pkg/workflow/dag"
 	util2 "github.com/devtron-labs/devtron/util"
@@ -191,6 +194,8 @@ func InitializeApp() (*App, error) {
 		sql.PgSqlWireSet,
 		user.SelfRegistrationWireSet,
 		externalLink.ExternalLinkWireSet,
+		celExpression.CelExpressionWireSet,
+		workflowLogs.WorkflowLogsWireSet,
 		team.TeamsWireSet,
 		AuthWireSet,
 		globalConfig.GlobalConfigWireSet,
@@ -273,6 +278,8 @@ func InitializeApp() (*App, error) {
 
 		repository11.NewBulkEditRepository,
 		wire.Bind(new(repository11.BulkEditRepository), new(*repository11.BulkEditRepositoryImpl)),
+		repository11.NewBulkEditJobRepositoryImpl,
+		wire.Bind(new(repository11.BulkEditJobRepository), new(*repository11.BulkEditJobRepositoryImpl)),
 
 		chartConfig.NewEnvConfigOverrideRepository,
 		wire.Bind(new(chartConfig.EnvConfigOverrideRepository), new(*chartConfig.EnvConfigOverrideRepositoryImpl)),
@@ -348,6 +355,8 @@ func InitializeApp() (*App, error) {
 		// scoped variables start
 		variables.NewScopedVariableServiceImpl,
 		wire.Bind(new(variables.ScopedVariableService), new(*variables.ScopedVariableServiceImpl)),
+		secretProvider.NewSecretProviderFactoryImpl,
+		wire.Bind(new(secretProvider.SecretProviderFactory), new(*secretProvider.SecretProviderFactoryImpl)),
 
 		parsers.NewVariableTemplateParserImpl,
 		wire.Bind(new(parsers.VariableTemplateParser), new(*parsers.VariableTemplateParserImpl)),
@@ -379,6 +388,8 @@ func InitializeApp() (*App, error) {
 		service.NewBulkUpdateServiceEntImpl,
 		service.NewBulkUpdateServiceImpl,
 		wire.Bind(new(service.BulkUpdateService), new(*service.BulkUpdateServiceImpl)),
+		service.NewBulkEditJobServiceImpl,
+		wire.Bind(new(service.BulkEditJobService), new(*service.BulkEditJobServiceImpl)),
 
 		repository.NewImageTagRepository,
 		wire.Bind(new(repository.ImageTagRepository), new(*repository.ImageTagRepositoryImpl)),
@@ -421,6 +432,9 @@ func InitializeApp() (*App, error) {
 
 		eClient.NewEventRESTClientImpl,
 		wire.Bind(new(eClient.EventClient), new(*eClient.EventRESTClientImpl)),
+		wire.Bind(new(eClient.NotificationDeliveryService), new(*eClient.EventRESTClientImpl)),
+		repository.NewNotificationDeliveryLogRepositoryImpl,
+		wire.Bind(new(repository.NotificationDeliveryLogRepository), new(*repository.NotificationDeliveryLogRepositoryImpl)),
 
 		eClient.NewEventSimpleFactoryImpl,
 		wire.Bind(new(eClient.EventFactory), new(*eClient.EventSimpleFactoryImpl)),
@@ -481,6 +495,14 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(notifier.WebhookNotificationService), new(*notifier.WebhookNotificationServiceImpl)),
 		repository.NewWebhookNotificationRepositoryImpl,
 		wire.Bind(new(repository.WebhookNotificationRepository), new(*repository.WebhookNotificationRepositoryImpl)),
+		notifier.NewTeamsNotificationServiceImpl,
+		wire.Bind(new(notifier.TeamsNotificationService), new(*notifier.TeamsNotificationServiceImpl)),
+		repository.NewTeamsNotificationRepositoryImpl,
+		wire.Bind(new(repository.TeamsNotificationRepository), new(*repository.TeamsNotificationRepositoryImpl)),
+		notifier.NewGoogleChatNotificationServiceImpl,
+		wire.Bind(new(notifier.GoogleChatNotificationService), new(*notifier.GoogleChatNotificationServiceImpl)),
+		repository.NewGoogleChatNotificationRepositoryImpl,
+		wire.Bind(new(repository.GoogleChatNotificationRepository), new(*repository.GoogleChatNotificationRepositoryImpl)),
 
 		notifier.NewNotificationConfigServiceImpl,
 		wire.Bind(new(notifier.NotificationConfigService), new(*notifier.NotificationConfigServiceImpl)),
@@ -853,6 +875,20 @@ func InitializeApp() (*App, error) {
 		cron.NewCiTriggerCronImpl,
 		wire.Bind(new(cron.CiTriggerCron), new(*cron.CiTriggerCronImpl)),
 
+		cron.GetNotificationDigestCronConfig,
+		cron.NewNotificationDigestCronImpl,
+		wire.Bind(new(cron.NotificationDigestCron), new(*cron.NotificationDigestCronImpl)),
+		repository.NewNotificationDigestEventRepositoryImpl,
+		wire.Bind(new(repository.NotificationDigestEventRepository), new(*repository.NotificationDigestEventRepositoryImpl)),
+
+		cron.GetNotificationDeliveryRetryCronConfig,
+		cron.NewNotificationDeliveryRetryCronImpl,
+		wire.Bind(new(cron.NotificationDeliveryRetryCron), new(*cron.NotificationDeliveryRetryCronImpl)),
+
+		cron.GetTektonWorkflowStatusCronConfig,
+		cron.NewTektonWorkflowStatusCronImpl,
+		wire.Bind(new(cron.TektonWorkflowStatusCron), new(*cron.TektonWorkflowStatusCronImpl)),
+
 		status2.NewPipelineStatusTimelineRestHandlerImpl,
 		wire.Bind(new(status2.PipelineStatusTimelineRestHandler), new(*status2.PipelineStatusTimelineRestHandlerImpl)),
 
@@ -925,6 +961,8 @@ func InitializeApp() (*App, error) {
 		wire.Bind(new(executors.ArgoWorkflowExecutor), new(*executors.ArgoWorkflowExecutorImpl)),
 		executors.NewSystemWorkflowExecutorImpl,
 		wire.Bind(new(executors.SystemWorkflowExecutor), new(*executors.SystemWorkflowExecutorImpl)),
+		executors.NewTektonWorkflowExecutorImpl,
+		wire.Bind(new(executors.TektonWorkflowExecutor), new(*executors.TektonWorkflowExecutorImpl)),
 		repository5.NewManifestPushConfigRepository,
 		wire.Bind(new(repository5.ManifestPushConfigRepository), new(*repository5.ManifestPushConfigRepositoryImpl)),
 		publish.NewGitOpsManifestPushServiceImpl,
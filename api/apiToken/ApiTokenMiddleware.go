@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiToken
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/apiToken"
+	"github.com/devtron-labs/devtron/pkg/apiToken/bean"
+	"go.uber.org/zap"
+)
+
+const xForwardedForHeader = "X-Forwarded-For"
+
+type ApiTokenMiddleware interface {
+	ApiTokenMiddleware(next http.Handler) http.Handler
+}
+
+type ApiTokenMiddlewareImpl struct {
+	logger          *zap.SugaredLogger
+	apiTokenService apiToken.ApiTokenService
+	tokenConfig     *apiToken.TokenVariableConfig
+}
+
+func NewApiTokenMiddlewareImpl(logger *zap.SugaredLogger, apiTokenService apiToken.ApiTokenService) (*ApiTokenMiddlewareImpl, error) {
+	tokenConfig, err := apiToken.GetTokenConfig()
+	if err != nil {
+		logger.Errorw("error while getting token config", "err", err)
+		return nil, err
+	}
+	return &ApiTokenMiddlewareImpl{
+		logger:          logger,
+		apiTokenService: apiTokenService,
+		tokenConfig:     tokenConfig,
+	}, nil
+}
+
+// ApiTokenMiddleware enforces the path and source ip restrictions of the api token scope and records the token usage,
+// requests with other tokens are passed through as is
+func (impl ApiTokenMiddlewareImpl) ApiTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var token string
+		if strings.Contains(r.URL.Path, bean.WebhookPathPrefix) {
+			token = r.Header.Get("api-token")
+		} else {
+			token = r.Header.Get("token")
+		}
+		if len(token) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		claims, err := impl.apiTokenService.VerifyApiToken(token)
+		if err != nil || claims == nil {
+			// invalid tokens are rejected by the authorizer
+			next.ServeHTTP(w, r)
+			return
+		}
+		clientIp := bean.GetClientIp(r.RemoteAddr, r.Header.Get(xForwardedForHeader), impl.tokenConfig.ApiTokenTrustedProxyCidrs)
+		if !claims.Scope.IsPathAllowed(r.URL.Path) {
+			impl.logger.Infow("api token scope does not allow the path", "email", claims.Email, "path", r.URL.Path)
+			common.WriteJsonResp(w, errors.New("api token is not allowed to access this api"), nil, http.StatusForbidden)
+			return
+		}
+		if !claims.Scope.IsClientIpAllowed(clientIp) {
+			impl.logger.Infow("api token scope does not allow the client ip", "email", claims.Email, "clientIp", clientIp)
+			common.WriteJsonResp(w, errors.New("api token is not allowed from this ip"), nil, http.StatusForbidden)
+			return
+		}
+		go impl.apiTokenService.RecordApiTokenUsage(claims, clientIp)
+		next.ServeHTTP(w, r)
+	})
+}
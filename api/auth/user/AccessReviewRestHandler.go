@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+)
+
+func (handler UserRestHandlerImpl) ReviewAccess(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	// RBAC enforcer applying
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	//RBAC enforcer Ends
+	var request bean2.AccessReviewRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, ReviewAccess", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, ReviewAccess", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	res, err := handler.accessReviewService.ReviewAccess(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, ReviewAccess", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// ExportEffectivePermissions exports the subject to effective permission matrix, format query param can be json(default) or csv
+func (handler UserRestHandlerImpl) ExportEffectivePermissions(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	// RBAC enforcer applying
+	token := r.Header.Get("token")
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	//RBAC enforcer Ends
+	format := bean2.PermissionExportFormat(r.URL.Query().Get("format"))
+	if len(format) == 0 {
+		format = bean2.PermissionExportFormatJson
+	}
+	if format != bean2.PermissionExportFormatJson && format != bean2.PermissionExportFormatCsv {
+		common.WriteJsonResp(w, fmt.Errorf("unsupported format %q, supported formats are json and csv", format), nil, http.StatusBadRequest)
+		return
+	}
+	permissions, err := handler.accessReviewService.GetEffectivePermissions()
+	if err != nil {
+		handler.logger.Errorw("service err, ExportEffectivePermissions", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if format == bean2.PermissionExportFormatJson {
+		common.WriteJsonResp(w, nil, permissions, http.StatusOK)
+		return
+	}
+	fileName := fmt.Sprintf("effective-permissions-%s.csv", time.Now().Format("20060102150405"))
+	w.Header().Set(common.CONTENT_DISPOSITION, "attachment; filename="+fileName)
+	w.Header().Set(common.CONTENT_TYPE, "text/csv")
+	csvWriter := csv.NewWriter(w)
+	err = csvWriter.Write(bean2.GetEffectivePermissionCsvHeader())
+	for i := 0; err == nil && i < len(permissions); i++ {
+		err = csvWriter.Write(permissions[i].ToCsvRecord())
+	}
+	csvWriter.Flush()
+	if err == nil {
+		err = csvWriter.Error()
+	}
+	if err != nil {
+		handler.logger.Errorw("error in writing effective permissions csv", "err", err)
+	}
+}
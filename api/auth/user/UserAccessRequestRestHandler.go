@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+)
+
+func (handler UserRestHandlerImpl) CreateAccessRequest(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request bean2.UserAccessRequestDto
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request err, CreateAccessRequest", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, CreateAccessRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	// a user requests access for self only
+	request.UserId = userId
+	res, err := handler.userAccessRequestService.CreateAccessRequest(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateAccessRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// GetAccessRequests returns the requests of the logged-in user along with the requests the user can approve
+func (handler UserRestHandlerImpl) GetAccessRequests(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	filter := &bean2.UserAccessRequestFilter{
+		Status: bean2.AccessRequestStatus(r.URL.Query().Get("status")),
+	}
+	requesterId, err := common.ExtractIntQueryParam(w, r, "userId", 0)
+	if err != nil {
+		return
+	}
+	filter.UserId = int32(requesterId)
+	requests, err := handler.userAccessRequestService.GetAccessRequests(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetAccessRequests", "err", err, "filter", filter)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	authorisedRequests := make([]*bean2.UserAccessRequestDto, 0, len(requests))
+	for _, request := range requests {
+		if request.UserId == userId || handler.checkRBACForAccessRequestApproval(token, request) {
+			authorisedRequests = append(authorisedRequests, request)
+		}
+	}
+	common.WriteJsonResp(w, nil, authorisedRequests, http.StatusOK)
+}
+
+func (handler UserRestHandlerImpl) GetAccessRequestById(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	request, err := handler.userAccessRequestService.GetAccessRequestById(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetAccessRequestById", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	if request.UserId != userId && !handler.checkRBACForAccessRequestApproval(token, request) {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, request, http.StatusOK)
+}
+
+func (handler UserRestHandlerImpl) ApproveAccessRequest(w http.ResponseWriter, r *http.Request) {
+	handler.handleAccessRequestAction(w, r, "ApproveAccessRequest", false, true, handler.userAccessRequestService.ApproveAccessRequest)
+}
+
+func (handler UserRestHandlerImpl) RejectAccessRequest(w http.ResponseWriter, r *http.Request) {
+	handler.handleAccessRequestAction(w, r, "RejectAccessRequest", false, true, handler.userAccessRequestService.RejectAccessRequest)
+}
+
+func (handler UserRestHandlerImpl) CancelAccessRequest(w http.ResponseWriter, r *http.Request) {
+	handler.handleAccessRequestAction(w, r, "CancelAccessRequest", true, false, handler.userAccessRequestService.CancelAccessRequest)
+}
+
+func (handler UserRestHandlerImpl) RevokeAccessRequest(w http.ResponseWriter, r *http.Request) {
+	handler.handleAccessRequestAction(w, r, "RevokeAccessRequest", true, true, handler.userAccessRequestService.RevokeAccessRequest)
+}
+
+func (handler UserRestHandlerImpl) GetRoleAssignmentAudits(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if int32(id) != userId && !handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*") {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	res, err := handler.userAuditService.GetRoleAssignmentAudits(int32(id))
+	if err != nil {
+		handler.logger.Errorw("service err, GetRoleAssignmentAudits", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// handleAccessRequestAction performs an action on the access request, allowRequester lets the requester act on own
+// request and allowManager lets the users having manager permissions on the requested roles act on it
+func (handler UserRestHandlerImpl) handleAccessRequestAction(w http.ResponseWriter, r *http.Request, actionName string, allowRequester, allowManager bool,
+	performAction func(action *bean2.UserAccessRequestActionDto) (*bean2.UserAccessRequestDto, error)) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	action := &bean2.UserAccessRequestActionDto{}
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(action)
+		if err != nil {
+			handler.logger.Errorw("request err, "+actionName, "err", err, "id", id)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
+	err = handler.validator.Struct(action)
+	if err != nil {
+		handler.logger.Errorw("validation err, "+actionName, "err", err, "payload", action)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	action.Id = id
+	action.UserId = userId
+
+	request, err := handler.userAccessRequestService.GetAccessRequestById(id)
+	if err != nil {
+		handler.logger.Errorw("service err, "+actionName, "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// RBAC enforcer applying
+	token := r.Header.Get("token")
+	isAuthorised := (allowRequester && request.UserId == userId) ||
+		(allowManager && handler.checkRBACForAccessRequestApproval(token, request))
+	if !isAuthorised {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	//RBAC enforcer Ends
+
+	res, err := performAction(action)
+	if err != nil {
+		handler.logger.Errorw("service err, "+actionName, "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// checkRBACForAccessRequestApproval checks if the user has the manager permissions required to assign the requested roles
+func (handler UserRestHandlerImpl) checkRBACForAccessRequestApproval(token string, request *bean2.UserAccessRequestDto) bool {
+	isAuthorised, err := handler.checkRBACForUserCreate(token, false, request.RoleFilters, nil)
+	if err != nil {
+		handler.logger.Errorw("error in checking rbac for access request", "err", err, "id", request.Id)
+		return false
+	}
+	return isAuthorised
+}
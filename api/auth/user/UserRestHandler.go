@@ -61,6 +61,17 @@ type UserRestHandler interface {
 	UpdateTriggerPolicyForTerminalAccess(w http.ResponseWriter, r *http.Request)
 	GetRoleCacheDump(w http.ResponseWriter, r *http.Request)
 	InvalidateRoleCache(w http.ResponseWriter, r *http.Request)
+
+	CreateAccessRequest(w http.ResponseWriter, r *http.Request)
+	GetAccessRequests(w http.ResponseWriter, r *http.Request)
+	GetAccessRequestById(w http.ResponseWriter, r *http.Request)
+	ApproveAccessRequest(w http.ResponseWriter, r *http.Request)
+	RejectAccessRequest(w http.ResponseWriter, r *http.Request)
+	CancelAccessRequest(w http.ResponseWriter, r *http.Request)
+	RevokeAccessRequest(w http.ResponseWriter, r *http.Request)
+	GetRoleAssignmentAudits(w http.ResponseWriter, r *http.Request)
+	ReviewAccess(w http.ResponseWriter, r *http.Request)
+	ExportEffectivePermissions(w http.ResponseWriter, r *http.Request)
 }
 
 type userNamePassword struct {
@@ -76,12 +87,19 @@ type UserRestHandlerImpl struct {
 	roleGroupService    user2.RoleGroupService
 	userCommonService   user2.UserCommonService
 	rbacEnforcementUtil commonEnforcementFunctionsUtil.CommonEnforcementUtil
+
+	userAccessRequestService user2.UserAccessRequestService
+	userAuditService         user2.UserAuditService
+	accessReviewService      user2.AccessReviewService
 }
 
 func NewUserRestHandlerImpl(userService user2.UserService, validator *validator.Validate,
 	logger *zap.SugaredLogger, enforcer casbin.Enforcer, roleGroupService user2.RoleGroupService,
 	userCommonService user2.UserCommonService,
-	rbacEnforcementUtil commonEnforcementFunctionsUtil.CommonEnforcementUtil) *UserRestHandlerImpl {
+	rbacEnforcementUtil commonEnforcementFunctionsUtil.CommonEnforcementUtil,
+	userAccessRequestService user2.UserAccessRequestService,
+	userAuditService user2.UserAuditService,
+	accessReviewService user2.AccessReviewService) *UserRestHandlerImpl {
 	userAuthHandler := &UserRestHandlerImpl{
 		userService:         userService,
 		validator:           validator,
@@ -90,6 +108,10 @@ func NewUserRestHandlerImpl(userService user2.UserService, validator *validator.
 		roleGroupService:    roleGroupService,
 		userCommonService:   userCommonService,
 		rbacEnforcementUtil: rbacEnforcementUtil,
+
+		userAccessRequestService: userAccessRequestService,
+		userAuditService:         userAuditService,
+		accessReviewService:      accessReviewService,
 	}
 	return userAuthHandler
 }
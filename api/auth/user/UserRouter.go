@@ -36,6 +36,28 @@ func NewUserRouterImpl(userRestHandler UserRestHandler) *UserRouterImpl {
 }
 
 func (router UserRouterImpl) InitUserRouter(userAuthRouter *mux.Router) {
+	//Just-in-time access requests, registered before /{id}
+	userAuthRouter.Path("/access-request").
+		HandlerFunc(router.userRestHandler.CreateAccessRequest).Methods("POST")
+	userAuthRouter.Path("/access-request").
+		HandlerFunc(router.userRestHandler.GetAccessRequests).Methods("GET")
+	userAuthRouter.Path("/access-request/{id}").
+		HandlerFunc(router.userRestHandler.GetAccessRequestById).Methods("GET")
+	userAuthRouter.Path("/access-request/{id}/approve").
+		HandlerFunc(router.userRestHandler.ApproveAccessRequest).Methods("PUT")
+	userAuthRouter.Path("/access-request/{id}/reject").
+		HandlerFunc(router.userRestHandler.RejectAccessRequest).Methods("PUT")
+	userAuthRouter.Path("/access-request/{id}/cancel").
+		HandlerFunc(router.userRestHandler.CancelAccessRequest).Methods("PUT")
+	userAuthRouter.Path("/access-request/{id}/revoke").
+		HandlerFunc(router.userRestHandler.RevokeAccessRequest).Methods("PUT")
+	userAuthRouter.Path("/{id}/role-assignment/audit").
+		HandlerFunc(router.userRestHandler.GetRoleAssignmentAudits).Methods("GET")
+	userAuthRouter.Path("/access-review").
+		HandlerFunc(router.userRestHandler.ReviewAccess).Methods("POST")
+	userAuthRouter.Path("/access-review/permissions/export").
+		HandlerFunc(router.userRestHandler.ExportEffectivePermissions).Methods("GET")
+
 	//User management
 	userAuthRouter.Path("/v2").
 		HandlerFunc(router.userRestHandler.GetAllV2).Methods("GET")
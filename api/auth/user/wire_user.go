@@ -55,6 +55,12 @@ var UserWireSet = wire.NewSet(
 	wire.Bind(new(user2.RoleGroupService), new(*user2.RoleGroupServiceImpl)),
 	repository2.NewRoleGroupRepositoryImpl,
 	wire.Bind(new(repository2.RoleGroupRepository), new(*repository2.RoleGroupRepositoryImpl)),
+	user2.NewUserAccessRequestServiceImpl,
+	wire.Bind(new(user2.UserAccessRequestService), new(*user2.UserAccessRequestServiceImpl)),
+	repository2.NewUserAccessRequestRepositoryImpl,
+	wire.Bind(new(repository2.UserAccessRequestRepository), new(*repository2.UserAccessRequestRepositoryImpl)),
+	user2.NewAccessReviewServiceImpl,
+	wire.Bind(new(user2.AccessReviewService), new(*user2.AccessReviewServiceImpl)),
 
 	casbin.NewEnforcerImpl,
 	wire.Bind(new(casbin.Enforcer), new(*casbin.EnforcerImpl)),
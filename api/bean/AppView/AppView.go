@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	driftBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
 	"time"
 )
 
@@ -193,11 +194,12 @@ type DeploymentDetailContainer struct {
 
 type AppDetailContainer struct {
 	DeploymentDetailContainer `json:",inline"`
-	InstanceDetail            []InstanceDetail       `json:"instanceDetail"` //pod list with cpu, memory usage percent
-	Environments              []Environment          `json:"otherEnvironment,omitempty"`
-	LinkOuts                  []LinkOuts             `json:"linkOuts,omitempty"`
-	ResourceTree              map[string]interface{} `json:"resourceTree,omitempty"`
-	Notes                     string                 `json:"notes,omitempty"`
+	InstanceDetail            []InstanceDetail          `json:"instanceDetail"` //pod list with cpu, memory usage percent
+	Environments              []Environment             `json:"otherEnvironment,omitempty"`
+	LinkOuts                  []LinkOuts                `json:"linkOuts,omitempty"`
+	ResourceTree              map[string]interface{}    `json:"resourceTree,omitempty"`
+	Notes                     string                    `json:"notes,omitempty"`
+	GitOpsDrift               *driftBean.GitOpsDriftDto `json:"gitOpsDrift,omitempty"`
 }
 type AppDetailsContainer struct {
 	ResourceTree  map[string]interface{} `json:"resourceTree,omitempty"`
@@ -25,16 +25,16 @@ import (
 
 type GitOpsConfigDto struct {
 	Id                    int             `json:"id,omitempty"`
-	Provider              string          `json:"provider" validate:"oneof=GITLAB GITHUB AZURE_DEVOPS BITBUCKET_CLOUD"`
+	Provider              string          `json:"provider" validate:"oneof=GITLAB GITHUB AZURE_DEVOPS BITBUCKET_CLOUD GITEA BITBUCKET_SERVER GENERIC_GIT"`
 	Username              string          `json:"username"`
 	Token                 string          `json:"token"`
 	GitLabGroupId         string          `json:"gitLabGroupId"`
-	GitHubOrgId           string          `json:"gitHubOrgId"`
+	GitHubOrgId           string          `json:"gitHubOrgId"` // also the organisation for GITEA
 	Host                  string          `json:"host"`
 	Active                bool            `json:"active"`
 	AzureProjectName      string          `json:"azureProjectName"`
 	BitBucketWorkspaceId  string          `json:"bitBucketWorkspaceId"`
-	BitBucketProjectKey   string          `json:"bitBucketProjectKey"`
+	BitBucketProjectKey   string          `json:"bitBucketProjectKey"` // also the project key for BITBUCKET_SERVER
 	AllowCustomRepository bool            `json:"allowCustomRepository"`
 	EnableTLSVerification bool            `json:"enableTLSVerification"`
 	TLSConfig             *bean.TLSConfig `json:"tlsConfig"`
@@ -44,6 +44,9 @@ type GitOpsConfigDto struct {
 	IsTLSCertDataPresent bool `json:"isTLSCertDataPresent"`
 	IsTLSKeyDataPresent  bool `json:"isTLSKeyDataPresent"`
 
+	// SigningConfig is set when the commits pushed to the gitops repositories are to be signed
+	SigningConfig *GitOpsSigningConfig `json:"signingConfig,omitempty"`
+
 	// TODO refactoring: create different struct for internal fields
 	GitRepoName    string `json:"-"`
 	TargetRevision string `json:"-"`
@@ -52,6 +55,18 @@ type GitOpsConfigDto struct {
 	UserId         int32  `json:"-"`
 }
 
+type GitOpsSigningConfig struct {
+	KeyType    constants.SigningKeyType `json:"keyType" validate:"oneof=GPG SSH"`
+	SigningKey string                   `json:"signingKey"` // armored gpg private key or OpenSSH private key
+	Passphrase string                   `json:"passphrase"`
+	// IsSigningKeyPresent is set in the response as the signing key is hidden in FE
+	IsSigningKeyPresent bool `json:"isSigningKeyPresent"`
+}
+
+func (cfg *GitOpsSigningConfig) IsEmpty() bool {
+	return cfg == nil || len(cfg.SigningKey) == 0
+}
+
 type AuthMode string
 
 const (
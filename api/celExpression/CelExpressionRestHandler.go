@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package celExpression
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+type CelExpressionRestHandler interface {
+	GetParamCatalog(w http.ResponseWriter, r *http.Request)
+	ValidateExpression(w http.ResponseWriter, r *http.Request)
+	EvaluateExpression(w http.ResponseWriter, r *http.Request)
+}
+
+type CelExpressionRestHandlerImpl struct {
+	logger              *zap.SugaredLogger
+	celEvaluatorService cel.EvaluatorService
+	userService         user.UserService
+	validator           *validator.Validate
+	enforcer            casbin.Enforcer
+	enforcerUtil        rbac.EnforcerUtil
+}
+
+func NewCelExpressionRestHandlerImpl(logger *zap.SugaredLogger,
+	celEvaluatorService cel.EvaluatorService,
+	userService user.UserService,
+	validator *validator.Validate,
+	enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil,
+) *CelExpressionRestHandlerImpl {
+	return &CelExpressionRestHandlerImpl{
+		logger:              logger,
+		celEvaluatorService: celEvaluatorService,
+		userService:         userService,
+		validator:           validator,
+		enforcer:            enforcer,
+		enforcerUtil:        enforcerUtil,
+	}
+}
+
+func (impl *CelExpressionRestHandlerImpl) GetParamCatalog(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	if !impl.isAuthorised(w, r) {
+		return
+	}
+	common.WriteJsonResp(w, nil, cel.GetParamCatalog(), http.StatusOK)
+}
+
+// isAuthorised allows super admins, and users with update access on the app when the expression is written
+// for an app, e.g. the promotion condition of one of its pipelines
+func (impl *CelExpressionRestHandlerImpl) isAuthorised(w http.ResponseWriter, r *http.Request) bool {
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return false
+	}
+	token := r.Header.Get("token")
+	if appId > 0 {
+		object := impl.enforcerUtil.GetAppRBACNameByAppId(appId)
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionUpdate, object); ok {
+			return true
+		}
+	} else if isSuperAdmin := impl.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); isSuperAdmin {
+		return true
+	}
+	common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+	return false
+}
+
+func (impl *CelExpressionRestHandlerImpl) ValidateExpression(w http.ResponseWriter, r *http.Request) {
+	request, ok := impl.decodeExpressionRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := impl.celEvaluatorService.ValidateExpression(*request)
+	if err != nil {
+		impl.logger.Errorw("service err, ValidateExpression", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *CelExpressionRestHandlerImpl) EvaluateExpression(w http.ResponseWriter, r *http.Request) {
+	request, ok := impl.decodeExpressionRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := impl.celEvaluatorService.EvaluateExpression(*request)
+	if err != nil {
+		// errors here are caused by the sample context, e.g. unknown params
+		impl.logger.Errorw("service err, EvaluateExpression", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *CelExpressionRestHandlerImpl) decodeExpressionRequest(w http.ResponseWriter, r *http.Request) (*cel.ExpressionRequest, bool) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return nil, false
+	}
+	if !impl.isAuthorised(w, r) {
+		return nil, false
+	}
+	decoder := json.NewDecoder(r.Body)
+	var request cel.ExpressionRequest
+	err = decoder.Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, decodeExpressionRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, decodeExpressionRequest", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	return &request, true
+}
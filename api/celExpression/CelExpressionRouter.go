@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package celExpression
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type CelExpressionRouter interface {
+	InitCelExpressionRouter(router *mux.Router)
+}
+
+type CelExpressionRouterImpl struct {
+	celExpressionRestHandler CelExpressionRestHandler
+}
+
+func NewCelExpressionRouterImpl(celExpressionRestHandler CelExpressionRestHandler) *CelExpressionRouterImpl {
+	return &CelExpressionRouterImpl{celExpressionRestHandler: celExpressionRestHandler}
+}
+
+func (impl *CelExpressionRouterImpl) InitCelExpressionRouter(router *mux.Router) {
+	router.Path("/params").HandlerFunc(impl.celExpressionRestHandler.GetParamCatalog).Methods("GET")
+	router.Path("/validate").HandlerFunc(impl.celExpressionRestHandler.ValidateExpression).Methods("POST")
+	router.Path("/evaluate").HandlerFunc(impl.celExpressionRestHandler.EvaluateExpression).Methods("POST")
+}
@@ -378,9 +378,14 @@ func (handler *K8sApplicationRestHandlerImpl) GetHostUrlsByBatch(w http.Response
 
 }
 func (handler *K8sApplicationRestHandlerImpl) CreateResource(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
 	decoder := json.NewDecoder(r.Body)
 	var request bean3.ResourceRequestBean
-	err := decoder.Decode(&request)
+	err = decoder.Decode(&request)
 	if err != nil {
 		handler.logger.Errorw("error in decoding request body", "err", err)
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
@@ -403,6 +408,7 @@ func (handler *K8sApplicationRestHandlerImpl) CreateResource(w http.ResponseWrit
 		return
 	}
 	//RBAC enforcer Ends
+	request.UserId = userId
 	resource, err := handler.k8sApplicationService.RecreateResource(r.Context(), &request)
 	if err != nil {
 		handler.logger.Errorw("error in creating resource", "err", err)
@@ -412,10 +418,15 @@ func (handler *K8sApplicationRestHandlerImpl) CreateResource(w http.ResponseWrit
 	common.WriteJsonResp(w, nil, resource, http.StatusOK)
 }
 func (handler *K8sApplicationRestHandlerImpl) UpdateResource(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
 	decoder := json.NewDecoder(r.Body)
 	var request bean3.ResourceRequestBean
 	token := r.Header.Get("token")
-	err := decoder.Decode(&request)
+	err = decoder.Decode(&request)
 	if err != nil {
 		handler.logger.Errorw("error in decoding request body", "err", err)
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
@@ -443,6 +454,7 @@ func (handler *K8sApplicationRestHandlerImpl) UpdateResource(w http.ResponseWrit
 		return
 	}
 
+	request.UserId = userId
 	resource, err := handler.k8sCommonService.UpdateResource(r.Context(), &request)
 	if err != nil {
 		handler.logger.Errorw("error in updating resource", "err", err)
@@ -951,17 +963,22 @@ func (handler *K8sApplicationRestHandlerImpl) GetResourceList(w http.ResponseWri
 }
 
 func (handler *K8sApplicationRestHandlerImpl) ApplyResources(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
 	decoder := json.NewDecoder(r.Body)
 	var request util3.ApplyResourcesRequest
 	token := r.Header.Get("token")
-	err := decoder.Decode(&request)
+	err = decoder.Decode(&request)
 	if err != nil {
 		handler.logger.Errorw("error in decoding request body", "err", err)
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
 		return
 	}
 
-	response, err := handler.k8sApplicationService.ApplyResources(r.Context(), token, &request, handler.verifyRbacForCluster)
+	response, err := handler.k8sApplicationService.ApplyResources(r.Context(), token, &request, userId, handler.verifyRbacForCluster)
 	if err != nil {
 		handler.logger.Errorw("error in applying resource", "err", err)
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
@@ -25,12 +25,15 @@ type K8sApplicationRouter interface {
 	InitK8sApplicationRouter(helmRouter *mux.Router)
 }
 type K8sApplicationRouterImpl struct {
-	k8sApplicationRestHandler K8sApplicationRestHandler
+	k8sApplicationRestHandler   K8sApplicationRestHandler
+	k8sResourceAuditRestHandler K8sResourceAuditRestHandler
 }
 
-func NewK8sApplicationRouterImpl(k8sApplicationRestHandler K8sApplicationRestHandler) *K8sApplicationRouterImpl {
+func NewK8sApplicationRouterImpl(k8sApplicationRestHandler K8sApplicationRestHandler,
+	k8sResourceAuditRestHandler K8sResourceAuditRestHandler) *K8sApplicationRouterImpl {
 	return &K8sApplicationRouterImpl{
-		k8sApplicationRestHandler: k8sApplicationRestHandler,
+		k8sApplicationRestHandler:   k8sApplicationRestHandler,
+		k8sResourceAuditRestHandler: k8sResourceAuditRestHandler,
 	}
 }
 
@@ -55,6 +58,12 @@ func (impl *K8sApplicationRouterImpl) InitK8sApplicationRouter(k8sAppRouter *mux
 	k8sAppRouter.Path("/resource/delete").
 		HandlerFunc(impl.k8sApplicationRestHandler.DeleteResource).Methods("POST")
 
+	k8sAppRouter.Path("/resource/audit").
+		HandlerFunc(impl.k8sResourceAuditRestHandler.GetResourceChangeAudits).Methods("GET")
+
+	k8sAppRouter.Path("/resource/audit/{id}").
+		HandlerFunc(impl.k8sResourceAuditRestHandler.GetResourceChangeAuditById).Methods("GET")
+
 	k8sAppRouter.Path("/events").
 		HandlerFunc(impl.k8sApplicationRestHandler.ListEvents).Methods("POST")
 
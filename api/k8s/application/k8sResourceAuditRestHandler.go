@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package application
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
+	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
+	"go.uber.org/zap"
+)
+
+type K8sResourceAuditRestHandler interface {
+	GetResourceChangeAudits(w http.ResponseWriter, r *http.Request)
+	GetResourceChangeAuditById(w http.ResponseWriter, r *http.Request)
+}
+
+type K8sResourceAuditRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	k8sResourceHistoryService kubernetesResourceAuditLogs.K8sResourceHistoryService
+	enforcer                  casbin.Enforcer
+	userService               user.UserService
+}
+
+func NewK8sResourceAuditRestHandlerImpl(logger *zap.SugaredLogger,
+	k8sResourceHistoryService kubernetesResourceAuditLogs.K8sResourceHistoryService,
+	enforcer casbin.Enforcer, userService user.UserService) *K8sResourceAuditRestHandlerImpl {
+	return &K8sResourceAuditRestHandlerImpl{
+		logger:                    logger,
+		k8sResourceHistoryService: k8sResourceHistoryService,
+		enforcer:                  enforcer,
+		userService:               userService,
+	}
+}
+
+// GetResourceChangeAudits lists the changes done on the kubernetes resources, filtered by resource and user. Super admins
+// can list the changes of all the users and others only their own
+func (handler *K8sResourceAuditRestHandlerImpl) GetResourceChangeAudits(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	filter, err := handler.getResourceChangeAuditFilter(w, r)
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if !handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*") {
+		filter.UserId = userId
+	}
+	res, err := handler.k8sResourceHistoryService.GetResourceChangeAudits(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetResourceChangeAudits", "err", err, "filter", filter)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+// GetResourceChangeAuditById returns the change along with the manifests before and after the change
+func (handler *K8sResourceAuditRestHandlerImpl) GetResourceChangeAuditById(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	audit, err := handler.k8sResourceHistoryService.GetResourceChangeAuditById(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetResourceChangeAuditById", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	if audit.UserId != userId && !handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*") {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, audit, http.StatusOK)
+}
+
+// getResourceChangeAuditFilter parses the filter from the query params, the bad request response is written on error
+func (handler *K8sResourceAuditRestHandlerImpl) getResourceChangeAuditFilter(w http.ResponseWriter, r *http.Request) (*bean.ResourceChangeAuditFilter, error) {
+	queryParams := r.URL.Query()
+	filter := &bean.ResourceChangeAuditFilter{
+		Group:     queryParams.Get("group"),
+		Kind:      queryParams.Get("kind"),
+		Namespace: queryParams.Get("namespace"),
+		Name:      queryParams.Get("name"),
+		Action:    queryParams.Get("action"),
+	}
+	userId, err := common.ExtractIntQueryParam(w, r, "userId", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserId = int32(userId)
+	filter.ClusterId, err = common.ExtractIntQueryParam(w, r, "clusterId", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.Offset, err = common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.Size, err = common.ExtractIntQueryParam(w, r, "size", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.From, err = common.ExtractTimeQueryParam(r, "from")
+	if err == nil {
+		filter.To, err = common.ExtractTimeQueryParam(r, "to")
+	}
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, err
+	}
+	return filter, nil
+}
@@ -324,6 +324,7 @@ func (handler *K8sCapacityRestHandlerImpl) UpdateNodeManifest(w http.ResponseWri
 		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
 		return
 	}
+	manifestUpdateReq.UserId = userId
 	updatedManifest, err := handler.k8sCapacityService.UpdateNodeManifest(r.Context(), &manifestUpdateReq)
 	if err != nil {
 		handler.logger.Errorw("error in updating node manifest", "err", err, "updateRequest", manifestUpdateReq)
@@ -359,6 +360,7 @@ func (handler *K8sCapacityRestHandlerImpl) DeleteNode(w http.ResponseWriter, r *
 		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
 		return
 	}
+	nodeDelReq.UserId = userId
 	updatedManifest, err := handler.k8sCapacityService.DeleteNode(r.Context(), &nodeDelReq)
 	if err != nil {
 		errCode := http.StatusInternalServerError
@@ -403,6 +405,7 @@ func (handler *K8sCapacityRestHandlerImpl) CordonOrUnCordonNode(w http.ResponseW
 		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
 		return
 	}
+	nodeCordonReq.UserId = userId
 	resp, err := handler.k8sCapacityService.CordonOrUnCordonNode(r.Context(), &nodeCordonReq)
 	if err != nil {
 		handler.logger.Errorw("error in cordon/unCordon node", "err", err, "req", nodeCordonReq)
@@ -447,6 +450,7 @@ func (handler *K8sCapacityRestHandlerImpl) DrainNode(w http.ResponseWriter, r *h
 		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
 		return
 	}
+	nodeDrainReq.UserId = userId
 	resp, err := handler.k8sCapacityService.DrainNode(r.Context(), &nodeDrainReq)
 	if err != nil {
 		handler.logger.Errorw("error in draining node", "err", err, "req", nodeDrainReq)
@@ -482,6 +486,7 @@ func (handler *K8sCapacityRestHandlerImpl) EditNodeTaints(w http.ResponseWriter,
 		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
 		return
 	}
+	nodeTaintReq.UserId = userId
 	resp, err := handler.k8sCapacityService.EditNodeTaints(r.Context(), &nodeTaintReq)
 	if err != nil {
 		handler.logger.Errorw("error in editing node taints", "err", err, "req", nodeTaintReq)
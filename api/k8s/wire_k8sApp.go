@@ -26,6 +26,8 @@ import (
 	capacity2 "github.com/devtron-labs/devtron/pkg/k8s/capacity"
 	"github.com/devtron-labs/devtron/pkg/k8s/informer"
 	"github.com/devtron-labs/devtron/pkg/terminal"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording"
+	recordingRepository "github.com/devtron-labs/devtron/pkg/terminal/recording/repository"
 	"github.com/google/wire"
 )
 
@@ -38,12 +40,19 @@ var K8sApplicationWireSet = wire.NewSet(
 	wire.Bind(new(application.K8sApplicationRouter), new(*application.K8sApplicationRouterImpl)),
 	application.NewK8sApplicationRestHandlerImpl,
 	wire.Bind(new(application.K8sApplicationRestHandler), new(*application.K8sApplicationRestHandlerImpl)),
+	application.NewK8sResourceAuditRestHandlerImpl,
+	wire.Bind(new(application.K8sResourceAuditRestHandler), new(*application.K8sResourceAuditRestHandlerImpl)),
 	clusterRepository.NewEphemeralContainersRepositoryImpl,
 	wire.Bind(new(clusterRepository.EphemeralContainersRepository), new(*clusterRepository.EphemeralContainersRepositoryImpl)),
 	cluster.NewEphemeralContainerServiceImpl,
 	wire.Bind(new(cluster.EphemeralContainerService), new(*cluster.EphemeralContainerServiceImpl)),
 	terminal.NewTerminalSessionHandlerImpl,
 	wire.Bind(new(terminal.TerminalSessionHandler), new(*terminal.TerminalSessionHandlerImpl)),
+	terminal.GetTerminalSessionConfig,
+	recording.NewTerminalSessionRecordingServiceImpl,
+	wire.Bind(new(recording.TerminalSessionRecordingService), new(*recording.TerminalSessionRecordingServiceImpl)),
+	recordingRepository.NewTerminalSessionRecordingRepositoryImpl,
+	wire.Bind(new(recordingRepository.TerminalSessionRecordingRepository), new(*recordingRepository.TerminalSessionRecordingRepositoryImpl)),
 	capacity.NewK8sCapacityRouterImpl,
 	wire.Bind(new(capacity.K8sCapacityRouter), new(*capacity.K8sCapacityRouterImpl)),
 	capacity.NewK8sCapacityRestHandlerImpl,
@@ -60,6 +60,7 @@ type BulkUpdateRestHandler interface {
 
 type BulkEditRestHandler interface {
 	BulkEditV1Beta1RestHandler
+	BulkEditJobRestHandler
 	// BulkEditV1Beta2RestHandlerEnt interface that defines the methods for bulk edit v1beta2.
 	// v1beta2 is an Ent only version, so it does not have a separate interface.
 	BulkEditV1Beta2RestHandlerEnt
@@ -71,6 +72,13 @@ type BulkEditV1Beta1RestHandler interface {
 	BulkEdit(w http.ResponseWriter, r *http.Request)
 }
 
+type BulkEditJobRestHandler interface {
+	CreateBulkEditJob(w http.ResponseWriter, r *http.Request)
+	GetBulkEditJobs(w http.ResponseWriter, r *http.Request)
+	GetBulkEditJob(w http.ResponseWriter, r *http.Request)
+	CancelBulkEditJob(w http.ResponseWriter, r *http.Request)
+}
+
 type BulkUpdateRestHandlerImpl struct {
 	pipelineBuilder         pipeline.PipelineBuilder
 	ciPipelineRepository    pipelineConfig.CiPipelineRepository
@@ -92,6 +100,7 @@ type BulkUpdateRestHandlerImpl struct {
 	cdHandler               pipeline.CdHandler
 	appCloneService         appClone.AppCloneService
 	materialRepository      repository.MaterialRepository
+	bulkEditJobService      service.BulkEditJobService
 }
 
 func NewBulkUpdateRestHandlerImpl(pipelineBuilder pipeline.PipelineBuilder, logger *zap.SugaredLogger,
@@ -110,6 +119,7 @@ func NewBulkUpdateRestHandlerImpl(pipelineBuilder pipeline.PipelineBuilder, logg
 	appCloneService appClone.AppCloneService,
 	appWorkflowService appWorkflow.AppWorkflowService,
 	materialRepository repository.MaterialRepository,
+	bulkEditJobService service.BulkEditJobService,
 ) *BulkUpdateRestHandlerImpl {
 	return &BulkUpdateRestHandlerImpl{
 		pipelineBuilder:         pipelineBuilder,
@@ -132,6 +142,7 @@ func NewBulkUpdateRestHandlerImpl(pipelineBuilder pipeline.PipelineBuilder, logg
 		appCloneService:         appCloneService,
 		appWorkflowService:      appWorkflowService,
 		materialRepository:      materialRepository,
+		bulkEditJobService:      bulkEditJobService,
 	}
 }
 
@@ -226,6 +237,27 @@ func (handler BulkUpdateRestHandlerImpl) CheckAuthForBulkUpdate(AppId int, EnvId
 
 }
 
+// checkAuthForImpactedObjectsUpdate checks that the user can update all the apps and environments impacted by a bulk edit
+func (handler BulkUpdateRestHandlerImpl) checkAuthForImpactedObjectsUpdate(impactedObjects *bean.ImpactedObjectsResponse, token string) bool {
+	rbacObjects := handler.enforcerUtil.GetRbacObjectsForAllApps(helper.CustomApp)
+	for _, deploymentTemplateImpactedApp := range impactedObjects.DeploymentTemplate {
+		if ok := handler.CheckAuthForBulkUpdate(deploymentTemplateImpactedApp.AppId, deploymentTemplateImpactedApp.EnvId, deploymentTemplateImpactedApp.AppName, rbacObjects, token); !ok {
+			return false
+		}
+	}
+	for _, impactedConfigMap := range impactedObjects.ConfigMap {
+		if ok := handler.CheckAuthForBulkUpdate(impactedConfigMap.AppId, impactedConfigMap.EnvId, impactedConfigMap.AppName, rbacObjects, token); !ok {
+			return false
+		}
+	}
+	for _, impactedSecret := range impactedObjects.Secret {
+		if ok := handler.CheckAuthForBulkUpdate(impactedSecret.AppId, impactedSecret.EnvId, impactedSecret.AppName, rbacObjects, token); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (handler BulkUpdateRestHandlerImpl) BulkEdit(w http.ResponseWriter, r *http.Request) {
 	userId, err := handler.userAuthService.GetLoggedInUser(r)
 	if userId == 0 || err != nil {
@@ -251,27 +283,9 @@ func (handler BulkUpdateRestHandlerImpl) BulkEdit(w http.ResponseWriter, r *http
 		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 		return
 	}
-	rbacObjects := handler.enforcerUtil.GetRbacObjectsForAllApps(helper.CustomApp)
-	for _, deploymentTemplateImpactedApp := range impactedObjects.DeploymentTemplate {
-		ok := handler.CheckAuthForBulkUpdate(deploymentTemplateImpactedApp.AppId, deploymentTemplateImpactedApp.EnvId, deploymentTemplateImpactedApp.AppName, rbacObjects, token)
-		if !ok {
-			common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
-			return
-		}
-	}
-	for _, impactedConfigMap := range impactedObjects.ConfigMap {
-		ok := handler.CheckAuthForBulkUpdate(impactedConfigMap.AppId, impactedConfigMap.EnvId, impactedConfigMap.AppName, rbacObjects, token)
-		if !ok {
-			common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
-			return
-		}
-	}
-	for _, impactedSecret := range impactedObjects.Secret {
-		ok := handler.CheckAuthForBulkUpdate(impactedSecret.AppId, impactedSecret.EnvId, impactedSecret.AppName, rbacObjects, token)
-		if !ok {
-			common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
-			return
-		}
+	if ok := handler.checkAuthForImpactedObjectsUpdate(impactedObjects, token); !ok {
+		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
+		return
 	}
 	isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionCreate, "*")
 	userMetadata := util2.GetUserMetadata(r.Context(), userId, isSuperAdmin)
@@ -279,6 +293,128 @@ func (handler BulkUpdateRestHandlerImpl) BulkEdit(w http.ResponseWriter, r *http
 	common.WriteJsonResp(w, nil, response, http.StatusOK)
 }
 
+func (handler BulkUpdateRestHandlerImpl) CreateBulkEditJob(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	decoder := json.NewDecoder(r.Body)
+	var request bean.BulkEditJobRequest
+	err = decoder.Decode(&request)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation err, CreateBulkEditJob", "err", err, "request", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	impactedObjects, err := handler.bulkUpdateService.DryRunBulkEdit(request.Script.Spec)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if ok := handler.checkAuthForImpactedObjectsUpdate(impactedObjects, token); !ok {
+		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionCreate, "*")
+	userMetadata := util2.GetUserMetadata(r.Context(), userId, isSuperAdmin)
+	job, err := handler.bulkEditJobService.CreateJob(&request, userMetadata)
+	if err != nil {
+		handler.logger.Errorw("service err, CreateBulkEditJob", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, job, http.StatusOK)
+}
+
+func (handler BulkUpdateRestHandlerImpl) GetBulkEditJobs(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	token := r.Header.Get("token")
+	// super admins can see the jobs of all the users
+	createdBy := userId
+	if isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); isSuperAdmin {
+		createdBy = 0
+	}
+	jobs, err := handler.bulkEditJobService.GetJobs(createdBy)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, jobs, http.StatusOK)
+}
+
+func (handler BulkUpdateRestHandlerImpl) GetBulkEditJob(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	jobId, err := strconv.Atoi(mux.Vars(r)["jobId"])
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid jobId", http.StatusBadRequest)
+		return
+	}
+	job, err := handler.bulkEditJobService.GetJob(jobId, true)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.checkAuthForBulkEditJob(job, userId, token, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	common.WriteJsonResp(w, nil, job, http.StatusOK)
+}
+
+func (handler BulkUpdateRestHandlerImpl) CancelBulkEditJob(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	jobId, err := strconv.Atoi(mux.Vars(r)["jobId"])
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid jobId", http.StatusBadRequest)
+		return
+	}
+	job, err := handler.bulkEditJobService.GetJob(jobId, false)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := handler.checkAuthForBulkEditJob(job, userId, token, casbin.ActionUpdate); !ok {
+		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	err = handler.bulkEditJobService.CancelJob(jobId, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, CancelBulkEditJob", "err", err, "jobId", jobId)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
+
+// checkAuthForBulkEditJob allows the creator of the job and super admins
+func (handler BulkUpdateRestHandlerImpl) checkAuthForBulkEditJob(job *bean.BulkEditJob, userId int32, token string, action string) bool {
+	if job.CreatedBy == userId {
+		return true
+	}
+	return handler.enforcer.Enforce(token, casbin.ResourceGlobal, action, "*")
+}
+
 func (handler BulkUpdateRestHandlerImpl) BulkHibernate(w http.ResponseWriter, r *http.Request) {
 	request, err := handler.decodeAndValidateBulkRequest(w, r)
 	if err != nil {
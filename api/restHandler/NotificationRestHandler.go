@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/devtron-labs/devtron/api/restHandler/common"
+	client "github.com/devtron-labs/devtron/client/events"
 	"github.com/devtron-labs/devtron/internal/sql/repository"
 	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
 	"github.com/devtron-labs/devtron/pkg/auth/user"
@@ -44,10 +45,12 @@ import (
 )
 
 const (
-	SLACK_CONFIG_DELETE_SUCCESS_RESP   = "Slack config deleted successfully."
-	WEBHOOK_CONFIG_DELETE_SUCCESS_RESP = "Webhook config deleted successfully."
-	SES_CONFIG_DELETE_SUCCESS_RESP     = "SES config deleted successfully."
-	SMTP_CONFIG_DELETE_SUCCESS_RESP    = "SMTP config deleted successfully."
+	SLACK_CONFIG_DELETE_SUCCESS_RESP       = "Slack config deleted successfully."
+	WEBHOOK_CONFIG_DELETE_SUCCESS_RESP     = "Webhook config deleted successfully."
+	SES_CONFIG_DELETE_SUCCESS_RESP         = "SES config deleted successfully."
+	SMTP_CONFIG_DELETE_SUCCESS_RESP        = "SMTP config deleted successfully."
+	TEAMS_CONFIG_DELETE_SUCCESS_RESP       = "Teams config deleted successfully."
+	GOOGLE_CHAT_CONFIG_DELETE_SUCCESS_RESP = "Google Chat config deleted successfully."
 )
 
 type NotificationRestHandler interface {
@@ -58,6 +61,8 @@ type NotificationRestHandler interface {
 	FindSlackConfig(w http.ResponseWriter, r *http.Request)
 	FindSMTPConfig(w http.ResponseWriter, r *http.Request)
 	FindWebhookConfig(w http.ResponseWriter, r *http.Request)
+	FindTeamsConfig(w http.ResponseWriter, r *http.Request)
+	FindGoogleChatConfig(w http.ResponseWriter, r *http.Request)
 	GetWebhookVariables(w http.ResponseWriter, r *http.Request)
 	FindAllNotificationConfig(w http.ResponseWriter, r *http.Request)
 	GetAllNotificationSettings(w http.ResponseWriter, r *http.Request)
@@ -67,6 +72,9 @@ type NotificationRestHandler interface {
 	RecipientListingSuggestion(w http.ResponseWriter, r *http.Request)
 	FindAllNotificationConfigAutocomplete(w http.ResponseWriter, r *http.Request)
 	GetOptionsForNotificationSettings(w http.ResponseWriter, r *http.Request)
+
+	FindNotificationDeliveries(w http.ResponseWriter, r *http.Request)
+	ReplayNotificationDelivery(w http.ResponseWriter, r *http.Request)
 }
 type NotificationRestHandlerImpl struct {
 	dockerRegistryConfig pipeline.DockerRegistryConfig
@@ -79,11 +87,14 @@ type NotificationRestHandlerImpl struct {
 	webhookService       notifier.WebhookNotificationService
 	sesService           notifier.SESNotificationService
 	smtpService          notifier.SMTPNotificationService
+	teamsService         notifier.TeamsNotificationService
+	googleChatService    notifier.GoogleChatNotificationService
 	enforcer             casbin.Enforcer
 	environmentService   environment.EnvironmentService
 	pipelineBuilder      pipeline.PipelineBuilder
 	enforcerUtil         rbac.EnforcerUtil
 	teamReadService      read.TeamReadService
+	deliveryService      client.NotificationDeliveryService
 }
 
 type ChannelDto struct {
@@ -97,7 +108,9 @@ func NewNotificationRestHandlerImpl(dockerRegistryConfig pipeline.DockerRegistry
 	slackService notifier.SlackNotificationService, webhookService notifier.WebhookNotificationService, sesService notifier.SESNotificationService, smtpService notifier.SMTPNotificationService,
 	enforcer casbin.Enforcer, environmentService environment.EnvironmentService, pipelineBuilder pipeline.PipelineBuilder,
 	enforcerUtil rbac.EnforcerUtil,
-	teamReadService read.TeamReadService) *NotificationRestHandlerImpl {
+	teamReadService read.TeamReadService, teamsService notifier.TeamsNotificationService,
+	googleChatService notifier.GoogleChatNotificationService,
+	deliveryService client.NotificationDeliveryService) *NotificationRestHandlerImpl {
 	return &NotificationRestHandlerImpl{
 		dockerRegistryConfig: dockerRegistryConfig,
 		logger:               logger,
@@ -109,11 +122,14 @@ func NewNotificationRestHandlerImpl(dockerRegistryConfig pipeline.DockerRegistry
 		webhookService:       webhookService,
 		sesService:           sesService,
 		smtpService:          smtpService,
+		teamsService:         teamsService,
+		googleChatService:    googleChatService,
 		enforcer:             enforcer,
 		environmentService:   environmentService,
 		pipelineBuilder:      pipelineBuilder,
 		enforcerUtil:         enforcerUtil,
 		teamReadService:      teamReadService,
+		deliveryService:      deliveryService,
 	}
 }
 
@@ -137,6 +153,13 @@ func (impl NotificationRestHandlerImpl) SaveNotificationSettingsV2(w http.Respon
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
 		return
 	}
+	for _, request := range notificationSetting.NotificationConfigRequest {
+		if err = request.ValidateDeliveryConfig(); err != nil {
+			impl.logger.Errorw("validation err, SaveNotificationSettings", "err", err, "payload", notificationSetting)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
 
 	//RBAC
 	token := r.Header.Get("token")
@@ -176,6 +199,13 @@ func (impl NotificationRestHandlerImpl) UpdateNotificationSettings(w http.Respon
 		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
 		return
 	}
+	for _, request := range notificationSetting.NotificationConfigRequest {
+		if err = request.ValidateDeliveryConfig(); err != nil {
+			impl.logger.Errorw("validation err, UpdateNotificationSettings", "err", err, "payload", notificationSetting)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+	}
 
 	//RBAC
 	token := r.Header.Get("token")
@@ -416,14 +446,78 @@ func (impl NotificationRestHandlerImpl) SaveNotificationChannelConfig(w http.Res
 		}
 		w.Header().Set("Content-Type", "application/json")
 		common.WriteJsonResp(w, nil, res, http.StatusOK)
+	} else if util.Teams == channelReq.Channel {
+		var teamsReq *beans.TeamsChannelConfig
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&teamsReq)
+		if err != nil {
+			impl.logger.Errorw("request err, SaveNotificationChannelConfig", "err", err, "teamsReq", teamsReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(teamsReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, SaveNotificationChannelConfig", "err", err, "teamsReq", teamsReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		res, cErr := impl.teamsService.SaveOrEditNotificationConfig(teamsReq.TeamsConfigDtos, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, SaveNotificationChannelConfig", "err", cErr, "teamsReq", teamsReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		common.WriteJsonResp(w, nil, res, http.StatusOK)
+	} else if util.GoogleChat == channelReq.Channel {
+		var googleChatReq *beans.GoogleChatChannelConfig
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&googleChatReq)
+		if err != nil {
+			impl.logger.Errorw("request err, SaveNotificationChannelConfig", "err", err, "googleChatReq", googleChatReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(googleChatReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, SaveNotificationChannelConfig", "err", err, "googleChatReq", googleChatReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		res, cErr := impl.googleChatService.SaveOrEditNotificationConfig(googleChatReq.GoogleChatConfigDtos, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, SaveNotificationChannelConfig", "err", cErr, "googleChatReq", googleChatReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		common.WriteJsonResp(w, nil, res, http.StatusOK)
 	}
 }
 
 type ChannelResponseDTO struct {
-	SlackConfigs   []*beans.SlackConfigDto   `json:"slackConfigs"`
-	WebhookConfigs []*beans.WebhookConfigDto `json:"webhookConfigs"`
-	SESConfigs     []*beans.SESConfigDto     `json:"sesConfigs"`
-	SMTPConfigs    []*beans.SMTPConfigDto    `json:"smtpConfigs"`
+	SlackConfigs      []*beans.SlackConfigDto      `json:"slackConfigs"`
+	WebhookConfigs    []*beans.WebhookConfigDto    `json:"webhookConfigs"`
+	SESConfigs        []*beans.SESConfigDto        `json:"sesConfigs"`
+	SMTPConfigs       []*beans.SMTPConfigDto       `json:"smtpConfigs"`
+	TeamsConfigs      []*beans.TeamsConfigDto      `json:"teamsConfigs"`
+	GoogleChatConfigs []*beans.GoogleChatConfigDto `json:"googleChatConfigs"`
 }
 
 func (impl NotificationRestHandlerImpl) FindAllNotificationConfig(w http.ResponseWriter, r *http.Request) {
@@ -510,6 +604,26 @@ func (impl NotificationRestHandlerImpl) FindAllNotificationConfig(w http.Respons
 	if pass {
 		channelsResponse.SMTPConfigs = smtpConfigs
 	}
+
+	teamsConfigs, err := impl.teamsService.FetchAllTeamsNotificationConfig()
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindAllNotificationConfig", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if pass {
+		channelsResponse.TeamsConfigs = teamsConfigs
+	}
+
+	googleChatConfigs, err := impl.googleChatService.FetchAllGoogleChatNotificationConfig()
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindAllNotificationConfig", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if pass {
+		channelsResponse.GoogleChatConfigs = googleChatConfigs
+	}
 	w.Header().Set("Content-Type", "application/json")
 	common.WriteJsonResp(w, fErr, channelsResponse, http.StatusOK)
 }
@@ -619,6 +733,60 @@ func (impl NotificationRestHandlerImpl) FindWebhookConfig(w http.ResponseWriter,
 	w.Header().Set("Content-Type", "application/json")
 	common.WriteJsonResp(w, fErr, webhookConfig, http.StatusOK)
 }
+func (impl NotificationRestHandlerImpl) FindTeamsConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionGet, "*"); !ok {
+		response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+		return
+	}
+
+	teamsConfig, fErr := impl.teamsService.FetchTeamsNotificationConfigById(id)
+	if fErr != nil && fErr != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindTeamsConfig, cannot find teams config", "err", fErr, "id", id)
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	common.WriteJsonResp(w, fErr, teamsConfig, http.StatusOK)
+}
+
+func (impl NotificationRestHandlerImpl) FindGoogleChatConfig(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionGet, "*"); !ok {
+		response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+		return
+	}
+
+	googleChatConfig, fErr := impl.googleChatService.FetchGoogleChatNotificationConfigById(id)
+	if fErr != nil && fErr != pg.ErrNoRows {
+		impl.logger.Errorw("service err, FindGoogleChatConfig, cannot find google chat config", "err", fErr, "id", id)
+		common.WriteJsonResp(w, fErr, nil, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	common.WriteJsonResp(w, fErr, googleChatConfig, http.StatusOK)
+}
+
 func (impl NotificationRestHandlerImpl) GetWebhookVariables(w http.ResponseWriter, r *http.Request) {
 	userId, err := impl.userAuthService.GetLoggedInUser(r)
 	if userId == 0 || err != nil {
@@ -735,6 +903,20 @@ func (impl NotificationRestHandlerImpl) FindAllNotificationConfigAutocomplete(w
 			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
 			return
 		}
+	} else if cType == string(util.Teams) {
+		channelsResponse, err = impl.teamsService.FetchAllTeamsNotificationConfigAutocomplete()
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("service err, FindAllNotificationConfigAutocomplete", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
+	} else if cType == string(util.GoogleChat) {
+		channelsResponse, err = impl.googleChatService.FetchAllGoogleChatNotificationConfigAutocomplete()
+		if err != nil && err != pg.ErrNoRows {
+			impl.logger.Errorw("service err, FindAllNotificationConfigAutocomplete", "err", err)
+			common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+			return
+		}
 	}
 	if channelsResponse == nil {
 		channelsResponse = make([]*beans.NotificationChannelAutoResponse, 0)
@@ -919,7 +1101,136 @@ func (impl NotificationRestHandlerImpl) DeleteNotificationChannelConfig(w http.R
 			return
 		}
 		common.WriteJsonResp(w, nil, SMTP_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
+	} else if util.Teams == channelReq.Channel {
+		var deleteReq *beans.TeamsConfigDto
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&deleteReq)
+		if err != nil {
+			impl.logger.Errorw("request err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(deleteReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		cErr := impl.teamsService.DeleteNotificationConfig(deleteReq, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, DeleteNotificationChannelConfig", "err", cErr, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		common.WriteJsonResp(w, nil, TEAMS_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
+	} else if util.GoogleChat == channelReq.Channel {
+		var deleteReq *beans.GoogleChatConfigDto
+		err = json.NewDecoder(ioutil.NopCloser(bytes.NewBuffer(data))).Decode(&deleteReq)
+		if err != nil {
+			impl.logger.Errorw("request err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		err = impl.validator.Struct(deleteReq)
+		if err != nil {
+			impl.logger.Errorw("validation err, DeleteNotificationChannelConfig", "err", err, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+			return
+		}
+
+		// RBAC enforcer applying
+		token := r.Header.Get("token")
+		if ok := impl.enforcer.Enforce(token, casbin.ResourceNotification, casbin.ActionCreate, "*"); !ok {
+			response.WriteResponse(http.StatusForbidden, "FORBIDDEN", w, errors.New("unauthorized"))
+			return
+		}
+		//RBAC enforcer Ends
+
+		cErr := impl.googleChatService.DeleteNotificationConfig(deleteReq, userId)
+		if cErr != nil {
+			impl.logger.Errorw("service err, DeleteNotificationChannelConfig", "err", cErr, "deleteReq", deleteReq)
+			common.WriteJsonResp(w, cErr, nil, http.StatusInternalServerError)
+			return
+		}
+		common.WriteJsonResp(w, nil, GOOGLE_CHAT_CONFIG_DELETE_SUCCESS_RESP, http.StatusOK)
 	} else {
 		common.WriteJsonResp(w, fmt.Errorf(" The channel you requested is not supported"), nil, http.StatusBadRequest)
 	}
 }
+
+func (impl NotificationRestHandlerImpl) FindNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	queryParams := r.URL.Query()
+	status := repository.NotificationDeliveryStatus(queryParams.Get("status"))
+	if err := client.ValidateDeliveryStatus(status); err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	offset, size := 0, 20
+	if offsetParam := queryParams.Get("offset"); len(offsetParam) > 0 {
+		if offset, err = strconv.Atoi(offsetParam); err != nil || offset < 0 {
+			common.WriteJsonResp(w, fmt.Errorf("invalid offset %q", offsetParam), nil, http.StatusBadRequest)
+			return
+		}
+	}
+	if sizeParam := queryParams.Get("size"); len(sizeParam) > 0 {
+		if size, err = strconv.Atoi(sizeParam); err != nil || size <= 0 {
+			common.WriteJsonResp(w, fmt.Errorf("invalid size %q", sizeParam), nil, http.StatusBadRequest)
+			return
+		}
+	}
+
+	token := r.Header.Get("token")
+	if isSuperAdmin := impl.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !isSuperAdmin {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	deliveries, err := impl.deliveryService.FindDeliveries(status, offset, size)
+	if err != nil {
+		impl.logger.Errorw("service err, FindNotificationDeliveries", "status", status, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, deliveries, http.StatusOK)
+}
+
+func (impl NotificationRestHandlerImpl) ReplayNotificationDelivery(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		// Error already written by ExtractIntPathParamWithContext
+		return
+	}
+
+	token := r.Header.Get("token")
+	if isSuperAdmin := impl.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !isSuperAdmin {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	impl.logger.Infow("request payload, ReplayNotificationDelivery", "id", id, "userId", userId)
+	delivery, err := impl.deliveryService.ReplayDelivery(id, userId)
+	if err != nil {
+		impl.logger.Errorw("service err, ReplayNotificationDelivery", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, delivery, http.StatusOK)
+}
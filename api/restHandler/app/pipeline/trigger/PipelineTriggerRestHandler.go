@@ -19,6 +19,7 @@ package trigger
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	util2 "github.com/devtron-labs/devtron/internal/util"
 	bean5 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
@@ -26,6 +27,8 @@ import (
 	bean2 "github.com/devtron-labs/devtron/pkg/deployment/deployedApp/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
 	bean3 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	scheduledDeploymentBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/bean"
+	scheduledDeploymentService "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/service"
 	"github.com/devtron-labs/devtron/pkg/eventProcessor/out"
 	bean4 "github.com/devtron-labs/devtron/pkg/eventProcessor/out/bean"
 
@@ -52,6 +55,10 @@ type PipelineTriggerRestHandler interface {
 	StartStopDeploymentGroup(w http.ResponseWriter, r *http.Request)
 	GetAllLatestDeploymentConfiguration(w http.ResponseWriter, r *http.Request)
 	RotatePods(w http.ResponseWriter, r *http.Request)
+	ScheduleDeployment(w http.ResponseWriter, r *http.Request)
+	GetScheduledDeployments(w http.ResponseWriter, r *http.Request)
+	GetScheduledDeployment(w http.ResponseWriter, r *http.Request)
+	CancelScheduledDeployment(w http.ResponseWriter, r *http.Request)
 }
 
 type PipelineTriggerRestHandlerImpl struct {
@@ -67,6 +74,7 @@ type PipelineTriggerRestHandlerImpl struct {
 	deployedAppService          deployedApp.DeployedAppService
 	cdHandlerService            devtronApps.HandlerService
 	workflowEventPublishService out.WorkflowEventPublishService
+	scheduledDeploymentService  scheduledDeploymentService.ScheduledDeploymentService
 }
 
 func NewPipelineRestHandler(appService app.AppService, userAuthService user.UserService, validator *validator.Validate,
@@ -75,7 +83,8 @@ func NewPipelineRestHandler(appService app.AppService, userAuthService user.User
 	deploymentConfigService pipeline.PipelineDeploymentConfigService,
 	deployedAppService deployedApp.DeployedAppService,
 	cdHandlerService devtronApps.HandlerService,
-	workflowEventPublishService out.WorkflowEventPublishService) *PipelineTriggerRestHandlerImpl {
+	workflowEventPublishService out.WorkflowEventPublishService,
+	scheduledDeploymentService scheduledDeploymentService.ScheduledDeploymentService) *PipelineTriggerRestHandlerImpl {
 	pipelineHandler := &PipelineTriggerRestHandlerImpl{
 		appService:                  appService,
 		userAuthService:             userAuthService,
@@ -89,6 +98,7 @@ func NewPipelineRestHandler(appService app.AppService, userAuthService user.User
 		deployedAppService:          deployedAppService,
 		cdHandlerService:            cdHandlerService,
 		workflowEventPublishService: workflowEventPublishService,
+		scheduledDeploymentService:  scheduledDeploymentService,
 	}
 	return pipelineHandler
 }
@@ -465,3 +475,137 @@ func (handler PipelineTriggerRestHandlerImpl) GetAllLatestDeploymentConfiguratio
 	// 5. Success response
 	common.WriteJsonResp(w, nil, allDeploymentconfig, http.StatusOK)
 }
+
+func (handler PipelineTriggerRestHandlerImpl) ScheduleDeployment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	var request scheduledDeploymentBean.ScheduledDeploymentRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		handler.logger.Errorw("request parsing error", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	err = handler.validator.Struct(request)
+	if err != nil {
+		handler.logger.Errorw("validation error", "err", err, "payload", request)
+		common.HandleValidationErrors(w, r, err)
+		return
+	}
+	token := r.Header.Get("token")
+	if rbacErr := handler.validateCdTriggerRBAC(token, request.AppId, request.PipelineId); rbacErr != nil {
+		common.WriteJsonResp(w, rbacErr, nil, rbacErr.(*util2.ApiError).HttpStatusCode)
+		return
+	}
+	resp, err := handler.scheduledDeploymentService.ScheduleDeployment(&request)
+	if err != nil {
+		handler.logger.Errorw("service err, ScheduleDeployment", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler PipelineTriggerRestHandlerImpl) GetScheduledDeployments(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := common.ExtractIntQueryParam(w, r, "appId", 0)
+	if err != nil {
+		return
+	}
+	pipelineId, err := common.ExtractIntQueryParam(w, r, "pipelineId", 0)
+	if err != nil {
+		return
+	}
+	if appId == 0 {
+		common.WriteJsonResp(w, util2.NewApiError(http.StatusBadRequest, "appId is required", "appId not provided"), nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(appId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, resourceName); !ok {
+		common.WriteForbiddenError(w, "view", "scheduled deployments")
+		return
+	}
+	filter := &scheduledDeploymentBean.ScheduledDeploymentFilter{
+		AppId:      appId,
+		PipelineId: pipelineId,
+	}
+	if statuses := r.URL.Query().Get("status"); len(statuses) > 0 {
+		for _, status := range strings.Split(statuses, ",") {
+			filter.Statuses = append(filter.Statuses, scheduledDeploymentBean.ScheduledDeploymentStatus(strings.TrimSpace(status)))
+		}
+	}
+	resp, err := handler.scheduledDeploymentService.GetScheduledDeployments(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetScheduledDeployments", "err", err, "appId", appId, "pipelineId", pipelineId)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler PipelineTriggerRestHandlerImpl) GetScheduledDeployment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	resp, err := handler.scheduledDeploymentService.GetScheduledDeployment(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetScheduledDeployment", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACNameByAppId(resp.AppId)
+	if ok := handler.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, resourceName); !ok {
+		common.WriteForbiddenError(w, "view", "scheduled deployment")
+		return
+	}
+	common.WriteJsonResp(w, nil, resp, http.StatusOK)
+}
+
+func (handler PipelineTriggerRestHandlerImpl) CancelScheduledDeployment(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	scheduledDeployment, err := handler.scheduledDeploymentService.GetScheduledDeployment(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetScheduledDeployment", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	// the user who scheduled the deployment can always cancel it, others need trigger access on the pipeline
+	if scheduledDeployment.ScheduledBy != userId {
+		token := r.Header.Get("token")
+		if rbacErr := handler.validateCdTriggerRBAC(token, scheduledDeployment.AppId, scheduledDeployment.PipelineId); rbacErr != nil {
+			common.WriteJsonResp(w, rbacErr, nil, rbacErr.(*util2.ApiError).HttpStatusCode)
+			return
+		}
+	}
+	err = handler.scheduledDeploymentService.CancelScheduledDeployment(id, userId)
+	if err != nil {
+		handler.logger.Errorw("service err, CancelScheduledDeployment", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, map[string]int{"id": id}, http.StatusOK)
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/devtron-labs/devtron/internal/util"
 	"github.com/gorilla/mux"
@@ -142,3 +143,16 @@ func ExtractBoolQueryParam(r *http.Request, paramName string) (bool, error) {
 
 	return boolValue, nil
 }
+
+// ExtractTimeQueryParam parses the RFC3339 time of the query param, returns nil if the param is not set
+func ExtractTimeQueryParam(r *http.Request, paramName string) (*time.Time, error) {
+	paramValue := r.URL.Query().Get(paramName)
+	if len(paramValue) == 0 {
+		return nil, nil
+	}
+	parsedTime, err := time.Parse(time.RFC3339, paramValue)
+	if err != nil {
+		return nil, err
+	}
+	return &parsedTime, nil
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/devtron-labs/devtron/api/restHandler/common"
 	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
@@ -34,6 +35,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/variables/utils"
 	"github.com/devtron-labs/devtron/util"
 	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 	"gopkg.in/go-playground/validator.v9"
 )
@@ -42,6 +44,11 @@ type ScopedVariableRestHandler interface {
 	CreateVariables(w http.ResponseWriter, r *http.Request)
 	GetScopedVariables(w http.ResponseWriter, r *http.Request)
 	GetJsonForVariables(w http.ResponseWriter, r *http.Request)
+	GetResolvedValuesDiff(w http.ResponseWriter, r *http.Request)
+	GetVariableVersions(w http.ResponseWriter, r *http.Request)
+	GetVariableVersion(w http.ResponseWriter, r *http.Request)
+	GetVariableVersionDiff(w http.ResponseWriter, r *http.Request)
+	RollbackVariableVersion(w http.ResponseWriter, r *http.Request)
 }
 
 type ScopedVariableRestHandlerImpl struct {
@@ -206,3 +213,170 @@ func (handler *ScopedVariableRestHandlerImpl) GetJsonForVariables(w http.Respons
 	}
 	common.WriteJsonResp(w, nil, jsonResponse, http.StatusOK)
 }
+
+func (handler *ScopedVariableRestHandlerImpl) GetResolvedValuesDiff(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	appId, err := strconv.Atoi(r.URL.Query().Get("appId"))
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid appId", http.StatusBadRequest)
+		return
+	}
+	var envIds []int
+	if envIdsQueryParam := r.URL.Query().Get("envIds"); len(envIdsQueryParam) > 0 {
+		for _, envIdString := range strings.Split(envIdsQueryParam, ",") {
+			envId, err := strconv.Atoi(strings.TrimSpace(envIdString))
+			if err != nil {
+				common.WriteJsonResp(w, err, "invalid envIds", http.StatusBadRequest)
+				return
+			}
+			envIds = append(envIds, envId)
+		}
+	}
+	var varNames []string
+	if varNamesQueryParam := r.URL.Query().Get("varNames"); len(varNamesQueryParam) > 0 {
+		for _, varName := range strings.Split(varNamesQueryParam, ",") {
+			varNames = append(varNames, strings.TrimSpace(varName))
+		}
+	}
+
+	app, err := handler.pipelineBuilder.GetApp(appId)
+	if err != nil {
+		handler.logger.Errorw("service err, GetResolvedValuesDiff", "err", err, "appId", appId)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	resourceName := handler.enforcerUtil.GetAppRBACName(app.AppName)
+	if ok := handler.enforcerUtil.CheckAppRbacForAppOrJob(token, resourceName, casbin.ActionGet); !ok {
+		common.WriteJsonResp(w, fmt.Errorf("unauthorized user"), "Unauthorized User", http.StatusForbidden)
+		return
+	}
+	isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*")
+
+	variableDiffs, err := handler.scopedVariableService.GetResolvedValuesDiff(appId, envIds, varNames, isSuperAdmin)
+	if err != nil {
+		handler.logger.Errorw("service err, GetResolvedValuesDiff", "err", err, "appId", appId, "envIds", envIds)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, variableDiffs, http.StatusOK)
+}
+
+func (handler *ScopedVariableRestHandlerImpl) GetVariableVersions(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	token := r.Header.Get("token")
+	if isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !isSuperAdmin {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	offset, err := common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return
+	}
+	size, err := common.ExtractIntQueryParam(w, r, "size", 20)
+	if err != nil {
+		return
+	}
+	if offset < 0 || size <= 0 {
+		common.WriteJsonResp(w, errors.New("invalid offset or size"), nil, http.StatusBadRequest)
+		return
+	}
+	versions, err := handler.scopedVariableService.GetVariableVersions(offset, size)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, versions, http.StatusOK)
+}
+
+func (handler *ScopedVariableRestHandlerImpl) GetVariableVersion(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid version", http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !isSuperAdmin {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	payload, err := handler.scopedVariableService.GetPayloadForVersion(version)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	manifest := utils.PayloadToManifest(*payload)
+	common.WriteJsonResp(w, nil, JsonResponse{Manifest: &manifest}, http.StatusOK)
+}
+
+func (handler *ScopedVariableRestHandlerImpl) GetVariableVersionDiff(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	fromVersion, err := strconv.Atoi(r.URL.Query().Get("fromVersion"))
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid fromVersion", http.StatusBadRequest)
+		return
+	}
+	toVersion, err := strconv.Atoi(r.URL.Query().Get("toVersion"))
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid toVersion", http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*"); !isSuperAdmin {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	diff, err := handler.scopedVariableService.GetVersionDiff(fromVersion, toVersion)
+	if err != nil {
+		handler.logger.Errorw("service err, GetVariableVersionDiff", "err", err, "fromVersion", fromVersion, "toVersion", toVersion)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, diff, http.StatusOK)
+}
+
+func (handler *ScopedVariableRestHandlerImpl) RollbackVariableVersion(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userAuthService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	version, err := strconv.Atoi(mux.Vars(r)["version"])
+	if err != nil {
+		common.WriteJsonResp(w, err, "invalid version", http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("token")
+	if isSuperAdmin := handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionCreate, "*"); !isSuperAdmin {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return
+	}
+	handler.logger.Infow("request received for variables rollback", "version", version, "userId", userId)
+	err = handler.scopedVariableService.RollbackToVersion(version, userId)
+	if err != nil {
+		if errors.As(err, &models.ValidationError{}) {
+			common.WriteJsonResp(w, err, nil, http.StatusNotAcceptable)
+		} else {
+			common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		}
+		return
+	}
+	common.WriteJsonResp(w, nil, nil, http.StatusOK)
+}
@@ -70,6 +70,12 @@ func (impl NotificationRouterImpl) InitNotificationRegRouter(configRouter *mux.R
 	configRouter.Path("/channel/webhook/{id}").
 		HandlerFunc(impl.notificationRestHandler.FindWebhookConfig).
 		Methods("GET")
+	configRouter.Path("/channel/teams/{id}").
+		HandlerFunc(impl.notificationRestHandler.FindTeamsConfig).
+		Methods("GET")
+	configRouter.Path("/channel/googleChat/{id}").
+		HandlerFunc(impl.notificationRestHandler.FindGoogleChatConfig).
+		Methods("GET")
 	configRouter.Path("/variables").
 		HandlerFunc(impl.notificationRestHandler.GetWebhookVariables).
 		Methods("GET")
@@ -89,4 +95,11 @@ func (impl NotificationRouterImpl) InitNotificationRegRouter(configRouter *mux.R
 		HandlerFunc(impl.notificationRestHandler.GetOptionsForNotificationSettings).
 		Methods("POST")
 
+	configRouter.Path("/delivery").
+		HandlerFunc(impl.notificationRestHandler.FindNotificationDeliveries).
+		Methods("GET")
+	configRouter.Path("/delivery/{id}/replay").
+		HandlerFunc(impl.notificationRestHandler.ReplayNotificationDelivery).
+		Methods("POST")
+
 }
@@ -46,5 +46,20 @@ func (impl ScopedVariableRouterImpl) InitScopedVariableRouter(router *mux.Router
 	router.Path("/variables/detail").
 		HandlerFunc(impl.scopedVariableRestHandler.GetJsonForVariables).
 		Methods("GET")
+	router.Path("/variables/diff").
+		HandlerFunc(impl.scopedVariableRestHandler.GetResolvedValuesDiff).
+		Methods("GET")
+	router.Path("/variables/versions").
+		HandlerFunc(impl.scopedVariableRestHandler.GetVariableVersions).
+		Methods("GET")
+	router.Path("/variables/versions/diff").
+		HandlerFunc(impl.scopedVariableRestHandler.GetVariableVersionDiff).
+		Methods("GET")
+	router.Path("/variables/versions/{version}").
+		HandlerFunc(impl.scopedVariableRestHandler.GetVariableVersion).
+		Methods("GET")
+	router.Path("/variables/versions/{version}/rollback").
+		HandlerFunc(impl.scopedVariableRestHandler.RollbackVariableVersion).
+		Methods("POST")
 
 }
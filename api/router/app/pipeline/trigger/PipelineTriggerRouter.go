@@ -57,6 +57,10 @@ type PipelineTriggerRouterImpl struct {
 
 func (router PipelineTriggerRouterImpl) InitPipelineTriggerRouter(pipelineTriggerRouter *mux.Router) {
 	pipelineTriggerRouter.Path("/cd-pipeline/trigger").HandlerFunc(router.restHandler.OverrideConfig).Methods("POST")
+	pipelineTriggerRouter.Path("/cd-pipeline/trigger/schedule").HandlerFunc(router.restHandler.ScheduleDeployment).Methods("POST")
+	pipelineTriggerRouter.Path("/cd-pipeline/trigger/schedule").HandlerFunc(router.restHandler.GetScheduledDeployments).Methods("GET")
+	pipelineTriggerRouter.Path("/cd-pipeline/trigger/schedule/{id}").HandlerFunc(router.restHandler.GetScheduledDeployment).Methods("GET")
+	pipelineTriggerRouter.Path("/cd-pipeline/trigger/schedule/{id}/cancel").HandlerFunc(router.restHandler.CancelScheduledDeployment).Methods("POST")
 	pipelineTriggerRouter.Path("/update-release-status").HandlerFunc(router.restHandler.ReleaseStatusUpdate).Methods("POST")
 	pipelineTriggerRouter.Path("/rotate-pods").HandlerFunc(router.restHandler.RotatePods).Methods("POST")
 	pipelineTriggerRouter.Path("/stop-start-app").HandlerFunc(router.restHandler.StartStopApp).Methods("POST")
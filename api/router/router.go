@@ -28,6 +28,7 @@ import (
 	"github.com/devtron-labs/devtron/api/auth/authorisation/globalConfig"
 	"github.com/devtron-labs/devtron/api/auth/sso"
 	"github.com/devtron-labs/devtron/api/auth/user"
+	"github.com/devtron-labs/devtron/api/celExpression"
 	"github.com/devtron-labs/devtron/api/chartRepo"
 	"github.com/devtron-labs/devtron/api/cluster"
 	"github.com/devtron-labs/devtron/api/dashboardEvent"
@@ -49,10 +50,13 @@ import (
 	terminal2 "github.com/devtron-labs/devtron/api/terminal"
 	"github.com/devtron-labs/devtron/api/userResource"
 	webhookHelm "github.com/devtron-labs/devtron/api/webhook/helm"
+	"github.com/devtron-labs/devtron/api/workflowLogs"
 	"github.com/devtron-labs/devtron/client/cron"
 	"github.com/devtron-labs/devtron/client/dashboard"
 	"github.com/devtron-labs/devtron/client/proxy"
 	"github.com/devtron-labs/devtron/client/telemetry"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
 	"github.com/devtron-labs/devtron/pkg/terminal"
 	"github.com/devtron-labs/devtron/util"
 	"github.com/gorilla/mux"
@@ -119,6 +123,9 @@ type MuxRouter struct {
 	rbacRoleRouter                     user.RbacRoleRouter
 	scopedVariableRouter               ScopedVariableRouter
 	ciTriggerCron                      cron.CiTriggerCron
+	notificationDigestCron             cron.NotificationDigestCron
+	notificationDeliveryRetryCron      cron.NotificationDeliveryRetryCron
+	tektonWorkflowStatusCron           cron.TektonWorkflowStatusCron
 	deploymentConfigurationRouter      configDiff.DeploymentConfigurationRouter
 	infraConfigRouter                  infraConfig.InfraConfigRouter
 	argoApplicationRouter              argoApplication.ArgoApplicationRouter
@@ -128,6 +135,10 @@ type MuxRouter struct {
 	userResourceRouter                 userResource.Router
 	overviewRouter                     OverviewRouter
 	globalAuthorisationConfigRouter    globalConfig.AuthorisationConfigRouter
+	celExpressionRouter                celExpression.CelExpressionRouter
+	workflowLogsRouter                 workflowLogs.WorkflowLogsRouter
+	gitOpsPullRequestService           pullRequest.GitOpsPullRequestService
+	gitOpsDriftDetectionService        drift.GitOpsDriftDetectionService
 }
 
 func NewMuxRouter(logger *zap.SugaredLogger,
@@ -155,6 +166,9 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 	rbacRoleRouter user.RbacRoleRouter,
 	scopedVariableRouter ScopedVariableRouter,
 	ciTriggerCron cron.CiTriggerCron,
+	notificationDigestCron cron.NotificationDigestCron,
+	notificationDeliveryRetryCron cron.NotificationDeliveryRetryCron,
+	tektonWorkflowStatusCron cron.TektonWorkflowStatusCron,
 	proxyRouter proxy.ProxyRouter,
 	deploymentConfigurationRouter configDiff.DeploymentConfigurationRouter,
 	infraConfigRouter infraConfig.InfraConfigRouter,
@@ -165,6 +179,10 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 	userResourceRouter userResource.Router,
 	overviewRouter OverviewRouter,
 	globalAuthorisationConfigRouter globalConfig.AuthorisationConfigRouter,
+	celExpressionRouter celExpression.CelExpressionRouter,
+	workflowLogsRouter workflowLogs.WorkflowLogsRouter,
+	gitOpsPullRequestService pullRequest.GitOpsPullRequestService,
+	gitOpsDriftDetectionService drift.GitOpsDriftDetectionService,
 ) *MuxRouter {
 	r := &MuxRouter{
 		Router:                             mux.NewRouter(),
@@ -225,6 +243,9 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 		rbacRoleRouter:                     rbacRoleRouter,
 		scopedVariableRouter:               scopedVariableRouter,
 		ciTriggerCron:                      ciTriggerCron,
+		notificationDigestCron:             notificationDigestCron,
+		notificationDeliveryRetryCron:      notificationDeliveryRetryCron,
+		tektonWorkflowStatusCron:           tektonWorkflowStatusCron,
 		deploymentConfigurationRouter:      deploymentConfigurationRouter,
 		infraConfigRouter:                  infraConfigRouter,
 		argoApplicationRouter:              argoApplicationRouter,
@@ -234,6 +255,10 @@ func NewMuxRouter(logger *zap.SugaredLogger,
 		userResourceRouter:                 userResourceRouter,
 		overviewRouter:                     overviewRouter,
 		globalAuthorisationConfigRouter:    globalAuthorisationConfigRouter,
+		celExpressionRouter:                celExpressionRouter,
+		workflowLogsRouter:                 workflowLogsRouter,
+		gitOpsPullRequestService:           gitOpsPullRequestService,
+		gitOpsDriftDetectionService:        gitOpsDriftDetectionService,
 	}
 	return r
 }
@@ -412,6 +437,12 @@ func (r MuxRouter) Init() {
 	externalLinkRouter := r.Router.PathPrefix("/orchestrator/external-links").Subrouter()
 	r.externalLinkRouter.InitExternalLinkRouter(externalLinkRouter)
 
+	celExpressionRouter := r.Router.PathPrefix("/orchestrator/cel-expression").Subrouter()
+	r.celExpressionRouter.InitCelExpressionRouter(celExpressionRouter)
+
+	workflowLogsRouter := r.Router.PathPrefix("/orchestrator/workflow/logs").Subrouter()
+	r.workflowLogsRouter.InitWorkflowLogsRouter(workflowLogsRouter)
+
 	// module router
 	moduleRouter := r.Router.PathPrefix("/orchestrator/module").Subrouter()
 	r.moduleRouter.Init(moduleRouter)
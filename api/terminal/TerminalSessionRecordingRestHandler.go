@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package terminal
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording/bean"
+	"go.uber.org/zap"
+)
+
+const asciicastContentType = "application/x-asciicast"
+
+type TerminalSessionRecordingRestHandler interface {
+	GetSessions(w http.ResponseWriter, r *http.Request)
+	GetSessionById(w http.ResponseWriter, r *http.Request)
+	ReplaySession(w http.ResponseWriter, r *http.Request)
+}
+
+type TerminalSessionRecordingRestHandlerImpl struct {
+	logger           *zap.SugaredLogger
+	recordingService recording.TerminalSessionRecordingService
+	enforcer         casbin.Enforcer
+	userService      user.UserService
+}
+
+func NewTerminalSessionRecordingRestHandlerImpl(logger *zap.SugaredLogger,
+	recordingService recording.TerminalSessionRecordingService,
+	enforcer casbin.Enforcer, userService user.UserService) *TerminalSessionRecordingRestHandlerImpl {
+	return &TerminalSessionRecordingRestHandlerImpl{
+		logger:           logger,
+		recordingService: recordingService,
+		enforcer:         enforcer,
+		userService:      userService,
+	}
+}
+
+// GetSessions lists the terminal sessions, super admins can list the sessions of all the users and others only their own
+func (handler *TerminalSessionRecordingRestHandlerImpl) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	filter, err := handler.getSessionFilter(w, r)
+	if err != nil {
+		return
+	}
+	token := r.Header.Get("token")
+	if !handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*") {
+		filter.UserId = userId
+	}
+	res, err := handler.recordingService.GetSessions(filter)
+	if err != nil {
+		handler.logger.Errorw("service err, GetSessions", "err", err, "filter", filter)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (handler *TerminalSessionRecordingRestHandlerImpl) GetSessionById(w http.ResponseWriter, r *http.Request) {
+	session, ok := handler.getAuthorisedSession(w, r)
+	if !ok {
+		return
+	}
+	common.WriteJsonResp(w, nil, session, http.StatusOK)
+}
+
+// ReplaySession streams the asciicast recording of the session
+func (handler *TerminalSessionRecordingRestHandlerImpl) ReplaySession(w http.ResponseWriter, r *http.Request) {
+	session, ok := handler.getAuthorisedSession(w, r)
+	if !ok {
+		return
+	}
+	filePath, cleanUp, err := handler.recordingService.DownloadRecording(session.Id)
+	if err != nil {
+		handler.logger.Errorw("service err, ReplaySession", "err", err, "id", session.Id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	defer cleanUp()
+	file, err := os.Open(filePath)
+	if err != nil {
+		handler.logger.Errorw("error in opening recording file", "err", err, "id", session.Id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+	w.Header().Set(common.CONTENT_DISPOSITION, "attachment; filename="+session.SessionId+".cast")
+	w.Header().Set(common.CONTENT_TYPE, asciicastContentType)
+	_, err = io.Copy(w, file)
+	if err != nil {
+		handler.logger.Errorw("error in writing recording", "err", err, "id", session.Id)
+	}
+}
+
+// getAuthorisedSession returns the session of the id path param if the logged-in user is a super admin or the owner of the
+// session, the error response is written otherwise
+func (handler *TerminalSessionRecordingRestHandlerImpl) getAuthorisedSession(w http.ResponseWriter, r *http.Request) (*bean.TerminalSessionDto, bool) {
+	userId, err := handler.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return nil, false
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return nil, false
+	}
+	session, err := handler.recordingService.GetSessionById(id)
+	if err != nil {
+		handler.logger.Errorw("service err, GetSessionById", "err", err, "id", id)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return nil, false
+	}
+	token := r.Header.Get("token")
+	if session.UserId != userId && !handler.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionGet, "*") {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return nil, false
+	}
+	return session, true
+}
+
+// getSessionFilter parses the filter from the query params, the bad request response is written on error
+func (handler *TerminalSessionRecordingRestHandlerImpl) getSessionFilter(w http.ResponseWriter, r *http.Request) (*bean.TerminalSessionFilter, error) {
+	filter := &bean.TerminalSessionFilter{
+		Namespace: r.URL.Query().Get("namespace"),
+		PodName:   r.URL.Query().Get("podName"),
+	}
+	userId, err := common.ExtractIntQueryParam(w, r, "userId", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserId = int32(userId)
+	filter.ClusterId, err = common.ExtractIntQueryParam(w, r, "clusterId", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.Offset, err = common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.Size, err = common.ExtractIntQueryParam(w, r, "size", 0)
+	if err != nil {
+		return nil, err
+	}
+	filter.From, err = parseTimeQueryParam(r, "from")
+	if err == nil {
+		filter.To, err = parseTimeQueryParam(r, "to")
+	}
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, err
+	}
+	return filter, nil
+}
+
+// parseTimeQueryParam parses the RFC3339 time of the query param, returns nil if the param is not set
+func parseTimeQueryParam(r *http.Request, paramName string) (*time.Time, error) {
+	paramValue := r.URL.Query().Get(paramName)
+	if len(paramValue) == 0 {
+		return nil, nil
+	}
+	parsedTime, err := time.Parse(time.RFC3339, paramValue)
+	if err != nil {
+		return nil, err
+	}
+	return &parsedTime, nil
+}
@@ -25,12 +25,15 @@ type UserTerminalAccessRouter interface {
 }
 
 type UserTerminalAccessRouterImpl struct {
-	userTerminalAccessRestHandler UserTerminalAccessRestHandler
+	userTerminalAccessRestHandler       UserTerminalAccessRestHandler
+	terminalSessionRecordingRestHandler TerminalSessionRecordingRestHandler
 }
 
-func NewUserTerminalAccessRouterImpl(userTerminalAccessRestHandler UserTerminalAccessRestHandler) *UserTerminalAccessRouterImpl {
+func NewUserTerminalAccessRouterImpl(userTerminalAccessRestHandler UserTerminalAccessRestHandler,
+	terminalSessionRecordingRestHandler TerminalSessionRecordingRestHandler) *UserTerminalAccessRouterImpl {
 	return &UserTerminalAccessRouterImpl{
-		userTerminalAccessRestHandler: userTerminalAccessRestHandler,
+		userTerminalAccessRestHandler:       userTerminalAccessRestHandler,
+		terminalSessionRecordingRestHandler: terminalSessionRecordingRestHandler,
 	}
 }
 
@@ -57,6 +60,12 @@ func (router UserTerminalAccessRouterImpl) InitTerminalAccessRouter(userTerminal
 		HandlerFunc(router.userTerminalAccessRestHandler.ValidateShell)
 	userTerminalAccessRouter.Path("/edit").
 		HandlerFunc(router.userTerminalAccessRestHandler.EditPodManifest).Methods("PUT")
+	userTerminalAccessRouter.Path("/session").
+		HandlerFunc(router.terminalSessionRecordingRestHandler.GetSessions).Methods("GET")
+	userTerminalAccessRouter.Path("/session/{id}").
+		HandlerFunc(router.terminalSessionRecordingRestHandler.GetSessionById).Methods("GET")
+	userTerminalAccessRouter.Path("/session/{id}/replay").
+		HandlerFunc(router.terminalSessionRecordingRestHandler.ReplaySession).Methods("GET")
 	//TODO fetch all user running/starting pods
 	//TODO fetch all running/starting pods also include sessionIds if session exists
 	//TODO terminate all Sessions
@@ -27,6 +27,8 @@ var TerminalWireSet = wire.NewSet(
 	wire.Bind(new(UserTerminalAccessRouter), new(*UserTerminalAccessRouterImpl)),
 	NewUserTerminalAccessRestHandlerImpl,
 	wire.Bind(new(UserTerminalAccessRestHandler), new(*UserTerminalAccessRestHandlerImpl)),
+	NewTerminalSessionRecordingRestHandlerImpl,
+	wire.Bind(new(TerminalSessionRecordingRestHandler), new(*TerminalSessionRecordingRestHandlerImpl)),
 	clusterTerminalAccess.GetTerminalAccessConfig,
 	clusterTerminalAccess.NewUserTerminalAccessServiceImpl,
 	wire.Bind(new(clusterTerminalAccess.UserTerminalAccessService), new(*clusterTerminalAccess.UserTerminalAccessServiceImpl)),
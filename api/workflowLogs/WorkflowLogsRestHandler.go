@@ -0,0 +1,289 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workflowLogs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/devtron-labs/devtron/api/restHandler/common"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"gopkg.in/go-playground/validator.v9"
+)
+
+const defaultLogLinesLimit = 1000
+
+type WorkflowLogsRestHandler interface {
+	GetLogLines(w http.ResponseWriter, r *http.Request)
+	SearchWorkflowLogs(w http.ResponseWriter, r *http.Request)
+	SearchPipelineLogs(w http.ResponseWriter, r *http.Request)
+	GetRetentionPolicies(w http.ResponseWriter, r *http.Request)
+	SaveRetentionPolicy(w http.ResponseWriter, r *http.Request)
+	DeleteRetentionPolicy(w http.ResponseWriter, r *http.Request)
+}
+
+type WorkflowLogsRestHandlerImpl struct {
+	logger                    *zap.SugaredLogger
+	workflowLogArchiveService logArchive.WorkflowLogArchiveService
+	userService               user.UserService
+	enforcer                  casbin.Enforcer
+	enforcerUtil              rbac.EnforcerUtil
+	validator                 *validator.Validate
+}
+
+func NewWorkflowLogsRestHandlerImpl(logger *zap.SugaredLogger,
+	workflowLogArchiveService logArchive.WorkflowLogArchiveService,
+	userService user.UserService,
+	enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil,
+	validator *validator.Validate,
+) *WorkflowLogsRestHandlerImpl {
+	return &WorkflowLogsRestHandlerImpl{
+		logger:                    logger,
+		workflowLogArchiveService: workflowLogArchiveService,
+		userService:               userService,
+		enforcer:                  enforcer,
+		enforcerUtil:              enforcerUtil,
+		validator:                 validator,
+	}
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) GetLogLines(w http.ResponseWriter, r *http.Request) {
+	workflowType, workflowId, ok := impl.authorizeWorkflow(w, r)
+	if !ok {
+		return
+	}
+	offset, err := common.ExtractIntQueryParam(w, r, "offset", 0)
+	if err != nil {
+		return
+	}
+	limit, err := common.ExtractIntQueryParam(w, r, "limit", defaultLogLinesLimit)
+	if err != nil {
+		return
+	}
+	res, err := impl.workflowLogArchiveService.GetLogLines(workflowType, workflowId, offset, limit)
+	if err != nil {
+		impl.logger.Errorw("service err, GetLogLines", "workflowType", workflowType, "workflowId", workflowId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) SearchWorkflowLogs(w http.ResponseWriter, r *http.Request) {
+	workflowType, workflowId, ok := impl.authorizeWorkflow(w, r)
+	if !ok {
+		return
+	}
+	query, ok := impl.getLogSearchQuery(w, r)
+	if !ok {
+		return
+	}
+	res, err := impl.workflowLogArchiveService.SearchLogs(workflowType, workflowId, query)
+	if err != nil {
+		impl.logger.Errorw("service err, SearchWorkflowLogs", "workflowType", workflowType, "workflowId", workflowId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) SearchPipelineLogs(w http.ResponseWriter, r *http.Request) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return
+	}
+	workflowType, ok := getWorkflowType(w, r)
+	if !ok {
+		return
+	}
+	pipelineId, err := common.ExtractIntPathParamWithContext(w, r, "pipelineId")
+	if err != nil {
+		return
+	}
+	appId, err := impl.workflowLogArchiveService.GetPipelineAppId(workflowType, pipelineId)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	if !impl.enforceAppGet(w, r, appId) {
+		return
+	}
+	query, ok := impl.getLogSearchQuery(w, r)
+	if !ok {
+		return
+	}
+	query.LastBuilds, err = common.ExtractIntQueryParam(w, r, "lastBuilds", 0)
+	if err != nil {
+		return
+	}
+	res, err := impl.workflowLogArchiveService.SearchPipelineLogs(workflowType, pipelineId, query)
+	if err != nil {
+		impl.logger.Errorw("service err, SearchPipelineLogs", "workflowType", workflowType, "pipelineId", pipelineId, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) GetRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	if _, ok := impl.authorizeSuperAdmin(w, r); !ok {
+		return
+	}
+	res, err := impl.workflowLogArchiveService.GetRetentionPolicies()
+	if err != nil {
+		impl.logger.Errorw("service err, GetRetentionPolicies", "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) SaveRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, ok := impl.authorizeSuperAdmin(w, r)
+	if !ok {
+		return
+	}
+	decoder := json.NewDecoder(r.Body)
+	var request bean.LogRetentionPolicyDto
+	err := decoder.Decode(&request)
+	if err != nil {
+		impl.logger.Errorw("request err, SaveRetentionPolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	err = impl.validator.Struct(request)
+	if err != nil {
+		impl.logger.Errorw("validation err, SaveRetentionPolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return
+	}
+	request.UserId = userId
+	res, err := impl.workflowLogArchiveService.SaveRetentionPolicy(&request)
+	if err != nil {
+		impl.logger.Errorw("service err, SaveRetentionPolicy", "err", err, "payload", request)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, res, http.StatusOK)
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) DeleteRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	userId, ok := impl.authorizeSuperAdmin(w, r)
+	if !ok {
+		return
+	}
+	id, err := common.ExtractIntPathParamWithContext(w, r, "id")
+	if err != nil {
+		return
+	}
+	err = impl.workflowLogArchiveService.DeleteRetentionPolicy(id, userId)
+	if err != nil {
+		impl.logger.Errorw("service err, DeleteRetentionPolicy", "id", id, "err", err)
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return
+	}
+	common.WriteJsonResp(w, nil, id, http.StatusOK)
+}
+
+// authorizeWorkflow checks the get access of the user on the app of the workflow
+func (impl *WorkflowLogsRestHandlerImpl) authorizeWorkflow(w http.ResponseWriter, r *http.Request) (bean.WorkflowLogType, int, bool) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return "", 0, false
+	}
+	workflowType, ok := getWorkflowType(w, r)
+	if !ok {
+		return "", 0, false
+	}
+	workflowId, err := common.ExtractIntPathParamWithContext(w, r, "workflowId")
+	if err != nil {
+		return "", 0, false
+	}
+	appId, err := impl.workflowLogArchiveService.GetWorkflowAppId(workflowType, workflowId)
+	if err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusInternalServerError)
+		return "", 0, false
+	}
+	if !impl.enforceAppGet(w, r, appId) {
+		return "", 0, false
+	}
+	return workflowType, workflowId, true
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) enforceAppGet(w http.ResponseWriter, r *http.Request, appId int) bool {
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceApplications, casbin.ActionGet, impl.enforcerUtil.GetAppRBACNameByAppId(appId)); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) authorizeSuperAdmin(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	userId, err := impl.userService.GetLoggedInUser(r)
+	if userId == 0 || err != nil {
+		common.HandleUnauthorized(w, r)
+		return 0, false
+	}
+	token := r.Header.Get("token")
+	if ok := impl.enforcer.Enforce(token, casbin.ResourceGlobal, casbin.ActionUpdate, "*"); !ok {
+		common.WriteJsonResp(w, errors.New("unauthorized"), nil, http.StatusForbidden)
+		return 0, false
+	}
+	return userId, true
+}
+
+func (impl *WorkflowLogsRestHandlerImpl) getLogSearchQuery(w http.ResponseWriter, r *http.Request) (*bean.LogSearchQuery, bool) {
+	queryParams := r.URL.Query()
+	query := &bean.LogSearchQuery{Pattern: queryParams.Get("pattern")}
+	var err error
+	if query.IsRegex, err = common.ExtractBoolQueryParam(r, "regex"); err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	if query.IgnoreCase, err = common.ExtractBoolQueryParam(r, "ignoreCase"); err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	if query.MaxMatches, err = common.ExtractIntQueryParam(w, r, "maxMatches", 0); err != nil {
+		return nil, false
+	}
+	if err = impl.validator.Struct(query); err != nil {
+		common.WriteJsonResp(w, err, nil, http.StatusBadRequest)
+		return nil, false
+	}
+	return query, true
+}
+
+func getWorkflowType(w http.ResponseWriter, r *http.Request) (bean.WorkflowLogType, bool) {
+	workflowType := bean.WorkflowLogType(mux.Vars(r)["workflowType"])
+	if !workflowType.IsValid() {
+		common.WriteJsonResp(w, errors.New("invalid workflow type, supported types are CI, PRE and POST"), nil, http.StatusBadRequest)
+		return "", false
+	}
+	return workflowType, true
+}
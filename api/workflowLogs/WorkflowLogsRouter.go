@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package workflowLogs
+
+import (
+	"github.com/gorilla/mux"
+)
+
+type WorkflowLogsRouter interface {
+	InitWorkflowLogsRouter(router *mux.Router)
+}
+
+type WorkflowLogsRouterImpl struct {
+	workflowLogsRestHandler WorkflowLogsRestHandler
+}
+
+func NewWorkflowLogsRouterImpl(workflowLogsRestHandler WorkflowLogsRestHandler) *WorkflowLogsRouterImpl {
+	return &WorkflowLogsRouterImpl{workflowLogsRestHandler: workflowLogsRestHandler}
+}
+
+func (impl *WorkflowLogsRouterImpl) InitWorkflowLogsRouter(router *mux.Router) {
+	router.Path("/retention-policy").HandlerFunc(impl.workflowLogsRestHandler.GetRetentionPolicies).Methods("GET")
+	router.Path("/retention-policy").HandlerFunc(impl.workflowLogsRestHandler.SaveRetentionPolicy).Methods("POST")
+	router.Path("/retention-policy/{id}").HandlerFunc(impl.workflowLogsRestHandler.DeleteRetentionPolicy).Methods("DELETE")
+	router.Path("/{workflowType}/pipeline/{pipelineId}/search").HandlerFunc(impl.workflowLogsRestHandler.SearchPipelineLogs).Methods("GET")
+	router.Path("/{workflowType}/{workflowId}/lines").HandlerFunc(impl.workflowLogsRestHandler.GetLogLines).Methods("GET")
+	router.Path("/{workflowType}/{workflowId}/search").HandlerFunc(impl.workflowLogsRestHandler.SearchWorkflowLogs).Methods("GET")
+}
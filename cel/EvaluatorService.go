@@ -3,6 +3,7 @@ package cel
 import (
 	"fmt"
 	"github.com/google/cel-go/cel"
+	celAst "github.com/google/cel-go/common/ast"
 	"github.com/google/cel-go/checker/decls"
 	"go.uber.org/zap"
 	expr "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
@@ -11,6 +12,10 @@ import (
 type EvaluatorService interface {
 	EvaluateCELRequest(request Request) (bool, error)
 	Validate(request Request) (*cel.Ast, *cel.Env, error)
+	// ValidateExpression type checks the expression against the param catalog and reports issues with their positions
+	ValidateExpression(request ExpressionRequest) (*ExpressionResponse, error)
+	// EvaluateExpression validates the expression and evaluates it against the sample context of the request
+	EvaluateExpression(request ExpressionRequest) (*ExpressionResponse, error)
 }
 
 type EvaluatorServiceImpl struct {
@@ -85,6 +90,91 @@ func (impl *EvaluatorServiceImpl) Validate(request Request) (*cel.Ast, *cel.Env,
 	return ast, env, nil
 }
 
+func (impl *EvaluatorServiceImpl) ValidateExpression(request ExpressionRequest) (*ExpressionResponse, error) {
+	response, _, _, err := impl.compileExpression(request)
+	return response, err
+}
+
+func (impl *EvaluatorServiceImpl) EvaluateExpression(request ExpressionRequest) (*ExpressionResponse, error) {
+	response, ast, env, err := impl.compileExpression(request)
+	if err != nil || !response.Valid {
+		return response, err
+	}
+	values, err := getContextValues(request.Context)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		impl.logger.Errorw("error in constructing CEL program", "expression", request.Expression, "err", err)
+		return nil, fmt.Errorf("program construction error: %s", err)
+	}
+	out, _, err := prg.Eval(values)
+	if err != nil {
+		// evaluation errors like missing context values are a result of the sample context, not a failure of the api
+		response.EvaluationError = err.Error()
+		return response, nil
+	}
+	response.Result = out.Value()
+	return response, nil
+}
+
+// compileExpression compiles the expression with all the params of the catalog declared, issues are returned in the response
+func (impl *EvaluatorServiceImpl) compileExpression(request ExpressionRequest) (*ExpressionResponse, *cel.Ast, *cel.Env, error) {
+	var declarations []*expr.Decl
+	for _, definition := range paramCatalog {
+		declsType, err := getDeclarationType(definition.Type)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		declarations = append(declarations, decls.NewVar(string(definition.ParamName), declsType))
+	}
+	env, err := cel.NewEnv(cel.Declarations(declarations...))
+	if err != nil {
+		impl.logger.Errorw("error in creating CEL env", "err", err)
+		return nil, nil, nil, err
+	}
+	response := &ExpressionResponse{}
+	ast, issues := env.Compile(request.Expression)
+	if issues != nil && issues.Err() != nil {
+		for _, issue := range issues.Errors() {
+			expressionIssue := &ExpressionIssue{Message: issue.Message}
+			if issue.Location != nil && issue.Location.Line() > 0 {
+				expressionIssue.Line = issue.Location.Line()
+				// cel columns are 0-based
+				expressionIssue.Column = issue.Location.Column() + 1
+			}
+			response.Issues = append(response.Issues, expressionIssue)
+		}
+		return response, nil, nil, nil
+	}
+	response.Valid = true
+	response.ResultType = ast.OutputType().String()
+	return response, ast, env, nil
+}
+
+// getContextValues converts the json decoded sample context to the values expected by the declared param types
+func getContextValues(context map[string]interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(context))
+	for key, value := range context {
+		definition, ok := GetParamDefinition(ParamName(key))
+		if !ok {
+			return nil, fmt.Errorf("unknown param '%s' in context", key)
+		}
+		if definition.Type == ParamTypeInteger {
+			// json numbers are decoded as float64 which cel does not match against int declarations
+			if floatValue, isFloat := value.(float64); isFloat {
+				if floatValue != float64(int64(floatValue)) {
+					return nil, fmt.Errorf("param '%s' must be an integer", key)
+				}
+				value = int64(floatValue)
+			}
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
 func getDeclarationType(paramType ParamValuesType) (*expr.Type, error) {
 	switch paramType {
 	case ParamTypeString:
@@ -99,7 +189,26 @@ func getDeclarationType(paramType ParamValuesType) (*expr.Type, error) {
 		return decls.NewListType(decls.String), nil
 	case ParamTypeMapStringToAny:
 		return decls.NewMapType(decls.String, decls.Dyn), nil
+	case ParamTypeMapStringToStr:
+		return decls.NewMapType(decls.String, decls.String), nil
 	default:
 		return nil, fmt.Errorf("unsupported parameter type: %s", paramType)
 	}
 }
+
+// GetReferencedParamNames parses the expression and returns the names of the params it references
+func GetReferencedParamNames(expression string) (map[ParamName]bool, error) {
+	env, err := cel.NewEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Parse(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("parse error: %s", issues.Err())
+	}
+	paramNames := make(map[ParamName]bool)
+	for _, ident := range celAst.MatchDescendants(celAst.NavigateAST(ast.NativeRep()), celAst.KindMatcher(celAst.IdentKind)) {
+		paramNames[ParamName(ident.AsIdent())] = true
+	}
+	return paramNames, nil
+}
@@ -9,6 +9,7 @@ const (
 	ParamTypeList           ParamValuesType = "list"
 	ParamTypeBool           ParamValuesType = "bool"
 	ParamTypeMapStringToAny ParamValuesType = "mapStringToAny"
+	ParamTypeMapStringToStr ParamValuesType = "mapStringToString"
 )
 
 type ParamName string
@@ -25,6 +26,75 @@ const ContainerRepo ParamName = "containerRepository"
 const ContainerImage ParamName = "containerImage"
 const ContainerImageTag ParamName = "containerImageTag"
 const ImageLabels ParamName = "imageLabels"
+const PipelineName ParamName = "pipelineName"
+const PipelineType ParamName = "pipelineType"
+const EventType ParamName = "eventType"
+const TriggeredBy ParamName = "triggeredBy"
+const FailureStage ParamName = "failureStage"
+const DurationInSecs ParamName = "durationInSecs"
+const GitBranch ParamName = "gitBranch"
+const CommitAuthor ParamName = "commitAuthor"
+const AppLabels ParamName = "appLabels"
+const ClusterLabels ParamName = "clusterLabels"
+const ArtifactAgeInMins ParamName = "artifactAgeInMins"
+const VulnerabilityCritical ParamName = "vulnerabilityCritical"
+const VulnerabilityHigh ParamName = "vulnerabilityHigh"
+const VulnerabilityMedium ParamName = "vulnerabilityMedium"
+const VulnerabilityLow ParamName = "vulnerabilityLow"
+
+// ParamDefinition describes a param of the catalog shared by all features evaluating CEL expressions
+type ParamDefinition struct {
+	ParamName   ParamName       `json:"paramName"`
+	Type        ParamValuesType `json:"type"`
+	Description string          `json:"description"`
+}
+
+var paramCatalog = []ParamDefinition{
+	{ParamName: AppName, Type: ParamTypeString, Description: "Name of the application"},
+	{ParamName: ProjectName, Type: ParamTypeString, Description: "Name of the project the application belongs to"},
+	{ParamName: AppLabels, Type: ParamTypeMapStringToStr, Description: "Labels of the application"},
+	{ParamName: EnvName, Type: ParamTypeString, Description: "Name of the environment"},
+	{ParamName: IsProdEnv, Type: ParamTypeBool, Description: "Whether the environment is marked as production"},
+	{ParamName: ClusterName, Type: ParamTypeString, Description: "Name of the cluster of the environment"},
+	{ParamName: ClusterLabels, Type: ParamTypeMapStringToStr, Description: "Labels of the cluster of the environment"},
+	{ParamName: PipelineName, Type: ParamTypeString, Description: "Name of the build or deployment pipeline"},
+	{ParamName: PipelineType, Type: ParamTypeString, Description: "Type of the pipeline, CI or CD"},
+	{ParamName: CdPipelineName, Type: ParamTypeString, Description: "Name of the deployment pipeline"},
+	{ParamName: CdPipelineTriggerType, Type: ParamTypeString, Description: "Trigger type of the deployment pipeline, AUTOMATIC or MANUAL"},
+	{ParamName: ChartRefId, Type: ParamTypeInteger, Description: "Id of the chart reference used by the deployment"},
+	{ParamName: ContainerRepo, Type: ParamTypeString, Description: "Container repository of the image"},
+	{ParamName: ContainerImage, Type: ParamTypeString, Description: "Complete container image"},
+	{ParamName: ContainerImageTag, Type: ParamTypeString, Description: "Tag of the container image"},
+	{ParamName: ImageLabels, Type: ParamTypeList, Description: "Release tags added to the image"},
+	{ParamName: ArtifactAgeInMins, Type: ParamTypeInteger, Description: "Minutes elapsed since the image was built"},
+	{ParamName: VulnerabilityCritical, Type: ParamTypeInteger, Description: "Number of critical vulnerabilities found in the image"},
+	{ParamName: VulnerabilityHigh, Type: ParamTypeInteger, Description: "Number of high vulnerabilities found in the image"},
+	{ParamName: VulnerabilityMedium, Type: ParamTypeInteger, Description: "Number of medium vulnerabilities found in the image"},
+	{ParamName: VulnerabilityLow, Type: ParamTypeInteger, Description: "Number of low vulnerabilities found in the image"},
+	{ParamName: GitBranch, Type: ParamTypeString, Description: "Git branch the image was built from"},
+	{ParamName: CommitAuthor, Type: ParamTypeString, Description: "Author of the commit the image was built from"},
+	{ParamName: EventType, Type: ParamTypeString, Description: "Type of the pipeline event, trigger, success or fail"},
+	{ParamName: TriggeredBy, Type: ParamTypeString, Description: "Email of the user who triggered the pipeline"},
+	{ParamName: FailureStage, Type: ParamTypeString, Description: "Stage which failed, PRE, DEPLOY, POST or CI"},
+	{ParamName: DurationInSecs, Type: ParamTypeInteger, Description: "Time taken by the pipeline run in seconds"},
+}
+
+// GetParamCatalog returns the definitions of all the params available to CEL expressions
+func GetParamCatalog() []ParamDefinition {
+	catalog := make([]ParamDefinition, len(paramCatalog))
+	copy(catalog, paramCatalog)
+	return catalog
+}
+
+// GetParamDefinition returns the catalog definition of the param
+func GetParamDefinition(paramName ParamName) (ParamDefinition, bool) {
+	for _, definition := range paramCatalog {
+		if definition.ParamName == paramName {
+			return definition, true
+		}
+	}
+	return ParamDefinition{}, false
+}
 
 type Request struct {
 	Expression         string             `json:"expression"`
@@ -40,3 +110,25 @@ type ExpressionParam struct {
 	Value     interface{}     `json:"value"`
 	Type      ParamValuesType `json:"type"`
 }
+
+// ExpressionRequest is an expression to be validated, or evaluated against a sample context keyed by param names
+type ExpressionRequest struct {
+	Expression string                 `json:"expression" validate:"required"`
+	Context    map[string]interface{} `json:"context,omitempty"`
+}
+
+// ExpressionIssue is a parse or type check error, line and column are 1-based positions in the expression
+type ExpressionIssue struct {
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+type ExpressionResponse struct {
+	Valid      bool               `json:"valid"`
+	Issues     []*ExpressionIssue `json:"issues,omitempty"`
+	ResultType string             `json:"resultType,omitempty"`
+	// Result and EvaluationError are set only on evaluation
+	Result          interface{} `json:"result,omitempty"`
+	EvaluationError string      `json:"evaluationError,omitempty"`
+}
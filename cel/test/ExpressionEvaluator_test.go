@@ -0,0 +1,98 @@
+package test
+
+import (
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEvaluatorServiceImpl_ValidateExpression(t *testing.T) {
+	log, err := util.NewSugardLogger()
+	if err != nil {
+		log.Panic(err)
+	}
+	impl := cel.NewCELServiceImpl(log)
+
+	res, err := impl.ValidateExpression(cel.ExpressionRequest{Expression: `gitBranch == "main" && vulnerabilityCritical == 0`})
+	assert.NoError(t, err)
+	assert.True(t, res.Valid)
+	assert.Equal(t, "bool", res.ResultType)
+
+	res, err = impl.ValidateExpression(cel.ExpressionRequest{Expression: "appName == 1 &&\n  unknownParam"})
+	assert.NoError(t, err)
+	assert.False(t, res.Valid)
+	assert.Len(t, res.Issues, 2)
+	assert.Equal(t, 1, res.Issues[0].Line)
+	assert.Equal(t, 9, res.Issues[0].Column)
+	assert.Equal(t, 2, res.Issues[1].Line)
+	assert.Equal(t, 3, res.Issues[1].Column)
+}
+
+func TestEvaluatorServiceImpl_EvaluateExpression(t *testing.T) {
+	log, err := util.NewSugardLogger()
+	if err != nil {
+		log.Panic(err)
+	}
+	impl := cel.NewCELServiceImpl(log)
+	context := map[string]interface{}{
+		"appLabels":             map[string]interface{}{"team": "payments"},
+		"vulnerabilityCritical": float64(0),
+		"artifactAgeInMins":     float64(30),
+	}
+
+	res, err := impl.EvaluateExpression(cel.ExpressionRequest{
+		Expression: `appLabels["team"] == "payments" && vulnerabilityCritical == 0 && artifactAgeInMins < 60`,
+		Context:    context,
+	})
+	assert.NoError(t, err)
+	assert.True(t, res.Valid)
+	assert.Equal(t, true, res.Result)
+
+	res, err = impl.EvaluateExpression(cel.ExpressionRequest{Expression: `commitAuthor`, Context: context})
+	assert.NoError(t, err)
+	assert.Equal(t, "string", res.ResultType)
+	assert.NotEmpty(t, res.EvaluationError)
+
+	_, err = impl.EvaluateExpression(cel.ExpressionRequest{Expression: `true`, Context: map[string]interface{}{"unknown": 1}})
+	assert.Error(t, err)
+}
+
+func TestEvaluatorServiceImpl_PromotionCondition(t *testing.T) {
+	log, err := util.NewSugardLogger()
+	if err != nil {
+		log.Panic(err)
+	}
+	impl := cel.NewCELServiceImpl(log)
+	getRequest := func(imageLabels []string, vulnerabilityCritical int) cel.Request {
+		return cel.Request{
+			Expression: `imageLabels.exists(l, l == "qa-approved") && vulnerabilityCritical == 0`,
+			ExpressionMetadata: cel.ExpressionMetadata{
+				Params: []cel.ExpressionParam{
+					{ParamName: cel.ImageLabels, Value: imageLabels, Type: cel.ParamTypeList},
+					{ParamName: cel.VulnerabilityCritical, Value: vulnerabilityCritical, Type: cel.ParamTypeInteger},
+				},
+			},
+		}
+	}
+	isAllowed, err := impl.EvaluateCELRequest(getRequest([]string{"qa-approved"}, 0))
+	assert.NoError(t, err)
+	assert.True(t, isAllowed)
+
+	isAllowed, err = impl.EvaluateCELRequest(getRequest([]string{"qa-approved"}, 2))
+	assert.NoError(t, err)
+	assert.False(t, isAllowed)
+
+	isAllowed, err = impl.EvaluateCELRequest(getRequest([]string{}, 0))
+	assert.NoError(t, err)
+	assert.False(t, isAllowed)
+}
+
+func TestGetReferencedParamNames(t *testing.T) {
+	paramNames, err := cel.GetReferencedParamNames(`appLabels["team"] == "payments" && vulnerabilityCritical == 0 && "main" in [gitBranch]`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[cel.ParamName]bool{cel.AppLabels: true, cel.VulnerabilityCritical: true, cel.GitBranch: true}, paramNames)
+
+	_, err = cel.GetReferencedParamNames(`appName ==`)
+	assert.Error(t, err)
+}
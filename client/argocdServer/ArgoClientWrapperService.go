@@ -82,6 +82,10 @@ type ApplicationClientWrapper interface {
 	ResourceTree(ctxt context.Context, query *application2.ResourcesQuery) (*v1alpha1.ApplicationTree, error)
 	GetArgoClient(ctxt context.Context) (application2.ApplicationServiceClient, *grpc.ClientConn, error)
 	GetApplicationResource(ctx context.Context, query *application2.ApplicationResourceRequest) (*application2.ApplicationResourceResponse, error)
+
+	// GetManagedResources returns the diff between the desired and the live state of the resources managed by the argoCd app
+	GetManagedResources(ctx context.Context, appName string) ([]*v1alpha1.ResourceDiff, error)
+
 	DeleteArgoApp(ctx context.Context, appName string, cascadeDelete bool) (*application2.ApplicationResponse, error)
 
 	// GetArgoAppByName fetches an argoCd app by its name
@@ -225,6 +229,20 @@ func (impl *ArgoClientWrapperServiceImpl) GetApplicationResource(ctx context.Con
 	return resource, nil
 }
 
+func (impl *ArgoClientWrapperServiceImpl) GetManagedResources(ctx context.Context, appName string) ([]*v1alpha1.ResourceDiff, error) {
+	grpcConfig, err := impl.acdConfigGetter.GetGRPCConfig()
+	if err != nil {
+		impl.logger.Errorw("error in getting grpc config", "err", err)
+		return nil, err
+	}
+	resp, err := impl.acdApplicationClient.ManagedResources(ctx, grpcConfig, &application2.ResourcesQuery{ApplicationName: &appName})
+	if err != nil {
+		impl.logger.Errorw("error in getting managed resources", "appName", appName, "err", err)
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
 func (impl *ArgoClientWrapperServiceImpl) GetArgoApplication(ctx context.Context, query *application2.ApplicationQuery) (*v1alpha1.Application, error) {
 	grpcConfig, err := impl.acdConfigGetter.GetGRPCConfig()
 	if err != nil {
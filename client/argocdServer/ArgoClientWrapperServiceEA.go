@@ -48,6 +48,11 @@ func (impl *ArgoClientWrapperServiceEAImpl) GetApplicationResource(ctx context.C
 	return nil, nil
 }
 
+func (impl *ArgoClientWrapperServiceEAImpl) GetManagedResources(ctx context.Context, appName string) ([]*v1alpha1.ResourceDiff, error) {
+	impl.logger.Info("not implemented for EA mode")
+	return nil, nil
+}
+
 func (impl *ArgoClientWrapperServiceEAImpl) GetArgoApplication(ctx context.Context, query *application2.ApplicationQuery) (*v1alpha1.Application, error) {
 	impl.logger.Info("not implemented")
 	return nil, nil
@@ -39,6 +39,9 @@ type ServiceClient interface {
 	// GetResource returns single application resource
 	GetResource(ctxt context.Context, grpcConfig *argoApplication.ArgoGRPCConfig, query *application.ApplicationResourceRequest) (*application.ApplicationResourceResponse, error)
 
+	// ManagedResources returns the diff between the desired and the live state of the resources managed by an application
+	ManagedResources(ctxt context.Context, grpcConfig *argoApplication.ArgoGRPCConfig, query *application.ResourcesQuery) (*application.ManagedResourcesResponse, error)
+
 	// Get returns an application by name
 	Get(ctx context.Context, grpcConfig *argoApplication.ArgoGRPCConfig, query *application.ApplicationQuery) (*v1alpha1.Application, error)
 
@@ -159,6 +162,18 @@ func (c ServiceClientImpl) GetResource(ctxt context.Context, grpcConfig *argoApp
 	return asc.GetResource(ctx, query)
 }
 
+func (c ServiceClientImpl) ManagedResources(ctxt context.Context, grpcConfig *argoApplication.ArgoGRPCConfig, query *application.ResourcesQuery) (*application.ManagedResourcesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctxt, argoApplication.TimeoutSlow)
+	defer cancel()
+	asc, conn, err := c.GetArgoClient(ctx, grpcConfig)
+	if err != nil {
+		c.logger.Errorw("error getting ArgoCD client", "error", err)
+		return nil, err
+	}
+	defer util.Close(conn, c.logger)
+	return asc.ManagedResources(ctx, query)
+}
+
 func (c ServiceClientImpl) Delete(ctx context.Context, grpcConfig *argoApplication.ArgoGRPCConfig, query *application.ApplicationDeleteRequest) (*application.ApplicationResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, argoApplication.TimeoutSlow)
 	defer cancel()
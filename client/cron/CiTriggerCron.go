@@ -17,12 +17,20 @@
 package cron
 
 import (
+	"context"
 	"fmt"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/client/gitSensor"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
 	repository2 "github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
 	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	"github.com/devtron-labs/devtron/pkg/bean"
 	"github.com/devtron-labs/devtron/pkg/build/pipeline/bean/common"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule"
+	repository4 "github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/repository"
 	"github.com/devtron-labs/devtron/pkg/build/trigger"
 	"github.com/devtron-labs/devtron/pkg/pipeline/repository"
 	repository3 "github.com/devtron-labs/devtron/pkg/plugin/repository"
@@ -33,32 +41,43 @@ import (
 
 type CiTriggerCron interface {
 	TriggerCiCron()
+	TriggerScheduledCi()
 }
 
 type CiTriggerCronImpl struct {
-	logger                  *zap.SugaredLogger
-	cron                    *cron.Cron
-	cfg                     *CiTriggerCronConfig
-	pipelineStageRepository repository.PipelineStageRepository
-	ciArtifactRepository    repository2.CiArtifactRepository
-	globalPluginRepository  repository3.GlobalPluginRepository
-	ciHandlerService        trigger.HandlerService
+	logger                    *zap.SugaredLogger
+	cron                      *cron.Cron
+	cfg                       *CiTriggerCronConfig
+	pipelineStageRepository   repository.PipelineStageRepository
+	ciArtifactRepository      repository2.CiArtifactRepository
+	globalPluginRepository    repository3.GlobalPluginRepository
+	ciHandlerService          trigger.HandlerService
+	ciPipelineScheduleService schedule.CiPipelineScheduleService
+	ciPipelineRepository      pipelineConfig.CiPipelineRepository
+	ciWorkflowRepository      pipelineConfig.CiWorkflowRepository
+	gitSensorClient           gitSensor.Client
 }
 
 func NewCiTriggerCronImpl(logger *zap.SugaredLogger, cfg *CiTriggerCronConfig, pipelineStageRepository repository.PipelineStageRepository,
 	ciArtifactRepository repository2.CiArtifactRepository, globalPluginRepository repository3.GlobalPluginRepository, cronLogger *cron2.CronLoggerImpl,
-	ciHandlerService trigger.HandlerService) *CiTriggerCronImpl {
+	ciHandlerService trigger.HandlerService, ciPipelineScheduleService schedule.CiPipelineScheduleService,
+	ciPipelineRepository pipelineConfig.CiPipelineRepository, ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
+	gitSensorClient gitSensor.Client) *CiTriggerCronImpl {
 	cron := cron.New(
 		cron.WithChain(cron.Recover(cronLogger)))
 	cron.Start()
 	impl := &CiTriggerCronImpl{
-		logger:                  logger,
-		cron:                    cron,
-		pipelineStageRepository: pipelineStageRepository,
-		cfg:                     cfg,
-		ciArtifactRepository:    ciArtifactRepository,
-		globalPluginRepository:  globalPluginRepository,
-		ciHandlerService:        ciHandlerService,
+		logger:                    logger,
+		cron:                      cron,
+		pipelineStageRepository:   pipelineStageRepository,
+		cfg:                       cfg,
+		ciArtifactRepository:      ciArtifactRepository,
+		globalPluginRepository:    globalPluginRepository,
+		ciHandlerService:          ciHandlerService,
+		ciPipelineScheduleService: ciPipelineScheduleService,
+		ciPipelineRepository:      ciPipelineRepository,
+		ciWorkflowRepository:      ciWorkflowRepository,
+		gitSensorClient:           gitSensorClient,
 	}
 
 	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.SourceControllerCronTime), impl.TriggerCiCron)
@@ -66,12 +85,18 @@ func NewCiTriggerCronImpl(logger *zap.SugaredLogger, cfg *CiTriggerCronConfig, p
 		logger.Errorw("error while configure cron job for ci workflow status update", "err", err)
 		return impl
 	}
+	_, err = cron.AddFunc(fmt.Sprintf("@every %dm", cfg.CiPipelineScheduleCronTime), impl.TriggerScheduledCi)
+	if err != nil {
+		logger.Errorw("error while configure cron job for scheduled ci trigger", "err", err)
+		return impl
+	}
 	return impl
 }
 
 type CiTriggerCronConfig struct {
-	SourceControllerCronTime int    `env:"CI_TRIGGER_CRON_TIME" envDefault:"2" description:"For image poll plugin"`
-	PluginName               string `env:"PLUGIN_NAME"  envDefault:"Pull images from container repository" description:"Handles image retrieval from a container repository and triggers subsequent CI processes upon detecting new images.Current default plugin name: Pull Images from Container Repository."`
+	SourceControllerCronTime   int    `env:"CI_TRIGGER_CRON_TIME" envDefault:"2" description:"For image poll plugin"`
+	PluginName                 string `env:"PLUGIN_NAME"  envDefault:"Pull images from container repository" description:"Handles image retrieval from a container repository and triggers subsequent CI processes upon detecting new images.Current default plugin name: Pull Images from Container Repository."`
+	CiPipelineScheduleCronTime int    `env:"CI_PIPELINE_SCHEDULE_CRON_TIME" envDefault:"1" description:"Interval in minutes at which the due cron schedules of ci and job pipelines are triggered"`
 }
 
 func GetCiTriggerCronConfig() (*CiTriggerCronConfig, error) {
@@ -111,3 +136,123 @@ func (impl *CiTriggerCronImpl) TriggerCiCron() {
 	}
 	return
 }
+
+// TriggerScheduledCi triggers the ci and job pipelines whose cron schedule is due
+func (impl *CiTriggerCronImpl) TriggerScheduledCi() {
+	schedules, err := impl.ciPipelineScheduleService.GetDueSchedules()
+	if err != nil {
+		return
+	}
+	for _, ciPipelineSchedule := range schedules {
+		claimed, err := impl.ciPipelineScheduleService.MarkTriggered(ciPipelineSchedule)
+		if err != nil || !claimed {
+			// either failed or already picked up by another replica
+			continue
+		}
+		err = impl.triggerScheduledCiPipeline(ciPipelineSchedule)
+		if err != nil {
+			impl.logger.Errorw("error in triggering scheduled ci pipeline", "ciPipelineId", ciPipelineSchedule.CiPipelineId, "err", err)
+		}
+	}
+}
+
+func (impl *CiTriggerCronImpl) triggerScheduledCiPipeline(ciPipelineSchedule *repository4.CiPipelineSchedule) error {
+	ciPipeline, err := impl.ciPipelineRepository.FindById(ciPipelineSchedule.CiPipelineId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching ci pipeline", "ciPipelineId", ciPipelineSchedule.CiPipelineId, "err", err)
+		return err
+	}
+	lastWorkflow, err := impl.ciWorkflowRepository.FindLastTriggeredWorkflow(ciPipeline.Id)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching last triggered workflow", "ciPipelineId", ciPipeline.Id, "err", err)
+		return err
+	}
+	hasLastWorkflow := err == nil
+	if ciPipelineSchedule.SkipIfRunning && hasLastWorkflow && isCiWorkflowInProgress(lastWorkflow) {
+		impl.logger.Infow("skipping scheduled trigger, last build is still in progress", "ciPipelineId", ciPipeline.Id, "ciWorkflowId", lastWorkflow.Id)
+		return nil
+	}
+	ciPipelineMaterials, err := impl.getHeadCiPipelineMaterials(ciPipeline)
+	if err != nil {
+		return err
+	}
+	if ciPipelineSchedule.SkipIfNoNewCommit && hasLastWorkflow && !hasNewCommit(ciPipelineMaterials, lastWorkflow) {
+		impl.logger.Infow("skipping scheduled trigger, no new commit since last build", "ciPipelineId", ciPipeline.Id, "ciWorkflowId", lastWorkflow.Id)
+		return nil
+	}
+	pipelineType := common.PipelineType(ciPipeline.PipelineType)
+	if len(pipelineType) == 0 {
+		pipelineType = common.DefaultPipelineType
+	}
+	ciTriggerRequest := bean.CiTriggerRequest{
+		PipelineId:         ciPipeline.Id,
+		CiPipelineMaterial: ciPipelineMaterials,
+		TriggeredBy:        bean2.SYSTEM_USER_ID,
+		InvalidateCache:    false,
+		PipelineType:       pipelineType.ToString(),
+	}
+	if pipelineType == common.CI_JOB {
+		ciEnvMapping, err := impl.ciPipelineRepository.FindCiEnvMappingByCiPipelineId(ciPipeline.Id)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching ci env mapping", "ciPipelineId", ciPipeline.Id, "err", err)
+			return err
+		}
+		ciTriggerRequest.EnvironmentId = ciEnvMapping.EnvironmentId
+	}
+	_, err = impl.ciHandlerService.HandleCIManual(ciTriggerRequest)
+	if err != nil {
+		return err
+	}
+	impl.logger.Infow("triggered scheduled ci pipeline", "ciPipelineId", ciPipeline.Id, "cronExpression", ciPipelineSchedule.CronExpression)
+	return nil
+}
+
+// getHeadCiPipelineMaterials returns the materials of the ci pipeline pointing to the latest commit of their branch
+func (impl *CiTriggerCronImpl) getHeadCiPipelineMaterials(ciPipeline *pipelineConfig.CiPipeline) ([]bean.CiPipelineMaterial, error) {
+	var materialIds []int
+	for _, material := range ciPipeline.CiPipelineMaterials {
+		if material.Type != constants.SOURCE_TYPE_BRANCH_FIXED {
+			return nil, fmt.Errorf("scheduled trigger is not supported for %s material %d", material.Type, material.Id)
+		}
+		materialIds = append(materialIds, material.Id)
+	}
+	if len(materialIds) == 0 {
+		return nil, nil
+	}
+	heads, err := impl.gitSensorClient.GetHeadForPipelineMaterials(context.Background(), &gitSensor.HeadRequest{MaterialIds: materialIds})
+	if err != nil {
+		impl.logger.Errorw("error in fetching head of ci pipeline materials", "ciPipelineId", ciPipeline.Id, "materialIds", materialIds, "err", err)
+		return nil, err
+	}
+	ciPipelineMaterials := make([]bean.CiPipelineMaterial, 0, len(heads))
+	for _, head := range heads {
+		if len(head.GitCommit.Commit) == 0 {
+			return nil, fmt.Errorf("no commit found for material %d", head.Id)
+		}
+		ciPipelineMaterials = append(ciPipelineMaterials, bean.CiPipelineMaterial{
+			Id:            head.Id,
+			GitMaterialId: head.GitMaterialId,
+			Type:          string(head.Type),
+			Value:         head.Value,
+			Active:        head.Active,
+			GitCommit:     pipelineConfig.GitCommit{Commit: head.GitCommit.Commit},
+		})
+	}
+	return ciPipelineMaterials, nil
+}
+
+func isCiWorkflowInProgress(ciWorkflow *pipelineConfig.CiWorkflow) bool {
+	return ciWorkflow.InProgress() || ciWorkflow.Status == string(v1alpha1.NodePending)
+}
+
+func hasNewCommit(ciPipelineMaterials []bean.CiPipelineMaterial, lastWorkflow *pipelineConfig.CiWorkflow) bool {
+	if len(ciPipelineMaterials) == 0 {
+		return true
+	}
+	for _, material := range ciPipelineMaterials {
+		if lastWorkflow.GitTriggers[material.Id].Commit != material.GitCommit.Commit {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/client/gitSensor"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	util2 "github.com/devtron-labs/devtron/internal/util"
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/bean"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule"
+	repository4 "github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/repository"
+	"github.com/devtron-labs/devtron/pkg/build/trigger"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+// ciPipelineScheduleRepositoryStub keeps the schedules in memory, claimedByOther marks the schedules
+// which another replica moves to their next trigger time between the lookup and the claim
+type ciPipelineScheduleRepositoryStub struct {
+	repository4.CiPipelineScheduleRepository
+	schedules      map[int]*repository4.CiPipelineSchedule
+	claimedByOther map[int]bool
+}
+
+func (impl *ciPipelineScheduleRepositoryStub) FindDue(dueOn time.Time) ([]*repository4.CiPipelineSchedule, error) {
+	dueSchedules := make([]*repository4.CiPipelineSchedule, 0)
+	for id := 1; id <= len(impl.schedules); id++ {
+		stored := impl.schedules[id]
+		if !stored.Active || stored.NextTriggerOn.After(dueOn) {
+			continue
+		}
+		fetched := *stored
+		dueSchedules = append(dueSchedules, &fetched)
+		if impl.claimedByOther[id] {
+			stored.NextTriggerOn = stored.NextTriggerOn.Add(time.Hour)
+		}
+	}
+	return dueSchedules, nil
+}
+
+func (impl *ciPipelineScheduleRepositoryStub) MarkTriggered(id int, dueOn time.Time, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error) {
+	stored := impl.schedules[id]
+	if !stored.Active || !stored.NextTriggerOn.Equal(dueOn) {
+		return false, nil
+	}
+	stored.NextTriggerOn = nextTriggerOn
+	stored.LastTriggeredOn = triggeredOn
+	return true, nil
+}
+
+type scheduledCiPipelineRepositoryStub struct {
+	pipelineConfig.CiPipelineRepository
+	ciPipeline *pipelineConfig.CiPipeline
+}
+
+func (impl *scheduledCiPipelineRepositoryStub) FindById(id int) (*pipelineConfig.CiPipeline, error) {
+	return impl.ciPipeline, nil
+}
+
+type scheduledCiWorkflowRepositoryStub struct {
+	pipelineConfig.CiWorkflowRepository
+	lastWorkflow *pipelineConfig.CiWorkflow
+}
+
+func (impl *scheduledCiWorkflowRepositoryStub) FindLastTriggeredWorkflow(pipelineId int) (*pipelineConfig.CiWorkflow, error) {
+	if impl.lastWorkflow == nil {
+		return nil, pg.ErrNoRows
+	}
+	return impl.lastWorkflow, nil
+}
+
+type gitSensorClientStub struct {
+	gitSensor.Client
+	headCommit string
+}
+
+func (impl *gitSensorClientStub) GetHeadForPipelineMaterials(ctx context.Context, req *gitSensor.HeadRequest) ([]*gitSensor.CiPipelineMaterial, error) {
+	heads := make([]*gitSensor.CiPipelineMaterial, 0, len(req.MaterialIds))
+	for _, materialId := range req.MaterialIds {
+		heads = append(heads, &gitSensor.CiPipelineMaterial{
+			Id:        materialId,
+			Type:      gitSensor.SourceType(constants.SOURCE_TYPE_BRANCH_FIXED),
+			Value:     "main",
+			Active:    true,
+			GitCommit: gitSensor.GitCommit{Commit: impl.headCommit},
+		})
+	}
+	return heads, nil
+}
+
+type ciHandlerServiceStub struct {
+	trigger.HandlerService
+	triggerRequests []bean.CiTriggerRequest
+}
+
+func (impl *ciHandlerServiceStub) HandleCIManual(ciTriggerRequest bean.CiTriggerRequest) (int, error) {
+	impl.triggerRequests = append(impl.triggerRequests, ciTriggerRequest)
+	return len(impl.triggerRequests), nil
+}
+
+func TestCiTriggerCronImpl_TriggerScheduledCi(t *testing.T) {
+	logger, err := util2.NewSugardLogger()
+	assert.NoError(t, err)
+	tests := []struct {
+		name              string
+		skipIfRunning     bool
+		skipIfNoNewCommit bool
+		materialType      constants.SourceType
+		lastWorkflow      *pipelineConfig.CiWorkflow
+		claimedByOther    bool
+		expectedTriggered bool
+	}{
+		{
+			name:              "due schedule is claimed and triggered at the head commit",
+			materialType:      constants.SOURCE_TYPE_BRANCH_FIXED,
+			expectedTriggered: true,
+		},
+		{
+			name:           "schedule claimed by another replica is not triggered again",
+			materialType:   constants.SOURCE_TYPE_BRANCH_FIXED,
+			claimedByOther: true,
+		},
+		{
+			name:          "skipped while the last build is still running",
+			skipIfRunning: true,
+			materialType:  constants.SOURCE_TYPE_BRANCH_FIXED,
+			lastWorkflow:  &pipelineConfig.CiWorkflow{Id: 7, Status: "Running"},
+		},
+		{
+			name:              "running build does not block the trigger unless requested",
+			materialType:      constants.SOURCE_TYPE_BRANCH_FIXED,
+			lastWorkflow:      &pipelineConfig.CiWorkflow{Id: 7, Status: "Running"},
+			expectedTriggered: true,
+		},
+		{
+			name:              "skipped when there is no new commit since the last build",
+			skipIfNoNewCommit: true,
+			materialType:      constants.SOURCE_TYPE_BRANCH_FIXED,
+			lastWorkflow:      &pipelineConfig.CiWorkflow{Id: 7, Status: "Succeeded", GitTriggers: map[int]pipelineConfig.GitCommit{3: {Commit: "a1b2c3"}}},
+		},
+		{
+			name:              "triggered when the branch moved since the last build",
+			skipIfNoNewCommit: true,
+			materialType:      constants.SOURCE_TYPE_BRANCH_FIXED,
+			lastWorkflow:      &pipelineConfig.CiWorkflow{Id: 7, Status: "Succeeded", GitTriggers: map[int]pipelineConfig.GitCommit{3: {Commit: "f0e1d2"}}},
+			expectedTriggered: true,
+		},
+		{
+			name:         "webhook material cannot be triggered on a schedule",
+			materialType: constants.SOURCE_TYPE_WEBHOOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dueOn := time.Now().Add(-time.Minute).UTC()
+			scheduleRepository := &ciPipelineScheduleRepositoryStub{
+				schedules: map[int]*repository4.CiPipelineSchedule{
+					1: {Id: 1, CiPipelineId: 10, CronExpression: "0 * * * *", Timezone: "UTC", Active: true, NextTriggerOn: dueOn,
+						SkipIfRunning: tt.skipIfRunning, SkipIfNoNewCommit: tt.skipIfNoNewCommit},
+				},
+				claimedByOther: map[int]bool{1: tt.claimedByOther},
+			}
+			ciHandlerService := &ciHandlerServiceStub{}
+			impl := &CiTriggerCronImpl{
+				logger:                    logger,
+				ciHandlerService:          ciHandlerService,
+				ciPipelineScheduleService: schedule.NewCiPipelineScheduleServiceImpl(logger, scheduleRepository),
+				ciPipelineRepository: &scheduledCiPipelineRepositoryStub{ciPipeline: &pipelineConfig.CiPipeline{
+					Id:                  10,
+					PipelineType:        "CI_BUILD",
+					CiPipelineMaterials: []*pipelineConfig.CiPipelineMaterial{{Id: 3, Type: tt.materialType, Value: "main"}},
+				}},
+				ciWorkflowRepository: &scheduledCiWorkflowRepositoryStub{lastWorkflow: tt.lastWorkflow},
+				gitSensorClient:      &gitSensorClientStub{headCommit: "a1b2c3"},
+			}
+			impl.TriggerScheduledCi()
+			// the next run of the cron finds nothing due, so the pipeline is triggered at most once
+			impl.TriggerScheduledCi()
+			if !tt.expectedTriggered {
+				assert.Empty(t, ciHandlerService.triggerRequests)
+				return
+			}
+			assert.Len(t, ciHandlerService.triggerRequests, 1)
+			triggerRequest := ciHandlerService.triggerRequests[0]
+			assert.Equal(t, 10, triggerRequest.PipelineId)
+			assert.EqualValues(t, bean2.SYSTEM_USER_ID, triggerRequest.TriggeredBy)
+			assert.Equal(t, "a1b2c3", triggerRequest.CiPipelineMaterial[0].GitCommit.Commit)
+			assert.True(t, scheduleRepository.schedules[1].NextTriggerOn.After(time.Now()))
+		})
+	}
+}
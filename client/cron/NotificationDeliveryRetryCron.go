@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	client "github.com/devtron-labs/devtron/client/events"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"time"
+)
+
+type NotificationDeliveryRetryCron interface {
+	RetryNotificationDeliveries()
+}
+
+type NotificationDeliveryRetryCronImpl struct {
+	logger          *zap.SugaredLogger
+	cron            *cron.Cron
+	cfg             *NotificationDeliveryRetryCronConfig
+	deliveryService client.NotificationDeliveryService
+}
+
+func NewNotificationDeliveryRetryCronImpl(logger *zap.SugaredLogger, cfg *NotificationDeliveryRetryCronConfig, cronLogger *cron2.CronLoggerImpl,
+	deliveryService client.NotificationDeliveryService) *NotificationDeliveryRetryCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &NotificationDeliveryRetryCronImpl{
+		logger:          logger,
+		cron:            cron,
+		cfg:             cfg,
+		deliveryService: deliveryService,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.RetryCronTimeInMins), impl.RetryNotificationDeliveries)
+	if err != nil {
+		logger.Errorw("error while configure cron job for notification delivery retry", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type NotificationDeliveryRetryCronConfig struct {
+	RetryCronTimeInMins int `env:"NOTIFICATION_DELIVERY_RETRY_CRON_TIME" envDefault:"1" description:"Interval in minutes at which failed notification deliveries due for retry are redelivered"`
+	LogRetentionInDays  int `env:"NOTIFICATION_DELIVERY_LOG_RETENTION_DAYS" envDefault:"30" description:"Number of days for which logs of succeeded notification deliveries are retained"`
+}
+
+func GetNotificationDeliveryRetryCronConfig() (*NotificationDeliveryRetryCronConfig, error) {
+	cfg := &NotificationDeliveryRetryCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse notification delivery retry cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *NotificationDeliveryRetryCronImpl) RetryNotificationDeliveries() {
+	impl.deliveryService.RetryFailedDeliveries()
+	if impl.cfg.LogRetentionInDays > 0 {
+		impl.deliveryService.DeleteSucceededDeliveries(time.Now().AddDate(0, 0, -impl.cfg.LogRetentionInDays))
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/caarlos0/env"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"sort"
+	"time"
+)
+
+type NotificationDigestCron interface {
+	FlushNotificationDigests()
+}
+
+type NotificationDigestCronImpl struct {
+	logger                         *zap.SugaredLogger
+	cron                           *cron.Cron
+	cfg                            *NotificationDigestCronConfig
+	digestEventRepository          repository.NotificationDigestEventRepository
+	notificationSettingsRepository repository.NotificationSettingsRepository
+	eventClient                    client.EventClient
+}
+
+func NewNotificationDigestCronImpl(logger *zap.SugaredLogger, cfg *NotificationDigestCronConfig, cronLogger *cron2.CronLoggerImpl,
+	digestEventRepository repository.NotificationDigestEventRepository,
+	notificationSettingsRepository repository.NotificationSettingsRepository,
+	eventClient client.EventClient) *NotificationDigestCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &NotificationDigestCronImpl{
+		logger:                         logger,
+		cron:                           cron,
+		cfg:                            cfg,
+		digestEventRepository:          digestEventRepository,
+		notificationSettingsRepository: notificationSettingsRepository,
+		eventClient:                    eventClient,
+	}
+
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.DigestCronTimeInMins), impl.FlushNotificationDigests)
+	if err != nil {
+		logger.Errorw("error while configure cron job for notification digest", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type NotificationDigestCronConfig struct {
+	DigestCronTimeInMins  int `env:"NOTIFICATION_DIGEST_CRON_TIME" envDefault:"1" description:"Interval in minutes at which pending notification digests are checked and sent"`
+	DigestRetentionInDays int `env:"NOTIFICATION_DIGEST_RETENTION_DAYS" envDefault:"7" description:"Number of days for which events already sent in a digest are retained"`
+	DigestStaleTimeInMins int `env:"NOTIFICATION_DIGEST_STALE_TIME" envDefault:"10" description:"Minutes after which digest events claimed by an instance which stopped before sending them are picked up again"`
+}
+
+func GetNotificationDigestCronConfig() (*NotificationDigestCronConfig, error) {
+	cfg := &NotificationDigestCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse notification digest cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// FlushNotificationDigests sends one summarised message per notification setting whose digest interval has elapsed
+// since its oldest pending event, events of deleted settings are discarded.
+// The cron runs on every instance, the events of a digest are claimed before sending so that it is sent only once.
+func (impl *NotificationDigestCronImpl) FlushNotificationDigests() {
+	staleBefore := time.Now().Add(-time.Duration(impl.cfg.DigestStaleTimeInMins) * time.Minute)
+	pendingEvents, err := impl.digestEventRepository.FindAllPending(staleBefore)
+	if err != nil {
+		impl.logger.Errorw("error while fetching pending digest events", "err", err)
+		return
+	}
+	if len(pendingEvents) == 0 {
+		return
+	}
+	eventsByViewId := make(map[int][]*repository.NotificationDigestEvent)
+	viewIds := make([]*int, 0)
+	for _, pendingEvent := range pendingEvents {
+		if _, ok := eventsByViewId[pendingEvent.ViewId]; !ok {
+			viewId := pendingEvent.ViewId
+			viewIds = append(viewIds, &viewId)
+		}
+		eventsByViewId[pendingEvent.ViewId] = append(eventsByViewId[pendingEvent.ViewId], pendingEvent)
+	}
+	views, err := impl.notificationSettingsRepository.FindNotificationSettingsViewByIds(viewIds)
+	if err != nil {
+		impl.logger.Errorw("error while fetching notification settings views for digest", "viewIds", viewIds, "err", err)
+		return
+	}
+	viewsById := make(map[int]*repository.NotificationSettingsView, len(views))
+	for _, view := range views {
+		viewsById[view.Id] = view
+	}
+
+	now := time.Now()
+	for viewId, digestEvents := range eventsByViewId {
+		view, ok := viewsById[viewId]
+		if !ok {
+			impl.logger.Infow("notification setting deleted, discarding pending digest events", "viewId", viewId, "count", len(digestEvents))
+			impl.markFlushed(digestEvents, now)
+			continue
+		}
+		nsConfig := &beans.NSConfig{}
+		if err = json.Unmarshal([]byte(view.Config), nsConfig); err != nil {
+			impl.logger.Errorw("error while unmarshalling notification settings view config", "viewId", viewId, "err", err)
+			continue
+		}
+		// events are ordered by creation, a setting switched back to immediate delivery is flushed right away
+		windowStart := digestEvents[0].CreatedOn
+		if nsConfig.IsDigest() && windowStart.Add(time.Duration(nsConfig.DigestIntervalInMins)*time.Minute).After(now) {
+			continue
+		}
+		claimedEvents, err := impl.digestEventRepository.ClaimForSending(getDigestEventIds(digestEvents), staleBefore)
+		if err != nil {
+			impl.logger.Errorw("error while claiming digest events", "viewId", viewId, "err", err)
+			continue
+		}
+		if len(claimedEvents) == 0 {
+			// claimed by another instance
+			continue
+		}
+		sort.Slice(claimedEvents, func(i, j int) bool {
+			return claimedEvents[i].CreatedOn.Before(claimedEvents[j].CreatedOn)
+		})
+		err = impl.sendDigest(view, nsConfig, claimedEvents, windowStart, now)
+		if err != nil {
+			impl.logger.Errorw("error while sending notification digest, will be retried", "viewId", viewId, "err", err)
+			if err = impl.digestEventRepository.ReleaseClaim(getDigestEventIds(claimedEvents)); err != nil {
+				impl.logger.Errorw("error while releasing claim of digest events", "viewId", viewId, "err", err)
+			}
+			continue
+		}
+		impl.markFlushed(claimedEvents, now)
+	}
+
+	if impl.cfg.DigestRetentionInDays > 0 {
+		deleted, err := impl.digestEventRepository.DeleteFlushedBefore(now.AddDate(0, 0, -impl.cfg.DigestRetentionInDays))
+		if err != nil {
+			impl.logger.Errorw("error while deleting sent digest events", "err", err)
+			return
+		}
+		impl.logger.Debugw("deleted sent digest events", "count", deleted)
+	}
+}
+
+func (impl *NotificationDigestCronImpl) sendDigest(view *repository.NotificationSettingsView, nsConfig *beans.NSConfig,
+	digestEvents []*repository.NotificationDigestEvent, windowStart, windowEnd time.Time) error {
+	events := make([]client.Event, 0, len(digestEvents))
+	for _, digestEvent := range digestEvents {
+		event := client.Event{}
+		if err := json.Unmarshal([]byte(digestEvent.Event), &event); err != nil {
+			impl.logger.Errorw("error while unmarshalling digest event, skipping", "id", digestEvent.Id, "err", err)
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	config := make([]repository.ConfigEntry, 0, len(nsConfig.Providers))
+	for _, provider := range nsConfig.Providers {
+		config = append(config, repository.ConfigEntry{
+			Dest:      provider.Destination.String(),
+			Rule:      provider.Rule,
+			ConfigId:  provider.ConfigId,
+			Recipient: provider.Recipient,
+		})
+	}
+	digestEvent := client.BuildDigestEvent(string(nsConfig.PipelineType), events, windowStart, windowEnd)
+	notificationSettings := []*repository.NotificationSettingsBean{
+		{
+			ViewId:       view.Id,
+			PipelineType: string(nsConfig.PipelineType),
+			EventTypeId:  int(util.Digest),
+			Config:       config,
+		},
+	}
+	_, err := impl.eventClient.SendDigestNotificationEvent(digestEvent, notificationSettings)
+	return err
+}
+
+func getDigestEventIds(digestEvents []*repository.NotificationDigestEvent) []int {
+	ids := make([]int, 0, len(digestEvents))
+	for _, digestEvent := range digestEvents {
+		ids = append(ids, digestEvent.Id)
+	}
+	return ids
+}
+
+func (impl *NotificationDigestCronImpl) markFlushed(digestEvents []*repository.NotificationDigestEvent, flushedOn time.Time) {
+	ids := getDigestEventIds(digestEvents)
+	if err := impl.digestEventRepository.MarkFlushed(ids, flushedOn); err != nil {
+		impl.logger.Errorw("error while marking digest events as sent", "ids", ids, "err", err)
+	}
+}
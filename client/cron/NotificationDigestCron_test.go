@@ -0,0 +1,199 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/client/events/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	util2 "github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+)
+
+// digestEventRepositoryStub keeps the digest events in memory, claimedByOther marks the events
+// which another instance claims between the lookup and the claim
+type digestEventRepositoryStub struct {
+	repository.NotificationDigestEventRepository
+	events         map[int]*repository.NotificationDigestEvent
+	claimedByOther map[int]bool
+}
+
+func (impl *digestEventRepositoryStub) FindAllPending(staleBefore time.Time) ([]*repository.NotificationDigestEvent, error) {
+	pendingEvents := make([]*repository.NotificationDigestEvent, 0)
+	for id := 1; id <= len(impl.events); id++ {
+		if event := impl.events[id]; isDigestEventClaimable(event, staleBefore) {
+			pendingEvents = append(pendingEvents, event)
+		}
+	}
+	return pendingEvents, nil
+}
+
+func (impl *digestEventRepositoryStub) ClaimForSending(ids []int, staleBefore time.Time) ([]*repository.NotificationDigestEvent, error) {
+	claimedEvents := make([]*repository.NotificationDigestEvent, 0)
+	for _, id := range ids {
+		if impl.claimedByOther[id] {
+			impl.events[id].Status = repository.NotificationDigestEventSending
+			impl.events[id].ClaimedOn = time.Now()
+		}
+		if event := impl.events[id]; isDigestEventClaimable(event, staleBefore) {
+			event.Status = repository.NotificationDigestEventSending
+			event.ClaimedOn = time.Now()
+			claimedEvents = append(claimedEvents, event)
+		}
+	}
+	return claimedEvents, nil
+}
+
+func (impl *digestEventRepositoryStub) ReleaseClaim(ids []int) error {
+	for _, id := range ids {
+		impl.events[id].Status = repository.NotificationDigestEventPending
+	}
+	return nil
+}
+
+func (impl *digestEventRepositoryStub) MarkFlushed(ids []int, flushedOn time.Time) error {
+	for _, id := range ids {
+		impl.events[id].Status = repository.NotificationDigestEventSent
+		impl.events[id].FlushedOn = flushedOn
+	}
+	return nil
+}
+
+func (impl *digestEventRepositoryStub) DeleteFlushedBefore(flushedBefore time.Time) (int, error) {
+	return 0, nil
+}
+
+func isDigestEventClaimable(event *repository.NotificationDigestEvent, staleBefore time.Time) bool {
+	return event.Status == repository.NotificationDigestEventPending ||
+		(event.Status == repository.NotificationDigestEventSending && event.ClaimedOn.Before(staleBefore))
+}
+
+type notificationSettingsRepositoryStub struct {
+	repository.NotificationSettingsRepository
+	views []*repository.NotificationSettingsView
+}
+
+func (impl *notificationSettingsRepositoryStub) FindNotificationSettingsViewByIds(ids []*int) ([]*repository.NotificationSettingsView, error) {
+	return impl.views, nil
+}
+
+type digestEventClientStub struct {
+	client.EventClient
+	sendErr error
+	digests []client.Event
+}
+
+func (impl *digestEventClientStub) SendDigestNotificationEvent(event client.Event, notificationSettingsBean []*repository.NotificationSettingsBean) (bool, error) {
+	impl.digests = append(impl.digests, event)
+	return impl.sendErr == nil, impl.sendErr
+}
+
+func getDigestSettingsView(t *testing.T, viewId int, digestIntervalInMins int) *repository.NotificationSettingsView {
+	config, err := json.Marshal(&beans.NSConfig{
+		PipelineType:         util.CD,
+		DeliveryMode:         beans.DeliveryModeDigest,
+		DigestIntervalInMins: digestIntervalInMins,
+		Providers:            []*bean.Provider{{Destination: util.Slack, ConfigId: 1}},
+	})
+	assert.NoError(t, err)
+	return &repository.NotificationSettingsView{Id: viewId, Config: string(config)}
+}
+
+func getPendingDigestEvents(t *testing.T, viewId int, createdOn time.Time, count int) map[int]*repository.NotificationDigestEvent {
+	events := make(map[int]*repository.NotificationDigestEvent, count)
+	for id := 1; id <= count; id++ {
+		event, err := json.Marshal(client.Event{
+			EventTypeId:  int(util.Fail),
+			PipelineType: string(util.CD),
+			PipelineId:   1,
+			Payload:      &client.Payload{AppName: "payments", PipelineName: "cd-prod"},
+		})
+		assert.NoError(t, err)
+		events[id] = &repository.NotificationDigestEvent{
+			Id:        id,
+			ViewId:    viewId,
+			Event:     string(event),
+			Status:    repository.NotificationDigestEventPending,
+			CreatedOn: createdOn.Add(time.Duration(id) * time.Second),
+		}
+	}
+	return events
+}
+
+func TestNotificationDigestCronImpl_FlushNotificationDigests(t *testing.T) {
+	logger, err := util2.NewSugardLogger()
+	assert.NoError(t, err)
+	cfg := &NotificationDigestCronConfig{DigestStaleTimeInMins: 10}
+	tests := []struct {
+		name               string
+		views              []*repository.NotificationSettingsView
+		createdOn          time.Time
+		claimedByOther     map[int]bool
+		sendErr            error
+		expectedDigests    int
+		expectedDigestSize int
+		expectedStatus     repository.NotificationDigestEventStatus
+	}{
+		{
+			name:               "events past the digest interval are claimed, sent as one digest and marked sent",
+			views:              []*repository.NotificationSettingsView{getDigestSettingsView(t, 1, 30)},
+			createdOn:          time.Now().Add(-time.Hour),
+			expectedDigests:    1,
+			expectedDigestSize: 3,
+			expectedStatus:     repository.NotificationDigestEventSent,
+		},
+		{
+			name:           "events within the digest interval are not claimed",
+			views:          []*repository.NotificationSettingsView{getDigestSettingsView(t, 1, 30)},
+			createdOn:      time.Now(),
+			expectedStatus: repository.NotificationDigestEventPending,
+		},
+		{
+			name:           "events claimed by another instance are not sent again",
+			views:          []*repository.NotificationSettingsView{getDigestSettingsView(t, 1, 30)},
+			createdOn:      time.Now().Add(-time.Hour),
+			claimedByOther: map[int]bool{1: true, 2: true, 3: true},
+			expectedStatus: repository.NotificationDigestEventSending,
+		},
+		{
+			name:               "claim is released when the digest cannot be sent",
+			views:              []*repository.NotificationSettingsView{getDigestSettingsView(t, 1, 30)},
+			createdOn:          time.Now().Add(-time.Hour),
+			sendErr:            errors.New("notifier unavailable"),
+			expectedDigests:    1,
+			expectedDigestSize: 3,
+			expectedStatus:     repository.NotificationDigestEventPending,
+		},
+		{
+			name:           "events of a deleted setting are discarded without sending",
+			createdOn:      time.Now().Add(-time.Hour),
+			expectedStatus: repository.NotificationDigestEventSent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digestEventRepository := &digestEventRepositoryStub{events: getPendingDigestEvents(t, 1, tt.createdOn, 3), claimedByOther: tt.claimedByOther}
+			eventClient := &digestEventClientStub{sendErr: tt.sendErr}
+			impl := &NotificationDigestCronImpl{
+				logger:                         logger,
+				cfg:                            cfg,
+				digestEventRepository:          digestEventRepository,
+				notificationSettingsRepository: &notificationSettingsRepositoryStub{views: tt.views},
+				eventClient:                    eventClient,
+			}
+			impl.FlushNotificationDigests()
+			assert.Len(t, eventClient.digests, tt.expectedDigests)
+			if tt.expectedDigests > 0 {
+				assert.Equal(t, tt.expectedDigestSize, eventClient.digests[0].Payload.DigestTotal)
+			}
+			for _, event := range digestEventRepository.events {
+				assert.Equal(t, tt.expectedStatus, event.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cron
+
+import (
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/pkg/workflow/tektonStatus"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type TektonWorkflowStatusCron interface {
+	SyncTektonWorkflowStatus()
+}
+
+type TektonWorkflowStatusCronImpl struct {
+	logger                      *zap.SugaredLogger
+	cron                        *cron.Cron
+	tektonWorkflowStatusService tektonStatus.TektonWorkflowStatusService
+}
+
+func NewTektonWorkflowStatusCronImpl(logger *zap.SugaredLogger, cfg *TektonWorkflowStatusCronConfig, cronLogger *cron2.CronLoggerImpl,
+	tektonWorkflowStatusService tektonStatus.TektonWorkflowStatusService) *TektonWorkflowStatusCronImpl {
+	cron := cron.New(
+		cron.WithChain(cron.SkipIfStillRunning(cronLogger), cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &TektonWorkflowStatusCronImpl{
+		logger:                      logger,
+		cron:                        cron,
+		tektonWorkflowStatusService: tektonWorkflowStatusService,
+	}
+
+	// execute periodically, kubewatch does not publish the status of tekton pipeline runs
+	_, err := cron.AddFunc(fmt.Sprintf("@every %ds", cfg.SyncCronTimeInSecs), impl.SyncTektonWorkflowStatus)
+	if err != nil {
+		logger.Errorw("error while configure cron job for tekton workflow status sync", "err", err)
+		return impl
+	}
+	return impl
+}
+
+type TektonWorkflowStatusCronConfig struct {
+	SyncCronTimeInSecs int `env:"TEKTON_WORKFLOW_STATUS_SYNC_CRON_TIME" envDefault:"30" description:"Interval in seconds at which the status of the workflows executed by tekton is synced"`
+}
+
+func GetTektonWorkflowStatusCronConfig() (*TektonWorkflowStatusCronConfig, error) {
+	cfg := &TektonWorkflowStatusCronConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse tekton workflow status cron config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (impl *TektonWorkflowStatusCronImpl) SyncTektonWorkflowStatus() {
+	impl.tektonWorkflowStatusService.SyncActiveWorkflowsStatus()
+}
@@ -119,6 +119,7 @@ func (impl *EventSimpleFactoryImpl) BuildExtraCDData(event Event, wfr *pipelineC
 		event.Payload = payload
 		event.CdWorkflowRunnerId = wfr.Id
 		event.CiArtifactId = wfr.CdWorkflow.CiArtifactId
+		event.DurationInSecs = getDurationInSecs(event, wfr.StartedOn, wfr.FinishedOn)
 	} else if pipelineOverrideId > 0 {
 		pipelineOverride, err := impl.pipelineOverrideRepository.FindById(pipelineOverrideId)
 		if err != nil {
@@ -136,6 +137,7 @@ func (impl *EventSimpleFactoryImpl) BuildExtraCDData(event Event, wfr *pipelineC
 			if wfr.Id > 0 {
 				event.CdWorkflowRunnerId = wfr.Id
 				event.CiArtifactId = pipelineOverride.CiArtifactId
+				event.DurationInSecs = getDurationInSecs(event, wfr.StartedOn, wfr.FinishedOn)
 
 				material, err := impl.getCiMaterialInfo(pipelineOverride.CiArtifact.PipelineId, pipelineOverride.CiArtifactId)
 				if err != nil {
@@ -197,6 +199,15 @@ func (impl *EventSimpleFactoryImpl) BuildExtraCIData(event Event, material *buil
 	}
 	event.Payload.MaterialTriggerInfo = material
 
+	if event.CiWorkflowRunnerId > 0 && util.EventType(event.EventTypeId) != util.Trigger {
+		ciWorkflow, err := impl.ciWorkflowRepository.FindById(event.CiWorkflowRunnerId)
+		if err != nil {
+			impl.logger.Errorw("found error on payload build for ci, skipping this error ", "ciWorkflowId", event.CiWorkflowRunnerId, "err", err)
+		} else {
+			event.DurationInSecs = getDurationInSecs(event, ciWorkflow.StartedOn, ciWorkflow.FinishedOn)
+		}
+	}
+
 	if event.UserId > 0 {
 		user, err := impl.userRepository.GetById(int32(event.UserId))
 		if err != nil {
@@ -229,6 +240,17 @@ func (impl *EventSimpleFactoryImpl) BuildExtraCIData(event Event, material *buil
 	return event
 }
 
+// getDurationInSecs returns the time taken by the workflow till the event, for a running workflow it is the time elapsed so far
+func getDurationInSecs(event Event, startedOn, finishedOn time.Time) int {
+	if util.EventType(event.EventTypeId) == util.Trigger || startedOn.IsZero() {
+		return 0
+	}
+	if finishedOn.IsZero() || finishedOn.Before(startedOn) {
+		finishedOn = time.Now()
+	}
+	return int(finishedOn.Sub(startedOn).Seconds())
+}
+
 func (impl *EventSimpleFactoryImpl) getCiMaterialInfo(ciPipelineId int, ciArtifactId int) (*buildBean.MaterialTriggerInfo, error) {
 	materialTriggerInfo := &buildBean.MaterialTriggerInfo{}
 	if ciPipelineId > 0 {
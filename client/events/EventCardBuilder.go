@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devtron-labs/devtron/api/bean"
+	eventBean "github.com/devtron-labs/devtron/client/events/bean"
+	util "github.com/devtron-labs/devtron/util/event"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+	googleChatCardId        = "devtron-event"
+)
+
+// cardContent is the channel agnostic content of a notification card, rendered
+// into the channel specific format by BuildTeamsCard and BuildGoogleChatCard
+type cardContent struct {
+	title    string
+	subtitle string
+	status   util.EventType
+	facts    []*eventBean.AdaptiveCardFact
+	links    []*eventBean.AdaptiveCardAction
+}
+
+// BuildTeamsCard renders the event as a Microsoft Teams message with an Adaptive Card attachment
+func BuildTeamsCard(event Event) *eventBean.TeamsMessage {
+	content := buildCardContent(event)
+	body := []*eventBean.AdaptiveCardElement{
+		{
+			Type:   "TextBlock",
+			Text:   content.title,
+			Weight: "Bolder",
+			Size:   "Medium",
+			Color:  getAdaptiveCardColor(content.status),
+			Wrap:   true,
+		},
+	}
+	if len(content.subtitle) > 0 {
+		body = append(body, &eventBean.AdaptiveCardElement{
+			Type: "TextBlock",
+			Text: content.subtitle,
+			Wrap: true,
+		})
+	}
+	body = append(body, &eventBean.AdaptiveCardElement{
+		Type:  "FactSet",
+		Facts: content.facts,
+	})
+	return &eventBean.TeamsMessage{
+		Type: "message",
+		Attachments: []*eventBean.TeamsAttachment{
+			{
+				ContentType: adaptiveCardContentType,
+				Content: &eventBean.AdaptiveCard{
+					Schema:  adaptiveCardSchema,
+					Type:    "AdaptiveCard",
+					Version: adaptiveCardVersion,
+					Body:    body,
+					Actions: content.links,
+				},
+			},
+		},
+	}
+}
+
+// BuildGoogleChatCard renders the event as a Google Chat cards v2 message
+func BuildGoogleChatCard(event Event) *eventBean.GoogleChatMessage {
+	content := buildCardContent(event)
+	widgets := make([]*eventBean.GoogleChatWidget, 0, len(content.facts)+1)
+	for _, fact := range content.facts {
+		widgets = append(widgets, &eventBean.GoogleChatWidget{
+			DecoratedText: &eventBean.GoogleChatDecoratedText{
+				TopLabel: fact.Title,
+				Text:     fact.Value,
+				WrapText: true,
+			},
+		})
+	}
+	if len(content.links) > 0 {
+		buttons := make([]*eventBean.GoogleChatButton, 0, len(content.links))
+		for _, link := range content.links {
+			buttons = append(buttons, &eventBean.GoogleChatButton{
+				Text:    link.Title,
+				OnClick: &eventBean.GoogleChatOnClick{OpenLink: &eventBean.GoogleChatOpenLink{Url: link.Url}},
+			})
+		}
+		widgets = append(widgets, &eventBean.GoogleChatWidget{
+			ButtonList: &eventBean.GoogleChatButtonList{Buttons: buttons},
+		})
+	}
+	return &eventBean.GoogleChatMessage{
+		CardsV2: []*eventBean.GoogleChatCardWithId{
+			{
+				CardId: googleChatCardId,
+				Card: &eventBean.GoogleChatCard{
+					Header: &eventBean.GoogleChatCardHeader{
+						Title:    content.title,
+						Subtitle: content.subtitle,
+					},
+					Sections: []*eventBean.GoogleChatSection{{Widgets: widgets}},
+				},
+			},
+		},
+	}
+}
+
+func buildCardContent(event Event) *cardContent {
+	payload := event.Payload
+	if payload == nil {
+		payload = &Payload{}
+	}
+	if util.EventType(event.EventTypeId) == util.Digest {
+		return buildDigestCardContent(event, payload)
+	}
+	content := &cardContent{
+		status: util.EventType(event.EventTypeId),
+		title:  fmt.Sprintf("%s %s", getCardSubject(event), getCardAction(util.EventType(event.EventTypeId))),
+	}
+	if len(payload.AppName) > 0 {
+		content.subtitle = payload.AppName
+		if len(payload.EnvName) > 0 {
+			content.subtitle = fmt.Sprintf("%s · %s", payload.AppName, payload.EnvName)
+		}
+	}
+	content.facts = appendFact(content.facts, "Application", payload.AppName)
+	content.facts = appendFact(content.facts, "Pipeline", payload.PipelineName)
+	content.facts = appendFact(content.facts, "Environment", payload.EnvName)
+	if event.PipelineType == string(util.CD) {
+		content.facts = appendFact(content.facts, "Stage", payload.Stage)
+		content.facts = appendFact(content.facts, "Image", payload.DockerImageUrl)
+	}
+	content.facts = appendFact(content.facts, "Triggered by", payload.TriggeredBy)
+	content.facts = appendFact(content.facts, "Time", event.EventTime)
+	if util.EventType(event.EventTypeId) == util.Fail {
+		content.facts = appendFact(content.facts, "Failure reason", payload.FailureReason)
+	}
+	if util.EventType(event.EventTypeId) == util.GitOpsDrift {
+		content.facts = appendFact(content.facts, "Out-of-band change in", payload.DriftedIn)
+		content.facts = appendFact(content.facts, "GitOps commit", payload.HeadCommitHash)
+	}
+
+	if event.PipelineType == string(util.CI) {
+		content.links = appendLink(content.links, "View build", event.BaseUrl, payload.BuildHistoryLink)
+	} else if event.PipelineType == string(util.CD) {
+		// drift is not caused by a deployment, so there is no deployment to link to
+		if util.EventType(event.EventTypeId) != util.GitOpsDrift {
+			content.links = appendLink(content.links, "View deployment", event.BaseUrl, payload.DeploymentHistoryLink)
+		}
+		content.links = appendLink(content.links, "App details", event.BaseUrl, payload.AppDetailLink)
+	}
+	return content
+}
+
+func buildDigestCardContent(event Event, payload *Payload) *cardContent {
+	content := &cardContent{
+		status:   util.Digest,
+		title:    fmt.Sprintf("%s digest: %d events", getCardSubject(event), payload.DigestTotal),
+		subtitle: payload.DigestWindow,
+	}
+	for _, item := range payload.DigestItems {
+		if item.Status == getCardAction(util.Fail) {
+			content.status = util.Fail
+		}
+		title := item.AppName
+		if len(item.PipelineName) > 0 {
+			title = fmt.Sprintf("%s · %s", title, item.PipelineName)
+		}
+		if len(item.EnvName) > 0 {
+			title = fmt.Sprintf("%s · %s", title, item.EnvName)
+		}
+		content.facts = appendFact(content.facts, title, fmt.Sprintf("%s ×%d (last at %s)", item.Status, item.Count, item.LastEventTime))
+	}
+	return content
+}
+
+func getCardSubject(event Event) string {
+	if event.PipelineType == string(util.CI) {
+		return "Build pipeline"
+	}
+	switch event.CdWorkflowType {
+	case bean.CD_WORKFLOW_TYPE_PRE:
+		return "Pre-deployment"
+	case bean.CD_WORKFLOW_TYPE_POST:
+		return "Post-deployment"
+	default:
+		return "Deployment pipeline"
+	}
+}
+
+func getCardAction(eventType util.EventType) string {
+	switch eventType {
+	case util.Trigger:
+		return "triggered"
+	case util.Success:
+		return "succeeded"
+	case util.Fail:
+		return "failed"
+	case util.GitOpsDrift:
+		return "drifted"
+	default:
+		return "updated"
+	}
+}
+
+func getAdaptiveCardColor(eventType util.EventType) string {
+	switch eventType {
+	case util.Success:
+		return "Good"
+	case util.Fail:
+		return "Attention"
+	case util.GitOpsDrift:
+		return "Warning"
+	default:
+		return "Accent"
+	}
+}
+
+func appendFact(facts []*eventBean.AdaptiveCardFact, title, value string) []*eventBean.AdaptiveCardFact {
+	if len(value) == 0 {
+		return facts
+	}
+	return append(facts, &eventBean.AdaptiveCardFact{Title: title, Value: value})
+}
+
+func appendLink(links []*eventBean.AdaptiveCardAction, title, baseUrl, path string) []*eventBean.AdaptiveCardAction {
+	if len(path) == 0 || len(baseUrl) == 0 {
+		return links
+	}
+	return append(links, &eventBean.AdaptiveCardAction{
+		Type:  "Action.OpenUrl",
+		Title: title,
+		Url:   strings.TrimSuffix(baseUrl, "/") + path,
+	})
+}
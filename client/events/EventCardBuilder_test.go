@@ -0,0 +1,110 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/api/bean"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func getCardTestEvent(eventType util.EventType) Event {
+	return Event{
+		EventTypeId:    int(eventType),
+		PipelineType:   string(util.CD),
+		CdWorkflowType: bean.CD_WORKFLOW_TYPE_DEPLOY,
+		EventTime:      "2024-05-09T12:00:00Z",
+		BaseUrl:        "https://devtron.example.com/",
+		Payload: &Payload{
+			AppName:               "payments",
+			EnvName:               "prod",
+			PipelineName:          "cd-prod",
+			DockerImageUrl:        "registry/payments:abc123",
+			TriggeredBy:           "admin@example.com",
+			DeploymentHistoryLink: "/dashboard/app/1/cd-details/2/3/4/source-code",
+			AppDetailLink:         "/dashboard/app/1/details/2/pod",
+			FailureReason:         "pods crash looping",
+		},
+	}
+}
+
+func TestBuildTeamsCard(t *testing.T) {
+	t.Run("failed deployment renders attention title, facts and links", func(t *testing.T) {
+		message := BuildTeamsCard(getCardTestEvent(util.Fail))
+		assert.Equal(t, "message", message.Type)
+		assert.Len(t, message.Attachments, 1)
+		card := message.Attachments[0].Content
+		assert.Equal(t, adaptiveCardContentType, message.Attachments[0].ContentType)
+		assert.Equal(t, "Deployment pipeline failed", card.Body[0].Text)
+		assert.Equal(t, "Attention", card.Body[0].Color)
+		assert.Equal(t, "payments · prod", card.Body[1].Text)
+		facts := card.Body[2].Facts
+		assert.Equal(t, "Failure reason", facts[len(facts)-1].Title)
+		assert.Len(t, card.Actions, 2)
+		assert.Equal(t, "https://devtron.example.com/dashboard/app/1/cd-details/2/3/4/source-code", card.Actions[0].Url)
+	})
+	t.Run("successful build does not render failure reason", func(t *testing.T) {
+		event := getCardTestEvent(util.Success)
+		event.PipelineType = string(util.CI)
+		event.Payload.BuildHistoryLink = "/dashboard/app/1/ci-details/5/6/artifacts"
+		card := BuildTeamsCard(event).Attachments[0].Content
+		assert.Equal(t, "Build pipeline succeeded", card.Body[0].Text)
+		for _, fact := range card.Body[2].Facts {
+			assert.NotEqual(t, "Failure reason", fact.Title)
+			assert.NotEqual(t, "Image", fact.Title)
+		}
+		assert.Len(t, card.Actions, 1)
+	})
+	t.Run("gitops drift renders the drift facts and links to app details only", func(t *testing.T) {
+		event := getCardTestEvent(util.GitOpsDrift)
+		event.CdWorkflowType = ""
+		event.Payload.DriftedIn = "Live cluster"
+		card := BuildTeamsCard(event).Attachments[0].Content
+		assert.Equal(t, "Deployment pipeline drifted", card.Body[0].Text)
+		assert.Equal(t, "Warning", card.Body[0].Color)
+		facts := card.Body[2].Facts
+		assert.Equal(t, "Out-of-band change in", facts[len(facts)-1].Title)
+		assert.Equal(t, "Live cluster", facts[len(facts)-1].Value)
+		assert.Len(t, card.Actions, 1)
+		assert.Equal(t, "App details", card.Actions[0].Title)
+	})
+}
+
+func TestBuildGoogleChatCard(t *testing.T) {
+	message := BuildGoogleChatCard(getCardTestEvent(util.Trigger))
+	assert.Len(t, message.CardsV2, 1)
+	card := message.CardsV2[0].Card
+	assert.Equal(t, "Deployment pipeline triggered", card.Header.Title)
+	widgets := card.Sections[0].Widgets
+	assert.NotNil(t, widgets[len(widgets)-1].ButtonList)
+	assert.Len(t, widgets[len(widgets)-1].ButtonList.Buttons, 2)
+
+	// payload must be valid json for the google chat webhook api
+	_, err := json.Marshal(message)
+	assert.Nil(t, err)
+}
+
+func TestBuildTeamsCard_DigestEvent(t *testing.T) {
+	failed := getCardTestEvent(util.Fail)
+	succeeded := getCardTestEvent(util.Success)
+	otherEnv := getCardTestEvent(util.Fail)
+	otherEnv.EnvId = 99
+	otherEnv.Payload.EnvName = "staging"
+	windowStart := time.Date(2024, 5, 9, 11, 30, 0, 0, time.UTC)
+	windowEnd := windowStart.Add(30 * time.Minute)
+
+	digest := BuildDigestEvent(string(util.CD), []Event{failed, succeeded, failed, otherEnv}, windowStart, windowEnd)
+	assert.Equal(t, int(util.Digest), digest.EventTypeId)
+	assert.Equal(t, 4, digest.Payload.DigestTotal)
+	assert.Len(t, digest.Payload.DigestItems, 3)
+	assert.Equal(t, "failed", digest.Payload.DigestItems[0].Status)
+	assert.Equal(t, 2, digest.Payload.DigestItems[0].Count)
+	assert.Equal(t, "staging", digest.Payload.DigestItems[2].EnvName)
+
+	card := BuildTeamsCard(digest).Attachments[0].Content
+	assert.Equal(t, "Deployment pipeline digest: 4 events", card.Body[0].Text)
+	assert.Equal(t, "Attention", card.Body[0].Color)
+	assert.Len(t, card.Body[2].Facts, 3)
+}
@@ -25,20 +25,27 @@ import (
 	"github.com/caarlos0/env"
 	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
 	"github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/cel"
 	"github.com/devtron-labs/devtron/internal/sql/repository"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
 	bean2 "github.com/devtron-labs/devtron/pkg/attributes/bean"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/module"
 	bean3 "github.com/devtron-labs/devtron/pkg/module/bean"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
 	util "github.com/devtron-labs/devtron/util/event"
 	"go.uber.org/zap"
 	"net/http"
+	"time"
 )
 
 type EventClientConfig struct {
 	DestinationURL     string             `env:"EVENT_URL" envDefault:"http://localhost:3000/notify" description:"Notifier service url"`
 	NotificationMedium NotificationMedium `env:"NOTIFICATION_MEDIUM" envDefault:"rest" description:"notification medium"`
+	// failed deliveries are retried with exponential backoff starting from the base delay and capped at the max delay
+	DeliveryMaxAttempts        int `env:"NOTIFICATION_DELIVERY_MAX_ATTEMPTS" envDefault:"5" description:"Number of attempts after which a failed notification delivery is dead lettered"`
+	DeliveryRetryBaseDelaySecs int `env:"NOTIFICATION_DELIVERY_RETRY_BASE_DELAY_SECS" envDefault:"60" description:"Delay in seconds before the first retry of a failed notification delivery, doubled on every attempt"`
+	DeliveryRetryMaxDelaySecs  int `env:"NOTIFICATION_DELIVERY_RETRY_MAX_DELAY_SECS" envDefault:"3600" description:"Maximum delay in seconds between retries of a failed notification delivery"`
 }
 type NotificationMedium string
 
@@ -56,6 +63,8 @@ func GetEventClientConfig() (*EventClientConfig, error) {
 type EventClient interface {
 	WriteNotificationEvent(event Event) (bool, error)
 	WriteNatsEvent(channel string, payload interface{}) error
+	// SendDigestNotificationEvent delivers a digest event to the given settings, bypassing the digest hold back
+	SendDigestNotificationEvent(event Event, notificationSettingsBean []*repository.NotificationSettingsBean) (bool, error)
 }
 
 type Event struct {
@@ -77,6 +86,7 @@ type Event struct {
 	CiArtifactId        int               `json:"ciArtifactId"`
 	EnvIdsForCiPipeline []int             `json:"envIdsForCiPipeline"`
 	BaseUrl             string            `json:"baseUrl"`
+	DurationInSecs      int               `json:"durationInSecs,omitempty"`
 	UserId              int               `json:"-"`
 }
 
@@ -94,6 +104,11 @@ type Payload struct {
 	BuildHistoryLink      string                         `json:"buildHistoryLink"`
 	MaterialTriggerInfo   *buildBean.MaterialTriggerInfo `json:"material"`
 	FailureReason         string                         `json:"failureReason"`
+	DigestWindow          string                         `json:"digestWindow,omitempty"`
+	DigestTotal           int                            `json:"digestTotal,omitempty"`
+	DigestItems           []*DigestItem                  `json:"digestItems,omitempty"`
+	DriftedIn             string                         `json:"driftedIn,omitempty"`
+	HeadCommitHash        string                         `json:"headCommitHash,omitempty"`
 }
 
 type EventRESTClientImpl struct {
@@ -106,16 +121,29 @@ type EventRESTClientImpl struct {
 	attributesRepository           repository.AttributesRepository
 	moduleService                  module.ModuleService
 	notificationSettingsRepository repository.NotificationSettingsRepository
+	teamsRepository                repository.TeamsNotificationRepository
+	googleChatRepository           repository.GoogleChatNotificationRepository
+	digestEventRepository          repository.NotificationDigestEventRepository
+	celEvaluatorService            cel.EvaluatorService
+	deliveryLogRepository          repository.NotificationDeliveryLogRepository
 }
 
 func NewEventRESTClientImpl(logger *zap.SugaredLogger, client *http.Client, config *EventClientConfig, pubsubClient *pubsub.PubSubClientServiceImpl,
 	ciPipelineRepository pipelineConfig.CiPipelineRepository, pipelineRepository pipelineConfig.PipelineRepository,
 	attributesRepository repository.AttributesRepository, moduleService module.ModuleService,
-	notificationSettingsRepository repository.NotificationSettingsRepository) *EventRESTClientImpl {
+	notificationSettingsRepository repository.NotificationSettingsRepository,
+	teamsRepository repository.TeamsNotificationRepository, googleChatRepository repository.GoogleChatNotificationRepository,
+	digestEventRepository repository.NotificationDigestEventRepository, celEvaluatorService cel.EvaluatorService,
+	deliveryLogRepository repository.NotificationDeliveryLogRepository) *EventRESTClientImpl {
 	return &EventRESTClientImpl{logger: logger, client: client, config: config, pubsubClient: pubsubClient,
 		ciPipelineRepository: ciPipelineRepository, pipelineRepository: pipelineRepository,
 		attributesRepository: attributesRepository, moduleService: moduleService,
-		notificationSettingsRepository: notificationSettingsRepository}
+		notificationSettingsRepository: notificationSettingsRepository,
+		teamsRepository:                teamsRepository,
+		googleChatRepository:           googleChatRepository,
+		digestEventRepository:          digestEventRepository,
+		celEvaluatorService:            celEvaluatorService,
+		deliveryLogRepository:          deliveryLogRepository}
 }
 
 func (impl *EventRESTClientImpl) buildFinalPayload(event Event, cdPipeline *pipelineConfig.Pipeline, ciPipeline *pipelineConfig.CiPipeline) *Payload {
@@ -241,20 +269,162 @@ func (impl *EventRESTClientImpl) sendEventsOnNats(body []byte) error {
 func (impl *EventRESTClientImpl) sendEvent(event Event) (bool, error) {
 	impl.logger.Debugw("event before send", "event", event)
 
-	// Step 1: Create payload and destination URL based on config
-	bodyBytes, destinationUrl, err := impl.createV2PayloadAndDestination(event)
+	// Step 1: Fetch the notification settings matching this event
+	notificationSettingsBean, err := impl.getNotificationSettings(event)
 	if err != nil {
 		return false, err
 	}
 
-	// Step 2: Send via appropriate medium (NATS or REST)
-	return impl.deliverEvent(bodyBytes, destinationUrl)
+	// Step 2: Drop the settings whose condition does not hold for this event
+	nsConfigs := impl.getNotificationSettingsViewConfigs(notificationSettingsBean)
+	notificationSettingsBean = impl.filterByCondition(event, notificationSettingsBean, nsConfigs)
+
+	// Step 3: Hold back the event for settings which are delivered as a periodic digest
+	notificationSettingsBean = impl.holdDigestEvents(event, notificationSettingsBean, nsConfigs)
+	if len(notificationSettingsBean) == 0 {
+		impl.logger.Debugw("no notification settings left to notify immediately", "eventTypeId", event.EventTypeId, "pipelineId", event.PipelineId)
+		return true, nil
+	}
+	return impl.deliverToNotificationSettings(event, notificationSettingsBean)
+}
+
+func (impl *EventRESTClientImpl) SendDigestNotificationEvent(event Event, notificationSettingsBean []*repository.NotificationSettingsBean) (bool, error) {
+	return impl.deliverToNotificationSettings(event, notificationSettingsBean)
+}
+
+func (impl *EventRESTClientImpl) deliverToNotificationSettings(event Event, notificationSettingsBean []*repository.NotificationSettingsBean) (bool, error) {
+	// Teams and Google Chat cards are rendered and delivered natively, the rest is sent to notifier
+	notificationSettingsBean, deliveryErr := impl.deliverChatCards(event, notificationSettingsBean)
+
+	// each destination is sent to notifier on its own, so that the failure of a channel is logged and retried for that destination only
+	for _, destination := range getNotifierDestinations(notificationSettingsBean) {
+		// Create payload and destination URL based on config
+		bodyBytes, destinationUrl, err := impl.createV2PayloadAndDestination(event, getNotificationSettingsForDestination(notificationSettingsBean, destination))
+		if err != nil {
+			return false, err
+		}
+		// Send via appropriate medium (NATS or REST)
+		err = impl.sendToNotifier(bodyBytes, destinationUrl)
+		impl.logDelivery(event, destination.Dest, destination.ConfigId, destination.Recipient, bodyBytes, err)
+		if err != nil {
+			deliveryErr = err
+		}
+	}
+	return deliveryErr == nil, deliveryErr
+}
+
+// getNotificationSettingsViewConfigs returns the config of the notification setting views the settings belong to, keyed by view id
+func (impl *EventRESTClientImpl) getNotificationSettingsViewConfigs(notificationSettingsBean []*repository.NotificationSettingsBean) map[int]*beans.NSConfig {
+	nsConfigs := make(map[int]*beans.NSConfig)
+	if len(notificationSettingsBean) == 0 {
+		return nsConfigs
+	}
+	viewIds := make(map[int]bool)
+	for _, setting := range notificationSettingsBean {
+		viewIds[setting.ViewId] = true
+	}
+	views, err := impl.notificationSettingsRepository.FindNotificationSettingsViewByIds(getConfigIdPointers(viewIds))
+	if err != nil {
+		impl.logger.Errorw("error while fetching notification settings views", "viewIds", viewIds, "err", err)
+		return nsConfigs
+	}
+	for _, view := range views {
+		nsConfig := &beans.NSConfig{}
+		if err = json.Unmarshal([]byte(view.Config), nsConfig); err != nil {
+			impl.logger.Errorw("error while unmarshalling notification settings view config", "viewId", view.Id, "err", err)
+			continue
+		}
+		nsConfigs[view.Id] = nsConfig
+	}
+	return nsConfigs
+}
+
+// filterByCondition drops the settings whose CEL condition evaluates to false for the event,
+// a condition which fails to evaluate does not suppress the notification
+func (impl *EventRESTClientImpl) filterByCondition(event Event, notificationSettingsBean []*repository.NotificationSettingsBean, nsConfigs map[int]*beans.NSConfig) []*repository.NotificationSettingsBean {
+	var params []cel.ExpressionParam
+	results := make(map[int]bool)
+	filteredSettings := make([]*repository.NotificationSettingsBean, 0, len(notificationSettingsBean))
+	for _, setting := range notificationSettingsBean {
+		nsConfig, ok := nsConfigs[setting.ViewId]
+		if !ok || len(nsConfig.Condition) == 0 {
+			filteredSettings = append(filteredSettings, setting)
+			continue
+		}
+		result, evaluated := results[setting.ViewId]
+		if !evaluated {
+			if params == nil {
+				params = GetNotificationConditionParamValues(event)
+			}
+			var err error
+			result, err = impl.celEvaluatorService.EvaluateCELRequest(cel.Request{
+				Expression:         nsConfig.Condition,
+				ExpressionMetadata: cel.ExpressionMetadata{Params: params},
+			})
+			if err != nil {
+				impl.logger.Errorw("error while evaluating notification condition, notifying anyway", "viewId", setting.ViewId, "condition", nsConfig.Condition, "err", err)
+				result = true
+			}
+			results[setting.ViewId] = result
+		}
+		if result {
+			filteredSettings = append(filteredSettings, setting)
+		}
+	}
+	return filteredSettings
 }
 
-func (impl *EventRESTClientImpl) createV2PayloadAndDestination(event Event) ([]byte, string, error) {
-	destinationUrl := impl.config.DestinationURL + "/v2"
+// holdDigestEvents persists the event once per notification setting view configured in digest mode and returns
+// the settings which are still to be notified immediately. On any failure the event is delivered immediately.
+func (impl *EventRESTClientImpl) holdDigestEvents(event Event, notificationSettingsBean []*repository.NotificationSettingsBean, nsConfigs map[int]*beans.NSConfig) []*repository.NotificationSettingsBean {
+	if len(notificationSettingsBean) == 0 || util.EventType(event.EventTypeId) == util.Digest {
+		return notificationSettingsBean
+	}
+	digestViewIds := make(map[int]bool)
+	for viewId, nsConfig := range nsConfigs {
+		if nsConfig.IsDigest() {
+			digestViewIds[viewId] = true
+		}
+	}
+	if len(digestViewIds) == 0 {
+		return notificationSettingsBean
+	}
+	eventJson, err := json.Marshal(event)
+	if err != nil {
+		impl.logger.Errorw("error while marshalling event for digest", "err", err)
+		return notificationSettingsBean
+	}
+	heldViewIds := make(map[int]bool)
+	remainingSettings := make([]*repository.NotificationSettingsBean, 0, len(notificationSettingsBean))
+	for _, setting := range notificationSettingsBean {
+		if !digestViewIds[setting.ViewId] {
+			remainingSettings = append(remainingSettings, setting)
+			continue
+		}
+		if heldViewIds[setting.ViewId] {
+			continue
+		}
+		digestEvent := &repository.NotificationDigestEvent{
+			ViewId:       setting.ViewId,
+			EventTypeId:  event.EventTypeId,
+			PipelineType: event.PipelineType,
+			PipelineId:   event.PipelineId,
+			AppId:        event.AppId,
+			EnvId:        event.EnvId,
+			Event:        string(eventJson),
+			CreatedOn:    time.Now(),
+		}
+		if err = impl.digestEventRepository.Save(digestEvent); err != nil {
+			impl.logger.Errorw("error while saving digest event, delivering immediately", "viewId", setting.ViewId, "err", err)
+			remainingSettings = append(remainingSettings, setting)
+			continue
+		}
+		heldViewIds[setting.ViewId] = true
+	}
+	return remainingSettings
+}
 
-	// Fetch notification settings
+func (impl *EventRESTClientImpl) getNotificationSettings(event Event) ([]*repository.NotificationSettingsBean, error) {
 	req := repository.GetRulesRequest{
 		TeamId:              event.TeamId,
 		EnvId:               event.EnvId,
@@ -270,14 +440,15 @@ func (impl *EventRESTClientImpl) createV2PayloadAndDestination(event Event) ([]b
 	)
 	if err != nil {
 		impl.logger.Errorw("error while fetching notification settings", "err", err)
-		return nil, "", err
+		return nil, err
 	}
 
 	// Process notification settings into beans
-	notificationSettingsBean, err := impl.processNotificationSettings(notificationSettings)
-	if err != nil {
-		return nil, "", err
-	}
+	return impl.processNotificationSettings(notificationSettings)
+}
+
+func (impl *EventRESTClientImpl) createV2PayloadAndDestination(event Event, notificationSettingsBean []*repository.NotificationSettingsBean) ([]byte, string, error) {
+	destinationUrl := impl.getNotifierV2Url()
 
 	// Create combined payload
 	combinedPayload := map[string]interface{}{
@@ -294,6 +465,10 @@ func (impl *EventRESTClientImpl) createV2PayloadAndDestination(event Event) ([]b
 	return bodyBytes, destinationUrl, nil
 }
 
+func (impl *EventRESTClientImpl) getNotifierV2Url() string {
+	return impl.config.DestinationURL + "/v2"
+}
+
 func (impl *EventRESTClientImpl) processNotificationSettings(notificationSettings []repository.NotificationSettings) ([]*repository.NotificationSettingsBean, error) {
 	notificationSettingsBean := make([]*repository.NotificationSettingsBean, 0)
 	for _, item := range notificationSettings {
@@ -319,36 +494,109 @@ func (impl *EventRESTClientImpl) processNotificationSettings(notificationSetting
 	return notificationSettingsBean, nil
 }
 
-func (impl *EventRESTClientImpl) deliverEvent(bodyBytes []byte, destinationUrl string) (bool, error) {
+// deliverChatCards posts the event as a native card to every Teams and Google Chat config referenced by the
+// notification settings and returns the settings with those entries removed, as notifier does not handle them,
+// along with the error of a failed delivery
+func (impl *EventRESTClientImpl) deliverChatCards(event Event, notificationSettingsBean []*repository.NotificationSettingsBean) ([]*repository.NotificationSettingsBean, error) {
+	var deliveryErr error
+	teamsConfigIds := make(map[int]bool)
+	googleChatConfigIds := make(map[int]bool)
+	for _, setting := range notificationSettingsBean {
+		remainingConfig := make([]repository.ConfigEntry, 0, len(setting.Config))
+		for _, entry := range setting.Config {
+			if entry.Dest == util.Teams.String() {
+				teamsConfigIds[entry.ConfigId] = true
+			} else if entry.Dest == util.GoogleChat.String() {
+				googleChatConfigIds[entry.ConfigId] = true
+			} else {
+				remainingConfig = append(remainingConfig, entry)
+			}
+		}
+		setting.Config = remainingConfig
+	}
+	if len(teamsConfigIds) > 0 {
+		teamsConfigs, err := impl.teamsRepository.FindByIds(getConfigIdPointers(teamsConfigIds))
+		if err != nil {
+			impl.logger.Errorw("error while fetching teams configs", "configIds", teamsConfigIds, "err", err)
+			deliveryErr = err
+		}
+		card := BuildTeamsCard(event)
+		for _, teamsConfig := range teamsConfigs {
+			if err = impl.postChatCard(event, util.Teams, teamsConfig.Id, teamsConfig.WebHookUrl, card); err != nil {
+				impl.logger.Errorw("error while delivering teams card", "configId", teamsConfig.Id, "err", err)
+				deliveryErr = err
+			}
+		}
+	}
+	if len(googleChatConfigIds) > 0 {
+		googleChatConfigs, err := impl.googleChatRepository.FindByIds(getConfigIdPointers(googleChatConfigIds))
+		if err != nil {
+			impl.logger.Errorw("error while fetching google chat configs", "configIds", googleChatConfigIds, "err", err)
+			deliveryErr = err
+		}
+		card := BuildGoogleChatCard(event)
+		for _, googleChatConfig := range googleChatConfigs {
+			if err = impl.postChatCard(event, util.GoogleChat, googleChatConfig.Id, googleChatConfig.WebHookUrl, card); err != nil {
+				impl.logger.Errorw("error while delivering google chat card", "configId", googleChatConfig.Id, "err", err)
+				deliveryErr = err
+			}
+		}
+	}
+	return notificationSettingsBean, deliveryErr
+}
+
+func (impl *EventRESTClientImpl) postChatCard(event Event, channel util.Channel, configId int, webhookUrl string, card interface{}) error {
+	bodyBytes, err := json.Marshal(card)
+	if err != nil {
+		return err
+	}
+	err = impl.postJson(webhookUrl, bodyBytes)
+	impl.logDelivery(event, channel.String(), configId, redactWebhookUrl(webhookUrl), bodyBytes, err)
+	return err
+}
+
+func getConfigIdPointers(configIds map[int]bool) []*int {
+	ids := make([]*int, 0, len(configIds))
+	for id := range configIds {
+		configId := id
+		ids = append(ids, &configId)
+	}
+	return ids
+}
+
+func (impl *EventRESTClientImpl) sendToNotifier(bodyBytes []byte, destinationUrl string) error {
 	if impl.config.NotificationMedium == PUB_SUB {
 		if err := impl.sendEventsOnNats(bodyBytes); err != nil {
 			impl.logger.Errorw("error while publishing event", "err", err)
-			return false, err
+			return err
 		}
-		return true, nil
+		return nil
 	}
+	if err := impl.postJson(destinationUrl, bodyBytes); err != nil {
+		impl.logger.Errorw("error while delivering event to notifier", "err", err)
+		return err
+	}
+	impl.logger.Debugw("event successfully delivered")
+	return nil
+}
 
+func (impl *EventRESTClientImpl) postJson(destinationUrl string, bodyBytes []byte) error {
 	req, err := http.NewRequest(http.MethodPost, destinationUrl, bytes.NewBuffer(bodyBytes))
 	if err != nil {
-		impl.logger.Errorw("error while creating HTTP request", "err", err)
-		return false, err
+		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := impl.client.Do(req)
 	if err != nil {
-		impl.logger.Errorw("error while sending HTTP request", "err", err)
-		return false, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		impl.logger.Errorw("unexpected response from notifier", "status", resp.StatusCode)
-		return false, fmt.Errorf("unexpected response code: %d", resp.StatusCode)
+		return fmt.Errorf("unexpected response code: %d", resp.StatusCode)
 	}
-
-	impl.logger.Debugw("event successfully delivered", "status", resp.StatusCode)
-	return true, nil
+	return nil
 }
 
 func (impl *EventRESTClientImpl) WriteNatsEvent(topic string, payload interface{}) error {
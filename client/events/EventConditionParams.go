@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"strings"
+
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	util "github.com/devtron-labs/devtron/util/event"
+)
+
+// GetNotificationConditionParamValues populates the notification condition params declared in
+// beans.GetNotificationConditionParams from the event
+func GetNotificationConditionParamValues(event Event) []cel.ExpressionParam {
+	payload := event.Payload
+	if payload == nil {
+		payload = &Payload{}
+	}
+	params := beans.GetNotificationConditionParams()
+	for i := range params {
+		switch params[i].ParamName {
+		case cel.AppName:
+			params[i].Value = payload.AppName
+		case cel.EnvName:
+			params[i].Value = payload.EnvName
+		case cel.IsProdEnv:
+			params[i].Value = event.IsProdEnv
+		case cel.PipelineName:
+			params[i].Value = payload.PipelineName
+		case cel.PipelineType:
+			params[i].Value = event.PipelineType
+		case cel.EventType:
+			params[i].Value = getConditionEventType(util.EventType(event.EventTypeId))
+		case cel.TriggeredBy:
+			params[i].Value = payload.TriggeredBy
+		case cel.ContainerImageTag:
+			params[i].Value = getImageTag(payload.DockerImageUrl)
+		case cel.FailureStage:
+			params[i].Value = getFailureStage(event)
+		case cel.DurationInSecs:
+			params[i].Value = event.DurationInSecs
+		}
+	}
+	return params
+}
+
+func getConditionEventType(eventType util.EventType) string {
+	switch eventType {
+	case util.Trigger:
+		return "trigger"
+	case util.Success:
+		return "success"
+	case util.Fail:
+		return "fail"
+	default:
+		return ""
+	}
+}
+
+// getFailureStage returns the stage which failed, PRE, DEPLOY or POST for deployments and CI for builds
+func getFailureStage(event Event) string {
+	if util.EventType(event.EventTypeId) != util.Fail {
+		return ""
+	}
+	if event.PipelineType == string(util.CI) {
+		return string(util.CI)
+	}
+	return string(event.CdWorkflowType)
+}
+
+func getImageTag(image string) string {
+	// the last colon separates the tag, unless it belongs to the registry host port
+	index := strings.LastIndex(image, ":")
+	if index < 0 || strings.Contains(image[index+1:], "/") {
+		return ""
+	}
+	return image[index+1:]
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/cel"
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNotificationCondition(t *testing.T) {
+	evaluator := cel.NewCELServiceImpl(zap.NewNop().Sugar())
+	condition := `isProdEnv && eventType == "fail" && durationInSecs > 600 && containerImageTag.startsWith("abc")`
+	evaluate := func(event Event) bool {
+		result, err := evaluator.EvaluateCELRequest(cel.Request{
+			Expression:         condition,
+			ExpressionMetadata: cel.ExpressionMetadata{Params: GetNotificationConditionParamValues(event)},
+		})
+		assert.NoError(t, err)
+		return result
+	}
+
+	event := getCardTestEvent(util.Fail)
+	event.IsProdEnv = true
+	event.DurationInSecs = 900
+	assert.True(t, evaluate(event))
+
+	event.DurationInSecs = 120
+	assert.False(t, evaluate(event))
+
+	event = getCardTestEvent(util.Success)
+	event.IsProdEnv = true
+	event.DurationInSecs = 900
+	assert.False(t, evaluate(event))
+
+	params := GetNotificationConditionParamValues(getCardTestEvent(util.Fail))
+	values := make(map[cel.ParamName]interface{})
+	for _, param := range params {
+		values[param.ParamName] = param.Value
+	}
+	assert.Equal(t, "DEPLOY", values[cel.FailureStage])
+	assert.Equal(t, "abc123", values[cel.ContainerImageTag])
+	assert.Equal(t, "", getImageTag("localhost:5000/payments"))
+}
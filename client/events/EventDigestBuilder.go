@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/bean"
+	util "github.com/devtron-labs/devtron/util/event"
+)
+
+// DigestItem summarises the events of one pipeline, environment and status combination held back for a digest
+type DigestItem struct {
+	AppName       string `json:"appName"`
+	EnvName       string `json:"envName,omitempty"`
+	PipelineName  string `json:"pipelineName"`
+	Status        string `json:"status"`
+	Count         int    `json:"count"`
+	LastEventTime string `json:"lastEventTime"`
+}
+
+type digestItemKey struct {
+	pipelineId  int
+	envId       int
+	eventTypeId int
+}
+
+// BuildDigestEvent groups the held back events by pipeline, environment and status into a single digest event,
+// items are ordered by the first occurrence of their group
+func BuildDigestEvent(pipelineType string, events []Event, windowStart, windowEnd time.Time) Event {
+	items := make([]*DigestItem, 0)
+	itemsByKey := make(map[digestItemKey]*DigestItem)
+	baseUrl := ""
+	for _, event := range events {
+		payload := event.Payload
+		if payload == nil {
+			payload = &Payload{}
+		}
+		if len(event.BaseUrl) > 0 {
+			baseUrl = event.BaseUrl
+		}
+		key := digestItemKey{pipelineId: event.PipelineId, envId: event.EnvId, eventTypeId: event.EventTypeId}
+		item, ok := itemsByKey[key]
+		if !ok {
+			item = &DigestItem{
+				AppName:      payload.AppName,
+				EnvName:      payload.EnvName,
+				PipelineName: payload.PipelineName,
+				Status:       getCardAction(util.EventType(event.EventTypeId)),
+			}
+			itemsByKey[key] = item
+			items = append(items, item)
+		}
+		item.Count++
+		item.LastEventTime = event.EventTime
+	}
+	return Event{
+		EventTypeId:  int(util.Digest),
+		PipelineType: pipelineType,
+		EventTime:    windowEnd.Format(bean.LayoutRFC3339),
+		BaseUrl:      baseUrl,
+		Payload: &Payload{
+			DigestWindow: fmt.Sprintf("%s - %s", windowStart.Format(bean.LayoutRFC3339), windowEnd.Format(bean.LayoutRFC3339)),
+			DigestTotal:  len(events),
+			DigestItems:  items,
+		},
+	}
+}
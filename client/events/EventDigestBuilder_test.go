@@ -0,0 +1,62 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	util "github.com/devtron-labs/devtron/util/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDigestTestEvent(eventType util.EventType, pipelineId, envId int, pipelineName, eventTime string) Event {
+	return Event{
+		EventTypeId:  int(eventType),
+		PipelineType: string(util.CD),
+		PipelineId:   pipelineId,
+		EnvId:        envId,
+		EventTime:    eventTime,
+		BaseUrl:      "https://devtron.example.com/",
+		Payload: &Payload{
+			AppName:      "payments",
+			EnvName:      "prod",
+			PipelineName: pipelineName,
+		},
+	}
+}
+
+func TestBuildDigestEvent(t *testing.T) {
+	windowStart := time.Date(2024, 5, 9, 12, 0, 0, 0, time.UTC)
+	windowEnd := windowStart.Add(30 * time.Minute)
+
+	t.Run("groups events by pipeline, environment and status in order of first occurrence", func(t *testing.T) {
+		events := []Event{
+			getDigestTestEvent(util.Fail, 1, 2, "cd-prod", "2024-05-09T12:01:00Z"),
+			getDigestTestEvent(util.Success, 1, 2, "cd-prod", "2024-05-09T12:05:00Z"),
+			getDigestTestEvent(util.Fail, 3, 2, "cd-canary", "2024-05-09T12:07:00Z"),
+			getDigestTestEvent(util.Fail, 1, 2, "cd-prod", "2024-05-09T12:10:00Z"),
+			getDigestTestEvent(util.Fail, 1, 4, "cd-prod", "2024-05-09T12:12:00Z"),
+		}
+		digest := BuildDigestEvent(string(util.CD), events, windowStart, windowEnd)
+		assert.Equal(t, int(util.Digest), digest.EventTypeId)
+		assert.Equal(t, string(util.CD), digest.PipelineType)
+		assert.Equal(t, "2024-05-09T12:30:00Z", digest.EventTime)
+		assert.Equal(t, "https://devtron.example.com/", digest.BaseUrl)
+		assert.Equal(t, "2024-05-09T12:00:00Z - 2024-05-09T12:30:00Z", digest.Payload.DigestWindow)
+		assert.Equal(t, 5, digest.Payload.DigestTotal)
+		assert.Equal(t, []*DigestItem{
+			{AppName: "payments", EnvName: "prod", PipelineName: "cd-prod", Status: "failed", Count: 2, LastEventTime: "2024-05-09T12:10:00Z"},
+			{AppName: "payments", EnvName: "prod", PipelineName: "cd-prod", Status: "succeeded", Count: 1, LastEventTime: "2024-05-09T12:05:00Z"},
+			{AppName: "payments", EnvName: "prod", PipelineName: "cd-canary", Status: "failed", Count: 1, LastEventTime: "2024-05-09T12:07:00Z"},
+			{AppName: "payments", EnvName: "prod", PipelineName: "cd-prod", Status: "failed", Count: 1, LastEventTime: "2024-05-09T12:12:00Z"},
+		}, digest.Payload.DigestItems)
+	})
+	t.Run("events without payload are still counted", func(t *testing.T) {
+		event := getDigestTestEvent(util.Trigger, 1, 2, "cd-prod", "2024-05-09T12:01:00Z")
+		event.Payload = nil
+		event.BaseUrl = ""
+		digest := BuildDigestEvent(string(util.CD), []Event{event}, windowStart, windowEnd)
+		assert.Empty(t, digest.BaseUrl)
+		assert.Equal(t, 1, digest.Payload.DigestTotal)
+		assert.Equal(t, []*DigestItem{{Status: "triggered", Count: 1, LastEventTime: "2024-05-09T12:01:00Z"}}, digest.Payload.DigestItems)
+	})
+}
@@ -0,0 +1,321 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/devtron-labs/common-lib/securestore"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	util2 "github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	util "github.com/devtron-labs/devtron/util/event"
+)
+
+const deliveryRetryBatchSize = 100
+
+// deliveryRetryLease is how long a claimed delivery stays hidden from other replicas while it is being redelivered
+const deliveryRetryLease = 5 * time.Minute
+
+// NotificationDeliveryService gives access to the delivery log of notifications and replays the failed ones
+type NotificationDeliveryService interface {
+	RetryFailedDeliveries()
+	FindDeliveries(status repository.NotificationDeliveryStatus, offset, size int) ([]*NotificationDeliveryDto, error)
+	ReplayDelivery(id int, userId int32) (*NotificationDeliveryDto, error)
+	DeleteSucceededDeliveries(updatedBefore time.Time)
+}
+
+type NotificationDeliveryDto struct {
+	Id            int                                   `json:"id"`
+	CorrelationId string                                `json:"correlationId"`
+	EventTypeId   int                                   `json:"eventTypeId"`
+	PipelineType  string                                `json:"pipelineType"`
+	PipelineId    int                                   `json:"pipelineId"`
+	AppId         int                                   `json:"appId"`
+	EnvId         int                                   `json:"envId"`
+	Channel       string                                `json:"channel"`
+	ConfigId      int                                   `json:"configId,omitempty"`
+	Destination   string                                `json:"destination,omitempty"`
+	PayloadHash   string                                `json:"payloadHash"`
+	Status        repository.NotificationDeliveryStatus `json:"status"`
+	Attempts      int                                   `json:"attempts"`
+	LastError     string                                `json:"lastError,omitempty"`
+	NextRetryOn   *time.Time                            `json:"nextRetryOn,omitempty"`
+	CreatedOn     time.Time                             `json:"createdOn"`
+	UpdatedOn     time.Time                             `json:"updatedOn"`
+}
+
+// logDelivery records the first attempt of a delivery to a destination, a failed one is scheduled for retry.
+// destination is a reference for the users and must not carry secrets like webhook urls.
+func (impl *EventRESTClientImpl) logDelivery(event Event, channel string, configId int, destination string, bodyBytes []byte, deliveryErr error) {
+	payloadHash := sha256.Sum256(bodyBytes)
+	now := time.Now()
+	deliveryLog := &repository.NotificationDeliveryLog{
+		CorrelationId: event.CorrelationId,
+		EventTypeId:   event.EventTypeId,
+		PipelineType:  event.PipelineType,
+		PipelineId:    event.PipelineId,
+		AppId:         event.AppId,
+		EnvId:         event.EnvId,
+		Channel:       channel,
+		ConfigId:      configId,
+		Destination:   destination,
+		Payload:       securestore.ToEncryptedString(string(bodyBytes)),
+		PayloadHash:   hex.EncodeToString(payloadHash[:]),
+	}
+	deliveryLog.CreatedOn = now
+	deliveryLog.CreatedBy = userBean.SYSTEM_USER_ID
+	impl.updateDeliveryAttempt(deliveryLog, deliveryErr, now)
+	if err := impl.deliveryLogRepository.Save(deliveryLog); err != nil {
+		impl.logger.Errorw("error while saving notification delivery log", "correlationId", event.CorrelationId, "channel", channel, "err", err)
+	}
+}
+
+// updateDeliveryAttempt counts an attempt and moves the delivery to its next status, retries back off exponentially
+func (impl *EventRESTClientImpl) updateDeliveryAttempt(deliveryLog *repository.NotificationDeliveryLog, deliveryErr error, now time.Time) {
+	deliveryLog.Attempts++
+	deliveryLog.UpdatedOn = now
+	deliveryLog.UpdatedBy = userBean.SYSTEM_USER_ID
+	deliveryLog.NextRetryOn = time.Time{}
+	if deliveryErr == nil {
+		deliveryLog.Status = repository.NotificationDeliverySucceeded
+		deliveryLog.LastError = ""
+		return
+	}
+	deliveryLog.LastError = deliveryErr.Error()
+	if deliveryLog.Attempts >= impl.config.DeliveryMaxAttempts {
+		deliveryLog.Status = repository.NotificationDeliveryDeadLetter
+		impl.logger.Warnw("notification delivery dead lettered", "correlationId", deliveryLog.CorrelationId, "channel", deliveryLog.Channel, "attempts", deliveryLog.Attempts, "err", deliveryErr)
+		return
+	}
+	deliveryLog.Status = repository.NotificationDeliveryRetrying
+	deliveryLog.NextRetryOn = now.Add(getDeliveryRetryDelay(deliveryLog.Attempts, impl.config.DeliveryRetryBaseDelaySecs, impl.config.DeliveryRetryMaxDelaySecs))
+}
+
+// getDeliveryRetryDelay returns the backoff after the given number of failed attempts, doubling from the base delay up to the max delay
+func getDeliveryRetryDelay(attempts, baseDelaySecs, maxDelaySecs int) time.Duration {
+	delaySecs := float64(baseDelaySecs) * math.Pow(2, float64(attempts-1))
+	if maxDelaySecs > 0 && delaySecs > float64(maxDelaySecs) {
+		delaySecs = float64(maxDelaySecs)
+	}
+	return time.Duration(delaySecs) * time.Second
+}
+
+// redeliver sends the logged payload again, the webhook url of a chat channel is read from its current config
+func (impl *EventRESTClientImpl) redeliver(deliveryLog *repository.NotificationDeliveryLog) error {
+	bodyBytes := []byte(deliveryLog.Payload.String())
+	switch deliveryLog.Channel {
+	case util.Teams.String(), util.GoogleChat.String():
+		webhookUrl, err := impl.getChatWebhookUrl(deliveryLog.Channel, deliveryLog.ConfigId)
+		if err != nil {
+			return err
+		}
+		return impl.postJson(webhookUrl, bodyBytes)
+	}
+	return impl.sendToNotifier(bodyBytes, impl.getNotifierV2Url())
+}
+
+func (impl *EventRESTClientImpl) getChatWebhookUrl(channel string, configId int) (string, error) {
+	configIds := []*int{&configId}
+	if channel == util.Teams.String() {
+		teamsConfigs, err := impl.teamsRepository.FindByIds(configIds)
+		if err != nil {
+			return "", err
+		} else if len(teamsConfigs) == 0 {
+			return "", fmt.Errorf("%s config %d not found", channel, configId)
+		}
+		return teamsConfigs[0].WebHookUrl, nil
+	}
+	googleChatConfigs, err := impl.googleChatRepository.FindByIds(configIds)
+	if err != nil {
+		return "", err
+	} else if len(googleChatConfigs) == 0 {
+		return "", fmt.Errorf("%s config %d not found", channel, configId)
+	}
+	return googleChatConfigs[0].WebHookUrl, nil
+}
+
+// notifierDestination is a single destination of the config entries of the notification settings
+type notifierDestination struct {
+	Dest      string
+	ConfigId  int
+	Recipient string
+}
+
+// getNotifierDestinations returns the distinct destinations of the notification settings, in order of appearance
+func getNotifierDestinations(notificationSettingsBean []*repository.NotificationSettingsBean) []notifierDestination {
+	destinations := make([]notifierDestination, 0)
+	seen := make(map[notifierDestination]bool)
+	for _, setting := range notificationSettingsBean {
+		for _, entry := range setting.Config {
+			destination := notifierDestination{Dest: entry.Dest, ConfigId: entry.ConfigId, Recipient: entry.Recipient}
+			if !seen[destination] {
+				seen[destination] = true
+				destinations = append(destinations, destination)
+			}
+		}
+	}
+	return destinations
+}
+
+// getNotificationSettingsForDestination returns copies of the settings which have the destination, with only that config entry
+func getNotificationSettingsForDestination(notificationSettingsBean []*repository.NotificationSettingsBean, destination notifierDestination) []*repository.NotificationSettingsBean {
+	destinationSettings := make([]*repository.NotificationSettingsBean, 0)
+	for _, setting := range notificationSettingsBean {
+		for _, entry := range setting.Config {
+			if entry.Dest == destination.Dest && entry.ConfigId == destination.ConfigId && entry.Recipient == destination.Recipient {
+				settingCopy := *setting
+				settingCopy.Config = []repository.ConfigEntry{entry}
+				destinationSettings = append(destinationSettings, &settingCopy)
+				break
+			}
+		}
+	}
+	return destinationSettings
+}
+
+// redactWebhookUrl keeps only the host of a webhook url, the path and query of chat webhooks carry the credentials
+func redactWebhookUrl(webhookUrl string) string {
+	parsedUrl, err := url.Parse(webhookUrl)
+	if err != nil || len(parsedUrl.Host) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s", parsedUrl.Scheme, parsedUrl.Host)
+}
+
+// RetryFailedDeliveries redelivers the failed deliveries whose backoff has elapsed
+func (impl *EventRESTClientImpl) RetryFailedDeliveries() {
+	now := time.Now()
+	deliveryLogs, err := impl.deliveryLogRepository.FindDueForRetry(now, deliveryRetryBatchSize)
+	if err != nil {
+		impl.logger.Errorw("error while fetching notification deliveries due for retry", "err", err)
+		return
+	}
+	for _, deliveryLog := range deliveryLogs {
+		claimed, err := impl.deliveryLogRepository.ClaimForRetry(deliveryLog.Id, deliveryLog.NextRetryOn, time.Now().Add(deliveryRetryLease))
+		if err != nil {
+			impl.logger.Errorw("error while claiming notification delivery for retry", "id", deliveryLog.Id, "err", err)
+			continue
+		} else if !claimed {
+			// retried by another replica
+			continue
+		}
+		deliveryErr := impl.redeliver(deliveryLog)
+		impl.updateDeliveryAttempt(deliveryLog, deliveryErr, time.Now())
+		if err = impl.deliveryLogRepository.Update(deliveryLog); err != nil {
+			impl.logger.Errorw("error while updating notification delivery log", "id", deliveryLog.Id, "err", err)
+		}
+	}
+}
+
+func (impl *EventRESTClientImpl) FindDeliveries(status repository.NotificationDeliveryStatus, offset, size int) ([]*NotificationDeliveryDto, error) {
+	deliveryLogs, err := impl.deliveryLogRepository.FindByStatus(status, offset, size)
+	if err != nil && !util2.IsErrNoRows(err) {
+		impl.logger.Errorw("error while fetching notification deliveries", "status", status, "err", err)
+		return nil, err
+	}
+	deliveries := make([]*NotificationDeliveryDto, 0, len(deliveryLogs))
+	for _, deliveryLog := range deliveryLogs {
+		deliveries = append(deliveries, adaptNotificationDelivery(deliveryLog))
+	}
+	return deliveries, nil
+}
+
+// ReplayDelivery redelivers a dead lettered notification right away, the delivery is dead lettered again if it fails
+func (impl *EventRESTClientImpl) ReplayDelivery(id int, userId int32) (*NotificationDeliveryDto, error) {
+	deliveryLog, err := impl.deliveryLogRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error while fetching notification delivery", "id", id, "err", err)
+		return nil, err
+	}
+	if deliveryLog.Status != repository.NotificationDeliveryDeadLetter {
+		return nil, util2.NewApiError(http.StatusBadRequest, "only dead lettered notifications can be replayed", fmt.Sprintf("notification delivery is in %s status", deliveryLog.Status))
+	}
+	claimed, err := impl.deliveryLogRepository.ClaimForReplay(id, time.Now().Add(deliveryRetryLease))
+	if err != nil {
+		impl.logger.Errorw("error while claiming notification delivery for replay", "id", id, "err", err)
+		return nil, err
+	} else if !claimed {
+		return nil, util2.NewApiError(http.StatusConflict, "notification is already being replayed", "notification delivery was claimed by another replay")
+	}
+	deliveryErr := impl.redeliver(deliveryLog)
+	deliveryLog.Attempts++
+	deliveryLog.UpdatedOn = time.Now()
+	deliveryLog.UpdatedBy = userId
+	if deliveryErr == nil {
+		deliveryLog.Status = repository.NotificationDeliverySucceeded
+		deliveryLog.LastError = ""
+	} else {
+		impl.logger.Errorw("error while replaying notification delivery", "id", id, "err", deliveryErr)
+		deliveryLog.LastError = deliveryErr.Error()
+	}
+	if err = impl.deliveryLogRepository.Update(deliveryLog); err != nil {
+		impl.logger.Errorw("error while updating notification delivery log", "id", id, "err", err)
+		return nil, err
+	}
+	return adaptNotificationDelivery(deliveryLog), nil
+}
+
+func (impl *EventRESTClientImpl) DeleteSucceededDeliveries(updatedBefore time.Time) {
+	deleted, err := impl.deliveryLogRepository.DeleteSucceededBefore(updatedBefore)
+	if err != nil {
+		impl.logger.Errorw("error while deleting succeeded notification deliveries", "err", err)
+		return
+	}
+	impl.logger.Debugw("deleted succeeded notification deliveries", "count", deleted)
+}
+
+func adaptNotificationDelivery(deliveryLog *repository.NotificationDeliveryLog) *NotificationDeliveryDto {
+	delivery := &NotificationDeliveryDto{
+		Id:            deliveryLog.Id,
+		CorrelationId: deliveryLog.CorrelationId,
+		EventTypeId:   deliveryLog.EventTypeId,
+		PipelineType:  deliveryLog.PipelineType,
+		PipelineId:    deliveryLog.PipelineId,
+		AppId:         deliveryLog.AppId,
+		EnvId:         deliveryLog.EnvId,
+		Channel:       deliveryLog.Channel,
+		ConfigId:      deliveryLog.ConfigId,
+		Destination:   deliveryLog.Destination,
+		PayloadHash:   deliveryLog.PayloadHash,
+		Status:        deliveryLog.Status,
+		Attempts:      deliveryLog.Attempts,
+		LastError:     deliveryLog.LastError,
+		CreatedOn:     deliveryLog.CreatedOn,
+		UpdatedOn:     deliveryLog.UpdatedOn,
+	}
+	if !deliveryLog.NextRetryOn.IsZero() {
+		nextRetryOn := deliveryLog.NextRetryOn
+		delivery.NextRetryOn = &nextRetryOn
+	}
+	return delivery
+}
+
+// ValidateDeliveryStatus checks the status filter of a delivery log query, an empty status matches all deliveries
+func ValidateDeliveryStatus(status repository.NotificationDeliveryStatus) error {
+	switch status {
+	case "", repository.NotificationDeliverySucceeded, repository.NotificationDeliveryRetrying, repository.NotificationDeliveryDeadLetter:
+		return nil
+	}
+	return fmt.Errorf("invalid delivery status %q", status)
+}
@@ -0,0 +1,359 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/common-lib/securestore"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeliveryRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, getDeliveryRetryDelay(1, 60, 3600))
+	assert.Equal(t, 2*time.Minute, getDeliveryRetryDelay(2, 60, 3600))
+	assert.Equal(t, 8*time.Minute, getDeliveryRetryDelay(4, 60, 3600))
+	assert.Equal(t, time.Hour, getDeliveryRetryDelay(10, 60, 3600))
+}
+
+func TestRedactWebhookUrl(t *testing.T) {
+	assert.Equal(t, "https://outlook.office.com", redactWebhookUrl("https://outlook.office.com/webhook/secret-id/IncomingWebhook/secret-token"))
+	assert.Equal(t, "https://chat.googleapis.com", redactWebhookUrl("https://chat.googleapis.com/v1/spaces/AAA/messages?key=secret&token=secret"))
+	assert.Equal(t, "", redactWebhookUrl("not a url"))
+}
+
+// deliveryLogRepositoryStub keeps the delivery logs in memory, claimedByOtherReplay makes another replay
+// claim a dead lettered delivery between its lookup and its claim
+type deliveryLogRepositoryStub struct {
+	lock                 sync.Mutex
+	deliveryLogs         map[int]*repository.NotificationDeliveryLog
+	claimedByOtherReplay bool
+}
+
+func newDeliveryLogRepositoryStub(deliveryLogs ...*repository.NotificationDeliveryLog) *deliveryLogRepositoryStub {
+	impl := &deliveryLogRepositoryStub{deliveryLogs: make(map[int]*repository.NotificationDeliveryLog)}
+	for _, deliveryLog := range deliveryLogs {
+		impl.deliveryLogs[deliveryLog.Id] = deliveryLog
+	}
+	return impl
+}
+
+func (impl *deliveryLogRepositoryStub) Save(deliveryLog *repository.NotificationDeliveryLog) error {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	deliveryLog.Id = len(impl.deliveryLogs) + 1
+	logCopy := *deliveryLog
+	impl.deliveryLogs[deliveryLog.Id] = &logCopy
+	return nil
+}
+
+func (impl *deliveryLogRepositoryStub) Update(deliveryLog *repository.NotificationDeliveryLog) error {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	logCopy := *deliveryLog
+	impl.deliveryLogs[deliveryLog.Id] = &logCopy
+	return nil
+}
+
+func (impl *deliveryLogRepositoryStub) FindById(id int) (*repository.NotificationDeliveryLog, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	deliveryLog, ok := impl.deliveryLogs[id]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	logCopy := *deliveryLog
+	return &logCopy, nil
+}
+
+func (impl *deliveryLogRepositoryStub) FindDueForRetry(dueOn time.Time, limit int) ([]*repository.NotificationDeliveryLog, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	deliveryLogs := make([]*repository.NotificationDeliveryLog, 0)
+	for _, deliveryLog := range impl.deliveryLogs {
+		if deliveryLog.Status == repository.NotificationDeliveryRetrying && !deliveryLog.NextRetryOn.After(dueOn) {
+			logCopy := *deliveryLog
+			deliveryLogs = append(deliveryLogs, &logCopy)
+		}
+	}
+	return deliveryLogs, nil
+}
+
+func (impl *deliveryLogRepositoryStub) ClaimForRetry(id int, dueOn time.Time, leaseUntil time.Time) (bool, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	deliveryLog := impl.deliveryLogs[id]
+	if deliveryLog.Status != repository.NotificationDeliveryRetrying || !deliveryLog.NextRetryOn.Equal(dueOn) {
+		return false, nil
+	}
+	deliveryLog.NextRetryOn = leaseUntil
+	return true, nil
+}
+
+func (impl *deliveryLogRepositoryStub) ClaimForReplay(id int, leaseUntil time.Time) (bool, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	deliveryLog := impl.deliveryLogs[id]
+	if impl.claimedByOtherReplay {
+		deliveryLog.Status = repository.NotificationDeliveryRetrying
+	}
+	if deliveryLog.Status != repository.NotificationDeliveryDeadLetter {
+		return false, nil
+	}
+	deliveryLog.Status = repository.NotificationDeliveryRetrying
+	deliveryLog.NextRetryOn = leaseUntil
+	return true, nil
+}
+
+func (impl *deliveryLogRepositoryStub) FindByStatus(status repository.NotificationDeliveryStatus, offset, size int) ([]*repository.NotificationDeliveryLog, error) {
+	return nil, nil
+}
+
+func (impl *deliveryLogRepositoryStub) DeleteSucceededBefore(updatedBefore time.Time) (int, error) {
+	return 0, nil
+}
+
+func (impl *deliveryLogRepositoryStub) getDeliveryLogs() []*repository.NotificationDeliveryLog {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	deliveryLogs := make([]*repository.NotificationDeliveryLog, 0, len(impl.deliveryLogs))
+	for id := 1; id <= len(impl.deliveryLogs); id++ {
+		deliveryLogs = append(deliveryLogs, impl.deliveryLogs[id])
+	}
+	return deliveryLogs
+}
+
+type teamsRepositoryStub struct {
+	repository.TeamsNotificationRepository
+	teamsConfigs map[int]*repository.TeamsConfig
+}
+
+func (impl *teamsRepositoryStub) FindByIds(ids []*int) ([]*repository.TeamsConfig, error) {
+	teamsConfigs := make([]*repository.TeamsConfig, 0)
+	for _, id := range ids {
+		if teamsConfig, ok := impl.teamsConfigs[*id]; ok {
+			teamsConfigs = append(teamsConfigs, teamsConfig)
+		}
+	}
+	return teamsConfigs, nil
+}
+
+// notifierStub fails the requests of the failing destinations and records the destinations of each request
+type notifierStub struct {
+	lock                sync.Mutex
+	failingDestinations map[string]bool
+	failAll             bool
+	requests            [][]repository.ConfigEntry
+}
+
+func (impl *notifierStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	request := struct {
+		NotificationSettings []*repository.NotificationSettingsBean `json:"notificationSettings"`
+	}{}
+	_ = json.Unmarshal(body, &request)
+	entries := make([]repository.ConfigEntry, 0)
+	failed := impl.failAll
+	for _, setting := range request.NotificationSettings {
+		for _, entry := range setting.Config {
+			entries = append(entries, entry)
+			failed = failed || impl.failingDestinations[entry.Dest]
+		}
+	}
+	impl.requests = append(impl.requests, entries)
+	if failed {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func newDeliveryTestClient(t *testing.T, deliveryLogRepository *deliveryLogRepositoryStub, notifier http.Handler) *EventRESTClientImpl {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	server := httptest.NewServer(notifier)
+	t.Cleanup(server.Close)
+	return &EventRESTClientImpl{
+		logger:                logger,
+		client:                server.Client(),
+		config:                &EventClientConfig{DestinationURL: server.URL, DeliveryMaxAttempts: 3, DeliveryRetryBaseDelaySecs: 60, DeliveryRetryMaxDelaySecs: 3600},
+		deliveryLogRepository: deliveryLogRepository,
+		teamsRepository:       &teamsRepositoryStub{teamsConfigs: map[int]*repository.TeamsConfig{7: {Id: 7, WebHookUrl: server.URL + "/teams/secret"}}},
+	}
+}
+
+func TestEventRESTClientImpl_deliverToNotificationSettings(t *testing.T) {
+	deliveryLogRepository := newDeliveryLogRepositoryStub()
+	notifier := &notifierStub{failingDestinations: map[string]bool{eventUtil.Webhook.String(): true}}
+	impl := newDeliveryTestClient(t, deliveryLogRepository, notifier)
+	settings := []*repository.NotificationSettingsBean{
+		{Id: 1, Config: []repository.ConfigEntry{{Dest: eventUtil.Slack.String(), ConfigId: 1}, {Dest: eventUtil.Webhook.String(), ConfigId: 2}}},
+		{Id: 2, Config: []repository.ConfigEntry{{Dest: eventUtil.Slack.String(), ConfigId: 1}, {Dest: eventUtil.SES.String(), ConfigId: 3, Recipient: "dev@example.com"}}},
+	}
+
+	delivered, err := impl.deliverToNotificationSettings(Event{CorrelationId: "c1", EventTypeId: int(eventUtil.Fail)}, settings)
+
+	assert.False(t, delivered)
+	assert.Error(t, err)
+	// one notifier request per destination, the slack config shared by both the settings is notified once
+	assert.Equal(t, [][]repository.ConfigEntry{
+		{{Dest: eventUtil.Slack.String(), ConfigId: 1}, {Dest: eventUtil.Slack.String(), ConfigId: 1}},
+		{{Dest: eventUtil.Webhook.String(), ConfigId: 2}},
+		{{Dest: eventUtil.SES.String(), ConfigId: 3, Recipient: "dev@example.com"}},
+	}, notifier.requests)
+	deliveryLogs := deliveryLogRepository.getDeliveryLogs()
+	assert.Len(t, deliveryLogs, 3)
+	assert.Equal(t, eventUtil.Slack.String(), deliveryLogs[0].Channel)
+	assert.Equal(t, repository.NotificationDeliverySucceeded, deliveryLogs[0].Status)
+	assert.Equal(t, eventUtil.Webhook.String(), deliveryLogs[1].Channel)
+	assert.Equal(t, 2, deliveryLogs[1].ConfigId)
+	assert.Equal(t, repository.NotificationDeliveryRetrying, deliveryLogs[1].Status)
+	assert.Equal(t, "dev@example.com", deliveryLogs[2].Destination)
+	assert.Equal(t, repository.NotificationDeliverySucceeded, deliveryLogs[2].Status)
+	// the settings of the caller are not narrowed down
+	assert.Len(t, settings[0].Config, 2)
+
+	t.Run("failed chat card delivery is reported", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub()
+		notifier := &notifierStub{failAll: true}
+		impl := newDeliveryTestClient(t, deliveryLogRepository, notifier)
+		settings := []*repository.NotificationSettingsBean{{Id: 1, Config: []repository.ConfigEntry{{Dest: eventUtil.Teams.String(), ConfigId: 7}}}}
+
+		delivered, err := impl.deliverToNotificationSettings(Event{CorrelationId: "c1", EventTypeId: int(eventUtil.Fail)}, settings)
+
+		assert.False(t, delivered)
+		assert.Error(t, err)
+		deliveryLogs := deliveryLogRepository.getDeliveryLogs()
+		assert.Len(t, deliveryLogs, 1)
+		assert.Equal(t, eventUtil.Teams.String(), deliveryLogs[0].Channel)
+		assert.Equal(t, repository.NotificationDeliveryRetrying, deliveryLogs[0].Status)
+	})
+}
+
+func TestEventRESTClientImpl_RetryFailedDeliveries(t *testing.T) {
+	payload := securestore.ToEncryptedString(`{"notificationSettings":[{"config":[{"dest":"slack","configId":1}]}]}`)
+	tests := []struct {
+		name            string
+		attempts        int
+		failAll         bool
+		wantStatus      repository.NotificationDeliveryStatus
+		wantAttempts    int
+		wantNextRetryIn time.Duration
+	}{
+		{name: "retry succeeds", attempts: 1, wantStatus: repository.NotificationDeliverySucceeded, wantAttempts: 2},
+		{name: "failed retry is scheduled with backoff", attempts: 1, failAll: true, wantStatus: repository.NotificationDeliveryRetrying, wantAttempts: 2, wantNextRetryIn: 2 * time.Minute},
+		{name: "failed last attempt is dead lettered", attempts: 2, failAll: true, wantStatus: repository.NotificationDeliveryDeadLetter, wantAttempts: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Slack.String(), ConfigId: 1,
+				Payload: payload, Status: repository.NotificationDeliveryRetrying, Attempts: tt.attempts, NextRetryOn: time.Now().Add(-time.Second)})
+			notifier := &notifierStub{failAll: tt.failAll}
+			impl := newDeliveryTestClient(t, deliveryLogRepository, notifier)
+
+			impl.RetryFailedDeliveries()
+
+			deliveryLog, err := deliveryLogRepository.FindById(1)
+			assert.NoError(t, err)
+			assert.Len(t, notifier.requests, 1)
+			assert.Equal(t, tt.wantStatus, deliveryLog.Status)
+			assert.Equal(t, tt.wantAttempts, deliveryLog.Attempts)
+			if tt.wantNextRetryIn > 0 {
+				assert.WithinDuration(t, time.Now().Add(tt.wantNextRetryIn), deliveryLog.NextRetryOn, 5*time.Second)
+			} else {
+				assert.True(t, deliveryLog.NextRetryOn.IsZero())
+			}
+			if tt.failAll {
+				assert.NotEmpty(t, deliveryLog.LastError)
+			}
+		})
+	}
+	t.Run("delivery claimed by another replica is not redelivered", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Slack.String(),
+			Payload: payload, Status: repository.NotificationDeliveryRetrying, Attempts: 1, NextRetryOn: time.Now().Add(-time.Second)})
+		notifier := &notifierStub{}
+		impl := newDeliveryTestClient(t, deliveryLogRepository, notifier)
+		dueLogs, err := deliveryLogRepository.FindDueForRetry(time.Now(), deliveryRetryBatchSize)
+		assert.NoError(t, err)
+		claimed, err := deliveryLogRepository.ClaimForRetry(1, dueLogs[0].NextRetryOn, time.Now().Add(deliveryRetryLease))
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+
+		impl.RetryFailedDeliveries()
+
+		assert.Empty(t, notifier.requests)
+		deliveryLog, err := deliveryLogRepository.FindById(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, deliveryLog.Attempts)
+	})
+}
+
+func TestEventRESTClientImpl_ReplayDelivery(t *testing.T) {
+	t.Run("only dead lettered deliveries are replayed", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Slack.String(),
+			Status: repository.NotificationDeliveryRetrying, Attempts: 1})
+		impl := newDeliveryTestClient(t, deliveryLogRepository, &notifierStub{})
+		_, err := impl.ReplayDelivery(1, 2)
+		assert.Error(t, err)
+	})
+	t.Run("replayed delivery succeeds", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Slack.String(),
+			Payload: securestore.ToEncryptedString(`{}`), Status: repository.NotificationDeliveryDeadLetter, Attempts: 3, LastError: "unexpected response code: 502"})
+		impl := newDeliveryTestClient(t, deliveryLogRepository, &notifierStub{})
+		delivery, err := impl.ReplayDelivery(1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, repository.NotificationDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 4, delivery.Attempts)
+		assert.Empty(t, delivery.LastError)
+		deliveryLog, err := deliveryLogRepository.FindById(1)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), deliveryLog.UpdatedBy)
+	})
+	t.Run("failed replay stays dead lettered", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Slack.String(),
+			Payload: securestore.ToEncryptedString(`{}`), Status: repository.NotificationDeliveryDeadLetter, Attempts: 3})
+		impl := newDeliveryTestClient(t, deliveryLogRepository, &notifierStub{failAll: true})
+		delivery, err := impl.ReplayDelivery(1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, repository.NotificationDeliveryDeadLetter, delivery.Status)
+		assert.Equal(t, 4, delivery.Attempts)
+		assert.NotEmpty(t, delivery.LastError)
+	})
+	t.Run("delivery claimed by another replay is not sent again", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Slack.String(),
+			Payload: securestore.ToEncryptedString(`{}`), Status: repository.NotificationDeliveryDeadLetter, Attempts: 3})
+		deliveryLogRepository.claimedByOtherReplay = true
+		notifier := &notifierStub{}
+		impl := newDeliveryTestClient(t, deliveryLogRepository, notifier)
+		_, err := impl.ReplayDelivery(1, 2)
+		apiErr, ok := err.(*util.ApiError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, apiErr.HttpStatusCode)
+		assert.Empty(t, notifier.requests)
+		deliveryLog, err := deliveryLogRepository.FindById(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, deliveryLog.Attempts)
+	})
+	t.Run("chat card is replayed to the current webhook url of its config", func(t *testing.T) {
+		deliveryLogRepository := newDeliveryLogRepositoryStub(&repository.NotificationDeliveryLog{Id: 1, Channel: eventUtil.Teams.String(), ConfigId: 7,
+			Payload: securestore.ToEncryptedString(`{}`), Status: repository.NotificationDeliveryDeadLetter, Attempts: 3})
+		var requestPaths []string
+		impl := newDeliveryTestClient(t, deliveryLogRepository, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestPaths = append(requestPaths, r.URL.Path)
+		}))
+		delivery, err := impl.ReplayDelivery(1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, repository.NotificationDeliverySucceeded, delivery.Status)
+		assert.Equal(t, []string{"/teams/secret"}, requestPaths)
+	})
+}
@@ -0,0 +1,95 @@
+package bean
+
+// TeamsMessage is the payload accepted by Microsoft Teams incoming webhooks / workflows,
+// wrapping a single Adaptive Card attachment.
+type TeamsMessage struct {
+	Type        string             `json:"type"`
+	Attachments []*TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string        `json:"contentType"`
+	ContentUrl  *string       `json:"contentUrl"`
+	Content     *AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string                 `json:"$schema"`
+	Type    string                 `json:"type"`
+	Version string                 `json:"version"`
+	Body    []*AdaptiveCardElement `json:"body"`
+	Actions []*AdaptiveCardAction  `json:"actions,omitempty"`
+}
+
+type AdaptiveCardElement struct {
+	Type   string              `json:"type"`
+	Text   string              `json:"text,omitempty"`
+	Weight string              `json:"weight,omitempty"`
+	Size   string              `json:"size,omitempty"`
+	Color  string              `json:"color,omitempty"`
+	Wrap   bool                `json:"wrap,omitempty"`
+	Facts  []*AdaptiveCardFact `json:"facts,omitempty"`
+}
+
+type AdaptiveCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Url   string `json:"url"`
+}
+
+// GoogleChatMessage is the payload accepted by Google Chat incoming webhooks using cards v2.
+type GoogleChatMessage struct {
+	CardsV2 []*GoogleChatCardWithId `json:"cardsV2"`
+}
+
+type GoogleChatCardWithId struct {
+	CardId string          `json:"cardId"`
+	Card   *GoogleChatCard `json:"card"`
+}
+
+type GoogleChatCard struct {
+	Header   *GoogleChatCardHeader `json:"header"`
+	Sections []*GoogleChatSection  `json:"sections"`
+}
+
+type GoogleChatCardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type GoogleChatSection struct {
+	Widgets []*GoogleChatWidget `json:"widgets"`
+}
+
+type GoogleChatWidget struct {
+	DecoratedText *GoogleChatDecoratedText `json:"decoratedText,omitempty"`
+	ButtonList    *GoogleChatButtonList    `json:"buttonList,omitempty"`
+}
+
+type GoogleChatDecoratedText struct {
+	TopLabel string `json:"topLabel"`
+	Text     string `json:"text"`
+	WrapText bool   `json:"wrapText,omitempty"`
+}
+
+type GoogleChatButtonList struct {
+	Buttons []*GoogleChatButton `json:"buttons"`
+}
+
+type GoogleChatButton struct {
+	Text    string             `json:"text"`
+	OnClick *GoogleChatOnClick `json:"onClick"`
+}
+
+type GoogleChatOnClick struct {
+	OpenLink *GoogleChatOpenLink `json:"openLink"`
+}
+
+type GoogleChatOpenLink struct {
+	Url string `json:"url"`
+}
@@ -0,0 +1,8 @@
+package constants
+
+type SigningKeyType string
+
+const (
+	SIGNING_KEY_TYPE_GPG SigningKeyType = "GPG"
+	SIGNING_KEY_TYPE_SSH SigningKeyType = "SSH"
+)
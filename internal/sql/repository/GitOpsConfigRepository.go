@@ -64,6 +64,9 @@ type GitOpsConfig struct {
 	TlsKey                string                      `sql:"tls_key"`
 	CaCert                string                      `sql:"ca_cert"`
 	AuthMode              constants.AuthMode          `sql:"auth_mode"`
+	SigningKeyType        constants.SigningKeyType    `sql:"signing_key_type"`
+	SigningKey            securestore.EncryptedString `sql:"signing_key"`
+	SigningKeyPassphrase  securestore.EncryptedString `sql:"signing_key_passphrase"`
 	sql.AuditLog
 }
 
@@ -76,12 +79,9 @@ func (impl *GitOpsConfigRepositoryImpl) GetConnection() *pg.DB {
 }
 
 func (impl *GitOpsConfigRepositoryImpl) CreateGitOpsConfig(model *GitOpsConfig, tx *pg.Tx) (*GitOpsConfig, error) {
-	var err error
-	if impl.GlobalEnvVariables.EnablePasswordEncryption {
-		model.Token, err = securestore.EncryptString(model.Token.String())
-		if err != nil {
-			return model, err
-		}
+	err := impl.encryptCredentials(model)
+	if err != nil {
+		return model, err
 	}
 	err = tx.Insert(model)
 	if err != nil {
@@ -91,11 +91,9 @@ func (impl *GitOpsConfigRepositoryImpl) CreateGitOpsConfig(model *GitOpsConfig,
 	return model, nil
 }
 func (impl *GitOpsConfigRepositoryImpl) UpdateGitOpsConfig(model *GitOpsConfig, tx *pg.Tx) (err error) {
-	if impl.GlobalEnvVariables.EnablePasswordEncryption {
-		model.Token, err = securestore.EncryptString(model.Token.String())
-		if err != nil {
-			return err
-		}
+	err = impl.encryptCredentials(model)
+	if err != nil {
+		return err
 	}
 	err = tx.Update(model)
 	if err != nil {
@@ -104,6 +102,24 @@ func (impl *GitOpsConfigRepositoryImpl) UpdateGitOpsConfig(model *GitOpsConfig,
 	}
 	return nil
 }
+
+// encryptCredentials encrypts the token and the commit signing key along with its passphrase
+func (impl *GitOpsConfigRepositoryImpl) encryptCredentials(model *GitOpsConfig) (err error) {
+	if !impl.GlobalEnvVariables.EnablePasswordEncryption {
+		return nil
+	}
+	model.Token, err = securestore.EncryptString(model.Token.String())
+	if err != nil {
+		return err
+	}
+	model.SigningKey, err = securestore.EncryptString(model.SigningKey.String())
+	if err != nil {
+		return err
+	}
+	model.SigningKeyPassphrase, err = securestore.EncryptString(model.SigningKeyPassphrase.String())
+	return err
+}
+
 func (impl *GitOpsConfigRepositoryImpl) GetGitOpsConfigById(id int) (*GitOpsConfig, error) {
 	var model GitOpsConfig
 	err := impl.dbConnection.Model(&model).Where("id = ?", id).Select()
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type GoogleChatNotificationRepository interface {
+	FindOne(id int) (*GoogleChatConfig, error)
+	UpdateGoogleChatConfig(googleChatConfig *GoogleChatConfig) (*GoogleChatConfig, error)
+	SaveGoogleChatConfig(googleChatConfig *GoogleChatConfig) (*GoogleChatConfig, error)
+	FindAll() ([]GoogleChatConfig, error)
+	FindByName(value string) ([]GoogleChatConfig, error)
+	FindByIds(ids []*int) ([]*GoogleChatConfig, error)
+	MarkGoogleChatConfigDeleted(googleChatConfig *GoogleChatConfig) error
+}
+
+type GoogleChatNotificationRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewGoogleChatNotificationRepositoryImpl(dbConnection *pg.DB) *GoogleChatNotificationRepositoryImpl {
+	return &GoogleChatNotificationRepositoryImpl{dbConnection: dbConnection}
+}
+
+type GoogleChatConfig struct {
+	tableName   struct{} `sql:"google_chat_config" pg:",discard_unknown_columns"`
+	Id          int      `sql:"id,pk"`
+	WebHookUrl  string   `sql:"web_hook_url"`
+	ConfigName  string   `sql:"config_name"`
+	Description string   `sql:"description"`
+	OwnerId     int32    `sql:"owner_id"`
+	Deleted     bool     `sql:"deleted,notnull"`
+	sql.AuditLog
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) FindOne(id int) (*GoogleChatConfig, error) {
+	details := &GoogleChatConfig{}
+	err := impl.dbConnection.Model(details).Where("id = ?", id).
+		Where("deleted = ?", false).Select()
+	return details, err
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) FindAll() ([]GoogleChatConfig, error) {
+	var googleChatConfigs []GoogleChatConfig
+	err := impl.dbConnection.Model(&googleChatConfigs).
+		Where("deleted = ?", false).Select()
+	return googleChatConfigs, err
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) UpdateGoogleChatConfig(googleChatConfig *GoogleChatConfig) (*GoogleChatConfig, error) {
+	return googleChatConfig, impl.dbConnection.Update(googleChatConfig)
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) SaveGoogleChatConfig(googleChatConfig *GoogleChatConfig) (*GoogleChatConfig, error) {
+	return googleChatConfig, impl.dbConnection.Insert(googleChatConfig)
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) FindByName(value string) ([]GoogleChatConfig, error) {
+	var googleChatConfigs []GoogleChatConfig
+	err := impl.dbConnection.Model(&googleChatConfigs).Where(`config_name like ?`, "%"+value+"%").
+		Where("deleted = ?", false).Select()
+	return googleChatConfigs, err
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) FindByIds(ids []*int) ([]*GoogleChatConfig, error) {
+	var objects []*GoogleChatConfig
+	err := impl.dbConnection.Model(&objects).Where("id in (?)", pg.In(ids)).
+		Where("deleted = ?", false).Select()
+	return objects, err
+}
+
+func (impl *GoogleChatNotificationRepositoryImpl) MarkGoogleChatConfigDeleted(googleChatConfig *GoogleChatConfig) error {
+	googleChatConfig.Deleted = true
+	return impl.dbConnection.Update(googleChatConfig)
+}
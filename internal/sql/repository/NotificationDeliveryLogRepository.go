@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/common-lib/securestore"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	globalUtil "github.com/devtron-labs/devtron/util"
+	"github.com/go-pg/pg"
+	"time"
+)
+
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliverySucceeded  NotificationDeliveryStatus = "SUCCEEDED"
+	NotificationDeliveryRetrying   NotificationDeliveryStatus = "RETRYING"
+	NotificationDeliveryDeadLetter NotificationDeliveryStatus = "DEAD_LETTER"
+)
+
+type NotificationDeliveryLogRepository interface {
+	Save(deliveryLog *NotificationDeliveryLog) error
+	Update(deliveryLog *NotificationDeliveryLog) error
+	FindById(id int) (*NotificationDeliveryLog, error)
+	FindDueForRetry(dueOn time.Time, limit int) ([]*NotificationDeliveryLog, error)
+	ClaimForRetry(id int, dueOn time.Time, leaseUntil time.Time) (bool, error)
+	ClaimForReplay(id int, leaseUntil time.Time) (bool, error)
+	FindByStatus(status NotificationDeliveryStatus, offset, size int) ([]*NotificationDeliveryLog, error)
+	DeleteSucceededBefore(updatedBefore time.Time) (int, error)
+}
+
+type NotificationDeliveryLogRepositoryImpl struct {
+	dbConnection       *pg.DB
+	GlobalEnvVariables *globalUtil.GlobalEnvVariables
+}
+
+func NewNotificationDeliveryLogRepositoryImpl(dbConnection *pg.DB, variables *globalUtil.EnvironmentVariables) *NotificationDeliveryLogRepositoryImpl {
+	return &NotificationDeliveryLogRepositoryImpl{dbConnection: dbConnection, GlobalEnvVariables: variables.GlobalEnvVariables}
+}
+
+// NotificationDeliveryLog records the delivery of an event to a single destination, i.e. a channel config (and recipient)
+// delivered through notifier or a natively delivered chat channel. Failed deliveries are retried with backoff until
+// they are dead lettered. Destination holds only a redacted reference, the payload is stored encrypted.
+type NotificationDeliveryLog struct {
+	tableName     struct{}                    `sql:"notification_delivery_log" pg:",discard_unknown_columns"`
+	Id            int                         `sql:"id,pk"`
+	CorrelationId string                      `sql:"correlation_id"`
+	EventTypeId   int                         `sql:"event_type_id"`
+	PipelineType  string                      `sql:"pipeline_type"`
+	PipelineId    int                         `sql:"pipeline_id"`
+	AppId         int                         `sql:"app_id"`
+	EnvId         int                         `sql:"env_id"`
+	Channel       string                      `sql:"channel"`
+	ConfigId      int                         `sql:"config_id"`
+	Destination   string                      `sql:"destination"`
+	Payload       securestore.EncryptedString `sql:"payload"`
+	PayloadHash   string                      `sql:"payload_hash"`
+	Status        NotificationDeliveryStatus  `sql:"status"`
+	Attempts      int                         `sql:"attempts"`
+	LastError     string                      `sql:"last_error"`
+	NextRetryOn   time.Time                   `sql:"next_retry_on"`
+	sql.AuditLog
+}
+
+func (impl *NotificationDeliveryLogRepositoryImpl) Save(deliveryLog *NotificationDeliveryLog) error {
+	if impl.GlobalEnvVariables.EnablePasswordEncryption {
+		payload, err := securestore.EncryptString(deliveryLog.Payload.String())
+		if err != nil {
+			return err
+		}
+		deliveryLog.Payload = payload
+	}
+	return impl.dbConnection.Insert(deliveryLog)
+}
+
+// Update saves the outcome of a delivery attempt, the payload is never updated
+func (impl *NotificationDeliveryLogRepositoryImpl) Update(deliveryLog *NotificationDeliveryLog) error {
+	_, err := impl.dbConnection.Model(deliveryLog).
+		Column("status", "attempts", "last_error", "next_retry_on", "updated_on", "updated_by").
+		WherePK().
+		Update()
+	return err
+}
+
+func (impl *NotificationDeliveryLogRepositoryImpl) FindById(id int) (*NotificationDeliveryLog, error) {
+	deliveryLog := &NotificationDeliveryLog{}
+	err := impl.dbConnection.Model(deliveryLog).Where("id = ?", id).Select()
+	return deliveryLog, err
+}
+
+func (impl *NotificationDeliveryLogRepositoryImpl) FindDueForRetry(dueOn time.Time, limit int) ([]*NotificationDeliveryLog, error) {
+	var deliveryLogs []*NotificationDeliveryLog
+	err := impl.dbConnection.Model(&deliveryLogs).
+		Where("status = ?", NotificationDeliveryRetrying).
+		Where("next_retry_on <= ?", dueOn).
+		Order("next_retry_on ASC").
+		Limit(limit).
+		Select()
+	return deliveryLogs, err
+}
+
+// ClaimForRetry pushes the next retry of a due delivery to leaseUntil, so that only the replica which claims it
+// redelivers it. It returns false if the delivery was already claimed by another replica.
+func (impl *NotificationDeliveryLogRepositoryImpl) ClaimForRetry(id int, dueOn time.Time, leaseUntil time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*NotificationDeliveryLog)(nil)).
+		Set("next_retry_on = ?", leaseUntil).
+		Where("id = ?", id).
+		Where("status = ?", NotificationDeliveryRetrying).
+		Where("next_retry_on = ?", dueOn).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+// ClaimForReplay moves a dead lettered delivery to retrying with its next retry at leaseUntil, so that it is replayed
+// only once and is picked up by the retry cron after the lease if the replay is interrupted. It returns false if the
+// delivery is not dead lettered anymore, i.e. it was already claimed for a replay.
+func (impl *NotificationDeliveryLogRepositoryImpl) ClaimForReplay(id int, leaseUntil time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*NotificationDeliveryLog)(nil)).
+		Set("status = ?", NotificationDeliveryRetrying).
+		Set("next_retry_on = ?", leaseUntil).
+		Where("id = ?", id).
+		Where("status = ?", NotificationDeliveryDeadLetter).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+// FindByStatus returns the latest delivery logs, of all statuses if status is empty
+func (impl *NotificationDeliveryLogRepositoryImpl) FindByStatus(status NotificationDeliveryStatus, offset, size int) ([]*NotificationDeliveryLog, error) {
+	var deliveryLogs []*NotificationDeliveryLog
+	query := impl.dbConnection.Model(&deliveryLogs)
+	if len(status) > 0 {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("id DESC").
+		Offset(offset).
+		Limit(size).
+		Select()
+	return deliveryLogs, err
+}
+
+func (impl *NotificationDeliveryLogRepositoryImpl) DeleteSucceededBefore(updatedBefore time.Time) (int, error) {
+	res, err := impl.dbConnection.Model(&NotificationDeliveryLog{}).
+		Where("status = ?", NotificationDeliverySucceeded).
+		Where("updated_on < ?", updatedBefore).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"time"
+)
+
+type NotificationDigestEventRepository interface {
+	Save(digestEvent *NotificationDigestEvent) error
+	// FindAllPending returns the pending events and the events whose sending claim is older than staleBefore
+	FindAllPending(staleBefore time.Time) ([]*NotificationDigestEvent, error)
+	// ClaimForSending moves the given events to sending, only the events claimed by this call are returned so
+	// that an event is sent by only one instance
+	ClaimForSending(ids []int, staleBefore time.Time) ([]*NotificationDigestEvent, error)
+	// ReleaseClaim moves events which could not be sent back to pending
+	ReleaseClaim(ids []int) error
+	MarkFlushed(ids []int, flushedOn time.Time) error
+	DeleteFlushedBefore(flushedBefore time.Time) (int, error)
+}
+
+type NotificationDigestEventStatus string
+
+const (
+	NotificationDigestEventPending NotificationDigestEventStatus = "PENDING"
+	NotificationDigestEventSending NotificationDigestEventStatus = "SENDING"
+	NotificationDigestEventSent    NotificationDigestEventStatus = "SENT"
+)
+
+type NotificationDigestEventRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewNotificationDigestEventRepositoryImpl(dbConnection *pg.DB) *NotificationDigestEventRepositoryImpl {
+	return &NotificationDigestEventRepositoryImpl{dbConnection: dbConnection}
+}
+
+// NotificationDigestEvent is an event held back for a notification setting configured in digest mode,
+// it is sent as part of a summarised message once the digest interval of the setting has elapsed
+type NotificationDigestEvent struct {
+	tableName    struct{}                      `sql:"notification_digest_event" pg:",discard_unknown_columns"`
+	Id           int                           `sql:"id,pk"`
+	ViewId       int                           `sql:"view_id"`
+	EventTypeId  int                           `sql:"event_type_id"`
+	PipelineType string                        `sql:"pipeline_type"`
+	PipelineId   int                           `sql:"pipeline_id"`
+	AppId        int                           `sql:"app_id"`
+	EnvId        int                           `sql:"env_id"`
+	Event        string                        `sql:"event"` // json of the complete event as built for immediate delivery
+	Status       NotificationDigestEventStatus `sql:"status,notnull"`
+	ClaimedOn    time.Time                     `sql:"claimed_on"`
+	FlushedOn    time.Time                     `sql:"flushed_on"`
+	CreatedOn    time.Time                     `sql:"created_on"`
+}
+
+func (impl *NotificationDigestEventRepositoryImpl) Save(digestEvent *NotificationDigestEvent) error {
+	if len(digestEvent.Status) == 0 {
+		digestEvent.Status = NotificationDigestEventPending
+	}
+	return impl.dbConnection.Insert(digestEvent)
+}
+
+// whereDigestEventClaimable matches the pending events and the events left in sending by an instance which stopped
+func whereDigestEventClaimable(staleBefore time.Time) func(q *orm.Query) (*orm.Query, error) {
+	return func(q *orm.Query) (*orm.Query, error) {
+		q = q.WhereOr("status = ?", NotificationDigestEventPending).
+			WhereOr("status = ? AND claimed_on < ?", NotificationDigestEventSending, staleBefore)
+		return q, nil
+	}
+}
+
+func (impl *NotificationDigestEventRepositoryImpl) FindAllPending(staleBefore time.Time) ([]*NotificationDigestEvent, error) {
+	var digestEvents []*NotificationDigestEvent
+	err := impl.dbConnection.Model(&digestEvents).
+		WhereGroup(whereDigestEventClaimable(staleBefore)).
+		Order("created_on ASC").
+		Select()
+	return digestEvents, err
+}
+
+func (impl *NotificationDigestEventRepositoryImpl) ClaimForSending(ids []int, staleBefore time.Time) ([]*NotificationDigestEvent, error) {
+	var digestEvents []*NotificationDigestEvent
+	if len(ids) == 0 {
+		return digestEvents, nil
+	}
+	_, err := impl.dbConnection.Model(&digestEvents).
+		Set("status = ?", NotificationDigestEventSending).
+		Set("claimed_on = ?", time.Now()).
+		Where("id IN (?)", pg.In(ids)).
+		WhereGroup(whereDigestEventClaimable(staleBefore)).
+		Returning("*").
+		Update()
+	return digestEvents, err
+}
+
+func (impl *NotificationDigestEventRepositoryImpl) ReleaseClaim(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := impl.dbConnection.Model(&NotificationDigestEvent{}).
+		Set("status = ?", NotificationDigestEventPending).
+		Where("id IN (?)", pg.In(ids)).
+		Where("status = ?", NotificationDigestEventSending).
+		Update()
+	return err
+}
+
+func (impl *NotificationDigestEventRepositoryImpl) MarkFlushed(ids []int, flushedOn time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := impl.dbConnection.Model(&NotificationDigestEvent{}).
+		Set("status = ?", NotificationDigestEventSent).
+		Set("flushed_on = ?", flushedOn).
+		Where("id IN (?)", pg.In(ids)).
+		Update()
+	return err
+}
+
+func (impl *NotificationDigestEventRepositoryImpl) DeleteFlushedBefore(flushedBefore time.Time) (int, error) {
+	res, err := impl.dbConnection.Model(&NotificationDigestEvent{}).
+		Where("status = ?", NotificationDigestEventSent).
+		Where("flushed_on < ?", flushedBefore).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
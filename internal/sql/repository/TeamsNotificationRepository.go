@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+)
+
+type TeamsNotificationRepository interface {
+	FindOne(id int) (*TeamsConfig, error)
+	UpdateTeamsConfig(teamsConfig *TeamsConfig) (*TeamsConfig, error)
+	SaveTeamsConfig(teamsConfig *TeamsConfig) (*TeamsConfig, error)
+	FindAll() ([]TeamsConfig, error)
+	FindByName(value string) ([]TeamsConfig, error)
+	FindByIds(ids []*int) ([]*TeamsConfig, error)
+	MarkTeamsConfigDeleted(teamsConfig *TeamsConfig) error
+}
+
+type TeamsNotificationRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewTeamsNotificationRepositoryImpl(dbConnection *pg.DB) *TeamsNotificationRepositoryImpl {
+	return &TeamsNotificationRepositoryImpl{dbConnection: dbConnection}
+}
+
+type TeamsConfig struct {
+	tableName   struct{} `sql:"teams_config" pg:",discard_unknown_columns"`
+	Id          int      `sql:"id,pk"`
+	WebHookUrl  string   `sql:"web_hook_url"`
+	ConfigName  string   `sql:"config_name"`
+	Description string   `sql:"description"`
+	OwnerId     int32    `sql:"owner_id"`
+	Deleted     bool     `sql:"deleted,notnull"`
+	sql.AuditLog
+}
+
+func (impl *TeamsNotificationRepositoryImpl) FindOne(id int) (*TeamsConfig, error) {
+	details := &TeamsConfig{}
+	err := impl.dbConnection.Model(details).Where("id = ?", id).
+		Where("deleted = ?", false).Select()
+	return details, err
+}
+
+func (impl *TeamsNotificationRepositoryImpl) FindAll() ([]TeamsConfig, error) {
+	var teamsConfigs []TeamsConfig
+	err := impl.dbConnection.Model(&teamsConfigs).
+		Where("deleted = ?", false).Select()
+	return teamsConfigs, err
+}
+
+func (impl *TeamsNotificationRepositoryImpl) UpdateTeamsConfig(teamsConfig *TeamsConfig) (*TeamsConfig, error) {
+	return teamsConfig, impl.dbConnection.Update(teamsConfig)
+}
+
+func (impl *TeamsNotificationRepositoryImpl) SaveTeamsConfig(teamsConfig *TeamsConfig) (*TeamsConfig, error) {
+	return teamsConfig, impl.dbConnection.Insert(teamsConfig)
+}
+
+func (impl *TeamsNotificationRepositoryImpl) FindByName(value string) ([]TeamsConfig, error) {
+	var teamsConfigs []TeamsConfig
+	err := impl.dbConnection.Model(&teamsConfigs).Where(`config_name like ?`, "%"+value+"%").
+		Where("deleted = ?", false).Select()
+	return teamsConfigs, err
+}
+
+func (impl *TeamsNotificationRepositoryImpl) FindByIds(ids []*int) ([]*TeamsConfig, error) {
+	var objects []*TeamsConfig
+	err := impl.dbConnection.Model(&objects).Where("id in (?)", pg.In(ids)).
+		Where("deleted = ?", false).Select()
+	return objects, err
+}
+
+func (impl *TeamsNotificationRepositoryImpl) MarkTeamsConfigDeleted(teamsConfig *TeamsConfig) error {
+	teamsConfig.Deleted = true
+	return impl.dbConnection.Update(teamsConfig)
+}
@@ -78,6 +78,8 @@ type PipelineOverrideRepository interface {
 	GetLatestReleaseDeploymentType(pipelineIds []int) ([]*PipelineOverride, error)
 	FindLatestByAppIdAndEnvId(appId, environmentId int, deploymentAppType string) (pipelineOverrides *PipelineOverride, err error)
 	FindLatestByCdWorkflowId(cdWorkflowId int) (pipelineOverride *PipelineOverride, err error)
+	// FindLatestCommittedByPipelineId returns the latest override of the pipeline which was committed to the gitops repository
+	FindLatestCommittedByPipelineId(pipelineId int) (pipelineOverride *PipelineOverride, err error)
 }
 
 type PipelineOverrideRepositoryImpl struct {
@@ -333,3 +335,14 @@ func (impl PipelineOverrideRepositoryImpl) FindLatestByCdWorkflowId(cdWorkflowId
 		Select()
 	return &override, err
 }
+
+func (impl PipelineOverrideRepositoryImpl) FindLatestCommittedByPipelineId(pipelineId int) (*PipelineOverride, error) {
+	var override PipelineOverride
+	err := impl.dbConnection.Model(&override).
+		Where("pipeline_id = ?", pipelineId).
+		Where("git_hash IS NOT NULL").
+		Where("git_hash <> ''").
+		Order("id DESC").Limit(1).
+		Select()
+	return &override, err
+}
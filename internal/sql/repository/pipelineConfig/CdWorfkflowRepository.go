@@ -53,6 +53,7 @@ type CdWorkflowRepository interface {
 	FindPreviousCdWfRunnerByStatus(pipelineId int, currentWFRunnerId int, status []string) ([]*CdWorkflowRunner, error)
 	FindWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error)
 	FindPreOrPostCdWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error)
+	FindPreOrPostCdWorkflowRunnersByExecutorTypeAndStatusesIn(executorType cdWorkflow.WorkflowExecutorType, activeStatuses []string) ([]*CdWorkflowRunner, error)
 	FindBasicWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error)
 	FindRetriedWorkflowCountByReferenceId(wfrId int) (int, error)
 	FindLatestWfrByAppIdAndEnvironmentId(appId int, environmentId int) (*CdWorkflowRunner, error)
@@ -540,6 +541,17 @@ func (impl *CdWorkflowRepositoryImpl) FindPreOrPostCdWorkflowRunnerById(wfrId in
 	return wfr, err
 }
 
+func (impl *CdWorkflowRepositoryImpl) FindPreOrPostCdWorkflowRunnersByExecutorTypeAndStatusesIn(executorType cdWorkflow.WorkflowExecutorType, activeStatuses []string) ([]*CdWorkflowRunner, error) {
+	var wfrs []*CdWorkflowRunner
+	err := impl.dbConnection.Model(&wfrs).
+		Column("cd_workflow_runner.*", "CdWorkflow", "CdWorkflow.Pipeline", "CdWorkflow.Pipeline.Environment").
+		Where("cd_workflow_runner.executor_type = ?", executorType).
+		Where("cd_workflow_runner.status in (?)", pg.In(activeStatuses)).
+		Where("cd_workflow_runner.workflow_type != ?", apiBean.CD_WORKFLOW_TYPE_DEPLOY).
+		Select()
+	return wfrs, err
+}
+
 func (impl *CdWorkflowRepositoryImpl) FindBasicWorkflowRunnerById(wfrId int) (*CdWorkflowRunner, error) {
 	wfr := &CdWorkflowRunner{}
 	err := impl.dbConnection.Model(wfr).
@@ -695,7 +707,7 @@ func (impl *CdWorkflowRepositoryImpl) FetchArtifactsByCdPipelineIdV2(listingFilt
 func (impl *CdWorkflowRepositoryImpl) GetLatestTriggersOfPipelinesStuckInNonTerminalStatuses(getPipelineDeployedWithinHours int, deploymentAppType string) ([]*CdWorkflowRunner, error) {
 	var wfrList []*CdWorkflowRunner
 	excludedStatusList := cdWorkflow.WfrTerminalStatusList
-	excludedStatusList = append(excludedStatusList, cdWorkflow.WorkflowInitiated, cdWorkflow.WorkflowInQueue, cdWorkflow.WorkflowStarting)
+	excludedStatusList = append(excludedStatusList, cdWorkflow.WorkflowInitiated, cdWorkflow.WorkflowInQueue, cdWorkflow.WorkflowStarting, cdWorkflow.WorkflowAwaitingMerge)
 	err := impl.dbConnection.
 		Model(&wfrList).
 		Column("cd_workflow_runner.*", "CdWorkflow.id", "CdWorkflow.pipeline_id", "CdWorkflow.Pipeline.id", "CdWorkflow.Pipeline.app_id", "CdWorkflow.Pipeline.environment_id", "CdWorkflow.Pipeline.deployment_app_name", "CdWorkflow.Pipeline.deleted", "CdWorkflow.Pipeline.Environment").
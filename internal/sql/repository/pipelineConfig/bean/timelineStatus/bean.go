@@ -51,6 +51,11 @@ const (
 	TIMELINE_STATUS_UNABLE_TO_FETCH_STATUS TimelineStatus = "UNABLE_TO_FETCH_STATUS"
 	TIMELINE_STATUS_DEPLOYMENT_SUPERSEDED  TimelineStatus = "DEPLOYMENT_SUPERSEDED"
 	TIMELINE_STATUS_MANIFEST_GENERATED     TimelineStatus = "HELM_PACKAGE_GENERATED" // TODO: remove as this deployment type is not supported
+	// TIMELINE_STATUS_PROMOTION_BLOCKED - is recorded on the source deployment when the promotion condition of a child pipeline blocks its auto trigger
+	TIMELINE_STATUS_PROMOTION_BLOCKED TimelineStatus = "PROMOTION_BLOCKED"
+	// TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED - is recorded when the values are pushed to a pull request instead of the target revision.
+	// The GIT_COMMIT timeline follows once the pull request is merged.
+	TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED TimelineStatus = "GIT_PULL_REQUEST_RAISED"
 )
 
 const (
@@ -62,4 +67,7 @@ const (
 	TIMELINE_DESCRIPTION_ARGOCD_SYNC_COMPLETED        string = "ArgoCD sync completed."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_COMPLETED         string = "Deployment has been performed successfully. Waiting for application to be healthy..."
 	TIMELINE_DESCRIPTION_DEPLOYMENT_SUPERSEDED        string = "This deployment is superseded."
+	TIMELINE_DESCRIPTION_PROMOTION_BLOCKED            string = "Promotion to pipeline %s blocked: %s"
+	TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_RAISED      string = "Pull request %s raised. Waiting for it to be merged..."
+	TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_MERGED      string = "Pull request %s merged successfully."
 )
@@ -35,6 +35,8 @@ const (
 	WorkflowTypePre            = "PRE"
 	WorkflowTypePost           = "POST"
 	WorkflowWaitingToStart     = "WaitingToStart"
+	// WorkflowAwaitingMerge is set on deployments whose values are pushed through a gitops pull request, until the pull request is merged or closed
+	WorkflowAwaitingMerge = "AwaitingMerge"
 )
 
 func (a WorkflowStatus) String() string {
@@ -46,14 +48,22 @@ var ErrorDeploymentSuperseded = errors.New(NEW_DEPLOYMENT_INITIATED)
 const (
 	WORKFLOW_EXECUTOR_TYPE_AWF    = "AWF"
 	WORKFLOW_EXECUTOR_TYPE_SYSTEM = "SYSTEM"
+	WORKFLOW_EXECUTOR_TYPE_TEKTON = "TEKTON"
 	NEW_DEPLOYMENT_INITIATED      = "A new deployment was initiated before this deployment completed!"
 	PIPELINE_DELETED              = "The pipeline has been deleted!"
 	FOUND_VULNERABILITY           = "Found vulnerability on image"
 	GITOPS_REPO_NOT_CONFIGURED    = "GitOps repository is not configured for the app"
+	GITOPS_PULL_REQUEST_CLOSED    = "The gitops pull request was closed without merging!"
 )
 
 type WorkflowExecutorType string
 
+// IsArgoWorkflowExecutor checks if the workflow is executed by argo workflows,
+// the workflows triggered before the executor type was saved are executed by argo workflows
+func (executorType WorkflowExecutorType) IsArgoWorkflowExecutor() bool {
+	return executorType == "" || executorType == WORKFLOW_EXECUTOR_TYPE_AWF
+}
+
 type CdWorkflowRunnerArtifactMetadata struct {
 	AppId            int  `pg:"app_id"`
 	EnvId            int  `pg:"env_id"`
@@ -16,10 +16,14 @@
 
 package apiToken
 
-import "github.com/golang-jwt/jwt/v4"
+import (
+	"github.com/devtron-labs/devtron/pkg/apiToken/bean"
+	"github.com/golang-jwt/jwt/v4"
+)
 
 type ApiTokenCustomClaims struct {
-	Email   string `json:"email"`
-	Version string `json:"version"`
+	Email   string              `json:"email"`
+	Version string              `json:"version"`
+	Scope   *bean.ApiTokenScope `json:"scope,omitempty"`
 	jwt.RegisteredClaims
 }
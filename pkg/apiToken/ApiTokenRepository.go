@@ -18,10 +18,12 @@ package apiToken
 
 import (
 	"fmt"
+	"github.com/devtron-labs/devtron/pkg/apiToken/bean"
 	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/go-pg/pg"
 	"github.com/go-pg/pg/orm"
+	"time"
 )
 
 type ApiToken struct {
@@ -33,7 +35,14 @@ type ApiToken struct {
 	Description  string   `sql:"description, notnull"`
 	ExpireAtInMs int64    `sql:"expire_at_in_ms"`
 	Token        string   `sql:"token, notnull"`
-	User         *repository.UserModel
+	// Scope is embedded in the token as well, nil for the tokens with full access
+	Scope *bean.ApiTokenScope `sql:"scope"`
+	// PreviousVersion of the token stays valid till PreviousVersionExpireAtInMs after a rotation
+	PreviousVersion             int       `sql:"previous_version"`
+	PreviousVersionExpireAtInMs int64     `sql:"previous_version_expire_at_in_ms"`
+	LastUsedAt                  time.Time `sql:"last_used_at"`
+	LastUsedByIp                string    `sql:"last_used_by_ip"`
+	User                        *repository.UserModel
 	sql.AuditLog
 }
 
@@ -44,6 +53,7 @@ type ApiTokenRepository interface {
 	FindActiveById(id int) (*ApiToken, error)
 	FindByName(name string) (*ApiToken, error)
 	UpdateIf(apiToken *ApiToken, previousTokenVersion int) error
+	UpdateLastUsed(name string, lastUsedAt time.Time, lastUsedByIp string) error
 }
 
 type ApiTokenRepositoryImpl struct {
@@ -76,6 +86,15 @@ func (impl ApiTokenRepositoryImpl) UpdateIf(apiToken *ApiToken, previousTokenVer
 	return nil
 }
 
+func (impl ApiTokenRepositoryImpl) UpdateLastUsed(name string, lastUsedAt time.Time, lastUsedByIp string) error {
+	_, err := impl.dbConnection.Model((*ApiToken)(nil)).
+		Set("last_used_at = ?", lastUsedAt).
+		Set("last_used_by_ip = ?", lastUsedByIp).
+		Where("name = ?", name).
+		Update()
+	return err
+}
+
 func (impl ApiTokenRepositoryImpl) FindAllActive() ([]*ApiToken, error) {
 	var apiTokens []*ApiToken
 	err := impl.dbConnection.Model(&apiTokens).
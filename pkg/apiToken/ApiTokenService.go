@@ -19,13 +19,18 @@ package apiToken
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/apiToken/bean"
 	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	userHelper "github.com/devtron-labs/devtron/pkg/auth/user/helper"
 
 	"github.com/devtron-labs/authenticator/middleware"
 	openapi "github.com/devtron-labs/devtron/api/openapi/openapiClient"
@@ -41,6 +46,11 @@ type ApiTokenService interface {
 	CreateApiToken(request *openapi.CreateApiTokenRequest, createdBy int32, managerAuth func(resource, token, object string) bool) (*openapi.CreateApiTokenResponse, error)
 	UpdateApiToken(apiTokenId int, request *openapi.UpdateApiTokenRequest, updatedBy int32) (*openapi.UpdateApiTokenResponse, error)
 	DeleteApiToken(apiTokenId int, deletedBy int32) (*openapi.ActionResponse, error)
+	// RotateApiToken issues a new token, the previous token stays valid for the overlap window
+	RotateApiToken(apiTokenId int, request *openapi.RotateApiTokenRequest, rotatedBy int32) (*openapi.RotateApiTokenResponse, error)
+	// VerifyApiToken returns nil claims for the tokens not issued as api tokens
+	VerifyApiToken(token string) (*ApiTokenCustomClaims, error)
+	RecordApiTokenUsage(claims *ApiTokenCustomClaims, clientIp string)
 	GetAllApiTokensForWebhook(projectName string, environmentName string, appName string, auth func(token string, projectObject string, envObject string) bool) ([]*openapi.ApiToken, error)
 }
 
@@ -51,6 +61,8 @@ type ApiTokenServiceImpl struct {
 	userAuditService      user2.UserAuditService
 	apiTokenRepository    ApiTokenRepository
 	TokenVariableConfig   *TokenVariableConfig
+	// usageRecordedAt holds the time of the last usage recorded in DB for a token name
+	usageRecordedAt *sync.Map
 }
 
 func NewApiTokenServiceImpl(logger *zap.SugaredLogger,
@@ -65,6 +77,7 @@ func NewApiTokenServiceImpl(logger *zap.SugaredLogger,
 		userService:           userService,
 		userAuditService:      userAuditService,
 		apiTokenRepository:    apiTokenRepository,
+		usageRecordedAt:       &sync.Map{},
 	}
 
 	cfg, err := GetTokenConfig()
@@ -84,7 +97,11 @@ func GetTokenConfig() (*TokenVariableConfig, error) {
 }
 
 type TokenVariableConfig struct {
-	HideApiTokens bool `env:"HIDE_API_TOKENS" envDefault:"false" description:"Boolean flag for should the api tokens generated be hidden from the UI"`
+	HideApiTokens                     bool `env:"HIDE_API_TOKENS" envDefault:"false" description:"Boolean flag for should the api tokens generated be hidden from the UI"`
+	ApiTokenRotationOverlapInMins     int  `env:"API_TOKEN_ROTATION_OVERLAP_IN_MINS" envDefault:"60" description:"Duration in minutes for which the previous token stays valid after a rotation, if not provided in the request"`
+	ApiTokenUsageRecordIntervalInSecs int  `env:"API_TOKEN_USAGE_RECORD_INTERVAL_IN_SECS" envDefault:"60" description:"Minimum interval in seconds between two last used updates of an api token"`
+	// ApiTokenTrustedProxyCidrs the X-Forwarded-For header is used for the ip allowlist only if the request comes from these proxies
+	ApiTokenTrustedProxyCidrs []string `env:"API_TOKEN_TRUSTED_PROXY_CIDRS" envDefault:"" envSeparator:"," description:"Comma separated ips/cidrs of the proxies (e.g. ingress controller) trusted to set the X-Forwarded-For header for the api token ip allowlist"`
 }
 
 var invalidCharsInApiTokenName = regexp.MustCompile("[,\\s]")
@@ -93,6 +110,8 @@ const (
 	ConcurrentTokenUpdateRequest  = "there is an ongoing request for the token with the same name, please try again after some time"
 	UniqueKeyViolationPgErrorCode = 23505
 	TokenVersionMismatch          = "token version mismatch"
+	// MaxApiTokenRotationOverlapInMins caps the overlap window of a rotation to a week
+	MaxApiTokenRotationOverlapInMins = 7 * 24 * 60
 )
 
 func (impl ApiTokenServiceImpl) GetAllApiTokensForWebhook(projectName string, environmentName string, appName string, auth func(token string, projectObject string, envObject string) bool) ([]*openapi.ApiToken, error) {
@@ -153,12 +172,6 @@ func (impl ApiTokenServiceImpl) GetAllActiveApiTokens() ([]*openapi.ApiToken, er
 	var apiTokens []*openapi.ApiToken
 	for _, apiTokenFromDb := range apiTokensFromDb {
 		userId := apiTokenFromDb.User.Id
-		latestAuditLog, err := impl.userAuditService.GetLatestByUserId(userId)
-		if err != nil {
-			impl.logger.Errorw("error while getting latest audit log", "error", err)
-			return nil, err
-		}
-
 		apiTokenIdI32 := int32(apiTokenFromDb.Id)
 		updatedAtStr := apiTokenFromDb.UpdatedOn.String()
 		apiToken := &openapi.ApiToken{
@@ -169,14 +182,30 @@ func (impl ApiTokenServiceImpl) GetAllActiveApiTokens() ([]*openapi.ApiToken, er
 			Description:    &apiTokenFromDb.Description,
 			ExpireAtInMs:   &apiTokenFromDb.ExpireAtInMs,
 			UpdatedAt:      &updatedAtStr,
+			Scope:          adaptToOpenapiApiTokenScope(apiTokenFromDb.Scope),
 		}
 		if !impl.TokenVariableConfig.HideApiTokens {
 			apiToken.Token = &apiTokenFromDb.Token
 		}
-		if latestAuditLog != nil {
-			lastUsedAtStr := latestAuditLog.CreatedOn.String()
+		if apiTokenFromDb.PreviousVersion > 0 && apiTokenFromDb.PreviousVersionExpireAtInMs > time.Now().UnixMilli() {
+			apiToken.PreviousTokenExpireAtInMs = &apiTokenFromDb.PreviousVersionExpireAtInMs
+		}
+		if !apiTokenFromDb.LastUsedAt.IsZero() {
+			lastUsedAtStr := apiTokenFromDb.LastUsedAt.String()
 			apiToken.LastUsedAt = &lastUsedAtStr
-			apiToken.LastUsedByIp = &latestAuditLog.ClientIp
+			apiToken.LastUsedByIp = &apiTokenFromDb.LastUsedByIp
+		} else {
+			// usage of the tokens is tracked on the api token since the scoped tokens, older tokens fall back to the user audit
+			latestAuditLog, err := impl.userAuditService.GetLatestByUserId(userId)
+			if err != nil {
+				impl.logger.Errorw("error while getting latest audit log", "error", err)
+				return nil, err
+			}
+			if latestAuditLog != nil {
+				lastUsedAtStr := latestAuditLog.CreatedOn.String()
+				apiToken.LastUsedAt = &lastUsedAtStr
+				apiToken.LastUsedByIp = &latestAuditLog.ClientIp
+			}
 		}
 		apiTokens = append(apiTokens, apiToken)
 	}
@@ -209,6 +238,14 @@ func (impl ApiTokenServiceImpl) CreateApiToken(request *openapi.CreateApiTokenRe
 
 	impl.logger.Info(fmt.Sprintf("apiTokenExists : %s", strconv.FormatBool(apiTokenExists)))
 
+	scope := adaptToApiTokenScope(request.Scope)
+	if scope != nil {
+		if err = scope.Validate(); err != nil {
+			impl.logger.Errorw("invalid api token scope", "name", name, "scope", request.Scope, "err", err)
+			return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+		}
+	}
+
 	// step-2 - Build email and version
 	email := fmt.Sprintf("%s%s", userBean.API_TOKEN_USER_EMAIL_PREFIX, name)
 	var (
@@ -231,7 +268,7 @@ func (impl ApiTokenServiceImpl) CreateApiToken(request *openapi.CreateApiTokenRe
 		expireAtInMs = time.Now().AddDate(1, 0, 0).UnixMilli()
 	}
 
-	token, err := impl.createApiJwtToken(email, tokenVersion, expireAtInMs)
+	token, err := impl.createApiJwtToken(email, tokenVersion, expireAtInMs, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -261,6 +298,7 @@ func (impl ApiTokenServiceImpl) CreateApiToken(request *openapi.CreateApiTokenRe
 		ExpireAtInMs: expireAtInMs,
 		Token:        token,
 		Version:      tokenVersion,
+		Scope:        scope,
 		AuditLog:     sql.AuditLog{UpdatedOn: time.Now()},
 	}
 	if apiTokenExists {
@@ -322,12 +360,15 @@ func (impl ApiTokenServiceImpl) UpdateApiToken(apiTokenId int, request *openapi.
 	// step-2 - If expires_at is not same, then token needs to be generated again
 	if *request.ExpireAtInMs != apiToken.ExpireAtInMs {
 		// regenerate token
-		token, err := impl.createApiJwtToken(apiToken.User.EmailId, tokenVersion, *request.ExpireAtInMs)
+		token, err := impl.createApiJwtToken(apiToken.User.EmailId, tokenVersion, *request.ExpireAtInMs, apiToken.Scope)
 		if err != nil {
 			return nil, err
 		}
 		apiToken.Token = token
 		apiToken.Version = tokenVersion
+		// a regenerated token revokes the previous token at once, overlap is kept only for rotation
+		apiToken.PreviousVersion = 0
+		apiToken.PreviousVersionExpireAtInMs = 0
 	}
 
 	// step-3 - update in DB
@@ -391,15 +432,124 @@ func (impl ApiTokenServiceImpl) DeleteApiToken(apiTokenId int, deletedBy int32)
 
 }
 
-func (impl ApiTokenServiceImpl) createApiJwtToken(email string, tokenVersion int, expireAtInMs int64) (string, error) {
+func (impl ApiTokenServiceImpl) RotateApiToken(apiTokenId int, request *openapi.RotateApiTokenRequest, rotatedBy int32) (*openapi.RotateApiTokenResponse, error) {
+	impl.logger.Infow("Rotating API token", "request", request, "rotatedBy", rotatedBy, "apiTokenId", apiTokenId)
+
+	// step-1 - check if the api-token exists, if not exists - throw error
+	apiToken, err := impl.apiTokenRepository.FindActiveById(apiTokenId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error while getting api token by id", "apiTokenId", apiTokenId, "error", err)
+		return nil, err
+	}
+	if apiToken == nil || apiToken.Id == 0 {
+		return nil, pg.ErrNoRows
+	}
+
+	overlapWindowInMins := impl.TokenVariableConfig.ApiTokenRotationOverlapInMins
+	if request.OverlapWindowInMins != nil {
+		overlapWindowInMins = int(*request.OverlapWindowInMins)
+	}
+	if overlapWindowInMins < 0 || overlapWindowInMins > MaxApiTokenRotationOverlapInMins {
+		errMsg := fmt.Sprintf("overlap window should be between 0 and %d minutes", MaxApiTokenRotationOverlapInMins)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	expireAtInMs := apiToken.ExpireAtInMs
+	if request.ExpireAtInMs != nil {
+		expireAtInMs = *request.ExpireAtInMs
+	}
+
+	// step-2 - issue the next version with the same scope, the current version stays valid till the overlap window
+	previousTokenVersion := apiToken.Version
+	tokenVersion := apiToken.Version + 1
+	token, err := impl.createApiJwtToken(apiToken.User.EmailId, tokenVersion, expireAtInMs, apiToken.Scope)
+	if err != nil {
+		return nil, err
+	}
+	previousTokenExpireAtInMs := time.Now().Add(time.Duration(overlapWindowInMins) * time.Minute).UnixMilli()
+	if apiToken.ExpireAtInMs > 0 && apiToken.ExpireAtInMs < previousTokenExpireAtInMs {
+		previousTokenExpireAtInMs = apiToken.ExpireAtInMs
+	}
+	apiToken.Token = token
+	apiToken.Version = tokenVersion
+	apiToken.ExpireAtInMs = expireAtInMs
+	apiToken.PreviousVersion = previousTokenVersion
+	apiToken.PreviousVersionExpireAtInMs = previousTokenExpireAtInMs
+	apiToken.UpdatedBy = rotatedBy
+	apiToken.UpdatedOn = time.Now()
+
+	// step-3 - update in DB only if `previousTokenVersion` is same as version stored in DB
+	err = impl.apiTokenRepository.UpdateIf(apiToken, previousTokenVersion)
+	if err != nil {
+		impl.logger.Errorw("error while rotating api-token", "apiTokenId", apiTokenId, "error", err)
+		if errors.Is(err, fmt.Errorf(TokenVersionMismatch)) {
+			return nil, fmt.Errorf(ConcurrentTokenUpdateRequest)
+		}
+		return nil, err
+	}
+
+	success := true
+	return &openapi.RotateApiTokenResponse{
+		Success:                   &success,
+		Token:                     &apiToken.Token,
+		PreviousTokenExpireAtInMs: &previousTokenExpireAtInMs,
+		HideApiToken:              impl.TokenVariableConfig.HideApiTokens,
+	}, nil
+}
+
+func (impl ApiTokenServiceImpl) VerifyApiToken(token string) (*ApiTokenCustomClaims, error) {
+	unverifiedClaims := &jwt.RegisteredClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(token, unverifiedClaims)
+	if err != nil || unverifiedClaims.Issuer != middleware.ApiTokenClaimIssuer {
+		return nil, nil
+	}
+	secretByteArr, err := impl.apiTokenSecretService.GetApiTokenSecretByteArr()
+	if err != nil {
+		impl.logger.Errorw("error while getting api token secret", "error", err)
+		return nil, err
+	}
+	claims := &ApiTokenCustomClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return secretByteArr, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// RecordApiTokenUsage updates the last used time and client ip of the token,
+// the DB is updated at most once per the configured interval for a token
+func (impl ApiTokenServiceImpl) RecordApiTokenUsage(claims *ApiTokenCustomClaims, clientIp string) {
+	tokenName, err := userHelper.ExtractTokenNameFromEmail(claims.Email)
+	if err != nil {
+		impl.logger.Errorw("error in extracting token name from email", "email", claims.Email, "err", err)
+		return
+	}
+	now := time.Now()
+	recordInterval := time.Duration(impl.TokenVariableConfig.ApiTokenUsageRecordIntervalInSecs) * time.Second
+	if recordedAt, ok := impl.usageRecordedAt.Load(tokenName); ok && now.Sub(recordedAt.(time.Time)) < recordInterval {
+		return
+	}
+	impl.usageRecordedAt.Store(tokenName, now)
+	err = impl.apiTokenRepository.UpdateLastUsed(tokenName, now, clientIp)
+	if err != nil {
+		impl.logger.Errorw("error in updating last used of api token", "tokenName", tokenName, "err", err)
+	}
+}
+
+func (impl ApiTokenServiceImpl) createApiJwtToken(email string, tokenVersion int, expireAtInMs int64, scope *bean.ApiTokenScope) (string, error) {
 	registeredClaims, secretByteArr, err := impl.setRegisteredClaims(expireAtInMs)
 	if err != nil {
 		return "", err
 	}
 	claims := &ApiTokenCustomClaims{
-		email,
-		strconv.Itoa(tokenVersion),
-		registeredClaims,
+		Email:            email,
+		Version:          strconv.Itoa(tokenVersion),
+		Scope:            scope,
+		RegisteredClaims: registeredClaims,
 	}
 	token, err := impl.generateToken(claims, secretByteArr)
 	if err != nil {
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiToken
+
+import (
+	openapi "github.com/devtron-labs/devtron/api/openapi/openapiClient"
+	"github.com/devtron-labs/devtron/pkg/apiToken/bean"
+)
+
+func adaptToApiTokenScope(scope *openapi.ApiTokenScope) *bean.ApiTokenScope {
+	if scope == nil {
+		return nil
+	}
+	apiTokenScope := &bean.ApiTokenScope{
+		AccessType:   bean.ApiTokenAccessType(scope.GetAccessType()),
+		Permissions:  make([]*bean.ApiTokenPermission, 0, len(scope.Permissions)),
+		AllowedCidrs: scope.AllowedCidrs,
+	}
+	if len(apiTokenScope.AccessType) == 0 {
+		apiTokenScope.AccessType = bean.ApiTokenAccessFull
+	}
+	for _, permission := range scope.Permissions {
+		apiTokenScope.Permissions = append(apiTokenScope.Permissions, &bean.ApiTokenPermission{
+			Resource: permission.GetResource(),
+			Action:   permission.GetAction(),
+			Object:   permission.GetObject(),
+		})
+	}
+	return apiTokenScope
+}
+
+func adaptToOpenapiApiTokenScope(scope *bean.ApiTokenScope) *openapi.ApiTokenScope {
+	if scope == nil {
+		return nil
+	}
+	accessType := string(scope.AccessType)
+	openapiScope := &openapi.ApiTokenScope{
+		AccessType:   &accessType,
+		AllowedCidrs: scope.AllowedCidrs,
+	}
+	for _, permission := range scope.Permissions {
+		openapiPermission := openapi.NewApiTokenPermission()
+		openapiPermission.SetResource(permission.Resource)
+		openapiPermission.SetAction(permission.Action)
+		openapiPermission.SetObject(permission.Object)
+		openapiScope.Permissions = append(openapiScope.Permissions, *openapiPermission)
+	}
+	return openapiScope
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+)
+
+type ApiTokenAccessType string
+
+const (
+	// ApiTokenAccessFull the token has all the permissions of the roles assigned to the token user
+	ApiTokenAccessFull ApiTokenAccessType = "FULL"
+	// ApiTokenAccessReadOnly the token has only the get permissions out of the roles assigned to the token user
+	ApiTokenAccessReadOnly ApiTokenAccessType = "READ_ONLY"
+	// ApiTokenAccessWebhookOnly the token can only be used to trigger the external ci webhook
+	ApiTokenAccessWebhookOnly ApiTokenAccessType = "WEBHOOK_ONLY"
+	// ApiTokenAccessCustom the token has only the listed permissions out of the roles assigned to the token user
+	ApiTokenAccessCustom ApiTokenAccessType = "CUSTOM"
+)
+
+const (
+	// ScopeClaimKey is the claim of the api token jwt carrying the scope
+	ScopeClaimKey = "scope"
+	// WebhookPathPrefix is the path of the external ci webhook, the only api allowed for webhook only tokens
+	WebhookPathPrefix = "/orchestrator/webhook/ext-ci/"
+)
+
+// ApiTokenScope narrows the permissions of an api token, the roles assigned to the token user are still enforced
+type ApiTokenScope struct {
+	AccessType  ApiTokenAccessType    `json:"accessType"`
+	Permissions []*ApiTokenPermission `json:"permissions,omitempty"`
+	// AllowedCidrs are the source ips/cidrs the token can be used from, all sources are allowed if empty
+	AllowedCidrs []string `json:"allowedCidrs,omitempty"`
+}
+
+// ApiTokenPermission is a casbin policy, e.g. {environment, trigger, env-y/app-x} to trigger the deployment of app-x in env-y
+type ApiTokenPermission struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	// Object of the resource with "/" separated parts, "*" matches any value of a part
+	Object string `json:"object"`
+}
+
+func (scope *ApiTokenScope) Validate() error {
+	switch scope.AccessType {
+	case ApiTokenAccessFull, ApiTokenAccessReadOnly, ApiTokenAccessWebhookOnly:
+		if len(scope.Permissions) > 0 {
+			return fmt.Errorf("permissions are supported only with the %s access type", ApiTokenAccessCustom)
+		}
+	case ApiTokenAccessCustom:
+		if len(scope.Permissions) == 0 {
+			return fmt.Errorf("at least one permission is required with the %s access type", ApiTokenAccessCustom)
+		}
+		for _, permission := range scope.Permissions {
+			if permission == nil || len(permission.Resource) == 0 || len(permission.Action) == 0 || len(permission.Object) == 0 {
+				return fmt.Errorf("resource, action and object are required in a permission")
+			}
+		}
+	default:
+		return fmt.Errorf("invalid access type %q", scope.AccessType)
+	}
+	for _, cidr := range scope.AllowedCidrs {
+		if _, err := parseCidr(cidr); err != nil {
+			return fmt.Errorf("invalid allowed cidr %q", cidr)
+		}
+	}
+	return nil
+}
+
+// IsClientIpAllowed checks the client ip (with or without the port) against the allowed cidrs
+func (scope *ApiTokenScope) IsClientIpAllowed(clientIp string) bool {
+	if scope == nil || len(scope.AllowedCidrs) == 0 {
+		return true
+	}
+	ip := parseIp(clientIp)
+	if ip == nil {
+		return false
+	}
+	return containsIp(scope.AllowedCidrs, ip)
+}
+
+// GetClientIp returns the ip of the request sender, the X-Forwarded-For header is trusted only if the
+// request comes from one of the trusted proxies; the right-most hop which is not a trusted proxy is the client then
+func GetClientIp(remoteAddr string, xForwardedFor string, trustedProxyCidrs []string) string {
+	remoteIp := parseIp(remoteAddr)
+	if remoteIp == nil || len(xForwardedFor) == 0 || !containsIp(trustedProxyCidrs, remoteIp) {
+		return remoteAddr
+	}
+	hops := strings.Split(xForwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		hopIp := parseIp(hop)
+		if hopIp == nil {
+			// the hops left of an invalid entry can not be trusted
+			return hop
+		}
+		if !containsIp(trustedProxyCidrs, hopIp) {
+			return hop
+		}
+	}
+	// all the hops are trusted proxies, the left-most one is the closest to the client
+	return strings.TrimSpace(hops[0])
+}
+
+func (scope *ApiTokenScope) IsPathAllowed(path string) bool {
+	if scope == nil || scope.AccessType != ApiTokenAccessWebhookOnly {
+		return true
+	}
+	return strings.HasPrefix(path, WebhookPathPrefix)
+}
+
+// parseIp supports an ip with or without the port
+func parseIp(ip string) net.IP {
+	ip = strings.TrimSpace(ip)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return net.ParseIP(ip)
+}
+
+func containsIp(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		ipNet, err := parseCidr(cidr)
+		if err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCidr supports a single ip as well as a cidr
+func parseCidr(cidr string) (*net.IPNet, error) {
+	if ip := net.ParseIP(cidr); ip != nil {
+		bits := 8 * net.IPv4len
+		if ip.To4() == nil {
+			bits = 8 * net.IPv6len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	return ipNet, err
+}
+
+// GetApiTokenScopeFromClaims returns nil for the tokens without a scope, such tokens have full access
+func GetApiTokenScopeFromClaims(claims map[string]interface{}) *ApiTokenScope {
+	scopeClaim, ok := claims[ScopeClaimKey]
+	if !ok || scopeClaim == nil {
+		return nil
+	}
+	scope := &ApiTokenScope{}
+	scopeJson, err := json.Marshal(scopeClaim)
+	if err == nil {
+		err = json.Unmarshal(scopeJson, scope)
+	}
+	if err != nil {
+		// a malformed scope grants nothing
+		return &ApiTokenScope{AccessType: ApiTokenAccessCustom}
+	}
+	return scope
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiTokenScope_IsClientIpAllowed(t *testing.T) {
+	scope := &ApiTokenScope{AccessType: ApiTokenAccessFull, AllowedCidrs: []string{"10.0.0.0/16", "192.168.1.10"}}
+	assert.True(t, scope.IsClientIpAllowed("10.0.12.4"))
+	assert.True(t, scope.IsClientIpAllowed("10.0.12.4:53412"))
+	assert.False(t, scope.IsClientIpAllowed("192.168.1.10, 10.1.0.1"))
+	assert.False(t, scope.IsClientIpAllowed("192.168.1.11"))
+	assert.False(t, scope.IsClientIpAllowed("invalid"))
+	assert.True(t, (&ApiTokenScope{AccessType: ApiTokenAccessFull}).IsClientIpAllowed("1.2.3.4"))
+	var nilScope *ApiTokenScope
+	assert.True(t, nilScope.IsClientIpAllowed("1.2.3.4"))
+}
+
+func TestGetClientIp(t *testing.T) {
+	trustedProxies := []string{"10.244.0.0/16"}
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		xForwardedFor string
+		want          string
+	}{
+		{name: "no proxy", remoteAddr: "203.0.113.7:41234", want: "203.0.113.7:41234"},
+		{name: "spoofed header from an untrusted source", remoteAddr: "203.0.113.7:41234", xForwardedFor: "192.168.1.10", want: "203.0.113.7:41234"},
+		{name: "trusted proxy", remoteAddr: "10.244.1.5:8080", xForwardedFor: "198.51.100.4", want: "198.51.100.4"},
+		{name: "spoofed left-most hop behind a trusted proxy", remoteAddr: "10.244.1.5:8080", xForwardedFor: "192.168.1.10, 198.51.100.4", want: "198.51.100.4"},
+		{name: "chain of trusted proxies", remoteAddr: "10.244.1.5:8080", xForwardedFor: "198.51.100.4, 10.244.2.9", want: "198.51.100.4"},
+		{name: "invalid hop", remoteAddr: "10.244.1.5:8080", xForwardedFor: "192.168.1.10, unknown", want: "unknown"},
+		{name: "no header from a trusted proxy", remoteAddr: "10.244.1.5:8080", want: "10.244.1.5:8080"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetClientIp(tt.remoteAddr, tt.xForwardedFor, trustedProxies))
+		})
+	}
+	// without trusted proxies the header is never used
+	assert.Equal(t, "203.0.113.7:41234", GetClientIp("203.0.113.7:41234", "192.168.1.10", nil))
+}
+
+func TestApiTokenScope_IsClientIpAllowed_SpoofedForwardedFor(t *testing.T) {
+	scope := &ApiTokenScope{AccessType: ApiTokenAccessFull, AllowedCidrs: []string{"192.168.1.10"}}
+	assert.False(t, scope.IsClientIpAllowed(GetClientIp("203.0.113.7:41234", "192.168.1.10", nil)))
+	assert.False(t, scope.IsClientIpAllowed(GetClientIp("10.244.1.5:8080", "192.168.1.10, 203.0.113.7", []string{"10.244.0.0/16"})))
+	assert.True(t, scope.IsClientIpAllowed(GetClientIp("10.244.1.5:8080", "203.0.113.7, 192.168.1.10", []string{"10.244.0.0/16"})))
+}
+
+func TestApiTokenScope_Validate(t *testing.T) {
+	assert.NoError(t, (&ApiTokenScope{AccessType: ApiTokenAccessReadOnly}).Validate())
+	assert.Error(t, (&ApiTokenScope{AccessType: "WRITE"}).Validate())
+	assert.Error(t, (&ApiTokenScope{AccessType: ApiTokenAccessCustom}).Validate())
+	assert.Error(t, (&ApiTokenScope{AccessType: ApiTokenAccessFull, AllowedCidrs: []string{"10.0.0.0/33"}}).Validate())
+	assert.NoError(t, (&ApiTokenScope{
+		AccessType:  ApiTokenAccessCustom,
+		Permissions: []*ApiTokenPermission{{Resource: "environment", Action: "trigger", Object: "env-y/app-x"}},
+	}).Validate())
+}
+
+func TestGetApiTokenScopeFromClaims(t *testing.T) {
+	assert.Nil(t, GetApiTokenScopeFromClaims(map[string]interface{}{"email": "API-TOKEN:test"}))
+	scope := GetApiTokenScopeFromClaims(map[string]interface{}{
+		ScopeClaimKey: map[string]interface{}{"accessType": "WEBHOOK_ONLY"},
+	})
+	assert.Equal(t, ApiTokenAccessWebhookOnly, scope.AccessType)
+	assert.True(t, scope.IsPathAllowed(WebhookPathPrefix+"1"))
+	assert.False(t, scope.IsPathAllowed("/orchestrator/app/list"))
+	malformedScope := GetApiTokenScopeFromClaims(map[string]interface{}{ScopeClaimKey: "malformed"})
+	assert.Equal(t, ApiTokenAccessCustom, malformedScope.AccessType)
+	assert.Empty(t, malformedScope.Permissions)
+}
@@ -30,6 +30,7 @@ import (
 	ciConfig "github.com/devtron-labs/devtron/pkg/build/pipeline/read"
 	chartRepoRepository "github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	driftRead "github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/read"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deployedAppMetrics"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/read"
 	"github.com/devtron-labs/devtron/pkg/dockerRegistry"
@@ -198,6 +199,7 @@ type AppListingServiceImpl struct {
 	ciArtifactRepository           repository.CiArtifactRepository
 	envConfigOverrideReadService   read.EnvConfigOverrideService
 	ciPipelineConfigReadService    ciConfig.CiPipelineConfigReadService
+	gitOpsDriftReadService         driftRead.GitOpsDriftReadService
 }
 
 func NewAppListingServiceImpl(Logger *zap.SugaredLogger,
@@ -211,7 +213,8 @@ func NewAppListingServiceImpl(Logger *zap.SugaredLogger,
 	dockerRegistryIpsConfigService dockerRegistry.DockerRegistryIpsConfigService, userRepository userrepository.UserRepository,
 	deployedAppMetricsService deployedAppMetrics.DeployedAppMetricsService, ciArtifactRepository repository.CiArtifactRepository,
 	envConfigOverrideReadService read.EnvConfigOverrideService,
-	ciPipelineConfigReadService ciConfig.CiPipelineConfigReadService) *AppListingServiceImpl {
+	ciPipelineConfigReadService ciConfig.CiPipelineConfigReadService,
+	gitOpsDriftReadService driftRead.GitOpsDriftReadService) *AppListingServiceImpl {
 	return &AppListingServiceImpl{
 		Logger:                         Logger,
 		appListingRepository:           appListingRepository,
@@ -231,6 +234,7 @@ func NewAppListingServiceImpl(Logger *zap.SugaredLogger,
 		ciArtifactRepository:           ciArtifactRepository,
 		envConfigOverrideReadService:   envConfigOverrideReadService,
 		ciPipelineConfigReadService:    ciPipelineConfigReadService,
+		gitOpsDriftReadService:         gitOpsDriftReadService,
 	}
 }
 
@@ -697,7 +701,13 @@ func (impl AppListingServiceImpl) FetchAppDetails(ctx context.Context, appId int
 	if err != nil {
 		return appDetailContainer, err
 	}
-
+	// drift is informational, app details are served without it if it can not be read
+	gitOpsDrift, err := impl.gitOpsDriftReadService.GetDrift(appId, envId)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching gitops drift, FetchAppDetails service", "appId", appId, "envId", envId, "error", err)
+	} else {
+		appDetailContainer.GitOpsDrift = gitOpsDrift
+	}
 	return appDetailContainer, nil
 }
 
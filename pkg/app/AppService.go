@@ -785,6 +785,11 @@ type ValuesOverrideResponse struct {
 	ManifestPushTemplate *bean3.ManifestPushTemplate
 }
 
+// IsPullRequestRaised is true when the values are pushed through a gitops pull request, the deployment is resumed once it is merged
+func (v *ValuesOverrideResponse) IsPullRequestRaised() bool {
+	return v.ManifestPushTemplate != nil && v.ManifestPushTemplate.RaisePullRequest
+}
+
 func (impl *AppServiceImpl) CreateGitOpsRepo(app *app.App, targetRevision string, userId int32) (gitOpsRepoName string, chartGitAttr *commonBean.ChartGitAttribute, err error) {
 
 	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, app.Id, 0)
@@ -44,19 +44,26 @@ type ManifestPushTemplate struct {
 	BuiltChartBytes        *[]byte
 	MergedValues           string
 	ArgoSyncNeeded         bool
+	// RaisePullRequest pushes the values to a new branch and raises a pull request instead of committing to the TargetRevision
+	RaisePullRequest bool
 }
 
 type ManifestPushResponse struct {
-	NewGitRepoUrl string
-	CommitHash    string
-	CommitTime    time.Time
-	Error         error
+	NewGitRepoUrl     string
+	CommitHash        string
+	CommitTime        time.Time
+	PullRequestNumber int
+	Error             error
 }
 
 func (m ManifestPushResponse) IsNewGitRepoConfigured() bool {
 	return len(m.NewGitRepoUrl) != 0
 }
 
+func (m ManifestPushResponse) IsPullRequestRaised() bool {
+	return m.PullRequestNumber != 0
+}
+
 type HelmRepositoryConfig struct {
 	repositoryName        string
 	containerRegistryName string
@@ -261,6 +261,32 @@ func GetAllSubjects() []string {
 	return e.GetAllSubjects()
 }
 
+// GetGroupingPolicies returns all the role mappings("g" policies) as [subject, role] pairs
+func GetGroupingPolicies() ([][]string, error) {
+	if isV2() {
+		return e2.GetGroupingPolicy()
+	}
+	return e.GetGroupingPolicy(), nil
+}
+
+// GetPolicies returns all the permissions("p" policies) as [role, resource, action, object, effect]
+func GetPolicies() ([][]string, error) {
+	if isV2() {
+		return e2.GetPolicy()
+	}
+	return e.GetPolicy(), nil
+}
+
+// EnforceWithoutCache evaluates the request directly against the loaded policies, used for the subjects
+// which are not logged in users like roles and groups so that the enforcer cache is not populated for them
+func EnforceWithoutCache(subject string, resource string, action string, resourceItem string) (bool, error) {
+	subject = strings.ToLower(subject)
+	if isV2() {
+		return e2.Enforce(subject, resource, action, resourceItem)
+	}
+	return e.EnforceSafe(subject, resource, action, resourceItem)
+}
+
 func DeleteRoleForUser(user string, role string) bool {
 	user = strings.ToLower(user)
 	role = strings.ToLower(role)
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package casbin
+
+import (
+	"strings"
+
+	apiTokenBean "github.com/devtron-labs/devtron/pkg/apiToken/bean"
+)
+
+// isAllowedByApiTokenScope checks the request against the scope of the api token, the scope only narrows
+// the permissions of the token user and the policies of the user are enforced after this check
+func isAllowedByApiTokenScope(scope *apiTokenBean.ApiTokenScope, resource, action, resourceItem string) bool {
+	if scope == nil {
+		return true
+	}
+	switch scope.AccessType {
+	case apiTokenBean.ApiTokenAccessFull:
+		return true
+	case apiTokenBean.ApiTokenAccessReadOnly:
+		return action == ActionGet
+	case apiTokenBean.ApiTokenAccessWebhookOnly:
+		// the external ci webhook checks the trigger access on the app and the environment
+		return action == ActionTrigger && (resource == ResourceApplications || resource == ResourceEnvironment)
+	case apiTokenBean.ApiTokenAccessCustom:
+		for _, permission := range scope.Permissions {
+			if (permission.Resource == "*" || permission.Resource == resource) &&
+				(permission.Action == "*" || permission.Action == action) &&
+				MatchKeyByPart(strings.ToLower(resourceItem), strings.ToLower(permission.Object)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func filterByApiTokenScope(scope *apiTokenBean.ApiTokenScope, resource, action string, vals []string) []string {
+	allowedVals := make([]string, 0, len(vals))
+	for _, val := range vals {
+		if isAllowedByApiTokenScope(scope, resource, action, val) {
+			allowedVals = append(allowedVals, val)
+		}
+	}
+	return allowedVals
+}
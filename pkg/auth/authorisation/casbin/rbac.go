@@ -31,6 +31,7 @@ import (
 	"github.com/casbin/casbin"
 	"github.com/devtron-labs/authenticator/jwt"
 	"github.com/devtron-labs/authenticator/middleware"
+	apiTokenBean "github.com/devtron-labs/devtron/pkg/apiToken/bean"
 	globalConfig "github.com/devtron-labs/devtron/pkg/auth/authorisation/globalConfig"
 	util3 "github.com/devtron-labs/devtron/pkg/auth/user/util"
 	"github.com/patrickmn/go-cache"
@@ -43,7 +44,8 @@ type Enforcer interface {
 	Enforce(token string, resource string, action string, resourceItem string) bool
 	//EnforceErr(emailId string, resource string, action string, resourceItem string) error
 	EnforceInBatch(token string, resource string, action string, vals []string) map[string]bool
-	//EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool
+	// EnforceByEmail enforces the policies of the user without a token, used for actions performed later on behalf of the user
+	EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool
 	//EnforceByEmailInBatch(emailId string, resource string, action string, vals []string) map[string]bool
 	InvalidateCache(emailId string) bool
 	InvalidateCompleteCache()
@@ -397,8 +399,8 @@ func (e *EnforcerImpl) GetCacheDump() string {
 
 // enforce is a helper to additionally check a default role and invoke a custom claims enforcement function
 func (e *EnforcerImpl) enforce(token string, resource string, action string, resourceItem string) bool {
-	subjects, invalid := e.getSubjectsFromToken(token)
-	if invalid {
+	subjects, apiTokenScope, invalid := e.getSubjectsFromToken(token)
+	if invalid || !isAllowedByApiTokenScope(apiTokenScope, resource, action, resourceItem) {
 		return false
 	}
 	for _, subject := range subjects {
@@ -411,10 +413,13 @@ func (e *EnforcerImpl) enforce(token string, resource string, action string, res
 
 // enforceInBatch is a helper to additionally check a default role and invoke a custom claims enforcement function
 func (e *EnforcerImpl) enforceInBatch(token string, resource string, action string, vals []string) map[string]bool {
-	subjects, invalid := e.getSubjectsFromToken(token)
+	subjects, apiTokenScope, invalid := e.getSubjectsFromToken(token)
 	if invalid {
 		return make(map[string]bool)
 	}
+	if apiTokenScope != nil {
+		vals = filterByApiTokenScope(apiTokenScope, resource, action, vals)
+	}
 	if len(subjects) == 1 {
 		return e.EnforceByEmailInBatch(subjects[0], resource, action, vals)
 	}
@@ -491,15 +496,16 @@ func (e *EnforcerImpl) VerifyTokenAndGetEmail(tokenString string) (string, bool)
 
 // getSubjectsFromToken parses the JWT token and returns all casbin subjects for the user.
 // When group claims config is active, returns [email, group:casbin1, group:casbin2, ...].
-// Otherwise returns just [email]. The invalid bool is true if the token is invalid.
-func (e *EnforcerImpl) getSubjectsFromToken(tokenString string) ([]string, bool) {
+// Otherwise returns just [email]. The scope is returned for the api tokens created with one.
+// The invalid bool is true if the token is invalid.
+func (e *EnforcerImpl) getSubjectsFromToken(tokenString string) ([]string, *apiTokenBean.ApiTokenScope, bool) {
 	claims, err := e.SessionManager.VerifyToken(tokenString)
 	if err != nil {
-		return nil, true
+		return nil, nil, true
 	}
 	mapClaims, err := jwt.MapClaims(claims)
 	if err != nil {
-		return nil, true
+		return nil, nil, true
 	}
 	email := jwt.GetField(mapClaims, "email")
 	sub := jwt.GetField(mapClaims, "sub")
@@ -507,7 +513,7 @@ func (e *EnforcerImpl) getSubjectsFromToken(tokenString string) ([]string, bool)
 		email = "admin"
 	}
 	if email == "" {
-		return nil, true
+		return nil, nil, true
 	}
 	subjects := make([]string, 0)
 	if e.globalAuthorisationConfigService.IsDevtronSystemManagedConfigActive() || util3.CheckIfAdminOrApiToken(email) {
@@ -522,7 +528,11 @@ func (e *EnforcerImpl) getSubjectsFromToken(tokenString string) ([]string, bool)
 			subjects = append(subjects, groupCasbinNames...)
 		}
 	}
-	return subjects, false
+	var apiTokenScope *apiTokenBean.ApiTokenScope
+	if util3.CheckIfApiToken(email) {
+		apiTokenScope = apiTokenBean.GetApiTokenScopeFromClaims(mapClaims)
+	}
+	return subjects, apiTokenScope, false
 }
 
 // enforce is a helper to additionally check a default role and invoke a custom claims enforcement function
@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	util3 "github.com/devtron-labs/devtron/pkg/auth/user/util"
+	"go.uber.org/zap"
+)
+
+const groupCasbinNamePrefix = "group:"
+
+type AccessReviewService interface {
+	// ReviewAccess resolves the users, api tokens and groups allowed to perform the action on the resource along with the
+	// roles through which the access is granted
+	ReviewAccess(request *userBean.AccessReviewRequest) (*userBean.AccessReviewResponse, error)
+	// GetEffectivePermissions returns the complete subject to effective permission matrix
+	GetEffectivePermissions() ([]*userBean.EffectivePermission, error)
+}
+
+type AccessReviewServiceImpl struct {
+	logger              *zap.SugaredLogger
+	enforcer            casbin.Enforcer
+	userRepository      repository.UserRepository
+	roleGroupRepository repository.RoleGroupRepository
+}
+
+func NewAccessReviewServiceImpl(logger *zap.SugaredLogger, enforcer casbin.Enforcer,
+	userRepository repository.UserRepository, roleGroupRepository repository.RoleGroupRepository) *AccessReviewServiceImpl {
+	return &AccessReviewServiceImpl{
+		logger:              logger,
+		enforcer:            enforcer,
+		userRepository:      userRepository,
+		roleGroupRepository: roleGroupRepository,
+	}
+}
+
+// accessReviewSubject is a casbin subject(user email, api token or group casbin name) to be reviewed
+type accessReviewSubject struct {
+	id          int32
+	name        string
+	casbinName  string
+	subjectType userBean.AccessReviewSubjectType
+}
+
+func (impl *AccessReviewServiceImpl) ReviewAccess(request *userBean.AccessReviewRequest) (*userBean.AccessReviewResponse, error) {
+	resource, resourceItem, err := getCasbinResourceAndObject(request)
+	if err != nil {
+		return nil, err
+	}
+	action := strings.ToLower(request.Action)
+	subjectRoles, err := getSubjectRoles()
+	if err != nil {
+		impl.logger.Errorw("error in getting grouping policies", "err", err)
+		return nil, err
+	}
+	subjects, err := impl.getAccessReviewSubjects()
+	if err != nil {
+		return nil, err
+	}
+	roleAllowed := make(map[string]bool)
+	isRoleAllowed := func(role string) bool {
+		if allowed, ok := roleAllowed[role]; ok {
+			return allowed
+		}
+		allowed, err := casbin.EnforceWithoutCache(role, resource, action, resourceItem)
+		if err != nil {
+			impl.logger.Errorw("error in enforcing role", "role", role, "resource", resource, "action", action, "resourceItem", resourceItem, "err", err)
+		}
+		roleAllowed[role] = allowed
+		return allowed
+	}
+	response := &userBean.AccessReviewResponse{
+		Resource:     resource,
+		Action:       action,
+		ResourceItem: resourceItem,
+		Subjects:     make([]*userBean.AccessReviewSubject, 0),
+	}
+	for _, subject := range subjects {
+		if !impl.isSubjectAllowed(subject, resource, action, resourceItem) {
+			continue
+		}
+		grants := make([]*userBean.AccessGrant, 0)
+		for _, grant := range getAccessGrants(subject.casbinName, subjectRoles) {
+			if isRoleAllowed(grant.Role) {
+				grants = append(grants, grant)
+			}
+		}
+		response.Subjects = append(response.Subjects, &userBean.AccessReviewSubject{
+			Id:          subject.id,
+			Name:        subject.name,
+			SubjectType: subject.subjectType,
+			Grants:      grants,
+		})
+	}
+	return response, nil
+}
+
+func (impl *AccessReviewServiceImpl) GetEffectivePermissions() ([]*userBean.EffectivePermission, error) {
+	subjectRoles, err := getSubjectRoles()
+	if err != nil {
+		impl.logger.Errorw("error in getting grouping policies", "err", err)
+		return nil, err
+	}
+	policies, err := casbin.GetPolicies()
+	if err != nil {
+		impl.logger.Errorw("error in getting policies", "err", err)
+		return nil, err
+	}
+	rolePolicies := make(map[string][][]string)
+	for _, policy := range policies {
+		// policy is [role, resource, action, object, effect]
+		if len(policy) < 5 {
+			continue
+		}
+		role := strings.ToLower(policy[0])
+		rolePolicies[role] = append(rolePolicies[role], policy)
+	}
+	subjects, err := impl.getAccessReviewSubjects()
+	if err != nil {
+		return nil, err
+	}
+	permissions := make([]*userBean.EffectivePermission, 0)
+	for _, subject := range subjects {
+		for _, grant := range getAccessGrants(subject.casbinName, subjectRoles) {
+			for _, policy := range rolePolicies[grant.Role] {
+				permissions = append(permissions, &userBean.EffectivePermission{
+					SubjectId:    subject.id,
+					Subject:      subject.name,
+					SubjectType:  subject.subjectType,
+					Resource:     policy[1],
+					Action:       policy[2],
+					ResourceItem: policy[3],
+					Effect:       policy[4],
+					GrantType:    grant.GrantType,
+					Role:         grant.Role,
+					Group:        grant.Group,
+				})
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// isSubjectAllowed enforces through the enforcer for users and api tokens so that the result matches the one of the
+// actual requests, groups are not logged in subjects and are enforced directly on the policies
+func (impl *AccessReviewServiceImpl) isSubjectAllowed(subject *accessReviewSubject, resource, action, resourceItem string) bool {
+	if subject.subjectType == userBean.AccessReviewSubjectGroup {
+		allowed, err := casbin.EnforceWithoutCache(subject.casbinName, resource, action, resourceItem)
+		if err != nil {
+			impl.logger.Errorw("error in enforcing group", "group", subject.casbinName, "err", err)
+		}
+		return allowed
+	}
+	return impl.enforcer.EnforceByEmail(subject.casbinName, resource, action, resourceItem)
+}
+
+func (impl *AccessReviewServiceImpl) getAccessReviewSubjects() ([]*accessReviewSubject, error) {
+	users, err := impl.userRepository.GetAllActive()
+	if err != nil {
+		impl.logger.Errorw("error in getting active users", "err", err)
+		return nil, err
+	}
+	roleGroups, err := impl.roleGroupRepository.GetAllRoleGroup()
+	if err != nil {
+		impl.logger.Errorw("error in getting role groups", "err", err)
+		return nil, err
+	}
+	subjects := make([]*accessReviewSubject, 0, len(users)+len(roleGroups))
+	for _, user := range users {
+		subject := &accessReviewSubject{
+			id:          user.Id,
+			name:        user.EmailId,
+			casbinName:  user.EmailId,
+			subjectType: userBean.AccessReviewSubjectUser,
+		}
+		if user.UserType == userBean.USER_TYPE_API_TOKEN || util3.CheckIfApiToken(user.EmailId) {
+			subject.name = strings.TrimPrefix(user.EmailId, util3.ApiTokenPrefix)
+			subject.subjectType = userBean.AccessReviewSubjectApiToken
+		}
+		subjects = append(subjects, subject)
+	}
+	for _, roleGroup := range roleGroups {
+		subjects = append(subjects, &accessReviewSubject{
+			id:          roleGroup.Id,
+			name:        roleGroup.Name,
+			casbinName:  strings.ToLower(roleGroup.CasbinName),
+			subjectType: userBean.AccessReviewSubjectGroup,
+		})
+	}
+	return subjects, nil
+}
+
+// getSubjectRoles returns the roles and groups mapped to each subject in casbin
+func getSubjectRoles() (map[string][]string, error) {
+	groupingPolicies, err := casbin.GetGroupingPolicies()
+	if err != nil {
+		return nil, err
+	}
+	subjectRoles := make(map[string][]string)
+	for _, groupingPolicy := range groupingPolicies {
+		if len(groupingPolicy) < 2 {
+			continue
+		}
+		subject := strings.ToLower(groupingPolicy[0])
+		subjectRoles[subject] = append(subjectRoles[subject], strings.ToLower(groupingPolicy[1]))
+	}
+	return subjectRoles, nil
+}
+
+// getAccessGrants resolves the roles of the subject, roles mapped through a group are reported with the group
+func getAccessGrants(subject string, subjectRoles map[string][]string) []*userBean.AccessGrant {
+	grants := make([]*userBean.AccessGrant, 0)
+	for _, role := range subjectRoles[subject] {
+		if strings.HasPrefix(role, groupCasbinNamePrefix) {
+			for _, groupRole := range subjectRoles[role] {
+				grants = append(grants, &userBean.AccessGrant{
+					GrantType: getAccessGrantType(groupRole, userBean.AccessGrantGroup),
+					Role:      groupRole,
+					Group:     strings.TrimPrefix(role, groupCasbinNamePrefix),
+				})
+			}
+			continue
+		}
+		grants = append(grants, &userBean.AccessGrant{
+			GrantType: getAccessGrantType(role, userBean.AccessGrantDirect),
+			Role:      role,
+		})
+	}
+	return grants
+}
+
+func getAccessGrantType(role string, defaultGrantType userBean.AccessGrantType) userBean.AccessGrantType {
+	if role == userBean.SUPERADMIN {
+		return userBean.AccessGrantSuperAdmin
+	}
+	return defaultGrantType
+}
+
+// getCasbinResourceAndObject builds the casbin resource and object for the entity in the same format as used while enforcing
+func getCasbinResourceAndObject(request *userBean.AccessReviewRequest) (string, string, error) {
+	project := strings.ToLower(request.Project)
+	app := strings.ToLower(request.App)
+	env := strings.ToLower(request.Env)
+	cluster := strings.ToLower(request.Cluster)
+	namespace := strings.ToLower(request.Namespace)
+	var resource, object string
+	var missingFields []string
+	// requireFields takes the field names and values in pairs
+	requireFields := func(fields ...string) {
+		for i := 0; i+1 < len(fields); i += 2 {
+			if len(fields[i+1]) == 0 {
+				missingFields = append(missingFields, fields[i])
+			}
+		}
+	}
+	switch request.EntityType {
+	case userBean.AccessReviewEntityProject:
+		requireFields("project", project)
+		resource, object = casbin.ResourceTeam, project
+	case userBean.AccessReviewEntityApp:
+		requireFields("project", project, "app", app)
+		resource, object = casbin.ResourceApplications, fmt.Sprintf("%s/%s", project, app)
+	case userBean.AccessReviewEntityEnv:
+		requireFields("env", env, "app", app)
+		resource, object = casbin.ResourceEnvironment, fmt.Sprintf("%s/%s", env, app)
+	case userBean.AccessReviewEntityCluster:
+		requireFields("cluster", cluster)
+		resource, object = casbin.ResourceCluster, cluster
+	case userBean.AccessReviewEntityHelmApp:
+		requireFields("project", project, "cluster", cluster, "namespace", namespace, "app", app)
+		resource, object = casbin.ResourceHelmApp, fmt.Sprintf("%s/%s__%s/%s", project, cluster, namespace, app)
+	default:
+		return "", "", util.NewApiError(http.StatusBadRequest, fmt.Sprintf("unsupported entity type %q", request.EntityType), "unsupported entity type")
+	}
+	if len(missingFields) > 0 {
+		errMsg := fmt.Sprintf("%s required for entity type %s", strings.Join(missingFields, ", "), request.EntityType)
+		return "", "", util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return resource, object, nil
+}
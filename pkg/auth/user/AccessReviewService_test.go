@@ -0,0 +1,140 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCasbinResourceAndObject(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          *userBean.AccessReviewRequest
+		expectedResource string
+		expectedObject   string
+		expectedErr      string
+	}{
+		{
+			name:             "project",
+			request:          &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityProject, Project: "Payments"},
+			expectedResource: casbin.ResourceTeam,
+			expectedObject:   "payments",
+		},
+		{
+			name:             "app",
+			request:          &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityApp, Project: "payments", App: "Billing"},
+			expectedResource: casbin.ResourceApplications,
+			expectedObject:   "payments/billing",
+		},
+		{
+			name:             "env",
+			request:          &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityEnv, Env: "prod", App: "billing"},
+			expectedResource: casbin.ResourceEnvironment,
+			expectedObject:   "prod/billing",
+		},
+		{
+			name:             "cluster",
+			request:          &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityCluster, Cluster: "default_cluster"},
+			expectedResource: casbin.ResourceCluster,
+			expectedObject:   "default_cluster",
+		},
+		{
+			name: "helm app",
+			request: &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityHelmApp, Project: "payments",
+				Cluster: "default_cluster", Namespace: "billing-ns", App: "billing"},
+			expectedResource: casbin.ResourceHelmApp,
+			expectedObject:   "payments/default_cluster__billing-ns/billing",
+		},
+		{
+			name:        "app without project",
+			request:     &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityApp, App: "billing"},
+			expectedErr: "project required for entity type app",
+		},
+		{
+			name:        "helm app without cluster and namespace",
+			request:     &userBean.AccessReviewRequest{EntityType: userBean.AccessReviewEntityHelmApp, Project: "payments", App: "billing"},
+			expectedErr: "cluster, namespace required for entity type helm-app",
+		},
+		{
+			name:        "unsupported entity type",
+			request:     &userBean.AccessReviewRequest{EntityType: "job"},
+			expectedErr: "unsupported entity type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, object, err := getCasbinResourceAndObject(tt.request)
+			if len(tt.expectedErr) > 0 {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResource, resource)
+			assert.Equal(t, tt.expectedObject, object)
+		})
+	}
+}
+
+func TestGetAccessGrants(t *testing.T) {
+	subjectRoles := map[string][]string{
+		"admin@example.com":     {userBean.SUPERADMIN},
+		"dev@example.com":       {"role:admin_payments_billing_", "group:developers"},
+		"ops@example.com":       {"group:admins"},
+		"group:developers":      {"role:view_payments__", "role:trigger_payments_billing_"},
+		"group:admins":          {userBean.SUPERADMIN},
+		"api-token:ci-pipeline": {"role:trigger_payments_billing_"},
+	}
+	tests := []struct {
+		name     string
+		subject  string
+		expected []*userBean.AccessGrant
+	}{
+		{
+			name:    "direct role",
+			subject: "api-token:ci-pipeline",
+			expected: []*userBean.AccessGrant{
+				{GrantType: userBean.AccessGrantDirect, Role: "role:trigger_payments_billing_"},
+			},
+		},
+		{
+			name:    "direct super admin",
+			subject: "admin@example.com",
+			expected: []*userBean.AccessGrant{
+				{GrantType: userBean.AccessGrantSuperAdmin, Role: userBean.SUPERADMIN},
+			},
+		},
+		{
+			name:    "direct and group inherited roles",
+			subject: "dev@example.com",
+			expected: []*userBean.AccessGrant{
+				{GrantType: userBean.AccessGrantDirect, Role: "role:admin_payments_billing_"},
+				{GrantType: userBean.AccessGrantGroup, Role: "role:view_payments__", Group: "developers"},
+				{GrantType: userBean.AccessGrantGroup, Role: "role:trigger_payments_billing_", Group: "developers"},
+			},
+		},
+		{
+			name:    "super admin inherited from a group",
+			subject: "ops@example.com",
+			expected: []*userBean.AccessGrant{
+				{GrantType: userBean.AccessGrantSuperAdmin, Role: userBean.SUPERADMIN, Group: "admins"},
+			},
+		},
+		{
+			name:     "group reviewed as a subject",
+			subject:  "group:developers",
+			expected: []*userBean.AccessGrant{{GrantType: userBean.AccessGrantDirect, Role: "role:view_payments__"}, {GrantType: userBean.AccessGrantDirect, Role: "role:trigger_payments_billing_"}},
+		},
+		{
+			name:     "subject without roles",
+			subject:  "new@example.com",
+			expected: []*userBean.AccessGrant{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getAccessGrants(tt.subject, subjectRoles))
+		})
+	}
+}
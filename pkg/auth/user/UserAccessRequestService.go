@@ -0,0 +1,444 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+// UserAccessRequestService manages the just-in-time access of the users, a user requests the roles of the role filters
+// for a duration, the roles are granted on the approval of a manager and revoked on expiry
+type UserAccessRequestService interface {
+	CreateAccessRequest(request *userBean.UserAccessRequestDto) (*userBean.UserAccessRequestDto, error)
+	GetAccessRequests(filter *userBean.UserAccessRequestFilter) ([]*userBean.UserAccessRequestDto, error)
+	GetAccessRequestById(id int) (*userBean.UserAccessRequestDto, error)
+	ApproveAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error)
+	RejectAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error)
+	CancelAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error)
+	// RevokeAccessRequest revokes the roles of an approved request before its expiry
+	RevokeAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error)
+	// RevokeExpiredAccessRequests revokes the roles of the approved requests past their expiry
+	RevokeExpiredAccessRequests()
+}
+
+type UserAccessRequestConfig struct {
+	MaxDurationInMins     int    `env:"USER_ACCESS_REQUEST_MAX_DURATION_IN_MINS" envDefault:"1440" description:"Maximum duration in minutes for which a user can request time-bound roles"`
+	ExpiryCheckCron       string `env:"USER_ACCESS_REQUEST_EXPIRY_CHECK_CRON" envDefault:"@every 1m" description:"Cron schedule of the job revoking the roles of the expired access requests"`
+	ExpiryStaleTimeInMins int    `env:"USER_ACCESS_REQUEST_EXPIRY_STALE_TIME" envDefault:"10" description:"Minutes after which an expired access request claimed by an instance which stopped before revoking it is picked up again"`
+}
+
+func GetUserAccessRequestConfig() (*UserAccessRequestConfig, error) {
+	cfg := &UserAccessRequestConfig{}
+	err := env.Parse(cfg)
+	return cfg, err
+}
+
+type UserAccessRequestServiceImpl struct {
+	logger                      *zap.SugaredLogger
+	cron                        *cron.Cron
+	config                      *UserAccessRequestConfig
+	userAccessRequestRepository repository.UserAccessRequestRepository
+	userAuthRepository          repository.UserAuthRepository
+	userService                 UserService
+	userAuditService            UserAuditService
+}
+
+func NewUserAccessRequestServiceImpl(logger *zap.SugaredLogger, cronLogger *cron2.CronLoggerImpl,
+	userAccessRequestRepository repository.UserAccessRequestRepository,
+	userAuthRepository repository.UserAuthRepository,
+	userService UserService,
+	userAuditService UserAuditService) (*UserAccessRequestServiceImpl, error) {
+	cfg, err := GetUserAccessRequestConfig()
+	if err != nil {
+		logger.Errorw("error in parsing user access request config", "err", err)
+		return nil, err
+	}
+	impl := &UserAccessRequestServiceImpl{
+		logger:                      logger,
+		config:                      cfg,
+		userAccessRequestRepository: userAccessRequestRepository,
+		userAuthRepository:          userAuthRepository,
+		userService:                 userService,
+		userAuditService:            userAuditService,
+	}
+	impl.cron = cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	impl.cron.Start()
+	_, err = impl.cron.AddFunc(cfg.ExpiryCheckCron, impl.RevokeExpiredAccessRequests)
+	if err != nil {
+		logger.Errorw("error while configure cron job for revoking expired access requests", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *UserAccessRequestServiceImpl) CreateAccessRequest(request *userBean.UserAccessRequestDto) (*userBean.UserAccessRequestDto, error) {
+	if request.DurationInMins > impl.config.MaxDurationInMins {
+		errMsg := fmt.Sprintf("access can be requested for at most %d minutes", impl.config.MaxDurationInMins)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	for index, roleFilter := range request.RoleFilters {
+		if roleFilter.Entity == "" {
+			request.RoleFilters[index].Entity = userBean.ENTITY_APPS
+			if roleFilter.AccessType == "" {
+				request.RoleFilters[index].AccessType = userBean.DEVTRON_APP
+			}
+		}
+	}
+	model := &repository.UserAccessRequest{
+		UserId:         request.UserId,
+		RoleFilters:    request.RoleFilters,
+		DurationInMins: request.DurationInMins,
+		Justification:  request.Justification,
+		Status:         userBean.AccessRequestPending,
+		AuditLog:       sql.NewDefaultAuditLog(request.UserId),
+	}
+	err := impl.userAccessRequestRepository.Save(model)
+	if err != nil {
+		impl.logger.Errorw("error in saving access request", "userId", request.UserId, "err", err)
+		return nil, err
+	}
+	impl.logger.Infow("access request created", "id", model.Id, "userId", model.UserId, "durationInMins", model.DurationInMins)
+	return impl.GetAccessRequestById(model.Id)
+}
+
+func (impl *UserAccessRequestServiceImpl) GetAccessRequests(filter *userBean.UserAccessRequestFilter) ([]*userBean.UserAccessRequestDto, error) {
+	models, err := impl.userAccessRequestRepository.FindByFilter(filter.UserId, filter.Status)
+	if err != nil {
+		impl.logger.Errorw("error in getting access requests", "filter", filter, "err", err)
+		return nil, err
+	}
+	emailById := make(map[int32]string)
+	requests := make([]*userBean.UserAccessRequestDto, 0, len(models))
+	for _, model := range models {
+		request := adaptToUserAccessRequestDto(model)
+		if model.ActionedBy > 0 {
+			if _, ok := emailById[model.ActionedBy]; !ok {
+				emailById[model.ActionedBy], _ = impl.userService.GetEmailById(model.ActionedBy)
+			}
+			request.ActionedByEmail = emailById[model.ActionedBy]
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func (impl *UserAccessRequestServiceImpl) GetAccessRequestById(id int) (*userBean.UserAccessRequestDto, error) {
+	model, err := impl.getAccessRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	request := adaptToUserAccessRequestDto(model)
+	if model.ActionedBy > 0 {
+		request.ActionedByEmail, _ = impl.userService.GetEmailById(model.ActionedBy)
+	}
+	return request, nil
+}
+
+func (impl *UserAccessRequestServiceImpl) ApproveAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error) {
+	model, err := impl.getAccessRequestWithStatus(action.Id, userBean.AccessRequestPending)
+	if err != nil {
+		return nil, err
+	}
+	if model.UserId == action.UserId {
+		return nil, util.NewApiError(http.StatusForbidden, "access request cannot be approved by the requester", "self approval of access request")
+	}
+	// marking approved before granting, so that a concurrent action on the request fails
+	now := time.Now()
+	expiresOn := now.Add(time.Duration(model.DurationInMins) * time.Minute)
+	model.Status = userBean.AccessRequestApproved
+	model.ApprovedOn = &now
+	model.ExpiresOn = &expiresOn
+	model.ActionedBy = action.UserId
+	model.ActionComment = action.Comment
+	model.UpdateAuditLog(action.UserId)
+	err = impl.updateIfStatus(model, userBean.AccessRequestPending)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIds, newlyMappedRoleIds, err := impl.userService.GrantTimeBoundRoles(model.UserId, model.RoleFilters, action.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in granting roles of access request", "id", model.Id, "userId", model.UserId, "err", err)
+		model.Status = userBean.AccessRequestPending
+		model.ApprovedOn, model.ExpiresOn = nil, nil
+		if revertErr := impl.updateIfStatus(model, userBean.AccessRequestApproved); revertErr != nil {
+			impl.logger.Errorw("error in reverting access request to pending", "id", model.Id, "err", revertErr)
+		}
+		return nil, err
+	}
+	// roles already granted by other active requests are owned by this request as well, so that they are
+	// revoked only on the expiry of the last of them
+	otherActiveRoleIds, err := impl.getRoleIdsGrantedByOtherActiveRequests(model)
+	if err != nil {
+		return nil, err
+	}
+	model.GrantedRoleIds = getGrantedRoleIds(roleIds, newlyMappedRoleIds, otherActiveRoleIds)
+	err = impl.updateIfStatus(model, userBean.AccessRequestApproved)
+	if err != nil {
+		return nil, err
+	}
+	impl.saveRoleAssignmentAudit(model, model.GrantedRoleIds, userBean.RoleAssignmentGranted, model.Justification, action.UserId)
+	impl.logger.Infow("access request approved", "id", model.Id, "userId", model.UserId, "approvedBy", action.UserId, "expiresOn", expiresOn)
+	return impl.GetAccessRequestById(model.Id)
+}
+
+func (impl *UserAccessRequestServiceImpl) RejectAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error) {
+	return impl.closePendingAccessRequest(action, userBean.AccessRequestRejected)
+}
+
+func (impl *UserAccessRequestServiceImpl) CancelAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error) {
+	return impl.closePendingAccessRequest(action, userBean.AccessRequestCancelled)
+}
+
+func (impl *UserAccessRequestServiceImpl) RevokeAccessRequest(action *userBean.UserAccessRequestActionDto) (*userBean.UserAccessRequestDto, error) {
+	model, err := impl.getAccessRequestWithStatus(action.Id, userBean.AccessRequestApproved)
+	if err != nil {
+		return nil, err
+	}
+	reason := action.Comment
+	if len(reason) == 0 {
+		reason = "revoked before expiry"
+	}
+	err = impl.revokeAccessRequest(model, userBean.AccessRequestRevoked, reason, action.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetAccessRequestById(model.Id)
+}
+
+// RevokeExpiredAccessRequests runs on every instance, each request is claimed before revoking so that it is
+// revoked by only one instance
+func (impl *UserAccessRequestServiceImpl) RevokeExpiredAccessRequests() {
+	models, err := impl.userAccessRequestRepository.FindApprovedExpiringBefore(time.Now())
+	if err != nil {
+		impl.logger.Errorw("error in getting expired access requests", "err", err)
+		return
+	}
+	if len(models) == 0 {
+		return
+	}
+	impl.logger.Infow("revoking expired access requests", "count", len(models))
+	revokedCount := 0
+	for _, model := range models {
+		now := time.Now()
+		claimed, err := impl.userAccessRequestRepository.ClaimForExpiry(model.Id, now, now.Add(-time.Duration(impl.config.ExpiryStaleTimeInMins)*time.Minute))
+		if err != nil {
+			impl.logger.Errorw("error in claiming expired access request", "id", model.Id, "err", err)
+			continue
+		}
+		if !claimed {
+			// claimed by another instance
+			continue
+		}
+		model.ClaimedOn = &now
+		err = impl.revokeAccessRequest(model, userBean.AccessRequestExpired, "access request expired", userBean.SystemUserId)
+		if err != nil {
+			// retried in the next run
+			impl.logger.Errorw("error in revoking expired access request", "id", model.Id, "userId", model.UserId, "err", err)
+			if err = impl.userAccessRequestRepository.ReleaseExpiryClaim(model.Id); err != nil {
+				impl.logger.Errorw("error in releasing claim of expired access request", "id", model.Id, "err", err)
+			}
+			continue
+		}
+		revokedCount++
+	}
+	if revokedCount > 0 {
+		// reloads the casbin policies from orchestrator so that the enforcer no longer has the revoked mappings
+		_, err = impl.userService.SyncOrchestratorToCasbin()
+		if err != nil {
+			impl.logger.Errorw("error in syncing orchestrator to casbin after revoking expired access requests", "err", err)
+		}
+	}
+}
+
+// revokeAccessRequest removes the roles granted by the request before closing it, a failure leaves the request
+// approved so that the revocation is retried
+func (impl *UserAccessRequestServiceImpl) revokeAccessRequest(model *repository.UserAccessRequest, status userBean.AccessRequestStatus, reason string, revokedBy int32) error {
+	otherActiveRoleIds, err := impl.getRoleIdsGrantedByOtherActiveRequests(model)
+	if err != nil {
+		return err
+	}
+	roleIdsToRevoke := getRoleIdsToRevoke(model.GrantedRoleIds, otherActiveRoleIds)
+	revokedRoles, err := impl.userService.RevokeTimeBoundRoles(model.UserId, roleIdsToRevoke)
+	if err != nil {
+		impl.logger.Errorw("error in revoking roles of access request", "id", model.Id, "userId", model.UserId, "err", err)
+		return err
+	}
+	now := time.Now()
+	model.Status = status
+	model.RevokedOn = &now
+	if status == userBean.AccessRequestRevoked {
+		model.ActionedBy = revokedBy
+		model.ActionComment = reason
+	}
+	model.UpdateAuditLog(revokedBy)
+	err = impl.updateIfStatus(model, userBean.AccessRequestApproved)
+	if err != nil {
+		return err
+	}
+	if len(revokedRoles) > 0 {
+		err = impl.userAuditService.SaveRoleAssignmentAudit(model.UserId, revokedRoles, userBean.RoleAssignmentRevoked, model.Id, reason, revokedBy)
+		if err != nil {
+			impl.logger.Errorw("error in saving role revoke audit", "id", model.Id, "err", err)
+		}
+	}
+	impl.logger.Infow("access request roles revoked", "id", model.Id, "userId", model.UserId, "status", status, "revokedRoles", len(revokedRoles))
+	return nil
+}
+
+func (impl *UserAccessRequestServiceImpl) closePendingAccessRequest(action *userBean.UserAccessRequestActionDto, status userBean.AccessRequestStatus) (*userBean.UserAccessRequestDto, error) {
+	model, err := impl.getAccessRequestWithStatus(action.Id, userBean.AccessRequestPending)
+	if err != nil {
+		return nil, err
+	}
+	model.Status = status
+	model.ActionedBy = action.UserId
+	model.ActionComment = action.Comment
+	model.UpdateAuditLog(action.UserId)
+	err = impl.updateIfStatus(model, userBean.AccessRequestPending)
+	if err != nil {
+		return nil, err
+	}
+	return impl.GetAccessRequestById(model.Id)
+}
+
+func (impl *UserAccessRequestServiceImpl) getRoleIdsGrantedByOtherActiveRequests(model *repository.UserAccessRequest) (map[int]bool, error) {
+	activeRequests, err := impl.userAccessRequestRepository.FindApprovedByUserId(model.UserId)
+	if err != nil {
+		impl.logger.Errorw("error in getting active access requests of user", "userId", model.UserId, "err", err)
+		return nil, err
+	}
+	roleIds := make(map[int]bool)
+	for _, activeRequest := range activeRequests {
+		if activeRequest.Id == model.Id {
+			continue
+		}
+		for _, roleId := range activeRequest.GrantedRoleIds {
+			roleIds[roleId] = true
+		}
+	}
+	return roleIds, nil
+}
+
+func (impl *UserAccessRequestServiceImpl) saveRoleAssignmentAudit(model *repository.UserAccessRequest, roleIds []int, action userBean.RoleAssignmentAction, reason string, performedBy int32) {
+	if len(roleIds) == 0 {
+		return
+	}
+	roles, err := impl.userAuthRepository.GetRolesByIds(roleIds)
+	if err == nil {
+		err = impl.userAuditService.SaveRoleAssignmentAudit(model.UserId, roles, action, model.Id, reason, performedBy)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving role assignment audit", "id", model.Id, "action", action, "err", err)
+	}
+}
+
+func (impl *UserAccessRequestServiceImpl) getAccessRequest(id int) (*repository.UserAccessRequest, error) {
+	model, err := impl.userAccessRequestRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "access request not found", fmt.Sprintf("access request %d not found", id))
+	} else if err != nil {
+		impl.logger.Errorw("error in getting access request", "id", id, "err", err)
+		return nil, err
+	}
+	return model, nil
+}
+
+func (impl *UserAccessRequestServiceImpl) getAccessRequestWithStatus(id int, status userBean.AccessRequestStatus) (*repository.UserAccessRequest, error) {
+	model, err := impl.getAccessRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	if model.Status != status {
+		errMsg := fmt.Sprintf("access request is %s, expected %s", model.Status, status)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return model, nil
+}
+
+func (impl *UserAccessRequestServiceImpl) updateIfStatus(model *repository.UserAccessRequest, status userBean.AccessRequestStatus) error {
+	updated, err := impl.userAccessRequestRepository.UpdateIfStatus(model, status)
+	if err != nil {
+		impl.logger.Errorw("error in updating access request", "id", model.Id, "err", err)
+		return err
+	}
+	if !updated {
+		return util.NewApiError(http.StatusConflict, "access request was updated concurrently, please refresh", fmt.Sprintf("access request %d is no longer %s", model.Id, status))
+	}
+	return nil
+}
+
+// getGrantedRoleIds returns the roles newly mapped to the user along with the roles of the request
+// already granted by other active requests
+func getGrantedRoleIds(roleIds, newlyMappedRoleIds []int, otherActiveRoleIds map[int]bool) []int {
+	grantedRoleIds := make([]int, 0, len(roleIds))
+	grantedRoleIds = append(grantedRoleIds, newlyMappedRoleIds...)
+	newlyMapped := make(map[int]bool, len(newlyMappedRoleIds))
+	for _, roleId := range newlyMappedRoleIds {
+		newlyMapped[roleId] = true
+	}
+	for _, roleId := range roleIds {
+		if !newlyMapped[roleId] && otherActiveRoleIds[roleId] {
+			grantedRoleIds = append(grantedRoleIds, roleId)
+		}
+	}
+	return grantedRoleIds
+}
+
+// getRoleIdsToRevoke skips the roles still granted by other active requests
+func getRoleIdsToRevoke(grantedRoleIds []int, otherActiveRoleIds map[int]bool) []int {
+	roleIds := make([]int, 0, len(grantedRoleIds))
+	for _, roleId := range grantedRoleIds {
+		if !otherActiveRoleIds[roleId] {
+			roleIds = append(roleIds, roleId)
+		}
+	}
+	return roleIds
+}
+
+func adaptToUserAccessRequestDto(model *repository.UserAccessRequest) *userBean.UserAccessRequestDto {
+	request := &userBean.UserAccessRequestDto{
+		Id:             model.Id,
+		UserId:         model.UserId,
+		RoleFilters:    model.RoleFilters,
+		DurationInMins: model.DurationInMins,
+		Justification:  model.Justification,
+		Status:         model.Status,
+		ActionedBy:     model.ActionedBy,
+		ActionComment:  model.ActionComment,
+		RequestedOn:    model.CreatedOn,
+		ApprovedOn:     model.ApprovedOn,
+		ExpiresOn:      model.ExpiresOn,
+		RevokedOn:      model.RevokedOn,
+	}
+	if model.User != nil {
+		request.EmailId = model.User.EmailId
+	}
+	return request
+}
@@ -0,0 +1,436 @@
+package user
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+// accessRequestRepositoryStub keeps the access requests in memory, the requests are copied in and out
+// so that the service only sees the changes it saved
+type accessRequestRepositoryStub struct {
+	repository.UserAccessRequestRepository
+	requests map[int]*repository.UserAccessRequest
+}
+
+func (impl *accessRequestRepositoryStub) FindById(id int) (*repository.UserAccessRequest, error) {
+	request, ok := impl.requests[id]
+	if !ok {
+		return nil, errors.New("pg: no rows in result set")
+	}
+	requestCopy := *request
+	return &requestCopy, nil
+}
+
+func (impl *accessRequestRepositoryStub) UpdateIfStatus(request *repository.UserAccessRequest, status userBean.AccessRequestStatus) (bool, error) {
+	if impl.requests[request.Id].Status != status {
+		return false, nil
+	}
+	requestCopy := *request
+	impl.requests[request.Id] = &requestCopy
+	return true, nil
+}
+
+func (impl *accessRequestRepositoryStub) FindApprovedByUserId(userId int32) ([]*repository.UserAccessRequest, error) {
+	requests := make([]*repository.UserAccessRequest, 0)
+	for id := 1; id <= len(impl.requests); id++ {
+		if request := impl.requests[id]; request.UserId == userId && request.Status == userBean.AccessRequestApproved {
+			requestCopy := *request
+			requests = append(requests, &requestCopy)
+		}
+	}
+	return requests, nil
+}
+
+func (impl *accessRequestRepositoryStub) FindApprovedExpiringBefore(expiresOn time.Time) ([]*repository.UserAccessRequest, error) {
+	requests := make([]*repository.UserAccessRequest, 0)
+	for id := 1; id <= len(impl.requests); id++ {
+		if request := impl.requests[id]; request.Status == userBean.AccessRequestApproved && !request.ExpiresOn.After(expiresOn) {
+			requestCopy := *request
+			requests = append(requests, &requestCopy)
+		}
+	}
+	return requests, nil
+}
+
+func (impl *accessRequestRepositoryStub) ClaimForExpiry(id int, claimedOn time.Time, staleBefore time.Time) (bool, error) {
+	request := impl.requests[id]
+	if request.Status != userBean.AccessRequestApproved || (request.ClaimedOn != nil && !request.ClaimedOn.Before(staleBefore)) {
+		return false, nil
+	}
+	request.ClaimedOn = &claimedOn
+	return true, nil
+}
+
+func (impl *accessRequestRepositoryStub) ReleaseExpiryClaim(id int) error {
+	impl.requests[id].ClaimedOn = nil
+	return nil
+}
+
+// accessRequestUserServiceStub holds the roles mapped to each user, grantErr and revokeErr fail the grant and the revoke
+type accessRequestUserServiceStub struct {
+	UserService
+	roleIdsByFilter map[string][]int
+	userRoleIds     map[int32]map[int]bool
+	grantErr        error
+	revokeErr       error
+	syncCount       int
+}
+
+func (impl *accessRequestUserServiceStub) GrantTimeBoundRoles(userId int32, roleFilters []userBean.RoleFilter, grantedBy int32) ([]int, []int, error) {
+	if impl.grantErr != nil {
+		return nil, nil, impl.grantErr
+	}
+	roleIds, newlyMappedRoleIds := make([]int, 0), make([]int, 0)
+	for _, roleFilter := range roleFilters {
+		for _, roleId := range impl.roleIdsByFilter[roleFilter.Team] {
+			roleIds = append(roleIds, roleId)
+			if !impl.userRoleIds[userId][roleId] {
+				impl.userRoleIds[userId][roleId] = true
+				newlyMappedRoleIds = append(newlyMappedRoleIds, roleId)
+			}
+		}
+	}
+	return roleIds, newlyMappedRoleIds, nil
+}
+
+func (impl *accessRequestUserServiceStub) RevokeTimeBoundRoles(userId int32, roleIds []int) ([]*repository.RoleModel, error) {
+	if impl.revokeErr != nil {
+		return nil, impl.revokeErr
+	}
+	revokedRoles := make([]*repository.RoleModel, 0, len(roleIds))
+	for _, roleId := range roleIds {
+		delete(impl.userRoleIds[userId], roleId)
+		revokedRoles = append(revokedRoles, &repository.RoleModel{Id: roleId})
+	}
+	return revokedRoles, nil
+}
+
+func (impl *accessRequestUserServiceStub) SyncOrchestratorToCasbin() (bool, error) {
+	impl.syncCount++
+	return true, nil
+}
+
+func (impl *accessRequestUserServiceStub) GetEmailById(userId int32) (string, error) {
+	return "", nil
+}
+
+type accessRequestUserAuthRepositoryStub struct {
+	repository.UserAuthRepository
+}
+
+func (impl *accessRequestUserAuthRepositoryStub) GetRolesByIds(ids []int) ([]*repository.RoleModel, error) {
+	roles := make([]*repository.RoleModel, 0, len(ids))
+	for _, id := range ids {
+		roles = append(roles, &repository.RoleModel{Id: id})
+	}
+	return roles, nil
+}
+
+// accessRequestUserAuditServiceStub records the role ids of the assignment audits by action
+type accessRequestUserAuditServiceStub struct {
+	UserAuditService
+	auditedRoleIds map[userBean.RoleAssignmentAction][]int
+}
+
+func (impl *accessRequestUserAuditServiceStub) SaveRoleAssignmentAudit(userId int32, roles []*repository.RoleModel, action userBean.RoleAssignmentAction, accessRequestId int, reason string, performedBy int32) error {
+	for _, role := range roles {
+		impl.auditedRoleIds[action] = append(impl.auditedRoleIds[action], role.Id)
+	}
+	return nil
+}
+
+const (
+	accessRequestTestUserId     int32 = 10
+	accessRequestTestApproverId int32 = 20
+)
+
+func getAccessRequestTestService(t *testing.T, requests []*repository.UserAccessRequest, userService *accessRequestUserServiceStub) (*UserAccessRequestServiceImpl, *accessRequestRepositoryStub, *accessRequestUserAuditServiceStub) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	accessRequestRepository := &accessRequestRepositoryStub{requests: make(map[int]*repository.UserAccessRequest)}
+	for _, request := range requests {
+		accessRequestRepository.requests[request.Id] = request
+	}
+	userAuditService := &accessRequestUserAuditServiceStub{auditedRoleIds: make(map[userBean.RoleAssignmentAction][]int)}
+	impl := &UserAccessRequestServiceImpl{
+		logger:                      logger,
+		config:                      &UserAccessRequestConfig{MaxDurationInMins: 60, ExpiryStaleTimeInMins: 10},
+		userAccessRequestRepository: accessRequestRepository,
+		userAuthRepository:          &accessRequestUserAuthRepositoryStub{},
+		userService:                 userService,
+		userAuditService:            userAuditService,
+	}
+	return impl, accessRequestRepository, userAuditService
+}
+
+func getPendingAccessRequest(id int, team string) *repository.UserAccessRequest {
+	return &repository.UserAccessRequest{
+		Id:             id,
+		UserId:         accessRequestTestUserId,
+		RoleFilters:    []userBean.RoleFilter{{Team: team}},
+		DurationInMins: 30,
+		Status:         userBean.AccessRequestPending,
+	}
+}
+
+func getApprovedAccessRequest(id int, grantedRoleIds []int, expiresOn time.Time) *repository.UserAccessRequest {
+	request := getPendingAccessRequest(id, "")
+	request.Status = userBean.AccessRequestApproved
+	request.GrantedRoleIds = grantedRoleIds
+	request.ExpiresOn = &expiresOn
+	return request
+}
+
+func TestGetGrantedRoleIds(t *testing.T) {
+	tests := []struct {
+		name               string
+		roleIds            []int
+		newlyMappedRoleIds []int
+		otherActiveRoleIds map[int]bool
+		expected           []int
+	}{
+		{
+			name:               "newly mapped roles are granted",
+			roleIds:            []int{1, 2},
+			newlyMappedRoleIds: []int{1, 2},
+			otherActiveRoleIds: map[int]bool{},
+			expected:           []int{1, 2},
+		},
+		{
+			name:               "role granted by another active request is owned by this request as well",
+			roleIds:            []int{1, 2},
+			newlyMappedRoleIds: []int{1},
+			otherActiveRoleIds: map[int]bool{2: true},
+			expected:           []int{1, 2},
+		},
+		{
+			name:               "role the user already held permanently is not granted",
+			roleIds:            []int{1, 3},
+			newlyMappedRoleIds: []int{1},
+			otherActiveRoleIds: map[int]bool{},
+			expected:           []int{1},
+		},
+		{
+			name:               "roles of other active requests outside the request are not granted",
+			roleIds:            []int{1},
+			newlyMappedRoleIds: []int{},
+			otherActiveRoleIds: map[int]bool{4: true},
+			expected:           []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getGrantedRoleIds(tt.roleIds, tt.newlyMappedRoleIds, tt.otherActiveRoleIds))
+		})
+	}
+}
+
+func TestGetRoleIdsToRevoke(t *testing.T) {
+	tests := []struct {
+		name               string
+		grantedRoleIds     []int
+		otherActiveRoleIds map[int]bool
+		expected           []int
+	}{
+		{
+			name:               "granted roles are revoked",
+			grantedRoleIds:     []int{1, 2},
+			otherActiveRoleIds: map[int]bool{},
+			expected:           []int{1, 2},
+		},
+		{
+			name:               "role shared with another active request is kept",
+			grantedRoleIds:     []int{1, 2},
+			otherActiveRoleIds: map[int]bool{2: true},
+			expected:           []int{1},
+		},
+		{
+			name:               "nothing is revoked when every role is shared",
+			grantedRoleIds:     []int{2},
+			otherActiveRoleIds: map[int]bool{2: true},
+			expected:           []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getRoleIdsToRevoke(tt.grantedRoleIds, tt.otherActiveRoleIds))
+		})
+	}
+}
+
+func TestUserAccessRequestServiceImpl_ApproveAccessRequest(t *testing.T) {
+	tests := []struct {
+		name                   string
+		requests               []*repository.UserAccessRequest
+		permanentRoleIds       []int
+		grantErr               error
+		approvedBy             int32
+		expectErr              bool
+		expectedStatus         userBean.AccessRequestStatus
+		expectedGrantedRoleIds []int
+	}{
+		{
+			name:                   "approval grants the newly mapped roles",
+			requests:               []*repository.UserAccessRequest{getPendingAccessRequest(1, "payments")},
+			approvedBy:             accessRequestTestApproverId,
+			expectedStatus:         userBean.AccessRequestApproved,
+			expectedGrantedRoleIds: []int{1, 2},
+		},
+		{
+			name:                   "role the user already held permanently is not owned by the request",
+			requests:               []*repository.UserAccessRequest{getPendingAccessRequest(1, "payments")},
+			permanentRoleIds:       []int{2},
+			approvedBy:             accessRequestTestApproverId,
+			expectedStatus:         userBean.AccessRequestApproved,
+			expectedGrantedRoleIds: []int{1},
+		},
+		{
+			name: "role shared with another active request is owned by both",
+			requests: []*repository.UserAccessRequest{
+				getPendingAccessRequest(1, "payments"),
+				getApprovedAccessRequest(2, []int{2}, time.Now().Add(time.Hour)),
+			},
+			permanentRoleIds:       []int{2},
+			approvedBy:             accessRequestTestApproverId,
+			expectedStatus:         userBean.AccessRequestApproved,
+			expectedGrantedRoleIds: []int{1, 2},
+		},
+		{
+			name:           "requester cannot approve their own request",
+			requests:       []*repository.UserAccessRequest{getPendingAccessRequest(1, "payments")},
+			approvedBy:     accessRequestTestUserId,
+			expectErr:      true,
+			expectedStatus: userBean.AccessRequestPending,
+		},
+		{
+			name:           "request is reverted to pending when the roles cannot be granted",
+			requests:       []*repository.UserAccessRequest{getPendingAccessRequest(1, "payments")},
+			grantErr:       errors.New("casbin unavailable"),
+			approvedBy:     accessRequestTestApproverId,
+			expectErr:      true,
+			expectedStatus: userBean.AccessRequestPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRoleIds := make(map[int]bool)
+			for _, roleId := range tt.permanentRoleIds {
+				userRoleIds[roleId] = true
+			}
+			userService := &accessRequestUserServiceStub{
+				roleIdsByFilter: map[string][]int{"payments": {1, 2}},
+				userRoleIds:     map[int32]map[int]bool{accessRequestTestUserId: userRoleIds},
+				grantErr:        tt.grantErr,
+			}
+			impl, accessRequestRepository, userAuditService := getAccessRequestTestService(t, tt.requests, userService)
+			_, err := impl.ApproveAccessRequest(&userBean.UserAccessRequestActionDto{Id: 1, UserId: tt.approvedBy})
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			request := accessRequestRepository.requests[1]
+			assert.Equal(t, tt.expectedStatus, request.Status)
+			assert.Equal(t, tt.expectedGrantedRoleIds, request.GrantedRoleIds)
+			assert.Equal(t, tt.expectedGrantedRoleIds, userAuditService.auditedRoleIds[userBean.RoleAssignmentGranted])
+			if tt.expectedStatus == userBean.AccessRequestApproved {
+				assert.WithinDuration(t, time.Now().Add(30*time.Minute), *request.ExpiresOn, time.Minute)
+			} else {
+				assert.Nil(t, request.ExpiresOn)
+			}
+		})
+	}
+}
+
+func TestUserAccessRequestServiceImpl_RevokeAccessRequest(t *testing.T) {
+	t.Run("revoke keeps the role shared with another active request", func(t *testing.T) {
+		userService := &accessRequestUserServiceStub{userRoleIds: map[int32]map[int]bool{accessRequestTestUserId: {1: true, 2: true}}}
+		impl, accessRequestRepository, userAuditService := getAccessRequestTestService(t, []*repository.UserAccessRequest{
+			getApprovedAccessRequest(1, []int{1, 2}, time.Now().Add(time.Hour)),
+			getApprovedAccessRequest(2, []int{2}, time.Now().Add(time.Hour)),
+		}, userService)
+		_, err := impl.RevokeAccessRequest(&userBean.UserAccessRequestActionDto{Id: 1, UserId: accessRequestTestApproverId})
+		assert.NoError(t, err)
+		assert.Equal(t, userBean.AccessRequestRevoked, accessRequestRepository.requests[1].Status)
+		assert.Equal(t, "revoked before expiry", accessRequestRepository.requests[1].ActionComment)
+		assert.Equal(t, map[int]bool{2: true}, userService.userRoleIds[accessRequestTestUserId])
+		assert.Equal(t, []int{1}, userAuditService.auditedRoleIds[userBean.RoleAssignmentRevoked])
+	})
+	t.Run("request which is not approved cannot be revoked", func(t *testing.T) {
+		userService := &accessRequestUserServiceStub{userRoleIds: map[int32]map[int]bool{accessRequestTestUserId: {}}}
+		impl, accessRequestRepository, _ := getAccessRequestTestService(t, []*repository.UserAccessRequest{getPendingAccessRequest(1, "payments")}, userService)
+		_, err := impl.RevokeAccessRequest(&userBean.UserAccessRequestActionDto{Id: 1, UserId: accessRequestTestApproverId})
+		assert.Error(t, err)
+		assert.Equal(t, userBean.AccessRequestPending, accessRequestRepository.requests[1].Status)
+	})
+}
+
+func TestUserAccessRequestServiceImpl_RevokeExpiredAccessRequests(t *testing.T) {
+	recentClaim := time.Now().Add(-time.Minute)
+	staleClaim := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name              string
+		claimedOn         *time.Time
+		revokeErr         error
+		expectedStatus    userBean.AccessRequestStatus
+		expectedRoleIds   map[int]bool
+		expectedClaimed   bool
+		expectedSyncCount int
+	}{
+		{
+			name:              "expired request is claimed and its roles revoked",
+			expectedStatus:    userBean.AccessRequestExpired,
+			expectedRoleIds:   map[int]bool{},
+			expectedClaimed:   true,
+			expectedSyncCount: 1,
+		},
+		{
+			name:            "request claimed by another instance is skipped",
+			claimedOn:       &recentClaim,
+			expectedStatus:  userBean.AccessRequestApproved,
+			expectedRoleIds: map[int]bool{1: true},
+			expectedClaimed: true,
+		},
+		{
+			name:              "stale claim of a stopped instance is picked up again",
+			claimedOn:         &staleClaim,
+			expectedStatus:    userBean.AccessRequestExpired,
+			expectedRoleIds:   map[int]bool{},
+			expectedClaimed:   true,
+			expectedSyncCount: 1,
+		},
+		{
+			name:            "claim is released when the roles cannot be revoked",
+			revokeErr:       errors.New("casbin unavailable"),
+			expectedStatus:  userBean.AccessRequestApproved,
+			expectedRoleIds: map[int]bool{1: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := &accessRequestUserServiceStub{
+				userRoleIds: map[int32]map[int]bool{accessRequestTestUserId: {1: true}},
+				revokeErr:   tt.revokeErr,
+			}
+			expiredRequest := getApprovedAccessRequest(1, []int{1}, time.Now().Add(-time.Minute))
+			expiredRequest.ClaimedOn = tt.claimedOn
+			impl, accessRequestRepository, _ := getAccessRequestTestService(t, []*repository.UserAccessRequest{
+				expiredRequest,
+				getApprovedAccessRequest(2, []int{}, time.Now().Add(time.Hour)),
+			}, userService)
+			impl.RevokeExpiredAccessRequests()
+			request := accessRequestRepository.requests[1]
+			assert.Equal(t, tt.expectedStatus, request.Status)
+			assert.Equal(t, tt.expectedClaimed, request.ClaimedOn != nil)
+			assert.Equal(t, tt.expectedRoleIds, userService.userRoleIds[accessRequestTestUserId])
+			assert.Equal(t, tt.expectedSyncCount, userService.syncCount)
+			assert.Equal(t, userBean.AccessRequestApproved, accessRequestRepository.requests[2].Status)
+		})
+	}
+}
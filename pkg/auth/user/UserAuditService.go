@@ -19,6 +19,7 @@ package user
 import (
 	"time"
 
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	repository2 "github.com/devtron-labs/devtron/pkg/auth/user/repository"
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
@@ -37,6 +38,9 @@ type UserAuditService interface {
 	GetLatestUser() (*UserAudit, error)
 	Update(userAudit *UserAudit) error
 	GetActiveUsersCountInLast30Days() (int, error)
+	// SaveRoleAssignmentAudit records the grant or the revoke of the roles of a user
+	SaveRoleAssignmentAudit(userId int32, roles []*repository2.RoleModel, action userBean.RoleAssignmentAction, accessRequestId int, reason string, performedBy int32) error
+	GetRoleAssignmentAudits(userId int32) ([]*userBean.RoleAssignmentAuditDto, error)
 }
 
 type UserAuditServiceImpl struct {
@@ -134,3 +138,50 @@ func (impl UserAuditServiceImpl) GetActiveUsersCountInLast30Days() (int, error)
 	}
 	return count, nil
 }
+
+func (impl UserAuditServiceImpl) SaveRoleAssignmentAudit(userId int32, roles []*repository2.RoleModel, action userBean.RoleAssignmentAction, accessRequestId int, reason string, performedBy int32) error {
+	impl.logger.Infow("Saving role assignment audit", "userId", userId, "action", action, "accessRequestId", accessRequestId)
+	performedOn := time.Now()
+	audits := make([]*repository2.UserRoleAssignmentAudit, 0, len(roles))
+	for _, role := range roles {
+		audits = append(audits, &repository2.UserRoleAssignmentAudit{
+			UserId:          userId,
+			RoleId:          role.Id,
+			Role:            role.Role,
+			Action:          action,
+			AccessRequestId: accessRequestId,
+			Reason:          reason,
+			PerformedBy:     performedBy,
+			PerformedOn:     performedOn,
+		})
+	}
+	err := impl.userAuditRepository.SaveRoleAssignmentAudits(audits)
+	if err != nil {
+		impl.logger.Errorw("error while saving role assignment audit", "userId", userId, "action", action, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (impl UserAuditServiceImpl) GetRoleAssignmentAudits(userId int32) ([]*userBean.RoleAssignmentAuditDto, error) {
+	audits, err := impl.userAuditRepository.GetRoleAssignmentAuditsByUserId(userId)
+	if err != nil {
+		impl.logger.Errorw("error while getting role assignment audits", "userId", userId, "error", err)
+		return nil, err
+	}
+	auditDtos := make([]*userBean.RoleAssignmentAuditDto, 0, len(audits))
+	for _, audit := range audits {
+		auditDtos = append(auditDtos, &userBean.RoleAssignmentAuditDto{
+			Id:              audit.Id,
+			UserId:          audit.UserId,
+			RoleId:          audit.RoleId,
+			Role:            audit.Role,
+			Action:          audit.Action,
+			AccessRequestId: audit.AccessRequestId,
+			Reason:          audit.Reason,
+			PerformedBy:     audit.PerformedBy,
+			PerformedOn:     audit.PerformedOn,
+		})
+	}
+	return auditDtos, nil
+}
@@ -82,6 +82,11 @@ type UserService interface {
 	UpdateUserGroupMappingIfActiveUser(emailId string, groups []string) error
 	GetEmailAndGroupClaimsFromToken(token string) (string, []string, error)
 	SyncOrchestratorToCasbin() (bool, error)
+	// GrantTimeBoundRoles maps the roles of the role filters to the user, returns the ids of all the roles of the
+	// role filters along with the ids of the roles which were not already mapped to the user
+	GrantTimeBoundRoles(userId int32, roleFilters []userBean.RoleFilter, grantedBy int32) (roleIds []int, newlyMappedRoleIds []int, err error)
+	// RevokeTimeBoundRoles removes the mapping of the roles from the user in orchestrator and casbin, returns the revoked roles
+	RevokeTimeBoundRoles(userId int32, roleIds []int) ([]*repository.RoleModel, error)
 	GetUserByToken(context context.Context, token string) (int32, string, error)
 	IsSuperAdmin(userId int, token string) (bool, error)
 	GetByIdIncludeDeleted(id int32) (*userBean.UserInfo, error)
@@ -1627,6 +1632,164 @@ func (impl *UserServiceImpl) SyncOrchestratorToCasbin() (bool, error) {
 	return true, nil
 }
 
+func (impl *UserServiceImpl) GrantTimeBoundRoles(userId int32, roleFilters []userBean.RoleFilter, grantedBy int32) ([]int, []int, error) {
+	if impl.getUserReqLockStateById(userId) {
+		impl.logger.Errorw("received concurrent request for user update, GrantTimeBoundRoles", "userId", userId)
+		return nil, nil, &util.ApiError{
+			Code:           "409",
+			HttpStatusCode: http.StatusConflict,
+			UserMessage:    ConcurrentRequestLockError,
+		}
+	}
+	err := impl.lockUnlockUserReqState(userId, true)
+	if err != nil {
+		impl.logger.Errorw("error in locking, lockUnlockUserReqState", "userId", userId)
+		return nil, nil, err
+	}
+	defer func() {
+		err = impl.lockUnlockUserReqState(userId, false)
+		if err != nil {
+			impl.logger.Errorw("error in unlocking, lockUnlockUserReqState", "userId", userId)
+		}
+	}()
+
+	model, err := impl.userRepository.GetById(userId)
+	if err != nil {
+		impl.logger.Errorw("error while fetching user from db", "userId", userId, "error", err)
+		return nil, nil, err
+	}
+	err = impl.validateUserRequest(&userBean.UserInfo{Id: userId, EmailId: model.EmailId, RoleFilters: roleFilters})
+	if err != nil {
+		impl.logger.Errorw("error in GrantTimeBoundRoles", "userId", userId, "roleFilters", roleFilters, "err", err)
+		return nil, nil, err
+	}
+	userRoleModels, err := impl.userAuthRepository.GetUserRoleMappingByUserId(userId)
+	if err != nil {
+		impl.logger.Errorw("error in getting user role mappings", "userId", userId, "err", err)
+		return nil, nil, err
+	}
+	existingRoles := make(map[int]repository.UserRoleModel, len(userRoleModels))
+	for _, userRoleModel := range userRoleModels {
+		existingRoles[userRoleModel.RoleId] = *userRoleModel
+	}
+
+	dbConnection := impl.userRepository.GetConnection()
+	tx, err := dbConnection.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Rollback tx on error.
+	defer tx.Rollback()
+	capacity, mapping := impl.userCommonService.GetCapacityForRoleFilter(roleFilters)
+	policies := make([]bean4.Policy, 0, capacity)
+	for index, roleFilter := range roleFilters {
+		policiesToBeAdded, _, err := impl.CreateOrUpdateUserRolesForAllTypes(tx, roleFilter, model, existingRoles, roleFilter.Entity, mapping[index], grantedBy)
+		if err != nil {
+			impl.logger.Errorw("error in GrantTimeBoundRoles", "userId", userId, "roleFilter", roleFilter, "err", err)
+			return nil, nil, err
+		}
+		policies = append(policies, policiesToBeAdded...)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+	//loading policy for safety
+	casbin2.LoadPolicy()
+	if len(policies) > 0 {
+		impl.logger.Debugw("casbin policies being added", "policies: ", policies)
+		casbin2.AddPolicy(policies)
+	}
+	//loading policy for syncing orchestrator to casbin with newly added policies
+	casbin2.LoadPolicy()
+
+	// the group policies of the user are the roles of the role filters
+	roleNames := make([]string, 0)
+	for _, policy := range policies {
+		if policy.Type == "g" && string(policy.Sub) == model.EmailId {
+			roleNames = append(roleNames, string(policy.Obj))
+		}
+	}
+	if len(roleNames) == 0 {
+		return nil, nil, nil
+	}
+	roles, err := impl.userAuthRepository.GetRoleByRoles(roleNames)
+	if err != nil {
+		impl.logger.Errorw("error in getting roles", "roles", roleNames, "err", err)
+		return nil, nil, err
+	}
+	roleIds := make([]int, 0, len(roles))
+	newlyMappedRoleIds := make([]int, 0, len(roles))
+	for _, role := range roles {
+		roleIds = append(roleIds, role.Id)
+		if _, ok := existingRoles[role.Id]; !ok {
+			newlyMappedRoleIds = append(newlyMappedRoleIds, role.Id)
+		}
+	}
+	return roleIds, newlyMappedRoleIds, nil
+}
+
+func (impl *UserServiceImpl) RevokeTimeBoundRoles(userId int32, roleIds []int) ([]*repository.RoleModel, error) {
+	if len(roleIds) == 0 {
+		return nil, nil
+	}
+	model, err := impl.userRepository.GetByIdIncludeDeleted(userId)
+	if err != nil {
+		impl.logger.Errorw("error while fetching user from db", "userId", userId, "error", err)
+		return nil, err
+	}
+	userRoleModels, err := impl.userAuthRepository.GetUserRoleMappingByUserId(userId)
+	if err != nil {
+		impl.logger.Errorw("error in getting user role mappings", "userId", userId, "err", err)
+		return nil, err
+	}
+	roleIdsToRevoke := make(map[int]bool, len(roleIds))
+	for _, roleId := range roleIds {
+		roleIdsToRevoke[roleId] = true
+	}
+	userRoleMappingIds := make([]int, 0, len(roleIds))
+	revokedRoleIds := make([]int, 0, len(roleIds))
+	for _, userRoleModel := range userRoleModels {
+		if roleIdsToRevoke[userRoleModel.RoleId] {
+			userRoleMappingIds = append(userRoleMappingIds, userRoleModel.Id)
+			revokedRoleIds = append(revokedRoleIds, userRoleModel.RoleId)
+		}
+	}
+	if len(revokedRoleIds) == 0 {
+		// already removed from the user
+		return nil, nil
+	}
+	roles, err := impl.userAuthRepository.GetRolesByIds(revokedRoleIds)
+	if err != nil {
+		impl.logger.Errorw("error in getting roles by ids", "roleIds", revokedRoleIds, "err", err)
+		return nil, err
+	}
+
+	dbConnection := impl.userRepository.GetConnection()
+	tx, err := dbConnection.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// Rollback tx on error.
+	defer tx.Rollback()
+	err = impl.userAuthRepository.DeleteUserRoleMappingByIds(userRoleMappingIds, tx)
+	if err != nil {
+		impl.logger.Errorw("error in deleting user role mappings", "userId", userId, "userRoleMappingIds", userRoleMappingIds, "err", err)
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]bean4.Policy, 0, len(roles))
+	for _, role := range roles {
+		policies = append(policies, adapter.GetCasbinGroupPolicyForEmailAndRoleOnly(model.EmailId, role.Role))
+	}
+	impl.logger.Debugw("casbin policies being eliminated", "policies: ", policies, "userId", userId)
+	casbin2.RemovePolicy(policies)
+	return roles, nil
+}
+
 func (impl *UserServiceImpl) IsSuperAdmin(userId int, token string) (bool, error) {
 	//validating if action user is not admin and trying to update user who has super admin polices, return 403
 	isSuperAdmin := false
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type AccessRequestStatus string
+
+const (
+	AccessRequestPending   AccessRequestStatus = "PENDING"
+	AccessRequestApproved  AccessRequestStatus = "APPROVED"
+	AccessRequestRejected  AccessRequestStatus = "REJECTED"
+	AccessRequestCancelled AccessRequestStatus = "CANCELLED"
+	AccessRequestRevoked   AccessRequestStatus = "REVOKED"
+	AccessRequestExpired   AccessRequestStatus = "EXPIRED"
+)
+
+type RoleAssignmentAction string
+
+const (
+	RoleAssignmentGranted RoleAssignmentAction = "GRANTED"
+	RoleAssignmentRevoked RoleAssignmentAction = "REVOKED"
+)
+
+// UserAccessRequestDto is a request of a user for the roles of the role filters for a duration,
+// the roles are granted on approval and revoked on expiry
+type UserAccessRequestDto struct {
+	Id             int                 `json:"id"`
+	UserId         int32               `json:"userId"`
+	EmailId        string              `json:"emailId"`
+	RoleFilters    []RoleFilter        `json:"roleFilters" validate:"required,min=1"`
+	DurationInMins int                 `json:"durationInMins" validate:"required,min=1"`
+	Justification  string              `json:"justification" validate:"required,max=1000"`
+	Status         AccessRequestStatus `json:"status"`
+	// ActionedBy is the user who approved, rejected, cancelled or revoked the request
+	ActionedBy      int32      `json:"actionedBy,omitempty"`
+	ActionedByEmail string     `json:"actionedByEmail,omitempty"`
+	ActionComment   string     `json:"actionComment,omitempty"`
+	RequestedOn     time.Time  `json:"requestedOn"`
+	ApprovedOn      *time.Time `json:"approvedOn,omitempty"`
+	ExpiresOn       *time.Time `json:"expiresOn,omitempty"`
+	RevokedOn       *time.Time `json:"revokedOn,omitempty"`
+}
+
+type UserAccessRequestActionDto struct {
+	Id      int    `json:"-"`
+	Comment string `json:"comment" validate:"max=1000"`
+	UserId  int32  `json:"-"` // user performing the action
+}
+
+type UserAccessRequestFilter struct {
+	UserId int32
+	Status AccessRequestStatus
+}
+
+type RoleAssignmentAuditDto struct {
+	Id              int                  `json:"id"`
+	UserId          int32                `json:"userId"`
+	RoleId          int                  `json:"roleId"`
+	Role            string               `json:"role"`
+	Action          RoleAssignmentAction `json:"action"`
+	AccessRequestId int                  `json:"accessRequestId,omitempty"`
+	Reason          string               `json:"reason,omitempty"`
+	PerformedBy     int32                `json:"performedBy"`
+	PerformedOn     time.Time            `json:"performedOn"`
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "strconv"
+
+type AccessReviewEntityType string
+
+const (
+	AccessReviewEntityProject AccessReviewEntityType = "project"
+	AccessReviewEntityApp     AccessReviewEntityType = "app"
+	AccessReviewEntityEnv     AccessReviewEntityType = "env"
+	AccessReviewEntityCluster AccessReviewEntityType = "cluster"
+	AccessReviewEntityHelmApp AccessReviewEntityType = "helm-app"
+)
+
+type AccessReviewSubjectType string
+
+const (
+	AccessReviewSubjectUser     AccessReviewSubjectType = "USER"
+	AccessReviewSubjectApiToken AccessReviewSubjectType = "API_TOKEN"
+	AccessReviewSubjectGroup    AccessReviewSubjectType = "GROUP"
+)
+
+// AccessGrantType tells how the permission has reached the subject
+type AccessGrantType string
+
+const (
+	AccessGrantDirect     AccessGrantType = "DIRECT"
+	AccessGrantGroup      AccessGrantType = "GROUP"
+	AccessGrantSuperAdmin AccessGrantType = "SUPER_ADMIN"
+)
+
+type PermissionExportFormat string
+
+const (
+	PermissionExportFormatJson PermissionExportFormat = "json"
+	PermissionExportFormatCsv  PermissionExportFormat = "csv"
+)
+
+// AccessReviewRequest identifies the resource to be reviewed, the fields required depend on the entity type
+//   - project: Project
+//   - app: Project, App
+//   - env: Env(environment identifier), App
+//   - cluster: Cluster
+//   - helm-app: Project, Cluster, Namespace, App
+type AccessReviewRequest struct {
+	EntityType AccessReviewEntityType `json:"entityType" validate:"required,oneof=project app env cluster helm-app"`
+	Action     string                 `json:"action" validate:"required"`
+	Project    string                 `json:"project"`
+	App        string                 `json:"app"`
+	Env        string                 `json:"env"`
+	Cluster    string                 `json:"cluster"`
+	Namespace  string                 `json:"namespace"`
+}
+
+type AccessReviewResponse struct {
+	Resource     string                 `json:"resource"`
+	Action       string                 `json:"action"`
+	ResourceItem string                 `json:"resourceItem"`
+	Subjects     []*AccessReviewSubject `json:"subjects"`
+}
+
+type AccessReviewSubject struct {
+	Id          int32                   `json:"id"`
+	Name        string                  `json:"name"`
+	SubjectType AccessReviewSubjectType `json:"subjectType"`
+	Grants      []*AccessGrant          `json:"grants"`
+}
+
+type AccessGrant struct {
+	GrantType AccessGrantType `json:"grantType"`
+	Role      string          `json:"role"`
+	Group     string          `json:"group,omitempty"`
+}
+
+// EffectivePermission is a row of the subject to effective permission matrix
+type EffectivePermission struct {
+	SubjectId    int32                   `json:"subjectId"`
+	Subject      string                  `json:"subject"`
+	SubjectType  AccessReviewSubjectType `json:"subjectType"`
+	Resource     string                  `json:"resource"`
+	Action       string                  `json:"action"`
+	ResourceItem string                  `json:"resourceItem"`
+	Effect       string                  `json:"effect"`
+	GrantType    AccessGrantType         `json:"grantType"`
+	Role         string                  `json:"role"`
+	Group        string                  `json:"group"`
+}
+
+func GetEffectivePermissionCsvHeader() []string {
+	return []string{"subjectId", "subject", "subjectType", "resource", "action", "resourceItem", "effect", "grantType", "role", "group"}
+}
+
+func (permission *EffectivePermission) ToCsvRecord() []string {
+	return []string{strconv.Itoa(int(permission.SubjectId)), permission.Subject, string(permission.SubjectType), permission.Resource,
+		permission.Action, permission.ResourceItem, permission.Effect, string(permission.GrantType), permission.Role, permission.Group}
+}
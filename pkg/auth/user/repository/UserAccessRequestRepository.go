@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+)
+
+type UserAccessRequest struct {
+	TableName      struct{}                  `sql:"user_access_request" pg:",discard_unknown_columns"`
+	Id             int                       `sql:"id,pk"`
+	UserId         int32                     `sql:"user_id,notnull"`
+	RoleFilters    []bean2.RoleFilter        `sql:"role_filters"`
+	DurationInMins int                       `sql:"duration_in_mins,notnull"`
+	Justification  string                    `sql:"justification,notnull"`
+	Status         bean2.AccessRequestStatus `sql:"status,notnull"`
+	// GrantedRoleIds are the roles mapped to the user by this request, revoked on expiry
+	GrantedRoleIds []int      `sql:"granted_role_ids,array"`
+	ActionedBy     int32      `sql:"actioned_by"`
+	ActionComment  string     `sql:"action_comment"`
+	ApprovedOn     *time.Time `sql:"approved_on"`
+	ExpiresOn      *time.Time `sql:"expires_on"`
+	RevokedOn      *time.Time `sql:"revoked_on"`
+	// ClaimedOn is set by the instance revoking the request on expiry
+	ClaimedOn *time.Time `sql:"claimed_on"`
+	User      *UserModel
+	sql.AuditLog
+}
+
+type UserAccessRequestRepository interface {
+	GetConnection() *pg.DB
+	Save(request *UserAccessRequest) error
+	// UpdateIfStatus updates the request only if its status in DB is the given status, returns false otherwise
+	UpdateIfStatus(request *UserAccessRequest, status bean2.AccessRequestStatus) (bool, error)
+	FindById(id int) (*UserAccessRequest, error)
+	FindByFilter(userId int32, status bean2.AccessRequestStatus) ([]*UserAccessRequest, error)
+	FindApprovedByUserId(userId int32) ([]*UserAccessRequest, error)
+	FindApprovedExpiringBefore(expiresOn time.Time) ([]*UserAccessRequest, error)
+	// ClaimForExpiry claims an approved request for revoking on expiry, returns false if another instance holds a
+	// claim newer than staleBefore or the request is no longer approved
+	ClaimForExpiry(id int, claimedOn time.Time, staleBefore time.Time) (bool, error)
+	// ReleaseExpiryClaim releases the claim of a request which could not be revoked, so that it is retried
+	ReleaseExpiryClaim(id int) error
+}
+
+type UserAccessRequestRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewUserAccessRequestRepositoryImpl(dbConnection *pg.DB) *UserAccessRequestRepositoryImpl {
+	return &UserAccessRequestRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl UserAccessRequestRepositoryImpl) GetConnection() *pg.DB {
+	return impl.dbConnection
+}
+
+func (impl UserAccessRequestRepositoryImpl) Save(request *UserAccessRequest) error {
+	return impl.dbConnection.Insert(request)
+}
+
+func (impl UserAccessRequestRepositoryImpl) UpdateIfStatus(request *UserAccessRequest, status bean2.AccessRequestStatus) (bool, error) {
+	res, err := impl.dbConnection.Model(request).
+		Where("id = ?", request.Id).
+		Where("status = ?", status).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (impl UserAccessRequestRepositoryImpl) FindById(id int) (*UserAccessRequest, error) {
+	request := &UserAccessRequest{}
+	err := impl.dbConnection.Model(request).
+		Column("user_access_request.*", "User").
+		Where("user_access_request.id = ?", id).
+		Select()
+	return request, err
+}
+
+func (impl UserAccessRequestRepositoryImpl) FindByFilter(userId int32, status bean2.AccessRequestStatus) ([]*UserAccessRequest, error) {
+	var requests []*UserAccessRequest
+	query := impl.dbConnection.Model(&requests).
+		Column("user_access_request.*", "User")
+	if userId > 0 {
+		query = query.Where("user_access_request.user_id = ?", userId)
+	}
+	if len(status) > 0 {
+		query = query.Where("user_access_request.status = ?", status)
+	}
+	err := query.Order("user_access_request.id DESC").Select()
+	return requests, err
+}
+
+func (impl UserAccessRequestRepositoryImpl) FindApprovedByUserId(userId int32) ([]*UserAccessRequest, error) {
+	var requests []*UserAccessRequest
+	err := impl.dbConnection.Model(&requests).
+		Where("user_id = ?", userId).
+		Where("status = ?", bean2.AccessRequestApproved).
+		Select()
+	return requests, err
+}
+
+func (impl UserAccessRequestRepositoryImpl) FindApprovedExpiringBefore(expiresOn time.Time) ([]*UserAccessRequest, error) {
+	var requests []*UserAccessRequest
+	err := impl.dbConnection.Model(&requests).
+		Where("status = ?", bean2.AccessRequestApproved).
+		Where("expires_on <= ?", expiresOn).
+		Order("expires_on ASC").
+		Select()
+	return requests, err
+}
+
+func (impl UserAccessRequestRepositoryImpl) ClaimForExpiry(id int, claimedOn time.Time, staleBefore time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model(&UserAccessRequest{}).
+		Set("claimed_on = ?", claimedOn).
+		Where("id = ?", id).
+		Where("status = ?", bean2.AccessRequestApproved).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("claimed_on IS NULL").
+				WhereOr("claimed_on < ?", staleBefore)
+			return q, nil
+		}).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (impl UserAccessRequestRepositoryImpl) ReleaseExpiryClaim(id int) error {
+	_, err := impl.dbConnection.Model(&UserAccessRequest{}).
+		Set("claimed_on = NULL").
+		Where("id = ?", id).
+		Update()
+	return err
+}
@@ -22,6 +22,7 @@ package repository
 import (
 	"time"
 
+	bean2 "github.com/devtron-labs/devtron/pkg/auth/user/bean"
 	"github.com/go-pg/pg"
 )
 
@@ -34,12 +35,28 @@ type UserAudit struct {
 	UpdatedOn time.Time `sql:"updated_on,type:timestamptz"`
 }
 
+// UserRoleAssignmentAudit records a grant or a revoke of a role of a user
+type UserRoleAssignmentAudit struct {
+	TableName       struct{}                   `sql:"user_role_assignment_audit"`
+	Id              int                        `sql:"id,pk"`
+	UserId          int32                      `sql:"user_id,notnull"`
+	RoleId          int                        `sql:"role_id,notnull"`
+	Role            string                     `sql:"role,notnull"`
+	Action          bean2.RoleAssignmentAction `sql:"action,notnull"`
+	AccessRequestId int                        `sql:"access_request_id"`
+	Reason          string                     `sql:"reason"`
+	PerformedBy     int32                      `sql:"performed_by,notnull"`
+	PerformedOn     time.Time                  `sql:"performed_on,type:timestamptz"`
+}
+
 type UserAuditRepository interface {
 	Save(userAudit *UserAudit) error
 	GetLatestByUserId(userId int32) (*UserAudit, error)
 	GetLatestUser() (*UserAudit, error)
 	Update(userAudit *UserAudit) error
 	GetActiveUsersCountInLast30Days() (int, error)
+	SaveRoleAssignmentAudits(audits []*UserRoleAssignmentAudit) error
+	GetRoleAssignmentAuditsByUserId(userId int32) ([]*UserRoleAssignmentAudit, error)
 }
 
 type UserAuditRepositoryImpl struct {
@@ -100,3 +117,19 @@ func (impl UserAuditRepositoryImpl) GetActiveUsersCountInLast30Days() (int, erro
 
 	return count, err
 }
+
+func (impl UserAuditRepositoryImpl) SaveRoleAssignmentAudits(audits []*UserRoleAssignmentAudit) error {
+	if len(audits) == 0 {
+		return nil
+	}
+	return impl.dbConnection.Insert(&audits)
+}
+
+func (impl UserAuditRepositoryImpl) GetRoleAssignmentAuditsByUserId(userId int32) ([]*UserRoleAssignmentAudit, error) {
+	var audits []*UserRoleAssignmentAudit
+	err := impl.dbConnection.Model(&audits).
+		Where("user_id = ?", userId).
+		Order("id desc").
+		Select()
+	return audits, err
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/auth/user/util"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
 	"go.uber.org/zap"
 	"time"
 )
@@ -38,6 +39,7 @@ type UserRepository interface {
 	GetEmailByIds(ids []int32) ([]string, error)
 	GetByIdIncludeDeleted(id int32) (*UserModel, error)
 	GetAllExcludingApiTokenUser() ([]UserModel, error)
+	GetAllActive() ([]UserModel, error)
 	GetAllExecutingQuery(query string, queryParams []interface{}) ([]UserModel, error)
 	//GetAllUserRoleMappingsForRoleId(roleId int) ([]UserRoleModel, error)
 	FetchActiveUserByEmail(email string) (userBean.UserInfo, error)
@@ -164,6 +166,18 @@ func (impl UserRepositoryImpl) GetAllExcludingApiTokenUser() ([]UserModel, error
 	return userModel, err
 }
 
+// GetAllActive returns all the active users including the api token users
+func (impl UserRepositoryImpl) GetAllActive() ([]UserModel, error) {
+	var userModel []UserModel
+	err := impl.dbConnection.Model(&userModel).
+		Where("active = ?", true).
+		Order("id asc").Select()
+	for i, user := range userModel {
+		userModel[i].EmailId = util.ConvertEmailToLowerCase(user.EmailId)
+	}
+	return userModel, err
+}
+
 func (impl UserRepositoryImpl) GetAllExecutingQuery(query string, queryParams []interface{}) ([]UserModel, error) {
 	var userModel []UserModel
 	_, err := impl.dbConnection.Query(&userModel, query, queryParams...)
@@ -270,10 +284,15 @@ func (impl UserRepositoryImpl) GetCountExecutingQuery(query string, queryParams
 // below method does operation on api_token table,
 // we are writing this method here instead of ApiTokenRepository to avoid cyclic import
 func (impl UserRepositoryImpl) CheckIfTokenExistsByTokenNameAndVersion(tokenName string, tokenVersion int) (bool, error) {
+	// the previous version of a rotated token is valid till the end of the overlap window
 	query := impl.dbConnection.Model().
 		Table(userBean.ApiTokenTableName).
 		Where("name = ?", tokenName).
-		Where("version = ?", tokenVersion)
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.Where("version = ?", tokenVersion).
+				WhereOr("previous_version = ? AND previous_version_expire_at_in_ms > ?", tokenVersion, time.Now().UnixMilli())
+			return q, nil
+		})
 
 	exists, err := query.Exists()
 	return exists, err
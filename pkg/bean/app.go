@@ -30,6 +30,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/bean/common"
 	CiPipeline2 "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	common2 "github.com/devtron-labs/devtron/pkg/build/pipeline/bean/common"
+	scheduleBean "github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/bean"
 	"github.com/devtron-labs/devtron/pkg/chartRepo/repository"
 	bean3 "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
@@ -151,6 +152,8 @@ type CiPipeline struct {
 	CustomTagObject          *CustomTagData         `json:"customTag,omitempty"`
 	DefaultTag               []string               `json:"defaultTag,omitempty"`
 	EnableCustomTag          bool                   `json:"enableCustomTag"`
+	// Schedule is left unchanged on update when not sent, send an empty cron expression to remove it
+	Schedule *scheduleBean.CiPipelineSchedule `json:"schedule,omitempty"`
 }
 
 func (ciPipeline *CiPipeline) IsLinkedCi() bool {
@@ -655,6 +658,7 @@ type CDPipelineConfigObject struct {
 	ApplicationObjectNamespace    string                                 `json:"applicationObjectNamespace"` //ACDAppNamespace
 	DeploymentAppName             string                                 `json:"deploymentAppName"`
 	ReleaseMode                   string                                 `json:"releaseMode" validate:"omitempty,oneof=link create"`
+	PromotionCondition            string                                 `json:"promotionCondition,omitempty"`
 }
 
 func (cdPipelineConfig *CDPipelineConfigObject) IsFluxDeploymentAppType() bool {
@@ -0,0 +1,181 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/adapter"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/bean"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"net/http"
+	"time"
+)
+
+type CiPipelineScheduleService interface {
+	// ValidateSchedule validates the cron expression and the timezone of the schedule
+	ValidateSchedule(schedule *bean.CiPipelineSchedule) error
+	// GetSchedule returns the active schedule of the ci pipeline, nil if the pipeline is not scheduled
+	GetSchedule(ciPipelineId int) (*bean.CiPipelineSchedule, error)
+	// SaveSchedule creates or updates the schedule of the ci pipeline, a schedule with an empty cron expression removes it
+	SaveSchedule(ciPipelineId int, schedule *bean.CiPipelineSchedule, userId int32) (*bean.CiPipelineSchedule, error)
+	DeleteSchedule(ciPipelineId int, userId int32, tx *pg.Tx) error
+	GetDueSchedules() ([]*repository.CiPipelineSchedule, error)
+	// MarkTriggered moves the schedule to its next trigger time. It returns false when the schedule
+	// was already picked up by another replica and the pipeline must not be triggered again.
+	MarkTriggered(schedule *repository.CiPipelineSchedule) (bool, error)
+}
+
+type CiPipelineScheduleServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	ciPipelineScheduleRepository repository.CiPipelineScheduleRepository
+}
+
+func NewCiPipelineScheduleServiceImpl(logger *zap.SugaredLogger,
+	ciPipelineScheduleRepository repository.CiPipelineScheduleRepository) *CiPipelineScheduleServiceImpl {
+	return &CiPipelineScheduleServiceImpl{
+		logger:                       logger,
+		ciPipelineScheduleRepository: ciPipelineScheduleRepository,
+	}
+}
+
+// GetNextTriggerTime returns the first time after the given time matching the cron expression in the given timezone
+func GetNextTriggerTime(cronExpression, timezone string, after time.Time) (time.Time, error) {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+	schedule, err := cron.ParseStandard(cronExpression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", cronExpression, err)
+	}
+	next := schedule.Next(after.In(location))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q never matches", cronExpression)
+	}
+	return next.UTC(), nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) ValidateSchedule(schedule *bean.CiPipelineSchedule) error {
+	if schedule == nil || schedule.IsRemoveRequest() {
+		return nil
+	}
+	_, err := GetNextTriggerTime(schedule.CronExpression, schedule.GetTimezone(), time.Now())
+	if err != nil {
+		return util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	return nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) GetSchedule(ciPipelineId int) (*bean.CiPipelineSchedule, error) {
+	model, err := impl.ciPipelineScheduleRepository.FindByCiPipelineId(ciPipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows || !model.Active {
+		return nil, nil
+	}
+	return adapter.GetCiPipelineScheduleDto(model), nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) SaveSchedule(ciPipelineId int, schedule *bean.CiPipelineSchedule, userId int32) (*bean.CiPipelineSchedule, error) {
+	model, err := impl.ciPipelineScheduleRepository.FindByCiPipelineId(ciPipelineId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+		return nil, err
+	}
+	exists := err == nil
+	if schedule.IsRemoveRequest() {
+		if exists && model.Active {
+			model.Active = false
+			model.UpdateAuditLog(userId)
+			err = impl.ciPipelineScheduleRepository.Update(model)
+			if err != nil {
+				impl.logger.Errorw("error in removing ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	timezone := schedule.GetTimezone()
+	nextTriggerOn, err := GetNextTriggerTime(schedule.CronExpression, timezone, time.Now())
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	if !exists {
+		model = &repository.CiPipelineSchedule{
+			CiPipelineId: ciPipelineId,
+			AuditLog:     sql.NewDefaultAuditLog(userId),
+		}
+	} else {
+		model.UpdateAuditLog(userId)
+	}
+	model.CronExpression = schedule.CronExpression
+	model.Timezone = timezone
+	model.SkipIfRunning = schedule.SkipIfRunning
+	model.SkipIfNoNewCommit = schedule.SkipIfNoNewCommit
+	model.Active = true
+	model.NextTriggerOn = nextTriggerOn
+	if !exists {
+		err = impl.ciPipelineScheduleRepository.Save(model)
+	} else {
+		err = impl.ciPipelineScheduleRepository.Update(model)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+		return nil, err
+	}
+	return adapter.GetCiPipelineScheduleDto(model), nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) DeleteSchedule(ciPipelineId int, userId int32, tx *pg.Tx) error {
+	err := impl.ciPipelineScheduleRepository.DeactivateByCiPipelineId(ciPipelineId, userId, tx)
+	if err != nil {
+		impl.logger.Errorw("error in deleting ci pipeline schedule", "ciPipelineId", ciPipelineId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) GetDueSchedules() ([]*repository.CiPipelineSchedule, error) {
+	schedules, err := impl.ciPipelineScheduleRepository.FindDue(time.Now())
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching due ci pipeline schedules", "err", err)
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (impl *CiPipelineScheduleServiceImpl) MarkTriggered(schedule *repository.CiPipelineSchedule) (bool, error) {
+	now := time.Now()
+	// triggers missed while devtron was down are not replayed, the schedule resumes from now
+	nextTriggerOn, err := GetNextTriggerTime(schedule.CronExpression, schedule.Timezone, now)
+	if err != nil {
+		impl.logger.Errorw("error in computing next trigger time", "ciPipelineId", schedule.CiPipelineId, "cronExpression", schedule.CronExpression, "err", err)
+		return false, err
+	}
+	claimed, err := impl.ciPipelineScheduleRepository.MarkTriggered(schedule.Id, schedule.NextTriggerOn, nextTriggerOn, now)
+	if err != nil {
+		impl.logger.Errorw("error in updating ci pipeline schedule", "ciPipelineId", schedule.CiPipelineId, "err", err)
+		return false, err
+	}
+	return claimed, nil
+}
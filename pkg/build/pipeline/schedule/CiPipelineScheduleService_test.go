@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/repository"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNextTriggerTime(t *testing.T) {
+	after := time.Date(2024, time.March, 10, 1, 30, 0, 0, time.UTC)
+	t.Run("utc", func(t *testing.T) {
+		next, err := GetNextTriggerTime("0 2 * * *", "UTC", after)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC), next)
+	})
+	t.Run("evaluated in timezone", func(t *testing.T) {
+		next, err := GetNextTriggerTime("0 2 * * *", "Asia/Kolkata", after)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2024, time.March, 10, 20, 30, 0, 0, time.UTC), next)
+	})
+	t.Run("invalid cron expression", func(t *testing.T) {
+		_, err := GetNextTriggerTime("0 2 * *", "UTC", after)
+		assert.NotNil(t, err)
+	})
+	t.Run("invalid timezone", func(t *testing.T) {
+		_, err := GetNextTriggerTime("0 2 * * *", "Mars/Olympus", after)
+		assert.NotNil(t, err)
+	})
+}
+
+// ciPipelineScheduleRepositoryStub keeps the schedules in memory and moves them like the
+// conditional update of the repository, only when they are still active and due on the given time
+type ciPipelineScheduleRepositoryStub struct {
+	repository.CiPipelineScheduleRepository
+	schedules map[int]*repository.CiPipelineSchedule
+}
+
+func (impl *ciPipelineScheduleRepositoryStub) MarkTriggered(id int, dueOn time.Time, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error) {
+	schedule, ok := impl.schedules[id]
+	if !ok || !schedule.Active || !schedule.NextTriggerOn.Equal(dueOn) {
+		return false, nil
+	}
+	schedule.NextTriggerOn = nextTriggerOn
+	schedule.LastTriggeredOn = triggeredOn
+	return true, nil
+}
+
+func TestCiPipelineScheduleServiceImpl_MarkTriggered(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	dueOn := time.Now().Add(-time.Minute).UTC()
+	getDueSchedule := func() *repository.CiPipelineSchedule {
+		return &repository.CiPipelineSchedule{Id: 1, CiPipelineId: 10, CronExpression: "*/5 * * * *", Timezone: "UTC", Active: true, NextTriggerOn: dueOn}
+	}
+
+	t.Run("due run is claimed once", func(t *testing.T) {
+		repositoryStub := &ciPipelineScheduleRepositoryStub{schedules: map[int]*repository.CiPipelineSchedule{1: getDueSchedule()}}
+		impl := NewCiPipelineScheduleServiceImpl(logger, repositoryStub)
+		// both replicas fetched the schedule before either of them claimed it
+		fetchedByReplica1, fetchedByReplica2 := getDueSchedule(), getDueSchedule()
+		claimed, err := impl.MarkTriggered(fetchedByReplica1)
+		assert.Nil(t, err)
+		assert.True(t, claimed)
+		claimed, err = impl.MarkTriggered(fetchedByReplica2)
+		assert.Nil(t, err)
+		assert.False(t, claimed)
+		stored := repositoryStub.schedules[1]
+		assert.True(t, stored.NextTriggerOn.After(time.Now()))
+		assert.False(t, stored.LastTriggeredOn.IsZero())
+	})
+	t.Run("missed runs are not replayed", func(t *testing.T) {
+		schedule := getDueSchedule()
+		schedule.NextTriggerOn = time.Now().Add(-24 * time.Hour).UTC()
+		repositoryStub := &ciPipelineScheduleRepositoryStub{schedules: map[int]*repository.CiPipelineSchedule{1: schedule}}
+		impl := NewCiPipelineScheduleServiceImpl(logger, repositoryStub)
+		fetched := *schedule
+		claimed, err := impl.MarkTriggered(&fetched)
+		assert.Nil(t, err)
+		assert.True(t, claimed)
+		assert.True(t, schedule.NextTriggerOn.After(time.Now()))
+		assert.True(t, schedule.NextTriggerOn.Before(time.Now().Add(5*time.Minute)))
+	})
+	t.Run("removed schedule is not claimed", func(t *testing.T) {
+		schedule := getDueSchedule()
+		schedule.Active = false
+		impl := NewCiPipelineScheduleServiceImpl(logger, &ciPipelineScheduleRepositoryStub{schedules: map[int]*repository.CiPipelineSchedule{1: schedule}})
+		claimed, err := impl.MarkTriggered(getDueSchedule())
+		assert.Nil(t, err)
+		assert.False(t, claimed)
+	})
+	t.Run("invalid cron expression", func(t *testing.T) {
+		schedule := getDueSchedule()
+		schedule.CronExpression = "*/5 * *"
+		impl := NewCiPipelineScheduleServiceImpl(logger, &ciPipelineScheduleRepositoryStub{schedules: map[int]*repository.CiPipelineSchedule{1: getDueSchedule()}})
+		claimed, err := impl.MarkTriggered(schedule)
+		assert.NotNil(t, err)
+		assert.False(t, claimed)
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+const DefaultTimezone = "UTC"
+
+// CiPipelineSchedule triggers a ci or job pipeline periodically on the given cron expression.
+// A schedule with an empty cron expression removes the existing schedule of the pipeline.
+type CiPipelineSchedule struct {
+	CronExpression string `json:"cronExpression"`
+	// Timezone is an IANA time zone name in which the cron expression is evaluated, defaults to UTC
+	Timezone string `json:"timezone,omitempty"`
+	// SkipIfRunning skips the scheduled trigger while the last build of the pipeline is still in progress
+	SkipIfRunning bool `json:"skipIfRunning"`
+	// SkipIfNoNewCommit skips the scheduled trigger if the head of every material was already built by the last build
+	SkipIfNoNewCommit bool       `json:"skipIfNoNewCommit"`
+	NextTriggerOn     *time.Time `json:"nextTriggerOn,omitempty"`
+	LastTriggeredOn   *time.Time `json:"lastTriggeredOn,omitempty"`
+}
+
+func (schedule *CiPipelineSchedule) IsRemoveRequest() bool {
+	return schedule != nil && len(schedule.CronExpression) == 0
+}
+
+func (schedule *CiPipelineSchedule) GetTimezone() string {
+	if schedule == nil || len(schedule.Timezone) == 0 {
+		return DefaultTimezone
+	}
+	return schedule.Timezone
+}
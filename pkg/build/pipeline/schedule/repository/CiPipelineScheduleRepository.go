@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"time"
+)
+
+type CiPipelineSchedule struct {
+	tableName         struct{}  `sql:"ci_pipeline_schedule" pg:",discard_unknown_columns"`
+	Id                int       `sql:"id,pk"`
+	CiPipelineId      int       `sql:"ci_pipeline_id,notnull"`
+	CronExpression    string    `sql:"cron_expression,notnull"`
+	Timezone          string    `sql:"timezone,notnull"`
+	SkipIfRunning     bool      `sql:"skip_if_running,notnull"`
+	SkipIfNoNewCommit bool      `sql:"skip_if_no_new_commit,notnull"`
+	Active            bool      `sql:"active,notnull"`
+	NextTriggerOn     time.Time `sql:"next_trigger_on,type:timestamptz"`
+	LastTriggeredOn   time.Time `sql:"last_triggered_on,type:timestamptz"`
+	sql.AuditLog
+}
+
+type CiPipelineScheduleRepository interface {
+	Save(model *CiPipelineSchedule) error
+	Update(model *CiPipelineSchedule) error
+	FindByCiPipelineId(ciPipelineId int) (*CiPipelineSchedule, error)
+	// FindDue returns the active schedules of active ci pipelines which were due on or before the given time
+	FindDue(dueOn time.Time) ([]*CiPipelineSchedule, error)
+	// MarkTriggered moves the schedule to its next trigger time, it returns false if the schedule was already
+	// moved by another replica since it was fetched, in which case the caller must not trigger the pipeline
+	MarkTriggered(id int, dueOn time.Time, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error)
+	DeactivateByCiPipelineId(ciPipelineId int, userId int32, tx *pg.Tx) error
+}
+
+type CiPipelineScheduleRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewCiPipelineScheduleRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *CiPipelineScheduleRepositoryImpl {
+	return &CiPipelineScheduleRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) Save(model *CiPipelineSchedule) error {
+	return impl.dbConnection.Insert(model)
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) Update(model *CiPipelineSchedule) error {
+	return impl.dbConnection.Update(model)
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) FindByCiPipelineId(ciPipelineId int) (*CiPipelineSchedule, error) {
+	model := &CiPipelineSchedule{}
+	err := impl.dbConnection.Model(model).
+		Where("ci_pipeline_id = ?", ciPipelineId).
+		Select()
+	return model, err
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) FindDue(dueOn time.Time) ([]*CiPipelineSchedule, error) {
+	var models []*CiPipelineSchedule
+	err := impl.dbConnection.Model(&models).
+		Join("INNER JOIN ci_pipeline cp ON cp.id = ci_pipeline_schedule.ci_pipeline_id").
+		Where("ci_pipeline_schedule.active = ?", true).
+		Where("ci_pipeline_schedule.next_trigger_on <= ?", dueOn).
+		Where("cp.active = ?", true).
+		Where("cp.deleted = ?", false).
+		Order("ci_pipeline_schedule.next_trigger_on ASC").
+		Select()
+	return models, err
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) MarkTriggered(id int, dueOn time.Time, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*CiPipelineSchedule)(nil)).
+		Set("next_trigger_on = ?", nextTriggerOn).
+		Set("last_triggered_on = ?", triggeredOn).
+		Set("updated_on = ?", triggeredOn).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Where("next_trigger_on = ?", dueOn).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *CiPipelineScheduleRepositoryImpl) DeactivateByCiPipelineId(ciPipelineId int, userId int32, tx *pg.Tx) error {
+	_, err := tx.Model((*CiPipelineSchedule)(nil)).
+		Set("active = ?", false).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("ci_pipeline_id = ?", ciPipelineId).
+		Update()
+	return err
+}
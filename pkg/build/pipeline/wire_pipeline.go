@@ -18,10 +18,17 @@ package pipeline
 
 import (
 	"github.com/devtron-labs/devtron/pkg/build/pipeline/read"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule/repository"
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	read.NewCiPipelineConfigReadServiceImpl,
 	wire.Bind(new(read.CiPipelineConfigReadService), new(*read.CiPipelineConfigReadServiceImpl)),
+
+	repository.NewCiPipelineScheduleRepositoryImpl,
+	wire.Bind(new(repository.CiPipelineScheduleRepository), new(*repository.CiPipelineScheduleRepositoryImpl)),
+	schedule.NewCiPipelineScheduleServiceImpl,
+	wire.Bind(new(schedule.CiPipelineScheduleService), new(*schedule.CiPipelineScheduleServiceImpl)),
 )
@@ -1735,7 +1735,7 @@ func (impl *HandlerServiceImpl) CancelBuild(workflowId int, forceAbort bool) (in
 	}
 
 	workflow.Status = cdWorkflow.WorkflowCancel
-	if workflow.ExecutorType == cdWorkflow.WORKFLOW_EXECUTOR_TYPE_SYSTEM {
+	if !workflow.ExecutorType.IsArgoWorkflowExecutor() {
 		workflow.PodStatus = "Failed"
 		workflow.Message = constants2.TERMINATE_MESSAGE
 	}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type BulkEditJobStatus string
+
+const (
+	BulkEditJobQueued    BulkEditJobStatus = "Queued"
+	BulkEditJobRunning   BulkEditJobStatus = "Running"
+	BulkEditJobSucceeded BulkEditJobStatus = "Succeeded"
+	// BulkEditJobFailed is set when the update of at least one of the apps of the job failed
+	BulkEditJobFailed    BulkEditJobStatus = "Failed"
+	BulkEditJobCancelled BulkEditJobStatus = "Cancelled"
+)
+
+type BulkEditJobItemStatus string
+
+const (
+	BulkEditJobItemPending   BulkEditJobItemStatus = "Pending"
+	BulkEditJobItemRunning   BulkEditJobItemStatus = "Running"
+	BulkEditJobItemSucceeded BulkEditJobItemStatus = "Succeeded"
+	BulkEditJobItemFailed    BulkEditJobItemStatus = "Failed"
+	BulkEditJobItemCancelled BulkEditJobItemStatus = "Cancelled"
+)
+
+func (status BulkEditJobStatus) IsTerminal() bool {
+	return status == BulkEditJobSucceeded || status == BulkEditJobFailed || status == BulkEditJobCancelled
+}
+
+type BulkEditJobRequest struct {
+	Script *BulkUpdateScript `json:"script" validate:"required"`
+	// ScheduledAt defers the job to a maintenance window, the job is started right away when it is not set or is in the past
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+	// BatchSize is the number of apps updated in parallel, defaults to BULK_EDIT_JOB_DEFAULT_BATCH_SIZE
+	BatchSize int `json:"batchSize,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+type BulkEditJobProgress struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Running   int `json:"running"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+}
+
+type BulkEditJobItem struct {
+	AppId      int                   `json:"appId"`
+	AppName    string                `json:"appName"`
+	Status     BulkEditJobItemStatus `json:"status"`
+	Response   *BulkUpdateResponse   `json:"response,omitempty"`
+	StartedOn  *time.Time            `json:"startedOn,omitempty"`
+	FinishedOn *time.Time            `json:"finishedOn,omitempty"`
+}
+
+type BulkEditJob struct {
+	Id          int                  `json:"id"`
+	Status      BulkEditJobStatus    `json:"status"`
+	BatchSize   int                  `json:"batchSize"`
+	ScheduledAt *time.Time           `json:"scheduledAt,omitempty"`
+	StartedOn   *time.Time           `json:"startedOn,omitempty"`
+	FinishedOn  *time.Time           `json:"finishedOn,omitempty"`
+	CreatedBy   int32                `json:"createdBy"`
+	CreatedOn   time.Time            `json:"createdOn"`
+	Progress    *BulkEditJobProgress `json:"progress"`
+	Spec        *BulkUpdatePayload   `json:"spec,omitempty"`
+	// Items is only populated when a single job is fetched
+	Items []*BulkEditJobItem `json:"items,omitempty"`
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/bulkAction/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"go.uber.org/zap"
+)
+
+type BulkEditJob struct {
+	tableName   struct{}               `sql:"bulk_edit_job" pg:",discard_unknown_columns"`
+	Id          int                    `sql:"id,pk"`
+	Spec        string                 `sql:"spec,notnull"`
+	Status      bean.BulkEditJobStatus `sql:"status,notnull"`
+	BatchSize   int                    `sql:"batch_size,notnull"`
+	ScheduledAt time.Time              `sql:"scheduled_at,notnull"`
+	StartedOn   time.Time              `sql:"started_on"`
+	FinishedOn  time.Time              `sql:"finished_on"`
+	// HeartbeatOn is refreshed by the instance running the job, a running job whose heartbeat stops is picked up again
+	HeartbeatOn time.Time `sql:"heartbeat_on"`
+	// ClaimCount is incremented every time an instance picks up the job, the instance running the job owns the latest claim
+	ClaimCount int `sql:"claim_count,notnull"`
+	// IsSuperAdmin and UserEmailId of the creator are kept to rebuild the user metadata when the job is run
+	IsSuperAdmin bool   `sql:"is_super_admin,notnull"`
+	UserEmailId  string `sql:"user_email_id"`
+	sql.AuditLog
+}
+
+type BulkEditJobItem struct {
+	tableName  struct{}                   `sql:"bulk_edit_job_item" pg:",discard_unknown_columns"`
+	Id         int                        `sql:"id,pk"`
+	JobId      int                        `sql:"job_id,notnull"`
+	AppId      int                        `sql:"app_id,notnull"`
+	AppName    string                     `sql:"app_name,notnull"`
+	Status     bean.BulkEditJobItemStatus `sql:"status,notnull"`
+	Response   string                     `sql:"response"`
+	StartedOn  time.Time                  `sql:"started_on"`
+	FinishedOn time.Time                  `sql:"finished_on"`
+	sql.AuditLog
+}
+
+type BulkEditJobRepository interface {
+	sql.TransactionWrapper
+	SaveJob(job *BulkEditJob, tx *pg.Tx) error
+	SaveItems(items []*BulkEditJobItem, tx *pg.Tx) error
+	FindJobById(id int) (*BulkEditJob, error)
+	// FindJobs returns the latest jobs first, jobs of all users are returned when createdBy is 0
+	FindJobs(createdBy int32, limit int) ([]*BulkEditJob, error)
+	// FindDueJobIds returns the queued jobs whose schedule has passed and the running jobs whose heartbeat is older than staleBefore
+	FindDueJobIds(now time.Time, staleBefore time.Time) ([]int, error)
+	FindItemsByJobId(jobId int) ([]*BulkEditJobItem, error)
+	// ClaimJob moves a queued job, or a running job whose heartbeat is older than staleBefore, to running.
+	// The claim count of the job is returned, 0 is returned if the job was picked up by another instance or cancelled.
+	ClaimJob(id int, staleBefore time.Time) (int, error)
+	// UpdateJobHeartbeat refreshes the heartbeat of a running job, false is returned if the claim is not the latest one
+	// or the job is not running anymore
+	UpdateJobHeartbeat(id int, claimCount int) (bool, error)
+	// MarkJobCancelled cancels a job which is not finished yet, false is returned if the job had already finished
+	MarkJobCancelled(id int, userId int32) (bool, error)
+	MarkJobFinished(id int, claimCount int, status bean.BulkEditJobStatus) error
+	UpdateItem(item *BulkEditJobItem) error
+	CancelPendingItems(jobId int, userId int32) error
+}
+
+type BulkEditJobRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewBulkEditJobRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger, transactionUtilImpl *sql.TransactionUtilImpl) *BulkEditJobRepositoryImpl {
+	return &BulkEditJobRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: transactionUtilImpl,
+	}
+}
+
+func (impl *BulkEditJobRepositoryImpl) SaveJob(job *BulkEditJob, tx *pg.Tx) error {
+	return tx.Insert(job)
+}
+
+func (impl *BulkEditJobRepositoryImpl) SaveItems(items []*BulkEditJobItem, tx *pg.Tx) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return tx.Insert(&items)
+}
+
+func (impl *BulkEditJobRepositoryImpl) FindJobById(id int) (*BulkEditJob, error) {
+	job := &BulkEditJob{}
+	err := impl.dbConnection.Model(job).Where("id = ?", id).Select()
+	return job, err
+}
+
+func (impl *BulkEditJobRepositoryImpl) FindJobs(createdBy int32, limit int) ([]*BulkEditJob, error) {
+	var jobs []*BulkEditJob
+	query := impl.dbConnection.Model(&jobs).
+		Column("id", "status", "batch_size", "scheduled_at", "started_on", "finished_on", "created_on", "created_by")
+	if createdBy > 0 {
+		query = query.Where("created_by = ?", createdBy)
+	}
+	err := query.Order("id DESC").Limit(limit).Select()
+	return jobs, err
+}
+
+func (impl *BulkEditJobRepositoryImpl) FindDueJobIds(now time.Time, staleBefore time.Time) ([]int, error) {
+	var jobIds []int
+	err := impl.dbConnection.Model((*BulkEditJob)(nil)).
+		Column("id").
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("status = ? AND scheduled_at <= ?", bean.BulkEditJobQueued, now).
+				WhereOr("status = ? AND heartbeat_on < ?", bean.BulkEditJobRunning, staleBefore)
+			return q, nil
+		}).
+		Order("scheduled_at ASC").
+		Select(&jobIds)
+	return jobIds, err
+}
+
+func (impl *BulkEditJobRepositoryImpl) FindItemsByJobId(jobId int) ([]*BulkEditJobItem, error) {
+	var items []*BulkEditJobItem
+	err := impl.dbConnection.Model(&items).
+		Where("job_id = ?", jobId).
+		Order("id ASC").
+		Select()
+	return items, err
+}
+
+func (impl *BulkEditJobRepositoryImpl) ClaimJob(id int, staleBefore time.Time) (int, error) {
+	now := time.Now()
+	job := &BulkEditJob{}
+	result, err := impl.dbConnection.Model(job).
+		Set("status = ?", bean.BulkEditJobRunning).
+		// a job picked up again keeps the time it was first started on
+		Set("started_on = COALESCE(started_on, ?)", now).
+		Set("heartbeat_on = ?", now).
+		Set("claim_count = claim_count + 1").
+		Set("updated_on = ?", now).
+		Where("id = ?", id).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("status = ?", bean.BulkEditJobQueued).
+				WhereOr("status = ? AND heartbeat_on < ?", bean.BulkEditJobRunning, staleBefore)
+			return q, nil
+		}).
+		Returning("claim_count").
+		Update()
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected() == 0 {
+		return 0, nil
+	}
+	return job.ClaimCount, nil
+}
+
+func (impl *BulkEditJobRepositoryImpl) UpdateJobHeartbeat(id int, claimCount int) (bool, error) {
+	result, err := impl.dbConnection.Model((*BulkEditJob)(nil)).
+		Set("heartbeat_on = ?", time.Now()).
+		Where("id = ?", id).
+		Where("status = ?", bean.BulkEditJobRunning).
+		Where("claim_count = ?", claimCount).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (impl *BulkEditJobRepositoryImpl) MarkJobCancelled(id int, userId int32) (bool, error) {
+	now := time.Now()
+	result, err := impl.dbConnection.Model((*BulkEditJob)(nil)).
+		Set("status = ?", bean.BulkEditJobCancelled).
+		Set("updated_on = ?", now).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Where("status IN (?)", pg.In([]bean.BulkEditJobStatus{bean.BulkEditJobQueued, bean.BulkEditJobRunning})).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (impl *BulkEditJobRepositoryImpl) MarkJobFinished(id int, claimCount int, status bean.BulkEditJobStatus) error {
+	now := time.Now()
+	_, err := impl.dbConnection.Model((*BulkEditJob)(nil)).
+		Set("status = ?", status).
+		Set("finished_on = ?", now).
+		Set("updated_on = ?", now).
+		Where("id = ?", id).
+		// a job cancelled while its last batch was running stays cancelled
+		Where("status = ?", bean.BulkEditJobRunning).
+		Where("claim_count = ?", claimCount).
+		Update()
+	return err
+}
+
+func (impl *BulkEditJobRepositoryImpl) UpdateItem(item *BulkEditJobItem) error {
+	_, err := impl.dbConnection.Model(item).WherePK().UpdateNotNull()
+	return err
+}
+
+func (impl *BulkEditJobRepositoryImpl) CancelPendingItems(jobId int, userId int32) error {
+	_, err := impl.dbConnection.Model((*BulkEditJobItem)(nil)).
+		Set("status = ?", bean.BulkEditJobItemCancelled).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("job_id = ?", jobId).
+		Where("status = ?", bean.BulkEditJobItemPending).
+		Update()
+	return err
+}
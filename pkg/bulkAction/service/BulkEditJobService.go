@@ -0,0 +1,567 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/asyncProvider"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/bulkAction/bean"
+	"github.com/devtron-labs/devtron/pkg/bulkAction/repository"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type BulkEditJobService interface {
+	// CreateJob persists the bulk edit as a job with one item per impacted app, the job is started right away unless it is scheduled for later
+	CreateJob(request *bean.BulkEditJobRequest, userMetadata *userBean.UserMetadata) (*bean.BulkEditJob, error)
+	GetJob(jobId int, includeItems bool) (*bean.BulkEditJob, error)
+	GetJobs(createdBy int32) ([]*bean.BulkEditJob, error)
+	// CancelJob stops a queued job, apps of a running job which are not picked up yet are not updated
+	CancelJob(jobId int, userId int32) error
+	// ExecuteDueJobs runs all the queued jobs whose schedule has passed, running jobs left behind by a stopped instance are resumed
+	ExecuteDueJobs()
+}
+
+type BulkEditJobConfig struct {
+	CronTimeInMins   int `env:"BULK_EDIT_JOB_CRON_TIME" envDefault:"1" description:"Interval in minutes at which bulk edit jobs whose schedule has passed are started"`
+	DefaultBatchSize int `env:"BULK_EDIT_JOB_DEFAULT_BATCH_SIZE" envDefault:"10" description:"Number of apps updated in parallel by a bulk edit job when the batch size is not given in the request"`
+	ListLimit        int `env:"BULK_EDIT_JOB_LIST_LIMIT" envDefault:"50" description:"Maximum number of bulk edit jobs returned in the job listing"`
+	StaleTimeInMins  int `env:"BULK_EDIT_JOB_STALE_TIME" envDefault:"10" description:"Minutes after which a running bulk edit job whose instance stopped sending heartbeats is picked up again"`
+}
+
+// jobHeartbeatInterval is the interval at which the instance running a bulk edit job refreshes its heartbeat, it must stay well below the stale time
+const jobHeartbeatInterval = 30 * time.Second
+
+const (
+	unauthorisedItemMessage = "creator of the job is not authorised to update the app"
+	interruptedItemMessage  = "update of the app was interrupted and may have been partly applied, please verify the app and retry"
+)
+
+type BulkEditJobServiceImpl struct {
+	logger                *zap.SugaredLogger
+	bulkEditJobRepository repository.BulkEditJobRepository
+	bulkUpdateService     BulkUpdateService
+	asyncRunnable         *async.Runnable
+	config                *BulkEditJobConfig
+	cron                  *cron.Cron
+	enforcer              casbin.Enforcer
+	enforcerUtil          rbac.EnforcerUtil
+}
+
+func NewBulkEditJobServiceImpl(logger *zap.SugaredLogger, bulkEditJobRepository repository.BulkEditJobRepository,
+	bulkUpdateService BulkUpdateService, asyncRunnable *async.Runnable, cronLogger *cron2.CronLoggerImpl,
+	enforcer casbin.Enforcer, enforcerUtil rbac.EnforcerUtil) (*BulkEditJobServiceImpl, error) {
+	config := &BulkEditJobConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing bulk edit job config", "err", err)
+		return nil, err
+	}
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &BulkEditJobServiceImpl{
+		logger:                logger,
+		bulkEditJobRepository: bulkEditJobRepository,
+		bulkUpdateService:     bulkUpdateService,
+		asyncRunnable:         asyncRunnable,
+		config:                config,
+		cron:                  cron,
+		enforcer:              enforcer,
+		enforcerUtil:          enforcerUtil,
+	}
+	// scheduled jobs are picked up by the cron, jobs without a schedule are started on creation
+	_, err = cron.AddFunc(fmt.Sprintf("@every %dm", config.CronTimeInMins), impl.ExecuteDueJobs)
+	if err != nil {
+		logger.Errorw("error while configure cron job for scheduled bulk edit jobs", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *BulkEditJobServiceImpl) CreateJob(request *bean.BulkEditJobRequest, userMetadata *userBean.UserMetadata) (*bean.BulkEditJob, error) {
+	payload := request.Script.Spec
+	impactedObjects, err := impl.bulkUpdateService.DryRunBulkEdit(payload)
+	if err != nil {
+		impl.logger.Errorw("error in getting impacted objects of bulk edit job", "err", err)
+		return nil, err
+	}
+	items := getJobItemsForImpactedApps(impactedObjects)
+	if len(items) == 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, "no apps are impacted by the bulk edit", "no impacted apps found for bulk edit job")
+	}
+	spec, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	job := &repository.BulkEditJob{
+		Spec:         string(spec),
+		Status:       bean.BulkEditJobQueued,
+		BatchSize:    request.BatchSize,
+		ScheduledAt:  now,
+		IsSuperAdmin: userMetadata.IsUserSuperAdmin,
+		UserEmailId:  userMetadata.UserEmailId,
+	}
+	if job.BatchSize == 0 {
+		job.BatchSize = impl.config.DefaultBatchSize
+	}
+	if request.ScheduledAt != nil && request.ScheduledAt.After(now) {
+		job.ScheduledAt = *request.ScheduledAt
+	}
+	job.CreateAuditLog(userMetadata.UserId)
+
+	tx, err := impl.bulkEditJobRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return nil, err
+	}
+	defer impl.bulkEditJobRepository.RollbackTx(tx)
+	err = impl.bulkEditJobRepository.SaveJob(job, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving bulk edit job", "err", err)
+		return nil, err
+	}
+	for _, item := range items {
+		item.JobId = job.Id
+		item.CreateAuditLog(userMetadata.UserId)
+	}
+	err = impl.bulkEditJobRepository.SaveItems(items, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving bulk edit job items", "jobId", job.Id, "err", err)
+		return nil, err
+	}
+	err = impl.bulkEditJobRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return nil, err
+	}
+	if !job.ScheduledAt.After(now) {
+		jobId := job.Id
+		impl.asyncRunnable.Execute(func() { impl.executeJob(jobId) })
+	}
+	return impl.GetJob(job.Id, false)
+}
+
+// getJobItemsForImpactedApps returns one item per app, all the deployment templates, config maps and secrets of the app are updated by the item
+func getJobItemsForImpactedApps(impactedObjects *bean.ImpactedObjectsResponse) []*repository.BulkEditJobItem {
+	items := make([]*repository.BulkEditJobItem, 0)
+	appIdToItem := make(map[int]*repository.BulkEditJobItem)
+	addItem := func(appId int, appName string) {
+		if _, ok := appIdToItem[appId]; ok {
+			return
+		}
+		item := &repository.BulkEditJobItem{AppId: appId, AppName: appName, Status: bean.BulkEditJobItemPending}
+		appIdToItem[appId] = item
+		items = append(items, item)
+	}
+	for _, impactedObject := range impactedObjects.DeploymentTemplate {
+		addItem(impactedObject.AppId, impactedObject.AppName)
+	}
+	for _, impactedObject := range impactedObjects.ConfigMap {
+		addItem(impactedObject.AppId, impactedObject.AppName)
+	}
+	for _, impactedObject := range impactedObjects.Secret {
+		addItem(impactedObject.AppId, impactedObject.AppName)
+	}
+	return items
+}
+
+func (impl *BulkEditJobServiceImpl) GetJob(jobId int, includeItems bool) (*bean.BulkEditJob, error) {
+	job, err := impl.bulkEditJobRepository.FindJobById(jobId)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("bulk edit job %d not found", jobId), err.Error())
+	} else if err != nil {
+		impl.logger.Errorw("error in getting bulk edit job", "jobId", jobId, "err", err)
+		return nil, err
+	}
+	items, err := impl.bulkEditJobRepository.FindItemsByJobId(jobId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting bulk edit job items", "jobId", jobId, "err", err)
+		return nil, err
+	}
+	jobDto := getBulkEditJobDto(job)
+	spec := &bean.BulkUpdatePayload{}
+	if err = json.Unmarshal([]byte(job.Spec), spec); err == nil {
+		jobDto.Spec = spec
+	}
+	jobDto.Progress = &bean.BulkEditJobProgress{Total: len(items)}
+	for _, item := range items {
+		switch item.Status {
+		case bean.BulkEditJobItemPending:
+			jobDto.Progress.Pending++
+		case bean.BulkEditJobItemRunning:
+			jobDto.Progress.Running++
+		case bean.BulkEditJobItemSucceeded:
+			jobDto.Progress.Succeeded++
+		case bean.BulkEditJobItemFailed:
+			jobDto.Progress.Failed++
+		case bean.BulkEditJobItemCancelled:
+			jobDto.Progress.Cancelled++
+		}
+		if includeItems {
+			jobDto.Items = append(jobDto.Items, getBulkEditJobItemDto(item))
+		}
+	}
+	return jobDto, nil
+}
+
+func (impl *BulkEditJobServiceImpl) GetJobs(createdBy int32) ([]*bean.BulkEditJob, error) {
+	jobs, err := impl.bulkEditJobRepository.FindJobs(createdBy, impl.config.ListLimit)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting bulk edit jobs", "createdBy", createdBy, "err", err)
+		return nil, err
+	}
+	jobDtos := make([]*bean.BulkEditJob, 0, len(jobs))
+	for _, job := range jobs {
+		jobDtos = append(jobDtos, getBulkEditJobDto(job))
+	}
+	return jobDtos, nil
+}
+
+func (impl *BulkEditJobServiceImpl) CancelJob(jobId int, userId int32) error {
+	cancelled, err := impl.bulkEditJobRepository.MarkJobCancelled(jobId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in cancelling bulk edit job", "jobId", jobId, "err", err)
+		return err
+	}
+	if !cancelled {
+		return util.NewApiError(http.StatusConflict, fmt.Sprintf("bulk edit job %d has already finished", jobId), "bulk edit job is not queued or running")
+	}
+	// items of a running job are cancelled by the executor before its next batch, cancelling them here as well lets the progress reflect it right away
+	err = impl.bulkEditJobRepository.CancelPendingItems(jobId, userId)
+	if err != nil {
+		impl.logger.Errorw("error in cancelling pending items of bulk edit job", "jobId", jobId, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *BulkEditJobServiceImpl) getStaleBefore() time.Time {
+	return time.Now().Add(-time.Duration(impl.config.StaleTimeInMins) * time.Minute)
+}
+
+func (impl *BulkEditJobServiceImpl) ExecuteDueJobs() {
+	jobIds, err := impl.bulkEditJobRepository.FindDueJobIds(time.Now(), impl.getStaleBefore())
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting due bulk edit jobs", "err", err)
+		return
+	}
+	for _, jobId := range jobIds {
+		impl.executeJob(jobId)
+	}
+}
+
+func (impl *BulkEditJobServiceImpl) executeJob(jobId int) {
+	// the claim is conditional on the job being queued or stale, so only one instance runs the job
+	claimCount, err := impl.bulkEditJobRepository.ClaimJob(jobId, impl.getStaleBefore())
+	if err != nil || claimCount == 0 {
+		if err != nil {
+			impl.logger.Errorw("error in claiming bulk edit job", "jobId", jobId, "err", err)
+		}
+		return
+	}
+	stopHeartbeat := impl.startJobHeartbeat(jobId, claimCount)
+	defer stopHeartbeat()
+	job, err := impl.bulkEditJobRepository.FindJobById(jobId)
+	if err != nil {
+		impl.logger.Errorw("error in getting bulk edit job", "jobId", jobId, "err", err)
+		return
+	}
+	payload := &bean.BulkUpdatePayload{}
+	if err = json.Unmarshal([]byte(job.Spec), payload); err != nil {
+		impl.logger.Errorw("error in decoding bulk edit job spec", "jobId", jobId, "err", err)
+		impl.finishJob(job, claimCount, bean.BulkEditJobFailed)
+		return
+	}
+	// access of the creator is checked again as it may have been revoked since the job was created
+	userMetadata := &userBean.UserMetadata{
+		UserId:           job.CreatedBy,
+		UserEmailId:      job.UserEmailId,
+		IsUserSuperAdmin: job.IsSuperAdmin && impl.enforcer.EnforceByEmail(job.UserEmailId, casbin.ResourceGlobal, casbin.ActionCreate, "*"),
+	}
+	var rbacObjects map[int]string
+	if !userMetadata.IsUserSuperAdmin {
+		rbacObjects = impl.enforcerUtil.GetRbacObjectsForAllApps(helper.CustomApp)
+	}
+	items, err := impl.getItemsToExecute(jobId, payload)
+	if err != nil {
+		impl.finishJob(job, claimCount, bean.BulkEditJobFailed)
+		return
+	}
+	for start := 0; start < len(items); start += job.BatchSize {
+		if !impl.isJobClaimed(jobId, claimCount) {
+			impl.logger.Infow("bulk edit job cancelled or picked up by another instance, skipping remaining apps", "jobId", jobId)
+			return
+		}
+		end := min(start+job.BatchSize, len(items))
+		wp := asyncProvider.NewBatchWorker[*repository.BulkEditJobItem](job.BatchSize, impl.logger)
+		for _, item := range items[start:end] {
+			wp.Submit(func() (*repository.BulkEditJobItem, error) {
+				impl.executeJobItem(item, payload, userMetadata, rbacObjects)
+				return item, nil
+			})
+		}
+		if err = wp.StopWait(); err != nil {
+			impl.logger.Errorw("error in executing bulk edit job batch", "jobId", jobId, "err", err)
+		}
+	}
+	finalItems, err := impl.bulkEditJobRepository.FindItemsByJobId(jobId)
+	if err != nil {
+		impl.logger.Errorw("error in getting bulk edit job items", "jobId", jobId, "err", err)
+		impl.finishJob(job, claimCount, bean.BulkEditJobFailed)
+		return
+	}
+	status := bean.BulkEditJobSucceeded
+	for _, item := range finalItems {
+		if item.Status == bean.BulkEditJobItemFailed {
+			status = bean.BulkEditJobFailed
+			break
+		}
+	}
+	impl.finishJob(job, claimCount, status)
+}
+
+// getItemsToExecute returns the pending items of the job. Items left running by an instance which stopped
+// may have been partly applied, these are failed instead of being run again, the user can retry them with a new job
+func (impl *BulkEditJobServiceImpl) getItemsToExecute(jobId int, payload *bean.BulkUpdatePayload) ([]*repository.BulkEditJobItem, error) {
+	items, err := impl.bulkEditJobRepository.FindItemsByJobId(jobId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting bulk edit job items", "jobId", jobId, "err", err)
+		return nil, err
+	}
+	itemsToExecute := make([]*repository.BulkEditJobItem, 0, len(items))
+	for _, item := range items {
+		switch item.Status {
+		case bean.BulkEditJobItemPending:
+			itemsToExecute = append(itemsToExecute, item)
+		case bean.BulkEditJobItemRunning:
+			impl.logger.Warnw("failing bulk edit job item interrupted by a stopped instance", "jobId", jobId, "appName", item.AppName)
+			impl.failJobItem(item, getFailedBulkUpdateResponse(payload, item, interruptedItemMessage))
+		}
+	}
+	return itemsToExecute, nil
+}
+
+// isJobClaimed refreshes the heartbeat of the job, false is returned if the job was cancelled or picked up by another instance
+func (impl *BulkEditJobServiceImpl) isJobClaimed(jobId int, claimCount int) bool {
+	claimed, err := impl.bulkEditJobRepository.UpdateJobHeartbeat(jobId, claimCount)
+	if err != nil {
+		// not stopping the job on a transient error, the heartbeat is refreshed again before the next batch
+		impl.logger.Errorw("error in updating heartbeat of bulk edit job", "jobId", jobId, "err", err)
+		return true
+	}
+	return claimed
+}
+
+// startJobHeartbeat keeps the heartbeat of the job fresh while a batch is running, the returned func stops it
+func (impl *BulkEditJobServiceImpl) startJobHeartbeat(jobId int, claimCount int) func() {
+	done := make(chan struct{})
+	ticker := time.NewTicker(jobHeartbeatInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				impl.isJobClaimed(jobId, claimCount)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (impl *BulkEditJobServiceImpl) finishJob(job *repository.BulkEditJob, claimCount int, status bean.BulkEditJobStatus) {
+	err := impl.bulkEditJobRepository.MarkJobFinished(job.Id, claimCount, status)
+	if err != nil {
+		impl.logger.Errorw("error in marking bulk edit job finished", "jobId", job.Id, "status", status, "err", err)
+	}
+}
+
+// executeJobItem runs the bulk edit for a single app by narrowing the includes of the payload down to the name of the app
+func (impl *BulkEditJobServiceImpl) executeJobItem(item *repository.BulkEditJobItem, payload *bean.BulkUpdatePayload, userMetadata *userBean.UserMetadata, rbacObjects map[int]string) {
+	item.Status = bean.BulkEditJobItemRunning
+	item.StartedOn = time.Now()
+	item.UpdatedOn = item.StartedOn
+	if err := impl.bulkEditJobRepository.UpdateItem(item); err != nil {
+		impl.logger.Errorw("error in updating bulk edit job item", "itemId", item.Id, "err", err)
+	}
+	appPayload := *payload
+	appPayload.Includes = &bean.NameIncludesExcludes{Names: []string{escapeLikePattern(item.AppName)}}
+	appPayload.Excludes = nil
+	var response *bean.BulkUpdateResponse
+	isAuthorised := impl.isAuthorisedForBulkEdit(&appPayload, userMetadata, rbacObjects)
+	if isAuthorised {
+		response = impl.bulkUpdateService.BulkEdit(context.Background(), &appPayload, userMetadata)
+	} else {
+		impl.logger.Warnw("creator of bulk edit job is not authorised to update the app anymore", "jobId", item.JobId, "appName", item.AppName, "emailId", userMetadata.UserEmailId)
+		response = getFailedBulkUpdateResponse(&appPayload, item, unauthorisedItemMessage)
+	}
+
+	status := bean.BulkEditJobItemSucceeded
+	if !isAuthorised || hasBulkUpdateFailure(response) {
+		status = bean.BulkEditJobItemFailed
+	}
+	impl.finishJobItem(item, status, response)
+}
+
+func (impl *BulkEditJobServiceImpl) failJobItem(item *repository.BulkEditJobItem, response *bean.BulkUpdateResponse) {
+	impl.finishJobItem(item, bean.BulkEditJobItemFailed, response)
+}
+
+func (impl *BulkEditJobServiceImpl) finishJobItem(item *repository.BulkEditJobItem, status bean.BulkEditJobItemStatus, response *bean.BulkUpdateResponse) {
+	item.Status = status
+	if responseJson, err := json.Marshal(response); err == nil {
+		item.Response = string(responseJson)
+	}
+	item.FinishedOn = time.Now()
+	item.UpdatedOn = item.FinishedOn
+	if err := impl.bulkEditJobRepository.UpdateItem(item); err != nil {
+		impl.logger.Errorw("error in updating bulk edit job item", "itemId", item.Id, "err", err)
+	}
+}
+
+// isAuthorisedForBulkEdit checks that the user can still update all the apps and environments impacted by the payload
+func (impl *BulkEditJobServiceImpl) isAuthorisedForBulkEdit(payload *bean.BulkUpdatePayload, userMetadata *userBean.UserMetadata, rbacObjects map[int]string) bool {
+	if userMetadata.IsUserSuperAdmin {
+		return true
+	}
+	impactedObjects, err := impl.bulkUpdateService.DryRunBulkEdit(payload)
+	if err != nil {
+		impl.logger.Errorw("error in getting impacted objects of bulk edit", "emailId", userMetadata.UserEmailId, "err", err)
+		return false
+	}
+	isAuthorised := func(appId int, appName string, envId int) bool {
+		if !impl.enforcer.EnforceByEmail(userMetadata.UserEmailId, casbin.ResourceApplications, casbin.ActionUpdate, rbacObjects[appId]) {
+			return false
+		}
+		if envId > 0 {
+			envObject := impl.enforcerUtil.GetAppRBACByAppNameAndEnvId(appName, envId)
+			return impl.enforcer.EnforceByEmail(userMetadata.UserEmailId, casbin.ResourceEnvironment, casbin.ActionUpdate, envObject)
+		}
+		return true
+	}
+	for _, impactedApp := range impactedObjects.DeploymentTemplate {
+		if !isAuthorised(impactedApp.AppId, impactedApp.AppName, impactedApp.EnvId) {
+			return false
+		}
+	}
+	for _, impactedApp := range impactedObjects.ConfigMap {
+		if !isAuthorised(impactedApp.AppId, impactedApp.AppName, impactedApp.EnvId) {
+			return false
+		}
+	}
+	for _, impactedApp := range impactedObjects.Secret {
+		if !isAuthorised(impactedApp.AppId, impactedApp.AppName, impactedApp.EnvId) {
+			return false
+		}
+	}
+	return true
+}
+
+// getFailedBulkUpdateResponse fails each part of the payload with the message
+func getFailedBulkUpdateResponse(payload *bean.BulkUpdatePayload, item *repository.BulkEditJobItem, failureMessage string) *bean.BulkUpdateResponse {
+	message := []string{failureMessage}
+	response := &bean.BulkUpdateResponse{}
+	if payload.DeploymentTemplate != nil {
+		response.DeploymentTemplate = &bean.DeploymentTemplateBulkUpdateResponse{Message: message,
+			Failure: []*bean.DeploymentTemplateBulkUpdateResponseForOneApp{{AppId: item.AppId, AppName: item.AppName, Message: message[0]}}}
+	}
+	if payload.ConfigMap != nil {
+		response.ConfigMap = &bean.CmAndSecretBulkUpdateResponse{Message: message,
+			Failure: []*bean.CmAndSecretBulkUpdateResponseForOneApp{{AppId: item.AppId, AppName: item.AppName, Message: message[0]}}}
+	}
+	if payload.Secret != nil {
+		response.Secret = &bean.CmAndSecretBulkUpdateResponse{Message: message,
+			Failure: []*bean.CmAndSecretBulkUpdateResponseForOneApp{{AppId: item.AppId, AppName: item.AppName, Message: message[0]}}}
+	}
+	return response
+}
+
+func hasBulkUpdateFailure(response *bean.BulkUpdateResponse) bool {
+	if response == nil {
+		return true
+	}
+	if response.DeploymentTemplate != nil && len(response.DeploymentTemplate.Failure) > 0 {
+		return true
+	}
+	if response.ConfigMap != nil && len(response.ConfigMap.Failure) > 0 {
+		return true
+	}
+	if response.Secret != nil && len(response.Secret.Failure) > 0 {
+		return true
+	}
+	return false
+}
+
+// escapeLikePattern escapes the LIKE wildcards so that the app name matches only itself in the bulk edit queries
+func escapeLikePattern(name string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(name)
+}
+
+func getBulkEditJobDto(job *repository.BulkEditJob) *bean.BulkEditJob {
+	jobDto := &bean.BulkEditJob{
+		Id:          job.Id,
+		Status:      job.Status,
+		BatchSize:   job.BatchSize,
+		ScheduledAt: getTimePtr(job.ScheduledAt),
+		StartedOn:   getTimePtr(job.StartedOn),
+		FinishedOn:  getTimePtr(job.FinishedOn),
+		CreatedBy:   job.CreatedBy,
+		CreatedOn:   job.CreatedOn,
+	}
+	return jobDto
+}
+
+func getBulkEditJobItemDto(item *repository.BulkEditJobItem) *bean.BulkEditJobItem {
+	itemDto := &bean.BulkEditJobItem{
+		AppId:      item.AppId,
+		AppName:    item.AppName,
+		Status:     item.Status,
+		StartedOn:  getTimePtr(item.StartedOn),
+		FinishedOn: getTimePtr(item.FinishedOn),
+	}
+	if len(item.Response) > 0 {
+		response := &bean.BulkUpdateResponse{}
+		if err := json.Unmarshal([]byte(item.Response), response); err == nil {
+			itemDto.Response = response
+		}
+	}
+	return itemDto
+}
+
+func getTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,376 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/bulkAction/bean"
+	"github.com/devtron-labs/devtron/pkg/bulkAction/repository"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetJobItemsForImpactedApps(t *testing.T) {
+	impactedObjects := &bean.ImpactedObjectsResponse{
+		DeploymentTemplate: []*bean.DeploymentTemplateImpactedObjectsResponseForOneApp{
+			{AppId: 1, AppName: "payments"},
+			{AppId: 1, AppName: "payments", EnvId: 2},
+		},
+		ConfigMap: []*bean.CmAndSecretImpactedObjectsResponseForOneApp{
+			{AppId: 2, AppName: "orders", EnvId: 2},
+		},
+		Secret: []*bean.CmAndSecretImpactedObjectsResponseForOneApp{
+			{AppId: 1, AppName: "payments", EnvId: 3},
+			{AppId: 3, AppName: "billing"},
+		},
+	}
+	items := getJobItemsForImpactedApps(impactedObjects)
+	assert.Len(t, items, 3)
+	for i, appName := range []string{"payments", "orders", "billing"} {
+		assert.Equal(t, appName, items[i].AppName)
+		assert.Equal(t, bean.BulkEditJobItemPending, items[i].Status)
+	}
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, "payments-api", escapeLikePattern("payments-api"))
+	assert.Equal(t, `a\%b\_c\\d`, escapeLikePattern(`a%b_c\d`))
+}
+
+func TestHasBulkUpdateFailure(t *testing.T) {
+	assert.False(t, hasBulkUpdateFailure(&bean.BulkUpdateResponse{
+		DeploymentTemplate: &bean.DeploymentTemplateBulkUpdateResponse{
+			Successful: []*bean.DeploymentTemplateBulkUpdateResponseForOneApp{{AppId: 1}},
+		},
+	}))
+	assert.True(t, hasBulkUpdateFailure(&bean.BulkUpdateResponse{
+		Secret: &bean.CmAndSecretBulkUpdateResponse{
+			Failure: []*bean.CmAndSecretBulkUpdateResponseForOneApp{{AppId: 1}},
+		},
+	}))
+}
+
+// bulkEditJobRepositoryStub keeps the jobs in memory and applies the same claim conditions as the queries of the repository
+type bulkEditJobRepositoryStub struct {
+	repository.BulkEditJobRepository
+	lock  sync.Mutex
+	jobs  map[int]*repository.BulkEditJob
+	items map[int][]*repository.BulkEditJobItem
+}
+
+func (impl *bulkEditJobRepositoryStub) FindJobById(id int) (*repository.BulkEditJob, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	job, ok := impl.jobs[id]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	jobCopy := *job
+	return &jobCopy, nil
+}
+
+func (impl *bulkEditJobRepositoryStub) isClaimable(job *repository.BulkEditJob, staleBefore time.Time) bool {
+	return job.Status == bean.BulkEditJobQueued || (job.Status == bean.BulkEditJobRunning && job.HeartbeatOn.Before(staleBefore))
+}
+
+func (impl *bulkEditJobRepositoryStub) FindDueJobIds(now time.Time, staleBefore time.Time) ([]int, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	jobIds := make([]int, 0)
+	for id, job := range impl.jobs {
+		if (job.Status == bean.BulkEditJobQueued && !job.ScheduledAt.After(now)) || (job.Status == bean.BulkEditJobRunning && job.HeartbeatOn.Before(staleBefore)) {
+			jobIds = append(jobIds, id)
+		}
+	}
+	return jobIds, nil
+}
+
+func (impl *bulkEditJobRepositoryStub) ClaimJob(id int, staleBefore time.Time) (int, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	job := impl.jobs[id]
+	if !impl.isClaimable(job, staleBefore) {
+		return 0, nil
+	}
+	job.Status = bean.BulkEditJobRunning
+	job.HeartbeatOn = time.Now()
+	job.ClaimCount++
+	return job.ClaimCount, nil
+}
+
+func (impl *bulkEditJobRepositoryStub) UpdateJobHeartbeat(id int, claimCount int) (bool, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	job := impl.jobs[id]
+	if job.Status != bean.BulkEditJobRunning || job.ClaimCount != claimCount {
+		return false, nil
+	}
+	job.HeartbeatOn = time.Now()
+	return true, nil
+}
+
+func (impl *bulkEditJobRepositoryStub) MarkJobFinished(id int, claimCount int, status bean.BulkEditJobStatus) error {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	job := impl.jobs[id]
+	if job.Status == bean.BulkEditJobRunning && job.ClaimCount == claimCount {
+		job.Status = status
+	}
+	return nil
+}
+
+func (impl *bulkEditJobRepositoryStub) FindItemsByJobId(jobId int) ([]*repository.BulkEditJobItem, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	items := make([]*repository.BulkEditJobItem, 0, len(impl.items[jobId]))
+	for _, item := range impl.items[jobId] {
+		itemCopy := *item
+		items = append(items, &itemCopy)
+	}
+	return items, nil
+}
+
+func (impl *bulkEditJobRepositoryStub) UpdateItem(item *repository.BulkEditJobItem) error {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	for _, savedItem := range impl.items[item.JobId] {
+		if savedItem.Id == item.Id {
+			*savedItem = *item
+		}
+	}
+	return nil
+}
+
+func (impl *bulkEditJobRepositoryStub) getItemStatuses(jobId int) map[string]bean.BulkEditJobItemStatus {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	statuses := make(map[string]bean.BulkEditJobItemStatus)
+	for _, item := range impl.items[jobId] {
+		statuses[item.AppName] = item.Status
+	}
+	return statuses
+}
+
+// bulkUpdateServiceStub fails the bulk edit of the apps in failingApps and records the apps it was called for
+type bulkUpdateServiceStub struct {
+	BulkUpdateService
+	lock        sync.Mutex
+	failingApps map[string]bool
+	updatedApps []string
+}
+
+func (impl *bulkUpdateServiceStub) BulkEdit(ctx context.Context, bulkUpdateRequest *bean.BulkUpdatePayload, userMetadata *userBean.UserMetadata) *bean.BulkUpdateResponse {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	appName := bulkUpdateRequest.Includes.Names[0]
+	impl.updatedApps = append(impl.updatedApps, appName)
+	response := &bean.DeploymentTemplateBulkUpdateResponse{}
+	if impl.failingApps[appName] {
+		response.Failure = []*bean.DeploymentTemplateBulkUpdateResponseForOneApp{{AppName: appName}}
+	} else {
+		response.Successful = []*bean.DeploymentTemplateBulkUpdateResponseForOneApp{{AppName: appName}}
+	}
+	return &bean.BulkUpdateResponse{DeploymentTemplate: response}
+}
+
+func (impl *bulkUpdateServiceStub) DryRunBulkEdit(bulkUpdateRequest *bean.BulkUpdatePayload) (*bean.ImpactedObjectsResponse, error) {
+	appName := bulkUpdateRequest.Includes.Names[0]
+	return &bean.ImpactedObjectsResponse{
+		DeploymentTemplate: []*bean.DeploymentTemplateImpactedObjectsResponseForOneApp{{AppId: len(appName), AppName: appName, EnvId: 1}},
+	}, nil
+}
+
+// enforcerStub denies update on the apps in deniedApps, the rbac object of an app is its name
+type enforcerStub struct {
+	casbin.Enforcer
+	deniedApps map[string]bool
+}
+
+func (impl *enforcerStub) EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool {
+	if resource == casbin.ResourceGlobal {
+		return false
+	}
+	return !impl.deniedApps[resourceItem]
+}
+
+type enforcerUtilStub struct {
+	rbac.EnforcerUtil
+	appNames []string
+}
+
+func (impl *enforcerUtilStub) GetRbacObjectsForAllApps(appType helper.AppType) map[int]string {
+	rbacObjects := make(map[int]string)
+	for _, appName := range impl.appNames {
+		rbacObjects[len(appName)] = appName
+	}
+	return rbacObjects
+}
+
+func (impl *enforcerUtilStub) GetAppRBACByAppNameAndEnvId(appName string, envId int) string {
+	return "env/" + appName
+}
+
+func newBulkEditJobTestService(t *testing.T, jobRepository *bulkEditJobRepositoryStub, bulkUpdateService *bulkUpdateServiceStub) *BulkEditJobServiceImpl {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	return &BulkEditJobServiceImpl{
+		logger:                logger,
+		bulkEditJobRepository: jobRepository,
+		bulkUpdateService:     bulkUpdateService,
+		config:                &BulkEditJobConfig{StaleTimeInMins: 10},
+		enforcer:              &enforcerStub{},
+		// app ids are the length of the app names in the stubs, these are unique for the apps of the tests
+		enforcerUtil: &enforcerUtilStub{appNames: []string{"payments", "orders", "billing"}},
+	}
+}
+
+func newBulkEditJobForTest(t *testing.T, id int, status bean.BulkEditJobStatus, itemStatuses map[string]bean.BulkEditJobItemStatus) (*repository.BulkEditJob, []*repository.BulkEditJobItem) {
+	spec, err := json.Marshal(&bean.BulkUpdatePayload{Global: true})
+	assert.NoError(t, err)
+	job := &repository.BulkEditJob{Id: id, Spec: string(spec), Status: status, BatchSize: 2, ScheduledAt: time.Now().Add(-time.Minute)}
+	items := make([]*repository.BulkEditJobItem, 0, len(itemStatuses))
+	for _, appName := range []string{"payments", "orders", "billing"} {
+		if itemStatus, ok := itemStatuses[appName]; ok {
+			items = append(items, &repository.BulkEditJobItem{Id: len(items) + 1, JobId: id, AppName: appName, Status: itemStatus})
+		}
+	}
+	return job, items
+}
+
+func TestBulkEditJobServiceImpl_ExecuteDueJobs(t *testing.T) {
+	allPending := map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemPending, "orders": bean.BulkEditJobItemPending, "billing": bean.BulkEditJobItemPending}
+	tests := []struct {
+		name             string
+		jobStatus        bean.BulkEditJobStatus
+		heartbeatAgo     time.Duration
+		itemStatuses     map[string]bean.BulkEditJobItemStatus
+		failingApps      map[string]bool
+		deniedApps       map[string]bool
+		wantJobStatus    bean.BulkEditJobStatus
+		wantItemStatuses map[string]bean.BulkEditJobItemStatus
+		wantUpdatedApps  []string
+	}{
+		{
+			name:          "all apps updated",
+			jobStatus:     bean.BulkEditJobQueued,
+			itemStatuses:  allPending,
+			wantJobStatus: bean.BulkEditJobSucceeded,
+			wantItemStatuses: map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemSucceeded,
+				"orders": bean.BulkEditJobItemSucceeded, "billing": bean.BulkEditJobItemSucceeded},
+			wantUpdatedApps: []string{"payments", "orders", "billing"},
+		},
+		{
+			name:          "failure of one app fails the job without stopping the others",
+			jobStatus:     bean.BulkEditJobQueued,
+			itemStatuses:  allPending,
+			failingApps:   map[string]bool{"orders": true},
+			wantJobStatus: bean.BulkEditJobFailed,
+			wantItemStatuses: map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemSucceeded,
+				"orders": bean.BulkEditJobItemFailed, "billing": bean.BulkEditJobItemSucceeded},
+			wantUpdatedApps: []string{"payments", "orders", "billing"},
+		},
+		{
+			name:          "app to which the creator lost access is not updated",
+			jobStatus:     bean.BulkEditJobQueued,
+			itemStatuses:  allPending,
+			deniedApps:    map[string]bool{"env/billing": true},
+			wantJobStatus: bean.BulkEditJobFailed,
+			wantItemStatuses: map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemSucceeded,
+				"orders": bean.BulkEditJobItemSucceeded, "billing": bean.BulkEditJobItemFailed},
+			wantUpdatedApps: []string{"payments", "orders"},
+		},
+		{
+			name:          "stale running job is resumed from its pending apps and the interrupted app is failed",
+			jobStatus:     bean.BulkEditJobRunning,
+			heartbeatAgo:  time.Hour,
+			itemStatuses:  map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemSucceeded, "orders": bean.BulkEditJobItemRunning, "billing": bean.BulkEditJobItemPending},
+			wantJobStatus: bean.BulkEditJobFailed,
+			wantItemStatuses: map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemSucceeded,
+				"orders": bean.BulkEditJobItemFailed, "billing": bean.BulkEditJobItemSucceeded},
+			wantUpdatedApps: []string{"billing"},
+		},
+		{
+			name:             "running job with a live heartbeat is left to its instance",
+			jobStatus:        bean.BulkEditJobRunning,
+			heartbeatAgo:     time.Minute,
+			itemStatuses:     map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemRunning, "orders": bean.BulkEditJobItemPending},
+			wantJobStatus:    bean.BulkEditJobRunning,
+			wantItemStatuses: map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemRunning, "orders": bean.BulkEditJobItemPending},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, items := newBulkEditJobForTest(t, 1, tt.jobStatus, tt.itemStatuses)
+			if tt.jobStatus == bean.BulkEditJobRunning {
+				job.ClaimCount = 1
+				job.HeartbeatOn = time.Now().Add(-tt.heartbeatAgo)
+			}
+			jobRepository := &bulkEditJobRepositoryStub{jobs: map[int]*repository.BulkEditJob{1: job}, items: map[int][]*repository.BulkEditJobItem{1: items}}
+			bulkUpdateService := &bulkUpdateServiceStub{failingApps: tt.failingApps}
+			impl := newBulkEditJobTestService(t, jobRepository, bulkUpdateService)
+			impl.enforcer = &enforcerStub{deniedApps: tt.deniedApps}
+
+			impl.ExecuteDueJobs()
+
+			savedJob, err := jobRepository.FindJobById(1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantJobStatus, savedJob.Status)
+			assert.Equal(t, tt.wantItemStatuses, jobRepository.getItemStatuses(1))
+			assert.ElementsMatch(t, tt.wantUpdatedApps, bulkUpdateService.updatedApps)
+		})
+	}
+}
+
+func TestBulkEditJobServiceImpl_executeJob_claim(t *testing.T) {
+	job, items := newBulkEditJobForTest(t, 1, bean.BulkEditJobQueued, map[string]bean.BulkEditJobItemStatus{"payments": bean.BulkEditJobItemPending})
+	jobRepository := &bulkEditJobRepositoryStub{jobs: map[int]*repository.BulkEditJob{1: job}, items: map[int][]*repository.BulkEditJobItem{1: items}}
+	bulkUpdateService := &bulkUpdateServiceStub{}
+	impl := newBulkEditJobTestService(t, jobRepository, bulkUpdateService)
+
+	// the job is picked up by two instances at once, only one of them runs it
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			impl.executeJob(1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"payments"}, bulkUpdateService.updatedApps)
+	savedJob, err := jobRepository.FindJobById(1)
+	assert.NoError(t, err)
+	assert.Equal(t, bean.BulkEditJobSucceeded, savedJob.Status)
+	assert.Equal(t, 1, savedJob.ClaimCount)
+
+	// an instance whose claim was taken over does not finish the job
+	jobRepository.jobs[1].Status = bean.BulkEditJobRunning
+	jobRepository.jobs[1].ClaimCount = 3
+	assert.False(t, impl.isJobClaimed(1, 2))
+	impl.finishJob(savedJob, 2, bean.BulkEditJobFailed)
+	assert.Equal(t, bean.BulkEditJobRunning, jobRepository.jobs[1].Status)
+}
@@ -164,6 +164,7 @@ func (impl *ClusterServiceImpl) ConvertClusterBeanToCluster(clusterBean *bean.Cl
 	model.PrometheusEndpoint = clusterBean.PrometheusUrl
 	model.InsecureSkipTlsVerify = clusterBean.InsecureSkipTLSVerify
 	model.IsProd = clusterBean.IsProd
+	model.Labels = clusterBean.Labels
 
 	if clusterBean.PrometheusAuth != nil {
 		model.PUserName = clusterBean.PrometheusAuth.UserName
@@ -198,9 +199,13 @@ func (impl *ClusterServiceImpl) getAndUpdateClusterConnectionStatus() {
 }
 
 func (impl *ClusterServiceImpl) Save(parent context.Context, bean *bean.ClusterBean, userId int32) (*bean.ClusterBean, error) {
+	err := validateClusterLabels(bean.Labels)
+	if err != nil {
+		return nil, err
+	}
 	//validating config
 
-	err := impl.CheckIfConfigIsValid(bean)
+	err = impl.CheckIfConfigIsValid(bean)
 
 	if err != nil {
 		if len(err.Error()) > 2000 {
@@ -386,6 +391,10 @@ func (impl *ClusterServiceImpl) FindByIdsWithoutConfig(ids []int) ([]*bean.Clust
 }
 
 func (impl *ClusterServiceImpl) Update(ctx context.Context, bean *bean.ClusterBean, userId int32) (*bean.ClusterBean, error) {
+	err := validateClusterLabels(bean.Labels)
+	if err != nil {
+		return nil, err
+	}
 	model, err := impl.clusterRepository.FindById(bean.Id)
 	if err != nil {
 		impl.logger.Errorw("error in fetching cluster", "clusterId", bean.Id, "err", err)
@@ -449,6 +458,10 @@ func (impl *ClusterServiceImpl) Update(ctx context.Context, bean *bean.ClusterBe
 	model.ServerUrl = bean.ServerUrl
 	model.InsecureSkipTlsVerify = bean.InsecureSkipTLSVerify
 	model.IsProd = bean.IsProd
+	// labels are kept as they are when not sent, an empty map clears them
+	if bean.Labels != nil {
+		model.Labels = bean.Labels
+	}
 	model.PrometheusEndpoint = bean.PrometheusUrl
 
 	if bean.PrometheusAuth != nil {
@@ -582,6 +595,7 @@ func (impl *ClusterServiceImpl) FindAllForAutoComplete() ([]bean.ClusterBean, er
 			IsCdArgoSetup:     m.CdArgoSetup,
 			IsVirtualCluster:  m.IsVirtualCluster,
 			IsProd:            m.IsProd,
+			Labels:            m.Labels,
 		})
 	}
 	return beans, nil
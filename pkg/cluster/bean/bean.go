@@ -38,6 +38,7 @@ type ClusterBean struct {
 	IsVirtualCluster        bool                       `json:"isVirtualCluster"`
 	ClusterUpdated          bool                       `json:"clusterUpdated"`
 	IsProd                  bool                       `json:"isProd"`
+	Labels                  map[string]string          `json:"labels,omitempty"`
 	ClusterStatus           ClusterStatus              `json:"clusterStatus,omitempty"`
 }
 
@@ -1,6 +1,13 @@
 package cluster
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/devtron-labs/devtron/internal/util"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
 
 const (
 	CmName = "cluster-event"
@@ -9,3 +16,16 @@ const (
 func ParseCmNameForK8sInformerOnClusterEvent(clusterId int) string {
 	return fmt.Sprintf("%s-%d", CmName, clusterId)
 }
+
+// validateClusterLabels checks that the cluster labels follow the kubernetes label syntax
+func validateClusterLabels(labels map[string]string) error {
+	for key, value := range labels {
+		errs := validation.IsQualifiedName(key)
+		errs = append(errs, validation.IsValidLabelValue(value)...)
+		if len(errs) > 0 {
+			errMsg := fmt.Sprintf("invalid cluster label '%s: %s', %s", key, value, strings.Join(errs, ", "))
+			return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+		}
+	}
+	return nil
+}
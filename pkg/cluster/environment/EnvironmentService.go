@@ -27,6 +27,9 @@ import (
 	bean2 "github.com/devtron-labs/devtron/pkg/cluster/environment/bean"
 	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	"github.com/devtron-labs/devtron/pkg/cluster/read"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -80,10 +83,11 @@ type EnvironmentServiceImpl struct {
 	K8sUtil               *util2.K8sServiceImpl
 	k8sInformerFactory    informer.K8sInformerFactory
 	//propertiesConfigService pipeline.PropertiesConfigService
-	userAuthService      user.UserAuthService
-	attributesRepository repository2.AttributesRepository
-	clusterReadService   read.ClusterReadService
-	grafanaClient        grafana.GrafanaClient
+	userAuthService         user.UserAuthService
+	attributesRepository    repository2.AttributesRepository
+	clusterReadService      read.ClusterReadService
+	grafanaClient           grafana.GrafanaClient
+	gitOpsConfigReadService config.GitOpsConfigReadService
 }
 
 func NewEnvironmentServiceImpl(environmentRepository repository.EnvironmentRepository,
@@ -92,7 +96,8 @@ func NewEnvironmentServiceImpl(environmentRepository repository.EnvironmentRepos
 	//  propertiesConfigService pipeline.PropertiesConfigService,
 	userAuthService user.UserAuthService, attributesRepository repository2.AttributesRepository,
 	clusterReadService read.ClusterReadService,
-	grafanaClient grafana.GrafanaClient) *EnvironmentServiceImpl {
+	grafanaClient grafana.GrafanaClient,
+	gitOpsConfigReadService config.GitOpsConfigReadService) *EnvironmentServiceImpl {
 	return &EnvironmentServiceImpl{
 		environmentRepository: environmentRepository,
 		logger:                logger,
@@ -100,10 +105,11 @@ func NewEnvironmentServiceImpl(environmentRepository repository.EnvironmentRepos
 		K8sUtil:               K8sUtil,
 		k8sInformerFactory:    k8sInformerFactory,
 		//propertiesConfigService: propertiesConfigService,
-		userAuthService:      userAuthService,
-		attributesRepository: attributesRepository,
-		clusterReadService:   clusterReadService,
-		grafanaClient:        grafanaClient,
+		userAuthService:         userAuthService,
+		attributesRepository:    attributesRepository,
+		clusterReadService:      clusterReadService,
+		grafanaClient:           grafanaClient,
+		gitOpsConfigReadService: gitOpsConfigReadService,
 	}
 }
 
@@ -135,6 +141,10 @@ func (impl EnvironmentServiceImpl) Create(mappings *bean2.EnvironmentBean, userI
 	if err != nil {
 		return nil, err
 	}
+	err = impl.validateGitOpsPullRequestSupport(mappings)
+	if err != nil {
+		return nil, err
+	}
 
 	clusterBean, err := impl.clusterReadService.FindById(mappings.ClusterId)
 	if err != nil {
@@ -154,13 +164,14 @@ func (impl EnvironmentServiceImpl) Create(mappings *bean2.EnvironmentBean, userI
 	}
 
 	model = &repository.Environment{
-		Name:                  mappings.Environment,
-		ClusterId:             mappings.ClusterId,
-		Active:                mappings.Active,
-		Namespace:             mappings.Namespace,
-		Default:               mappings.Default,
-		Description:           mappings.Description,
-		EnvironmentIdentifier: identifier,
+		Name:                     mappings.Environment,
+		ClusterId:                mappings.ClusterId,
+		Active:                   mappings.Active,
+		Namespace:                mappings.Namespace,
+		Default:                  mappings.Default,
+		Description:              mappings.Description,
+		EnvironmentIdentifier:    identifier,
+		GitOpsPullRequestEnabled: mappings.GitOpsPullRequestEnabled,
 	}
 	model.CreatedBy = userId
 	model.UpdatedBy = userId
@@ -198,16 +209,17 @@ func (impl EnvironmentServiceImpl) FindOne(environment string) (*bean2.Environme
 		return nil, err
 	}
 	bean := &bean2.EnvironmentBean{
-		Id:                    model.Id,
-		Environment:           model.Name,
-		ClusterId:             model.Cluster.Id,
-		Active:                model.Active,
-		PrometheusEndpoint:    model.Cluster.PrometheusEndpoint,
-		Namespace:             model.Namespace,
-		Default:               model.Default,
-		EnvironmentIdentifier: model.EnvironmentIdentifier,
-		Description:           model.Description,
-		DataSourceId:          model.GrafanaDatasourceId,
+		Id:                       model.Id,
+		Environment:              model.Name,
+		ClusterId:                model.Cluster.Id,
+		Active:                   model.Active,
+		PrometheusEndpoint:       model.Cluster.PrometheusEndpoint,
+		Namespace:                model.Namespace,
+		Default:                  model.Default,
+		EnvironmentIdentifier:    model.EnvironmentIdentifier,
+		Description:              model.Description,
+		DataSourceId:             model.GrafanaDatasourceId,
+		GitOpsPullRequestEnabled: model.GitOpsPullRequestEnabled,
 	}
 	return bean, nil
 }
@@ -220,17 +232,18 @@ func (impl EnvironmentServiceImpl) GetAllActive() ([]bean2.EnvironmentBean, erro
 	var beans []bean2.EnvironmentBean
 	for _, model := range models {
 		beans = append(beans, bean2.EnvironmentBean{
-			Id:                    model.Id,
-			Environment:           model.Name,
-			ClusterId:             model.Cluster.Id,
-			ClusterName:           model.Cluster.ClusterName,
-			Active:                model.Active,
-			PrometheusEndpoint:    model.Cluster.PrometheusEndpoint,
-			Namespace:             model.Namespace,
-			Default:               model.Default,
-			EnvironmentIdentifier: model.EnvironmentIdentifier,
-			Description:           model.Description,
-			IsVirtualEnvironment:  model.IsVirtualEnvironment,
+			Id:                       model.Id,
+			Environment:              model.Name,
+			ClusterId:                model.Cluster.Id,
+			ClusterName:              model.Cluster.ClusterName,
+			Active:                   model.Active,
+			PrometheusEndpoint:       model.Cluster.PrometheusEndpoint,
+			Namespace:                model.Namespace,
+			Default:                  model.Default,
+			EnvironmentIdentifier:    model.EnvironmentIdentifier,
+			Description:              model.Description,
+			IsVirtualEnvironment:     model.IsVirtualEnvironment,
+			GitOpsPullRequestEnabled: model.GitOpsPullRequestEnabled,
 		})
 	}
 	return beans, nil
@@ -246,16 +259,17 @@ func (impl EnvironmentServiceImpl) FindById(id int) (*bean2.EnvironmentBean, err
 		return nil, err
 	}
 	bean := &bean2.EnvironmentBean{
-		Id:                    model.Id,
-		Environment:           model.Name,
-		ClusterId:             model.Cluster.Id,
-		Active:                model.Active,
-		PrometheusEndpoint:    model.Cluster.PrometheusEndpoint,
-		Namespace:             model.Namespace,
-		Default:               model.Default,
-		EnvironmentIdentifier: model.EnvironmentIdentifier,
-		Description:           model.Description,
-		IsVirtualEnvironment:  model.IsVirtualEnvironment,
+		Id:                       model.Id,
+		Environment:              model.Name,
+		ClusterId:                model.Cluster.Id,
+		Active:                   model.Active,
+		PrometheusEndpoint:       model.Cluster.PrometheusEndpoint,
+		Namespace:                model.Namespace,
+		Default:                  model.Default,
+		EnvironmentIdentifier:    model.EnvironmentIdentifier,
+		Description:              model.Description,
+		IsVirtualEnvironment:     model.IsVirtualEnvironment,
+		GitOpsPullRequestEnabled: model.GitOpsPullRequestEnabled,
 	}
 	return bean, nil
 }
@@ -266,6 +280,10 @@ func (impl EnvironmentServiceImpl) Update(mappings *bean2.EnvironmentBean, userI
 		impl.logger.Errorw("error in finding environment for update", "err", err)
 		return mappings, err
 	}
+	err = impl.validateGitOpsPullRequestSupport(mappings)
+	if err != nil {
+		return nil, err
+	}
 	/*isNamespaceChange := false
 	if model.Namespace != mappings.Namespace {
 		isNamespaceChange = true
@@ -283,6 +301,7 @@ func (impl EnvironmentServiceImpl) Update(mappings *bean2.EnvironmentBean, userI
 	model.UpdatedBy = userId
 	model.UpdatedOn = time.Now()
 	model.Description = mappings.Description
+	model.GitOpsPullRequestEnabled = mappings.GitOpsPullRequestEnabled
 
 	//namespace create if not exist
 	if len(model.Namespace) > 0 {
@@ -473,6 +492,26 @@ func (impl EnvironmentServiceImpl) validateNamespaces(namespace string, envs []*
 	return nil
 }
 
+// validateGitOpsPullRequestSupport rejects pull request based gitops deployments if the configured gitops provider cannot raise pull requests
+func (impl EnvironmentServiceImpl) validateGitOpsPullRequestSupport(mappings *bean2.EnvironmentBean) error {
+	if !mappings.GitOpsPullRequestEnabled {
+		return nil
+	}
+	gitOpsConfig, err := impl.gitOpsConfigReadService.GetGitOpsConfigActive()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in fetching active gitops config", "err", err)
+		return err
+	} else if util.IsErrNoRows(err) || gitOpsConfig == nil {
+		return util.NewApiError(http.StatusBadRequest, "gitops pull requests cannot be enabled as gitops is not configured", "gitops is not configured")
+	}
+	if !git.IsPullRequestSupported(gitOpsConfig.Provider) {
+		return util.NewApiError(http.StatusBadRequest,
+			fmt.Sprintf("gitops pull requests are not supported for the configured gitops provider %s", gitOpsConfig.Provider),
+			"gitops provider does not implement pull requests")
+	}
+	return nil
+}
+
 func (impl EnvironmentServiceImpl) FindByIds(ids []*int) ([]*bean2.EnvironmentBean, error) {
 	models, err := impl.environmentRepository.FindByIds(ids)
 	if err != nil {
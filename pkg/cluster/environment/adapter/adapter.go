@@ -10,15 +10,16 @@ import (
 // Note: NewEnvironmentBean doesn't include AppCount and AllowedDeploymentTypes
 func NewEnvironmentBean(envModel *repository.Environment) *bean.EnvironmentBean {
 	envBean := &bean.EnvironmentBean{
-		Id:                    envModel.Id,
-		Environment:           envModel.Name,
-		ClusterId:             envModel.ClusterId,
-		Active:                envModel.Active,
-		Default:               envModel.Default,
-		Namespace:             envModel.Namespace,
-		EnvironmentIdentifier: envModel.EnvironmentIdentifier,
-		Description:           envModel.Description,
-		IsVirtualEnvironment:  envModel.IsVirtualEnvironment,
+		Id:                       envModel.Id,
+		Environment:              envModel.Name,
+		ClusterId:                envModel.ClusterId,
+		Active:                   envModel.Active,
+		Default:                  envModel.Default,
+		Namespace:                envModel.Namespace,
+		EnvironmentIdentifier:    envModel.EnvironmentIdentifier,
+		Description:              envModel.Description,
+		IsVirtualEnvironment:     envModel.IsVirtualEnvironment,
+		GitOpsPullRequestEnabled: envModel.GitOpsPullRequestEnabled,
 	}
 	if envModel.Cluster != nil {
 		envBean.ClusterConfig = envModel.Cluster.Config
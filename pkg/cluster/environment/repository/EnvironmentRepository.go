@@ -50,6 +50,8 @@ type Environment struct {
 	EnvironmentIdentifier string `sql:"environment_identifier"`
 	Description           string `sql:"description"`
 	IsVirtualEnvironment  bool   `sql:"is_virtual_environment"`
+	// GitOpsPullRequestEnabled makes gitops deployments raise a pull request instead of committing to the target revision
+	GitOpsPullRequestEnabled bool `sql:"gitops_pull_request_enabled,notnull"`
 	sql.AuditLog
 }
 
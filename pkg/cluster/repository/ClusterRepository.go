@@ -45,6 +45,7 @@ type Cluster struct {
 	IsVirtualCluster       bool                     `sql:"is_virtual_cluster"`
 	InsecureSkipTlsVerify  bool                     `sql:"insecure_skip_tls_verify"`
 	IsProd                 bool                     `sql:"is_prod"`
+	Labels                 map[string]string        `sql:"labels"`
 	sql.AuditLog
 }
 
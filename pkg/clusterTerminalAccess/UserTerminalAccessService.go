@@ -36,6 +36,8 @@ import (
 	"github.com/devtron-labs/devtron/pkg/k8s"
 	bean2 "github.com/devtron-labs/devtron/pkg/k8s/bean"
 	"github.com/devtron-labs/devtron/pkg/k8s/capacity"
+	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
+	auditBean "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
 	"github.com/devtron-labs/devtron/pkg/terminal"
 	"github.com/devtron-labs/devtron/util"
 	cron3 "github.com/devtron-labs/devtron/util/cron"
@@ -78,6 +80,7 @@ type UserTerminalAccessServiceImpl struct {
 	K8sCapacityService           capacity.K8sCapacityService
 	k8sUtil                      *k8s2.K8sServiceImpl
 	asyncRunnable                *async.Runnable
+	k8sResourceHistoryService    kubernetesResourceAuditLogs.K8sResourceHistoryService
 }
 
 type UserTerminalAccessSessionData struct {
@@ -106,7 +109,8 @@ func NewUserTerminalAccessServiceImpl(logger *zap.SugaredLogger,
 	config *models.UserTerminalSessionConfig, k8sCommonService k8s.K8sCommonService,
 	terminalSessionHandler terminal.TerminalSessionHandler,
 	K8sCapacityService capacity.K8sCapacityService, k8sUtil *k8s2.K8sServiceImpl,
-	cronLogger *cron3.CronLoggerImpl, asyncRunnable *async.Runnable) (*UserTerminalAccessServiceImpl, error) {
+	cronLogger *cron3.CronLoggerImpl, asyncRunnable *async.Runnable,
+	k8sResourceHistoryService kubernetesResourceAuditLogs.K8sResourceHistoryService) (*UserTerminalAccessServiceImpl, error) {
 	//fetches all running and starting entities from db and start SyncStatus
 	podStatusSyncCron := cron.New(cron.WithChain(cron.Recover(cronLogger)))
 	terminalAccessDataArrayMutex := &sync.RWMutex{}
@@ -123,6 +127,7 @@ func NewUserTerminalAccessServiceImpl(logger *zap.SugaredLogger,
 		K8sCapacityService:           K8sCapacityService,
 		k8sUtil:                      k8sUtil,
 		asyncRunnable:                asyncRunnable,
+		k8sResourceHistoryService:    k8sResourceHistoryService,
 	}
 	podStatusSyncCron.Start()
 	_, err := podStatusSyncCron.AddFunc(fmt.Sprintf("@every %ds", config.TerminalPodStatusSyncTimeInSecs), accessServiceImpl.SyncPodStatus)
@@ -1124,6 +1129,20 @@ func (impl *UserTerminalAccessServiceImpl) EditTerminalPodManifest(ctx context.C
 		//send podObject data
 		return result, err
 	}
+	err = impl.k8sResourceHistoryService.SaveResourceChangeAudit(&auditBean.ResourceChangeAuditRequest{
+		ClusterId: editManifestRequest.ClusterId,
+		ResourceIdentifier: k8s2.ResourceIdentifier{
+			Name:             podObject.Name,
+			Namespace:        podObject.Namespace,
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+		},
+		Action:        auditBean.ActionCreate,
+		ManifestAfter: kubernetesResourceAuditLogs.ToUnstructured(&podObject),
+		UserId:        editManifestRequest.UserId,
+	})
+	if err != nil {
+		impl.Logger.Errorw("error in saving resource change audit for edited terminal pod", "userTerminalAccessId", userTerminalAccessId, "err", err)
+	}
 	result.PodExists = false
 	result.DebugNode = utils1.IsNodeDebugPod(&podObject)
 	var containers []models.Container
@@ -1161,6 +1180,7 @@ func (impl *UserTerminalAccessServiceImpl) forceDeletePod(ctx context.Context, p
 		return false
 	}
 	podRequestBean.K8sRequest.ForceDelete = true
+	podRequestBean.UserId = userId
 	_, err = impl.K8sCommonService.DeleteResource(ctx, podRequestBean)
 	if err != nil && !k8s.IsResourceNotFoundErr(err) {
 		return false
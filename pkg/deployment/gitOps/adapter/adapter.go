@@ -44,7 +44,20 @@ func GetGitOpsConfigBean(model *repository.GitOpsConfig) *apiGitOpsBean.GitOpsCo
 			TLSCertData: model.TlsCert,
 			TLSKeyData:  model.TlsKey,
 		},
-		AuthMode: ToGitOpsSupportedModes(model.AuthMode),
+		AuthMode:      ToGitOpsSupportedModes(model.AuthMode),
+		SigningConfig: GetGitOpsSigningConfig(model),
+	}
+}
+
+func GetGitOpsSigningConfig(model *repository.GitOpsConfig) *apiGitOpsBean.GitOpsSigningConfig {
+	if len(model.SigningKey.String()) == 0 {
+		return nil
+	}
+	return &apiGitOpsBean.GitOpsSigningConfig{
+		KeyType:             model.SigningKeyType,
+		SigningKey:          model.SigningKey.String(),
+		Passphrase:          model.SigningKeyPassphrase.String(),
+		IsSigningKeyPresent: true,
 	}
 }
 
@@ -0,0 +1,393 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/caarlos0/env"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	commonBean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	gitBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"path/filepath"
+	"reflect"
+	"sigs.k8s.io/yaml"
+	"slices"
+	"strings"
+	"time"
+)
+
+type GitOpsDriftDetectionService interface {
+	// DetectDrift compares, for every argoCd cd pipeline, the values last committed by Devtron with the head of the
+	// gitops repository and the desired state of the gitops repository with the live state of the cluster.
+	// A notification event is sent when a pipeline starts drifting.
+	DetectDrift()
+}
+
+type GitOpsDriftConfig struct {
+	DetectionEnabled        bool `env:"GITOPS_DRIFT_DETECTION_ENABLED" envDefault:"false" description:"Enable the periodic detection of out-of-band changes in the gitops repository and the live cluster"`
+	DetectionCronTimeInMins int  `env:"GITOPS_DRIFT_DETECTION_CRON_TIME" envDefault:"30" description:"Interval in minutes at which the cd pipelines are checked for out-of-band changes"`
+}
+
+func GetGitOpsDriftConfig() (*GitOpsDriftConfig, error) {
+	cfg := &GitOpsDriftConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse gitops drift config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+type GitOpsDriftDetectionServiceImpl struct {
+	logger                     *zap.SugaredLogger
+	cron                       *cron.Cron
+	cfg                        *GitOpsDriftConfig
+	gitOpsDriftRepository      repository.GitOpsDriftRepository
+	pipelineRepository         pipelineConfig.PipelineRepository
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository
+	cdWorkflowRepository       pipelineConfig.CdWorkflowRepository
+	deploymentConfigService    common.DeploymentConfigService
+	gitOpsConfigReadService    config.GitOpsConfigReadService
+	gitOperationService        git.GitOperationService
+	argoClientWrapperService   argocdServer.ArgoClientWrapperService
+	eventFactory               client.EventFactory
+	eventClient                client.EventClient
+}
+
+func NewGitOpsDriftDetectionServiceImpl(logger *zap.SugaredLogger, cfg *GitOpsDriftConfig, cronLogger *cron2.CronLoggerImpl,
+	gitOpsDriftRepository repository.GitOpsDriftRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	deploymentConfigService common.DeploymentConfigService,
+	gitOpsConfigReadService config.GitOpsConfigReadService,
+	gitOperationService git.GitOperationService,
+	argoClientWrapperService argocdServer.ArgoClientWrapperService,
+	eventFactory client.EventFactory,
+	eventClient client.EventClient) *GitOpsDriftDetectionServiceImpl {
+	impl := &GitOpsDriftDetectionServiceImpl{
+		logger:                     logger,
+		cfg:                        cfg,
+		gitOpsDriftRepository:      gitOpsDriftRepository,
+		pipelineRepository:         pipelineRepository,
+		pipelineOverrideRepository: pipelineOverrideRepository,
+		cdWorkflowRepository:       cdWorkflowRepository,
+		deploymentConfigService:    deploymentConfigService,
+		gitOpsConfigReadService:    gitOpsConfigReadService,
+		gitOperationService:        gitOperationService,
+		argoClientWrapperService:   argoClientWrapperService,
+		eventFactory:               eventFactory,
+		eventClient:                eventClient,
+	}
+	if !cfg.DetectionEnabled {
+		return impl
+	}
+	impl.cron = cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	impl.cron.Start()
+	_, err := impl.cron.AddFunc(fmt.Sprintf("@every %dm", cfg.DetectionCronTimeInMins), impl.DetectDrift)
+	if err != nil {
+		logger.Errorw("error while configure cron job for gitops drift detection", "err", err)
+		return impl
+	}
+	return impl
+}
+
+// driftCheck is a pipeline claimed by this instance for the current run of the drift detection
+type driftCheck struct {
+	pipeline         *pipelineConfig.Pipeline
+	deploymentConfig *commonBean.DeploymentConfig
+	override         *chartConfig.PipelineOverride
+	existingDrift    *repository.GitOpsDrift
+	filePath         string
+}
+
+// filesOnHead are the values files of all the checked pipelines committed to the same repository and revision
+type filesOnHead struct {
+	fileVersions map[string]*gitBean.GitFileVersion
+	err          error
+}
+
+func (impl *GitOpsDriftDetectionServiceImpl) DetectDrift() {
+	pipelines, err := impl.pipelineRepository.FindActiveByDeploymentAppType(util.PIPELINE_DEPLOYMENT_TYPE_ACD)
+	if err != nil {
+		impl.logger.Errorw("error in fetching argoCd pipelines for drift detection", "err", err)
+		return
+	}
+	// every instance runs the detection, a pipeline checked within the last half interval has been picked up by another instance
+	checkedBefore := time.Now().Add(-time.Duration(impl.cfg.DetectionCronTimeInMins) * time.Minute / 2)
+	trackedPipelineIds := make([]int, 0, len(pipelines))
+	checks := make([]*driftCheck, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		check, isTracked, err := impl.claimDriftCheck(pipeline, checkedBefore)
+		if err != nil {
+			impl.logger.Errorw("error in detecting gitops drift", "pipelineId", pipeline.Id, "err", err)
+		}
+		if isTracked || err != nil {
+			trackedPipelineIds = append(trackedPipelineIds, pipeline.Id)
+		}
+		if check != nil {
+			checks = append(checks, check)
+		}
+	}
+	headFiles := impl.getFilesOnHead(checks)
+	for _, check := range checks {
+		drift := impl.computeDrift(check, headFiles[getRepoKey(check.deploymentConfig)])
+		err = impl.saveDrift(check, drift)
+		if err != nil {
+			impl.logger.Errorw("error in saving gitops drift", "pipelineId", check.pipeline.Id, "err", err)
+		}
+	}
+	err = impl.gitOpsDriftRepository.DeleteByPipelineIdsNotIn(trackedPipelineIds)
+	if err != nil {
+		impl.logger.Errorw("error in deleting gitops drift of untracked pipelines", "err", err)
+	}
+}
+
+// claimDriftCheck returns false if the pipeline is not deployed via gitops by Devtron and hence can not drift.
+// No check is returned if the drift need not be computed or is being computed by another instance.
+func (impl *GitOpsDriftDetectionServiceImpl) claimDriftCheck(pipeline *pipelineConfig.Pipeline, checkedBefore time.Time) (*driftCheck, bool, error) {
+	deploymentConfig, err := impl.deploymentConfigService.GetConfigForDevtronApps(nil, pipeline.AppId, pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching deployment config", "appId", pipeline.AppId, "envId", pipeline.EnvironmentId, "err", err)
+		return nil, false, err
+	}
+	// linked releases are not committed by Devtron, so there is no desired state to compare with
+	if !deploymentConfig.IsArgoCdClientSupported() {
+		return nil, false, nil
+	}
+	runner, err := impl.cdWorkflowRepository.FindLatestByPipelineIdAndRunnerType(pipeline.Id, apiBean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching latest deployment runner", "pipelineId", pipeline.Id, "err", err)
+		return nil, false, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, false, nil
+	}
+	if !slices.Contains(cdWorkflow.WfrTerminalStatusList, runner.Status) {
+		// the cluster is expected to differ from git while a deployment is progressing, last computed drift is retained
+		return nil, true, nil
+	}
+	override, err := impl.pipelineOverrideRepository.FindLatestCommittedByPipelineId(pipeline.Id)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching latest committed pipeline override", "pipelineId", pipeline.Id, "err", err)
+		return nil, false, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, false, nil
+	}
+	existingDrift, err := impl.gitOpsDriftRepository.FindByPipelineId(pipeline.Id)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching gitops drift", "pipelineId", pipeline.Id, "err", err)
+		return nil, true, err
+	}
+	// the first drift of a pipeline is claimed on save, see saveDrift
+	if existingDrift.Id > 0 {
+		isClaimed, err := impl.gitOpsDriftRepository.ClaimForCheck(existingDrift.Id, checkedBefore, time.Now())
+		if err != nil {
+			impl.logger.Errorw("error in claiming gitops drift check", "pipelineId", pipeline.Id, "err", err)
+			return nil, true, err
+		} else if !isClaimed {
+			return nil, true, nil
+		}
+	}
+	return &driftCheck{
+		pipeline:         pipeline,
+		deploymentConfig: deploymentConfig,
+		override:         override,
+		existingDrift:    existingDrift,
+		filePath:         filepath.Join(deploymentConfig.GetChartLocation(), deploymentConfig.GetValuesFilePathForCommit()),
+	}, true, nil
+}
+
+// getFilesOnHead clones every gitops repository once for all the pipelines committed to it
+func (impl *GitOpsDriftDetectionServiceImpl) getFilesOnHead(checks []*driftCheck) map[string]*filesOnHead {
+	filePaths := make(map[string][]string)
+	deploymentConfigs := make(map[string]*commonBean.DeploymentConfig)
+	for _, check := range checks {
+		repoKey := getRepoKey(check.deploymentConfig)
+		filePaths[repoKey] = append(filePaths[repoKey], check.filePath)
+		deploymentConfigs[repoKey] = check.deploymentConfig
+	}
+	headFiles := make(map[string]*filesOnHead, len(filePaths))
+	for repoKey, deploymentConfig := range deploymentConfigs {
+		repoName := impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(deploymentConfig.GetRepoURL())
+		fileVersions, err := impl.gitOperationService.GetFilesOnHead(context.Background(), repoName, deploymentConfig.GetRepoURL(), deploymentConfig.GetTargetRevision(), filePaths[repoKey])
+		if err != nil {
+			impl.logger.Errorw("error in reading values files from gitops repository", "repoUrl", deploymentConfig.GetRepoURL(), "targetRevision", deploymentConfig.GetTargetRevision(), "err", err)
+		}
+		headFiles[repoKey] = &filesOnHead{fileVersions: fileVersions, err: err}
+	}
+	return headFiles
+}
+
+func getRepoKey(deploymentConfig *commonBean.DeploymentConfig) string {
+	return deploymentConfig.GetRepoURL() + "@" + deploymentConfig.GetTargetRevision()
+}
+
+func (impl *GitOpsDriftDetectionServiceImpl) saveDrift(check *driftCheck, drift *repository.GitOpsDrift) error {
+	pipeline, existingDrift := check.pipeline, check.existingDrift
+	drift.PipelineId = pipeline.Id
+	drift.AppId = pipeline.AppId
+	drift.EnvId = pipeline.EnvironmentId
+	drift.PipelineOverrideId = check.override.Id
+	wasDrifted := existingDrift.Id > 0 && existingDrift.Status == bean.DriftStatusDrifted
+	if drift.Status == bean.DriftStatusDrifted {
+		drift.DetectedOn = drift.CheckedOn
+		if wasDrifted {
+			drift.DetectedOn = existingDrift.DetectedOn
+		}
+	}
+	drift.UpdatedOn = drift.CheckedOn
+	drift.UpdatedBy = 1
+	if existingDrift.Id > 0 {
+		drift.Id = existingDrift.Id
+		drift.CreatedOn = existingDrift.CreatedOn
+		drift.CreatedBy = existingDrift.CreatedBy
+		err := impl.gitOpsDriftRepository.Update(drift)
+		if err != nil {
+			return err
+		}
+	} else {
+		drift.CreatedOn = drift.CheckedOn
+		drift.CreatedBy = 1
+		isSaved, err := impl.gitOpsDriftRepository.SaveIfNotExists(drift)
+		if err != nil {
+			return err
+		} else if !isSaved {
+			// another instance checked the pipeline at the same time and has notified the drift
+			return nil
+		}
+	}
+	if drift.Status == bean.DriftStatusDrifted && !wasDrifted {
+		impl.writeDriftNotificationEvent(pipeline, drift)
+	}
+	return nil
+}
+
+// computeDrift never fails, the checks which could not be completed are reported in the message and mark the drift as unknown
+func (impl *GitOpsDriftDetectionServiceImpl) computeDrift(check *driftCheck, headFiles *filesOnHead) *repository.GitOpsDrift {
+	ctx := context.Background()
+	deploymentConfig, override, filePath := check.deploymentConfig, check.override, check.filePath
+	drift := &repository.GitOpsDrift{
+		DeployedCommitHash: override.GitHash,
+		CheckedOn:          time.Now(),
+	}
+	var failures []string
+
+	if headFiles.err != nil {
+		failures = append(failures, fmt.Sprintf("could not read %s from the gitops repository: %s", filePath, headFiles.err.Error()))
+	} else {
+		fileVersion := headFiles.fileVersions[filePath]
+		drift.HeadCommitHash = fileVersion.CommitHash
+		drift.GitDrifted = !fileVersion.Exists || !isValuesEqual(override.PipelineMergedValues, fileVersion.Content)
+		if drift.GitDrifted {
+			gitDiff, _ := json.Marshal(&bean.GitDiff{
+				FilePath:       filePath,
+				DeployedValues: override.PipelineMergedValues,
+				HeadValues:     fileVersion.Content,
+			})
+			drift.GitDiff = string(gitDiff)
+		}
+	}
+
+	managedResources, err := impl.argoClientWrapperService.GetManagedResources(ctx, deploymentConfig.GetAcdAppName())
+	if err != nil {
+		impl.logger.Errorw("error in fetching managed resources of argoCd app", "acdAppName", deploymentConfig.GetAcdAppName(), "err", err)
+		failures = append(failures, fmt.Sprintf("could not compare the live state of the cluster: %s", err.Error()))
+	} else if resourceDiffs := adapter.GetResourceDiffs(managedResources); len(resourceDiffs) > 0 {
+		drift.LiveDrifted = true
+		liveDiff, _ := json.Marshal(resourceDiffs)
+		drift.LiveDiff = string(liveDiff)
+	}
+
+	switch {
+	case drift.GitDrifted || drift.LiveDrifted:
+		drift.Status = bean.DriftStatusDrifted
+	case len(failures) > 0:
+		drift.Status = bean.DriftStatusUnknown
+	default:
+		drift.Status = bean.DriftStatusInSync
+	}
+	drift.Message = strings.Join(failures, "; ")
+	return drift
+}
+
+func (impl *GitOpsDriftDetectionServiceImpl) writeDriftNotificationEvent(pipeline *pipelineConfig.Pipeline, drift *repository.GitOpsDrift) {
+	event, err := impl.eventFactory.Build(eventUtil.GitOpsDrift, &pipeline.Id, pipeline.AppId, &pipeline.EnvironmentId, eventUtil.CD)
+	if err != nil {
+		impl.logger.Errorw("error in building gitops drift event", "pipelineId", pipeline.Id, "err", err)
+		return
+	}
+	event.Payload = &client.Payload{
+		DriftedIn:      getDriftedIn(drift),
+		HeadCommitHash: drift.HeadCommitHash,
+	}
+	_, err = impl.eventClient.WriteNotificationEvent(event)
+	if err != nil {
+		impl.logger.Errorw("error in writing gitops drift event", "pipelineId", pipeline.Id, "err", err)
+	}
+}
+
+func getDriftedIn(drift *repository.GitOpsDrift) string {
+	switch {
+	case drift.GitDrifted && drift.LiveDrifted:
+		return "GitOps repository and live cluster"
+	case drift.GitDrifted:
+		return "GitOps repository"
+	default:
+		return "Live cluster"
+	}
+}
+
+// isValuesEqual compares the values semantically, as the values may be committed as json or yaml
+func isValuesEqual(deployedValues, headValues string) bool {
+	deployedJson, err := yaml.YAMLToJSON([]byte(deployedValues))
+	if err != nil {
+		return false
+	}
+	headJson, err := yaml.YAMLToJSON([]byte(headValues))
+	if err != nil {
+		return false
+	}
+	var deployed, head interface{}
+	if err = json.Unmarshal(deployedJson, &deployed); err != nil {
+		return false
+	}
+	if err = json.Unmarshal(headJson, &head); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(deployed, head)
+}
@@ -0,0 +1,291 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package drift
+
+import (
+	"context"
+	"fmt"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/client/argocdServer"
+	client "github.com/devtron-labs/devtron/client/events"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/common"
+	commonBean "github.com/devtron-labs/devtron/pkg/deployment/common/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	gitBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsValuesEqual(t *testing.T) {
+	deployedValues := `{"replicaCount":2,"image":{"tag":"abc123"},"env":[{"name":"A","value":"1"}]}`
+	t.Run("same values committed as yaml", func(t *testing.T) {
+		headValues := "env:\n- name: A\n  value: \"1\"\nimage:\n  tag: abc123\nreplicaCount: 2\n"
+		assert.True(t, isValuesEqual(deployedValues, headValues))
+	})
+	t.Run("changed value", func(t *testing.T) {
+		headValues := `{"replicaCount":3,"image":{"tag":"abc123"},"env":[{"name":"A","value":"1"}]}`
+		assert.False(t, isValuesEqual(deployedValues, headValues))
+	})
+	t.Run("invalid head values", func(t *testing.T) {
+		assert.False(t, isValuesEqual(deployedValues, "replicaCount: [2"))
+	})
+}
+
+type pipelineRepositoryStub struct {
+	pipelineConfig.PipelineRepository
+	pipelines []*pipelineConfig.Pipeline
+}
+
+func (impl *pipelineRepositoryStub) FindActiveByDeploymentAppType(deploymentAppType string) ([]*pipelineConfig.Pipeline, error) {
+	return impl.pipelines, nil
+}
+
+type deploymentConfigServiceStub struct {
+	common.DeploymentConfigService
+	repoUrls map[int]string
+}
+
+func (impl *deploymentConfigServiceStub) GetConfigForDevtronApps(tx *pg.Tx, appId, envId int) (*commonBean.DeploymentConfig, error) {
+	return &commonBean.DeploymentConfig{
+		AppId:             appId,
+		EnvironmentId:     envId,
+		DeploymentAppType: util.PIPELINE_DEPLOYMENT_TYPE_ACD,
+		ReleaseConfiguration: &commonBean.ReleaseConfiguration{
+			ArgoCDSpec: commonBean.ArgoCDSpec{
+				Metadata: commonBean.ApplicationMetadata{Name: fmt.Sprintf("app-%d-env-%d", appId, envId)},
+				Spec: commonBean.ApplicationSpec{Source: &commonBean.ApplicationSource{
+					RepoURL: impl.repoUrls[appId],
+					Path:    fmt.Sprintf("env/%d", envId),
+					Helm:    &commonBean.ApplicationSourceHelm{ValueFiles: []string{"values.yaml"}},
+				}},
+			},
+		},
+	}, nil
+}
+
+type cdWorkflowRepositoryStub struct {
+	pipelineConfig.CdWorkflowRepository
+}
+
+func (impl *cdWorkflowRepositoryStub) FindLatestByPipelineIdAndRunnerType(pipelineId int, runnerType apiBean.WorkflowType) (pipelineConfig.CdWorkflowRunner, error) {
+	return pipelineConfig.CdWorkflowRunner{Status: cdWorkflow.WfrTerminalStatusList[0]}, nil
+}
+
+type pipelineOverrideRepositoryStub struct {
+	chartConfig.PipelineOverrideRepository
+}
+
+func (impl *pipelineOverrideRepositoryStub) FindLatestCommittedByPipelineId(pipelineId int) (*chartConfig.PipelineOverride, error) {
+	return &chartConfig.PipelineOverride{Id: pipelineId * 10, GitHash: "abc123", PipelineMergedValues: `{"replicaCount":1}`}, nil
+}
+
+// gitOpsDriftRepositoryStub is shared by the instances, same as the database
+type gitOpsDriftRepositoryStub struct {
+	repository.GitOpsDriftRepository
+	lock   sync.Mutex
+	drifts map[int]*repository.GitOpsDrift
+}
+
+func (impl *gitOpsDriftRepositoryStub) FindByPipelineId(pipelineId int) (*repository.GitOpsDrift, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	if drift, ok := impl.drifts[pipelineId]; ok {
+		driftCopy := *drift
+		return &driftCopy, nil
+	}
+	return &repository.GitOpsDrift{}, pg.ErrNoRows
+}
+
+func (impl *gitOpsDriftRepositoryStub) SaveIfNotExists(model *repository.GitOpsDrift) (bool, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	if _, ok := impl.drifts[model.PipelineId]; ok {
+		return false, nil
+	}
+	model.Id = model.PipelineId
+	driftCopy := *model
+	impl.drifts[model.PipelineId] = &driftCopy
+	return true, nil
+}
+
+func (impl *gitOpsDriftRepositoryStub) Update(model *repository.GitOpsDrift) error {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	driftCopy := *model
+	impl.drifts[model.PipelineId] = &driftCopy
+	return nil
+}
+
+func (impl *gitOpsDriftRepositoryStub) ClaimForCheck(id int, checkedBefore time.Time, checkedOn time.Time) (bool, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	for _, drift := range impl.drifts {
+		if drift.Id == id && drift.CheckedOn.Before(checkedBefore) {
+			drift.CheckedOn = checkedOn
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (impl *gitOpsDriftRepositoryStub) DeleteByPipelineIdsNotIn(pipelineIds []int) error {
+	return nil
+}
+
+type gitOpsConfigReadServiceStub struct {
+	config.GitOpsConfigReadService
+}
+
+func (impl *gitOpsConfigReadServiceStub) GetGitOpsRepoNameFromUrl(gitRepoUrl string) string {
+	return gitRepoUrl
+}
+
+type gitOperationServiceStub struct {
+	git.GitOperationService
+	lock        sync.Mutex
+	clonedRepos []string
+	files       map[string]string
+}
+
+func (impl *gitOperationServiceStub) GetFilesOnHead(ctx context.Context, gitOpsRepoName, repoUrl, targetRevision string, filePaths []string) (map[string]*gitBean.GitFileVersion, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	impl.clonedRepos = append(impl.clonedRepos, repoUrl)
+	fileVersions := make(map[string]*gitBean.GitFileVersion)
+	for _, filePath := range filePaths {
+		content, ok := impl.files[repoUrl+"/"+filePath]
+		fileVersions[filePath] = &gitBean.GitFileVersion{Content: content, Exists: ok, CommitHash: "def456"}
+	}
+	return fileVersions, nil
+}
+
+type argoClientWrapperServiceStub struct {
+	argocdServer.ArgoClientWrapperService
+}
+
+func (impl *argoClientWrapperServiceStub) GetManagedResources(ctx context.Context, appName string) ([]*v1alpha1.ResourceDiff, error) {
+	return nil, nil
+}
+
+type eventFactoryStub struct {
+	client.EventFactory
+}
+
+func (impl *eventFactoryStub) Build(eventType eventUtil.EventType, sourceId *int, appId int, envId *int, pipelineType eventUtil.PipelineType) (client.Event, error) {
+	return client.Event{EventTypeId: int(eventType), PipelineId: *sourceId}, nil
+}
+
+type eventClientStub struct {
+	client.EventClient
+	lock   sync.Mutex
+	events []client.Event
+}
+
+func (impl *eventClientStub) WriteNotificationEvent(event client.Event) (bool, error) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	impl.events = append(impl.events, event)
+	return true, nil
+}
+
+func TestDetectDrift(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	pipelines := []*pipelineConfig.Pipeline{
+		{Id: 1, AppId: 1, EnvironmentId: 1},
+		{Id: 2, AppId: 1, EnvironmentId: 2},
+		{Id: 3, AppId: 2, EnvironmentId: 1},
+	}
+	gitOpsDriftRepository := &gitOpsDriftRepositoryStub{drifts: map[int]*repository.GitOpsDrift{
+		// checked by a previous run of the detection
+		1: {Id: 1, PipelineId: 1, Status: bean.DriftStatusInSync, CheckedOn: time.Now().Add(-time.Hour)},
+	}}
+	gitOperationService := &gitOperationServiceStub{files: map[string]string{
+		"https://git/devtron/app-1/env/1/values.yaml": "replicaCount: 1\n",
+		"https://git/devtron/app-1/env/2/values.yaml": "replicaCount: 3\n",
+		"https://git/devtron/app-2/env/1/values.yaml": "replicaCount: 1\n",
+	}}
+	eventClient := &eventClientStub{}
+	newInstance := func() *GitOpsDriftDetectionServiceImpl {
+		return NewGitOpsDriftDetectionServiceImpl(logger, &GitOpsDriftConfig{DetectionCronTimeInMins: 30}, nil,
+			gitOpsDriftRepository,
+			&pipelineRepositoryStub{pipelines: pipelines},
+			&pipelineOverrideRepositoryStub{},
+			&cdWorkflowRepositoryStub{},
+			&deploymentConfigServiceStub{repoUrls: map[int]string{1: "https://git/devtron/app-1", 2: "https://git/devtron/app-2"}},
+			&gitOpsConfigReadServiceStub{},
+			gitOperationService,
+			&argoClientWrapperServiceStub{},
+			&eventFactoryStub{},
+			eventClient)
+	}
+
+	t.Run("repository is cloned once per run", func(t *testing.T) {
+		newInstance().DetectDrift()
+		assert.ElementsMatch(t, []string{"https://git/devtron/app-1", "https://git/devtron/app-2"}, gitOperationService.clonedRepos)
+		assert.Len(t, gitOpsDriftRepository.drifts, 3)
+		assert.Equal(t, bean.DriftStatusInSync, gitOpsDriftRepository.drifts[1].Status)
+		assert.Equal(t, bean.DriftStatusDrifted, gitOpsDriftRepository.drifts[2].Status)
+		assert.Equal(t, "def456", gitOpsDriftRepository.drifts[2].HeadCommitHash)
+		assert.Equal(t, bean.DriftStatusInSync, gitOpsDriftRepository.drifts[3].Status)
+		assert.Len(t, eventClient.events, 1)
+		assert.Equal(t, 2, eventClient.events[0].PipelineId)
+	})
+
+	t.Run("pipelines checked by another instance are skipped", func(t *testing.T) {
+		gitOperationService.clonedRepos = nil
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				newInstance().DetectDrift()
+			}()
+		}
+		wg.Wait()
+		assert.Empty(t, gitOperationService.clonedRepos)
+		assert.Len(t, eventClient.events, 1)
+	})
+
+	t.Run("first drift is notified by one instance", func(t *testing.T) {
+		gitOpsDriftRepository.drifts = map[int]*repository.GitOpsDrift{}
+		eventClient.events = nil
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				newInstance().DetectDrift()
+			}()
+		}
+		wg.Wait()
+		assert.Len(t, gitOpsDriftRepository.drifts, 3)
+		assert.Len(t, eventClient.events, 1)
+	})
+}
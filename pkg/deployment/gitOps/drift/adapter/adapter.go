@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"encoding/json"
+	"github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
+)
+
+func GetGitOpsDriftDto(model *repository.GitOpsDrift) (*bean.GitOpsDriftDto, error) {
+	dto := &bean.GitOpsDriftDto{
+		Status:             model.Status,
+		GitDrifted:         model.GitDrifted,
+		LiveDrifted:        model.LiveDrifted,
+		DeployedCommitHash: model.DeployedCommitHash,
+		HeadCommitHash:     model.HeadCommitHash,
+		Message:            model.Message,
+		CheckedOn:          model.CheckedOn,
+	}
+	if !model.DetectedOn.IsZero() {
+		detectedOn := model.DetectedOn
+		dto.DetectedOn = &detectedOn
+	}
+	if len(model.GitDiff) > 0 {
+		gitDiff := &bean.GitDiff{}
+		if err := json.Unmarshal([]byte(model.GitDiff), gitDiff); err != nil {
+			return nil, err
+		}
+		dto.GitDiff = gitDiff
+	}
+	if len(model.LiveDiff) > 0 {
+		if err := json.Unmarshal([]byte(model.LiveDiff), &dto.LiveDiff); err != nil {
+			return nil, err
+		}
+	}
+	return dto, nil
+}
+
+// GetResourceDiffs returns the diff of the resources which are modified in the cluster, hooks are not tracked by argoCd after they are run
+func GetResourceDiffs(managedResources []*v1alpha1.ResourceDiff) []*bean.ResourceDiff {
+	resourceDiffs := make([]*bean.ResourceDiff, 0)
+	for _, resource := range managedResources {
+		if resource == nil || !resource.Modified || resource.Hook {
+			continue
+		}
+		resourceDiffs = append(resourceDiffs, &bean.ResourceDiff{
+			Group:       resource.Group,
+			Kind:        resource.Kind,
+			Namespace:   resource.Namespace,
+			Name:        resource.Name,
+			TargetState: resource.PredictedLiveState,
+			LiveState:   resource.NormalizedLiveState,
+		})
+	}
+	return resourceDiffs
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import "time"
+
+type DriftStatus string
+
+const (
+	DriftStatusInSync  DriftStatus = "InSync"
+	DriftStatusDrifted DriftStatus = "Drifted"
+	// DriftStatusUnknown is set when the drift could not be computed, the reason is available in the message
+	DriftStatusUnknown DriftStatus = "Unknown"
+)
+
+// GitOpsDriftDto is the drift of a cd pipeline from the state last committed to the gitops repository by Devtron
+type GitOpsDriftDto struct {
+	Status             DriftStatus     `json:"status"`
+	GitDrifted         bool            `json:"gitDrifted"`
+	LiveDrifted        bool            `json:"liveDrifted"`
+	DeployedCommitHash string          `json:"deployedCommitHash"`
+	HeadCommitHash     string          `json:"headCommitHash,omitempty"`
+	GitDiff            *GitDiff        `json:"gitDiff,omitempty"`
+	LiveDiff           []*ResourceDiff `json:"liveDiff,omitempty"`
+	Message            string          `json:"message,omitempty"`
+	DetectedOn         *time.Time      `json:"detectedOn,omitempty"`
+	CheckedOn          time.Time       `json:"checkedOn"`
+}
+
+func (dto *GitOpsDriftDto) IsDrifted() bool {
+	return dto != nil && dto.Status == DriftStatusDrifted
+}
+
+// GitDiff holds the values file as committed by Devtron and as present on the head of the gitops repository
+type GitDiff struct {
+	FilePath       string `json:"filePath"`
+	DeployedValues string `json:"deployedValues"`
+	HeadValues     string `json:"headValues"`
+}
+
+// ResourceDiff holds a resource whose live state in the cluster differs from the state desired by the gitops repository
+type ResourceDiff struct {
+	Group       string `json:"group,omitempty"`
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	TargetState string `json:"targetState"`
+	LiveState   string `json:"liveState"`
+}
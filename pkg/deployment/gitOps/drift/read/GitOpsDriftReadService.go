@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package read
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type GitOpsDriftReadService interface {
+	// GetDrift returns the last computed drift of the cd pipeline, nil if the drift was never computed
+	GetDrift(appId, envId int) (*bean.GitOpsDriftDto, error)
+}
+
+type GitOpsDriftReadServiceImpl struct {
+	logger                *zap.SugaredLogger
+	gitOpsDriftRepository repository.GitOpsDriftRepository
+}
+
+func NewGitOpsDriftReadServiceImpl(logger *zap.SugaredLogger,
+	gitOpsDriftRepository repository.GitOpsDriftRepository) *GitOpsDriftReadServiceImpl {
+	return &GitOpsDriftReadServiceImpl{
+		logger:                logger,
+		gitOpsDriftRepository: gitOpsDriftRepository,
+	}
+}
+
+func (impl *GitOpsDriftReadServiceImpl) GetDrift(appId, envId int) (*bean.GitOpsDriftDto, error) {
+	model, err := impl.gitOpsDriftRepository.FindByAppIdAndEnvId(appId, envId)
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in fetching gitops drift", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	} else if err == pg.ErrNoRows {
+		return nil, nil
+	}
+	dto, err := adapter.GetGitOpsDriftDto(model)
+	if err != nil {
+		impl.logger.Errorw("error in parsing gitops drift", "appId", appId, "envId", envId, "err", err)
+		return nil, err
+	}
+	return dto, nil
+}
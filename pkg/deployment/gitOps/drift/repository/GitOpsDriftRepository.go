@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"time"
+)
+
+// GitOpsDrift is the last computed drift of a cd pipeline, there is at most one row per pipeline
+type GitOpsDrift struct {
+	tableName          struct{}         `sql:"gitops_drift" pg:",discard_unknown_columns"`
+	Id                 int              `sql:"id,pk"`
+	PipelineId         int              `sql:"pipeline_id,notnull"`
+	AppId              int              `sql:"app_id,notnull"`
+	EnvId              int              `sql:"env_id,notnull"`
+	PipelineOverrideId int              `sql:"pipeline_override_id,notnull"`
+	DeployedCommitHash string           `sql:"deployed_commit_hash"`
+	HeadCommitHash     string           `sql:"head_commit_hash"`
+	Status             bean.DriftStatus `sql:"status,notnull"`
+	GitDrifted         bool             `sql:"git_drifted,notnull"`
+	LiveDrifted        bool             `sql:"live_drifted,notnull"`
+	GitDiff            string           `sql:"git_diff"`
+	LiveDiff           string           `sql:"live_diff"`
+	Message            string           `sql:"message"`
+	DetectedOn         time.Time        `sql:"detected_on,type:timestamptz"`
+	CheckedOn          time.Time        `sql:"checked_on,type:timestamptz,notnull"`
+	sql.AuditLog
+}
+
+type GitOpsDriftRepository interface {
+	// SaveIfNotExists saves the first drift of a pipeline, false is returned if another instance saved it first
+	SaveIfNotExists(model *GitOpsDrift) (bool, error)
+	Update(model *GitOpsDrift) error
+	// ClaimForCheck moves the checked on time of the drift to checkedOn if it was last checked before checkedBefore,
+	// false is returned if the pipeline is being checked by another instance
+	ClaimForCheck(id int, checkedBefore time.Time, checkedOn time.Time) (bool, error)
+	FindByPipelineId(pipelineId int) (*GitOpsDrift, error)
+	FindByAppIdAndEnvId(appId, envId int) (*GitOpsDrift, error)
+	DeleteByPipelineIdsNotIn(pipelineIds []int) error
+}
+
+type GitOpsDriftRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewGitOpsDriftRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *GitOpsDriftRepositoryImpl {
+	return &GitOpsDriftRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *GitOpsDriftRepositoryImpl) SaveIfNotExists(model *GitOpsDrift) (bool, error) {
+	res, err := impl.dbConnection.Model(model).
+		OnConflict("(pipeline_id) DO NOTHING").
+		Insert()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *GitOpsDriftRepositoryImpl) Update(model *GitOpsDrift) error {
+	return impl.dbConnection.Update(model)
+}
+
+func (impl *GitOpsDriftRepositoryImpl) ClaimForCheck(id int, checkedBefore time.Time, checkedOn time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*GitOpsDrift)(nil)).
+		Set("checked_on = ?", checkedOn).
+		Where("id = ?", id).
+		Where("checked_on < ?", checkedBefore).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *GitOpsDriftRepositoryImpl) FindByPipelineId(pipelineId int) (*GitOpsDrift, error) {
+	model := &GitOpsDrift{}
+	err := impl.dbConnection.Model(model).
+		Where("pipeline_id = ?", pipelineId).
+		Select()
+	return model, err
+}
+
+func (impl *GitOpsDriftRepositoryImpl) FindByAppIdAndEnvId(appId, envId int) (*GitOpsDrift, error) {
+	model := &GitOpsDrift{}
+	err := impl.dbConnection.Model(model).
+		Join("INNER JOIN pipeline p ON p.id = gitops_drift.pipeline_id").
+		Where("gitops_drift.app_id = ?", appId).
+		Where("gitops_drift.env_id = ?", envId).
+		Where("p.deleted = ?", false).
+		Order("gitops_drift.id DESC").
+		Limit(1).
+		Select()
+	return model, err
+}
+
+// DeleteByPipelineIdsNotIn removes the drift of the pipelines which are no longer checked, e.g. deleted or migrated to helm
+func (impl *GitOpsDriftRepositoryImpl) DeleteByPipelineIdsNotIn(pipelineIds []int) error {
+	query := impl.dbConnection.Model((*GitOpsDrift)(nil))
+	if len(pipelineIds) > 0 {
+		query = query.Where("pipeline_id NOT IN (?)", pg.In(pipelineIds))
+	} else {
+		query = query.Where("1 = 1")
+	}
+	_, err := query.Delete()
+	return err
+}
@@ -62,6 +62,7 @@ func (factory *GitFactory) Reload(gitOpsConfigReadService config.GitOpsConfigRea
 	clientHelperMap := make(map[string]*ClientHelperObject, len(cfgs))
 	for _, cfg := range cfgs {
 		gitOpsHelper, _ := NewGitOpsHelperImpl(cfg.GetAuth(), factory.logger, cfg.GetTLSConfig(), cfg.EnableTLSVerification)
+		gitOpsHelper.SetSigningKey(cfg.GetSigningKey())
 		client, clientCreationError := NewGitOpsClient(cfg, factory.logger, gitOpsHelper)
 		if clientCreationError != nil && cfg.IsActiveConfig { // only passing error in case of active config
 			return err
@@ -126,6 +127,7 @@ func (factory *GitFactory) NewClientForValidation(gitOpsConfig *gitOps.GitOpsCon
 		factory.logger.Errorw("error in creating gitOps helper", "gitProvider", cfg.GitProvider, "err", err)
 		return nil, gitOpsHelper, err
 	}
+	gitOpsHelper.SetSigningKey(cfg.GetSigningKey())
 	client, err := NewGitOpsClient(cfg, factory.logger, gitOpsHelper)
 	if err != nil {
 		factory.logger.Errorw("error in creating gitOps client", "gitProvider", cfg.GitProvider, "err", err)
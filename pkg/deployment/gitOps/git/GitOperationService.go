@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path"
@@ -54,6 +55,14 @@ type GitOperationService interface {
 	GitPull(clonedDir string, repoUrl string, targetRevision string) error
 
 	CommitValues(ctx context.Context, chartGitAttr *ChartConfig) (commitHash string, commitTime time.Time, err error)
+	// CommitValuesToPullRequest pushes the values to sourceBranch and raises a pull request for it against the target revision
+	CommitValuesToPullRequest(ctx context.Context, chartGitAttr *ChartConfig, repoUrl, sourceBranch string) (*bean.PullRequest, error)
+	GetPullRequest(ctx context.Context, repoName string, number int) (*bean.PullRequest, error)
+	// ClosePullRequest closes the pull request without merging it and deletes its source branch
+	ClosePullRequest(ctx context.Context, repoName, repoUrl string, number int, sourceBranch string) error
+	// GetFilesOnHead returns the files, relative to the repository root, as present on the head of the target revision.
+	// The repository is cloned once for all the files, the result is keyed by the file path.
+	GetFilesOnHead(ctx context.Context, gitOpsRepoName, repoUrl, targetRevision string, filePaths []string) (map[string]*bean.GitFileVersion, error)
 	PushChartToGitRepo(ctx context.Context, gitOpsRepoName, chartLocation, tempReferenceTemplateDir, repoUrl, targetRevision string, userId int32) (err error)
 	CloneChartForHelmApp(helmAppName, gitRepoUrl, targetRevision string) (string, error)
 	PushChartToGitOpsRepoForHelmApp(ctx context.Context, pushChartToGitRequest *bean.PushChartToGitRequestDTO, valuesConfig *ChartConfig) (*commonBean.ChartGitAttribute, string, error)
@@ -290,6 +299,120 @@ func (impl *GitOperationServiceImpl) CommitValues(ctx context.Context, chartGitA
 	return commitHash, commitTime, nil
 }
 
+func (impl *GitOperationServiceImpl) getPullRequestClient() (GitOpsPullRequestClient, error) {
+	pullRequestClient, ok := impl.gitFactory.Client.(GitOpsPullRequestClient)
+	if !ok {
+		return nil, util.NewApiError(http.StatusBadRequest, "pull requests are not supported for the configured gitops provider", "gitops provider does not implement pull requests")
+	}
+	return pullRequestClient, nil
+}
+
+func (impl *GitOperationServiceImpl) CommitValuesToPullRequest(ctx context.Context, chartGitAttr *ChartConfig, repoUrl, sourceBranch string) (*bean.PullRequest, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "gitOperationService.CommitValuesToPullRequest")
+	defer span.End()
+	pullRequestClient, err := impl.getPullRequestClient()
+	if err != nil {
+		return nil, err
+	}
+	targetBranch := chartGitAttr.TargetRevision
+	if len(targetBranch) == 0 {
+		targetBranch = globalUtil.GetDefaultTargetRevision()
+	}
+	clonedDir, err := impl.gitFactory.GitOpsHelper.Clone(repoUrl, fmt.Sprintf("%s-%s", chartGitAttr.ChartRepoName, getDir()), targetBranch)
+	defer impl.chartTemplateService.CleanDir(clonedDir)
+	if err != nil {
+		impl.logger.Errorw("error in cloning repo", "repoUrl", repoUrl, "err", err)
+		return nil, err
+	}
+	filePath := filepath.Join(clonedDir, chartGitAttr.ChartLocation, chartGitAttr.FileName)
+	if err = os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		impl.logger.Errorw("error in creating chart dir", "filePath", filePath, "err", err)
+		return nil, err
+	}
+	if err = os.WriteFile(filePath, []byte(chartGitAttr.FileContent), 0666); err != nil {
+		impl.logger.Errorw("error in writing values file", "filePath", filePath, "err", err)
+		return nil, err
+	}
+	_, err = impl.gitFactory.GitOpsHelper.CommitAndPushToBranch(newCtx, clonedDir, sourceBranch, chartGitAttr.ReleaseMessage, chartGitAttr.UserName, chartGitAttr.UserEmailId)
+	if err != nil {
+		impl.logger.Errorw("error in pushing values to pull request branch", "repoUrl", repoUrl, "sourceBranch", sourceBranch, "err", err)
+		return nil, err
+	}
+	pullRequest, err := pullRequestClient.CreatePullRequest(newCtx, &bean.PullRequestConfig{
+		RepoName:     chartGitAttr.ChartRepoName,
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+		Title:        chartGitAttr.ReleaseMessage,
+		Description:  fmt.Sprintf("Updates %s for %s. The deployment proceeds once this pull request is merged.", chartGitAttr.FileName, chartGitAttr.ChartName),
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating pull request", "repoName", chartGitAttr.ChartRepoName, "sourceBranch", sourceBranch, "err", err)
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+func (impl *GitOperationServiceImpl) GetPullRequest(ctx context.Context, repoName string, number int) (*bean.PullRequest, error) {
+	pullRequestClient, err := impl.getPullRequestClient()
+	if err != nil {
+		return nil, err
+	}
+	return pullRequestClient.GetPullRequest(ctx, repoName, number)
+}
+
+func (impl *GitOperationServiceImpl) ClosePullRequest(ctx context.Context, repoName, repoUrl string, number int, sourceBranch string) error {
+	pullRequestClient, err := impl.getPullRequestClient()
+	if err != nil {
+		return err
+	}
+	err = pullRequestClient.ClosePullRequest(ctx, repoName, number)
+	if err != nil {
+		impl.logger.Errorw("error in closing pull request", "repoName", repoName, "number", number, "err", err)
+		return err
+	}
+	err = impl.gitFactory.GitOpsHelper.DeleteBranch(ctx, repoUrl, fmt.Sprintf("%s-%s", repoName, getDir()), sourceBranch)
+	if err != nil {
+		impl.logger.Errorw("error in deleting pull request branch", "repoUrl", repoUrl, "sourceBranch", sourceBranch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *GitOperationServiceImpl) GetFilesOnHead(ctx context.Context, gitOpsRepoName, repoUrl, targetRevision string, filePaths []string) (map[string]*bean.GitFileVersion, error) {
+	_, span := otel.Tracer("orchestrator").Start(ctx, "gitOperationService.GetFilesOnHead")
+	defer span.End()
+	if len(targetRevision) == 0 {
+		targetRevision = globalUtil.GetDefaultTargetRevision()
+	}
+	clonedDir, err := impl.gitFactory.GitOpsHelper.Clone(repoUrl, fmt.Sprintf("%s-%s", gitOpsRepoName, getDir()), targetRevision)
+	defer impl.chartTemplateService.CleanDir(clonedDir)
+	if err != nil {
+		impl.logger.Errorw("error in cloning repo", "repoUrl", repoUrl, "err", err)
+		return nil, err
+	}
+	fileVersions := make(map[string]*bean.GitFileVersion, len(filePaths))
+	for _, filePath := range filePaths {
+		if _, ok := fileVersions[filePath]; ok {
+			continue
+		}
+		fileVersion := &bean.GitFileVersion{}
+		content, err := os.ReadFile(filepath.Join(clonedDir, filePath))
+		if err != nil && !os.IsNotExist(err) {
+			impl.logger.Errorw("error in reading file", "repoUrl", repoUrl, "filePath", filePath, "err", err)
+			return nil, err
+		} else if err == nil {
+			fileVersion.Content = string(content)
+			fileVersion.Exists = true
+		}
+		fileVersion.CommitHash, err = impl.gitFactory.GitOpsHelper.GetLastCommitForPath(clonedDir, filePath)
+		if err != nil {
+			return nil, err
+		}
+		fileVersions[filePath] = fileVersion
+	}
+	return fileVersions, nil
+}
+
 func (impl *GitOperationServiceImpl) isRetryableGitCommitError(err error) bool {
 	return retryFunc.IsRetryableError(err)
 }
@@ -630,6 +753,20 @@ func (impl *GitOperationServiceImpl) UpdateGitHostUrlByProvider(request *apiBean
 		}
 	case bean.BITBUCKET_PROVIDER:
 		request.Host = BITBUCKET_CLONE_BASE_URL + request.BitBucketWorkspaceId
+
+	case bean.GITEA_PROVIDER:
+		orgUrl, err := buildGithubOrgUrl(request.Host, request.GitHubOrgId)
+		if err != nil {
+			return err
+		}
+		request.Host = orgUrl
+
+	case bean.BITBUCKET_SERVER_PROVIDER:
+		projectUrl, err := buildGithubOrgUrl(request.Host, path.Join("scm", strings.ToLower(request.BitBucketProjectKey)))
+		if err != nil {
+			return err
+		}
+		request.Host = projectUrl
 	}
 	return nil
 }
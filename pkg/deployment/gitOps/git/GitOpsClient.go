@@ -40,6 +40,23 @@ type GitOpsClient interface {
 	CreateFirstCommitOnHead(ctx context.Context, config *gitOps.GitOpsConfigDto) (string, error)
 }
 
+// GitOpsPullRequestClient is implemented by the providers which support raising pull requests for gitops commits
+type GitOpsPullRequestClient interface {
+	CreatePullRequest(ctx context.Context, config *bean.PullRequestConfig) (*bean.PullRequest, error)
+	GetPullRequest(ctx context.Context, repoName string, number int) (*bean.PullRequest, error)
+	// ClosePullRequest closes (declines) the pull request without merging it
+	ClosePullRequest(ctx context.Context, repoName string, number int) error
+}
+
+// IsPullRequestSupported returns true for the providers whose client implements GitOpsPullRequestClient
+func IsPullRequestSupported(gitProvider string) bool {
+	switch gitProvider {
+	case bean.GITHUB_PROVIDER, bean.GITLAB_PROVIDER, bean.GITEA_PROVIDER, bean.BITBUCKET_SERVER_PROVIDER:
+		return true
+	}
+	return false
+}
+
 func GetGitConfigAll(gitOpsConfigReadService config.GitOpsConfigReadService) ([]*bean.GitConfig, error) {
 	gitOpsConfigs, err := gitOpsConfigReadService.GetAllGitOpsConfig()
 	if err != nil && err != pg.ErrNoRows {
@@ -49,7 +66,7 @@ func GetGitConfigAll(gitOpsConfigReadService config.GitOpsConfigReadService) ([]
 	}
 	cfgs := make([]*bean.GitConfig, 0, len(gitOpsConfigs))
 	for _, gitOpsConfig := range gitOpsConfigs {
-		cfgs = append(cfgs, &bean.GitConfig{
+		cfg := &bean.GitConfig{
 			GitlabGroupId:         gitOpsConfig.GitLabGroupId,
 			GitToken:              gitOpsConfig.Token,
 			GitUserName:           gitOpsConfig.Username,
@@ -66,7 +83,13 @@ func GetGitConfigAll(gitOpsConfigReadService config.GitOpsConfigReadService) ([]
 			TLSKey:                gitOpsConfig.TLSConfig.TLSKeyData,
 			EnableTLSVerification: gitOpsConfig.EnableTLSVerification,
 			AuthMode:              gitOpsConfig.AuthMode.ToInternalAuthMode(),
-		})
+		}
+		if !gitOpsConfig.SigningConfig.IsEmpty() {
+			cfg.SigningKeyType = gitOpsConfig.SigningConfig.KeyType
+			cfg.SigningKey = gitOpsConfig.SigningConfig.SigningKey
+			cfg.SigningKeyPassphrase = gitOpsConfig.SigningConfig.Passphrase
+		}
+		cfgs = append(cfgs, cfg)
 	}
 	return cfgs, nil
 }
@@ -95,6 +118,12 @@ func NewGitOpsClient(config *bean.GitConfig, logger *zap.SugaredLogger, gitOpsHe
 	} else if config.GitProvider == bean.BITBUCKET_PROVIDER {
 		gitBitbucketClient := NewGitBitbucketClient(config.GitUserName, config.GitToken, config.GitHost, logger, gitOpsHelper, tlsConfig, config.AuthMode)
 		return gitBitbucketClient, nil
+	} else if config.GitProvider == bean.GITEA_PROVIDER {
+		return NewGitGiteaClient(config.GitHost, config.GitToken, config.GithubOrganization, logger, gitOpsHelper, tlsConfig)
+	} else if config.GitProvider == bean.BITBUCKET_SERVER_PROVIDER {
+		return NewGitBitbucketServerClient(config.GitHost, config.GitUserName, config.GitToken, config.BitbucketProjectKey, logger, gitOpsHelper, tlsConfig, config.AuthMode)
+	} else if config.GitProvider == bean.GENERIC_GIT_PROVIDER {
+		return NewGenericGitClient(config.GitHost, logger, gitOpsHelper)
 	} else {
 		logger.Warn("no gitops config provided, gitops will not work")
 		return &UnimplementedGitOpsClient{}, nil
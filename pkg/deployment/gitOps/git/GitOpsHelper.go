@@ -42,6 +42,7 @@ type GitOpsHelper struct {
 	gitCommandManager git.GitCommandManager
 	tlsConfig         *bean.TLSConfig
 	isTlsEnabled      bool
+	signingKey        *git.SigningKey
 }
 
 func NewGitOpsHelperImpl(auth *git.BasicAuth, logger *zap.SugaredLogger, tlsConfig *bean.TLSConfig, isTlsEnabled bool) (*GitOpsHelper, error) {
@@ -59,6 +60,11 @@ func (impl *GitOpsHelper) SetAuth(auth *git.BasicAuth) {
 	impl.Auth = auth
 }
 
+// SetSigningKey sets the key used for signing the commits, commits are left unsigned if it is nil
+func (impl *GitOpsHelper) SetSigningKey(signingKey *git.SigningKey) {
+	impl.signingKey = signingKey
+}
+
 func (impl *GitOpsHelper) GetCloneDirectory(targetDir string) (clonedDir string) {
 	start := time.Now()
 	defer func() {
@@ -137,6 +143,17 @@ func (impl *GitOpsHelper) Pull(repoRoot, targetRevision string) (err error) {
 	return nil
 }
 
+// GetLastCommitForPath returns the hash of the last commit on the checked out revision which modified the path
+func (impl *GitOpsHelper) GetLastCommitForPath(repoRoot, path string) (commitHash string, err error) {
+	ctx := git.BuildGitContext(context.Background())
+	commitHash, errMsg, err := impl.gitCommandManager.GetLastCommitForPath(ctx, repoRoot, path)
+	if err != nil {
+		impl.logger.Errorw("error in getting last commit for path", "repoRoot", repoRoot, "path", path, "errMsg", errMsg, "err", err)
+		return "", fmt.Errorf("error in getting last commit for %s: %s %v", path, errMsg, err)
+	}
+	return commitHash, nil
+}
+
 const PushErrorMessage = "failed to push some refs"
 
 func (impl *GitOpsHelper) CommitAndPushAllChanges(ctx context.Context, repoRoot, targetRevision, commitMsg, name, emailId string) (commitHash string, err error) {
@@ -147,7 +164,8 @@ func (impl *GitOpsHelper) CommitAndPushAllChanges(ctx context.Context, repoRoot,
 		span.End()
 	}()
 	gitCtx := git.BuildGitContext(newCtx).WithCredentials(impl.Auth).
-		WithTLSData(impl.tlsConfig.CaData, impl.tlsConfig.TLSKeyData, impl.tlsConfig.TLSCertData, impl.isTlsEnabled)
+		WithTLSData(impl.tlsConfig.CaData, impl.tlsConfig.TLSKeyData, impl.tlsConfig.TLSCertData, impl.isTlsEnabled).
+		WithSigningKey(impl.signingKey)
 	commitHash, err = impl.gitCommandManager.CommitAndPush(gitCtx, repoRoot, targetRevision, commitMsg, name, emailId)
 	if err != nil && strings.Contains(err.Error(), PushErrorMessage) {
 		return commitHash, fmt.Errorf("%s %v", "push failed due to conflicts", err)
@@ -155,6 +173,53 @@ func (impl *GitOpsHelper) CommitAndPushAllChanges(ctx context.Context, repoRoot,
 	return commitHash, nil
 }
 
+// CommitAndPushToBranch pushes the changes committed on the checked out revision to a separate branch, the target revision is left untouched
+func (impl *GitOpsHelper) CommitAndPushToBranch(ctx context.Context, repoRoot, branch, commitMsg, name, emailId string) (commitHash string, err error) {
+	start := time.Now()
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsHelper.CommitAndPushToBranch")
+	defer func() {
+		util.TriggerGitOpsMetrics("CommitAndPushToBranch", "GitService", start, err)
+		span.End()
+	}()
+	gitCtx := git.BuildGitContext(newCtx).WithCredentials(impl.Auth).
+		WithTLSData(impl.tlsConfig.CaData, impl.tlsConfig.TLSKeyData, impl.tlsConfig.TLSCertData, impl.isTlsEnabled).
+		WithSigningKey(impl.signingKey)
+	return impl.gitCommandManager.CommitAndPushToBranch(gitCtx, repoRoot, branch, commitMsg, name, emailId)
+}
+
+// DeleteRemoteBranch deletes the branch on origin of the cloned repository
+func (impl *GitOpsHelper) DeleteRemoteBranch(ctx context.Context, repoRoot, branch string) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteRemoteBranch", "GitService", start, err)
+	}()
+	gitCtx := git.BuildGitContext(ctx).WithCredentials(impl.Auth).
+		WithTLSData(impl.tlsConfig.CaData, impl.tlsConfig.TLSKeyData, impl.tlsConfig.TLSCertData, impl.isTlsEnabled)
+	return impl.gitCommandManager.DeleteRemoteBranch(gitCtx, repoRoot, branch)
+}
+
+// DeleteBranch deletes the branch of the remote repository, the repository is not fetched for it
+func (impl *GitOpsHelper) DeleteBranch(ctx context.Context, repoUrl, targetDir, branch string) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteBranch", "GitService", start, err)
+	}()
+	gitCtx := git.BuildGitContext(ctx).WithCredentials(impl.Auth).
+		WithTLSData(impl.tlsConfig.CaData, impl.tlsConfig.TLSKeyData, impl.tlsConfig.TLSCertData, impl.isTlsEnabled)
+	rootDir := filepath.Join(bean2.GIT_WORKING_DIR, targetDir)
+	defer func() {
+		if cleanUpErr := os.RemoveAll(rootDir); cleanUpErr != nil {
+			impl.logger.Errorw("error cleaning work path for git-ops", "rootDir", rootDir, "err", cleanUpErr)
+		}
+	}()
+	err = impl.init(gitCtx, rootDir, repoUrl, false)
+	if err != nil {
+		impl.logger.Errorw("error in initialising repo for branch deletion", "repoUrl", repoUrl, "err", err)
+		return err
+	}
+	return impl.gitCommandManager.DeleteRemoteBranch(gitCtx, rootDir, branch)
+}
+
 func (impl *GitOpsHelper) pullFromBranch(ctx git.GitContext, rootDir, branch string) (string, string, error) {
 	start := time.Now()
 	response, errMsg, err := impl.gitCommandManager.PullCli(ctx, rootDir, branch)
@@ -0,0 +1,361 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/util"
+	"go.uber.org/zap"
+)
+
+const BITBUCKET_SERVER_API_BASE_PATH = "rest/api/1.0"
+
+// GitBitbucketServerClient is the client for self-hosted Bitbucket Server / Data Center,
+// which exposes a different rest api than Bitbucket Cloud.
+type GitBitbucketServerClient struct {
+	httpClient   *http.Client
+	host         string
+	username     string
+	token        string
+	projectKey   string
+	authMode     constants.AuthMode
+	logger       *zap.SugaredLogger
+	gitOpsHelper *GitOpsHelper
+}
+
+type bitbucketServerCreateRepoRequest struct {
+	Name          string `json:"name"`
+	ScmId         string `json:"scmId"`
+	Forkable      bool   `json:"forkable"`
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+}
+
+type bitbucketServerBranches struct {
+	Size int `json:"size"`
+}
+
+type bitbucketServerRef struct {
+	Id string `json:"id"`
+}
+
+type bitbucketServerCreatePullRequestRequest struct {
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	FromRef     bitbucketServerRef `json:"fromRef"`
+	ToRef       bitbucketServerRef `json:"toRef"`
+}
+
+type bitbucketServerPullRequest struct {
+	Id      int                `json:"id"`
+	Version int                `json:"version"`
+	State   string             `json:"state"`
+	ToRef   bitbucketServerRef `json:"toRef"`
+	Links   struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+	Properties struct {
+		MergeCommit *bitbucketServerRef `json:"mergeCommit"`
+	} `json:"properties"`
+}
+
+type bitbucketServerCommits struct {
+	Values []bitbucketServerRef `json:"values"`
+}
+
+func NewGitBitbucketServerClient(host, username, token, projectKey string, logger *zap.SugaredLogger, gitOpsHelper *GitOpsHelper, tlsConfig *tls.Config, authMode constants.AuthMode) (GitOpsClient, error) {
+	if _, err := url.ParseRequestURI(host); err != nil {
+		logger.Errorw("invalid bitbucket server host", "host", host, "err", err)
+		return nil, err
+	}
+	if len(projectKey) == 0 {
+		return nil, fmt.Errorf("no bitbucket server project key found")
+	}
+	return &GitBitbucketServerClient{
+		httpClient:   util.GetHTTPClientWithTLSConfig(tlsConfig),
+		host:         strings.TrimSuffix(host, "/"),
+		username:     username,
+		token:        token,
+		projectKey:   strings.ToUpper(projectKey),
+		authMode:     authMode,
+		logger:       logger,
+		gitOpsHelper: gitOpsHelper,
+	}, nil
+}
+
+// getRepoSlug bitbucket server always derives a lower case slug from the repository name
+func getRepoSlug(repoName string) string {
+	return strings.ToLower(repoName)
+}
+
+func (impl *GitBitbucketServerClient) getRepoUrl(repoName string) string {
+	return fmt.Sprintf("%s/scm/%s/%s.git", impl.host, strings.ToLower(impl.projectKey), getRepoSlug(repoName))
+}
+
+func (impl *GitBitbucketServerClient) getRepoApiPath(repoName string) string {
+	return fmt.Sprintf("projects/%s/repos/%s", url.PathEscape(impl.projectKey), url.PathEscape(getRepoSlug(repoName)))
+}
+
+// doRequest calls the bitbucket server rest api and decodes the response in result if provided.
+// It returns the response status code so that callers can handle not found cases.
+func (impl *GitBitbucketServerClient) doRequest(ctx context.Context, method, apiPath string, body, result interface{}) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s/%s", impl.host, BITBUCKET_SERVER_API_BASE_PATH, apiPath), reqBody)
+	if err != nil {
+		return 0, err
+	}
+	if impl.authMode == constants.AUTH_MODE_ACCESS_TOKEN {
+		// HTTP access tokens authenticate against the REST API with a Bearer header.
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impl.token))
+	} else {
+		req.SetBasicAuth(impl.username, impl.token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := impl.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("bitbucket server api %s %s failed with status %d: %s", method, apiPath, resp.StatusCode, string(respBody))
+	}
+	if result != nil && len(respBody) > 0 {
+		if err = json.Unmarshal(respBody, result); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func (impl *GitBitbucketServerClient) repoExists(ctx context.Context, repoName string) (exists bool, isEmpty bool, err error) {
+	statusCode, err := impl.doRequest(ctx, http.MethodGet, impl.getRepoApiPath(repoName), nil, nil)
+	if statusCode == http.StatusNotFound {
+		return false, isEmpty, nil
+	} else if err != nil {
+		return false, isEmpty, err
+	}
+	branches := &bitbucketServerBranches{}
+	_, err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/branches?limit=1", impl.getRepoApiPath(repoName)), nil, branches)
+	if err != nil {
+		return true, isEmpty, err
+	}
+	return true, branches.Size == 0, nil
+}
+
+func (impl *GitBitbucketServerClient) DeleteRepository(config *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteRepository", "GitBitbucketServerClient", start, err)
+	}()
+	_, err = impl.doRequest(context.Background(), http.MethodDelete, impl.getRepoApiPath(config.GitRepoName), nil, nil)
+	if err != nil {
+		impl.logger.Errorw("error in deleting repo bitbucket server", "repoName", config.GitRepoName, "err", err)
+	}
+	return err
+}
+
+func (impl *GitBitbucketServerClient) GetRepoUrl(config *bean2.GitOpsConfigDto) (repoUrl string, isRepoEmpty bool, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetRepoUrl", "GitBitbucketServerClient", start, err)
+	}()
+	exists, isRepoEmpty, err := impl.repoExists(context.Background(), config.GitRepoName)
+	if err != nil {
+		impl.logger.Errorw("error in getting repo bitbucket server", "repoName", config.GitRepoName, "err", err)
+		return "", isRepoEmpty, err
+	} else if !exists {
+		return "", isRepoEmpty, nil
+	}
+	return impl.getRepoUrl(config.GitRepoName), isRepoEmpty, nil
+}
+
+func (impl *GitBitbucketServerClient) CreateRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (url string, isNew bool, isEmpty bool, detailedErrorGitOpsConfigActions DetailedErrorGitOpsConfigActions) {
+	var err error
+	start := time.Now()
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+
+	repoUrl, isEmpty, err := impl.GetRepoUrl(config)
+	if err != nil {
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.GetRepoUrlStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitBitbucketServerClient", start, err)
+		return "", false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	if len(repoUrl) > 0 {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.GetRepoUrlStage)
+		util.TriggerGitOpsMetrics("CreateRepository", "GitBitbucketServerClient", start, nil)
+		return repoUrl, false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	createRequest := &bitbucketServerCreateRepoRequest{
+		Name:          config.GitRepoName,
+		ScmId:         "git",
+		Forkable:      false,
+		DefaultBranch: config.TargetRevision,
+	}
+	_, err = impl.doRequest(ctx, http.MethodPost, fmt.Sprintf("projects/%s/repos", impl.projectKey), createRequest, nil)
+	if err != nil {
+		impl.logger.Errorw("error in creating repo bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateRepoStage] = err
+		repoUrl, isEmpty, err = impl.GetRepoUrl(config)
+		if err != nil || len(repoUrl) == 0 {
+			util.TriggerGitOpsMetrics("CreateRepository", "GitBitbucketServerClient", start, err)
+			return "", true, isEmpty, detailedErrorGitOpsConfigActions
+		}
+	}
+	repoUrl = impl.getRepoUrl(config.GitRepoName)
+	impl.logger.Infow("repo created", "repoUrl", repoUrl)
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CreateRepoStage)
+
+	_, err = impl.CreateReadme(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme bitbucket server", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateReadmeStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitBitbucketServerClient", start, err)
+		return "", true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	isEmpty = false //As we have created readme, repo is no longer empty
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CloneHttpStage, bean.CreateReadmeStage)
+	util.TriggerGitOpsMetrics("CreateRepository", "GitBitbucketServerClient", start, nil)
+	return repoUrl, true, isEmpty, detailedErrorGitOpsConfigActions
+}
+
+func (impl *GitBitbucketServerClient) CreateFirstCommitOnHead(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	return impl.CreateReadme(ctx, config)
+}
+
+func (impl *GitBitbucketServerClient) CreateReadme(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	hash, _, err := impl.CommitValues(ctx, getReadmeChartConfig(config), config, true)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme bitbucket server", "repo", config.GitRepoName, "err", err)
+	}
+	return hash, err
+}
+
+func (impl *GitBitbucketServerClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto, publishStatusConflictError bool) (commitHash string, commitTime time.Time, err error) {
+	return commitValuesOverGit(ctx, impl.gitOpsHelper, impl.logger, impl.getRepoUrl(config.ChartRepoName), config, publishStatusConflictError, "GitBitbucketServerClient")
+}
+
+func (impl *GitBitbucketServerClient) CreatePullRequest(ctx context.Context, config *bean.PullRequestConfig) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitBitbucketServerClient", start, err)
+	}()
+	request := &bitbucketServerCreatePullRequestRequest{
+		Title:       config.Title,
+		Description: config.Description,
+		FromRef:     bitbucketServerRef{Id: fmt.Sprintf("refs/heads/%s", config.SourceBranch)},
+		ToRef:       bitbucketServerRef{Id: fmt.Sprintf("refs/heads/%s", config.TargetBranch)},
+	}
+	pr := &bitbucketServerPullRequest{}
+	_, err = impl.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/pull-requests", impl.getRepoApiPath(config.RepoName)), request, pr)
+	if err != nil {
+		impl.logger.Errorw("error in creating bitbucket server pull request", "repo", config.RepoName, "sourceBranch", config.SourceBranch, "err", err)
+		return nil, err
+	}
+	return pr.toPullRequest(), nil
+}
+
+func (impl *GitBitbucketServerClient) GetPullRequest(ctx context.Context, repoName string, number int) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitBitbucketServerClient", start, err)
+	}()
+	pr := &bitbucketServerPullRequest{}
+	_, err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests/%d", impl.getRepoApiPath(repoName), number), nil, pr)
+	if err != nil {
+		impl.logger.Errorw("error in getting bitbucket server pull request", "repo", repoName, "number", number, "err", err)
+		return nil, err
+	}
+	pullRequest = pr.toPullRequest()
+	if pullRequest.State == bean.PullRequestMerged && len(pullRequest.MergeCommitHash) == 0 {
+		// older bitbucket server versions do not return the merge commit, the head of the target branch is used instead
+		commits := &bitbucketServerCommits{}
+		_, err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/commits?until=%s&limit=1", impl.getRepoApiPath(repoName), url.QueryEscape(pr.ToRef.Id)), nil, commits)
+		if err != nil {
+			impl.logger.Errorw("error in getting bitbucket server target branch head", "repo", repoName, "toRef", pr.ToRef.Id, "err", err)
+			return nil, err
+		}
+		if len(commits.Values) > 0 {
+			pullRequest.MergeCommitHash = commits.Values[0].Id
+		}
+	}
+	return pullRequest, nil
+}
+
+func (impl *GitBitbucketServerClient) ClosePullRequest(ctx context.Context, repoName string, number int) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("ClosePullRequest", "GitBitbucketServerClient", start, err)
+	}()
+	// bitbucket server requires the current version of the pull request to decline it
+	pr := &bitbucketServerPullRequest{}
+	_, err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("%s/pull-requests/%d", impl.getRepoApiPath(repoName), number), nil, pr)
+	if err != nil {
+		impl.logger.Errorw("error in getting bitbucket server pull request", "repo", repoName, "number", number, "err", err)
+		return err
+	}
+	_, err = impl.doRequest(ctx, http.MethodPost, fmt.Sprintf("%s/pull-requests/%d/decline?version=%d", impl.getRepoApiPath(repoName), number, pr.Version), nil, nil)
+	if err != nil {
+		impl.logger.Errorw("error in declining bitbucket server pull request", "repo", repoName, "number", number, "err", err)
+	}
+	return err
+}
+
+func (pr *bitbucketServerPullRequest) toPullRequest() *bean.PullRequest {
+	pullRequest := &bean.PullRequest{
+		Number: pr.Id,
+		State:  bean.PullRequestOpen,
+	}
+	if len(pr.Links.Self) > 0 {
+		pullRequest.Url = pr.Links.Self[0].Href
+	}
+	switch pr.State {
+	case "MERGED":
+		pullRequest.State = bean.PullRequestMerged
+		if pr.Properties.MergeCommit != nil {
+			pullRequest.MergeCommitHash = pr.Properties.MergeCommit.Id
+		}
+	case "DECLINED":
+		pullRequest.State = bean.PullRequestClosed
+	}
+	return pullRequest
+}
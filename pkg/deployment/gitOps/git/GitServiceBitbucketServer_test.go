@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+const bitbucketServerTestRepoPath = "/rest/api/1.0/projects/DEVTRON/repos/gitops-repo"
+
+func newBitbucketServerTestServer(t *testing.T, assertAuth func(r *http.Request), routes map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertAuth(r)
+		route := r.Method + " " + r.URL.Path
+		if len(r.URL.RawQuery) > 0 {
+			route += "?" + r.URL.RawQuery
+		}
+		response, ok := routes[route]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func newBitbucketServerTestClient(t *testing.T, host string, authMode constants.AuthMode) *GitBitbucketServerClient {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	client, err := NewGitBitbucketServerClient(host, "devtron-user", "bbs-token", "devtron", logger, nil, nil, authMode)
+	assert.NoError(t, err)
+	return client.(*GitBitbucketServerClient)
+}
+
+func newBitbucketServerPullRequest(id int, state string, mergeCommit string) bitbucketServerPullRequest {
+	pr := bitbucketServerPullRequest{Id: id, State: state, ToRef: bitbucketServerRef{Id: "refs/heads/master"}}
+	if len(mergeCommit) > 0 {
+		pr.Properties.MergeCommit = &bitbucketServerRef{Id: mergeCommit}
+	}
+	return pr
+}
+
+func TestGitBitbucketServerClient(t *testing.T) {
+	routes := map[string]interface{}{
+		"GET " + bitbucketServerTestRepoPath:                                                  struct{}{},
+		"GET " + bitbucketServerTestRepoPath + "/branches?limit=1":                            bitbucketServerBranches{Size: 1},
+		"GET " + bitbucketServerTestRepoPath + "/pull-requests/4":                             bitbucketServerPullRequest{Id: 4, Version: 3, State: "OPEN"},
+		"POST " + bitbucketServerTestRepoPath + "/pull-requests/4/decline?version=3":          bitbucketServerPullRequest{Id: 4, Version: 4, State: "DECLINED"},
+		"GET " + bitbucketServerTestRepoPath + "/pull-requests/5":                             newBitbucketServerPullRequest(5, "MERGED", "abc123"),
+		"GET " + bitbucketServerTestRepoPath + "/pull-requests/6":                             newBitbucketServerPullRequest(6, "MERGED", ""),
+		"GET " + bitbucketServerTestRepoPath + "/pull-requests/7":                             newBitbucketServerPullRequest(7, "DECLINED", ""),
+		"GET " + bitbucketServerTestRepoPath + "/commits?until=refs%2Fheads%2Fmaster&limit=1": bitbucketServerCommits{Values: []bitbucketServerRef{{Id: "def456"}}},
+	}
+
+	t.Run("access token is sent as bearer", func(t *testing.T) {
+		server := newBitbucketServerTestServer(t, func(r *http.Request) {
+			assert.Equal(t, "Bearer bbs-token", r.Header.Get("Authorization"))
+		}, routes)
+		defer server.Close()
+		client := newBitbucketServerTestClient(t, server.URL, constants.AUTH_MODE_ACCESS_TOKEN)
+
+		repoUrl, isRepoEmpty, err := client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "GitOps-Repo"})
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL+"/scm/devtron/gitops-repo.git", repoUrl)
+		assert.False(t, isRepoEmpty)
+	})
+
+	t.Run("password is sent with basic auth", func(t *testing.T) {
+		server := newBitbucketServerTestServer(t, func(r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "devtron-user", username)
+			assert.Equal(t, "bbs-token", password)
+		}, routes)
+		defer server.Close()
+		client := newBitbucketServerTestClient(t, server.URL, constants.AUTH_MODE_USERNAME_PASSWORD)
+
+		repoUrl, _, err := client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "gitops-repo"})
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL+"/scm/devtron/gitops-repo.git", repoUrl)
+
+		repoUrl, _, err = client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "missing-repo"})
+		assert.NoError(t, err)
+		assert.Empty(t, repoUrl)
+	})
+
+	t.Run("pull request states", func(t *testing.T) {
+		server := newBitbucketServerTestServer(t, func(r *http.Request) {}, routes)
+		defer server.Close()
+		client := newBitbucketServerTestClient(t, server.URL, constants.AUTH_MODE_ACCESS_TOKEN)
+
+		pullRequest, err := client.GetPullRequest(context.Background(), "gitops-repo", 4)
+		assert.NoError(t, err)
+		assert.Equal(t, bean.PullRequestOpen, pullRequest.State)
+
+		pullRequest, err = client.GetPullRequest(context.Background(), "gitops-repo", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, bean.PullRequestMerged, pullRequest.State)
+		assert.Equal(t, "abc123", pullRequest.MergeCommitHash)
+
+		// merge commit is not returned by older versions, head of the target branch is used
+		pullRequest, err = client.GetPullRequest(context.Background(), "gitops-repo", 6)
+		assert.NoError(t, err)
+		assert.Equal(t, bean.PullRequestMerged, pullRequest.State)
+		assert.Equal(t, "def456", pullRequest.MergeCommitHash)
+
+		pullRequest, err = client.GetPullRequest(context.Background(), "gitops-repo", 7)
+		assert.NoError(t, err)
+		assert.Equal(t, bean.PullRequestClosed, pullRequest.State)
+	})
+
+	t.Run("pull request is declined with its current version", func(t *testing.T) {
+		server := newBitbucketServerTestServer(t, func(r *http.Request) {}, routes)
+		defer server.Close()
+		client := newBitbucketServerTestClient(t, server.URL, constants.AUTH_MODE_ACCESS_TOKEN)
+
+		assert.NoError(t, client.ClosePullRequest(context.Background(), "gitops-repo", 4))
+		// decline is rejected for a stale version
+		assert.Error(t, client.ClosePullRequest(context.Background(), "gitops-repo", 5))
+	})
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devtron-labs/common-lib/utils/retryFunc"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/util"
+	"go.uber.org/zap"
+)
+
+// GenericGitClient works with any git server reachable over HTTP(S).
+// It never calls provider specific repository APIs: repositories must be pre-created
+// under the configured host and all writes are done with plain git using the push credentials.
+// ssh hosts are not supported as gitops operations authenticate with basic auth only.
+type GenericGitClient struct {
+	host         string
+	logger       *zap.SugaredLogger
+	gitOpsHelper *GitOpsHelper
+}
+
+func NewGenericGitClient(host string, logger *zap.SugaredLogger, gitOpsHelper *GitOpsHelper) (GitOpsClient, error) {
+	if !strings.HasPrefix(host, HTTP_URL_PROTOCOL) && !strings.HasPrefix(host, HTTPS_URL_PROTOCOL) {
+		return nil, fmt.Errorf("invalid host url '%s', only http(s) hosts are supported for generic git provider", host)
+	}
+	return &GenericGitClient{
+		host:         strings.TrimSuffix(host, "/"),
+		logger:       logger,
+		gitOpsHelper: gitOpsHelper,
+	}, nil
+}
+
+func (impl *GenericGitClient) getRepoUrl(repoName string) string {
+	return fmt.Sprintf("%s/%s.git", impl.host, repoName)
+}
+
+// DeleteRepository is a no-op as repositories are owned by the git server admins
+func (impl *GenericGitClient) DeleteRepository(config *bean2.GitOpsConfigDto) error {
+	impl.logger.Infow("skipping repository deletion for generic git provider", "repoName", config.GitRepoName)
+	return nil
+}
+
+func (impl *GenericGitClient) GetRepoUrl(config *bean2.GitOpsConfigDto) (repoUrl string, isRepoEmpty bool, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetRepoUrl", "GenericGitClient", start, err)
+	}()
+	repoUrl = impl.getRepoUrl(config.GitRepoName)
+	clonedDir, err := impl.gitOpsHelper.Clone(repoUrl, fmt.Sprintf("/ensure-clone/%s", config.GitRepoName), config.TargetRevision)
+	if err != nil {
+		impl.logger.Errorw("error in reaching pre-created repository", "repoUrl", repoUrl, "err", err)
+		return "", isRepoEmpty, err
+	}
+	defer impl.cleanUp(clonedDir)
+	isRepoEmpty, err = isClonedRepoEmpty(clonedDir)
+	if err != nil {
+		impl.logger.Errorw("error in reading cloned repository", "clonedDir", clonedDir, "err", err)
+		return "", isRepoEmpty, err
+	}
+	return repoUrl, isRepoEmpty, nil
+}
+
+func (impl *GenericGitClient) cleanUp(cloneDir string) {
+	err := os.RemoveAll(cloneDir)
+	if err != nil {
+		impl.logger.Errorw("error cleaning work path for git-ops", "cloneDir", cloneDir, "err", err)
+	}
+}
+
+// isClonedRepoEmpty returns true if nothing apart from the .git directory is present after clone
+func isClonedRepoEmpty(clonedDir string) (bool, error) {
+	entries, err := os.ReadDir(clonedDir)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.Name() != ".git" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// CreateRepository does not create anything, it only verifies that the pre-created repository
+// is reachable with the configured credentials. An empty repository is initialised with a readme.
+func (impl *GenericGitClient) CreateRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (url string, isNew bool, isEmpty bool, detailedErrorGitOpsConfigActions DetailedErrorGitOpsConfigActions) {
+	var err error
+	start := time.Now()
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+
+	repoUrl, isEmpty, err := impl.GetRepoUrl(config)
+	if err != nil {
+		err = fmt.Errorf("repository %s must be pre-created for generic git provider: %v", impl.getRepoUrl(config.GitRepoName), err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.GetRepoUrlStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GenericGitClient", start, err)
+		return "", false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.GetRepoUrlStage, bean.CloneHttpStage)
+	if !isEmpty {
+		util.TriggerGitOpsMetrics("CreateRepository", "GenericGitClient", start, nil)
+		return repoUrl, false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+
+	_, err = impl.CreateReadme(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme generic git", "repoUrl", repoUrl, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateReadmeStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GenericGitClient", start, err)
+		return "", false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	isEmpty = false //As we have created readme, repo is no longer empty
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CreateReadmeStage)
+	util.TriggerGitOpsMetrics("CreateRepository", "GenericGitClient", start, nil)
+	return repoUrl, false, isEmpty, detailedErrorGitOpsConfigActions
+}
+
+func (impl *GenericGitClient) CreateFirstCommitOnHead(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	return impl.CreateReadme(ctx, config)
+}
+
+func (impl *GenericGitClient) CreateReadme(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	hash, _, err := impl.CommitValues(ctx, getReadmeChartConfig(config), config, true)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme generic git", "repo", config.GitRepoName, "err", err)
+	}
+	return hash, err
+}
+
+func (impl *GenericGitClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto, publishStatusConflictError bool) (commitHash string, commitTime time.Time, err error) {
+	return commitValuesOverGit(ctx, impl.gitOpsHelper, impl.logger, impl.getRepoUrl(config.ChartRepoName), config, publishStatusConflictError, "GenericGitClient")
+}
+
+func getReadmeChartConfig(config *bean2.GitOpsConfigDto) *ChartConfig {
+	return &ChartConfig{
+		ChartName:      config.GitRepoName,
+		ChartLocation:  "",
+		FileName:       "README.md",
+		FileContent:    "@devtron",
+		ReleaseMessage: "pushing readme",
+		ChartRepoName:  config.GitRepoName,
+		TargetRevision: config.TargetRevision,
+		UserName:       config.Username,
+		UserEmailId:    config.UserEmailId,
+	}
+}
+
+// commitValuesOverGit clones the repository, writes the chart file and pushes it with the
+// helper credentials. It is used by the providers which do not expose a file commit api to devtron.
+func commitValuesOverGit(ctx context.Context, gitOpsHelper *GitOpsHelper, logger *zap.SugaredLogger, repoUrl string, config *ChartConfig,
+	publishStatusConflictError bool, clientName string) (commitHash string, commitTime time.Time, err error) {
+	start := time.Now()
+	branch := config.TargetRevision
+	if len(branch) == 0 || config.UseDefaultBranch {
+		branch = util.GetDefaultTargetRevision()
+	}
+	clonedDir, err := gitOpsHelper.Clone(repoUrl, fmt.Sprintf("%s-%s", config.ChartRepoName, getDir()), branch)
+	defer func() {
+		if len(clonedDir) == 0 {
+			return
+		}
+		if cleanUpErr := os.RemoveAll(clonedDir); cleanUpErr != nil {
+			logger.Errorw("error cleaning work path for git-ops", "clonedDir", clonedDir, "err", cleanUpErr)
+		}
+	}()
+	if err != nil {
+		logger.Errorw("error in cloning repo", "repoUrl", repoUrl, "err", err)
+		util.TriggerGitOpsMetrics("CommitValues", clientName, start, err)
+		return "", time.Time{}, err
+	}
+	filePath := filepath.Join(clonedDir, config.ChartLocation, config.FileName)
+	if err = os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		logger.Errorw("error in creating chart dir", "filePath", filePath, "err", err)
+		util.TriggerGitOpsMetrics("CommitValues", clientName, start, err)
+		return "", time.Time{}, err
+	}
+	if err = os.WriteFile(filePath, []byte(config.FileContent), 0666); err != nil {
+		logger.Errorw("error in writing commit file", "filePath", filePath, "err", err)
+		util.TriggerGitOpsMetrics("CommitValues", clientName, start, err)
+		return "", time.Time{}, err
+	}
+	commitHash, err = gitOpsHelper.CommitAndPushAllChanges(ctx, clonedDir, branch, config.ReleaseMessage, config.UserName, config.UserEmailId)
+	if err != nil {
+		logger.Errorw("error in commit and push", "repoUrl", repoUrl, "branch", branch, "err", err)
+		if strings.Contains(err.Error(), PushErrorMessage) {
+			if publishStatusConflictError {
+				util.TriggerGitOpsMetrics("CommitValues", clientName, start, err)
+			}
+			return "", time.Time{}, retryFunc.NewRetryableError(err)
+		}
+		util.TriggerGitOpsMetrics("CommitValues", clientName, start, err)
+		return "", time.Time{}, err
+	}
+	util.TriggerGitOpsMetrics("CommitValues", clientName, start, nil)
+	return commitHash, time.Now(), nil
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/commandManager"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+func TestNewGenericGitClient(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+
+	t.Run("ssh host is rejected", func(t *testing.T) {
+		_, err := NewGenericGitClient("ssh://git@git.example.com/devtron", logger, nil)
+		assert.Error(t, err)
+		_, err = NewGenericGitClient("git@git.example.com:devtron", logger, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("http host is accepted", func(t *testing.T) {
+		client, err := NewGenericGitClient("https://git.example.com/devtron/", logger, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://git.example.com/devtron/gitops-repo.git", client.(*GenericGitClient).getRepoUrl("gitops-repo"))
+	})
+}
+
+func TestGenericGitClient_GetRepoUrl(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not found")
+	}
+	t.Setenv("USE_GIT_CLI", "true")
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	gitOpsHelper, err := NewGitOpsHelperImpl(&commandManager.BasicAuth{Username: "devtron"}, logger, &apiBean.TLSConfig{}, false)
+	assert.NoError(t, err)
+	// a local bare repository stands in for the pre-created repository on the git server
+	host := t.TempDir()
+	repoName := "gitops-" + rand.String(5)
+	err = exec.Command("git", "init", "--bare", "--initial-branch=master", filepath.Join(host, repoName+".git")).Run()
+	assert.NoError(t, err)
+	client := &GenericGitClient{host: host, logger: logger, gitOpsHelper: gitOpsHelper}
+	config := &bean2.GitOpsConfigDto{GitRepoName: repoName, TargetRevision: "master", Username: "devtron", UserEmailId: "devtron@example.com"}
+
+	repoUrl, isRepoEmpty, err := client.GetRepoUrl(config)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(host, repoName+".git"), repoUrl)
+	assert.True(t, isRepoEmpty)
+
+	_, _, _, detailedError := client.CreateRepository(context.Background(), config)
+	assert.Empty(t, detailedError.StageErrorMap)
+
+	_, isRepoEmpty, err = client.GetRepoUrl(config)
+	assert.NoError(t, err)
+	assert.False(t, isRepoEmpty)
+
+	_, _, err = client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "missing-repo", TargetRevision: "master"})
+	assert.Error(t, err)
+
+	t.Run("dry run branch is pushed and deleted", func(t *testing.T) {
+		clonedDir, err := gitOpsHelper.Clone(repoUrl, "dry-run-"+repoName, "master")
+		assert.NoError(t, err)
+		defer os.RemoveAll(clonedDir)
+		err = os.WriteFile(filepath.Join(clonedDir, "dryrun.txt"), []byte("dry run"), 0666)
+		assert.NoError(t, err)
+		_, err = gitOpsHelper.CommitAndPushToBranch(context.Background(), clonedDir, "devtron-dryrun-1", "dry run", "devtron", "devtron@example.com")
+		assert.NoError(t, err)
+		assert.NoError(t, exec.Command("git", "-C", repoUrl, "rev-parse", "--verify", "refs/heads/devtron-dryrun-1").Run())
+
+		err = gitOpsHelper.DeleteRemoteBranch(context.Background(), clonedDir, "devtron-dryrun-1")
+		assert.NoError(t, err)
+		assert.Error(t, exec.Command("git", "-C", repoUrl, "rev-parse", "--verify", "refs/heads/devtron-dryrun-1").Run())
+	})
+
+	t.Run("branch is deleted without cloning the repository", func(t *testing.T) {
+		assert.NoError(t, exec.Command("git", "-C", repoUrl, "branch", "devtron/release-1-env-2", "master").Run())
+
+		err := gitOpsHelper.DeleteBranch(context.Background(), repoUrl, "delete-branch-"+repoName, "devtron/release-1-env-2")
+		assert.NoError(t, err)
+		assert.Error(t, exec.Command("git", "-C", repoUrl, "rev-parse", "--verify", "refs/heads/devtron/release-1-env-2").Run())
+	})
+}
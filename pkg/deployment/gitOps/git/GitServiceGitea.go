@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/util"
+	"go.uber.org/zap"
+)
+
+const GITEA_API_BASE_PATH = "api/v1"
+
+type GitGiteaClient struct {
+	httpClient   *http.Client
+	host         string
+	token        string
+	org          string
+	logger       *zap.SugaredLogger
+	gitOpsHelper *GitOpsHelper
+}
+
+type giteaRepository struct {
+	Name     string `json:"name"`
+	CloneUrl string `json:"clone_url"`
+	Empty    bool   `json:"empty"`
+}
+
+type giteaCreateRepoRequest struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Private       bool   `json:"private"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+}
+
+type giteaPullRequest struct {
+	Number         int    `json:"number"`
+	HtmlUrl        string `json:"html_url"`
+	State          string `json:"state"`
+	Merged         bool   `json:"merged"`
+	MergeCommitSha string `json:"merge_commit_sha"`
+}
+
+type giteaEditPullRequestRequest struct {
+	State string `json:"state"`
+}
+
+type giteaCreatePullRequestRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+}
+
+func NewGitGiteaClient(host, token, org string, logger *zap.SugaredLogger, gitOpsHelper *GitOpsHelper, tlsConfig *tls.Config) (GitOpsClient, error) {
+	if _, err := url.ParseRequestURI(host); err != nil {
+		logger.Errorw("invalid gitea host", "host", host, "err", err)
+		return nil, err
+	}
+	if len(org) == 0 {
+		return nil, fmt.Errorf("no gitea organisation found")
+	}
+	return &GitGiteaClient{
+		httpClient:   util.GetHTTPClientWithTLSConfig(tlsConfig),
+		host:         strings.TrimSuffix(host, "/"),
+		token:        token,
+		org:          org,
+		logger:       logger,
+		gitOpsHelper: gitOpsHelper,
+	}, nil
+}
+
+func (impl *GitGiteaClient) getRepoUrl(repoName string) string {
+	return fmt.Sprintf("%s/%s/%s.git", impl.host, impl.org, repoName)
+}
+
+// doRequest calls the gitea rest api and decodes the response in result if provided.
+// It returns the response status code so that callers can handle not found cases.
+func (impl *GitGiteaClient) doRequest(ctx context.Context, method, apiPath string, body, result interface{}) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s/%s", impl.host, GITEA_API_BASE_PATH, apiPath), reqBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", impl.token))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := impl.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("gitea api %s %s failed with status %d: %s", method, apiPath, resp.StatusCode, string(respBody))
+	}
+	if result != nil && len(respBody) > 0 {
+		if err = json.Unmarshal(respBody, result); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func (impl *GitGiteaClient) getRepository(ctx context.Context, repoName string) (repo *giteaRepository, exists bool, err error) {
+	repo = &giteaRepository{}
+	statusCode, err := impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s", url.PathEscape(impl.org), url.PathEscape(repoName)), nil, repo)
+	if statusCode == http.StatusNotFound {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return repo, true, nil
+}
+
+func (impl *GitGiteaClient) DeleteRepository(config *bean2.GitOpsConfigDto) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("DeleteRepository", "GitGiteaClient", start, err)
+	}()
+	_, err = impl.doRequest(context.Background(), http.MethodDelete, fmt.Sprintf("repos/%s/%s", url.PathEscape(impl.org), url.PathEscape(config.GitRepoName)), nil, nil)
+	if err != nil {
+		impl.logger.Errorw("error in deleting repo gitea", "repoName", config.GitRepoName, "err", err)
+	}
+	return err
+}
+
+func (impl *GitGiteaClient) GetRepoUrl(config *bean2.GitOpsConfigDto) (repoUrl string, isRepoEmpty bool, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetRepoUrl", "GitGiteaClient", start, err)
+	}()
+	repo, exists, err := impl.getRepository(context.Background(), config.GitRepoName)
+	if err != nil {
+		impl.logger.Errorw("error in getting repo gitea", "repoName", config.GitRepoName, "err", err)
+		return "", isRepoEmpty, err
+	} else if !exists {
+		return "", isRepoEmpty, nil
+	}
+	return impl.getRepoUrl(config.GitRepoName), repo.Empty, nil
+}
+
+func (impl *GitGiteaClient) CreateRepository(ctx context.Context, config *bean2.GitOpsConfigDto) (url string, isNew bool, isEmpty bool, detailedErrorGitOpsConfigActions DetailedErrorGitOpsConfigActions) {
+	var err error
+	start := time.Now()
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+
+	repoUrl, isEmpty, err := impl.GetRepoUrl(config)
+	if err != nil {
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.GetRepoUrlStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return "", false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	if len(repoUrl) > 0 {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.GetRepoUrlStage)
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, nil)
+		return repoUrl, false, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	createRequest := &giteaCreateRepoRequest{
+		Name:          config.GitRepoName,
+		Description:   config.Description,
+		Private:       true,
+		DefaultBranch: config.TargetRevision,
+	}
+	_, err = impl.doRequest(ctx, http.MethodPost, fmt.Sprintf("orgs/%s/repos", impl.org), createRequest, nil)
+	if err != nil {
+		impl.logger.Errorw("error in creating repo gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateRepoStage] = err
+		repoUrl, isEmpty, err = impl.GetRepoUrl(config)
+		if err != nil || len(repoUrl) == 0 {
+			util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+			return "", true, isEmpty, detailedErrorGitOpsConfigActions
+		}
+	}
+	repoUrl = impl.getRepoUrl(config.GitRepoName)
+	impl.logger.Infow("repo created", "repoUrl", repoUrl)
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CreateRepoStage)
+
+	_, err = impl.CreateReadme(ctx, config)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme gitea", "repoName", config.GitRepoName, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[bean.CreateReadmeStage] = err
+		util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, err)
+		return "", true, isEmpty, detailedErrorGitOpsConfigActions
+	}
+	isEmpty = false //As we have created readme, repo is no longer empty
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, bean.CloneHttpStage, bean.CreateReadmeStage)
+	util.TriggerGitOpsMetrics("CreateRepository", "GitGiteaClient", start, nil)
+	return repoUrl, true, isEmpty, detailedErrorGitOpsConfigActions
+}
+
+func (impl *GitGiteaClient) CreateFirstCommitOnHead(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	return impl.CreateReadme(ctx, config)
+}
+
+func (impl *GitGiteaClient) CreateReadme(ctx context.Context, config *bean2.GitOpsConfigDto) (string, error) {
+	hash, _, err := impl.CommitValues(ctx, getReadmeChartConfig(config), config, true)
+	if err != nil {
+		impl.logger.Errorw("error in creating readme gitea", "repo", config.GitRepoName, "err", err)
+	}
+	return hash, err
+}
+
+func (impl *GitGiteaClient) CommitValues(ctx context.Context, config *ChartConfig, gitOpsConfig *bean2.GitOpsConfigDto, publishStatusConflictError bool) (commitHash string, commitTime time.Time, err error) {
+	return commitValuesOverGit(ctx, impl.gitOpsHelper, impl.logger, impl.getRepoUrl(config.ChartRepoName), config, publishStatusConflictError, "GitGiteaClient")
+}
+
+func (impl *GitGiteaClient) CreatePullRequest(ctx context.Context, config *bean.PullRequestConfig) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitGiteaClient", start, err)
+	}()
+	request := &giteaCreatePullRequestRequest{
+		Title: config.Title,
+		Body:  config.Description,
+		Head:  config.SourceBranch,
+		Base:  config.TargetBranch,
+	}
+	pr := &giteaPullRequest{}
+	_, err = impl.doRequest(ctx, http.MethodPost, fmt.Sprintf("repos/%s/%s/pulls", url.PathEscape(impl.org), url.PathEscape(config.RepoName)), request, pr)
+	if err != nil {
+		impl.logger.Errorw("error in creating gitea pull request", "repo", config.RepoName, "sourceBranch", config.SourceBranch, "err", err)
+		return nil, err
+	}
+	return pr.toPullRequest(), nil
+}
+
+func (impl *GitGiteaClient) GetPullRequest(ctx context.Context, repoName string, number int) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitGiteaClient", start, err)
+	}()
+	pr := &giteaPullRequest{}
+	_, err = impl.doRequest(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s/pulls/%d", url.PathEscape(impl.org), url.PathEscape(repoName), number), nil, pr)
+	if err != nil {
+		impl.logger.Errorw("error in getting gitea pull request", "repo", repoName, "number", number, "err", err)
+		return nil, err
+	}
+	return pr.toPullRequest(), nil
+}
+
+func (impl *GitGiteaClient) ClosePullRequest(ctx context.Context, repoName string, number int) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("ClosePullRequest", "GitGiteaClient", start, err)
+	}()
+	_, err = impl.doRequest(ctx, http.MethodPatch, fmt.Sprintf("repos/%s/%s/pulls/%d", url.PathEscape(impl.org), url.PathEscape(repoName), number), &giteaEditPullRequestRequest{State: "closed"}, nil)
+	if err != nil {
+		impl.logger.Errorw("error in closing gitea pull request", "repo", repoName, "number", number, "err", err)
+	}
+	return err
+}
+
+func (pr *giteaPullRequest) toPullRequest() *bean.PullRequest {
+	pullRequest := &bean.PullRequest{
+		Number: pr.Number,
+		Url:    pr.HtmlUrl,
+		State:  bean.PullRequestOpen,
+	}
+	if pr.Merged {
+		pullRequest.State = bean.PullRequestMerged
+		pullRequest.MergeCommitHash = pr.MergeCommitSha
+	} else if pr.State == "closed" {
+		pullRequest.State = bean.PullRequestClosed
+	}
+	return pullRequest
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bean2 "github.com/devtron-labs/devtron/api/bean/gitOps"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGiteaTestServer(t *testing.T, routes map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token gitea-token", r.Header.Get("Authorization"))
+		response, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func newGiteaTestClient(t *testing.T, host string) *GitGiteaClient {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	client, err := NewGitGiteaClient(host+"/", "gitea-token", "devtron", logger, nil, nil)
+	assert.NoError(t, err)
+	return client.(*GitGiteaClient)
+}
+
+func TestGitGiteaClient(t *testing.T) {
+	server := newGiteaTestServer(t, map[string]interface{}{
+		"GET /api/v1/repos/devtron/existing-repo": giteaRepository{Name: "existing-repo"},
+		"GET /api/v1/repos/devtron/empty-repo":    giteaRepository{Name: "empty-repo", Empty: true},
+		"POST /api/v1/repos/devtron/existing-repo/pulls": giteaPullRequest{Number: 4,
+			HtmlUrl: "https://gitea/devtron/existing-repo/pulls/4", State: "open"},
+		"GET /api/v1/repos/devtron/existing-repo/pulls/4":   giteaPullRequest{Number: 4, State: "open"},
+		"GET /api/v1/repos/devtron/existing-repo/pulls/5":   giteaPullRequest{Number: 5, State: "closed", Merged: true, MergeCommitSha: "abc123"},
+		"GET /api/v1/repos/devtron/existing-repo/pulls/6":   giteaPullRequest{Number: 6, State: "closed"},
+		"PATCH /api/v1/repos/devtron/existing-repo/pulls/4": giteaPullRequest{Number: 4, State: "closed"},
+	})
+	defer server.Close()
+	client := newGiteaTestClient(t, server.URL)
+
+	t.Run("repo url of an existing repository", func(t *testing.T) {
+		repoUrl, isRepoEmpty, err := client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "existing-repo"})
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL+"/devtron/existing-repo.git", repoUrl)
+		assert.False(t, isRepoEmpty)
+
+		_, isRepoEmpty, err = client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "empty-repo"})
+		assert.NoError(t, err)
+		assert.True(t, isRepoEmpty)
+	})
+
+	t.Run("missing repository returns no url", func(t *testing.T) {
+		repoUrl, _, err := client.GetRepoUrl(&bean2.GitOpsConfigDto{GitRepoName: "missing-repo"})
+		assert.NoError(t, err)
+		assert.Empty(t, repoUrl)
+	})
+
+	t.Run("existing repository is not created again", func(t *testing.T) {
+		repoUrl, isNew, _, detailedError := client.CreateRepository(context.Background(), &bean2.GitOpsConfigDto{GitRepoName: "existing-repo"})
+		assert.Empty(t, detailedError.StageErrorMap)
+		assert.Equal(t, server.URL+"/devtron/existing-repo.git", repoUrl)
+		assert.False(t, isNew)
+	})
+
+	t.Run("pull request states", func(t *testing.T) {
+		pullRequest, err := client.CreatePullRequest(context.Background(), &bean.PullRequestConfig{RepoName: "existing-repo",
+			SourceBranch: "devtron/release-1-env-2", TargetBranch: "master", Title: "release"})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, pullRequest.Number)
+		assert.Equal(t, "https://gitea/devtron/existing-repo/pulls/4", pullRequest.Url)
+		assert.Equal(t, bean.PullRequestOpen, pullRequest.State)
+
+		pullRequest, err = client.GetPullRequest(context.Background(), "existing-repo", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, bean.PullRequestMerged, pullRequest.State)
+		assert.Equal(t, "abc123", pullRequest.MergeCommitHash)
+
+		pullRequest, err = client.GetPullRequest(context.Background(), "existing-repo", 6)
+		assert.NoError(t, err)
+		assert.Equal(t, bean.PullRequestClosed, pullRequest.State)
+
+		_, err = client.GetPullRequest(context.Background(), "existing-repo", 7)
+		assert.Error(t, err)
+
+		assert.NoError(t, client.ClosePullRequest(context.Background(), "existing-repo", 4))
+		assert.Error(t, client.ClosePullRequest(context.Background(), "existing-repo", 7))
+	})
+}
@@ -360,3 +360,61 @@ func (impl GitHubClient) ensureProjectAvailabilityOnSsh(projectName string, repo
 	}
 	return false, nil
 }
+
+func (impl GitHubClient) CreatePullRequest(ctx context.Context, config *bean.PullRequestConfig) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("CreatePullRequest", "GitHubClient", start, err)
+	}()
+	pr, _, err := impl.client.PullRequests.Create(ctx, impl.org, config.RepoName, &github.NewPullRequest{
+		Title: github.String(config.Title),
+		Head:  github.String(config.SourceBranch),
+		Base:  github.String(config.TargetBranch),
+		Body:  github.String(config.Description),
+	})
+	if err != nil {
+		impl.logger.Errorw("error in creating github pull request", "repo", config.RepoName, "sourceBranch", config.SourceBranch, "err", err)
+		return nil, err
+	}
+	return getGithubPullRequest(pr), nil
+}
+
+func (impl GitHubClient) GetPullRequest(ctx context.Context, repoName string, number int) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("GetPullRequest", "GitHubClient", start, err)
+	}()
+	pr, _, err := impl.client.PullRequests.Get(ctx, impl.org, repoName, number)
+	if err != nil {
+		impl.logger.Errorw("error in getting github pull request", "repo", repoName, "number", number, "err", err)
+		return nil, err
+	}
+	return getGithubPullRequest(pr), nil
+}
+
+func (impl GitHubClient) ClosePullRequest(ctx context.Context, repoName string, number int) (err error) {
+	start := time.Now()
+	defer func() {
+		globalUtil.TriggerGitOpsMetrics("ClosePullRequest", "GitHubClient", start, err)
+	}()
+	_, _, err = impl.client.PullRequests.Edit(ctx, impl.org, repoName, number, &github.PullRequest{State: github.String("closed")})
+	if err != nil {
+		impl.logger.Errorw("error in closing github pull request", "repo", repoName, "number", number, "err", err)
+	}
+	return err
+}
+
+func getGithubPullRequest(pr *github.PullRequest) *bean.PullRequest {
+	pullRequest := &bean.PullRequest{
+		Number: pr.GetNumber(),
+		Url:    pr.GetHTMLURL(),
+		State:  bean.PullRequestOpen,
+	}
+	if pr.GetMerged() {
+		pullRequest.State = bean.PullRequestMerged
+		pullRequest.MergeCommitHash = pr.GetMergeCommitSHA()
+	} else if pr.GetState() == "closed" {
+		pullRequest.State = bean.PullRequestClosed
+	}
+	return pullRequest
+}
@@ -398,3 +398,72 @@ func (impl GitLabClient) CommitValues(ctx context.Context, config *ChartConfig,
 	util.TriggerGitOpsMetrics("CommitValues", "GitLabClient", start, nil)
 	return c.ID, commitTime, err
 }
+
+func (impl GitLabClient) CreatePullRequest(ctx context.Context, config *bean.PullRequestConfig) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("CreatePullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.CreateMergeRequest(fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, config.RepoName), &gitlab.CreateMergeRequestOptions{
+		Title:              gitlab.String(config.Title),
+		Description:        gitlab.String(config.Description),
+		SourceBranch:       gitlab.String(config.SourceBranch),
+		TargetBranch:       gitlab.String(config.TargetBranch),
+		RemoveSourceBranch: gitlab.Bool(true),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in creating gitlab merge request", "repo", config.RepoName, "sourceBranch", config.SourceBranch, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequest(mr), nil
+}
+
+func (impl GitLabClient) GetPullRequest(ctx context.Context, repoName string, number int) (pullRequest *bean.PullRequest, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetPullRequest", "GitLabClient", start, err)
+	}()
+	mr, _, err := impl.client.MergeRequests.GetMergeRequest(fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, repoName), number, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in getting gitlab merge request", "repo", repoName, "number", number, "err", err)
+		return nil, err
+	}
+	return getGitlabPullRequest(mr), nil
+}
+
+func (impl GitLabClient) ClosePullRequest(ctx context.Context, repoName string, number int) (err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("ClosePullRequest", "GitLabClient", start, err)
+	}()
+	_, _, err = impl.client.MergeRequests.UpdateMergeRequest(fmt.Sprintf("%s/%s", impl.config.GitlabGroupPath, repoName), number, &gitlab.UpdateMergeRequestOptions{
+		StateEvent: gitlab.String("close"),
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		impl.logger.Errorw("error in closing gitlab merge request", "repo", repoName, "number", number, "err", err)
+	}
+	return err
+}
+
+func getGitlabPullRequest(mr *gitlab.MergeRequest) *bean.PullRequest {
+	pullRequest := &bean.PullRequest{
+		Number: mr.IID,
+		Url:    mr.WebURL,
+		State:  bean.PullRequestOpen,
+	}
+	switch mr.State {
+	case "merged":
+		pullRequest.State = bean.PullRequestMerged
+		// squashed merge requests are merged with the squash commit when no merge commit is created
+		pullRequest.MergeCommitHash = mr.MergeCommitSHA
+		if len(pullRequest.MergeCommitHash) == 0 {
+			pullRequest.MergeCommitHash = mr.SquashCommitSHA
+		}
+		if len(pullRequest.MergeCommitHash) == 0 {
+			pullRequest.MergeCommitHash = mr.SHA
+		}
+	case "closed":
+		pullRequest.State = bean.PullRequestClosed
+	}
+	return pullRequest
+}
@@ -41,5 +41,10 @@ func ConvertGitOpsConfigToGitConfig(dto *bean2.GitOpsConfigDto) *bean.GitConfig
 		config.TLSCert = dto.TLSConfig.TLSCertData
 		config.TLSKey = dto.TLSConfig.TLSKeyData
 	}
+	if !dto.SigningConfig.IsEmpty() {
+		config.SigningKeyType = dto.SigningConfig.KeyType
+		config.SigningKey = dto.SigningConfig.SigningKey
+		config.SigningKeyPassphrase = dto.SigningConfig.Passphrase
+	}
 	return config
 }
@@ -42,6 +42,10 @@ type GitConfig struct {
 	CaCert                string
 	TLSCert               string
 	TLSKey                string
+
+	SigningKeyType       constants.SigningKeyType
+	SigningKey           string
+	SigningKeyPassphrase string
 }
 
 type PushChartToGitRequestDTO struct {
@@ -54,6 +58,40 @@ type PushChartToGitRequestDTO struct {
 	UserId            int32
 }
 
+type PullRequestState string
+
+const (
+	PullRequestOpen   PullRequestState = "OPEN"
+	PullRequestMerged PullRequestState = "MERGED"
+	PullRequestClosed PullRequestState = "CLOSED"
+	// PullRequestSuperseded is set when the deployment was superseded before the pull request got merged, such pull requests are not tracked anymore
+	PullRequestSuperseded PullRequestState = "SUPERSEDED"
+)
+
+// PullRequestConfig is the request to open a pull request from SourceBranch into TargetBranch of the gitops repository
+type PullRequestConfig struct {
+	RepoName     string
+	SourceBranch string
+	TargetBranch string
+	Title        string
+	Description  string
+}
+
+// PullRequest is the provider agnostic view of a pull/merge request
+type PullRequest struct {
+	Number          int
+	Url             string
+	State           PullRequestState
+	MergeCommitHash string // set only once the pull request is merged
+}
+
+// GitFileVersion is the content of a file on the head of a revision along with the last commit which modified it
+type GitFileVersion struct {
+	Content    string
+	CommitHash string
+	Exists     bool
+}
+
 func bitBucketGitOpsHelperClient(cfg GitConfig) *git.BasicAuth {
 	username := cfg.GitUserName
 
@@ -89,6 +127,17 @@ func (cfg GitConfig) GetAuth() *git.BasicAuth {
 
 }
 
+func (cfg GitConfig) GetSigningKey() *git.SigningKey {
+	if len(cfg.SigningKey) == 0 {
+		return nil
+	}
+	return &git.SigningKey{
+		KeyType:    cfg.SigningKeyType,
+		PrivateKey: cfg.SigningKey,
+		Passphrase: cfg.SigningKeyPassphrase,
+	}
+}
+
 func (cfg GitConfig) GetTLSConfig() *bean.TLSConfig {
 	return &bean.TLSConfig{
 		CaData:      cfg.CaCert,
@@ -27,9 +27,15 @@ const (
 	GITHUB_PROVIDER       = "GITHUB"
 	AZURE_DEVOPS_PROVIDER = "AZURE_DEVOPS"
 	BITBUCKET_PROVIDER    = "BITBUCKET_CLOUD"
-	GITHUB_API_V3         = "api/v3"
-	GITHUB_HOST           = "github.com"
-	GIT_TLS_DIR           = "/tmp/gitops/tls"
+	GITEA_PROVIDER        = "GITEA"
+	// BITBUCKET_SERVER_PROVIDER is the self-hosted Bitbucket Server / Data Center edition
+	BITBUCKET_SERVER_PROVIDER = "BITBUCKET_SERVER"
+	// GENERIC_GIT_PROVIDER works with any git server reachable over HTTP(S).
+	// Repositories are never created by devtron, they must be pre-created under the configured host.
+	GENERIC_GIT_PROVIDER = "GENERIC_GIT"
+	GITHUB_API_V3        = "api/v3"
+	GITHUB_HOST          = "github.com"
+	GIT_TLS_DIR          = "/tmp/gitops/tls"
 	// BITBUCKET_ACCESS_TOKEN_USERNAME is the fixed username Bitbucket Cloud expects for
 	// git-over-HTTPS when authenticating with a repository/project/workspace access token.
 	// Ref: https://support.atlassian.com/bitbucket-cloud/docs/using-access-tokens/
@@ -17,10 +17,13 @@
 package commandManager
 
 import (
+	"bytes"
 	"fmt"
 	git_manager "github.com/devtron-labs/common-lib/git-manager"
 	"github.com/devtron-labs/devtron/util"
+	"os"
 	"os/exec"
+	"strings"
 	"time"
 )
 
@@ -54,6 +57,12 @@ func (impl *GitCliManagerImpl) CommitAndPush(ctx GitContext, repoRoot, targetRev
 	if err != nil {
 		return "", err
 	}
+	if ctx.IsCommitSigningEnabled() {
+		err = impl.signLastCommit(ctx, repoRoot)
+		if err != nil {
+			return "", err
+		}
+	}
 	commit, _, err := impl.lastCommitHash(ctx, repoRoot)
 	if err != nil {
 		return "", err
@@ -65,6 +74,18 @@ func (impl *GitCliManagerImpl) CommitAndPush(ctx GitContext, repoRoot, targetRev
 	return commit, err
 }
 
+func (impl *GitCliManagerImpl) CommitAndPushToBranch(ctx GitContext, repoRoot, branch, commitMsg, name, emailId string) (commitHash string, err error) {
+	return impl.CommitAndPush(ctx, repoRoot, fmt.Sprintf("HEAD:refs/heads/%s", branch), commitMsg, name, emailId)
+}
+
+func (impl *GitCliManagerImpl) DeleteRemoteBranch(ctx GitContext, repoRoot, branch string) error {
+	_, errMsg, err := impl.push(ctx, repoRoot, fmt.Sprintf(":refs/heads/%s", branch))
+	if err != nil {
+		impl.logger.Errorw("error in deleting remote branch", "branch", branch, "errMsg", errMsg, "err", err)
+	}
+	return err
+}
+
 func (impl *GitCliManagerImpl) Pull(ctx GitContext, targetRevision string, repoRoot string) (err error) {
 
 	start := time.Now()
@@ -128,6 +149,50 @@ func (impl *GitCliManagerImpl) commit(ctx GitContext, rootDir string, commitMsg
 	return output, errMsg, err
 }
 
+// signLastCommit rewrites the HEAD commit with the gpgsig header, the signature is created in process
+// so neither gpg nor ssh-keygen are needed in the image
+func (impl *GitCliManagerImpl) signLastCommit(ctx GitContext, rootDir string) error {
+	impl.logger.Debugw("git sign commit", "location", rootDir, "keyType", ctx.signingKey.KeyType)
+	signer, err := NewCommitSigner(ctx.signingKey)
+	if err != nil {
+		impl.logger.Errorw("error in creating commit signer", "keyType", ctx.signingKey.KeyType, "err", err)
+		return err
+	}
+	commitObject, err := impl.runGitCommandWithInput(ctx, rootDir, nil, "cat-file", "commit", "HEAD")
+	if err != nil {
+		return err
+	}
+	signature, err := signer.Sign(bytes.NewReader(commitObject))
+	if err != nil {
+		impl.logger.Errorw("error in signing commit", "location", rootDir, "err", err)
+		return err
+	}
+	signedCommit, err := impl.runGitCommandWithInput(ctx, rootDir, addSignatureToCommitObject(commitObject, signature), "hash-object", "-t", "commit", "-w", "--stdin")
+	if err != nil {
+		return err
+	}
+	_, err = impl.runGitCommandWithInput(ctx, rootDir, nil, "update-ref", "HEAD", strings.TrimSpace(string(signedCommit)))
+	return err
+}
+
+// runGitCommandWithInput returns the untrimmed output of the command, as the commit object is signed byte for byte
+func (impl *GitCliManagerImpl) runGitCommandWithInput(ctx GitContext, rootDir string, input []byte, arg ...string) ([]byte, error) {
+	cmd, cancel := impl.createCmdWithContext(ctx, "git", append([]string{"-C", rootDir}, arg...)...)
+	defer cancel()
+	cmd.Env = append(os.Environ(), "HOME=/dev/null")
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
+	var stdErr bytes.Buffer
+	cmd.Stderr = &stdErr
+	output, err := cmd.Output()
+	if err != nil {
+		impl.logger.Errorw("error in git command", "root", rootDir, "args", arg, "errMsg", stdErr.String(), "err", err)
+		return nil, fmt.Errorf("%s %v", stdErr.String(), err)
+	}
+	return output, nil
+}
+
 func (impl *GitCliManagerImpl) lastCommitHash(ctx GitContext, rootDir string) (response, errMsg string, err error) {
 	impl.logger.Debugw("git log", "location", rootDir)
 	cmd, cancel := impl.createCmdWithContext(ctx, "git", "-C", rootDir, "log", "--format=format:%H", "-n", "1")
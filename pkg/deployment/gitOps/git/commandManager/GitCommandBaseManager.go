@@ -38,6 +38,10 @@ type GitCommandManagerBase interface {
 	// In that case, use GetCurrentBranch to get the default branch.
 	GetDefaultBranch(ctx GitContext, rootDir string) (response, errMsg string, err error)
 	PullCli(ctx GitContext, rootDir string, branch string) (response, errMsg string, err error)
+	// GetLastCommitForPath returns the hash of the last commit on the checked out revision which modified the path.
+	//	command: git -C <rootDir> log -1 --format=%H -- <path>
+	// The response is empty if the path was never committed.
+	GetLastCommitForPath(ctx GitContext, rootDir string, path string) (response, errMsg string, err error)
 }
 
 type GitManagerBaseImpl struct {
@@ -124,6 +128,19 @@ func (impl *GitManagerBaseImpl) PullCli(ctx GitContext, rootDir string, branch s
 	return output, errMsg, err
 }
 
+func (impl *GitManagerBaseImpl) GetLastCommitForPath(ctx GitContext, rootDir string, path string) (response, errMsg string, err error) {
+	start := time.Now()
+	defer func() {
+		util.TriggerGitOpsMetrics("GetLastCommitForPath", "GitCli", start, err)
+	}()
+	impl.logger.Debugw("git log -1 --format=%H", "location", rootDir, "path", path)
+	cmd, cancel := impl.createCmdWithContext(ctx, "git", "-C", rootDir, "log", "-1", "--format=%H", "--", path)
+	defer cancel()
+	output, errMsg, err := impl.runCommand(cmd)
+	impl.logger.Debugw("git log -1 --format=%H output", "root", rootDir, "opt", output, "errMsg", errMsg, "error", err)
+	return output, errMsg, err
+}
+
 func (impl *GitManagerBaseImpl) runCommandWithCred(cmd *exec.Cmd, auth *BasicAuth, tlsPathInfo *git_manager.TlsPathInfo) (response, errMsg string, err error) {
 	cmd.Env = append(os.Environ(),
 		fmt.Sprintf("GIT_ASKPASS=%s", GIT_ASK_PASS),
@@ -25,6 +25,11 @@ type GitCommandManager interface {
 	GitCommandManagerBase
 	AddRepo(ctx GitContext, rootDir string, remoteUrl string, isBare bool) error
 	CommitAndPush(ctx GitContext, repoRoot, targetRevision, commitMsg, name, emailId string) (string, error)
+	// CommitAndPushToBranch commits all the changes on the checked out revision and pushes them to the given branch on origin,
+	// the branch is created on origin if it does not exist
+	CommitAndPushToBranch(ctx GitContext, repoRoot, branch, commitMsg, name, emailId string) (string, error)
+	// DeleteRemoteBranch deletes the branch on origin
+	DeleteRemoteBranch(ctx GitContext, repoRoot, branch string) error
 	Pull(ctx GitContext, targetRevision string, repoRoot string) (err error)
 }
 
@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandManager
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"golang.org/x/crypto/ssh"
+	"io"
+	"strings"
+)
+
+// CommitSigner signs the encoded commit object, it satisfies the go-git Signer interface
+type CommitSigner interface {
+	Sign(message io.Reader) ([]byte, error)
+}
+
+const (
+	sshSignatureMagicPreamble = "SSHSIG"
+	sshSignatureVersion       = 1
+	sshSignatureNamespace     = "git"
+	sshSignatureHashAlgorithm = "sha512"
+	sshSignatureArmorStart    = "-----BEGIN SSH SIGNATURE-----"
+	sshSignatureArmorEnd      = "-----END SSH SIGNATURE-----"
+	sshSignatureLineLength    = 70
+)
+
+func NewCommitSigner(signingKey *SigningKey) (CommitSigner, error) {
+	switch signingKey.KeyType {
+	case constants.SIGNING_KEY_TYPE_GPG:
+		return newGpgCommitSigner(signingKey)
+	case constants.SIGNING_KEY_TYPE_SSH:
+		return newSshCommitSigner(signingKey)
+	}
+	return nil, fmt.Errorf("unsupported signing key type %q", signingKey.KeyType)
+}
+
+// ValidateSigningKey checks that the signing key can be decrypted and used for signing a commit
+func ValidateSigningKey(signingKey *SigningKey) error {
+	signer, err := NewCommitSigner(signingKey)
+	if err != nil {
+		return err
+	}
+	_, err = signer.Sign(strings.NewReader("devtron gitops commit signing validation"))
+	if err != nil {
+		return fmt.Errorf("error in signing with the %s key: %v", signingKey.KeyType, err)
+	}
+	return nil
+}
+
+type gpgCommitSigner struct {
+	entity *openpgp.Entity
+}
+
+func newGpgCommitSigner(signingKey *SigningKey) (*gpgCommitSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(signingKey.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("error in reading the armored gpg private key: %v", err)
+	}
+	for _, entity := range entities {
+		if entity.PrivateKey == nil {
+			continue
+		}
+		if entity.PrivateKey.Encrypted {
+			err = entity.DecryptPrivateKeys([]byte(signingKey.Passphrase))
+			if err != nil {
+				return nil, fmt.Errorf("error in decrypting the gpg private key: %v", err)
+			}
+		}
+		return &gpgCommitSigner{entity: entity}, nil
+	}
+	return nil, fmt.Errorf("no gpg private key found")
+}
+
+func (signer *gpgCommitSigner) Sign(message io.Reader) ([]byte, error) {
+	var signature bytes.Buffer
+	err := openpgp.ArmoredDetachSign(&signature, signer.entity, message, nil)
+	if err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
+
+type sshCommitSigner struct {
+	signer ssh.Signer
+}
+
+func newSshCommitSigner(signingKey *SigningKey) (*sshCommitSigner, error) {
+	var signer ssh.Signer
+	var err error
+	if len(signingKey.Passphrase) > 0 {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(signingKey.PrivateKey), []byte(signingKey.Passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(signingKey.PrivateKey))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing the ssh private key: %v", err)
+	}
+	return &sshCommitSigner{signer: signer}, nil
+}
+
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+type sshSignatureBlob struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// Sign creates an armored SSHSIG signature in the git namespace, same as `ssh-keygen -Y sign -n git`
+func (signer *sshCommitSigner) Sign(message io.Reader) ([]byte, error) {
+	hash := sha512.New()
+	_, err := io.Copy(hash, message)
+	if err != nil {
+		return nil, err
+	}
+	signedData := append([]byte(sshSignatureMagicPreamble), ssh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Hash:          hash.Sum(nil),
+	})...)
+	var signature *ssh.Signature
+	if algorithmSigner, ok := signer.signer.(ssh.AlgorithmSigner); ok && signer.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// ssh-rsa (SHA-1) signatures are rejected by git, rsa keys are signed with rsa-sha2-512
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = signer.signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	blob := append([]byte(sshSignatureMagicPreamble), ssh.Marshal(sshSignatureBlob{
+		Version:       sshSignatureVersion,
+		PublicKey:     signer.signer.PublicKey().Marshal(),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Signature:     ssh.Marshal(signature),
+	})...)
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	var armored bytes.Buffer
+	armored.WriteString(sshSignatureArmorStart + "\n")
+	for len(encoded) > sshSignatureLineLength {
+		armored.WriteString(encoded[:sshSignatureLineLength] + "\n")
+		encoded = encoded[sshSignatureLineLength:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString(sshSignatureArmorEnd + "\n")
+	return armored.Bytes(), nil
+}
+
+// addSignatureToCommitObject adds the gpgsig header to the raw commit object, continuation lines of a header are indented by a space
+func addSignatureToCommitObject(commitObject, signature []byte) []byte {
+	signatureHeader := "gpgsig " + strings.ReplaceAll(strings.TrimSuffix(string(signature), "\n"), "\n", "\n ") + "\n"
+	// the headers end at the first empty line, followed by the commit message
+	headerEnd := bytes.Index(commitObject, []byte("\n\n")) + 1
+	if headerEnd <= 0 {
+		headerEnd = len(commitObject)
+	}
+	signedObject := make([]byte, 0, len(commitObject)+len(signatureHeader))
+	signedObject = append(signedObject, commitObject[:headerEnd]...)
+	signedObject = append(signedObject, signatureHeader...)
+	signedObject = append(signedObject, commitObject[headerEnd:]...)
+	return signedObject
+}
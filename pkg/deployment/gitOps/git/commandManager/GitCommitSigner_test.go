@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commandManager
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/devtron-labs/devtron/internal/sql/constants"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+const testCommitObject = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+	"author devtron <devtron@example.com> 1700000000 +0000\n" +
+	"committer devtron <devtron@example.com> 1700000000 +0000\n" +
+	"\n" +
+	"release-1-env-2\n" +
+	"\n" +
+	"updates values.yaml\n"
+
+func newSshSigningKey(t *testing.T, keyType string, passphrase string) (*SigningKey, ssh.PublicKey) {
+	var privateKey interface{}
+	switch keyType {
+	case ssh.KeyAlgoED25519:
+		_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		privateKey = ed25519Key
+	case ssh.KeyAlgoRSA:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		privateKey = rsaKey
+	}
+	var block *pem.Block
+	var err error
+	if len(passphrase) > 0 {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(privateKey, "devtron", []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(privateKey, "devtron")
+	}
+	assert.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	assert.NoError(t, err)
+	return &SigningKey{KeyType: constants.SIGNING_KEY_TYPE_SSH, PrivateKey: string(pem.EncodeToMemory(block)), Passphrase: passphrase}, signer.PublicKey()
+}
+
+func newGpgSigningKey(t *testing.T) (*SigningKey, string) {
+	entity, err := openpgp.NewEntity("devtron", "", "devtron@example.com", &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA})
+	assert.NoError(t, err)
+	var privateKey, publicKey bytes.Buffer
+	privateKeyWriter, err := armor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.SerializePrivate(privateKeyWriter, nil))
+	assert.NoError(t, privateKeyWriter.Close())
+	publicKeyWriter, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.Serialize(publicKeyWriter))
+	assert.NoError(t, publicKeyWriter.Close())
+	return &SigningKey{KeyType: constants.SIGNING_KEY_TYPE_GPG, PrivateKey: privateKey.String()}, publicKey.String()
+}
+
+// verifySshSignature verifies the armored SSHSIG signature of the message the same way as `ssh-keygen -Y verify -n git`
+func verifySshSignature(t *testing.T, publicKey ssh.PublicKey, message []byte, armoredSignature []byte) error {
+	armored := strings.TrimSpace(string(armoredSignature))
+	assert.True(t, strings.HasPrefix(armored, sshSignatureArmorStart))
+	assert.True(t, strings.HasSuffix(armored, sshSignatureArmorEnd))
+	encoded := strings.TrimSuffix(strings.TrimPrefix(armored, sshSignatureArmorStart), sshSignatureArmorEnd)
+	for _, line := range strings.Split(strings.TrimSpace(encoded), "\n") {
+		assert.LessOrEqual(t, len(line), sshSignatureLineLength)
+	}
+	blobBytes, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(blobBytes, []byte(sshSignatureMagicPreamble)))
+	blob := &sshSignatureBlob{}
+	assert.NoError(t, ssh.Unmarshal(blobBytes[len(sshSignatureMagicPreamble):], blob))
+	assert.Equal(t, uint32(sshSignatureVersion), blob.Version)
+	assert.Equal(t, sshSignatureNamespace, blob.Namespace)
+	assert.Equal(t, sshSignatureHashAlgorithm, blob.HashAlgorithm)
+	assert.Equal(t, publicKey.Marshal(), blob.PublicKey)
+	signature := &ssh.Signature{}
+	assert.NoError(t, ssh.Unmarshal(blob.Signature, signature))
+	if publicKey.Type() == ssh.KeyAlgoRSA {
+		assert.Equal(t, ssh.KeyAlgoRSASHA512, signature.Format)
+	}
+	hash := sha512.Sum512(message)
+	signedData := append([]byte(sshSignatureMagicPreamble), ssh.Marshal(sshSignedData{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Hash:          hash[:],
+	})...)
+	return publicKey.Verify(signedData, signature)
+}
+
+// decodeSignedCommit parses the signed commit object with go-git, as any git client reading the repository would
+func decodeSignedCommit(t *testing.T, signedObject []byte) (*object.Commit, []byte) {
+	encodedObject := &plumbing.MemoryObject{}
+	encodedObject.SetType(plumbing.CommitObject)
+	_, err := encodedObject.Write(signedObject)
+	assert.NoError(t, err)
+	commit := &object.Commit{}
+	assert.NoError(t, commit.Decode(encodedObject))
+	payload := &plumbing.MemoryObject{}
+	assert.NoError(t, commit.EncodeWithoutSignature(payload))
+	reader, err := payload.Reader()
+	assert.NoError(t, err)
+	var payloadBytes bytes.Buffer
+	_, err = payloadBytes.ReadFrom(reader)
+	assert.NoError(t, err)
+	return commit, payloadBytes.Bytes()
+}
+
+func TestSshCommitSigner_Sign(t *testing.T) {
+	for _, keyType := range []string{ssh.KeyAlgoED25519, ssh.KeyAlgoRSA} {
+		t.Run(keyType, func(t *testing.T) {
+			signingKey, publicKey := newSshSigningKey(t, keyType, "")
+			signer, err := NewCommitSigner(signingKey)
+			assert.NoError(t, err)
+
+			signature, err := signer.Sign(strings.NewReader(testCommitObject))
+			assert.NoError(t, err)
+			assert.NoError(t, verifySshSignature(t, publicKey, []byte(testCommitObject), signature))
+			assert.Error(t, verifySshSignature(t, publicKey, []byte(testCommitObject+"tampered"), signature))
+		})
+	}
+
+	t.Run("encrypted key", func(t *testing.T) {
+		signingKey, publicKey := newSshSigningKey(t, ssh.KeyAlgoED25519, "s3cr3t")
+		signer, err := NewCommitSigner(signingKey)
+		assert.NoError(t, err)
+		signature, err := signer.Sign(strings.NewReader(testCommitObject))
+		assert.NoError(t, err)
+		assert.NoError(t, verifySshSignature(t, publicKey, []byte(testCommitObject), signature))
+
+		signingKey.Passphrase = "wrong"
+		assert.Error(t, ValidateSigningKey(signingKey))
+	})
+}
+
+func TestAddSignatureToCommitObject(t *testing.T) {
+	t.Run("ssh signature", func(t *testing.T) {
+		signingKey, publicKey := newSshSigningKey(t, ssh.KeyAlgoED25519, "")
+		signer, err := NewCommitSigner(signingKey)
+		assert.NoError(t, err)
+		signature, err := signer.Sign(strings.NewReader(testCommitObject))
+		assert.NoError(t, err)
+
+		commit, payload := decodeSignedCommit(t, addSignatureToCommitObject([]byte(testCommitObject), signature))
+		assert.Equal(t, string(signature), commit.PGPSignature)
+		assert.Equal(t, "release-1-env-2\n\nupdates values.yaml\n", commit.Message)
+		// the payload without the signature is the commit object which has been signed
+		assert.Equal(t, testCommitObject, string(payload))
+		assert.NoError(t, verifySshSignature(t, publicKey, payload, []byte(commit.PGPSignature)))
+	})
+
+	t.Run("gpg signature", func(t *testing.T) {
+		signingKey, armoredPublicKey := newGpgSigningKey(t)
+		signer, err := NewCommitSigner(signingKey)
+		assert.NoError(t, err)
+		signature, err := signer.Sign(strings.NewReader(testCommitObject))
+		assert.NoError(t, err)
+
+		commit, payload := decodeSignedCommit(t, addSignatureToCommitObject([]byte(testCommitObject), signature))
+		assert.Equal(t, testCommitObject, string(payload))
+		entity, err := commit.Verify(armoredPublicKey)
+		assert.NoError(t, err)
+		assert.NotNil(t, entity)
+	})
+}
+
+func TestGitCliManagerImpl_signLastCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not found")
+	}
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen binary not found")
+	}
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	impl := &GitCliManagerImpl{GitManagerBaseImpl: &GitManagerBaseImpl{logger: logger, cfg: &configuration{UseGitCli: true}}}
+	rootDir := t.TempDir()
+	runGit := func(args ...string) error {
+		cmd := exec.Command("git", append([]string{"-C", rootDir, "-c", "user.name=devtron", "-c", "user.email=devtron@example.com"}, args...)...)
+		cmd.Env = append(os.Environ(), "HOME=/dev/null")
+		return cmd.Run()
+	}
+	assert.NoError(t, runGit("init"))
+	assert.NoError(t, os.WriteFile(filepath.Join(rootDir, "values.yaml"), []byte("replicaCount: 1\n"), 0666))
+	assert.NoError(t, runGit("add", "."))
+	assert.NoError(t, runGit("commit", "-m", "release-1-env-2"))
+
+	signingKey, publicKey := newSshSigningKey(t, ssh.KeyAlgoED25519, "")
+	ctx := BuildGitContext(context.Background()).WithSigningKey(signingKey)
+	assert.NoError(t, impl.signLastCommit(ctx, rootDir))
+
+	// git verifies the signature with the allowed signers, same as it would on a push to a protected branch
+	allowedSigners := filepath.Join(t.TempDir(), "allowed_signers")
+	assert.NoError(t, os.WriteFile(allowedSigners, []byte("devtron@example.com "+string(ssh.MarshalAuthorizedKey(publicKey))), 0666))
+	assert.NoError(t, runGit("-c", "gpg.format=ssh", "-c", "gpg.ssh.allowedSignersFile="+allowedSigners, "verify-commit", "HEAD"))
+
+	otherSigningKey, otherPublicKey := newSshSigningKey(t, ssh.KeyAlgoED25519, "")
+	assert.NotEqual(t, signingKey.PrivateKey, otherSigningKey.PrivateKey)
+	assert.NoError(t, os.WriteFile(allowedSigners, []byte("devtron@example.com "+string(ssh.MarshalAuthorizedKey(otherPublicKey))), 0666))
+	assert.Error(t, runGit("-c", "gpg.format=ssh", "-c", "gpg.ssh.allowedSignersFile="+allowedSigners, "verify-commit", "HEAD"))
+}
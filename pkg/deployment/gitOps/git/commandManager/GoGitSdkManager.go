@@ -17,9 +17,11 @@
 package commandManager
 
 import (
+	"fmt"
 	"github.com/devtron-labs/devtron/util"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"time"
@@ -81,16 +83,56 @@ func (impl *GoGitSDKManagerImpl) getRepoAndWorktree(repoRoot string) (*git.Repos
 }
 
 func (impl *GoGitSDKManagerImpl) CommitAndPush(ctx GitContext, repoRoot, targetRevision, commitMsg, name, emailId string) (string, error) {
-	repo, workTree, err := impl.getRepoAndWorktree(repoRoot)
+	repo, commit, err := impl.commit(ctx, repoRoot, commitMsg, name, emailId)
 	if err != nil {
 		return "", err
 	}
-	err = workTree.AddGlob("")
+	//-----------push
+	err = repo.PushContext(ctx, impl.getPushOptions(ctx))
+	return commit, err
+}
+
+func (impl *GoGitSDKManagerImpl) CommitAndPushToBranch(ctx GitContext, repoRoot, branch, commitMsg, name, emailId string) (string, error) {
+	repo, commit, err := impl.commit(ctx, repoRoot, commitMsg, name, emailId)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
 	if err != nil {
 		return "", err
 	}
+	//-----------push the checked out revision to the branch
+	pushOptions := impl.getPushOptions(ctx)
+	pushOptions.RefSpecs = []config.RefSpec{
+		config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), plumbing.NewBranchReferenceName(branch))),
+	}
+	err = repo.PushContext(ctx, pushOptions)
+	return commit, err
+}
+
+func (impl *GoGitSDKManagerImpl) DeleteRemoteBranch(ctx GitContext, repoRoot, branch string) error {
+	repo, err := git.PlainOpen(repoRoot)
+	if err != nil {
+		return err
+	}
+	pushOptions := impl.getPushOptions(ctx)
+	pushOptions.RefSpecs = []config.RefSpec{
+		config.RefSpec(fmt.Sprintf(":%s", plumbing.NewBranchReferenceName(branch))),
+	}
+	return repo.PushContext(ctx, pushOptions)
+}
+
+func (impl *GoGitSDKManagerImpl) commit(ctx GitContext, repoRoot, commitMsg, name, emailId string) (*git.Repository, string, error) {
+	repo, workTree, err := impl.getRepoAndWorktree(repoRoot)
+	if err != nil {
+		return nil, "", err
+	}
+	err = workTree.AddGlob("")
+	if err != nil {
+		return nil, "", err
+	}
 	//--  commit
-	commit, err := workTree.Commit(commitMsg, &git.CommitOptions{
+	commitOptions := &git.CommitOptions{
 		Author: &object.Signature{
 			Name:  name,
 			Email: emailId,
@@ -101,22 +143,30 @@ func (impl *GoGitSDKManagerImpl) CommitAndPush(ctx GitContext, repoRoot, targetR
 			Email: emailId,
 			When:  time.Now(),
 		},
-	})
+	}
+	if ctx.IsCommitSigningEnabled() {
+		commitOptions.Signer, err = NewCommitSigner(ctx.signingKey)
+		if err != nil {
+			impl.logger.Errorw("error in creating commit signer", "keyType", ctx.signingKey.KeyType, "err", err)
+			return nil, "", err
+		}
+	}
+	commit, err := workTree.Commit(commitMsg, commitOptions)
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 	impl.logger.Debugw("git hash", "repo", repoRoot, "hash", commit.String())
-	//-----------push
+	return repo, commit.String(), nil
+}
 
+func (impl *GoGitSDKManagerImpl) getPushOptions(ctx GitContext) *git.PushOptions {
 	pushOptions := &git.PushOptions{
 		Auth: ctx.auth.ToBasicAuth(),
 	}
 	if len(ctx.CACert) > 0 {
 		pushOptions.CABundle = []byte(ctx.CACert)
 	}
-
-	err = repo.PushContext(ctx, pushOptions)
-	return commit.String(), err
+	return pushOptions
 }
 
 func (auth *BasicAuth) ToBasicAuth() *http.BasicAuth {
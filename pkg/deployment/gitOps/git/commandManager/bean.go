@@ -32,6 +32,7 @@ type GitContext struct {
 	TLSKey                 string
 	TLSCertificate         string
 	TLSVerificationEnabled bool
+	signingKey             *SigningKey
 }
 
 func (gitCtx GitContext) WithCredentials(auth *BasicAuth) GitContext {
@@ -47,6 +48,15 @@ func (gitCtx GitContext) WithTLSData(caData string, tlsKey string, tlsCertificat
 	return gitCtx
 }
 
+func (gitCtx GitContext) WithSigningKey(signingKey *SigningKey) GitContext {
+	gitCtx.signingKey = signingKey
+	return gitCtx
+}
+
+func (gitCtx GitContext) IsCommitSigningEnabled() bool {
+	return gitCtx.signingKey != nil && len(gitCtx.signingKey.PrivateKey) > 0
+}
+
 func BuildGitContext(ctx context.Context) GitContext {
 	return GitContext{
 		Context: ctx,
@@ -64,3 +74,10 @@ type BasicAuth struct {
 	Username, Password string
 	AuthMode           constants.AuthMode
 }
+
+// SigningKey is the armored GPG or the OpenSSH private key used for signing the gitops commits
+type SigningKey struct {
+	KeyType    constants.SigningKeyType
+	PrivateKey string
+	Passphrase string
+}
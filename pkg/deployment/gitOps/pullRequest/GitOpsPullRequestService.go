@@ -0,0 +1,243 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pullRequest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/caarlos0/env"
+	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	userDeploymentRequestRepo "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/repository"
+	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
+	"github.com/devtron-labs/devtron/pkg/workflow/cd"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"slices"
+	"time"
+)
+
+type GitOpsPullRequestService interface {
+	// SyncOpenPullRequests polls the gitops provider for all open pull requests,
+	// resumes the deployments whose pull requests are merged and fails the ones whose pull requests are closed.
+	// Every pull request is claimed before it is acted upon so that only one replica resumes the deployment.
+	SyncOpenPullRequests()
+}
+
+type GitOpsPullRequestConfig struct {
+	PollCronTimeInMins int `env:"GITOPS_PULL_REQUEST_POLL_CRON_TIME" envDefault:"2" description:"Interval in minutes at which open gitops pull requests are polled for merge"`
+}
+
+func GetGitOpsPullRequestConfig() (*GitOpsPullRequestConfig, error) {
+	cfg := &GitOpsPullRequestConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse gitops pull request config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+type GitOpsPullRequestServiceImpl struct {
+	logger                          *zap.SugaredLogger
+	cron                            *cron.Cron
+	gitOpsPullRequestRepository     repository.GitOpsPullRequestRepository
+	gitOperationService             git.GitOperationService
+	pipelineOverrideRepository      chartConfig.PipelineOverrideRepository
+	pipelineStatusTimelineService   status.PipelineStatusTimelineService
+	cdWorkflowCommonService         cd.CdWorkflowCommonService
+	cdWorkflowRepository            pipelineConfig.CdWorkflowRepository
+	userDeploymentRequestRepository userDeploymentRequestRepo.UserDeploymentRequestRepository
+	pubSubClient                    *pubsub.PubSubClientServiceImpl
+}
+
+func NewGitOpsPullRequestServiceImpl(logger *zap.SugaredLogger, cfg *GitOpsPullRequestConfig, cronLogger *cron2.CronLoggerImpl,
+	gitOpsPullRequestRepository repository.GitOpsPullRequestRepository,
+	gitOperationService git.GitOperationService,
+	pipelineOverrideRepository chartConfig.PipelineOverrideRepository,
+	pipelineStatusTimelineService status.PipelineStatusTimelineService,
+	cdWorkflowCommonService cd.CdWorkflowCommonService,
+	cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	userDeploymentRequestRepository userDeploymentRequestRepo.UserDeploymentRequestRepository,
+	pubSubClient *pubsub.PubSubClientServiceImpl) *GitOpsPullRequestServiceImpl {
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &GitOpsPullRequestServiceImpl{
+		logger:                          logger,
+		cron:                            cron,
+		gitOpsPullRequestRepository:     gitOpsPullRequestRepository,
+		gitOperationService:             gitOperationService,
+		pipelineOverrideRepository:      pipelineOverrideRepository,
+		pipelineStatusTimelineService:   pipelineStatusTimelineService,
+		cdWorkflowCommonService:         cdWorkflowCommonService,
+		cdWorkflowRepository:            cdWorkflowRepository,
+		userDeploymentRequestRepository: userDeploymentRequestRepository,
+		pubSubClient:                    pubSubClient,
+	}
+	_, err := cron.AddFunc(fmt.Sprintf("@every %dm", cfg.PollCronTimeInMins), impl.SyncOpenPullRequests)
+	if err != nil {
+		logger.Errorw("error while configure cron job for gitops pull request sync", "err", err)
+		return impl
+	}
+	return impl
+}
+
+func (impl *GitOpsPullRequestServiceImpl) SyncOpenPullRequests() {
+	pullRequests, err := impl.gitOpsPullRequestRepository.FindByStatus(bean.PullRequestOpen)
+	if err != nil {
+		impl.logger.Errorw("error in fetching open gitops pull requests", "err", err)
+		return
+	}
+	for _, pullRequest := range pullRequests {
+		err = impl.syncPullRequest(pullRequest)
+		if err != nil {
+			impl.logger.Errorw("error in syncing gitops pull request", "id", pullRequest.Id, "number", pullRequest.PullRequestNumber, "err", err)
+		}
+	}
+}
+
+func (impl *GitOpsPullRequestServiceImpl) syncPullRequest(pullRequest *repository.GitOpsPullRequest) error {
+	runner, err := impl.cdWorkflowRepository.FindBasicWorkflowRunnerById(pullRequest.CdWorkflowRunnerId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cd workflow runner", "cdWfrId", pullRequest.CdWorkflowRunnerId, "err", err)
+		return err
+	}
+	if slices.Contains(cdWorkflow.WfrTerminalStatusList, runner.Status) {
+		// the deployment has been superseded or failed, the pull request must not be merged anymore
+		return impl.handleSupersededPullRequest(pullRequest, fmt.Sprintf("deployment moved to %s status before merge", runner.Status))
+	}
+	remotePullRequest, err := impl.gitOperationService.GetPullRequest(context.Background(), pullRequest.RepoName, pullRequest.PullRequestNumber)
+	if err != nil {
+		impl.logger.Errorw("error in fetching pull request from gitops provider", "repoName", pullRequest.RepoName, "number", pullRequest.PullRequestNumber, "err", err)
+		return err
+	}
+	switch remotePullRequest.State {
+	case bean.PullRequestMerged:
+		return impl.handleMergedPullRequest(pullRequest, remotePullRequest, runner)
+	case bean.PullRequestClosed:
+		isClaimed, err := impl.updateStatus(pullRequest, bean.PullRequestClosed, cdWorkflow.GITOPS_PULL_REQUEST_CLOSED)
+		if err != nil || !isClaimed {
+			return err
+		}
+		return impl.cdWorkflowCommonService.MarkDeploymentFailedForRunnerId(runner.Id, errors.New(cdWorkflow.GITOPS_PULL_REQUEST_CLOSED), pullRequest.CreatedBy)
+	}
+	return nil
+}
+
+// handleSupersededPullRequest closes the pull request of a deployment which will not proceed anymore and deletes its release branch
+func (impl *GitOpsPullRequestServiceImpl) handleSupersededPullRequest(pullRequest *repository.GitOpsPullRequest, message string) error {
+	isClaimed, err := impl.updateStatus(pullRequest, bean.PullRequestSuperseded, message)
+	if err != nil || !isClaimed {
+		return err
+	}
+	err = impl.gitOperationService.ClosePullRequest(context.Background(), pullRequest.RepoName, pullRequest.RepoUrl, pullRequest.PullRequestNumber, pullRequest.SourceBranch)
+	if err != nil {
+		impl.logger.Errorw("error in closing superseded pull request", "repoName", pullRequest.RepoName, "number", pullRequest.PullRequestNumber, "sourceBranch", pullRequest.SourceBranch, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *GitOpsPullRequestServiceImpl) handleMergedPullRequest(pullRequest *repository.GitOpsPullRequest,
+	remotePullRequest *bean.PullRequest, runner *pipelineConfig.CdWorkflowRunner) error {
+	userDeploymentRequest, err := impl.userDeploymentRequestRepository.FindByCdWfId(runner.CdWorkflowId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching user deployment request", "cdWfId", runner.CdWorkflowId, "err", err)
+		return err
+	}
+	tx, err := impl.gitOpsPullRequestRepository.StartTx()
+	if err != nil {
+		impl.logger.Errorw("error in starting transaction", "err", err)
+		return err
+	}
+	defer impl.gitOpsPullRequestRepository.RollbackTx(tx)
+	pullRequest.Status = bean.PullRequestMerged
+	pullRequest.MergeCommitHash = remotePullRequest.MergeCommitHash
+	pullRequest.UpdatedOn = time.Now()
+	pullRequest.UpdatedBy = userBean.SYSTEM_USER_ID
+	isClaimed, err := impl.gitOpsPullRequestRepository.UpdateStatusIfOpen(pullRequest, tx)
+	if err != nil {
+		impl.logger.Errorw("error in updating gitops pull request", "id", pullRequest.Id, "err", err)
+		return err
+	} else if !isClaimed {
+		// the merge has already been handled by another replica, which resumes the deployment
+		impl.logger.Infow("gitops pull request already moved out of open, skipping", "id", pullRequest.Id)
+		return nil
+	}
+	err = impl.pipelineOverrideRepository.UpdateCommitDetails(context.Background(), tx, pullRequest.PipelineOverrideId, remotePullRequest.MergeCommitHash, time.Now(), pullRequest.CreatedBy)
+	if err != nil {
+		impl.logger.Errorw("error in updating commit details in pipeline override", "pipelineOverrideId", pullRequest.PipelineOverrideId, "err", err)
+		return err
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(runner.Id, timelineStatus.TIMELINE_STATUS_GIT_COMMIT,
+		fmt.Sprintf(timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_MERGED, pullRequest.PullRequestUrl), pullRequest.CreatedBy)
+	_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving git commit timeline", "cdWfrId", runner.Id, "err", err)
+		return err
+	}
+	err = impl.gitOpsPullRequestRepository.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction", "err", err)
+		return err
+	}
+	// resuming the deployment, the git commit timeline makes the consumer skip the chart push and sync the argo application
+	err = impl.cdWorkflowCommonService.UpdateNonTerminalStatusInRunner(context.Background(), runner.Id, pullRequest.CreatedBy, cdWorkflow.WorkflowInitiated)
+	if err != nil {
+		impl.logger.Errorw("error in updating cd workflow runner status", "cdWfrId", runner.Id, "err", err)
+		return err
+	}
+	payload, err := json.Marshal(&eventProcessorBean.UserDeploymentRequest{Id: userDeploymentRequest.Id})
+	if err != nil {
+		impl.logger.Errorw("failed to marshal async CD deploy event request", "userDeploymentRequestId", userDeploymentRequest.Id, "err", err)
+		return err
+	}
+	err = impl.pubSubClient.Publish(pubsub.DEVTRON_CHART_GITOPS_INSTALL_TOPIC, string(payload))
+	if err != nil {
+		impl.logger.Errorw("error in publishing deployment request after pull request merge", "userDeploymentRequestId", userDeploymentRequest.Id, "err", err)
+		return err
+	}
+	return nil
+}
+
+// updateStatus moves the open pull request to state, isClaimed is false if another replica has already moved it
+func (impl *GitOpsPullRequestServiceImpl) updateStatus(pullRequest *repository.GitOpsPullRequest, state bean.PullRequestState, message string) (isClaimed bool, err error) {
+	pullRequest.Status = state
+	pullRequest.Message = message
+	pullRequest.UpdatedOn = time.Now()
+	pullRequest.UpdatedBy = userBean.SYSTEM_USER_ID
+	isClaimed, err = impl.gitOpsPullRequestRepository.UpdateStatusIfOpen(pullRequest, nil)
+	if err != nil {
+		impl.logger.Errorw("error in updating gitops pull request", "id", pullRequest.Id, "state", state, "err", err)
+		return false, err
+	} else if !isClaimed {
+		impl.logger.Infow("gitops pull request already moved out of open, skipping", "id", pullRequest.Id, "state", state)
+	}
+	return isClaimed, nil
+}
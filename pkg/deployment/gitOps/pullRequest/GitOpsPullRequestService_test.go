@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pullRequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
+	"github.com/devtron-labs/devtron/internal/sql/repository/chartConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
+	userDeploymentRequestRepo "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/repository"
+	"github.com/devtron-labs/devtron/pkg/workflow/cd"
+	"github.com/go-pg/pg"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+// gitOpsPullRequestRepositoryStub is shared by the replicas, it keeps the stored status of the pull requests
+type gitOpsPullRequestRepositoryStub struct {
+	repository.GitOpsPullRequestRepository
+	pullRequests map[int]repository.GitOpsPullRequest
+}
+
+func (impl *gitOpsPullRequestRepositoryStub) StartTx() (*pg.Tx, error) { return nil, nil }
+
+func (impl *gitOpsPullRequestRepositoryStub) RollbackTx(tx *pg.Tx) error { return nil }
+
+func (impl *gitOpsPullRequestRepositoryStub) CommitTx(tx *pg.Tx) error { return nil }
+
+func (impl *gitOpsPullRequestRepositoryStub) FindByStatus(state bean.PullRequestState) ([]*repository.GitOpsPullRequest, error) {
+	pullRequests := make([]*repository.GitOpsPullRequest, 0)
+	for _, pullRequest := range impl.pullRequests {
+		if pullRequest.Status == state {
+			pullRequestCopy := pullRequest
+			pullRequests = append(pullRequests, &pullRequestCopy)
+		}
+	}
+	return pullRequests, nil
+}
+
+func (impl *gitOpsPullRequestRepositoryStub) UpdateStatusIfOpen(model *repository.GitOpsPullRequest, tx *pg.Tx) (bool, error) {
+	if impl.pullRequests[model.Id].Status != bean.PullRequestOpen {
+		return false, nil
+	}
+	impl.pullRequests[model.Id] = *model
+	return true, nil
+}
+
+type cdWorkflowRepositoryStub struct {
+	pipelineConfig.CdWorkflowRepository
+	runner *pipelineConfig.CdWorkflowRunner
+}
+
+func (impl *cdWorkflowRepositoryStub) FindBasicWorkflowRunnerById(wfrId int) (*pipelineConfig.CdWorkflowRunner, error) {
+	return impl.runner, nil
+}
+
+type gitOperationServiceStub struct {
+	git.GitOperationService
+	remotePullRequest  *bean.PullRequest
+	closedPullRequests []string
+}
+
+func (impl *gitOperationServiceStub) GetPullRequest(ctx context.Context, repoName string, number int) (*bean.PullRequest, error) {
+	return impl.remotePullRequest, nil
+}
+
+func (impl *gitOperationServiceStub) ClosePullRequest(ctx context.Context, repoName, repoUrl string, number int, sourceBranch string) error {
+	impl.closedPullRequests = append(impl.closedPullRequests, sourceBranch)
+	return nil
+}
+
+type pipelineOverrideRepositoryStub struct {
+	chartConfig.PipelineOverrideRepository
+}
+
+func (impl *pipelineOverrideRepositoryStub) UpdateCommitDetails(ctx context.Context, tx *pg.Tx, id int, gitHash string, commitTime time.Time, userId int32) error {
+	return nil
+}
+
+type pipelineStatusTimelineServiceStub struct {
+	status.PipelineStatusTimelineService
+}
+
+func (impl *pipelineStatusTimelineServiceStub) NewDevtronAppPipelineStatusTimelineDbObject(cdWorkflowRunnerId int, timelineStatus timelineStatus.TimelineStatus, timelineDescription string, userId int32) *pipelineConfig.PipelineStatusTimeline {
+	return &pipelineConfig.PipelineStatusTimeline{CdWorkflowRunnerId: cdWorkflowRunnerId, Status: timelineStatus}
+}
+
+func (impl *pipelineStatusTimelineServiceStub) SaveTimelineIfNotAlreadyPresent(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) (bool, error) {
+	return true, nil
+}
+
+type cdWorkflowCommonServiceStub struct {
+	cd.CdWorkflowCommonService
+	resumedRunnerIds []int
+	failedRunnerIds  []int
+}
+
+func (impl *cdWorkflowCommonServiceStub) UpdateNonTerminalStatusInRunner(ctx context.Context, wfrId int, userId int32, status string) error {
+	impl.resumedRunnerIds = append(impl.resumedRunnerIds, wfrId)
+	return nil
+}
+
+func (impl *cdWorkflowCommonServiceStub) MarkDeploymentFailedForRunnerId(cdWfrId int, releaseErr error, triggeredBy int32) error {
+	impl.failedRunnerIds = append(impl.failedRunnerIds, cdWfrId)
+	return nil
+}
+
+type userDeploymentRequestRepositoryStub struct {
+	userDeploymentRequestRepo.UserDeploymentRequestRepository
+}
+
+func (impl *userDeploymentRequestRepositoryStub) FindByCdWfId(cdWfId int) (*userDeploymentRequestRepo.UserDeploymentRequest, error) {
+	return &userDeploymentRequestRepo.UserDeploymentRequest{Id: 9}, nil
+}
+
+// jetStreamStub records the published deployment requests
+type jetStreamStub struct {
+	nats.JetStreamContext
+	publishedMsgs []string
+}
+
+func (impl *jetStreamStub) StreamInfo(stream string, opts ...nats.JSOpt) (*nats.StreamInfo, error) {
+	return &nats.StreamInfo{}, nil
+}
+
+func (impl *jetStreamStub) UpdateStream(cfg *nats.StreamConfig, opts ...nats.JSOpt) (*nats.StreamInfo, error) {
+	return &nats.StreamInfo{}, nil
+}
+
+func (impl *jetStreamStub) Publish(subj string, data []byte, opts ...nats.PubOpt) (*nats.PubAck, error) {
+	impl.publishedMsgs = append(impl.publishedMsgs, string(data))
+	return &nats.PubAck{}, nil
+}
+
+type gitOpsPullRequestTestEnv struct {
+	pullRequestRepository   *gitOpsPullRequestRepositoryStub
+	gitOperationService     *gitOperationServiceStub
+	cdWorkflowCommonService *cdWorkflowCommonServiceStub
+	jetStream               *jetStreamStub
+}
+
+func (env *gitOpsPullRequestTestEnv) newReplica(t *testing.T, runner *pipelineConfig.CdWorkflowRunner) *GitOpsPullRequestServiceImpl {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	return &GitOpsPullRequestServiceImpl{
+		logger:                          logger,
+		gitOpsPullRequestRepository:     env.pullRequestRepository,
+		gitOperationService:             env.gitOperationService,
+		pipelineOverrideRepository:      &pipelineOverrideRepositoryStub{},
+		pipelineStatusTimelineService:   &pipelineStatusTimelineServiceStub{},
+		cdWorkflowCommonService:         env.cdWorkflowCommonService,
+		cdWorkflowRepository:            &cdWorkflowRepositoryStub{runner: runner},
+		userDeploymentRequestRepository: &userDeploymentRequestRepositoryStub{},
+		pubSubClient:                    &pubsub.PubSubClientServiceImpl{Logger: logger, NatsClient: &pubsub.NatsClient{JetStrCtxt: env.jetStream}},
+	}
+}
+
+func newGitOpsPullRequestTestEnv(remotePullRequest *bean.PullRequest) *gitOpsPullRequestTestEnv {
+	return &gitOpsPullRequestTestEnv{
+		pullRequestRepository: &gitOpsPullRequestRepositoryStub{pullRequests: map[int]repository.GitOpsPullRequest{
+			1: {Id: 1, CdWorkflowRunnerId: 21, PipelineOverrideId: 31, RepoName: "gitops-repo", PullRequestNumber: 4,
+				SourceBranch: "devtron/release-31-env-2", Status: bean.PullRequestOpen},
+		}},
+		gitOperationService:     &gitOperationServiceStub{remotePullRequest: remotePullRequest},
+		cdWorkflowCommonService: &cdWorkflowCommonServiceStub{},
+		jetStream:               &jetStreamStub{},
+	}
+}
+
+func TestGitOpsPullRequestServiceImpl_SyncOpenPullRequests(t *testing.T) {
+	// both replicas fetch the open pull requests before either of them acts on them
+	syncOnTwoReplicas := func(t *testing.T, env *gitOpsPullRequestTestEnv, runner *pipelineConfig.CdWorkflowRunner) {
+		replicas := []*GitOpsPullRequestServiceImpl{env.newReplica(t, runner), env.newReplica(t, runner)}
+		openPullRequests, err := env.pullRequestRepository.FindByStatus(bean.PullRequestOpen)
+		assert.NoError(t, err)
+		for _, replica := range replicas {
+			for _, pullRequest := range openPullRequests {
+				pullRequestCopy := *pullRequest
+				assert.NoError(t, replica.syncPullRequest(&pullRequestCopy))
+			}
+		}
+	}
+
+	t.Run("merged pull request resumes the deployment only on the replica which claims it", func(t *testing.T) {
+		env := newGitOpsPullRequestTestEnv(&bean.PullRequest{Number: 4, State: bean.PullRequestMerged, MergeCommitHash: "abc123"})
+		syncOnTwoReplicas(t, env, &pipelineConfig.CdWorkflowRunner{Id: 21, CdWorkflowId: 11, Status: cdWorkflow.WorkflowInProgress})
+
+		assert.Equal(t, []int{21}, env.cdWorkflowCommonService.resumedRunnerIds)
+		assert.Len(t, env.jetStream.publishedMsgs, 1)
+		pullRequest := env.pullRequestRepository.pullRequests[1]
+		assert.Equal(t, bean.PullRequestMerged, pullRequest.Status)
+		assert.Equal(t, "abc123", pullRequest.MergeCommitHash)
+		assert.Equal(t, int32(userBean.SYSTEM_USER_ID), pullRequest.UpdatedBy)
+	})
+
+	t.Run("closed pull request fails the deployment once", func(t *testing.T) {
+		env := newGitOpsPullRequestTestEnv(&bean.PullRequest{Number: 4, State: bean.PullRequestClosed})
+		syncOnTwoReplicas(t, env, &pipelineConfig.CdWorkflowRunner{Id: 21, CdWorkflowId: 11, Status: cdWorkflow.WorkflowInProgress})
+
+		assert.Equal(t, []int{21}, env.cdWorkflowCommonService.failedRunnerIds)
+		assert.Empty(t, env.cdWorkflowCommonService.resumedRunnerIds)
+		assert.Empty(t, env.jetStream.publishedMsgs)
+		assert.Equal(t, bean.PullRequestClosed, env.pullRequestRepository.pullRequests[1].Status)
+		assert.Equal(t, int32(userBean.SYSTEM_USER_ID), env.pullRequestRepository.pullRequests[1].UpdatedBy)
+	})
+
+	t.Run("pull request of a superseded deployment is closed and its branch deleted", func(t *testing.T) {
+		env := newGitOpsPullRequestTestEnv(&bean.PullRequest{Number: 4, State: bean.PullRequestOpen})
+		syncOnTwoReplicas(t, env, &pipelineConfig.CdWorkflowRunner{Id: 21, CdWorkflowId: 11, Status: cdWorkflow.WorkflowAborted})
+
+		assert.Equal(t, []string{"devtron/release-31-env-2"}, env.gitOperationService.closedPullRequests)
+		assert.Empty(t, env.cdWorkflowCommonService.resumedRunnerIds)
+		assert.Empty(t, env.jetStream.publishedMsgs)
+		assert.Equal(t, bean.PullRequestSuperseded, env.pullRequestRepository.pullRequests[1].Status)
+	})
+
+	t.Run("open pull request is left untouched", func(t *testing.T) {
+		env := newGitOpsPullRequestTestEnv(&bean.PullRequest{Number: 4, State: bean.PullRequestOpen})
+		syncOnTwoReplicas(t, env, &pipelineConfig.CdWorkflowRunner{Id: 21, CdWorkflowId: 11, Status: cdWorkflow.WorkflowInProgress})
+
+		assert.Empty(t, env.gitOperationService.closedPullRequests)
+		assert.Empty(t, env.cdWorkflowCommonService.resumedRunnerIds)
+		assert.Equal(t, bean.PullRequestOpen, env.pullRequestRepository.pullRequests[1].Status)
+	})
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type GitOpsPullRequest struct {
+	tableName          struct{}              `sql:"gitops_pull_request" pg:",discard_unknown_columns"`
+	Id                 int                   `sql:"id,pk"`
+	CdWorkflowRunnerId int                   `sql:"cd_workflow_runner_id,notnull"`
+	PipelineOverrideId int                   `sql:"pipeline_override_id,notnull"`
+	AppId              int                   `sql:"app_id,notnull"`
+	EnvId              int                   `sql:"env_id,notnull"`
+	RepoUrl            string                `sql:"repo_url,notnull"`
+	RepoName           string                `sql:"repo_name,notnull"`
+	PullRequestNumber  int                   `sql:"pull_request_number,notnull"`
+	PullRequestUrl     string                `sql:"pull_request_url"`
+	SourceBranch       string                `sql:"source_branch,notnull"`
+	TargetBranch       string                `sql:"target_branch,notnull"`
+	Status             bean.PullRequestState `sql:"status,notnull"`
+	MergeCommitHash    string                `sql:"merge_commit_hash"`
+	Message            string                `sql:"message"`
+	sql.AuditLog
+}
+
+type GitOpsPullRequestRepository interface {
+	sql.TransactionWrapper
+	Save(model *GitOpsPullRequest, tx *pg.Tx) error
+	// UpdateStatusIfOpen moves the pull request out of open with the status of the model,
+	// false is returned if it has already been moved, e.g. by the sync running on another replica
+	UpdateStatusIfOpen(model *GitOpsPullRequest, tx *pg.Tx) (bool, error)
+	FindByStatus(status bean.PullRequestState) ([]*GitOpsPullRequest, error)
+}
+
+type GitOpsPullRequestRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+	*sql.TransactionUtilImpl
+}
+
+func NewGitOpsPullRequestRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger,
+	transactionUtilImpl *sql.TransactionUtilImpl) *GitOpsPullRequestRepositoryImpl {
+	return &GitOpsPullRequestRepositoryImpl{
+		dbConnection:        dbConnection,
+		logger:              logger,
+		TransactionUtilImpl: transactionUtilImpl,
+	}
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) Save(model *GitOpsPullRequest, tx *pg.Tx) error {
+	if tx != nil {
+		return tx.Insert(model)
+	}
+	return impl.dbConnection.Insert(model)
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) UpdateStatusIfOpen(model *GitOpsPullRequest, tx *pg.Tx) (bool, error) {
+	query := impl.dbConnection.Model((*GitOpsPullRequest)(nil))
+	if tx != nil {
+		query = tx.Model((*GitOpsPullRequest)(nil))
+	}
+	res, err := query.
+		Set("status = ?", model.Status).
+		Set("merge_commit_hash = ?", model.MergeCommitHash).
+		Set("message = ?", model.Message).
+		Set("updated_on = ?", model.UpdatedOn).
+		Set("updated_by = ?", model.UpdatedBy).
+		Where("id = ?", model.Id).
+		Where("status = ?", bean.PullRequestOpen).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *GitOpsPullRequestRepositoryImpl) FindByStatus(status bean.PullRequestState) ([]*GitOpsPullRequest, error) {
+	var models []*GitOpsPullRequest
+	err := impl.dbConnection.Model(&models).
+		Where("status = ?", status).
+		Order("id ASC").
+		Select()
+	return models, err
+}
@@ -44,4 +44,11 @@ const (
 	CloneHttp         = "Clone Http"
 	CloneSSH          = "Clone Ssh"
 	CreateReadmeStage = "Create Readme"
+	SignCommitStage   = "Sign Commit"
+	DeleteBranchStage = "Delete Branch"
+
+	// GenericGitValidationRepoName is the repository which must be pre-created under the host of the generic git provider,
+	// the dry run pushes a commit to a temporary branch of it as repositories cannot be created on a generic git server
+	GenericGitValidationRepoName = "devtron-gitops-validation"
+	DryrunBranchName             = "devtron-dryrun-"
 )
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -33,7 +34,9 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/adapter"
 	bean2 "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/commandManager"
 	gitOpsBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/validation/bean"
 	globalUtil "github.com/devtron-labs/devtron/util"
 	"github.com/microsoft/azure-devops-go-api/azuredevops"
@@ -43,7 +46,8 @@ import (
 
 type GitOpsValidationService interface {
 	// GitOpsValidateDryRun performs the following validations:
-	// "Get Repo URL", "Create Repo (if it doesn't exist)", "Create Readme", "Clone Http", "Clone Ssh", "Commit On Rest", "Push", "Delete Repo"
+	// "Sign Commit" (if a signing key is configured), "Get Repo URL", "Create Repo (if it doesn't exist)", "Create Readme", "Clone Http", "Clone Ssh", "Commit On Rest", "Push", "Delete Repo"
+	// for the generic git provider a temporary branch of the pre-created validation repository is pushed and deleted instead of a repository
 	// And returns: gitOps.DetailedErrorGitOpsConfigResponse
 	GitOpsValidateDryRun(isArgoModuleInstalled bool, config *apiBean.GitOpsConfigDto) apiBean.DetailedErrorGitOpsConfigResponse
 	// ValidateGitOpsRepoUrl performs the following validations:
@@ -89,11 +93,26 @@ func (impl *GitOpsValidationServiceImpl) GitOpsValidateDryRun(isArgoModuleInstal
 	// before the skip check so the create/update save path, which shares this config pointer,
 	// also sees the resolved auth mode.
 	//bean2.ResolveBitbucketCloudAuthMode(config)
+	// the signing key is validated even if the dry run is skipped, as every gitops commit is signed with it
+	if !config.SigningConfig.IsEmpty() {
+		signingKeyErr := commandManager.ValidateSigningKey(adapter.ConvertGitOpsConfigToGitConfig(config).GetSigningKey())
+		if signingKeyErr != nil {
+			impl.logger.Errorw("error in validating gitops commit signing key", "keyType", config.SigningConfig.KeyType, "err", signingKeyErr)
+			return impl.convertDetailedErrorToResponse(git.DetailedErrorGitOpsConfigActions{
+				StageErrorMap: map[string]error{gitOpsBean.SignCommitStage: signingKeyErr},
+				ValidatedOn:   time.Now(),
+			})
+		}
+	}
 	if config.AllowCustomRepository || !isArgoModuleInstalled {
 		return apiBean.DetailedErrorGitOpsConfigResponse{
 			ValidationSkipped: true,
 		}
 	}
+	// generic git repositories are pre-created by the user, so a dry run repository cannot be created and deleted
+	if strings.ToUpper(config.Provider) == bean2.GENERIC_GIT_PROVIDER {
+		return impl.genericGitValidateDryRun(config)
+	}
 	detailedErrorGitOpsConfigActions := git.DetailedErrorGitOpsConfigActions{}
 	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
 
@@ -120,6 +139,9 @@ func (impl *GitOpsValidationServiceImpl) GitOpsValidateDryRun(isArgoModuleInstal
 
 	detailedErrorGitOpsConfigActions.StageErrorMap = detailedErrorCreateRepo.StageErrorMap
 	detailedErrorGitOpsConfigActions.SuccessfulStages = detailedErrorCreateRepo.SuccessfulStages
+	if !config.SigningConfig.IsEmpty() {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, gitOpsBean.SignCommitStage)
+	}
 
 	for stage, stageErr := range detailedErrorGitOpsConfigActions.StageErrorMap {
 		if stage == gitOpsBean.CreateRepoStage || stage == gitOpsBean.GetRepoUrlStage {
@@ -176,6 +198,79 @@ func (impl *GitOpsValidationServiceImpl) GitOpsValidateDryRun(isArgoModuleInstal
 	return detailedErrorGitOpsConfigResponse
 }
 
+// genericGitValidateDryRun clones the pre-created validation repository and pushes a commit to a temporary branch, which is deleted afterwards
+func (impl *GitOpsValidationServiceImpl) genericGitValidateDryRun(config *apiBean.GitOpsConfigDto) apiBean.DetailedErrorGitOpsConfigResponse {
+	detailedErrorGitOpsConfigActions := git.DetailedErrorGitOpsConfigActions{}
+	detailedErrorGitOpsConfigActions.StageErrorMap = make(map[string]error)
+	client, gitService, err := impl.gitFactory.NewClientForValidation(config)
+	if err != nil {
+		impl.logger.Errorw("error in creating new client for validation", "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[fmt.Sprintf("error in connecting with %s", strings.ToUpper(config.Provider))] = impl.extractErrorMessageByProvider(err, config.Provider)
+		detailedErrorGitOpsConfigActions.ValidatedOn = time.Now()
+		return impl.convertDetailedErrorToResponse(detailedErrorGitOpsConfigActions)
+	}
+	userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(config.UserId)
+	config.UserEmailId = userEmailId
+	config.GitRepoName = gitOpsBean.GenericGitValidationRepoName
+	config.TargetRevision = globalUtil.GetDefaultTargetRevision()
+	repoUrl, _, err := client.GetRepoUrl(config)
+	if err != nil {
+		impl.logger.Errorw("error in reaching generic git validation repository", "repoName", config.GitRepoName, "err", err)
+		err = fmt.Errorf("repository %s must be pre-created for validation: %v", config.GitRepoName, err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[fmt.Sprintf("error in connecting with %s", strings.ToUpper(config.Provider))] = impl.extractErrorMessageByProvider(err, config.Provider)
+		detailedErrorGitOpsConfigActions.ValidatedOn = time.Now()
+		return impl.convertDetailedErrorToResponse(detailedErrorGitOpsConfigActions)
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, gitOpsBean.GetRepoUrlStage)
+	dryRunId := globalUtil.Generate(6)
+	chartDir := fmt.Sprintf("%s-%s", config.GitRepoName, dryRunId)
+	clonedDir, err := gitService.Clone(repoUrl, chartDir, config.TargetRevision)
+	if len(clonedDir) > 0 {
+		defer impl.chartTemplateService.CleanDir(clonedDir)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in cloning repo", "url", repoUrl, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[gitOpsBean.CloneStage] = impl.extractErrorMessageByProvider(err, config.Provider)
+		detailedErrorGitOpsConfigActions.ValidatedOn = time.Now()
+		return impl.convertDetailedErrorToResponse(detailedErrorGitOpsConfigActions)
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, gitOpsBean.CloneStage)
+	err = os.WriteFile(filepath.Join(clonedDir, fmt.Sprintf("dryrun-%s.txt", dryRunId)), []byte("@devtron"), 0666)
+	if err != nil {
+		impl.logger.Errorw("error in writing dry run file", "clonedDir", clonedDir, "err", err)
+		detailedErrorGitOpsConfigActions.StageErrorMap[gitOpsBean.CommitOnRestStage] = err
+		detailedErrorGitOpsConfigActions.ValidatedOn = time.Now()
+		return impl.convertDetailedErrorToResponse(detailedErrorGitOpsConfigActions)
+	}
+	dryRunBranch := gitOpsBean.DryrunBranchName + dryRunId
+	ctx := context.Background()
+	commit, err := gitService.CommitAndPushToBranch(ctx, clonedDir, dryRunBranch, "dry run commit", userName, userEmailId)
+	if err != nil {
+		impl.logger.Errorw("error in commit and pushing dry run branch", "branch", dryRunBranch, "err", err)
+		if commit == "" {
+			detailedErrorGitOpsConfigActions.StageErrorMap[gitOpsBean.CommitOnRestStage] = err
+		} else {
+			detailedErrorGitOpsConfigActions.StageErrorMap[gitOpsBean.PushStage] = impl.extractErrorMessageByProvider(err, config.Provider)
+		}
+		detailedErrorGitOpsConfigActions.ValidatedOn = time.Now()
+		return impl.convertDetailedErrorToResponse(detailedErrorGitOpsConfigActions)
+	}
+	detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, gitOpsBean.CommitOnRestStage, gitOpsBean.PushStage)
+	if !config.SigningConfig.IsEmpty() {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, gitOpsBean.SignCommitStage)
+	}
+	err = gitService.DeleteRemoteBranch(ctx, clonedDir, dryRunBranch)
+	if err != nil {
+		// like the deletion of the dry run repository, a failure here does not fail the validation
+		impl.logger.Errorw("error in deleting dry run branch", "branch", dryRunBranch, "err", err)
+		detailedErrorGitOpsConfigActions.DeleteRepoFailed = true
+	} else {
+		detailedErrorGitOpsConfigActions.SuccessfulStages = append(detailedErrorGitOpsConfigActions.SuccessfulStages, gitOpsBean.DeleteBranchStage)
+	}
+	detailedErrorGitOpsConfigActions.ValidatedOn = time.Now()
+	return impl.convertDetailedErrorToResponse(detailedErrorGitOpsConfigActions)
+}
+
 func (impl *GitOpsValidationServiceImpl) ValidateGitOpsRepoUrl(request *gitOpsBean.ValidateGitOpsRepoUrlRequest) (string, error) {
 	// Validate: Organisational URL starts
 	sanitiseGitRepoUrl, err := impl.validateForGitOpsOrg(request)
@@ -316,6 +411,12 @@ func (impl *GitOpsValidationServiceImpl) extractErrorMessageByProvider(err error
 		return fmt.Errorf("bitbucket client error: %s", err.Error())
 	case bean2.GITHUB_PROVIDER:
 		return fmt.Errorf("github client error: %s", err.Error())
+	case bean2.GITEA_PROVIDER:
+		return fmt.Errorf("gitea client error: %s", err.Error())
+	case bean2.BITBUCKET_SERVER_PROVIDER:
+		return fmt.Errorf("bitbucket server client error: %s", err.Error())
+	case bean2.GENERIC_GIT_PROVIDER:
+		return fmt.Errorf("git client error: %s", err.Error())
 	}
 	return err
 }
@@ -349,6 +450,18 @@ func (impl *GitOpsValidationServiceImpl) getValidationErrorForNonOrganisationalU
 	case bean2.AZURE_DEVOPS_PROVIDER:
 		errorMessageKey = "The repository must belong to Azure DevOps Project"
 		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.AzureProjectName)
+
+	case bean2.GITEA_PROVIDER:
+		errorMessageKey = "The repository must belong to Gitea organization"
+		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.GitHubOrgId)
+
+	case bean2.BITBUCKET_SERVER_PROVIDER:
+		errorMessageKey = "The repository must belong to Bitbucket Server project"
+		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.BitBucketProjectKey)
+
+	case bean2.GENERIC_GIT_PROVIDER:
+		errorMessageKey = "The repository must belong to git host"
+		errorMessage = fmt.Sprintf("%s as configured in global configurations > GitOps", activeGitOpsConfig.Host)
 	}
 	apiErrorMsg := fmt.Sprintf("%s: %s", errorMessageKey, errorMessage)
 	return util.NewApiError(http.StatusBadRequest, apiErrorMsg, apiErrorMsg).
@@ -19,7 +19,12 @@ package gitOps
 import (
 	"github.com/devtron-labs/devtron/internal/sql/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift"
+	driftRead "github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/read"
+	driftRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/drift/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest"
+	pullRequestRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/validation"
 	"github.com/google/wire"
 )
@@ -39,6 +44,23 @@ var GitOpsWireSet = wire.NewSet(
 
 	git.NewGitCredentialServiceImpl,
 	wire.Bind(new(git.GitCredentialService), new(*git.GitCredentialServiceImpl)),
+
+	pullRequestRepository.NewGitOpsPullRequestRepositoryImpl,
+	wire.Bind(new(pullRequestRepository.GitOpsPullRequestRepository), new(*pullRequestRepository.GitOpsPullRequestRepositoryImpl)),
+
+	pullRequest.GetGitOpsPullRequestConfig,
+	pullRequest.NewGitOpsPullRequestServiceImpl,
+	wire.Bind(new(pullRequest.GitOpsPullRequestService), new(*pullRequest.GitOpsPullRequestServiceImpl)),
+
+	driftRepository.NewGitOpsDriftRepositoryImpl,
+	wire.Bind(new(driftRepository.GitOpsDriftRepository), new(*driftRepository.GitOpsDriftRepositoryImpl)),
+
+	driftRead.NewGitOpsDriftReadServiceImpl,
+	wire.Bind(new(driftRead.GitOpsDriftReadService), new(*driftRead.GitOpsDriftReadServiceImpl)),
+
+	drift.GetGitOpsDriftConfig,
+	drift.NewGitOpsDriftDetectionServiceImpl,
+	wire.Bind(new(drift.GitOpsDriftDetectionService), new(*drift.GitOpsDriftDetectionServiceImpl)),
 )
 
 var GitOpsEAWireSet = wire.NewSet(
@@ -32,6 +32,8 @@ import (
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/config"
 	gitOpsBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/config/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/gitOps/git"
+	gitBean "github.com/devtron-labs/devtron/pkg/deployment/gitOps/git/bean"
+	pullRequestRepository "github.com/devtron-labs/devtron/pkg/deployment/gitOps/pullRequest/repository"
 	"github.com/devtron-labs/devtron/pkg/deployment/manifest/deploymentTemplate/chartRef"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	globalUtil "github.com/devtron-labs/devtron/util"
@@ -60,6 +62,7 @@ type GitOpsManifestPushServiceImpl struct {
 	argoClientWrapperService      argocdServer.ArgoClientWrapperService
 	deploymentConfigService       common.DeploymentConfigService
 	chartTemplateService          util.ChartTemplateService
+	gitOpsPullRequestRepository   pullRequestRepository.GitOpsPullRequestRepository
 	*sql.TransactionUtilImpl
 }
 
@@ -74,7 +77,8 @@ func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
 	argoClientWrapperService argocdServer.ArgoClientWrapperService,
 	transactionUtilImpl *sql.TransactionUtilImpl,
 	deploymentConfigService common.DeploymentConfigService,
-	chartTemplateService util.ChartTemplateService) *GitOpsManifestPushServiceImpl {
+	chartTemplateService util.ChartTemplateService,
+	gitOpsPullRequestRepository pullRequestRepository.GitOpsPullRequestRepository) *GitOpsManifestPushServiceImpl {
 	return &GitOpsManifestPushServiceImpl{
 		logger:                        logger,
 		pipelineStatusTimelineService: pipelineStatusTimelineService,
@@ -88,6 +92,7 @@ func NewGitOpsManifestPushServiceImpl(logger *zap.SugaredLogger,
 		TransactionUtilImpl:           transactionUtilImpl,
 		deploymentConfigService:       deploymentConfigService,
 		chartTemplateService:          chartTemplateService,
+		gitOpsPullRequestRepository:   gitOpsPullRequestRepository,
 	}
 }
 
@@ -188,6 +193,19 @@ func (impl *GitOpsManifestPushServiceImpl) PushChart(ctx context.Context, manife
 		return manifestPushResponse
 	}
 
+	// 5. Raise a pull request with the chart values for environments which require it,
+	// the commit details are updated once the pull request is merged.
+	// Chart templates pushed in step 4 are still committed to the target revision directly.
+	if manifestPushTemplate.RaisePullRequest {
+		pullRequest, err := impl.raisePullRequestForValues(newCtx, manifestPushTemplate)
+		if err != nil {
+			manifestPushResponse.Error = err
+			impl.SaveTimelineForError(manifestPushTemplate, err)
+			return manifestPushResponse
+		}
+		manifestPushResponse.PullRequestNumber = pullRequest.Number
+		return manifestPushResponse
+	}
 	// 5. Commit chart values to Git Repository
 	commitHash, commitTime, err := impl.commitValuesToGit(newCtx, manifestPushTemplate)
 	if err != nil {
@@ -254,13 +272,9 @@ func (impl *GitOpsManifestPushServiceImpl) pushChartToGitRepo(ctx context.Contex
 	return nil
 }
 
-func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) (commitHash string, commitTime time.Time, err error) {
-	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.commitValuesToGit")
-	defer span.End()
-	commitHash = ""
-	commitTime = time.Time{}
+func (impl *GitOpsManifestPushServiceImpl) getChartConfigForValues(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) *git.ChartConfig {
 	chartRepoName := impl.gitOpsConfigReadService.GetGitOpsRepoNameFromUrl(manifestPushTemplate.RepoUrl)
-	_, span = otel.Tracer("orchestrator").Start(newCtx, "gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit")
+	_, span := otel.Tracer("orchestrator").Start(ctx, "gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit")
 	//getting username & emailId for commit author data
 	userEmailId, userName := impl.gitOpsConfigReadService.GetUserEmailIdAndNameForGitOpsCommit(manifestPushTemplate.UserId)
 	span.End()
@@ -277,6 +291,15 @@ func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context
 	}
 	bitBucketBaseDir := fmt.Sprintf("%d-%s", manifestPushTemplate.PipelineOverrideId, impl.chartTemplateService.GetDir())
 	chartGitAttr.SetBitBucketBaseDir(bitBucketBaseDir)
+	return chartGitAttr
+}
+
+func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) (commitHash string, commitTime time.Time, err error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.commitValuesToGit")
+	defer span.End()
+	commitHash = ""
+	commitTime = time.Time{}
+	chartGitAttr := impl.getChartConfigForValues(newCtx, manifestPushTemplate)
 	commitHash, commitTime, err = impl.gitOperationService.CommitValues(newCtx, chartGitAttr)
 	if err != nil {
 		impl.logger.Errorw("error in git commit", "err", err)
@@ -285,6 +308,61 @@ func (impl *GitOpsManifestPushServiceImpl) commitValuesToGit(ctx context.Context
 	return commitHash, commitTime, nil
 }
 
+// raisePullRequestForValues pushes the values to a release branch and raises a pull request against the target revision.
+// The pull request is tracked by GitOpsPullRequestService which resumes the deployment once it is merged.
+func (impl *GitOpsManifestPushServiceImpl) raisePullRequestForValues(ctx context.Context, manifestPushTemplate *bean.ManifestPushTemplate) (*gitBean.PullRequest, error) {
+	newCtx, span := otel.Tracer("orchestrator").Start(ctx, "GitOpsManifestPushServiceImpl.raisePullRequestForValues")
+	defer span.End()
+	chartGitAttr := impl.getChartConfigForValues(newCtx, manifestPushTemplate)
+	sourceBranch := fmt.Sprintf("devtron/release-%d-env-%d", manifestPushTemplate.PipelineOverrideId, manifestPushTemplate.TargetEnvironmentId)
+	pullRequest, err := impl.gitOperationService.CommitValuesToPullRequest(newCtx, chartGitAttr, manifestPushTemplate.RepoUrl, sourceBranch)
+	if err != nil {
+		impl.logger.Errorw("error in raising pull request for values", "pipelineOverrideId", manifestPushTemplate.PipelineOverrideId, "err", err)
+		return nil, err
+	}
+	targetBranch := chartGitAttr.TargetRevision
+	if len(targetBranch) == 0 {
+		targetBranch = globalUtil.GetDefaultTargetRevision()
+	}
+	model := &pullRequestRepository.GitOpsPullRequest{
+		CdWorkflowRunnerId: manifestPushTemplate.WorkflowRunnerId,
+		PipelineOverrideId: manifestPushTemplate.PipelineOverrideId,
+		AppId:              manifestPushTemplate.AppId,
+		EnvId:              manifestPushTemplate.EnvironmentId,
+		RepoUrl:            manifestPushTemplate.RepoUrl,
+		RepoName:           chartGitAttr.ChartRepoName,
+		PullRequestNumber:  pullRequest.Number,
+		PullRequestUrl:     pullRequest.Url,
+		SourceBranch:       sourceBranch,
+		TargetBranch:       targetBranch,
+		Status:             gitBean.PullRequestOpen,
+	}
+	model.CreateAuditLog(manifestPushTemplate.UserId)
+	tx, err := impl.TransactionUtilImpl.StartTx()
+	defer impl.TransactionUtilImpl.RollbackTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in transaction begin in saving gitops pull request", "err", err)
+		return nil, err
+	}
+	err = impl.gitOpsPullRequestRepository.Save(model, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving gitops pull request", "pullRequest", model, "err", err)
+		return nil, err
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(manifestPushTemplate.WorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED, fmt.Sprintf(timelineStatus.TIMELINE_DESCRIPTION_GIT_PULL_REQUEST_RAISED, pullRequest.Url), manifestPushTemplate.UserId)
+	_, err = impl.pipelineStatusTimelineService.SaveTimelineIfNotAlreadyPresent(timeline, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving pull request raised timeline", "timeline", timeline, "err", err)
+		return nil, err
+	}
+	err = impl.TransactionUtilImpl.CommitTx(tx)
+	if err != nil {
+		impl.logger.Errorw("error in committing transaction to save gitops pull request", "err", err)
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
 func (impl *GitOpsManifestPushServiceImpl) SaveTimelineForError(manifestPushTemplate *bean.ManifestPushTemplate, gitCommitErr error) {
 	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(manifestPushTemplate.WorkflowRunnerId, timelineStatus.TIMELINE_STATUS_GIT_COMMIT_FAILED, fmt.Sprintf("Git commit failed - %v", gitCommitErr), manifestPushTemplate.UserId)
 	timelineErr := impl.pipelineStatusTimelineService.SaveTimeline(timeline, nil)
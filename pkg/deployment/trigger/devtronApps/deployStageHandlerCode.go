@@ -704,6 +704,9 @@ func (impl *HandlerServiceImpl) TriggerRelease(ctx context.Context, overrideRequ
 	if err != nil {
 		return 0, manifestPushTemplate, err
 	}
+	if valuesOverrideResponse.IsPullRequestRaised() {
+		return releaseNo, valuesOverrideResponse.ManifestPushTemplate, nil
+	}
 
 	err = impl.triggerReleaseSuccessHandling(triggerEvent, overrideRequest, valuesOverrideResponse, helmManifest)
 	if err != nil {
@@ -746,6 +749,13 @@ func (impl *HandlerServiceImpl) performGitOps(ctx context.Context,
 		// Update GitOps repo url after repo new repo created
 		valuesOverrideResponse.DeploymentConfig.SetRepoURL(manifestPushResponse.NewGitRepoUrl)
 	}
+	if manifestPushResponse.IsPullRequestRaised() {
+		err = impl.cdWorkflowCommonService.UpdateNonTerminalStatusInRunner(newCtx, overrideRequest.WfrId, overrideRequest.UserId, cdWorkflow.WorkflowAwaitingMerge)
+		if err != nil {
+			impl.logger.Errorw("error in updating the workflow runner status", "err", err)
+			return err
+		}
+	}
 	valuesOverrideResponse.ManifestPushTemplate = manifestPushTemplate
 	return nil
 }
@@ -777,6 +787,11 @@ func (impl *HandlerServiceImpl) buildTriggerEventForOverrideRequest(overrideRequ
 		slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_INITIATED) {
 		// git commit has already been performed
 		triggerEvent.PerformChartPush = false
+	} else if slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_GIT_PULL_REQUEST_RAISED) {
+		// the values are in an open pull request, the GIT_COMMIT timeline is saved once it is merged
+		impl.logger.Infow("deployment is awaiting gitops pull request merge. skipping", "cdWfrId", overrideRequest.WfrId)
+		skipRequest = true
+		return triggerEvent, skipRequest, nil
 	}
 	if slices.Contains(timelineStatuses, timelineStatus.TIMELINE_STATUS_ARGOCD_SYNC_COMPLETED) {
 		// ArgoCd sync has already been performed
@@ -796,6 +811,11 @@ func (impl *HandlerServiceImpl) triggerPipeline(overrideRequest *bean3.ValuesOve
 			return releaseNo, err
 		}
 		impl.logger.Debugw("chart push operation completed successfully", "cdWfrId", overrideRequest.WfrId)
+		if valuesOverrideResponse.IsPullRequestRaised() {
+			// the deployment is resumed by the gitops pull request service once the pull request is merged
+			impl.logger.Infow("gitops pull request raised, deployment is awaiting merge", "cdWfrId", overrideRequest.WfrId)
+			return valuesOverrideResponse.PipelineOverride.PipelineReleaseCounter, nil
+		}
 	}
 
 	if triggerEvent.PerformDeploymentOnCluster {
@@ -868,6 +888,8 @@ func (impl *HandlerServiceImpl) buildManifestPushTemplate(overrideRequest *bean3
 		manifestPushTemplate.ReleaseMode = valuesOverrideResponse.DeploymentConfig.ReleaseMode
 		manifestPushTemplate.IsCustomGitRepository = common.IsCustomGitOpsRepo(valuesOverrideResponse.DeploymentConfig.ConfigType)
 		manifestPushTemplate.ArgoSyncNeeded = valuesOverrideResponse.DeploymentConfig.IsArgoAppSyncAndRefreshSupported()
+		manifestPushTemplate.RaisePullRequest = valuesOverrideResponse.DeploymentConfig.IsAcdRelease() &&
+			valuesOverrideResponse.EnvOverride.Environment != nil && valuesOverrideResponse.EnvOverride.Environment.GitOpsPullRequestEnabled
 	}
 	return manifestPushTemplate, nil
 }
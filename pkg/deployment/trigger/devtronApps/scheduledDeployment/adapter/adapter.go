@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adapter
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/repository"
+	"time"
+)
+
+func GetScheduledDeploymentDto(model *repository.ScheduledDeployment) *bean.ScheduledDeployment {
+	return &bean.ScheduledDeployment{
+		Id:                     model.Id,
+		AppId:                  model.AppId,
+		EnvId:                  model.EnvId,
+		PipelineId:             model.PipelineId,
+		CdWorkflowType:         model.CdWorkflowType,
+		CiArtifactId:           model.CiArtifactId,
+		UseLatestArtifact:      model.UseLatestArtifact,
+		ScheduleType:           model.ScheduleType,
+		CronExpression:         model.CronExpression,
+		Timezone:               model.Timezone,
+		Status:                 model.Status,
+		NextTriggerOn:          getTimePtr(model.NextTriggerOn),
+		LastTriggeredOn:        getTimePtr(model.LastTriggeredOn),
+		LastCdWorkflowRunnerId: model.LastCdWorkflowRunnerId,
+		LastCiArtifactId:       model.LastCiArtifactId,
+		Message:                model.Message,
+		ScheduledBy:            model.CreatedBy,
+		CreatedOn:              model.CreatedOn,
+	}
+}
+
+func getTimePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"time"
+)
+
+type ScheduleType string
+
+const (
+	// ScheduleTypeOnce triggers the deployment a single time at the scheduled time
+	ScheduleTypeOnce ScheduleType = "ONCE"
+	// ScheduleTypeRecurring triggers the deployment on every occurrence of the cron expression until cancelled
+	ScheduleTypeRecurring ScheduleType = "RECURRING"
+)
+
+type ScheduledDeploymentStatus string
+
+const (
+	ScheduledDeploymentScheduled ScheduledDeploymentStatus = "Scheduled"
+	ScheduledDeploymentTriggered ScheduledDeploymentStatus = "Triggered"
+	ScheduledDeploymentFailed    ScheduledDeploymentStatus = "Failed"
+	ScheduledDeploymentCancelled ScheduledDeploymentStatus = "Cancelled"
+)
+
+type ScheduledDeploymentRequest struct {
+	AppId          int                  `json:"appId" validate:"required"`
+	PipelineId     int                  `json:"pipelineId" validate:"required"`
+	CdWorkflowType apiBean.WorkflowType `json:"cdWorkflowType" validate:"omitempty,oneof=PRE DEPLOY POST"`
+	// CiArtifactId is the artifact to deploy, not required when UseLatestArtifact is set
+	CiArtifactId int `json:"ciArtifactId"`
+	// UseLatestArtifact resolves the artifact at fire time to the latest image built by the ci pipeline of the cd pipeline
+	UseLatestArtifact bool         `json:"useLatestArtifact"`
+	ScheduleType      ScheduleType `json:"scheduleType" validate:"oneof=ONCE RECURRING"`
+	// ScheduledAt is the time of a ONCE deployment
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+	// CronExpression and Timezone are used for a RECURRING deployment, the timezone defaults to UTC
+	CronExpression string `json:"cronExpression,omitempty"`
+	Timezone       string `json:"timezone,omitempty"`
+	UserId         int32  `json:"-"`
+}
+
+type ScheduledDeployment struct {
+	Id                     int                       `json:"id"`
+	AppId                  int                       `json:"appId"`
+	EnvId                  int                       `json:"envId"`
+	PipelineId             int                       `json:"pipelineId"`
+	CdWorkflowType         apiBean.WorkflowType      `json:"cdWorkflowType"`
+	CiArtifactId           int                       `json:"ciArtifactId,omitempty"`
+	UseLatestArtifact      bool                      `json:"useLatestArtifact"`
+	ScheduleType           ScheduleType              `json:"scheduleType"`
+	CronExpression         string                    `json:"cronExpression,omitempty"`
+	Timezone               string                    `json:"timezone,omitempty"`
+	Status                 ScheduledDeploymentStatus `json:"status"`
+	NextTriggerOn          *time.Time                `json:"nextTriggerOn,omitempty"`
+	LastTriggeredOn        *time.Time                `json:"lastTriggeredOn,omitempty"`
+	LastCdWorkflowRunnerId int                       `json:"lastCdWorkflowRunnerId,omitempty"`
+	LastCiArtifactId       int                       `json:"lastCiArtifactId,omitempty"`
+	Message                string                    `json:"message,omitempty"`
+	ScheduledBy            int32                     `json:"scheduledBy"`
+	ScheduledByEmail       string                    `json:"scheduledByEmail,omitempty"`
+	CreatedOn              time.Time                 `json:"createdOn"`
+}
+
+type ScheduledDeploymentFilter struct {
+	AppId      int
+	PipelineId int
+	// Statuses filters the scheduled deployments by status, all statuses are returned when empty
+	Statuses []ScheduledDeploymentStatus
+}
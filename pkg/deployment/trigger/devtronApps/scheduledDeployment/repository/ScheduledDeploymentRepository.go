@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/bean"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ScheduledDeployment struct {
+	tableName              struct{}                       `sql:"scheduled_deployment" pg:",discard_unknown_columns"`
+	Id                     int                            `sql:"id,pk"`
+	AppId                  int                            `sql:"app_id,notnull"`
+	EnvId                  int                            `sql:"env_id,notnull"`
+	PipelineId             int                            `sql:"pipeline_id,notnull"`
+	CdWorkflowType         apiBean.WorkflowType           `sql:"cd_workflow_type,notnull"`
+	CiArtifactId           int                            `sql:"ci_artifact_id"`
+	UseLatestArtifact      bool                           `sql:"use_latest_artifact,notnull"`
+	ScheduleType           bean.ScheduleType              `sql:"schedule_type,notnull"`
+	CronExpression         string                         `sql:"cron_expression"`
+	Timezone               string                         `sql:"timezone"`
+	Status                 bean.ScheduledDeploymentStatus `sql:"status,notnull"`
+	NextTriggerOn          time.Time                      `sql:"next_trigger_on,type:timestamptz"`
+	LastTriggeredOn        time.Time                      `sql:"last_triggered_on,type:timestamptz"`
+	LastCdWorkflowRunnerId int                            `sql:"last_cd_workflow_runner_id"`
+	LastCiArtifactId       int                            `sql:"last_ci_artifact_id"`
+	Message                string                         `sql:"message"`
+	sql.AuditLog
+}
+
+type ScheduledDeploymentRepository interface {
+	Save(model *ScheduledDeployment) error
+	Update(model *ScheduledDeployment) error
+	FindById(id int) (*ScheduledDeployment, error)
+	FindByFilter(filter *bean.ScheduledDeploymentFilter) ([]*ScheduledDeployment, error)
+	// FindDue returns the scheduled deployments of active cd pipelines which were due on or before the given time
+	FindDue(dueOn time.Time) ([]*ScheduledDeployment, error)
+	// MarkTriggered moves a due scheduled deployment to the given status and next trigger time. It returns false if
+	// the scheduled deployment was already picked up by another replica or cancelled since it was fetched.
+	MarkTriggered(id int, dueOn time.Time, status bean.ScheduledDeploymentStatus, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error)
+	// MarkCancelled cancels the scheduled deployment if it is still scheduled
+	MarkCancelled(id int, userId int32) (bool, error)
+	// MarkFailed fails a triggered ONCE scheduled deployment
+	MarkFailed(id int) error
+	// UpdateTriggerResult records the outcome of the last trigger of the scheduled deployment
+	UpdateTriggerResult(id int, cdWorkflowRunnerId int, ciArtifactId int, message string) error
+}
+
+type ScheduledDeploymentRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewScheduledDeploymentRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *ScheduledDeploymentRepositoryImpl {
+	return &ScheduledDeploymentRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) Save(model *ScheduledDeployment) error {
+	return impl.dbConnection.Insert(model)
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) Update(model *ScheduledDeployment) error {
+	return impl.dbConnection.Update(model)
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindById(id int) (*ScheduledDeployment, error) {
+	model := &ScheduledDeployment{}
+	err := impl.dbConnection.Model(model).
+		Where("id = ?", id).
+		Select()
+	return model, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindByFilter(filter *bean.ScheduledDeploymentFilter) ([]*ScheduledDeployment, error) {
+	var models []*ScheduledDeployment
+	query := impl.dbConnection.Model(&models).
+		Where("app_id = ?", filter.AppId)
+	if filter.PipelineId > 0 {
+		query = query.Where("pipeline_id = ?", filter.PipelineId)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN (?)", pg.In(filter.Statuses))
+	}
+	err := query.Order("id DESC").Select()
+	return models, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) FindDue(dueOn time.Time) ([]*ScheduledDeployment, error) {
+	var models []*ScheduledDeployment
+	err := impl.dbConnection.Model(&models).
+		Join("INNER JOIN pipeline p ON p.id = scheduled_deployment.pipeline_id").
+		Where("scheduled_deployment.status = ?", bean.ScheduledDeploymentScheduled).
+		Where("scheduled_deployment.next_trigger_on <= ?", dueOn).
+		Where("p.deleted = ?", false).
+		Order("scheduled_deployment.next_trigger_on ASC").
+		Select()
+	return models, err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) MarkTriggered(id int, dueOn time.Time, status bean.ScheduledDeploymentStatus, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error) {
+	res, err := impl.dbConnection.Model((*ScheduledDeployment)(nil)).
+		Set("status = ?", status).
+		Set("next_trigger_on = ?", nextTriggerOn).
+		Set("last_triggered_on = ?", triggeredOn).
+		Set("updated_on = ?", triggeredOn).
+		Where("id = ?", id).
+		Where("status = ?", bean.ScheduledDeploymentScheduled).
+		Where("next_trigger_on = ?", dueOn).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) MarkCancelled(id int, userId int32) (bool, error) {
+	res, err := impl.dbConnection.Model((*ScheduledDeployment)(nil)).
+		Set("status = ?", bean.ScheduledDeploymentCancelled).
+		Set("updated_on = ?", time.Now()).
+		Set("updated_by = ?", userId).
+		Where("id = ?", id).
+		Where("status = ?", bean.ScheduledDeploymentScheduled).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() == 1, nil
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) MarkFailed(id int) error {
+	_, err := impl.dbConnection.Model((*ScheduledDeployment)(nil)).
+		Set("status = ?", bean.ScheduledDeploymentFailed).
+		Set("updated_on = ?", time.Now()).
+		Where("id = ?", id).
+		Where("status = ?", bean.ScheduledDeploymentTriggered).
+		Update()
+	return err
+}
+
+func (impl *ScheduledDeploymentRepositoryImpl) UpdateTriggerResult(id int, cdWorkflowRunnerId int, ciArtifactId int, message string) error {
+	_, err := impl.dbConnection.Model((*ScheduledDeployment)(nil)).
+		Set("last_cd_workflow_runner_id = ?", cdWorkflowRunnerId).
+		Set("last_ci_artifact_id = ?", ciArtifactId).
+		Set("message = ?", message).
+		Set("updated_on = ?", time.Now()).
+		Where("id = ?", id).
+		Update()
+	return err
+}
@@ -0,0 +1,385 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/caarlos0/env"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/adapter"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/bean"
+	scheduledDeploymentRepository "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/repository"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type ScheduledDeploymentService interface {
+	// ScheduleDeployment persists a one-shot or recurring deployment of the cd pipeline, RBAC of the requesting user is checked by the caller
+	ScheduleDeployment(request *bean.ScheduledDeploymentRequest) (*bean.ScheduledDeployment, error)
+	GetScheduledDeployment(id int) (*bean.ScheduledDeployment, error)
+	GetScheduledDeployments(filter *bean.ScheduledDeploymentFilter) ([]*bean.ScheduledDeployment, error)
+	// CancelScheduledDeployment stops all future triggers of the scheduled deployment
+	CancelScheduledDeployment(id int, userId int32) error
+	// TriggerDueDeployments triggers all the scheduled deployments whose next trigger time has passed,
+	// RBAC of the user who scheduled the deployment and the deployment feasibility are checked again at this point
+	TriggerDueDeployments()
+}
+
+type ScheduledDeploymentConfig struct {
+	CronTimeInMins int `env:"SCHEDULED_DEPLOYMENT_CRON_TIME" envDefault:"1" description:"Interval in minutes at which scheduled deployments whose trigger time has passed are triggered"`
+}
+
+type ScheduledDeploymentServiceImpl struct {
+	logger                        *zap.SugaredLogger
+	scheduledDeploymentRepository scheduledDeploymentRepository.ScheduledDeploymentRepository
+	pipelineRepository            pipelineConfig.PipelineRepository
+	ciArtifactRepository          repository.CiArtifactRepository
+	cdHandlerService              devtronApps.HandlerService
+	feasibilityManager            devtronApps.FeasibilityManager
+	userService                   user.UserService
+	enforcer                      casbin.Enforcer
+	enforcerUtil                  rbac.EnforcerUtil
+	config                        *ScheduledDeploymentConfig
+	cron                          *cron.Cron
+}
+
+func NewScheduledDeploymentServiceImpl(logger *zap.SugaredLogger,
+	scheduledDeploymentRepository scheduledDeploymentRepository.ScheduledDeploymentRepository,
+	pipelineRepository pipelineConfig.PipelineRepository,
+	ciArtifactRepository repository.CiArtifactRepository,
+	cdHandlerService devtronApps.HandlerService,
+	feasibilityManager devtronApps.FeasibilityManager,
+	userService user.UserService,
+	enforcer casbin.Enforcer,
+	enforcerUtil rbac.EnforcerUtil,
+	cronLogger *cron2.CronLoggerImpl) (*ScheduledDeploymentServiceImpl, error) {
+	config := &ScheduledDeploymentConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing scheduled deployment config", "err", err)
+		return nil, err
+	}
+	cron := cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	cron.Start()
+	impl := &ScheduledDeploymentServiceImpl{
+		logger:                        logger,
+		scheduledDeploymentRepository: scheduledDeploymentRepository,
+		pipelineRepository:            pipelineRepository,
+		ciArtifactRepository:          ciArtifactRepository,
+		cdHandlerService:              cdHandlerService,
+		feasibilityManager:            feasibilityManager,
+		userService:                   userService,
+		enforcer:                      enforcer,
+		enforcerUtil:                  enforcerUtil,
+		config:                        config,
+		cron:                          cron,
+	}
+	_, err = cron.AddFunc(fmt.Sprintf("@every %dm", config.CronTimeInMins), impl.TriggerDueDeployments)
+	if err != nil {
+		logger.Errorw("error while configure cron job for scheduled deployments", "err", err)
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) ScheduleDeployment(request *bean.ScheduledDeploymentRequest) (*bean.ScheduledDeployment, error) {
+	pipeline, err := impl.pipelineRepository.FindById(request.PipelineId)
+	if util.IsErrNoRows(err) || (err == nil && pipeline.AppId != request.AppId) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("cd pipeline %d not found", request.PipelineId), "cd pipeline not found for app")
+	} else if err != nil {
+		impl.logger.Errorw("error in getting cd pipeline", "pipelineId", request.PipelineId, "err", err)
+		return nil, err
+	}
+	if !request.UseLatestArtifact {
+		if request.CiArtifactId == 0 {
+			return nil, util.NewApiError(http.StatusBadRequest, "ciArtifactId is required when useLatestArtifact is not set", "ci artifact id not provided")
+		}
+		_, err = impl.ciArtifactRepository.Get(request.CiArtifactId)
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("ci artifact %d not found", request.CiArtifactId), err.Error())
+		} else if err != nil {
+			impl.logger.Errorw("error in getting ci artifact", "ciArtifactId", request.CiArtifactId, "err", err)
+			return nil, err
+		}
+	}
+	model := &scheduledDeploymentRepository.ScheduledDeployment{
+		AppId:          request.AppId,
+		EnvId:          pipeline.EnvironmentId,
+		PipelineId:     request.PipelineId,
+		CdWorkflowType: request.CdWorkflowType,
+		ScheduleType:   request.ScheduleType,
+		Status:         bean.ScheduledDeploymentScheduled,
+	}
+	if model.CdWorkflowType == "" {
+		model.CdWorkflowType = apiBean.CD_WORKFLOW_TYPE_DEPLOY
+	}
+	if request.UseLatestArtifact {
+		model.UseLatestArtifact = true
+	} else {
+		model.CiArtifactId = request.CiArtifactId
+	}
+	nextTriggerOn, err := getFirstTriggerTime(request, time.Now())
+	if err != nil {
+		return nil, util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	model.NextTriggerOn = nextTriggerOn
+	if request.ScheduleType == bean.ScheduleTypeRecurring {
+		model.CronExpression = request.CronExpression
+		model.Timezone = getTimezone(request.Timezone)
+	}
+	model.CreateAuditLog(request.UserId)
+	err = impl.scheduledDeploymentRepository.Save(model)
+	if err != nil {
+		impl.logger.Errorw("error in saving scheduled deployment", "request", request, "err", err)
+		return nil, err
+	}
+	return adapter.GetScheduledDeploymentDto(model), nil
+}
+
+// getFirstTriggerTime validates the schedule of the request and returns the time at which it is triggered first
+func getFirstTriggerTime(request *bean.ScheduledDeploymentRequest, now time.Time) (time.Time, error) {
+	switch request.ScheduleType {
+	case bean.ScheduleTypeOnce:
+		if request.ScheduledAt == nil || !request.ScheduledAt.After(now) {
+			return time.Time{}, fmt.Errorf("scheduledAt must be in the future for a %s scheduled deployment", bean.ScheduleTypeOnce)
+		}
+		return request.ScheduledAt.UTC(), nil
+	case bean.ScheduleTypeRecurring:
+		if len(request.CronExpression) == 0 {
+			return time.Time{}, fmt.Errorf("cronExpression is required for a %s scheduled deployment", bean.ScheduleTypeRecurring)
+		}
+		return schedule.GetNextTriggerTime(request.CronExpression, getTimezone(request.Timezone), now)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported schedule type %q", request.ScheduleType)
+	}
+}
+
+func getTimezone(timezone string) string {
+	if len(timezone) == 0 {
+		return "UTC"
+	}
+	return timezone
+}
+
+func (impl *ScheduledDeploymentServiceImpl) GetScheduledDeployment(id int) (*bean.ScheduledDeployment, error) {
+	model, err := impl.scheduledDeploymentRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("scheduled deployment %d not found", id), err.Error())
+	} else if err != nil {
+		impl.logger.Errorw("error in getting scheduled deployment", "id", id, "err", err)
+		return nil, err
+	}
+	dto := adapter.GetScheduledDeploymentDto(model)
+	dto.ScheduledByEmail, _ = impl.userService.GetEmailById(model.CreatedBy)
+	return dto, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) GetScheduledDeployments(filter *bean.ScheduledDeploymentFilter) ([]*bean.ScheduledDeployment, error) {
+	models, err := impl.scheduledDeploymentRepository.FindByFilter(filter)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting scheduled deployments", "filter", filter, "err", err)
+		return nil, err
+	}
+	emailByUserId := make(map[int32]string)
+	dtos := make([]*bean.ScheduledDeployment, 0, len(models))
+	for _, model := range models {
+		dto := adapter.GetScheduledDeploymentDto(model)
+		if _, ok := emailByUserId[model.CreatedBy]; !ok {
+			emailByUserId[model.CreatedBy], _ = impl.userService.GetEmailById(model.CreatedBy)
+		}
+		dto.ScheduledByEmail = emailByUserId[model.CreatedBy]
+		dtos = append(dtos, dto)
+	}
+	return dtos, nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) CancelScheduledDeployment(id int, userId int32) error {
+	cancelled, err := impl.scheduledDeploymentRepository.MarkCancelled(id, userId)
+	if err != nil {
+		impl.logger.Errorw("error in cancelling scheduled deployment", "id", id, "err", err)
+		return err
+	}
+	if !cancelled {
+		return util.NewApiError(http.StatusConflict, fmt.Sprintf("scheduled deployment %d is not scheduled anymore", id), "scheduled deployment is not in scheduled state")
+	}
+	return nil
+}
+
+func (impl *ScheduledDeploymentServiceImpl) TriggerDueDeployments() {
+	now := time.Now()
+	models, err := impl.scheduledDeploymentRepository.FindDue(now)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting due scheduled deployments", "err", err)
+		return
+	}
+	for _, model := range models {
+		impl.triggerScheduledDeployment(model, now)
+	}
+}
+
+func (impl *ScheduledDeploymentServiceImpl) triggerScheduledDeployment(model *scheduledDeploymentRepository.ScheduledDeployment, now time.Time) {
+	status, nextTriggerOn := bean.ScheduledDeploymentTriggered, model.NextTriggerOn
+	if model.ScheduleType == bean.ScheduleTypeRecurring {
+		next, err := schedule.GetNextTriggerTime(model.CronExpression, model.Timezone, now)
+		if err != nil {
+			impl.logger.Errorw("error in computing next trigger time of scheduled deployment", "id", model.Id, "err", err)
+			status = bean.ScheduledDeploymentFailed
+		} else {
+			status, nextTriggerOn = bean.ScheduledDeploymentScheduled, next
+		}
+	}
+	// claiming the occurrence makes sure that only one replica triggers it
+	claimed, err := impl.scheduledDeploymentRepository.MarkTriggered(model.Id, model.NextTriggerOn, status, nextTriggerOn, now)
+	if err != nil {
+		impl.logger.Errorw("error in marking scheduled deployment as triggered", "id", model.Id, "err", err)
+		return
+	}
+	if !claimed || status == bean.ScheduledDeploymentFailed {
+		return
+	}
+	cdWorkflowRunnerId, ciArtifactId, err := impl.triggerDeployment(model, now)
+	message := "deployment triggered"
+	if err != nil {
+		impl.logger.Errorw("error in triggering scheduled deployment", "id", model.Id, "pipelineId", model.PipelineId, "err", err)
+		message = err.Error()
+		if apiErr, ok := err.(*util.ApiError); ok {
+			if userMessage, ok := apiErr.UserMessage.(string); ok && len(userMessage) > 0 {
+				message = userMessage
+			}
+		}
+		if model.ScheduleType == bean.ScheduleTypeOnce {
+			if err = impl.scheduledDeploymentRepository.MarkFailed(model.Id); err != nil {
+				impl.logger.Errorw("error in marking scheduled deployment as failed", "id", model.Id, "err", err)
+			}
+		}
+	}
+	err = impl.scheduledDeploymentRepository.UpdateTriggerResult(model.Id, cdWorkflowRunnerId, ciArtifactId, message)
+	if err != nil {
+		impl.logger.Errorw("error in updating trigger result of scheduled deployment", "id", model.Id, "err", err)
+	}
+}
+
+// triggerDeployment triggers the cd pipeline on behalf of the user who scheduled the deployment,
+// the deployment is recorded in the trigger history as triggered by that user
+func (impl *ScheduledDeploymentServiceImpl) triggerDeployment(model *scheduledDeploymentRepository.ScheduledDeployment, now time.Time) (int, int, error) {
+	pipeline, err := impl.pipelineRepository.FindById(model.PipelineId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cd pipeline %d not found", model.PipelineId)
+	}
+	emailId, err := impl.userService.GetActiveEmailById(model.CreatedBy)
+	if err != nil {
+		return 0, 0, fmt.Errorf("user who scheduled the deployment is not active anymore")
+	}
+	appObject := impl.enforcerUtil.GetAppRBACNameByAppId(model.AppId)
+	envObject := impl.enforcerUtil.GetAppRBACByAppIdAndPipelineId(model.AppId, model.PipelineId)
+	if !impl.enforcer.EnforceByEmail(emailId, casbin.ResourceApplications, casbin.ActionTrigger, appObject) ||
+		!impl.enforcer.EnforceByEmail(emailId, casbin.ResourceEnvironment, casbin.ActionTrigger, envObject) {
+		return 0, 0, fmt.Errorf("user %s is not authorized to trigger the deployment anymore", emailId)
+	}
+	artifact, err := impl.getArtifactToDeploy(model, pipeline)
+	if err != nil {
+		return 0, 0, err
+	}
+	referenceId := fmt.Sprintf("scheduled-deployment-%d-%d", model.Id, now.Unix())
+	triggerContext := triggerBean.TriggerContext{
+		Context:     context.Background(),
+		ReferenceId: &referenceId,
+		TriggerType: triggerBean.Manual,
+	}
+	err = impl.feasibilityManager.CheckFeasibility(&triggerBean.TriggerRequirementRequestDto{
+		TriggerRequest: triggerBean.CdTriggerRequest{
+			Pipeline:       pipeline,
+			Artifact:       artifact,
+			TriggeredBy:    model.CreatedBy,
+			WorkflowType:   model.CdWorkflowType,
+			TriggerContext: triggerContext,
+		},
+	})
+	if err != nil {
+		return 0, artifact.Id, err
+	}
+	overrideRequest := &apiBean.ValuesOverrideRequest{
+		PipelineId:           model.PipelineId,
+		AppId:                model.AppId,
+		CiArtifactId:         artifact.Id,
+		CdWorkflowType:       model.CdWorkflowType,
+		DeploymentWithConfig: apiBean.DEPLOYMENT_CONFIG_TYPE_LAST_SAVED,
+		UserId:               model.CreatedBy,
+	}
+	userMetadata := &userBean.UserMetadata{
+		UserEmailId:      emailId,
+		IsUserSuperAdmin: impl.enforcer.EnforceByEmail(emailId, casbin.ResourceGlobal, casbin.ActionCreate, "*"),
+		UserId:           model.CreatedBy,
+	}
+	cdWorkflowRunnerId, _, _, err := impl.cdHandlerService.ManualCdTrigger(triggerContext, overrideRequest, userMetadata)
+	if err != nil {
+		return 0, artifact.Id, err
+	}
+	if overrideRequest.WfrId > 0 {
+		cdWorkflowRunnerId = overrideRequest.WfrId
+	}
+	return cdWorkflowRunnerId, artifact.Id, nil
+}
+
+// getArtifactToDeploy returns the artifact of the scheduled deployment, for the latest artifact it is the latest image built
+// by the ci pipeline of the cd pipeline, falling back to the last deployed one for pipelines without a ci pipeline of their own
+func (impl *ScheduledDeploymentServiceImpl) getArtifactToDeploy(model *scheduledDeploymentRepository.ScheduledDeployment, pipeline *pipelineConfig.Pipeline) (*repository.CiArtifact, error) {
+	ciArtifactId := model.CiArtifactId
+	if model.UseLatestArtifact {
+		ciArtifactId = 0
+		if pipeline.CiPipelineId > 0 {
+			artifacts, err := impl.ciArtifactRepository.GetLatestArtifactsByCiPipelineId(pipeline.CiPipelineId, 1)
+			if err != nil && !util.IsErrNoRows(err) {
+				return nil, err
+			}
+			if len(artifacts) > 0 {
+				ciArtifactId = artifacts[0].Id
+			}
+		}
+		if ciArtifactId == 0 {
+			latestDeployedId, err := impl.ciArtifactRepository.GetLatest(pipeline.Id)
+			if err != nil && !util.IsErrNoRows(err) {
+				return nil, err
+			}
+			ciArtifactId = latestDeployedId
+		}
+		if ciArtifactId == 0 {
+			return nil, fmt.Errorf("no artifact found to deploy on cd pipeline %d", pipeline.Id)
+		}
+	}
+	artifact, err := impl.ciArtifactRepository.Get(ciArtifactId)
+	if err != nil {
+		return nil, fmt.Errorf("ci artifact %d not found", ciArtifactId)
+	}
+	return artifact, nil
+}
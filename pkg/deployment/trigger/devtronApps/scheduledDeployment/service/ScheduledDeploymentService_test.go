@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package service
+
+import (
+	"errors"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	appBean "github.com/devtron-labs/devtron/pkg/app/bean"
+	"github.com/devtron-labs/devtron/pkg/auth/authorisation/casbin"
+	"github.com/devtron-labs/devtron/pkg/auth/user"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/bean"
+	scheduledDeploymentRepository "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/util/rbac"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetFirstTriggerTime(t *testing.T) {
+	now := time.Date(2024, time.March, 10, 1, 30, 0, 0, time.UTC)
+	t.Run("once in future", func(t *testing.T) {
+		scheduledAt := now.Add(time.Hour)
+		next, err := getFirstTriggerTime(&bean.ScheduledDeploymentRequest{ScheduleType: bean.ScheduleTypeOnce, ScheduledAt: &scheduledAt}, now)
+		assert.Nil(t, err)
+		assert.Equal(t, scheduledAt, next)
+	})
+	t.Run("once in past", func(t *testing.T) {
+		scheduledAt := now.Add(-time.Hour)
+		_, err := getFirstTriggerTime(&bean.ScheduledDeploymentRequest{ScheduleType: bean.ScheduleTypeOnce, ScheduledAt: &scheduledAt}, now)
+		assert.NotNil(t, err)
+	})
+	t.Run("recurring defaults to utc", func(t *testing.T) {
+		next, err := getFirstTriggerTime(&bean.ScheduledDeploymentRequest{ScheduleType: bean.ScheduleTypeRecurring, CronExpression: "0 2 * * *"}, now)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2024, time.March, 10, 2, 0, 0, 0, time.UTC), next)
+	})
+	t.Run("recurring without cron expression", func(t *testing.T) {
+		_, err := getFirstTriggerTime(&bean.ScheduledDeploymentRequest{ScheduleType: bean.ScheduleTypeRecurring}, now)
+		assert.NotNil(t, err)
+	})
+}
+
+// scheduledDeploymentRepositoryStub keeps the scheduled deployments in memory, claimedByOther marks the
+// scheduled deployments which another replica triggers between the lookup and the claim
+type scheduledDeploymentRepositoryStub struct {
+	scheduledDeploymentRepository.ScheduledDeploymentRepository
+	scheduledDeployments map[int]*scheduledDeploymentRepository.ScheduledDeployment
+	claimedByOther       map[int]bool
+}
+
+func (impl *scheduledDeploymentRepositoryStub) FindDue(dueOn time.Time) ([]*scheduledDeploymentRepository.ScheduledDeployment, error) {
+	models := make([]*scheduledDeploymentRepository.ScheduledDeployment, 0)
+	for id := 1; id <= len(impl.scheduledDeployments); id++ {
+		stored := impl.scheduledDeployments[id]
+		if stored.Status != bean.ScheduledDeploymentScheduled || stored.NextTriggerOn.After(dueOn) {
+			continue
+		}
+		fetched := *stored
+		models = append(models, &fetched)
+		if impl.claimedByOther[id] {
+			stored.Status = bean.ScheduledDeploymentTriggered
+		}
+	}
+	return models, nil
+}
+
+func (impl *scheduledDeploymentRepositoryStub) MarkTriggered(id int, dueOn time.Time, status bean.ScheduledDeploymentStatus, nextTriggerOn time.Time, triggeredOn time.Time) (bool, error) {
+	stored := impl.scheduledDeployments[id]
+	if stored.Status != bean.ScheduledDeploymentScheduled || !stored.NextTriggerOn.Equal(dueOn) {
+		return false, nil
+	}
+	stored.Status = status
+	stored.NextTriggerOn = nextTriggerOn
+	stored.LastTriggeredOn = triggeredOn
+	return true, nil
+}
+
+func (impl *scheduledDeploymentRepositoryStub) MarkFailed(id int) error {
+	if stored := impl.scheduledDeployments[id]; stored.Status == bean.ScheduledDeploymentTriggered {
+		stored.Status = bean.ScheduledDeploymentFailed
+	}
+	return nil
+}
+
+func (impl *scheduledDeploymentRepositoryStub) UpdateTriggerResult(id int, cdWorkflowRunnerId int, ciArtifactId int, message string) error {
+	stored := impl.scheduledDeployments[id]
+	stored.LastCdWorkflowRunnerId = cdWorkflowRunnerId
+	stored.LastCiArtifactId = ciArtifactId
+	stored.Message = message
+	return nil
+}
+
+type pipelineRepositoryStub struct {
+	pipelineConfig.PipelineRepository
+}
+
+func (impl *pipelineRepositoryStub) FindById(id int) (*pipelineConfig.Pipeline, error) {
+	return &pipelineConfig.Pipeline{Id: id, AppId: 1, EnvironmentId: 2, CiPipelineId: 3}, nil
+}
+
+type ciArtifactRepositoryStub struct {
+	repository.CiArtifactRepository
+	latestCiArtifactId int
+}
+
+func (impl *ciArtifactRepositoryStub) Get(id int) (*repository.CiArtifact, error) {
+	return &repository.CiArtifact{Id: id}, nil
+}
+
+func (impl *ciArtifactRepositoryStub) GetLatestArtifactsByCiPipelineId(ciPipelineId, limit int) ([]repository.CiArtifact, error) {
+	return []repository.CiArtifact{{Id: impl.latestCiArtifactId}}, nil
+}
+
+type userServiceStub struct {
+	user.UserService
+	activeEmailIds map[int32]string
+}
+
+func (impl *userServiceStub) GetActiveEmailById(userId int32) (string, error) {
+	emailId, ok := impl.activeEmailIds[userId]
+	if !ok {
+		return "", errors.New("user not found")
+	}
+	return emailId, nil
+}
+
+// enforcerStub allows every action except the resources listed in denied
+type enforcerStub struct {
+	casbin.Enforcer
+	denied map[string]bool
+}
+
+func (impl *enforcerStub) EnforceByEmail(emailId string, resource string, action string, resourceItem string) bool {
+	return !impl.denied[resource]
+}
+
+type enforcerUtilStub struct {
+	rbac.EnforcerUtil
+}
+
+func (impl *enforcerUtilStub) GetAppRBACNameByAppId(appId int) string {
+	return "payments/billing"
+}
+
+func (impl *enforcerUtilStub) GetAppRBACByAppIdAndPipelineId(appId int, pipelineId int) string {
+	return "prod/billing"
+}
+
+type feasibilityManagerStub struct {
+	devtronApps.FeasibilityManager
+	err error
+}
+
+func (impl *feasibilityManagerStub) CheckFeasibility(triggerRequirementRequest *triggerBean.TriggerRequirementRequestDto) error {
+	return impl.err
+}
+
+type cdHandlerServiceStub struct {
+	devtronApps.HandlerService
+	overrideRequests []*apiBean.ValuesOverrideRequest
+	userMetadata     []*userBean.UserMetadata
+}
+
+func (impl *cdHandlerServiceStub) ManualCdTrigger(triggerContext triggerBean.TriggerContext, overrideRequest *apiBean.ValuesOverrideRequest, userMetadata *userBean.UserMetadata) (int, string, *appBean.ManifestPushTemplate, error) {
+	impl.overrideRequests = append(impl.overrideRequests, overrideRequest)
+	impl.userMetadata = append(impl.userMetadata, userMetadata)
+	return 100 + len(impl.overrideRequests), "", nil, nil
+}
+
+func TestScheduledDeploymentServiceImpl_TriggerDueDeployments(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	tests := []struct {
+		name                 string
+		scheduleType         bean.ScheduleType
+		useLatestArtifact    bool
+		claimedByOther       bool
+		inactiveUser         bool
+		denied               map[string]bool
+		feasibilityErr       error
+		expectedTriggered    bool
+		expectedCiArtifactId int
+		expectedStatus       bean.ScheduledDeploymentStatus
+		expectedMessage      string
+	}{
+		{
+			name:                 "due deployment is claimed and triggered as the user who scheduled it",
+			scheduleType:         bean.ScheduleTypeOnce,
+			expectedTriggered:    true,
+			expectedCiArtifactId: 5,
+			expectedStatus:       bean.ScheduledDeploymentTriggered,
+			expectedMessage:      "deployment triggered",
+		},
+		{
+			name:                 "latest artifact is resolved at trigger time",
+			scheduleType:         bean.ScheduleTypeOnce,
+			useLatestArtifact:    true,
+			expectedTriggered:    true,
+			expectedCiArtifactId: 9,
+			expectedStatus:       bean.ScheduledDeploymentTriggered,
+			expectedMessage:      "deployment triggered",
+		},
+		{
+			name:           "deployment claimed by another replica is not triggered again",
+			scheduleType:   bean.ScheduleTypeOnce,
+			claimedByOther: true,
+			expectedStatus: bean.ScheduledDeploymentTriggered,
+		},
+		{
+			name:            "environment trigger access revoked since scheduling",
+			scheduleType:    bean.ScheduleTypeOnce,
+			denied:          map[string]bool{casbin.ResourceEnvironment: true},
+			expectedStatus:  bean.ScheduledDeploymentFailed,
+			expectedMessage: "user dev@example.com is not authorized to trigger the deployment anymore",
+		},
+		{
+			name:            "user who scheduled the deployment is deactivated",
+			scheduleType:    bean.ScheduleTypeOnce,
+			inactiveUser:    true,
+			expectedStatus:  bean.ScheduledDeploymentFailed,
+			expectedMessage: "user who scheduled the deployment is not active anymore",
+		},
+		{
+			name:                 "deployment rejected by the feasibility check",
+			scheduleType:         bean.ScheduleTypeOnce,
+			feasibilityErr:       util.NewApiError(http.StatusForbidden, "deployment window is closed", "blocked by deployment window"),
+			expectedCiArtifactId: 5,
+			expectedStatus:       bean.ScheduledDeploymentFailed,
+			expectedMessage:      "deployment window is closed",
+		},
+		{
+			name:                 "rejected recurring deployment stays scheduled for its next occurrence",
+			scheduleType:         bean.ScheduleTypeRecurring,
+			feasibilityErr:       util.NewApiError(http.StatusForbidden, "deployment window is closed", "blocked by deployment window"),
+			expectedCiArtifactId: 5,
+			expectedStatus:       bean.ScheduledDeploymentScheduled,
+			expectedMessage:      "deployment window is closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dueOn := time.Now().Add(-time.Minute).UTC()
+			model := &scheduledDeploymentRepository.ScheduledDeployment{
+				Id:                1,
+				AppId:             1,
+				EnvId:             2,
+				PipelineId:        4,
+				CdWorkflowType:    apiBean.CD_WORKFLOW_TYPE_DEPLOY,
+				UseLatestArtifact: tt.useLatestArtifact,
+				ScheduleType:      tt.scheduleType,
+				Status:            bean.ScheduledDeploymentScheduled,
+				NextTriggerOn:     dueOn,
+				AuditLog:          sql.NewDefaultAuditLog(7),
+			}
+			if !tt.useLatestArtifact {
+				model.CiArtifactId = 5
+			}
+			if tt.scheduleType == bean.ScheduleTypeRecurring {
+				model.CronExpression, model.Timezone = "0 * * * *", "UTC"
+			}
+			activeEmailIds := map[int32]string{7: "dev@example.com"}
+			if tt.inactiveUser {
+				activeEmailIds = nil
+			}
+			repositoryStub := &scheduledDeploymentRepositoryStub{
+				scheduledDeployments: map[int]*scheduledDeploymentRepository.ScheduledDeployment{1: model},
+				claimedByOther:       map[int]bool{1: tt.claimedByOther},
+			}
+			cdHandlerService := &cdHandlerServiceStub{}
+			impl := &ScheduledDeploymentServiceImpl{
+				logger:                        logger,
+				scheduledDeploymentRepository: repositoryStub,
+				pipelineRepository:            &pipelineRepositoryStub{},
+				ciArtifactRepository:          &ciArtifactRepositoryStub{latestCiArtifactId: 9},
+				cdHandlerService:              cdHandlerService,
+				feasibilityManager:            &feasibilityManagerStub{err: tt.feasibilityErr},
+				userService:                   &userServiceStub{activeEmailIds: activeEmailIds},
+				enforcer:                      &enforcerStub{denied: tt.denied},
+				enforcerUtil:                  &enforcerUtilStub{},
+			}
+			impl.TriggerDueDeployments()
+			// the next run of the cron finds nothing due, so the deployment is triggered at most once
+			impl.TriggerDueDeployments()
+			assert.Equal(t, tt.expectedStatus, model.Status)
+			assert.Equal(t, tt.expectedMessage, model.Message)
+			assert.Equal(t, tt.expectedCiArtifactId, model.LastCiArtifactId)
+			if !tt.expectedTriggered {
+				assert.Empty(t, cdHandlerService.overrideRequests)
+				assert.Zero(t, model.LastCdWorkflowRunnerId)
+			} else {
+				assert.Len(t, cdHandlerService.overrideRequests, 1)
+				assert.Equal(t, tt.expectedCiArtifactId, cdHandlerService.overrideRequests[0].CiArtifactId)
+				assert.Equal(t, int32(7), cdHandlerService.overrideRequests[0].UserId)
+				assert.Equal(t, "dev@example.com", cdHandlerService.userMetadata[0].UserEmailId)
+				assert.Equal(t, 101, model.LastCdWorkflowRunnerId)
+			}
+			if tt.scheduleType == bean.ScheduleTypeRecurring {
+				assert.True(t, model.NextTriggerOn.After(time.Now()))
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package scheduledDeployment
+
+import (
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/repository"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment/service"
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	repository.NewScheduledDeploymentRepositoryImpl,
+	wire.Bind(new(repository.ScheduledDeploymentRepository), new(*repository.ScheduledDeploymentRepositoryImpl)),
+
+	service.NewScheduledDeploymentServiceImpl,
+	wire.Bind(new(service.ScheduledDeploymentService), new(*service.ScheduledDeploymentServiceImpl)),
+)
@@ -25,4 +25,5 @@ var DevtronAppsDeployTriggerWireSet = wire.NewSet(
 	userDeploymentRequest.WireSet,
 	NewHandlerServiceImpl,
 	wire.Bind(new(HandlerService), new(*HandlerServiceImpl)),
+	wire.Bind(new(FeasibilityManager), new(*HandlerServiceImpl)),
 )
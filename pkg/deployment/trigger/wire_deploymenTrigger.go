@@ -18,9 +18,11 @@ package trigger
 
 import (
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps"
+	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/scheduledDeployment"
 	"github.com/google/wire"
 )
 
 var DeploymentTriggerWireSet = wire.NewSet(
 	devtronApps.DevtronAppsDeployTriggerWireSet,
+	scheduledDeployment.WireSet,
 )
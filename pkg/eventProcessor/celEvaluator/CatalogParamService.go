@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package celEvaluator
+
+import (
+	"errors"
+	"time"
+
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	clusterRepository "github.com/devtron-labs/devtron/pkg/cluster/repository"
+	repository4 "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/repository"
+	securityBean "github.com/devtron-labs/devtron/pkg/policyGovernance/security/imageScanning/repository/bean"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+// CatalogParamService populates the catalog params describing the app, the cluster and the artifact,
+// shared by the evaluators which have these available
+type CatalogParamService interface {
+	// GetAppClusterAndArtifactParams returns every catalog param, the ones which need a lookup
+	// are only resolved when the expression references them and are left empty otherwise
+	GetAppClusterAndArtifactParams(expression string, appId int, cluster *clusterRepository.Cluster, artifact *repository.CiArtifact) ([]cel.ExpressionParam, error)
+}
+
+type CatalogParamServiceImpl struct {
+	logger                    *zap.SugaredLogger
+	appLabelRepository        pipelineConfig.AppLabelRepository
+	imageScanResultRepository repository4.ImageScanResultRepository
+}
+
+func NewCatalogParamServiceImpl(logger *zap.SugaredLogger,
+	appLabelRepository pipelineConfig.AppLabelRepository,
+	imageScanResultRepository repository4.ImageScanResultRepository) *CatalogParamServiceImpl {
+	return &CatalogParamServiceImpl{
+		logger:                    logger,
+		appLabelRepository:        appLabelRepository,
+		imageScanResultRepository: imageScanResultRepository,
+	}
+}
+
+func (impl *CatalogParamServiceImpl) GetAppClusterAndArtifactParams(expression string, appId int, cluster *clusterRepository.Cluster, artifact *repository.CiArtifact) ([]cel.ExpressionParam, error) {
+	if artifact == nil {
+		return nil, errors.New("artifact not found for evaluating the catalog params")
+	}
+	referencedParams, err := cel.GetReferencedParamNames(expression)
+	if err != nil {
+		impl.logger.Errorw("error in parsing expression for referenced params", "expression", expression, "err", err)
+		return nil, err
+	}
+	appLabels := make(map[string]string)
+	if referencedParams[cel.AppLabels] {
+		appLabels, err = impl.getAppLabels(appId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	severityCount := make(map[securityBean.Severity]int)
+	if referencedParams[cel.VulnerabilityCritical] || referencedParams[cel.VulnerabilityHigh] ||
+		referencedParams[cel.VulnerabilityMedium] || referencedParams[cel.VulnerabilityLow] {
+		severityCount, err = impl.getVulnerabilityCount(artifact)
+		if err != nil {
+			return nil, err
+		}
+	}
+	clusterLabels := make(map[string]string)
+	if cluster != nil {
+		for key, value := range cluster.Labels {
+			clusterLabels[key] = value
+		}
+	}
+	gitBranch, commitAuthor := getGitBranchAndCommitAuthor(artifact)
+	params := []cel.ExpressionParam{
+		{ParamName: cel.AppLabels, Value: appLabels, Type: cel.ParamTypeMapStringToStr},
+		{ParamName: cel.ClusterLabels, Value: clusterLabels, Type: cel.ParamTypeMapStringToStr},
+		{ParamName: cel.ArtifactAgeInMins, Value: int(time.Since(artifact.CreatedOn).Minutes()), Type: cel.ParamTypeInteger},
+		{ParamName: cel.VulnerabilityCritical, Value: severityCount[securityBean.Critical], Type: cel.ParamTypeInteger},
+		{ParamName: cel.VulnerabilityHigh, Value: severityCount[securityBean.High], Type: cel.ParamTypeInteger},
+		{ParamName: cel.VulnerabilityMedium, Value: severityCount[securityBean.Medium], Type: cel.ParamTypeInteger},
+		{ParamName: cel.VulnerabilityLow, Value: severityCount[securityBean.Low], Type: cel.ParamTypeInteger},
+		{ParamName: cel.GitBranch, Value: gitBranch, Type: cel.ParamTypeString},
+		{ParamName: cel.CommitAuthor, Value: commitAuthor, Type: cel.ParamTypeString},
+	}
+	return params, nil
+}
+
+func (impl *CatalogParamServiceImpl) getAppLabels(appId int) (map[string]string, error) {
+	labels, err := impl.appLabelRepository.FindAllByAppId(appId)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching app labels", "appId", appId, "err", err)
+		return nil, err
+	}
+	appLabels := make(map[string]string, len(labels))
+	for _, label := range labels {
+		appLabels[label.Key] = label.Value
+	}
+	return appLabels, nil
+}
+
+// getVulnerabilityCount counts the vulnerabilities of the latest scan of the artifact by severity
+func (impl *CatalogParamServiceImpl) getVulnerabilityCount(artifact *repository.CiArtifact) (map[securityBean.Severity]int, error) {
+	severityCount := make(map[securityBean.Severity]int)
+	if len(artifact.ImageDigest) == 0 {
+		return severityCount, nil
+	}
+	scanResults, err := impl.imageScanResultRepository.FindByImageDigest(artifact.ImageDigest)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching image scan result", "imageDigest", artifact.ImageDigest, "err", err)
+		return nil, err
+	}
+	if len(scanResults) == 0 {
+		return severityCount, nil
+	}
+	// results are ordered by execution time desc
+	latestExecutionHistoryId := scanResults[0].ImageScanExecutionHistoryId
+	for _, scanResult := range scanResults {
+		if scanResult.ImageScanExecutionHistoryId != latestExecutionHistoryId {
+			continue
+		}
+		severityCount[scanResult.CveStore.GetSeverity()]++
+	}
+	return severityCount, nil
+}
+
+func getGitBranchAndCommitAuthor(artifact *repository.CiArtifact) (gitBranch string, commitAuthor string) {
+	ciMaterialInfos, err := repository.GetCiMaterialInfo(artifact.MaterialInfo, artifact.DataSource)
+	if err != nil {
+		return gitBranch, commitAuthor
+	}
+	for _, ciMaterialInfo := range ciMaterialInfos {
+		if len(ciMaterialInfo.Modifications) > 0 {
+			return ciMaterialInfo.Modifications[0].Branch, ciMaterialInfo.Modifications[0].Author
+		}
+	}
+	return gitBranch, commitAuthor
+}
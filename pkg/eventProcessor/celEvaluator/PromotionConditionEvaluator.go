@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package celEvaluator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/devtron-labs/devtron/cel"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	repository3 "github.com/devtron-labs/devtron/internal/sql/repository/imageTagging"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/team/read"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type PromotionConditionEvaluator interface {
+	// EvaluatePromotionCondition evaluates the promotion condition of the pipeline for the artifact,
+	// the blocked reason is returned when the artifact is not allowed to be promoted
+	EvaluatePromotionCondition(pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) (isPromotionAllowed bool, blockedReason string, err error)
+}
+
+type PromotionConditionEvaluatorImpl struct {
+	logger              *zap.SugaredLogger
+	celEvaluatorService cel.EvaluatorService
+	imageTagRepository  repository3.ImageTaggingRepository
+	teamReadService     read.TeamReadService
+	envRepository       repository2.EnvironmentRepository
+	catalogParamService CatalogParamService
+}
+
+func NewPromotionConditionEvaluatorImpl(logger *zap.SugaredLogger,
+	celEvaluatorService cel.EvaluatorService,
+	imageTagRepository repository3.ImageTaggingRepository,
+	teamReadService read.TeamReadService,
+	envRepository repository2.EnvironmentRepository,
+	catalogParamService CatalogParamService) *PromotionConditionEvaluatorImpl {
+	return &PromotionConditionEvaluatorImpl{
+		logger:              logger,
+		celEvaluatorService: celEvaluatorService,
+		imageTagRepository:  imageTagRepository,
+		teamReadService:     teamReadService,
+		envRepository:       envRepository,
+		catalogParamService: catalogParamService,
+	}
+}
+
+func (impl *PromotionConditionEvaluatorImpl) EvaluatePromotionCondition(pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) (bool, string, error) {
+	if len(pipeline.PromotionCondition) == 0 {
+		return true, "", nil
+	}
+	params, err := impl.getParamsForPromotion(pipeline, artifact)
+	if err != nil {
+		impl.logger.Errorw("error in getting promotion condition params", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "err", err)
+		return false, "", err
+	}
+	evalReq := cel.Request{
+		Expression: pipeline.PromotionCondition,
+		ExpressionMetadata: cel.ExpressionMetadata{
+			Params: params,
+		},
+	}
+	isPromotionAllowed, err := impl.celEvaluatorService.EvaluateCELRequest(evalReq)
+	if err != nil {
+		// a condition which cannot be evaluated must not let the artifact through
+		impl.logger.Errorw("error in evaluating promotion condition", "pipelineId", pipeline.Id, "promotionCondition", pipeline.PromotionCondition, "err", err)
+		return false, fmt.Sprintf("condition '%s' could not be evaluated, %s", pipeline.PromotionCondition, err.Error()), nil
+	}
+	if !isPromotionAllowed {
+		return false, fmt.Sprintf("condition '%s' not satisfied", pipeline.PromotionCondition), nil
+	}
+	return true, "", nil
+}
+
+func (impl *PromotionConditionEvaluatorImpl) getParamsForPromotion(pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) ([]cel.ExpressionParam, error) {
+	imageReleaseTags, err := impl.imageTagRepository.GetTagsByArtifactId(artifact.Id)
+	if err != nil && !errors.Is(err, pg.ErrNoRows) {
+		impl.logger.Errorw("error in fetching image tags using artifactId", "artifactId", artifact.Id, "err", err)
+		return nil, err
+	}
+	imageLabels := make([]string, 0, len(imageReleaseTags))
+	for _, imageTag := range imageReleaseTags {
+		imageLabels = append(imageLabels, imageTag.TagName)
+	}
+	project, err := impl.teamReadService.FindOne(pipeline.App.TeamId)
+	if err != nil {
+		impl.logger.Errorw("error while getting project", "projectId", pipeline.App.TeamId, "err", err)
+		return nil, err
+	}
+	env, err := impl.envRepository.FindById(pipeline.EnvironmentId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environment", "envId", pipeline.EnvironmentId, "err", err)
+		return nil, err
+	}
+	containerRepository, containerImageTag, err := artifact.ExtractImageRepoAndTag()
+	if err != nil {
+		impl.logger.Errorw("error in getting image tag and repo", "err", err)
+	}
+	var clusterName string
+	if env.Cluster != nil {
+		clusterName = env.Cluster.ClusterName
+	}
+	params := []cel.ExpressionParam{
+		{ParamName: cel.AppName, Value: pipeline.App.AppName, Type: cel.ParamTypeString},
+		{ParamName: cel.ProjectName, Value: project.Name, Type: cel.ParamTypeString},
+		{ParamName: cel.EnvName, Value: env.Name, Type: cel.ParamTypeString},
+		{ParamName: cel.IsProdEnv, Value: env.Default, Type: cel.ParamTypeBool},
+		{ParamName: cel.ClusterName, Value: clusterName, Type: cel.ParamTypeString},
+		{ParamName: cel.CdPipelineName, Value: pipeline.Name, Type: cel.ParamTypeString},
+		{ParamName: cel.CdPipelineTriggerType, Value: pipeline.TriggerType.ToString(), Type: cel.ParamTypeString},
+		{ParamName: cel.ContainerRepo, Value: containerRepository, Type: cel.ParamTypeString},
+		{ParamName: cel.ContainerImage, Value: artifact.Image, Type: cel.ParamTypeString},
+		{ParamName: cel.ContainerImageTag, Value: containerImageTag, Type: cel.ParamTypeString},
+		{ParamName: cel.ImageLabels, Value: imageLabels, Type: cel.ParamTypeList},
+	}
+	catalogParams, err := impl.catalogParamService.GetAppClusterAndArtifactParams(pipeline.PromotionCondition, pipeline.AppId, env.Cluster, artifact)
+	if err != nil {
+		impl.logger.Errorw("error in getting app, cluster and artifact params", "pipelineId", pipeline.Id, "err", err)
+		return nil, err
+	}
+	params = append(params, catalogParams...)
+	return params, nil
+}
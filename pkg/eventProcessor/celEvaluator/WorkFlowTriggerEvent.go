@@ -22,19 +22,22 @@ type TriggerEventEvaluatorImpl struct {
 	attributesService   attributes.AttributesService
 	celEvaluatorService cel.EvaluatorService
 	teamReadService     read.TeamReadService
+	catalogParamService CatalogParamService
 }
 
 func NewTriggerEventEvaluatorImpl(logger *zap.SugaredLogger,
 	imageTagRepository repository.ImageTaggingRepository,
 	attributesService attributes.AttributesService,
 	celEvaluatorService cel.EvaluatorService,
-	teamReadService read.TeamReadService) (*TriggerEventEvaluatorImpl, error) {
+	teamReadService read.TeamReadService,
+	catalogParamService CatalogParamService) (*TriggerEventEvaluatorImpl, error) {
 	impl := &TriggerEventEvaluatorImpl{
 		logger:              logger,
 		imageTagRepository:  imageTagRepository,
 		attributesService:   attributesService,
 		celEvaluatorService: celEvaluatorService,
 		teamReadService:     teamReadService,
+		catalogParamService: catalogParamService,
 	}
 	return impl, nil
 }
@@ -45,7 +48,7 @@ func (impl *TriggerEventEvaluatorImpl) IsPriorityDeployment(valuesOverrideRespon
 		impl.logger.Errorw("error while getting priority deployment CEL expression", "err", err)
 		return isPriorityEvent, err
 	}
-	params, err := impl.getParamsForPriorityDeployment(valuesOverrideResponse)
+	params, err := impl.getParamsForPriorityDeployment(expression, valuesOverrideResponse)
 	if err != nil {
 		impl.logger.Errorw("error while getting priority deployment CEL expression metadata", "err", err)
 		return isPriorityEvent, err
@@ -68,7 +71,7 @@ func (impl *TriggerEventEvaluatorImpl) getPriorityDeploymentExpression() (string
 	return attribute.Value, nil
 }
 
-func (impl *TriggerEventEvaluatorImpl) getParamsForPriorityDeployment(valuesOverrideResponse *app.ValuesOverrideResponse) ([]cel.ExpressionParam, error) {
+func (impl *TriggerEventEvaluatorImpl) getParamsForPriorityDeployment(expression string, valuesOverrideResponse *app.ValuesOverrideResponse) ([]cel.ExpressionParam, error) {
 	imageReleaseTags, err := impl.imageTagRepository.GetTagsByArtifactId(valuesOverrideResponse.Artifact.Id)
 	if err != nil && !errors.Is(err, pg.ErrNoRows) {
 		impl.logger.Errorw("error in fetching image tags using artifactId", "err", err, "artifactId", valuesOverrideResponse.Artifact.Id)
@@ -151,5 +154,12 @@ func (impl *TriggerEventEvaluatorImpl) getParamsForPriorityDeployment(valuesOver
 			Type:      cel.ParamTypeList,
 		},
 	}
+	catalogParams, err := impl.catalogParamService.GetAppClusterAndArtifactParams(expression, valuesOverrideResponse.Pipeline.AppId,
+		valuesOverrideResponse.EnvOverride.Environment.Cluster, valuesOverrideResponse.Artifact)
+	if err != nil {
+		impl.logger.Errorw("error in getting app, cluster and artifact params", "pipelineId", valuesOverrideResponse.Pipeline.Id, "err", err)
+		return nil, err
+	}
+	params = append(params, catalogParams...)
 	return params, nil
 }
@@ -22,8 +22,12 @@ import (
 )
 
 var EventProcessorOutWireSet = wire.NewSet(
+	celEvaluator.NewCatalogParamServiceImpl,
+	wire.Bind(new(celEvaluator.CatalogParamService), new(*celEvaluator.CatalogParamServiceImpl)),
 	celEvaluator.NewTriggerEventEvaluatorImpl,
 	wire.Bind(new(celEvaluator.TriggerEventEvaluator), new(*celEvaluator.TriggerEventEvaluatorImpl)),
+	celEvaluator.NewPromotionConditionEvaluatorImpl,
+	wire.Bind(new(celEvaluator.PromotionConditionEvaluator), new(*celEvaluator.PromotionConditionEvaluatorImpl)),
 
 	NewWorkflowEventPublishServiceImpl,
 	wire.Bind(new(WorkflowEventPublishService), new(*WorkflowEventPublishServiceImpl)),
@@ -73,6 +73,7 @@ type WorkflowServiceImpl struct {
 	globalCMCSService       pipeline.GlobalCMCSService
 	argoWorkflowExecutor    executors.ArgoWorkflowExecutor
 	systemWorkflowExecutor  executors.SystemWorkflowExecutor
+	tektonWorkflowExecutor  executors.TektonWorkflowExecutor
 	k8sCommonService        k8s2.K8sCommonService
 	infraProvider           infraProviders.InfraProvider
 	ucid                    ucid.Service
@@ -90,6 +91,7 @@ func NewWorkflowServiceImpl(Logger *zap.SugaredLogger,
 	globalCMCSService pipeline.GlobalCMCSService,
 	argoWorkflowExecutor executors.ArgoWorkflowExecutor,
 	systemWorkflowExecutor executors.SystemWorkflowExecutor,
+	tektonWorkflowExecutor executors.TektonWorkflowExecutor,
 	k8sCommonService k8s2.K8sCommonService,
 	infraProvider infraProviders.InfraProvider,
 	ucid ucid.Service,
@@ -106,6 +108,7 @@ func NewWorkflowServiceImpl(Logger *zap.SugaredLogger,
 		argoWorkflowExecutor:    argoWorkflowExecutor,
 		k8sUtil:                 k8sUtil,
 		systemWorkflowExecutor:  systemWorkflowExecutor,
+		tektonWorkflowExecutor:  tektonWorkflowExecutor,
 		k8sCommonService:        k8sCommonService,
 		infraProvider:           infraProvider,
 		ucid:                    ucid,
@@ -183,6 +186,11 @@ func (impl *WorkflowServiceImpl) createWorkflowTemplateAndAuditTrigger(workflowR
 		return bean3.WorkflowTemplate{}, err
 	}
 	workflowRequest.AddNodeConstraintsFromConfig(&workflowTemplate, impl.ciCdConfig)
+	err = workflowRequest.AddPodConfigurationsFromConfig(&workflowTemplate, impl.ciCdConfig)
+	if err != nil {
+		impl.Logger.Errorw("error occurred while adding workflow pod configurations", "err", err)
+		return bean3.WorkflowTemplate{}, err
+	}
 	infraConfiguration := &v1.InfraConfig{}
 	shouldAddExistingCmCsInWorkflow := impl.shouldAddExistingCmCsInWorkflow(workflowRequest)
 	if workflowRequest.Type == bean3.CI_WORKFLOW_PIPELINE_TYPE || workflowRequest.Type == bean3.JOB_WORKFLOW_PIPELINE_TYPE {
@@ -425,6 +433,8 @@ func (impl *WorkflowServiceImpl) getWorkflowExecutor(executorType cdWorkflow.Wor
 		return impl.argoWorkflowExecutor
 	} else if executorType == cdWorkflow.WORKFLOW_EXECUTOR_TYPE_SYSTEM {
 		return impl.systemWorkflowExecutor
+	} else if executorType == cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON {
+		return impl.tektonWorkflowExecutor
 	}
 	impl.Logger.Warnw("workflow executor not found", "type", executorType)
 	return nil
@@ -618,8 +628,31 @@ func (impl *WorkflowServiceImpl) applyInfraConfigToWorkflowTemplate(workflowRequ
 				return err
 			}
 		}
+
+		// Apply ephemeral storage limits and requests
+		if infraConfig.GetCiLimitEphemeralStorage() != "" {
+			if ephemeralStorageLimit, err := resource.ParseQuantity(infraConfig.GetCiLimitEphemeralStorage()); err == nil {
+				container.Resources.Limits[v12.ResourceEphemeralStorage] = ephemeralStorageLimit
+				impl.Logger.Debugw("applied historical ephemeral storage limit to workflow template", "ephemeralStorageLimit", infraConfig.GetCiLimitEphemeralStorage(), "workflowId", workflowRequest.WorkflowId)
+			} else {
+				impl.Logger.Errorw("failed to parse ephemeral storage limit from historical config", "ephemeralStorageLimit", infraConfig.GetCiLimitEphemeralStorage(), "err", err)
+				return err
+			}
+		}
+		if infraConfig.GetCiReqEphemeralStorage() != "" {
+			if ephemeralStorageRequest, err := resource.ParseQuantity(infraConfig.GetCiReqEphemeralStorage()); err == nil {
+				container.Resources.Requests[v12.ResourceEphemeralStorage] = ephemeralStorageRequest
+				impl.Logger.Debugw("applied historical ephemeral storage request to workflow template", "ephemeralStorageRequest", infraConfig.GetCiReqEphemeralStorage(), "workflowId", workflowRequest.WorkflowId)
+			} else {
+				impl.Logger.Errorw("failed to parse ephemeral storage request from historical config", "ephemeralStorageRequest", infraConfig.GetCiReqEphemeralStorage(), "err", err)
+				return err
+			}
+		}
 	}
 
+	// Apply service account, priority class and pod metadata
+	workflowRequest.AddPodInfraConfigurations(workflowTemplate, infraConfig)
+
 	return impl.applyEnterpriseInfraConfigToWorkflowTemplate(workflowRequest, workflowTemplate, infraConfig)
 }
 
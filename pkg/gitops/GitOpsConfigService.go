@@ -184,8 +184,9 @@ func (impl *GitOpsConfigServiceImpl) ValidateAndUpdateGitOpsConfig(config *apiBe
 	isTlsDetailsEmpty := config.EnableTLSVerification &&
 		(config.TLSConfig == nil ||
 			(config.TLSConfig != nil && (len(config.TLSConfig.CaData) == 0 || len(config.TLSConfig.TLSCertData) == 0 || len(config.TLSConfig.TLSKeyData) == 0)))
+	isSigningKeyHidden := isSigningKeyHiddenInRequest(config)
 
-	if isTokenEmpty || isTlsDetailsEmpty {
+	if isTokenEmpty || isTlsDetailsEmpty || isSigningKeyHidden {
 		model, err := impl.gitOpsRepository.GetGitOpsConfigById(config.Id)
 		if err != nil {
 			impl.logger.Errorw("No matching entry found for update.", "id", config.Id)
@@ -198,6 +199,10 @@ func (impl *GitOpsConfigServiceImpl) ValidateAndUpdateGitOpsConfig(config *apiBe
 		if isTokenEmpty {
 			config.Token = model.Token.String()
 		}
+		if isSigningKeyHidden {
+			config.SigningConfig.SigningKey = model.SigningKey.String()
+			config.SigningConfig.Passphrase = model.SigningKeyPassphrase.String()
+		}
 		if isTlsDetailsEmpty {
 			caData := model.CaCert
 			tlsCert := model.TlsCert
@@ -432,6 +437,7 @@ func (impl *GitOpsConfigServiceImpl) createGitOpsConfig(ctx context.Context, req
 		AuthMode:              request.AuthMode.ToInternalAuthMode(),
 		AuditLog:              sql.AuditLog{CreatedBy: request.UserId, CreatedOn: time.Now(), UpdatedOn: time.Now(), UpdatedBy: request.UserId},
 	}
+	setSigningConfigInModel(model, request.SigningConfig)
 
 	if request.EnableTLSVerification {
 		if len(request.TLSConfig.CaData) > 0 {
@@ -708,6 +714,7 @@ func (impl *GitOpsConfigServiceImpl) updateGitOpsConfig(request *apiBean.GitOpsC
 	model.UpdatedBy = request.UserId
 	model.UpdatedOn = time.Now()
 	model.AuthMode = request.AuthMode.ToInternalAuthMode()
+	setSigningConfigInModel(model, request.SigningConfig)
 
 	if request.EnableTLSVerification {
 		if len(request.TLSConfig.CaData) > 0 {
@@ -827,6 +834,7 @@ func (impl *GitOpsConfigServiceImpl) GetGitOpsConfigById(id int) (*apiBean.GitOp
 		IsCADataPresent:      len(model.CaCert) > 0,
 		IsTLSCertDataPresent: len(model.TlsCert) > 0,
 		IsTLSKeyDataPresent:  len(model.TlsKey) > 0,
+		SigningConfig:        getHiddenSigningConfig(model),
 	}
 	return config, err
 }
@@ -863,6 +871,7 @@ func (impl *GitOpsConfigServiceImpl) GetAllGitOpsConfig() ([]*apiBean.GitOpsConf
 			IsTLSCertDataPresent: len(model.TlsCert) > 0,
 			IsTLSKeyDataPresent:  len(model.TlsKey) > 0,
 			AuthMode:             adapter.ToGitOpsSupportedModes(model.AuthMode),
+			SigningConfig:        getHiddenSigningConfig(model),
 		}
 		configs = append(configs, config)
 	}
@@ -898,6 +907,7 @@ func (impl *GitOpsConfigServiceImpl) GetGitOpsConfigByProvider(provider string)
 		IsCADataPresent:      len(model.CaCert) > 0,
 		IsTLSCertDataPresent: len(model.TlsCert) > 0,
 		IsTLSKeyDataPresent:  len(model.TlsKey) > 0,
+		SigningConfig:        getHiddenSigningConfig(model),
 	}
 
 	return config, err
@@ -907,8 +917,9 @@ func (impl *GitOpsConfigServiceImpl) GitOpsValidateDryRun(isArgoModuleInstalled
 
 	isTokenEmpty := config.Token == ""
 	isTlsDetailsEmpty := config.EnableTLSVerification && (len(config.TLSConfig.CaData) == 0 && len(config.TLSConfig.TLSCertData) == 0 && len(config.TLSConfig.TLSKeyData) == 0)
+	isSigningKeyHidden := isSigningKeyHiddenInRequest(config)
 
-	if isTokenEmpty || isTlsDetailsEmpty {
+	if isTokenEmpty || isTlsDetailsEmpty || isSigningKeyHidden {
 		model, err := impl.gitOpsRepository.GetGitOpsConfigById(config.Id)
 		if err != nil {
 			impl.logger.Errorw("No matching entry found for update.", "id", config.Id)
@@ -921,6 +932,10 @@ func (impl *GitOpsConfigServiceImpl) GitOpsValidateDryRun(isArgoModuleInstalled
 		if isTokenEmpty {
 			config.Token = model.Token.String()
 		}
+		if isSigningKeyHidden {
+			config.SigningConfig.SigningKey = model.SigningKey.String()
+			config.SigningConfig.Passphrase = model.SigningKeyPassphrase.String()
+		}
 		if isTlsDetailsEmpty {
 			caData := model.CaCert
 			tlsCert := model.TlsCert
@@ -997,3 +1012,31 @@ func (impl *GitOpsConfigServiceImpl) createRepoElement(secretName string, reques
 	repoData.UsernameSecret = usernameSecret
 	return repoData
 }
+
+// isSigningKeyHiddenInRequest is true when the saved signing key, which is hidden in FE, is to be retained
+func isSigningKeyHiddenInRequest(config *apiBean.GitOpsConfigDto) bool {
+	return config.SigningConfig != nil && config.SigningConfig.IsSigningKeyPresent && len(config.SigningConfig.SigningKey) == 0
+}
+
+func setSigningConfigInModel(model *repository.GitOpsConfig, signingConfig *apiBean.GitOpsSigningConfig) {
+	if signingConfig.IsEmpty() {
+		model.SigningKeyType = ""
+		model.SigningKey = ""
+		model.SigningKeyPassphrase = ""
+		return
+	}
+	model.SigningKeyType = signingConfig.KeyType
+	model.SigningKey = securestore.ToEncryptedString(signingConfig.SigningKey)
+	model.SigningKeyPassphrase = securestore.ToEncryptedString(signingConfig.Passphrase)
+}
+
+func getHiddenSigningConfig(model *repository.GitOpsConfig) *apiBean.GitOpsSigningConfig {
+	if len(model.SigningKey.String()) == 0 {
+		return nil
+	}
+	// sending only the key type as the signing key and passphrase are hidden in FE
+	return &apiBean.GitOpsSigningConfig{
+		KeyType:             model.SigningKeyType,
+		IsSigningKeyPresent: true,
+	}
+}
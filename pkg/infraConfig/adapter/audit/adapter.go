@@ -17,6 +17,7 @@
 package audit
 
 import (
+	"encoding/json"
 	"github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
 	"github.com/devtron-labs/devtron/pkg/infraConfig/repository/audit"
 	"strconv"
@@ -29,6 +30,11 @@ func GetInfraConfigTriggerAudit(config *v1.InfraConfig) ([]*audit.InfraConfigTri
 	infraConfigTriggerHistories = append(infraConfigTriggerHistories, GetMemoryLimit(config))
 	infraConfigTriggerHistories = append(infraConfigTriggerHistories, GetMemoryRequest(config))
 	infraConfigTriggerHistories = append(infraConfigTriggerHistories, GetCiDefaultTimeout(config))
+	podConfigTriggerHistories, err := getPodConfigTriggerAudit(config)
+	if err != nil {
+		return infraConfigTriggerHistories, err
+	}
+	infraConfigTriggerHistories = append(infraConfigTriggerHistories, podConfigTriggerHistories...)
 	infraConfigEntTriggerHistories, err := getInfraConfigEntTriggerAudit(config)
 	if err != nil {
 		return infraConfigTriggerHistories, err
@@ -71,3 +77,48 @@ func GetCiDefaultTimeout(config *v1.InfraConfig) *audit.InfraConfigTriggerHistor
 		Key:         v1.TimeOutKey,
 	}
 }
+
+// getPodConfigTriggerAudit returns the histories of the optional pod configurations,
+// only the configurations applied to the workflow are audited
+func getPodConfigTriggerAudit(config *v1.InfraConfig) ([]*audit.InfraConfigTriggerHistory, error) {
+	infraConfigTriggerHistories := make([]*audit.InfraConfigTriggerHistory, 0)
+	stringConfigs := []struct {
+		key   v1.ConfigKey
+		value string
+	}{
+		{key: v1.EphemeralStorageLimitKey, value: config.GetCiLimitEphemeralStorage()},
+		{key: v1.EphemeralStorageRequestKey, value: config.GetCiReqEphemeralStorage()},
+		{key: v1.ServiceAccountKey, value: config.GetCiServiceAccount()},
+		{key: v1.PriorityClassKey, value: config.GetCiPriorityClass()},
+	}
+	for _, stringConfig := range stringConfigs {
+		if len(stringConfig.value) == 0 {
+			continue
+		}
+		infraConfigTriggerHistories = append(infraConfigTriggerHistories, &audit.InfraConfigTriggerHistory{
+			ValueString: stringConfig.value,
+			Key:         stringConfig.key,
+		})
+	}
+	mapConfigs := []struct {
+		key   v1.ConfigKey
+		value map[string]string
+	}{
+		{key: v1.PodLabelsKey, value: config.GetCiPodLabels()},
+		{key: v1.PodAnnotationsKey, value: config.GetCiPodAnnotations()},
+	}
+	for _, mapConfig := range mapConfigs {
+		if len(mapConfig.value) == 0 {
+			continue
+		}
+		valueString, err := json.Marshal(mapConfig.value)
+		if err != nil {
+			return infraConfigTriggerHistories, err
+		}
+		infraConfigTriggerHistories = append(infraConfigTriggerHistories, &audit.InfraConfigTriggerHistory{
+			ValueString: string(valueString),
+			Key:         mapConfig.key,
+		})
+	}
+	return infraConfigTriggerHistories, nil
+}
@@ -64,7 +64,7 @@ type GenericConfigurationBean[T any] struct {
 
 type ConfigurationBeanAbstract struct {
 	Id          int          `json:"id"`
-	Key         ConfigKeyStr `json:"key" validate:"required,oneof=cpu_limit cpu_request memory_limit memory_request timeout node_selector tolerations cm cs"`
+	Key         ConfigKeyStr `json:"key" validate:"required,oneof=cpu_limit cpu_request memory_limit memory_request timeout node_selector tolerations cm cs ephemeral_storage_limit ephemeral_storage_request service_account pod_labels pod_annotations priority_class"`
 	Unit        string       `json:"unit"`
 	ProfileName string       `json:"profileName,omitempty"`
 	ProfileId   int          `json:"profileId,omitempty"`
@@ -90,6 +90,13 @@ const (
 	TolerationsKey  ConfigKey = 7
 	ConfigMapKey    ConfigKey = 8
 	SecretKey       ConfigKey = 9
+
+	EphemeralStorageLimitKey   ConfigKey = 10
+	EphemeralStorageRequestKey ConfigKey = 11
+	ServiceAccountKey          ConfigKey = 12
+	PodLabelsKey               ConfigKey = 13
+	PodAnnotationsKey          ConfigKey = 14
+	PriorityClassKey           ConfigKey = 15
 )
 
 // ConfigKeyStr represents the configuration key in the API
@@ -108,13 +115,25 @@ const (
 	TOLERATIONS   ConfigKeyStr = "tolerations"
 	CONFIG_MAP    ConfigKeyStr = "cm"
 	SECRET        ConfigKeyStr = "cs"
+
+	EPHEMERAL_STORAGE_LIMIT   ConfigKeyStr = "ephemeral_storage_limit"
+	EPHEMERAL_STORAGE_REQUEST ConfigKeyStr = "ephemeral_storage_request"
+	SERVICE_ACCOUNT           ConfigKeyStr = "service_account"
+	POD_LABELS                ConfigKeyStr = "pod_labels"
+	POD_ANNOTATIONS           ConfigKeyStr = "pod_annotations"
+	PRIORITY_CLASS            ConfigKeyStr = "priority_class"
 )
 
 // AllConfigKeysV0 contains the list of supported configuration keys in V0
 var AllConfigKeysV0 = []ConfigKeyStr{CPU_LIMIT, CPU_REQUEST, MEMORY_LIMIT, MEMORY_REQUEST, TIME_OUT}
 
 // AllConfigKeysV1 contains the list of supported configuration keys in V1
-var AllConfigKeysV1 = append(AllConfigKeysV0, []ConfigKeyStr{NODE_SELECTOR, TOLERATIONS, CONFIG_MAP, SECRET}...)
+var AllConfigKeysV1 = append(AllConfigKeysV0, []ConfigKeyStr{NODE_SELECTOR, TOLERATIONS, CONFIG_MAP, SECRET,
+	EPHEMERAL_STORAGE_LIMIT, EPHEMERAL_STORAGE_REQUEST, SERVICE_ACCOUNT, POD_LABELS, POD_ANNOTATIONS, PRIORITY_CLASS}...)
+
+// OptionalConfigKeys contains the configuration keys which are not mandatory in the global profile,
+// the workflow defaults are used for the keys which are not configured in the applied profile
+var OptionalConfigKeys = []ConfigKeyStr{EPHEMERAL_STORAGE_LIMIT, EPHEMERAL_STORAGE_REQUEST, SERVICE_ACCOUNT, POD_LABELS, POD_ANNOTATIONS, PRIORITY_CLASS}
 
 type InfraConfigKeys map[ConfigKeyStr]bool
 
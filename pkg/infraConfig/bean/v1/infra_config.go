@@ -32,6 +32,15 @@ type InfraConfig struct {
 	// CiDefaultTimeout is the default timeout for CI jobs in seconds
 	// Earlier it was in int64, but now it is in float64
 	CiDefaultTimeout float64 `env:"DEFAULT_TIMEOUT" envDefault:"3600" description:"Timeout for CI to be completed"`
+	// CiLimitEphemeralStorage and CiReqEphemeralStorage are not applied to the workflow when empty
+	CiLimitEphemeralStorage string `env:"LIMIT_CI_EPHEMERAL_STORAGE" envDefault:"" description:"Ephemeral storage limit of the CI pod, not applied when empty"`
+	CiReqEphemeralStorage   string `env:"REQ_CI_EPHEMERAL_STORAGE" envDefault:"" description:"Ephemeral storage request of the CI pod, not applied when empty"`
+
+	// pod spec and metadata, the workflow defaults are used when empty
+	CiServiceAccount string            `env:"-"`
+	CiPriorityClass  string            `env:"-"`
+	CiPodLabels      map[string]string `env:"-"`
+	CiPodAnnotations map[string]string `env:"-"`
 
 	// cm and cs
 	ConfigMaps []bean.ConfigSecretMap `env:"-"`
@@ -121,3 +130,93 @@ func (infraConfig *InfraConfig) SetCiTimeout(timeout float64) *InfraConfig {
 	infraConfig.CiDefaultTimeout = timeout
 	return infraConfig
 }
+
+func (infraConfig *InfraConfig) GetCiLimitEphemeralStorage() string {
+	if infraConfig == nil {
+		return ""
+	}
+	return infraConfig.CiLimitEphemeralStorage
+}
+
+func (infraConfig *InfraConfig) SetCiLimitEphemeralStorage(ephemeralStorage string) *InfraConfig {
+	if infraConfig == nil {
+		return nil
+	}
+	infraConfig.CiLimitEphemeralStorage = ephemeralStorage
+	return infraConfig
+}
+
+func (infraConfig *InfraConfig) GetCiReqEphemeralStorage() string {
+	if infraConfig == nil {
+		return ""
+	}
+	return infraConfig.CiReqEphemeralStorage
+}
+
+func (infraConfig *InfraConfig) SetCiReqEphemeralStorage(ephemeralStorage string) *InfraConfig {
+	if infraConfig == nil {
+		return nil
+	}
+	infraConfig.CiReqEphemeralStorage = ephemeralStorage
+	return infraConfig
+}
+
+func (infraConfig *InfraConfig) GetCiServiceAccount() string {
+	if infraConfig == nil {
+		return ""
+	}
+	return infraConfig.CiServiceAccount
+}
+
+func (infraConfig *InfraConfig) SetCiServiceAccount(serviceAccount string) *InfraConfig {
+	if infraConfig == nil {
+		return nil
+	}
+	infraConfig.CiServiceAccount = serviceAccount
+	return infraConfig
+}
+
+func (infraConfig *InfraConfig) GetCiPriorityClass() string {
+	if infraConfig == nil {
+		return ""
+	}
+	return infraConfig.CiPriorityClass
+}
+
+func (infraConfig *InfraConfig) SetCiPriorityClass(priorityClass string) *InfraConfig {
+	if infraConfig == nil {
+		return nil
+	}
+	infraConfig.CiPriorityClass = priorityClass
+	return infraConfig
+}
+
+func (infraConfig *InfraConfig) GetCiPodLabels() map[string]string {
+	if infraConfig == nil {
+		return nil
+	}
+	return infraConfig.CiPodLabels
+}
+
+func (infraConfig *InfraConfig) SetCiPodLabels(labels map[string]string) *InfraConfig {
+	if infraConfig == nil {
+		return nil
+	}
+	infraConfig.CiPodLabels = labels
+	return infraConfig
+}
+
+func (infraConfig *InfraConfig) GetCiPodAnnotations() map[string]string {
+	if infraConfig == nil {
+		return nil
+	}
+	return infraConfig.CiPodAnnotations
+}
+
+func (infraConfig *InfraConfig) SetCiPodAnnotations(annotations map[string]string) *InfraConfig {
+	if infraConfig == nil {
+		return nil
+	}
+	infraConfig.CiPodAnnotations = annotations
+	return infraConfig
+}
@@ -76,6 +76,18 @@ func (impl *InfraConfigClientImpl) getTimeoutConfigFactory() configFactory[float
 	return impl.configFactories.timeoutConfigFactory
 }
 
+func (impl *InfraConfigClientImpl) getEphemeralStorageConfigFactory() configFactory[float64] {
+	return impl.configFactories.ephemeralStorageConfigFactory
+}
+
+func (impl *InfraConfigClientImpl) getPodSpecConfigFactory() configFactory[string] {
+	return impl.configFactories.podSpecConfigFactory
+}
+
+func (impl *InfraConfigClientImpl) getPodMetadataConfigFactory() configFactory[map[string]string] {
+	return impl.configFactories.podMetadataConfigFactory
+}
+
 func (impl *InfraConfigClientImpl) GetDefaultConfigurationForPlatform(platformName string, defaultConfigurationsMap map[string][]*v1.ConfigurationBean) []*v1.ConfigurationBean {
 	if len(defaultConfigurationsMap) == 0 {
 		return []*v1.ConfigurationBean{}
@@ -144,6 +156,15 @@ func (impl *InfraConfigClientImpl) GetConfigurationUnits() (map[v1.ConfigKeyStr]
 	for configKey, supportedUnits := range impl.getTimeoutConfigFactory().getSupportedUnits() {
 		configurationUnits[configKey] = supportedUnits
 	}
+	for configKey, supportedUnits := range impl.getEphemeralStorageConfigFactory().getSupportedUnits() {
+		configurationUnits[configKey] = supportedUnits
+	}
+	for configKey, supportedUnits := range impl.getPodSpecConfigFactory().getSupportedUnits() {
+		configurationUnits[configKey] = supportedUnits
+	}
+	for configKey, supportedUnits := range impl.getPodMetadataConfigFactory().getSupportedUnits() {
+		configurationUnits[configKey] = supportedUnits
+	}
 	entConfigurationUnits, err := impl.getEntConfigurationUnits()
 	if err != nil {
 		return configurationUnits, err
@@ -201,6 +222,15 @@ func (impl *InfraConfigClientImpl) GetInfraConfigEntities(profileId int, infraCo
 		func(entity *repository.InfraProfileConfigurationEntity) bool {
 			return entity != nil
 		})
+	ephemeralStorageInfraEntities, err := impl.getEphemeralStorageConfigFactory().getInfraConfigEntities(infraConfig, profileId, v1.RUNNER_PLATFORM)
+	if err != nil {
+		impl.logger.Errorw("error in getting infra ephemeral storage config entities", "error", err, "infraConfig", infraConfig)
+		return defaultConfigurations, err
+	}
+	defaultConfigurations = sliceUtil.Filter(defaultConfigurations, ephemeralStorageInfraEntities,
+		func(entity *repository.InfraProfileConfigurationEntity) bool {
+			return entity != nil
+		})
 	entInfraEntities, err := impl.getInfraConfigEntEntities(profileId, infraConfig)
 	if err != nil {
 		impl.logger.Errorw("error in getting infra ent config entities", "error", err, "infraConfig", infraConfig)
@@ -330,6 +360,48 @@ func (impl *InfraConfigClientImpl) validateConfig(platformName string, platformC
 		}
 	}
 
+	ephemeralStorageConfigKeys := impl.getEphemeralStorageConfigFactory().getConfigKeys()
+	if err := impl.getEphemeralStorageConfigFactory().validate(platformConfigurations, defaultConfigurations); err != nil {
+		for _, ephemeralStorageConfigKey := range ephemeralStorageConfigKeys {
+			supportedConfigKeyMap = supportedConfigKeyMap.MarkUnConfigured(ephemeralStorageConfigKey)
+		}
+		if !skipError {
+			return supportedConfigKeyMap, err
+		}
+	} else {
+		for _, ephemeralStorageConfigKey := range ephemeralStorageConfigKeys {
+			supportedConfigKeyMap = supportedConfigKeyMap.MarkConfigured(ephemeralStorageConfigKey)
+		}
+	}
+
+	podSpecConfigKeys := impl.getPodSpecConfigFactory().getConfigKeys()
+	if err := impl.getPodSpecConfigFactory().validate(platformConfigurations, defaultConfigurations); err != nil {
+		for _, podSpecConfigKey := range podSpecConfigKeys {
+			supportedConfigKeyMap = supportedConfigKeyMap.MarkUnConfigured(podSpecConfigKey)
+		}
+		if !skipError {
+			return supportedConfigKeyMap, err
+		}
+	} else {
+		for _, podSpecConfigKey := range podSpecConfigKeys {
+			supportedConfigKeyMap = supportedConfigKeyMap.MarkConfigured(podSpecConfigKey)
+		}
+	}
+
+	podMetadataConfigKeys := impl.getPodMetadataConfigFactory().getConfigKeys()
+	if err := impl.getPodMetadataConfigFactory().validate(platformConfigurations, defaultConfigurations); err != nil {
+		for _, podMetadataConfigKey := range podMetadataConfigKeys {
+			supportedConfigKeyMap = supportedConfigKeyMap.MarkUnConfigured(podMetadataConfigKey)
+		}
+		if !skipError {
+			return supportedConfigKeyMap, err
+		}
+	} else {
+		for _, podMetadataConfigKey := range podMetadataConfigKeys {
+			supportedConfigKeyMap = supportedConfigKeyMap.MarkConfigured(podMetadataConfigKey)
+		}
+	}
+
 	supportedConfigKeyMap, err := impl.validateEntConfig(supportedConfigKeyMap, platformConfigurations, defaultConfigurations, skipError)
 	if !skipError && err != nil {
 		return supportedConfigKeyMap, err
@@ -368,6 +440,12 @@ func (impl *InfraConfigClientImpl) formatTypedValueAsString(configKey v1.ConfigK
 		return impl.getMemoryConfigFactory().formatTypedValueAsString(configValue)
 	case v1.TIME_OUT:
 		return impl.getTimeoutConfigFactory().formatTypedValueAsString(configValue)
+	case v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
+		return impl.getEphemeralStorageConfigFactory().formatTypedValueAsString(configValue)
+	case v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS:
+		return impl.getPodSpecConfigFactory().formatTypedValueAsString(configValue)
+	case v1.POD_LABELS, v1.POD_ANNOTATIONS:
+		return impl.getPodMetadataConfigFactory().formatTypedValueAsString(configValue)
 	default:
 		return impl.formatTypedValueAsStringEnt(configKey, configValue)
 	}
@@ -382,6 +460,12 @@ func (impl *InfraConfigClientImpl) convertValueStringToInterface(configKey v1.Co
 		return impl.getMemoryConfigFactory().getValueFromString(valueString)
 	case v1.TIME_OUT:
 		return impl.getTimeoutConfigFactory().getValueFromString(valueString)
+	case v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
+		return impl.getEphemeralStorageConfigFactory().getValueFromString(valueString)
+	case v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS:
+		return impl.getPodSpecConfigFactory().getValueFromString(valueString)
+	case v1.POD_LABELS, v1.POD_ANNOTATIONS:
+		return impl.getPodMetadataConfigFactory().getValueFromString(valueString)
 	// Add more cases as needed for different config keys
 	default:
 		return impl.convertValueStringToInterfaceEnt(configKey, valueString)
@@ -403,6 +487,18 @@ func (impl *InfraConfigClientImpl) HandlePostUpdateOperations(tx *pg.Tx, updated
 			if err := impl.getTimeoutConfigFactory().handlePostUpdateOperations(tx, updatedInfraConfig); err != nil {
 				return err
 			}
+		case v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
+			if err := impl.getEphemeralStorageConfigFactory().handlePostUpdateOperations(tx, updatedInfraConfig); err != nil {
+				return err
+			}
+		case v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS:
+			if err := impl.getPodSpecConfigFactory().handlePostUpdateOperations(tx, updatedInfraConfig); err != nil {
+				return err
+			}
+		case v1.POD_LABELS, v1.POD_ANNOTATIONS:
+			if err := impl.getPodMetadataConfigFactory().handlePostUpdateOperations(tx, updatedInfraConfig); err != nil {
+				return err
+			}
 		default:
 			if err := impl.handlePostUpdateOperationEnt(tx, updatedInfraConfig); err != nil {
 				return err
@@ -427,6 +523,18 @@ func (impl *InfraConfigClientImpl) HandlePostCreateOperations(tx *pg.Tx, created
 			if err := impl.getTimeoutConfigFactory().handlePostCreateOperations(tx, createdInfraConfig); err != nil {
 				return err
 			}
+		case v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
+			if err := impl.getEphemeralStorageConfigFactory().handlePostCreateOperations(tx, createdInfraConfig); err != nil {
+				return err
+			}
+		case v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS:
+			if err := impl.getPodSpecConfigFactory().handlePostCreateOperations(tx, createdInfraConfig); err != nil {
+				return err
+			}
+		case v1.POD_LABELS, v1.POD_ANNOTATIONS:
+			if err := impl.getPodMetadataConfigFactory().handlePostCreateOperations(tx, createdInfraConfig); err != nil {
+				return err
+			}
 		default:
 			if err := impl.handlePostCreateOperationEnt(tx, createdInfraConfig); err != nil {
 				return err
@@ -444,6 +552,12 @@ func (impl *InfraConfigClientImpl) OverrideInfraConfig(infraConfiguration *v1.In
 		return impl.getMemoryConfigFactory().overrideInfraConfig(infraConfiguration, configurationBean)
 	case v1.TIME_OUT:
 		return impl.getTimeoutConfigFactory().overrideInfraConfig(infraConfiguration, configurationBean)
+	case v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
+		return impl.getEphemeralStorageConfigFactory().overrideInfraConfig(infraConfiguration, configurationBean)
+	case v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS:
+		return impl.getPodSpecConfigFactory().overrideInfraConfig(infraConfiguration, configurationBean)
+	case v1.POD_LABELS, v1.POD_ANNOTATIONS:
+		return impl.getPodMetadataConfigFactory().overrideInfraConfig(infraConfiguration, configurationBean)
 	default:
 		return impl.overrideInfraConfigEnt(infraConfiguration, configurationBean)
 	}
@@ -461,6 +575,12 @@ func (impl *InfraConfigClientImpl) MergeInfraConfigurations(supportedConfigKey v
 		return impl.getMemoryConfigFactory().getAppliedConfiguration(v1.MEMORY_REQUEST, profileConfiguration, defaultConfigurations)
 	case v1.TIME_OUT:
 		return impl.getTimeoutConfigFactory().getAppliedConfiguration(v1.TIME_OUT, profileConfiguration, defaultConfigurations)
+	case v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
+		return impl.getEphemeralStorageConfigFactory().getAppliedConfiguration(supportedConfigKey, profileConfiguration, defaultConfigurations)
+	case v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS:
+		return impl.getPodSpecConfigFactory().getAppliedConfiguration(supportedConfigKey, profileConfiguration, defaultConfigurations)
+	case v1.POD_LABELS, v1.POD_ANNOTATIONS:
+		return impl.getPodMetadataConfigFactory().getAppliedConfiguration(supportedConfigKey, profileConfiguration, defaultConfigurations)
 	default:
 		return impl.mergeInfraConfigurationsEnt(supportedConfigKey, profileConfiguration, defaultConfigurations)
 	}
@@ -31,7 +31,7 @@ import (
 )
 
 type nativeValueKind interface {
-	float64
+	float64 | string | map[string]string
 }
 
 func validLimitRequestForCPUorMem(lim, limFactor, req, reqFactor float64) bool {
@@ -46,9 +46,12 @@ type configFactory[T any] interface {
 }
 
 type configFactories struct {
-	cpuConfigFactory     configFactory[float64]
-	memConfigFactory     configFactory[float64]
-	timeoutConfigFactory configFactory[float64]
+	cpuConfigFactory              configFactory[float64]
+	memConfigFactory              configFactory[float64]
+	timeoutConfigFactory          configFactory[float64]
+	ephemeralStorageConfigFactory configFactory[float64]
+	podSpecConfigFactory          configFactory[string]
+	podMetadataConfigFactory      configFactory[map[string]string]
 	configEntFactories
 }
 
@@ -63,10 +66,13 @@ func getConfigFactory(logger *zap.SugaredLogger,
 	scopedVariableManager variables.ScopedVariableManager,
 	configReadService read.ConfigReadService) *configFactories {
 	return &configFactories{
-		cpuConfigFactory:     newCPUClientImpl(logger),
-		memConfigFactory:     newMemClientImpl(logger),
-		timeoutConfigFactory: newTimeoutClientImpl(logger),
-		configEntFactories:   newConfigEntFactories(logger, scopedVariableManager, configReadService),
+		cpuConfigFactory:              newCPUClientImpl(logger),
+		memConfigFactory:              newMemClientImpl(logger),
+		timeoutConfigFactory:          newTimeoutClientImpl(logger),
+		ephemeralStorageConfigFactory: newEphemeralStorageClientImpl(logger),
+		podSpecConfigFactory:          newPodSpecClientImpl(logger),
+		podMetadataConfigFactory:      newPodMetadataClientImpl(logger),
+		configEntFactories:            newConfigEntFactories(logger, scopedVariableManager, configReadService),
 	}
 }
 
@@ -0,0 +1,269 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/adapter"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/errors"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/repository"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/units"
+	unitsBean "github.com/devtron-labs/devtron/pkg/infraConfig/units/bean"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	globalUtil "github.com/devtron-labs/devtron/util"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// ephemeralStorageClientImpl handles the ephemeral storage limit and request of the ci pod,
+// the values share the memory units
+type ephemeralStorageClientImpl struct {
+	logger         *zap.SugaredLogger
+	memUnitFactory units.UnitService[float64]
+}
+
+func newEphemeralStorageClientImpl(logger *zap.SugaredLogger) *ephemeralStorageClientImpl {
+	return &ephemeralStorageClientImpl{
+		logger:         logger,
+		memUnitFactory: units.NewMemoryUnitFactory(logger),
+	}
+}
+
+func (impl *ephemeralStorageClientImpl) getMemoryClient() units.UnitService[float64] {
+	return impl.memUnitFactory
+}
+
+// getAppliedConfiguration:
+//   - If the configuration is not found in profileBean,
+//     then the ephemeral storage configuration of the global profile is inherited.
+//   - If the configuration is not found in the global profile as well,
+//     then the ephemeral storage is not applied to the workflow.
+func (impl *ephemeralStorageClientImpl) getAppliedConfiguration(key v1.ConfigKeyStr, profileConfigBean *v1.ConfigurationBean, defaultConfigurations []*v1.ConfigurationBean) (*v1.ConfigurationBean, error) {
+	profileData, err := impl.getValueFromBean(profileConfigBean)
+	if err != nil {
+		impl.logger.Errorw("error in getting ephemeral storage config data", "error", err, "ephemeralStorageConfig", profileConfigBean)
+		return profileConfigBean, err
+	}
+	defaultConfigBean, defaultData, err := impl.getConfigBeanAndDataForKey(key, defaultConfigurations)
+	if err != nil {
+		impl.logger.Errorw("error in getting ephemeral storage config data", "error", err, "ephemeralStorageConfig", defaultConfigurations)
+		return profileConfigBean, err
+	}
+	defaultConfigBeanAbstract := v1.ConfigurationBeanAbstract{
+		Key:  key,
+		Unit: impl.memUnitFactory.GetDefaultUnitSuffix(),
+	}
+	return getInheritedConfigurations(defaultConfigBeanAbstract, profileData, defaultData, profileConfigBean, defaultConfigBean)
+}
+
+func (impl *ephemeralStorageClientImpl) getConfigBeanAndDataForKey(key v1.ConfigKeyStr, configurations []*v1.ConfigurationBean) (configBean *v1.ConfigurationBean, configData float64, err error) {
+	for _, configuration := range configurations {
+		if configuration.Key == key {
+			configBean = configuration
+			configData, err = impl.getValueFromBean(configBean)
+			return configBean, configData, err
+		}
+	}
+	return configBean, configData, nil
+}
+
+func (impl *ephemeralStorageClientImpl) validate(platformConfigurations, defaultConfigurations []*v1.ConfigurationBean) (err error) {
+	var storageLimit, storageReq *v1.ConfigurationBean
+	for _, configuration := range platformConfigurations {
+		switch configuration.Key {
+		case v1.EPHEMERAL_STORAGE_LIMIT:
+			storageLimit = configuration
+		case v1.EPHEMERAL_STORAGE_REQUEST:
+			storageReq = configuration
+		}
+	}
+	storageLimit, err = impl.getAppliedConfiguration(v1.EPHEMERAL_STORAGE_LIMIT, storageLimit, defaultConfigurations)
+	if err != nil {
+		impl.logger.Errorw("error in merging ephemeral storage limit configurations", "error", err, "storageLimit", storageLimit)
+		return err
+	}
+	storageReq, err = impl.getAppliedConfiguration(v1.EPHEMERAL_STORAGE_REQUEST, storageReq, defaultConfigurations)
+	if err != nil {
+		impl.logger.Errorw("error in merging ephemeral storage request configurations", "error", err, "storageReq", storageReq)
+		return err
+	}
+	storageLimitConfig, err := impl.validateConfigurationUnit(storageLimit)
+	if err != nil {
+		return err
+	}
+	storageReqConfig, err := impl.validateConfigurationUnit(storageReq)
+	if err != nil {
+		return err
+	}
+	// the limit is compared with the request only when both of them are applied
+	if storageLimitConfig.IsEmpty() || storageReqConfig.IsEmpty() || storageLimitConfig.Value == 0 || storageReqConfig.Value == 0 {
+		return nil
+	}
+	if !validLimitRequestForCPUorMem(storageLimitConfig.Value, storageLimitConfig.Unit.ConversionFactor, storageReqConfig.Value, storageReqConfig.Unit.ConversionFactor) {
+		impl.logger.Errorw("error in comparing ephemeral storage limit and request", "storageLimit", storageLimitConfig, "storageReq", storageReqConfig)
+		return util.NewApiError(http.StatusBadRequest, errors.EphemeralStorageLimReqErrorCompErr, errors.EphemeralStorageLimReqErrorCompErr)
+	}
+	return nil
+}
+
+func (impl *ephemeralStorageClientImpl) validateConfigurationUnit(configurationBean *v1.ConfigurationBean) (*unitsBean.ConfigValue[float64], error) {
+	if configurationBean.IsEmpty() {
+		return nil, nil
+	}
+	value, err := impl.getValueFromBean(configurationBean)
+	if err != nil {
+		impl.logger.Errorw("error in getting ephemeral storage value", "error", err, "configuration", configurationBean)
+		return nil, err
+	}
+	configValue, err := impl.getMemoryClient().Validate(adapter.GetGenericConfigurationBean(configurationBean, value))
+	if err != nil {
+		impl.logger.Errorw("error in validating ephemeral storage unit", "error", err, "configuration", configurationBean)
+		return nil, err
+	}
+	return configValue, nil
+}
+
+func (impl *ephemeralStorageClientImpl) getConfigKeys() []v1.ConfigKeyStr {
+	return []v1.ConfigKeyStr{v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST}
+}
+
+func (impl *ephemeralStorageClientImpl) getSupportedUnits() map[v1.ConfigKeyStr]map[string]v1.Unit {
+	supportedUnitsMap := make(map[v1.ConfigKeyStr]map[string]v1.Unit)
+	supportedUnits := impl.getMemoryClient().GetAllUnits()
+	for _, configKey := range impl.getConfigKeys() {
+		supportedUnitsMap[configKey] = supportedUnits
+	}
+	return supportedUnitsMap
+}
+
+// getInfraConfigEntities returns the ephemeral storage configurations provided in the environment,
+// unlike cpu and memory nothing is created for the global profile when they are not provided
+func (impl *ephemeralStorageClientImpl) getInfraConfigEntities(infraConfig *v1.InfraConfig, profileId int, platformName string) ([]*repository.InfraProfileConfigurationEntity, error) {
+	defaultConfigurations := make([]*repository.InfraProfileConfigurationEntity, 0)
+	envValues := map[v1.ConfigKeyStr]string{
+		v1.EPHEMERAL_STORAGE_LIMIT:   infraConfig.GetCiLimitEphemeralStorage(),
+		v1.EPHEMERAL_STORAGE_REQUEST: infraConfig.GetCiReqEphemeralStorage(),
+	}
+	for _, key := range impl.getConfigKeys() {
+		if len(envValues[key]) == 0 {
+			continue
+		}
+		value, unitType, err := parseCPUorMemoryValue[unitsBean.MemoryUnitStr](envValues[key])
+		if err != nil {
+			return defaultConfigurations, err
+		}
+		parsedValue, err := impl.getMemoryClient().ParseValAndUnit(value, unitType.GetUnitSuffix())
+		if err != nil {
+			return defaultConfigurations, err
+		}
+		defaultConfigurations = append(defaultConfigurations, adapter.NewInfraProfileConfigEntity(key, profileId, platformName, parsedValue))
+	}
+	return defaultConfigurations, nil
+}
+
+func (impl *ephemeralStorageClientImpl) getValueFromString(valueString string) (float64, int, error) {
+	valueFloat, err := strconv.ParseFloat(valueString, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	truncateValue := globalUtil.TruncateFloat(valueFloat, 2)
+	return truncateValue, impl.getValueCount(truncateValue), nil
+}
+
+func (impl *ephemeralStorageClientImpl) overrideInfraConfig(infraConfiguration *v1.InfraConfig, configurationBean *v1.ConfigurationBean) (*v1.InfraConfig, error) {
+	storageData, err := impl.getValueFromBean(configurationBean)
+	if err != nil {
+		return infraConfiguration, err
+	}
+	// a missing configuration is not applied to the workflow
+	var storageInfraConfigData string
+	if storageData != 0 {
+		if configurationBean.Unit == unitsBean.BYTE.String() {
+			storageInfraConfigData = fmt.Sprintf("%v", storageData)
+		} else {
+			storageInfraConfigData = fmt.Sprintf("%v%v", storageData, configurationBean.Unit)
+		}
+	}
+	switch configurationBean.Key {
+	case v1.EPHEMERAL_STORAGE_LIMIT:
+		infraConfiguration = infraConfiguration.SetCiLimitEphemeralStorage(storageInfraConfigData)
+	case v1.EPHEMERAL_STORAGE_REQUEST:
+		infraConfiguration = infraConfiguration.SetCiReqEphemeralStorage(storageInfraConfigData)
+	default:
+		errMsg := fmt.Sprintf("invalid key %q for ephemeral storage configuration", configurationBean.Key)
+		return infraConfiguration, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return infraConfiguration, nil
+}
+
+func (impl *ephemeralStorageClientImpl) getValueFromBean(configurationBean *v1.ConfigurationBean) (float64, error) {
+	if configurationBean == nil || configurationBean.Value == nil {
+		return 0, nil
+	}
+	valueString, err := impl.formatTypedValueAsString(configurationBean.Value)
+	if err != nil {
+		return 0, err
+	}
+	storageData, _, err := impl.getValueFromString(valueString)
+	if err != nil {
+		impl.logger.Errorw("error in getting ephemeral storage data", "error", err, "configuration", configurationBean)
+		return 0, err
+	}
+	return storageData, nil
+}
+
+func (impl *ephemeralStorageClientImpl) formatTypedValueAsString(configValue any) (string, error) {
+	valueFloat, ok := configValue.(float64)
+	if !ok || valueFloat < 0 {
+		errMsg := fmt.Sprintf("invalid value for ephemeral storage configuration: %v", configValue)
+		return "", util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	truncateValue := globalUtil.TruncateFloat(valueFloat, 2)
+	return strconv.FormatFloat(truncateValue, 'f', -1, 64), nil
+}
+
+func (impl *ephemeralStorageClientImpl) getValueCount(value float64) int {
+	if reflect.ValueOf(value).IsZero() {
+		return 0
+	}
+	return 1
+}
+
+func (impl *ephemeralStorageClientImpl) handlePostCreateOperations(tx *pg.Tx, createdInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *ephemeralStorageClientImpl) handlePostUpdateOperations(tx *pg.Tx, updatedInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *ephemeralStorageClientImpl) handlePostDeleteOperations(tx *pg.Tx, deletedInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *ephemeralStorageClientImpl) handleInfraConfigTriggerAudit(workflowId int, triggeredBy int32, infraConfig *v1.InfraConfig) error {
+	return nil
+}
+
+func (impl *ephemeralStorageClientImpl) resolveScopeVariablesForAppliedConfiguration(scope resourceQualifiers.Scope, configuration *v1.ConfigurationBean) (*v1.ConfigurationBean, map[string]string, error) {
+	return configuration, nil, nil
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/adapter"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/repository"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/units"
+	unitsBean "github.com/devtron-labs/devtron/pkg/infraConfig/units/bean"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"maps"
+	"net/http"
+	"strings"
+)
+
+// podMetadataClientImpl handles the labels and annotations added to the ci pod
+type podMetadataClientImpl struct {
+	logger        *zap.SugaredLogger
+	noUnitFactory *units.NoUnitFactory[map[string]string]
+}
+
+func newPodMetadataClientImpl(logger *zap.SugaredLogger) *podMetadataClientImpl {
+	return &podMetadataClientImpl{
+		logger:        logger,
+		noUnitFactory: units.NewNoUnitFactory[map[string]string](logger),
+	}
+}
+
+// getAppliedConfiguration:
+//   - If the configuration is found in the profileBean as well as in the global profile,
+//     then both are merged (the profile entries take precedence) and the configuration is PARTIALLY_INHERITING.
+//   - Otherwise, the configuration is inherited like any other configuration.
+func (impl *podMetadataClientImpl) getAppliedConfiguration(key v1.ConfigKeyStr, profileConfigBean *v1.ConfigurationBean, defaultConfigurations []*v1.ConfigurationBean) (*v1.ConfigurationBean, error) {
+	profileData, err := impl.getValueFromBean(profileConfigBean)
+	if err != nil {
+		impl.logger.Errorw("error in getting pod metadata config data", "error", err, "key", key, "podMetadataConfig", profileConfigBean)
+		return profileConfigBean, err
+	}
+	var defaultConfigBean *v1.ConfigurationBean
+	for _, defaultConfiguration := range defaultConfigurations {
+		if defaultConfiguration.Key == key {
+			defaultConfigBean = defaultConfiguration
+			break
+		}
+	}
+	defaultData, err := impl.getValueFromBean(defaultConfigBean)
+	if err != nil {
+		impl.logger.Errorw("error in getting pod metadata config data", "error", err, "key", key, "podMetadataConfig", defaultConfigBean)
+		return profileConfigBean, err
+	}
+	if len(profileData) != 0 && len(defaultData) != 0 && profileConfigBean.Id != defaultConfigBean.Id {
+		mergedData := maps.Clone(defaultData)
+		maps.Copy(mergedData, profileData)
+		return getAppliedConfigurationBean(profileConfigBean, mergedData,
+			v1.PARTIALLY_INHERITING, []int{profileConfigBean.Id, defaultConfigBean.Id}), nil
+	}
+	defaultConfigBeanAbstract := v1.ConfigurationBeanAbstract{
+		Key:  key,
+		Unit: impl.noUnitFactory.GetDefaultUnitSuffix(),
+	}
+	return getInheritedConfigurations(defaultConfigBeanAbstract, profileData, defaultData, profileConfigBean, defaultConfigBean)
+}
+
+func (impl *podMetadataClientImpl) validate(platformConfigurations, defaultConfigurations []*v1.ConfigurationBean) error {
+	for _, configuration := range platformConfigurations {
+		if configuration.Key != v1.POD_LABELS && configuration.Key != v1.POD_ANNOTATIONS {
+			continue
+		}
+		value, err := impl.getValueFromBean(configuration)
+		if err != nil {
+			return err
+		}
+		if _, err = impl.noUnitFactory.Validate(adapter.GetGenericConfigurationBean(configuration, value)); err != nil {
+			impl.logger.Errorw("error in validating pod metadata configuration unit", "error", err, "configuration", configuration)
+			return err
+		}
+		for metadataKey, metadataValue := range value {
+			errs := validation.IsQualifiedName(metadataKey)
+			if configuration.Key == v1.POD_LABELS {
+				errs = append(errs, validation.IsValidLabelValue(metadataValue)...)
+			}
+			if len(errs) > 0 {
+				errMsg := fmt.Sprintf("invalid entry %q: %q for %s: %s", metadataKey, metadataValue, configuration.Key, strings.Join(errs, ", "))
+				return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+			}
+		}
+	}
+	return nil
+}
+
+func (impl *podMetadataClientImpl) getConfigKeys() []v1.ConfigKeyStr {
+	return []v1.ConfigKeyStr{v1.POD_LABELS, v1.POD_ANNOTATIONS}
+}
+
+func (impl *podMetadataClientImpl) getSupportedUnits() map[v1.ConfigKeyStr]map[string]v1.Unit {
+	supportedUnitsMap := make(map[v1.ConfigKeyStr]map[string]v1.Unit)
+	supportedUnits := impl.noUnitFactory.GetAllUnits()
+	for _, configKey := range impl.getConfigKeys() {
+		supportedUnitsMap[configKey] = supportedUnits
+	}
+	return supportedUnitsMap
+}
+
+// getInfraConfigEntities returns no entities, the global profile does not add any pod labels or annotations by default
+func (impl *podMetadataClientImpl) getInfraConfigEntities(infraConfig *v1.InfraConfig, profileId int, platformName string) ([]*repository.InfraProfileConfigurationEntity, error) {
+	return make([]*repository.InfraProfileConfigurationEntity, 0), nil
+}
+
+func (impl *podMetadataClientImpl) getValueFromString(valueString string) (map[string]string, int, error) {
+	value, err := impl.noUnitFactory.GetValue(valueString)
+	if err != nil {
+		impl.logger.Errorw("error in parsing pod metadata configuration value", "error", err, "valueString", valueString)
+		return nil, 0, err
+	}
+	if len(value) == 0 {
+		return nil, 0, nil
+	}
+	return value, impl.getValueCount(value), nil
+}
+
+func (impl *podMetadataClientImpl) getValueFromBean(configurationBean *v1.ConfigurationBean) (map[string]string, error) {
+	if configurationBean == nil || configurationBean.Value == nil {
+		return nil, nil
+	}
+	value, err := impl.getTypedValue(configurationBean.Value)
+	if err != nil {
+		impl.logger.Errorw("error in getting pod metadata data", "error", err, "configuration", configurationBean)
+		return nil, err
+	}
+	return value, nil
+}
+
+// getTypedValue converts the value received in the request (decoded as map[string]interface{})
+// or read from the database (map[string]string) to map[string]string.
+// An empty map is returned as nil, so that it is treated as a missing configuration.
+func (impl *podMetadataClientImpl) getTypedValue(configValue any) (map[string]string, error) {
+	var value map[string]string
+	switch v := configValue.(type) {
+	case map[string]string:
+		value = maps.Clone(v)
+	case map[string]interface{}:
+		value = make(map[string]string, len(v))
+		for key, val := range v {
+			valString, ok := val.(string)
+			if !ok {
+				errMsg := fmt.Sprintf("invalid value for %q in pod metadata configuration: %v", key, val)
+				return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+			}
+			value[key] = valString
+		}
+	default:
+		errMsg := fmt.Sprintf("invalid value for pod metadata configuration: %v", configValue)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+	return value, nil
+}
+
+func (impl *podMetadataClientImpl) formatTypedValueAsString(configValue any) (string, error) {
+	value, err := impl.getTypedValue(configValue)
+	if err != nil {
+		return "", err
+	}
+	parsedValue, err := impl.noUnitFactory.ParseValAndUnit(value, unitsBean.NoUnit.GetUnitSuffix())
+	if err != nil {
+		return "", err
+	}
+	return parsedValue.GetValueString(), nil
+}
+
+func (impl *podMetadataClientImpl) overrideInfraConfig(infraConfiguration *v1.InfraConfig, configurationBean *v1.ConfigurationBean) (*v1.InfraConfig, error) {
+	value, err := impl.getValueFromBean(configurationBean)
+	if err != nil {
+		return infraConfiguration, err
+	}
+	switch configurationBean.Key {
+	case v1.POD_LABELS:
+		infraConfiguration = infraConfiguration.SetCiPodLabels(value)
+	case v1.POD_ANNOTATIONS:
+		infraConfiguration = infraConfiguration.SetCiPodAnnotations(value)
+	default:
+		errMsg := fmt.Sprintf("invalid key %q for pod metadata configuration", configurationBean.Key)
+		return infraConfiguration, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return infraConfiguration, nil
+}
+
+func (impl *podMetadataClientImpl) getValueCount(value map[string]string) int {
+	return len(value)
+}
+
+func (impl *podMetadataClientImpl) handlePostCreateOperations(tx *pg.Tx, createdInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *podMetadataClientImpl) handlePostUpdateOperations(tx *pg.Tx, updatedInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *podMetadataClientImpl) handlePostDeleteOperations(tx *pg.Tx, deletedInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *podMetadataClientImpl) handleInfraConfigTriggerAudit(workflowId int, triggeredBy int32, infraConfig *v1.InfraConfig) error {
+	return nil
+}
+
+func (impl *podMetadataClientImpl) resolveScopeVariablesForAppliedConfiguration(scope resourceQualifiers.Scope, configuration *v1.ConfigurationBean) (*v1.ConfigurationBean, map[string]string, error) {
+	return configuration, nil, nil
+}
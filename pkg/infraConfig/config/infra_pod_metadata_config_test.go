@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestPodMetadataGetAppliedConfiguration(t *testing.T) {
+	impl := newPodMetadataClientImpl(zap.NewNop().Sugar())
+	newConfigBean := func(id int, value any) *v1.ConfigurationBean {
+		return &v1.ConfigurationBean{
+			ConfigurationBeanAbstract: v1.ConfigurationBeanAbstract{Id: id, Key: v1.POD_LABELS, Active: true},
+			Value:                     value,
+		}
+	}
+	defaultConfigurations := []*v1.ConfigurationBean{newConfigBean(1, map[string]string{"team": "platform", "tier": "ci"})}
+
+	t.Run("profile and global profile labels are merged", func(t *testing.T) {
+		profileConfig := newConfigBean(2, map[string]interface{}{"tier": "build"})
+		appliedConfig, err := impl.getAppliedConfiguration(v1.POD_LABELS, profileConfig, defaultConfigurations)
+		assert.NoError(t, err)
+		assert.Equal(t, v1.PARTIALLY_INHERITING, appliedConfig.ConfigState)
+		assert.Equal(t, map[string]string{"team": "platform", "tier": "build"}, appliedConfig.Value)
+		assert.ElementsMatch(t, []int{1, 2}, appliedConfig.AppliedConfigIds)
+	})
+
+	t.Run("global profile labels are inherited", func(t *testing.T) {
+		appliedConfig, err := impl.getAppliedConfiguration(v1.POD_LABELS, nil, defaultConfigurations)
+		assert.NoError(t, err)
+		assert.Equal(t, v1.INHERITING_GLOBAL_PROFILE, appliedConfig.ConfigState)
+		assert.Equal(t, map[string]string{"team": "platform", "tier": "ci"}, appliedConfig.Value)
+	})
+
+	t.Run("profile labels override when global profile has none", func(t *testing.T) {
+		profileConfig := newConfigBean(2, map[string]interface{}{"tier": "build"})
+		appliedConfig, err := impl.getAppliedConfiguration(v1.POD_LABELS, profileConfig, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, v1.OVERRIDDEN, appliedConfig.ConfigState)
+		assert.Equal(t, map[string]string{"tier": "build"}, appliedConfig.Value)
+	})
+
+	t.Run("invalid label value is rejected", func(t *testing.T) {
+		profileConfig := newConfigBean(2, map[string]interface{}{"tier": "not a valid label"})
+		err := impl.validate([]*v1.ConfigurationBean{profileConfig}, defaultConfigurations)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/adapter"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/repository"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/units"
+	unitsBean "github.com/devtron-labs/devtron/pkg/infraConfig/units/bean"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"net/http"
+	"strings"
+)
+
+// podSpecClientImpl handles the string valued pod spec configurations of the ci pod,
+// i.e. the service account and the priority class
+type podSpecClientImpl struct {
+	logger        *zap.SugaredLogger
+	noUnitFactory *units.NoUnitFactory[string]
+}
+
+func newPodSpecClientImpl(logger *zap.SugaredLogger) *podSpecClientImpl {
+	return &podSpecClientImpl{
+		logger:        logger,
+		noUnitFactory: units.NewNoUnitFactory[string](logger),
+	}
+}
+
+// getAppliedConfiguration:
+//   - If the configuration is not found in profileBean, then the global profile configuration is inherited.
+//   - If the configuration is not found in the global profile as well, then nothing is applied
+//     and the workflow falls back to the node constraints.
+func (impl *podSpecClientImpl) getAppliedConfiguration(key v1.ConfigKeyStr, profileConfigBean *v1.ConfigurationBean, defaultConfigurations []*v1.ConfigurationBean) (*v1.ConfigurationBean, error) {
+	profileData, err := impl.getValueFromBean(profileConfigBean)
+	if err != nil {
+		impl.logger.Errorw("error in getting pod spec config data", "error", err, "key", key, "podSpecConfig", profileConfigBean)
+		return profileConfigBean, err
+	}
+	var defaultConfigBean *v1.ConfigurationBean
+	for _, defaultConfiguration := range defaultConfigurations {
+		if defaultConfiguration.Key == key {
+			defaultConfigBean = defaultConfiguration
+			break
+		}
+	}
+	defaultData, err := impl.getValueFromBean(defaultConfigBean)
+	if err != nil {
+		impl.logger.Errorw("error in getting pod spec config data", "error", err, "key", key, "podSpecConfig", defaultConfigBean)
+		return profileConfigBean, err
+	}
+	defaultConfigBeanAbstract := v1.ConfigurationBeanAbstract{
+		Key:  key,
+		Unit: impl.noUnitFactory.GetDefaultUnitSuffix(),
+	}
+	return getInheritedConfigurations(defaultConfigBeanAbstract, profileData, defaultData, profileConfigBean, defaultConfigBean)
+}
+
+func (impl *podSpecClientImpl) validate(platformConfigurations, defaultConfigurations []*v1.ConfigurationBean) error {
+	for _, configuration := range platformConfigurations {
+		if configuration.Key != v1.SERVICE_ACCOUNT && configuration.Key != v1.PRIORITY_CLASS {
+			continue
+		}
+		value, err := impl.getValueFromBean(configuration)
+		if err != nil {
+			return err
+		}
+		if _, err = impl.noUnitFactory.Validate(adapter.GetGenericConfigurationBean(configuration, value)); err != nil {
+			impl.logger.Errorw("error in validating pod spec configuration unit", "error", err, "configuration", configuration)
+			return err
+		}
+		if len(value) == 0 {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+			errMsg := fmt.Sprintf("invalid value %q for %s: %s", value, configuration.Key, strings.Join(errs, ", "))
+			return util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+		}
+	}
+	return nil
+}
+
+func (impl *podSpecClientImpl) getConfigKeys() []v1.ConfigKeyStr {
+	return []v1.ConfigKeyStr{v1.SERVICE_ACCOUNT, v1.PRIORITY_CLASS}
+}
+
+func (impl *podSpecClientImpl) getSupportedUnits() map[v1.ConfigKeyStr]map[string]v1.Unit {
+	supportedUnitsMap := make(map[v1.ConfigKeyStr]map[string]v1.Unit)
+	supportedUnits := impl.noUnitFactory.GetAllUnits()
+	for _, configKey := range impl.getConfigKeys() {
+		supportedUnitsMap[configKey] = supportedUnits
+	}
+	return supportedUnitsMap
+}
+
+// getInfraConfigEntities returns no entities, the global profile does not set a service account or a priority class by default
+func (impl *podSpecClientImpl) getInfraConfigEntities(infraConfig *v1.InfraConfig, profileId int, platformName string) ([]*repository.InfraProfileConfigurationEntity, error) {
+	return make([]*repository.InfraProfileConfigurationEntity, 0), nil
+}
+
+func (impl *podSpecClientImpl) getValueFromString(valueString string) (string, int, error) {
+	value, err := impl.noUnitFactory.GetValue(valueString)
+	if err != nil {
+		impl.logger.Errorw("error in parsing pod spec configuration value", "error", err, "valueString", valueString)
+		return "", 0, err
+	}
+	return value, impl.getValueCount(value), nil
+}
+
+func (impl *podSpecClientImpl) getValueFromBean(configurationBean *v1.ConfigurationBean) (string, error) {
+	if configurationBean == nil || configurationBean.Value == nil {
+		return "", nil
+	}
+	value, ok := configurationBean.Value.(string)
+	if !ok {
+		errMsg := fmt.Sprintf("invalid value for %s configuration: %v", configurationBean.Key, configurationBean.Value)
+		return "", util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+func (impl *podSpecClientImpl) formatTypedValueAsString(configValue any) (string, error) {
+	value, ok := configValue.(string)
+	if !ok {
+		errMsg := fmt.Sprintf("invalid value for pod spec configuration: %v", configValue)
+		return "", util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	parsedValue, err := impl.noUnitFactory.ParseValAndUnit(strings.TrimSpace(value), unitsBean.NoUnit.GetUnitSuffix())
+	if err != nil {
+		return "", err
+	}
+	return parsedValue.GetValueString(), nil
+}
+
+func (impl *podSpecClientImpl) overrideInfraConfig(infraConfiguration *v1.InfraConfig, configurationBean *v1.ConfigurationBean) (*v1.InfraConfig, error) {
+	value, err := impl.getValueFromBean(configurationBean)
+	if err != nil {
+		return infraConfiguration, err
+	}
+	switch configurationBean.Key {
+	case v1.SERVICE_ACCOUNT:
+		infraConfiguration = infraConfiguration.SetCiServiceAccount(value)
+	case v1.PRIORITY_CLASS:
+		infraConfiguration = infraConfiguration.SetCiPriorityClass(value)
+	default:
+		errMsg := fmt.Sprintf("invalid key %q for pod spec configuration", configurationBean.Key)
+		return infraConfiguration, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return infraConfiguration, nil
+}
+
+func (impl *podSpecClientImpl) getValueCount(value string) int {
+	if len(value) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func (impl *podSpecClientImpl) handlePostCreateOperations(tx *pg.Tx, createdInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *podSpecClientImpl) handlePostUpdateOperations(tx *pg.Tx, updatedInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *podSpecClientImpl) handlePostDeleteOperations(tx *pg.Tx, deletedInfraConfig *repository.InfraProfileConfigurationEntity) error {
+	return nil
+}
+
+func (impl *podSpecClientImpl) handleInfraConfigTriggerAudit(workflowId int, triggeredBy int32, infraConfig *v1.InfraConfig) error {
+	return nil
+}
+
+func (impl *podSpecClientImpl) resolveScopeVariablesForAppliedConfiguration(scope resourceQualifiers.Scope, configuration *v1.ConfigurationBean) (*v1.ConfigurationBean, map[string]string, error) {
+	return configuration, nil, nil
+}
@@ -26,6 +26,8 @@ const CPULimReqErrorCompErr = "cpu limit should not be less than cpu request"
 
 const MEMLimReqErrorCompErr = "memory limit should not be less than memory request"
 
+const EphemeralStorageLimReqErrorCompErr = "ephemeral storage limit should not be less than ephemeral storage request"
+
 var NoPropertiesFoundError = errors.New("no properties found")
 
 var ProfileIdsRequired = errors.New("profile ids cannot be empty")
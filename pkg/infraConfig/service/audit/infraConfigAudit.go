@@ -17,6 +17,7 @@
 package audit
 
 import (
+	"encoding/json"
 	audit2 "github.com/devtron-labs/devtron/pkg/infraConfig/adapter/audit"
 	infraBean "github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
 	"github.com/devtron-labs/devtron/pkg/infraConfig/repository/audit"
@@ -109,6 +110,25 @@ func (impl *InfraConfigAuditServiceImpl) fetchInfraConfigFromHistory(infraConfig
 				impl.logger.Errorw("failed to parse timeout value", "valueString", history.ValueString, "parseErr", parseErr)
 				return nil, parseErr
 			}
+		case infraBean.EphemeralStorageLimitKey:
+			infraConfig.CiLimitEphemeralStorage = history.ValueString
+		case infraBean.EphemeralStorageRequestKey:
+			infraConfig.CiReqEphemeralStorage = history.ValueString
+		case infraBean.ServiceAccountKey:
+			infraConfig.CiServiceAccount = history.ValueString
+		case infraBean.PriorityClassKey:
+			infraConfig.CiPriorityClass = history.ValueString
+		case infraBean.PodLabelsKey, infraBean.PodAnnotationsKey:
+			podMetadata := make(map[string]string)
+			if err := json.Unmarshal([]byte(history.ValueString), &podMetadata); err != nil {
+				impl.logger.Errorw("failed to parse pod metadata value", "key", history.Key, "valueString", history.ValueString, "err", err)
+				return nil, err
+			}
+			if history.Key == infraBean.PodLabelsKey {
+				infraConfig.CiPodLabels = podMetadata
+			} else {
+				infraConfig.CiPodAnnotations = podMetadata
+			}
 		}
 	}
 
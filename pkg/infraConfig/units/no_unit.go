@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package units
+
+import (
+	"encoding/json"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/bean/v1"
+	"github.com/devtron-labs/devtron/pkg/infraConfig/errors"
+	unitsBean "github.com/devtron-labs/devtron/pkg/infraConfig/units/bean"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NoUnitFactory handles the configurations which do not have a unit, e.g. service account or pod labels.
+// The values are stored as json in the value string.
+type NoUnitFactory[T any] struct {
+	logger  *zap.SugaredLogger
+	noUnits map[unitsBean.NoUnitStr]v1.Unit
+}
+
+func NewNoUnitFactory[T any](logger *zap.SugaredLogger) *NoUnitFactory[T] {
+	return &NoUnitFactory[T]{
+		logger:  logger,
+		noUnits: unitsBean.GetNoUnit(),
+	}
+}
+
+func (n *NoUnitFactory[T]) GetAllUnits() map[string]v1.Unit {
+	units := make(map[string]v1.Unit)
+	for key, value := range n.noUnits {
+		units[string(key)] = value
+	}
+	return units
+}
+
+func (n *NoUnitFactory[T]) GetDefaultUnitSuffix() string {
+	return unitsBean.NoUnit.String()
+}
+
+func (n *NoUnitFactory[T]) ParseValAndUnit(val T, unitType unitsBean.UnitType) (*unitsBean.ParsedValue, error) {
+	valueString, err := parseJsonValueToString(val)
+	if err != nil {
+		return nil, err
+	}
+	return unitsBean.NewParsedValue().
+		WithValueString(valueString).
+		WithUnit(unitType), nil
+}
+
+func (n *NoUnitFactory[T]) GetValue(valueString string) (T, error) {
+	var value T
+	err := json.Unmarshal([]byte(valueString), &value)
+	return value, err
+}
+
+func (n *NoUnitFactory[T]) Validate(config *v1.GenericConfigurationBean[T]) (*unitsBean.ConfigValue[T], error) {
+	noUnit, ok := unitsBean.NoUnitStr(config.Unit).GetUnit()
+	if !ok {
+		errMsg := errors.InvalidUnitFound(config.Unit, config.Key)
+		return nil, util.NewApiError(http.StatusBadRequest, errMsg, errMsg)
+	}
+	return unitsBean.NewConfigValue(noUnit, config.Value), nil
+}
@@ -44,11 +44,12 @@ func GetUnitSuffix(unitKey v1.ConfigKeyStr, unitStr string) unitsBean.UnitType {
 	switch unitKey {
 	case v1.CPU_LIMIT, v1.CPU_REQUEST:
 		return unitsBean.CPUUnitStr(unitStr).GetUnitSuffix()
-	case v1.MEMORY_LIMIT, v1.MEMORY_REQUEST:
+	case v1.MEMORY_LIMIT, v1.MEMORY_REQUEST, v1.EPHEMERAL_STORAGE_LIMIT, v1.EPHEMERAL_STORAGE_REQUEST:
 		return unitsBean.MemoryUnitStr(unitStr).GetUnitSuffix()
 	case v1.TIME_OUT:
 		return unitsBean.TimeUnitStr(unitStr).GetUnitSuffix()
-	case v1.TOLERATIONS, v1.NODE_SELECTOR, v1.SECRET, v1.CONFIG_MAP:
+	case v1.TOLERATIONS, v1.NODE_SELECTOR, v1.SECRET, v1.CONFIG_MAP,
+		v1.SERVICE_ACCOUNT, v1.POD_LABELS, v1.POD_ANNOTATIONS, v1.PRIORITY_CLASS:
 		return unitsBean.NoUnitStr(unitStr).GetUnitSuffix()
 	default:
 		return unitsBean.NoUnitStr(unitStr).GetUnitSuffix()
@@ -60,11 +61,12 @@ func GetUnitSuffixStr(unitKey v1.ConfigKey, unit unitsBean.UnitType) string {
 	switch unitKey {
 	case v1.CPULimitKey, v1.CPURequestKey:
 		return unit.GetCPUUnitStr().String()
-	case v1.MemoryLimitKey, v1.MemoryRequestKey:
+	case v1.MemoryLimitKey, v1.MemoryRequestKey, v1.EphemeralStorageLimitKey, v1.EphemeralStorageRequestKey:
 		return unit.GetMemoryUnitStr().String()
 	case v1.TimeOutKey:
 		return unit.GetTimeUnitStr().String()
-	case v1.TolerationsKey, v1.NodeSelectorKey, v1.SecretKey, v1.ConfigMapKey:
+	case v1.TolerationsKey, v1.NodeSelectorKey, v1.SecretKey, v1.ConfigMapKey,
+		v1.ServiceAccountKey, v1.PodLabelsKey, v1.PodAnnotationsKey, v1.PriorityClassKey:
 		return unit.GetNoUnitStr().String()
 	}
 	return unit.GetNoUnitStr().String()
@@ -95,13 +97,25 @@ func GetConfigKeysMapForPlatform(platform string) v1.InfraConfigKeys {
 	}
 	if platform == v1.RUNNER_PLATFORM {
 		defaultConfigKeys[v1.TIME_OUT] = true
+		defaultConfigKeys[v1.EPHEMERAL_STORAGE_LIMIT] = true
+		defaultConfigKeys[v1.EPHEMERAL_STORAGE_REQUEST] = true
+		defaultConfigKeys[v1.SERVICE_ACCOUNT] = true
+		defaultConfigKeys[v1.POD_LABELS] = true
+		defaultConfigKeys[v1.POD_ANNOTATIONS] = true
+		defaultConfigKeys[v1.PRIORITY_CLASS] = true
 	}
 	return getConfigKeysMapForPlatformEnt(defaultConfigKeys, platform)
 }
 
 func GetMandatoryConfigKeys(profileName, platformName string) []v1.ConfigKeyStr {
 	if profileName == v1.GLOBAL_PROFILE_NAME {
-		return GetConfigKeysMapForPlatform(platformName).GetAllSupportedKeys()
+		mandatoryKeys := make([]v1.ConfigKeyStr, 0)
+		for _, key := range GetConfigKeysMapForPlatform(platformName).GetAllSupportedKeys() {
+			if !slices.Contains(v1.OptionalConfigKeys, key) {
+				mandatoryKeys = append(mandatoryKeys, key)
+			}
+		}
+		return mandatoryKeys
 	}
 	return make([]v1.ConfigKeyStr, 0)
 }
@@ -133,6 +147,18 @@ func GetConfigKeyStr(configKey v1.ConfigKey) v1.ConfigKeyStr {
 		return v1.MEMORY_REQUEST
 	case v1.TimeOutKey:
 		return v1.TIME_OUT
+	case v1.EphemeralStorageLimitKey:
+		return v1.EPHEMERAL_STORAGE_LIMIT
+	case v1.EphemeralStorageRequestKey:
+		return v1.EPHEMERAL_STORAGE_REQUEST
+	case v1.ServiceAccountKey:
+		return v1.SERVICE_ACCOUNT
+	case v1.PodLabelsKey:
+		return v1.POD_LABELS
+	case v1.PodAnnotationsKey:
+		return v1.POD_ANNOTATIONS
+	case v1.PriorityClassKey:
+		return v1.PRIORITY_CLASS
 	}
 	return getEntConfigKeyStr(configKey)
 }
@@ -149,6 +175,18 @@ func GetConfigKey(configKeyStr v1.ConfigKeyStr) v1.ConfigKey {
 		return v1.MemoryRequestKey
 	case v1.TIME_OUT:
 		return v1.TimeOutKey
+	case v1.EPHEMERAL_STORAGE_LIMIT:
+		return v1.EphemeralStorageLimitKey
+	case v1.EPHEMERAL_STORAGE_REQUEST:
+		return v1.EphemeralStorageRequestKey
+	case v1.SERVICE_ACCOUNT:
+		return v1.ServiceAccountKey
+	case v1.POD_LABELS:
+		return v1.PodLabelsKey
+	case v1.POD_ANNOTATIONS:
+		return v1.PodAnnotationsKey
+	case v1.PRIORITY_CLASS:
+		return v1.PriorityClassKey
 	}
 	return getEntConfigKey(configKeyStr)
 }
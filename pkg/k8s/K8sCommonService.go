@@ -18,6 +18,7 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/caarlos0/env"
 	"github.com/devtron-labs/common-lib/async"
@@ -32,11 +33,14 @@ import (
 	"github.com/devtron-labs/devtron/pkg/cluster/read"
 	bean3 "github.com/devtron-labs/devtron/pkg/k8s/application/bean"
 	bean5 "github.com/devtron-labs/devtron/pkg/k8s/bean"
+	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
+	auditBean "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
 	"github.com/devtron-labs/devtron/util"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 	apiV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
@@ -77,6 +81,7 @@ type K8sCommonServiceImpl struct {
 	argoApplicationConfigService config.ArgoApplicationConfigService
 	ClusterReadService           read.ClusterReadService
 	asyncRunnable                *async.Runnable
+	k8sResourceHistoryService    kubernetesResourceAuditLogs.K8sResourceHistoryService
 }
 type K8sApplicationServiceConfig struct {
 	BatchSize        int `env:"BATCH_SIZE" envDefault:"5" description:"there is feature to get URL's of services/ingresses. so to extract those, we need to parse all the servcie and ingress objects of the application. this BATCH_SIZE flag controls the no of these objects get parsed in one go."`
@@ -85,7 +90,8 @@ type K8sApplicationServiceConfig struct {
 
 func NewK8sCommonServiceImpl(Logger *zap.SugaredLogger, k8sUtils *k8s.K8sServiceImpl,
 	argoApplicationConfigService config.ArgoApplicationConfigService,
-	ClusterReadService read.ClusterReadService, asyncRunnable *async.Runnable) *K8sCommonServiceImpl {
+	ClusterReadService read.ClusterReadService, asyncRunnable *async.Runnable,
+	k8sResourceHistoryService kubernetesResourceAuditLogs.K8sResourceHistoryService) *K8sCommonServiceImpl {
 	cfg := &K8sApplicationServiceConfig{}
 	err := env.Parse(cfg)
 	if err != nil {
@@ -98,6 +104,7 @@ func NewK8sCommonServiceImpl(Logger *zap.SugaredLogger, k8sUtils *k8s.K8sService
 		argoApplicationConfigService: argoApplicationConfigService,
 		ClusterReadService:           ClusterReadService,
 		asyncRunnable:                asyncRunnable,
+		k8sResourceHistoryService:    k8sResourceHistoryService,
 	}
 }
 
@@ -181,6 +188,10 @@ func (impl *K8sCommonServiceImpl) UpdateResource(ctx context.Context, request *b
 		impl.logger.Errorw("error in getting rest config", "err", err, "clusterId", clusterId, "externalArgoApplicationName", request.ExternalArgoApplicationName)
 		return nil, err
 	}
+	var manifestBefore *unstructured.Unstructured
+	if request.UserId > 0 {
+		manifestBefore = impl.getManifestBeforeUpdate(ctx, restConfig, request)
+	}
 	resp, err := impl.K8sUtil.UpdateResource(ctx, restConfig, resourceIdentifier.GroupVersionKind, resourceIdentifier.Namespace, request.K8sRequest.Patch)
 	if err != nil {
 		impl.logger.Errorw("error in updating resource", "err", err, "clusterId", clusterId)
@@ -190,8 +201,47 @@ func (impl *K8sCommonServiceImpl) UpdateResource(ctx context.Context, request *b
 		}
 		return nil, err
 	}
+	if request.UserId > 0 {
+		resourceIdentifier.Name = resp.Manifest.GetName()
+		impl.saveResourceChangeAudit(request.ClusterId, resourceIdentifier, auditBean.ActionUpdate, false, manifestBefore, &resp.Manifest, request.UserId)
+	}
 	return resp, nil
 }
+
+// getManifestBeforeUpdate returns the current manifest of the resource being updated for the audit, name of the resource
+// is taken from the update manifest as it is not always set in the request
+func (impl *K8sCommonServiceImpl) getManifestBeforeUpdate(ctx context.Context, restConfig *rest.Config, request *bean5.ResourceRequestBean) *unstructured.Unstructured {
+	resourceIdentifier := request.K8sRequest.ResourceIdentifier
+	updateManifest := &unstructured.Unstructured{}
+	err := json.Unmarshal([]byte(request.K8sRequest.Patch), &updateManifest.Object)
+	if err != nil {
+		// invalid manifest will fail the update as well
+		return nil
+	}
+	resp, err := impl.K8sUtil.GetResource(ctx, resourceIdentifier.Namespace, updateManifest.GetName(), resourceIdentifier.GroupVersionKind, restConfig)
+	if err != nil {
+		impl.logger.Errorw("error in getting resource manifest before update for audit", "clusterId", request.ClusterId, "name", updateManifest.GetName(), "err", err)
+		return nil
+	}
+	return &resp.Manifest
+}
+
+// saveResourceChangeAudit records the action in the resource change audit, error in saving the audit does not fail the action
+func (impl *K8sCommonServiceImpl) saveResourceChangeAudit(clusterId int, resourceIdentifier k8s.ResourceIdentifier, action string, forceDelete bool,
+	manifestBefore, manifestAfter *unstructured.Unstructured, userId int32) {
+	err := impl.k8sResourceHistoryService.SaveResourceChangeAudit(&auditBean.ResourceChangeAuditRequest{
+		ClusterId:          clusterId,
+		ResourceIdentifier: resourceIdentifier,
+		Action:             action,
+		ForceDelete:        forceDelete,
+		ManifestBefore:     manifestBefore,
+		ManifestAfter:      manifestAfter,
+		UserId:             userId,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in saving resource change audit", "clusterId", clusterId, "resourceIdentifier", resourceIdentifier, "action", action, "err", err)
+	}
+}
 func (impl *K8sCommonServiceImpl) GetRestConfigOfCluster(ctx context.Context, request *bean5.ResourceRequestBean) (*rest.Config, error) {
 	//getting rest config by clusterId
 	clusterId := request.ClusterId
@@ -226,6 +276,9 @@ func (impl *K8sCommonServiceImpl) DeleteResource(ctx context.Context, request *b
 		impl.logger.Errorw("error in deleting resource", "err", err, "clusterId", clusterId)
 		return nil, err
 	}
+	if request.UserId > 0 {
+		impl.saveResourceChangeAudit(request.ClusterId, resourceIdentifier, auditBean.ActionDelete, request.K8sRequest.ForceDelete, &resp.Manifest, nil, request.UserId)
+	}
 	return resp, nil
 }
 
@@ -53,6 +53,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/k8s"
 	bean3 "github.com/devtron-labs/devtron/pkg/k8s/application/bean"
 	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
+	auditBean "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
 	"github.com/devtron-labs/devtron/pkg/terminal"
 	util3 "github.com/devtron-labs/devtron/pkg/util"
 	util2 "github.com/devtron-labs/devtron/util"
@@ -85,7 +86,7 @@ type K8sApplicationService interface {
 	GetResourceList(ctx context.Context, token string, request *bean4.ResourceRequestBean, validateResourceAccess func(token string, clusterName string, request bean4.ResourceRequestBean, casbinAction string) bool) (*k8s2.ClusterResourceListMap, error)
 	GetResourceListWithRestConfig(ctx context.Context, token string, request *bean4.ResourceRequestBean, validateResourceAccess func(token string, clusterName string, request bean4.ResourceRequestBean, casbinAction string) bool,
 		restConfig *rest.Config, clusterName string) (*k8s2.ClusterResourceListMap, error)
-	ApplyResources(ctx context.Context, token string, request *k8s2.ApplyResourcesRequest, resourceRbacHandler func(token string, clusterName string, request bean4.ResourceRequestBean, casbinAction string) bool) ([]*k8s2.ApplyResourcesResponse, error)
+	ApplyResources(ctx context.Context, token string, request *k8s2.ApplyResourcesRequest, userId int32, resourceRbacHandler func(token string, clusterName string, request bean4.ResourceRequestBean, casbinAction string) bool) ([]*k8s2.ApplyResourcesResponse, error)
 	CreatePodEphemeralContainers(req *bean5.EphemeralContainerRequest) error
 	TerminatePodEphemeralContainer(req bean5.EphemeralContainerRequest) (bool, error)
 	GetPodContainersList(clusterId int, namespace, podName string) (*bean4.PodContainerList, error)
@@ -775,7 +776,7 @@ func (impl *K8sApplicationServiceImpl) GetResourceListWithRestConfig(ctx context
 	return resourceList, nil
 }
 
-func (impl *K8sApplicationServiceImpl) ApplyResources(ctx context.Context, token string, request *k8s2.ApplyResourcesRequest, validateResourceAccess func(token string, clusterName string, request bean4.ResourceRequestBean, casbinAction string) bool) ([]*k8s2.ApplyResourcesResponse, error) {
+func (impl *K8sApplicationServiceImpl) ApplyResources(ctx context.Context, token string, request *k8s2.ApplyResourcesRequest, userId int32, validateResourceAccess func(token string, clusterName string, request bean4.ResourceRequestBean, casbinAction string) bool) ([]*k8s2.ApplyResourcesResponse, error) {
 	manifests, err := yamlUtil.SplitYAMLs([]byte(request.Manifest))
 	if err != nil {
 		impl.logger.Errorw("error in splitting yaml in manifest", "err", err)
@@ -815,7 +816,7 @@ func (impl *K8sApplicationServiceImpl) ApplyResources(ctx context.Context, token
 		}
 		actionAllowed := validateResourceAccess(token, clusterBean.ClusterName, resourceRequestBean, casbin.ActionUpdate)
 		if actionAllowed {
-			resourceExists, err := impl.applyResourceFromManifest(ctx, manifest, restConfig, namespace, clusterId)
+			resourceExists, err := impl.applyResourceFromManifest(ctx, manifest, restConfig, namespace, clusterId, userId)
 			manifestRes.IsUpdate = resourceExists
 			if err != nil {
 				manifestRes.Error = err.Error()
@@ -829,7 +830,7 @@ func (impl *K8sApplicationServiceImpl) ApplyResources(ctx context.Context, token
 	return response, nil
 }
 
-func (impl *K8sApplicationServiceImpl) applyResourceFromManifest(ctx context.Context, manifest unstructured.Unstructured, restConfig *rest.Config, namespace string, clusterId int) (bool, error) {
+func (impl *K8sApplicationServiceImpl) applyResourceFromManifest(ctx context.Context, manifest unstructured.Unstructured, restConfig *rest.Config, namespace string, clusterId int, userId int32) (bool, error) {
 	var isUpdateResource bool
 	k8sRequestBean := &k8s2.K8sRequestBean{
 		ResourceIdentifier: k8s2.ResourceIdentifier{
@@ -849,33 +850,54 @@ func (impl *K8sApplicationServiceImpl) applyResourceFromManifest(ctx context.Con
 		ClusterId:  clusterId,
 	}
 
-	_, err = impl.k8sCommonService.GetResource(ctx, request)
+	existingResource, err := impl.k8sCommonService.GetResource(ctx, request)
+	resourceIdentifier := k8sRequestBean.ResourceIdentifier
 	if err != nil {
 		statusError, ok := err.(*errors2.StatusError)
 		if !ok || statusError == nil || statusError.ErrStatus.Reason != metav1.StatusReasonNotFound {
 			impl.logger.Errorw("error in getting resource", "err", err)
 			return isUpdateResource, err
 		}
-		resourceIdentifier := k8sRequestBean.ResourceIdentifier
 		// case of resource not found
-		_, err = impl.K8sUtil.CreateResources(ctx, restConfig, jsonStr, resourceIdentifier.GroupVersionKind, resourceIdentifier.Namespace)
+		resp, err := impl.K8sUtil.CreateResources(ctx, restConfig, jsonStr, resourceIdentifier.GroupVersionKind, resourceIdentifier.Namespace)
 		if err != nil {
 			impl.logger.Errorw("error in creating resource", "err", err)
 			return isUpdateResource, err
 		}
+		impl.saveResourceChangeAudit(clusterId, resourceIdentifier, auditBean.ActionCreate, nil, &resp.Manifest, userId)
 	} else {
 		// case of resource update
 		isUpdateResource = true
-		resourceIdentifier := k8sRequestBean.ResourceIdentifier
-		_, err = impl.K8sUtil.PatchResourceRequest(ctx, restConfig, types.StrategicMergePatchType, jsonStr, resourceIdentifier.Name, resourceIdentifier.Namespace, resourceIdentifier.GroupVersionKind)
+		resp, err := impl.K8sUtil.PatchResourceRequest(ctx, restConfig, types.StrategicMergePatchType, jsonStr, resourceIdentifier.Name, resourceIdentifier.Namespace, resourceIdentifier.GroupVersionKind)
 		if err != nil {
 			impl.logger.Errorw("error in updating resource", "err", err)
 			return isUpdateResource, err
 		}
+		var manifestBefore *unstructured.Unstructured
+		if existingResource != nil && existingResource.ManifestResponse != nil {
+			manifestBefore = &existingResource.ManifestResponse.Manifest
+		}
+		impl.saveResourceChangeAudit(clusterId, resourceIdentifier, auditBean.ActionPatch, manifestBefore, &resp.Manifest, userId)
 	}
 
 	return isUpdateResource, nil
 }
+
+// saveResourceChangeAudit records the action in the resource change audit, error in saving the audit does not fail the action
+func (impl *K8sApplicationServiceImpl) saveResourceChangeAudit(clusterId int, resourceIdentifier k8s2.ResourceIdentifier, action string,
+	manifestBefore, manifestAfter *unstructured.Unstructured, userId int32) {
+	err := impl.K8sResourceHistoryService.SaveResourceChangeAudit(&auditBean.ResourceChangeAuditRequest{
+		ClusterId:          clusterId,
+		ResourceIdentifier: resourceIdentifier,
+		Action:             action,
+		ManifestBefore:     manifestBefore,
+		ManifestAfter:      manifestAfter,
+		UserId:             userId,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in saving resource change audit", "clusterId", clusterId, "resourceIdentifier", resourceIdentifier, "action", action, "err", err)
+	}
+}
 func (impl *K8sApplicationServiceImpl) CreatePodEphemeralContainers(req *bean5.EphemeralContainerRequest) error {
 	var clientSet *kubernetes.Clientset
 	var v1Client *v1.CoreV1Client
@@ -930,7 +952,7 @@ func (impl *K8sApplicationServiceImpl) CreatePodEphemeralContainers(req *bean5.E
 		return fmt.Errorf("error creating patch to add debug container: %v", err)
 	}
 
-	_, err = v1Client.Pods(req.Namespace).Patch(context.Background(), pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "ephemeralcontainers")
+	patchedPod, err := v1Client.Pods(req.Namespace).Patch(context.Background(), pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}, "ephemeralcontainers")
 	if err != nil {
 		if serr, ok := err.(*errors2.StatusError); ok && serr.Status().Reason == metav1.StatusReasonNotFound && serr.ErrStatus.Details.Name == "" {
 			impl.logger.Errorw("error occurred while creating ephemeral containers", "err", err, "reason", "ephemeral containers are disabled for this cluster")
@@ -978,6 +1000,8 @@ func (impl *K8sApplicationServiceImpl) CreatePodEphemeralContainers(req *bean5.E
 			impl.logger.Errorw("error in saving ephemeral container data", "err", err)
 			return err
 		}
+		impl.saveResourceChangeAudit(req.ClusterId, getPodResourceIdentifier(req.Namespace, req.PodName), auditBean.ActionEphemeralContainerCreate,
+			kubernetesResourceAuditLogs.ToUnstructured(pod), kubernetesResourceAuditLogs.ToUnstructured(patchedPod), req.UserId)
 		return nil
 	}
 
@@ -1124,6 +1148,8 @@ func (impl *K8sApplicationServiceImpl) TerminatePodEphemeralContainer(req bean5.
 			impl.logger.Errorw("error in saving ephemeral container data", "err", err)
 			return true, err
 		}
+		// the container is terminated by killing its process, pod manifest is not changed by the action
+		impl.saveResourceChangeAudit(req.ClusterId, getPodResourceIdentifier(req.Namespace, req.PodName), auditBean.ActionEphemeralContainerTerminate, nil, nil, req.UserId)
 
 	}
 
@@ -1222,10 +1248,12 @@ func (impl *K8sApplicationServiceImpl) RecreateResource(ctx context.Context, req
 		impl.logger.Errorw("error in creating resource", "err", err, "request", request)
 		return nil, err
 	}
+	impl.saveResourceChangeAudit(request.AppIdentifier.ClusterId, request.K8sRequest.ResourceIdentifier, auditBean.ActionRecreate, nil, &resp.Manifest, request.UserId)
 	return resp, nil
 }
 
 func (impl *K8sApplicationServiceImpl) DeleteResourceWithAudit(ctx context.Context, request *bean4.ResourceRequestBean, userId int32) (*k8s2.ManifestResponse, error) {
+	request.UserId = userId
 	resp, err := impl.k8sCommonService.DeleteResource(ctx, request)
 	if err != nil {
 		if k8s.IsResourceNotFoundErr(err) {
@@ -1344,3 +1372,11 @@ func (impl K8sApplicationServiceImpl) K8sServerVersionCheckForEphemeralContainer
 	}
 	return matched, nil
 }
+
+func getPodResourceIdentifier(namespace, podName string) k8s2.ResourceIdentifier {
+	return k8s2.ResourceIdentifier{
+		Name:             podName,
+		Namespace:        namespace,
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+	}
+}
@@ -35,6 +35,8 @@ type ResourceRequestBean struct {
 	ExternalArgoApplicationName string                     `json:"externalArgoApplicationName,omitempty"`
 	ExternalFluxAppIdentifier   *bean2.FluxAppIdentifier   `json:"-"`
 	ExternalArgoAppIdentifier   *bean3.ArgoAppIdentifier   `json:"-"`
+	// UserId is set for the update and delete actions performed by a user to record them in the resource change audit
+	UserId int32 `json:"-"`
 }
 
 func (r *ResourceRequestBean) IsValidAppType() bool {
@@ -195,6 +195,7 @@ type NodeUpdateRequestDto struct {
 	Taints           []corev1.Taint    `json:"taints"`
 	NodeCordonHelper *NodeCordonHelper `json:"nodeCordonOptions"`
 	NodeDrainHelper  *NodeDrainHelper  `json:"nodeDrainOptions" validate:"required"`
+	UserId           int32             `json:"-"`
 }
 
 type NodeCordonHelper struct {
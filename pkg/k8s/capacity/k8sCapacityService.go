@@ -28,6 +28,8 @@ import (
 	application2 "github.com/devtron-labs/devtron/pkg/k8s/application"
 	bean3 "github.com/devtron-labs/devtron/pkg/k8s/bean"
 	"github.com/devtron-labs/devtron/pkg/k8s/capacity/bean"
+	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs"
+	auditBean "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,21 +64,24 @@ type K8sCapacityService interface {
 }
 
 type K8sCapacityServiceImpl struct {
-	logger                *zap.SugaredLogger
-	k8sApplicationService application2.K8sApplicationService
-	K8sUtil               *k8s2.K8sServiceImpl
-	k8sCommonService      k8s.K8sCommonService
+	logger                    *zap.SugaredLogger
+	k8sApplicationService     application2.K8sApplicationService
+	K8sUtil                   *k8s2.K8sServiceImpl
+	k8sCommonService          k8s.K8sCommonService
+	k8sResourceHistoryService kubernetesResourceAuditLogs.K8sResourceHistoryService
 }
 
 func NewK8sCapacityServiceImpl(Logger *zap.SugaredLogger,
 	k8sApplicationService application2.K8sApplicationService,
 	K8sUtil *k8s2.K8sServiceImpl,
-	k8sCommonService k8s.K8sCommonService) *K8sCapacityServiceImpl {
+	k8sCommonService k8s.K8sCommonService,
+	k8sResourceHistoryService kubernetesResourceAuditLogs.K8sResourceHistoryService) *K8sCapacityServiceImpl {
 	return &K8sCapacityServiceImpl{
-		logger:                Logger,
-		k8sApplicationService: k8sApplicationService,
-		K8sUtil:               K8sUtil,
-		k8sCommonService:      k8sCommonService,
+		logger:                    Logger,
+		k8sApplicationService:     k8sApplicationService,
+		K8sUtil:                   K8sUtil,
+		k8sCommonService:          k8sCommonService,
+		k8sResourceHistoryService: k8sResourceHistoryService,
 	}
 }
 
@@ -570,7 +575,7 @@ func (impl *K8sCapacityServiceImpl) UpdateNodeManifest(ctx context.Context, requ
 		},
 		Patch: request.ManifestPatch,
 	}
-	requestResourceBean := &bean3.ResourceRequestBean{K8sRequest: manifestUpdateReq, ClusterId: request.ClusterId}
+	requestResourceBean := &bean3.ResourceRequestBean{K8sRequest: manifestUpdateReq, ClusterId: request.ClusterId, UserId: request.UserId}
 	manifestResponse, err := impl.k8sCommonService.UpdateResource(ctx, requestResourceBean)
 	if err != nil {
 		impl.logger.Errorw("error in updating node manifest", "err", err)
@@ -590,8 +595,7 @@ func (impl *K8sCapacityServiceImpl) DeleteNode(ctx context.Context, request *bea
 			},
 		},
 	}
-	resourceRequest := &bean3.ResourceRequestBean{K8sRequest: deleteReq, ClusterId: request.ClusterId}
-	// Here Sending userId as 0 as appIdentifier is being sent nil so user id is not used in method. Update userid if appIdentifier is used
+	resourceRequest := &bean3.ResourceRequestBean{K8sRequest: deleteReq, ClusterId: request.ClusterId, UserId: request.UserId}
 	manifestResponse, err := impl.k8sCommonService.DeleteResource(ctx, resourceRequest)
 	if err != nil {
 		if k8s.IsResourceNotFoundErr(err) {
@@ -622,6 +626,7 @@ func (impl *K8sCapacityServiceImpl) CordonOrUnCordonNode(ctx context.Context, re
 	if node.Spec.Unschedulable == request.NodeCordonHelper.UnschedulableDesired {
 		return respMessage, getErrorForCordonUpdateReq(request.NodeCordonHelper.UnschedulableDesired)
 	}
+	nodeBefore := node.DeepCopy()
 	//updating node with desired cordon value
 	node, err = k8s2.UpdateNodeUnschedulableProperty(request.NodeCordonHelper.UnschedulableDesired, node, k8sClientSet)
 	if err != nil {
@@ -631,8 +636,10 @@ func (impl *K8sCapacityServiceImpl) CordonOrUnCordonNode(ctx context.Context, re
 
 	if request.NodeCordonHelper.UnschedulableDesired {
 		respMessage = fmt.Sprintf("Node successfully Cordoned.")
+		impl.saveNodeChangeAudit(request, auditBean.ActionNodeCordon, nodeBefore, node)
 	} else {
 		respMessage = fmt.Sprintf("Node successfully UnCordoned.")
+		impl.saveNodeChangeAudit(request, auditBean.ActionNodeUnCordon, nodeBefore, node)
 	}
 	return respMessage, nil
 }
@@ -655,6 +662,7 @@ func (impl *K8sCapacityServiceImpl) DrainNode(ctx context.Context, request *bean
 		impl.logger.Errorw("error in getting node", "err", err)
 		return respMessage, err
 	}
+	nodeBefore := node.DeepCopy()
 	//checking if node is unschedulable or not, if not then need to unschedule before draining
 	if !node.Spec.Unschedulable {
 		node, err = k8s2.UpdateNodeUnschedulableProperty(true, node, k8sClientSet)
@@ -674,6 +682,7 @@ func (impl *K8sCapacityServiceImpl) DrainNode(ctx context.Context, request *bean
 		return respMessage, err
 	}
 	respMessage = "Node Drained Successfully."
+	impl.saveNodeChangeAudit(request, auditBean.ActionNodeDrain, nodeBefore, node)
 	return respMessage, nil
 }
 
@@ -695,6 +704,7 @@ func (impl *K8sCapacityServiceImpl) EditNodeTaints(ctx context.Context, request
 		impl.logger.Errorw("error in getting node", "err", err)
 		return respMessage, err
 	}
+	nodeBefore := node.DeepCopy()
 	node.Spec.Taints = request.Taints
 	node, err = k8sClientSet.CoreV1().Nodes().Update(context.Background(), node, v1.UpdateOptions{})
 	if err != nil {
@@ -702,9 +712,28 @@ func (impl *K8sCapacityServiceImpl) EditNodeTaints(ctx context.Context, request
 		return respMessage, err
 	}
 	respMessage = "Taints edited Successfully."
+	impl.saveNodeChangeAudit(request, auditBean.ActionNodeTaintEdit, nodeBefore, node)
 	return respMessage, nil
 }
 
+// saveNodeChangeAudit records the node action in the resource change audit, error in saving the audit does not fail the action
+func (impl *K8sCapacityServiceImpl) saveNodeChangeAudit(request *bean.NodeUpdateRequestDto, action string, nodeBefore, nodeAfter *corev1.Node) {
+	err := impl.k8sResourceHistoryService.SaveResourceChangeAudit(&auditBean.ResourceChangeAuditRequest{
+		ClusterId: request.ClusterId,
+		ResourceIdentifier: k8s2.ResourceIdentifier{
+			Name:             request.Name,
+			GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Node"},
+		},
+		Action:         action,
+		ManifestBefore: kubernetesResourceAuditLogs.ToUnstructured(nodeBefore),
+		ManifestAfter:  kubernetesResourceAuditLogs.ToUnstructured(nodeAfter),
+		UserId:         request.UserId,
+	})
+	if err != nil {
+		impl.logger.Errorw("error in saving node change audit", "clusterId", request.ClusterId, "nodeName", request.Name, "action", action, "err", err)
+	}
+}
+
 func (impl *K8sCapacityServiceImpl) GetNode(ctx context.Context, clusterId int, nodeName string) (*corev1.Node, error) {
 	//getting kubernetes clientSet by rest config
 	_, _, k8sClientSet, err := impl.k8sCommonService.GetK8sConfigAndClientsByClusterId(ctx, clusterId)
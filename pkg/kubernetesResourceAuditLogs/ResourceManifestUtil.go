@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetesResourceAuditLogs
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	secretKind                  = "Secret"
+	maskedSecretValue           = "********"
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// ToUnstructured converts the typed kubernetes object for the resource change audit, nil is returned if the object
+// can not be converted so that the action is still audited without the manifest
+func ToUnstructured(object runtime.Object) *unstructured.Unstructured {
+	if object == nil {
+		return nil
+	}
+	objectMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil
+	}
+	return &unstructured.Unstructured{Object: objectMap}
+}
+
+// getAuditManifest returns the yaml of the manifest to be stored in the audit, managed fields are dropped and the
+// values of secrets are masked as the audit is readable by users not having access to the secret. gvk is set on the
+// manifests of typed objects as those are returned without the type meta by the clients
+func getAuditManifest(manifest *unstructured.Unstructured, gvk schema.GroupVersionKind) (string, error) {
+	if manifest == nil || len(manifest.Object) == 0 {
+		return "", nil
+	}
+	auditManifest := manifest.DeepCopy()
+	if len(auditManifest.GetKind()) == 0 {
+		auditManifest.SetGroupVersionKind(gvk)
+	}
+	unstructured.RemoveNestedField(auditManifest.Object, "metadata", "managedFields")
+	if auditManifest.GetKind() == secretKind && len(auditManifest.GroupVersionKind().Group) == 0 {
+		maskSecretValues(auditManifest.Object, "data")
+		maskSecretValues(auditManifest.Object, "stringData")
+		unstructured.RemoveNestedField(auditManifest.Object, "metadata", "annotations", lastAppliedConfigAnnotation)
+	}
+	manifestYaml, err := yaml.Marshal(auditManifest.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(manifestYaml), nil
+}
+
+// maskSecretValues keeps the keys of the secret so that added and removed keys are visible in the diff
+func maskSecretValues(object map[string]interface{}, field string) {
+	values, found, err := unstructured.NestedMap(object, field)
+	if err != nil || !found {
+		return
+	}
+	for key := range values {
+		values[key] = maskedSecretValue
+	}
+	_ = unstructured.SetNestedMap(object, values, field)
+}
+
+func getManifestDiff(manifestBefore, manifestAfter string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(manifestBefore),
+		B:        difflib.SplitLines(manifestAfter),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  3,
+	})
+}
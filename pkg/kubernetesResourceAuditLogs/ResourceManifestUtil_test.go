@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package kubernetesResourceAuditLogs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestGetAuditManifestMasksSecretValues(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":          "db-credentials",
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"annotations": map[string]interface{}{
+				lastAppliedConfigAnnotation: `{"data":{"password":"c2VjcmV0"}}`,
+			},
+		},
+		"data":       map[string]interface{}{"password": "c2VjcmV0"},
+		"stringData": map[string]interface{}{"user": "admin"},
+	}}
+	manifest, err := getAuditManifest(secret, schema.GroupVersionKind{Version: "v1", Kind: "Secret"})
+	assert.Nil(t, err)
+	assert.NotContains(t, manifest, "c2VjcmV0")
+	assert.NotContains(t, manifest, "admin")
+	assert.NotContains(t, manifest, "managedFields")
+	assert.Contains(t, manifest, "password: '"+maskedSecretValue+"'")
+	assert.Contains(t, manifest, "user: '"+maskedSecretValue+"'")
+	// the manifest passed is not modified
+	assert.Equal(t, "c2VjcmV0", secret.Object["data"].(map[string]interface{})["password"])
+
+	manifest, err = getAuditManifest(nil, schema.GroupVersionKind{})
+	assert.Nil(t, err)
+	assert.Empty(t, manifest)
+}
+
+func TestGetAuditManifestSetsKindOfTypedObject(t *testing.T) {
+	pod := ToUnstructured(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug-pod"}})
+	manifest, err := getAuditManifest(pod, schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+	assert.Nil(t, err)
+	assert.Contains(t, manifest, "apiVersion: v1\n")
+	assert.Contains(t, manifest, "kind: Pod\n")
+	assert.Contains(t, manifest, "name: debug-pod\n")
+}
+
+func TestGetManifestDiff(t *testing.T) {
+	diff, err := getManifestDiff("kind: ConfigMap\ndata:\n  key: old\n", "kind: ConfigMap\ndata:\n  key: new\n")
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(diff, "--- before\n+++ after\n"))
+	assert.Contains(t, diff, "-  key: old\n")
+	assert.Contains(t, diff, "+  key: new\n")
+
+	diff, err = getManifestDiff("kind: ConfigMap\n", "kind: ConfigMap\n")
+	assert.Nil(t, err)
+	assert.Empty(t, diff)
+}
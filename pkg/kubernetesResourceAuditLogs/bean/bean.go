@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"time"
+
+	"github.com/devtron-labs/common-lib/utils/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// actions recorded in the resource change audit, action types of the app resource history are kept as is
+const (
+	ActionCreate                      = "create"
+	ActionUpdate                      = "update"
+	ActionPatch                       = "patch"
+	ActionDelete                      = "delete"
+	ActionRecreate                    = "recreate"
+	ActionEphemeralContainerCreate    = "ephemeral_container_create"
+	ActionEphemeralContainerTerminate = "ephemeral_container_terminate"
+	ActionNodeCordon                  = "cordon"
+	ActionNodeUnCordon                = "uncordon"
+	ActionNodeDrain                   = "drain"
+	ActionNodeTaintEdit               = "taint_edit"
+)
+
+// ResourceChangeAuditRequest is a mutating action performed by a user on a kubernetes resource, ManifestBefore is nil for
+// created resources and ManifestAfter is nil for deleted ones
+type ResourceChangeAuditRequest struct {
+	ClusterId          int
+	ResourceIdentifier k8s.ResourceIdentifier
+	Action             string
+	ForceDelete        bool
+	ManifestBefore     *unstructured.Unstructured
+	ManifestAfter      *unstructured.Unstructured
+	UserId             int32
+}
+
+type ResourceChangeAuditFilter struct {
+	ClusterId int
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+	Action    string
+	UserId    int32
+	From      *time.Time
+	To        *time.Time
+	Offset    int
+	Size      int
+}
+
+type ResourceChangeAuditDto struct {
+	Id             int       `json:"id"`
+	ClusterId      int       `json:"clusterId"`
+	Group          string    `json:"group"`
+	Version        string    `json:"version"`
+	Kind           string    `json:"kind"`
+	Namespace      string    `json:"namespace"`
+	Name           string    `json:"name"`
+	Action         string    `json:"action"`
+	ForceDelete    bool      `json:"forceDelete"`
+	ManifestBefore string    `json:"manifestBefore,omitempty"`
+	ManifestAfter  string    `json:"manifestAfter,omitempty"`
+	ManifestDiff   string    `json:"manifestDiff,omitempty"`
+	UserId         int32     `json:"userId"`
+	UserEmail      string    `json:"userEmail"`
+	ActionOn       time.Time `json:"actionOn"`
+}
+
+type ResourceChangeAuditListResponse struct {
+	TotalCount int                       `json:"totalCount"`
+	Audits     []*ResourceChangeAuditDto `json:"audits"`
+}
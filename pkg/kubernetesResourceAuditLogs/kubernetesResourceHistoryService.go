@@ -21,10 +21,14 @@ import (
 	"github.com/devtron-labs/common-lib/utils/k8s"
 	"github.com/devtron-labs/devtron/api/helm-app/service/bean"
 	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/util"
+	userRepository "github.com/devtron-labs/devtron/pkg/auth/user/repository"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	bean2 "github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
 	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/repository"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"go.uber.org/zap"
+	"net/http"
 	"time"
 )
 
@@ -34,9 +38,19 @@ const (
 	GitOps string = "argo_cd"
 )
 
+const (
+	defaultAuditPageSize = 20
+	maxAuditPageSize     = 100
+)
+
 type K8sResourceHistoryService interface {
 	SaveArgoCdAppsResourceDeleteHistory(query *application.ApplicationResourceDeleteRequest, appId int, envId int, userId int32) error
 	SaveHelmAppsResourceHistory(appIdentifier *bean.AppIdentifier, k8sRequestBean *k8s.K8sRequestBean, userId int32, actionType string) error
+	// SaveResourceChangeAudit records the mutating action of the user on the kubernetes resource along with the diff of
+	// the manifest before and after the action
+	SaveResourceChangeAudit(request *bean2.ResourceChangeAuditRequest) error
+	GetResourceChangeAudits(filter *bean2.ResourceChangeAuditFilter) (*bean2.ResourceChangeAuditListResponse, error)
+	GetResourceChangeAuditById(id int) (*bean2.ResourceChangeAuditDto, error)
 }
 
 type K8sResourceHistoryServiceImpl struct {
@@ -44,15 +58,18 @@ type K8sResourceHistoryServiceImpl struct {
 	K8sResourceHistoryRepository repository.K8sResourceHistoryRepository
 	logger                       *zap.SugaredLogger
 	envRepository                repository2.EnvironmentRepository
+	userRepository               userRepository.UserRepository
 }
 
 func Newk8sResourceHistoryServiceImpl(K8sResourceHistoryRepository repository.K8sResourceHistoryRepository,
-	logger *zap.SugaredLogger, appRepository app.AppRepository, envRepository repository2.EnvironmentRepository) *K8sResourceHistoryServiceImpl {
+	logger *zap.SugaredLogger, appRepository app.AppRepository, envRepository repository2.EnvironmentRepository,
+	userRepository userRepository.UserRepository) *K8sResourceHistoryServiceImpl {
 	return &K8sResourceHistoryServiceImpl{
 		K8sResourceHistoryRepository: K8sResourceHistoryRepository,
 		logger:                       logger,
 		appRepository:                appRepository,
 		envRepository:                envRepository,
+		userRepository:               userRepository,
 	}
 }
 
@@ -113,3 +130,130 @@ func (impl K8sResourceHistoryServiceImpl) SaveHelmAppsResourceHistory(appIdentif
 	return err
 
 }
+
+func (impl K8sResourceHistoryServiceImpl) SaveResourceChangeAudit(request *bean2.ResourceChangeAuditRequest) error {
+	gvk := request.ResourceIdentifier.GroupVersionKind
+	manifestBefore, err := getAuditManifest(request.ManifestBefore, gvk)
+	if err != nil {
+		impl.logger.Errorw("error in getting manifest before the action for audit", "resourceIdentifier", request.ResourceIdentifier, "err", err)
+		return err
+	}
+	manifestAfter, err := getAuditManifest(request.ManifestAfter, gvk)
+	if err != nil {
+		impl.logger.Errorw("error in getting manifest after the action for audit", "resourceIdentifier", request.ResourceIdentifier, "err", err)
+		return err
+	}
+	manifestDiff, err := getManifestDiff(manifestBefore, manifestAfter)
+	if err != nil {
+		impl.logger.Errorw("error in getting manifest diff for audit", "resourceIdentifier", request.ResourceIdentifier, "err", err)
+		return err
+	}
+	resourceIdentifier := request.ResourceIdentifier
+	k8sResourceHistory := &repository.K8sResourceHistory{
+		ClusterId:      request.ClusterId,
+		Namespace:      resourceIdentifier.Namespace,
+		ResourceName:   resourceIdentifier.Name,
+		Kind:           resourceIdentifier.GroupVersionKind.Kind,
+		Group:          resourceIdentifier.GroupVersionKind.Group,
+		Version:        resourceIdentifier.GroupVersionKind.Version,
+		ForceDelete:    request.ForceDelete,
+		ActionType:     request.Action,
+		ManifestBefore: manifestBefore,
+		ManifestAfter:  manifestAfter,
+		ManifestDiff:   manifestDiff,
+	}
+	k8sResourceHistory.CreateAuditLog(request.UserId)
+	err = impl.K8sResourceHistoryRepository.SaveK8sResourceHistory(k8sResourceHistory)
+	if err != nil {
+		impl.logger.Errorw("error in saving resource change audit", "resourceIdentifier", resourceIdentifier, "action", request.Action, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl K8sResourceHistoryServiceImpl) GetResourceChangeAudits(filter *bean2.ResourceChangeAuditFilter) (*bean2.ResourceChangeAuditListResponse, error) {
+	if filter.Size <= 0 {
+		filter.Size = defaultAuditPageSize
+	} else if filter.Size > maxAuditPageSize {
+		filter.Size = maxAuditPageSize
+	}
+	k8sResourceHistories, totalCount, err := impl.K8sResourceHistoryRepository.FindByFilter(filter)
+	if err != nil {
+		impl.logger.Errorw("error in getting resource change audits", "filter", filter, "err", err)
+		return nil, err
+	}
+	userEmails, err := impl.getUserEmails(k8sResourceHistories)
+	if err != nil {
+		return nil, err
+	}
+	response := &bean2.ResourceChangeAuditListResponse{
+		TotalCount: totalCount,
+		Audits:     make([]*bean2.ResourceChangeAuditDto, 0, len(k8sResourceHistories)),
+	}
+	for _, k8sResourceHistory := range k8sResourceHistories {
+		audit := adaptToResourceChangeAuditDto(k8sResourceHistory)
+		audit.UserEmail = userEmails[audit.UserId]
+		response.Audits = append(response.Audits, audit)
+	}
+	return response, nil
+}
+
+func (impl K8sResourceHistoryServiceImpl) GetResourceChangeAuditById(id int) (*bean2.ResourceChangeAuditDto, error) {
+	k8sResourceHistory, err := impl.K8sResourceHistoryRepository.FindById(id)
+	if err != nil {
+		impl.logger.Errorw("error in getting resource change audit", "id", id, "err", err)
+		if util.IsErrNoRows(err) {
+			return nil, util.NewApiError(http.StatusNotFound, "resource change audit not found", err.Error())
+		}
+		return nil, err
+	}
+	userEmails, err := impl.getUserEmails([]*repository.K8sResourceHistory{k8sResourceHistory})
+	if err != nil {
+		return nil, err
+	}
+	audit := adaptToResourceChangeAuditDto(k8sResourceHistory)
+	audit.ManifestBefore = k8sResourceHistory.ManifestBefore
+	audit.ManifestAfter = k8sResourceHistory.ManifestAfter
+	audit.UserEmail = userEmails[audit.UserId]
+	return audit, nil
+}
+
+func (impl K8sResourceHistoryServiceImpl) getUserEmails(k8sResourceHistories []*repository.K8sResourceHistory) (map[int32]string, error) {
+	userEmails := make(map[int32]string)
+	userIds := make([]int32, 0, len(k8sResourceHistories))
+	for _, k8sResourceHistory := range k8sResourceHistories {
+		if _, ok := userEmails[k8sResourceHistory.UpdatedBy]; !ok {
+			userEmails[k8sResourceHistory.UpdatedBy] = ""
+			userIds = append(userIds, k8sResourceHistory.UpdatedBy)
+		}
+	}
+	if len(userIds) == 0 {
+		return userEmails, nil
+	}
+	users, err := impl.userRepository.GetByIds(userIds)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting users", "userIds", userIds, "err", err)
+		return nil, err
+	}
+	for _, user := range users {
+		userEmails[user.Id] = user.EmailId
+	}
+	return userEmails, nil
+}
+
+func adaptToResourceChangeAuditDto(k8sResourceHistory *repository.K8sResourceHistory) *bean2.ResourceChangeAuditDto {
+	return &bean2.ResourceChangeAuditDto{
+		Id:           k8sResourceHistory.Id,
+		ClusterId:    k8sResourceHistory.ClusterId,
+		Group:        k8sResourceHistory.Group,
+		Version:      k8sResourceHistory.Version,
+		Kind:         k8sResourceHistory.Kind,
+		Namespace:    k8sResourceHistory.Namespace,
+		Name:         k8sResourceHistory.ResourceName,
+		Action:       k8sResourceHistory.ActionType,
+		ForceDelete:  k8sResourceHistory.ForceDelete,
+		ManifestDiff: k8sResourceHistory.ManifestDiff,
+		UserId:       k8sResourceHistory.UpdatedBy,
+		ActionOn:     k8sResourceHistory.UpdatedOn,
+	}
+}
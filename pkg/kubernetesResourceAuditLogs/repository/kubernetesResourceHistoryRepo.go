@@ -17,6 +17,7 @@
 package repository
 
 import (
+	"github.com/devtron-labs/devtron/pkg/kubernetesResourceAuditLogs/bean"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
@@ -35,11 +36,19 @@ type K8sResourceHistory struct {
 	ForceDelete       bool     `sql:"force_delete, omitempty"`
 	ActionType        string   `sql:"action_type"`
 	DeploymentAppType string   `sql:"deployment_app_type"`
+	ClusterId         int      `sql:"cluster_id"`
+	Version           string   `sql:"version"`
+	ManifestBefore    string   `sql:"manifest_before"`
+	ManifestAfter     string   `sql:"manifest_after"`
+	ManifestDiff      string   `sql:"manifest_diff"`
 	sql.AuditLog
 }
 
 type K8sResourceHistoryRepository interface {
 	SaveK8sResourceHistory(history *K8sResourceHistory) error
+	FindById(id int) (*K8sResourceHistory, error)
+	// FindByFilter returns the histories of the page without the manifests along with the total count of the histories matching the filter
+	FindByFilter(filter *bean.ResourceChangeAuditFilter) ([]*K8sResourceHistory, int, error)
 }
 
 type K8sResourceHistoryRepositoryImpl struct {
@@ -57,3 +66,52 @@ func NewK8sResourceHistoryRepositoryImpl(dbConnection *pg.DB, logger *zap.Sugare
 func (repo K8sResourceHistoryRepositoryImpl) SaveK8sResourceHistory(k8sResourceHistory *K8sResourceHistory) error {
 	return repo.dbConnection.Insert(k8sResourceHistory)
 }
+
+func (repo K8sResourceHistoryRepositoryImpl) FindById(id int) (*K8sResourceHistory, error) {
+	k8sResourceHistory := &K8sResourceHistory{}
+	err := repo.dbConnection.Model(k8sResourceHistory).
+		Where("id = ?", id).
+		Select()
+	return k8sResourceHistory, err
+}
+
+func (repo K8sResourceHistoryRepositoryImpl) FindByFilter(filter *bean.ResourceChangeAuditFilter) ([]*K8sResourceHistory, int, error) {
+	var k8sResourceHistories []*K8sResourceHistory
+	query := repo.dbConnection.Model(&k8sResourceHistories).
+		Column("id", "cluster_id", "namespace", "resource_name", "kind", "group", "version", "force_delete",
+			"action_type", "manifest_diff", "created_on", "created_by", "updated_on", "updated_by").
+		// histories of the app resources saved before the change audit are not recorded against a cluster
+		Where("cluster_id IS NOT NULL")
+	if filter.ClusterId > 0 {
+		query = query.Where("cluster_id = ?", filter.ClusterId)
+	}
+	if len(filter.Group) > 0 {
+		query = query.Where("\"group\" = ?", filter.Group)
+	}
+	if len(filter.Kind) > 0 {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if len(filter.Namespace) > 0 {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+	if len(filter.Name) > 0 {
+		query = query.Where("resource_name = ?", filter.Name)
+	}
+	if len(filter.Action) > 0 {
+		query = query.Where("action_type = ?", filter.Action)
+	}
+	if filter.UserId > 0 {
+		query = query.Where("updated_by = ?", filter.UserId)
+	}
+	if filter.From != nil {
+		query = query.Where("updated_on >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("updated_on <= ?", *filter.To)
+	}
+	count, err := query.Order("id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Size).
+		SelectAndCount()
+	return k8sResourceHistories, count, err
+}
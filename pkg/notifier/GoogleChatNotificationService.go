@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/notifier/adapter"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type GoogleChatNotificationService interface {
+	SaveOrEditNotificationConfig(channelReq []beans.GoogleChatConfigDto, userId int32) ([]int, error)
+	FetchGoogleChatNotificationConfigById(id int) (*beans.GoogleChatConfigDto, error)
+	FetchAllGoogleChatNotificationConfig() ([]*beans.GoogleChatConfigDto, error)
+	FetchAllGoogleChatNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error)
+	DeleteNotificationConfig(deleteReq *beans.GoogleChatConfigDto, userId int32) error
+}
+
+type GoogleChatNotificationServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	googleChatRepository           repository.GoogleChatNotificationRepository
+	notificationSettingsRepository repository.NotificationSettingsRepository
+}
+
+func NewGoogleChatNotificationServiceImpl(logger *zap.SugaredLogger, googleChatRepository repository.GoogleChatNotificationRepository,
+	notificationSettingsRepository repository.NotificationSettingsRepository) *GoogleChatNotificationServiceImpl {
+	return &GoogleChatNotificationServiceImpl{
+		logger:                         logger,
+		googleChatRepository:           googleChatRepository,
+		notificationSettingsRepository: notificationSettingsRepository,
+	}
+}
+
+func (impl *GoogleChatNotificationServiceImpl) SaveOrEditNotificationConfig(channelReq []beans.GoogleChatConfigDto, userId int32) ([]int, error) {
+	var responseIds []int
+	googleChatConfigs := adapter.BuildGoogleChatNewConfigs(channelReq, userId)
+	for _, config := range googleChatConfigs {
+		if config.Id != 0 {
+			model, err := impl.googleChatRepository.FindOne(config.Id)
+			if err != nil && !util.IsErrNoRows(err) {
+				impl.logger.Errorw("err while fetching google chat config", "err", err)
+				return []int{}, err
+			}
+			adapter.BuildConfigUpdateModelForGoogleChat(config, model, userId)
+			model, uErr := impl.googleChatRepository.UpdateGoogleChatConfig(model)
+			if uErr != nil {
+				impl.logger.Errorw("err while updating google chat config", "err", uErr)
+				return []int{}, uErr
+			}
+		} else {
+			_, iErr := impl.googleChatRepository.SaveGoogleChatConfig(config)
+			if iErr != nil {
+				impl.logger.Errorw("err while inserting google chat config", "err", iErr)
+				return []int{}, iErr
+			}
+		}
+		responseIds = append(responseIds, config.Id)
+	}
+	return responseIds, nil
+}
+
+func (impl *GoogleChatNotificationServiceImpl) FetchGoogleChatNotificationConfigById(id int) (*beans.GoogleChatConfigDto, error) {
+	googleChatConfig, err := impl.googleChatRepository.FindOne(id)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find google chat config", "err", err, "id", id)
+		return nil, err
+	}
+	googleChatConfigDto := adapter.AdaptGoogleChatConfig(*googleChatConfig)
+	return &googleChatConfigDto, nil
+}
+
+func (impl *GoogleChatNotificationServiceImpl) FetchAllGoogleChatNotificationConfig() ([]*beans.GoogleChatConfigDto, error) {
+	var responseDto []*beans.GoogleChatConfigDto
+	googleChatConfigs, err := impl.googleChatRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all google chat config", "err", err)
+		return []*beans.GoogleChatConfigDto{}, err
+	}
+	for _, googleChatConfig := range googleChatConfigs {
+		googleChatConfigDto := adapter.AdaptGoogleChatConfig(googleChatConfig)
+		responseDto = append(responseDto, &googleChatConfigDto)
+	}
+	if responseDto == nil {
+		responseDto = make([]*beans.GoogleChatConfigDto, 0)
+	}
+	return responseDto, nil
+}
+
+func (impl *GoogleChatNotificationServiceImpl) FetchAllGoogleChatNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error) {
+	var responseDto []*beans.NotificationChannelAutoResponse
+	googleChatConfigs, err := impl.googleChatRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all google chat config", "err", err)
+		return []*beans.NotificationChannelAutoResponse{}, err
+	}
+	for _, googleChatConfig := range googleChatConfigs {
+		googleChatConfigDto := &beans.NotificationChannelAutoResponse{
+			Id:         googleChatConfig.Id,
+			ConfigName: googleChatConfig.ConfigName,
+		}
+		responseDto = append(responseDto, googleChatConfigDto)
+	}
+	return responseDto, nil
+}
+
+func (impl *GoogleChatNotificationServiceImpl) DeleteNotificationConfig(deleteReq *beans.GoogleChatConfigDto, userId int32) error {
+	existingConfig, err := impl.googleChatRepository.FindOne(deleteReq.Id)
+	if err != nil {
+		impl.logger.Errorw("No matching entry found for delete", "err", err, "id", deleteReq.Id)
+		return err
+	}
+	notifications, err := impl.notificationSettingsRepository.FindNotificationSettingsByConfigIdAndConfigType(deleteReq.Id, eventUtil.GoogleChat.String())
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in deleting google chat config", "config", deleteReq)
+		return err
+	}
+	if len(notifications) > 0 {
+		impl.logger.Errorw("found notifications using this config, cannot delete", "config", deleteReq)
+		return fmt.Errorf(" Please delete all notifications using this config before deleting")
+	}
+
+	existingConfig.UpdatedOn = time.Now()
+	existingConfig.UpdatedBy = userId
+	//deleting google chat config
+	err = impl.googleChatRepository.MarkGoogleChatConfigDeleted(existingConfig)
+	if err != nil {
+		impl.logger.Errorw("error in deleting google chat config", "err", err, "id", existingConfig.Id)
+		return err
+	}
+	return nil
+}
@@ -53,6 +53,9 @@ func (impl NotificationConfigBuilderImpl) BuildNotificationSettingsConfig(notifi
 	nsConfig.PipelineType = notificationSettingsRequest.PipelineType
 	nsConfig.EventTypeIds = notificationSettingsRequest.EventTypeIds
 	nsConfig.Providers = notificationSettingsRequest.Providers
+	nsConfig.DeliveryMode = notificationSettingsRequest.DeliveryMode
+	nsConfig.DigestIntervalInMins = notificationSettingsRequest.DigestIntervalInMins
+	nsConfig.Condition = notificationSettingsRequest.Condition
 
 	config, err := json.Marshal(nsConfig)
 	if err != nil {
@@ -19,6 +19,8 @@ package notifier
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/cel"
 	clusterService "github.com/devtron-labs/devtron/pkg/cluster"
 	repository3 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	"github.com/devtron-labs/devtron/pkg/notifier/beans"
@@ -26,6 +28,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/team/read"
 	repository2 "github.com/devtron-labs/devtron/pkg/team/repository"
 	"github.com/devtron-labs/devtron/util/sliceUtil"
+	"net/http"
 	"time"
 
 	"github.com/devtron-labs/devtron/internal/sql/repository"
@@ -57,6 +60,8 @@ type NotificationConfigServiceImpl struct {
 	pipelineRepository             pipelineConfig.PipelineRepository
 	slackRepository                repository.SlackNotificationRepository
 	webhookRepository              repository.WebhookNotificationRepository
+	teamsRepository                repository.TeamsNotificationRepository
+	googleChatRepository           repository.GoogleChatNotificationRepository
 	sesRepository                  repository.SESNotificationRepository
 	smtpRepository                 repository.SMTPNotificationRepository
 	environmentRepository          repository3.EnvironmentRepository
@@ -65,6 +70,7 @@ type NotificationConfigServiceImpl struct {
 	userRepository                 repository4.UserRepository
 	ciPipelineMaterialRepository   pipelineConfig.CiPipelineMaterialRepository
 	teamReadService                read.TeamReadService
+	celEvaluatorService            cel.EvaluatorService
 }
 
 func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSettingsRepository repository.NotificationSettingsRepository, notificationConfigBuilder NotificationConfigBuilder, ciPipelineRepository pipelineConfig.CiPipelineRepository,
@@ -73,7 +79,9 @@ func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSet
 	teamRepository repository2.TeamRepository,
 	environmentRepository repository3.EnvironmentRepository, appRepository app.AppRepository, clusterService clusterService.ClusterService,
 	userRepository repository4.UserRepository, ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository,
-	teamReadService read.TeamReadService) *NotificationConfigServiceImpl {
+	teamReadService read.TeamReadService, teamsRepository repository.TeamsNotificationRepository,
+	googleChatRepository repository.GoogleChatNotificationRepository,
+	celEvaluatorService cel.EvaluatorService) *NotificationConfigServiceImpl {
 	return &NotificationConfigServiceImpl{
 		logger:                         logger,
 		notificationSettingsRepository: notificationSettingsRepository,
@@ -83,6 +91,8 @@ func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSet
 		sesRepository:                  sesRepository,
 		slackRepository:                slackRepository,
 		webhookRepository:              webhookRepository,
+		teamsRepository:                teamsRepository,
+		googleChatRepository:           googleChatRepository,
 		smtpRepository:                 smtpRepository,
 		environmentRepository:          environmentRepository,
 		appRepository:                  appRepository,
@@ -90,6 +100,7 @@ func NewNotificationConfigServiceImpl(logger *zap.SugaredLogger, notificationSet
 		ciPipelineMaterialRepository:   ciPipelineMaterialRepository,
 		clusterService:                 clusterService,
 		teamReadService:                teamReadService,
+		celEvaluatorService:            celEvaluatorService,
 	}
 }
 
@@ -294,12 +305,18 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 		if config.Providers != nil && len(config.Providers) > 0 {
 			var slackIds []*int
 			var webhookIds []*int
+			var teamsIds []*int
+			var googleChatIds []*int
 			var providerConfigs []*beans.ProvidersConfig
 			for _, item := range config.Providers {
 				if item.Destination == util.Slack {
 					slackIds = append(slackIds, &item.ConfigId)
 				} else if item.Destination == util.Webhook {
 					webhookIds = append(webhookIds, &item.ConfigId)
+				} else if item.Destination == util.Teams {
+					teamsIds = append(teamsIds, &item.ConfigId)
+				} else if item.Destination == util.GoogleChat {
+					googleChatIds = append(googleChatIds, &item.ConfigId)
 				} else {
 					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Dest: string(item.Destination), Recipient: item.Recipient, Id: item.ConfigId})
 				}
@@ -324,6 +341,26 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.Webhook)})
 				}
 			}
+			if len(teamsIds) > 0 {
+				teamsConfigs, err := impl.teamsRepository.FindByIds(teamsIds)
+				if err != nil && err != pg.ErrNoRows {
+					impl.logger.Errorw("error in fetching teams config", "teamsIds", teamsIds, "err", err)
+					return notificationSettingsResponses, deletedItemCount, err
+				}
+				for _, item := range teamsConfigs {
+					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.Teams)})
+				}
+			}
+			if len(googleChatIds) > 0 {
+				googleChatConfigs, err := impl.googleChatRepository.FindByIds(googleChatIds)
+				if err != nil && err != pg.ErrNoRows {
+					impl.logger.Errorw("error in fetching google chat config", "googleChatIds", googleChatIds, "err", err)
+					return notificationSettingsResponses, deletedItemCount, err
+				}
+				for _, item := range googleChatConfigs {
+					providerConfigs = append(providerConfigs, &beans.ProvidersConfig{Id: item.Id, ConfigName: item.ConfigName, Dest: string(util.GoogleChat)})
+				}
+			}
 			notificationSettingsResponse.ProvidersConfig = providerConfigs
 		}
 
@@ -370,6 +407,12 @@ func (impl *NotificationConfigServiceImpl) BuildNotificationSettingsResponse(not
 
 		notificationSettingsResponse.PipelineType = string(config.PipelineType)
 		notificationSettingsResponse.EventTypes = config.EventTypeIds
+		notificationSettingsResponse.Condition = config.Condition
+		notificationSettingsResponse.DeliveryMode = beans.DeliveryModeImmediate
+		if config.IsDigest() {
+			notificationSettingsResponse.DeliveryMode = beans.DeliveryModeDigest
+			notificationSettingsResponse.DigestIntervalInMins = config.DigestIntervalInMins
+		}
 
 		notificationSettingsResponses = append(notificationSettingsResponses, notificationSettingsResponse)
 	}
@@ -509,9 +552,31 @@ func (impl *NotificationConfigServiceImpl) buildPipelineResponses(config beans.C
 	return pipelineType, pipelineResponses, nil
 }
 
+// validateCondition type checks the condition of a notification setting against the attributes available on events
+func (impl *NotificationConfigServiceImpl) validateCondition(condition string) error {
+	if len(condition) == 0 {
+		return nil
+	}
+	request := cel.Request{
+		Expression: condition,
+		ExpressionMetadata: cel.ExpressionMetadata{
+			Params: beans.GetNotificationConditionParams(),
+		},
+	}
+	_, _, err := impl.celEvaluatorService.Validate(request)
+	if err != nil {
+		impl.logger.Errorw("invalid notification condition", "condition", condition, "err", err)
+		return util2.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid condition: %s", err.Error()), err.Error())
+	}
+	return nil
+}
+
 func (impl *NotificationConfigServiceImpl) saveNotificationSetting(notificationSettingsRequest *beans.NotificationConfigRequest, userId int32, tx *pg.Tx) (int, error) {
 	var existingNotificationSettingsConfig *repository.NotificationSettingsView
 	var err error
+	if err = impl.validateCondition(notificationSettingsRequest.Condition); err != nil {
+		return 0, err
+	}
 	if notificationSettingsRequest.Id != 0 {
 		existingNotificationSettingsConfig, err = impl.notificationSettingsRepository.FindNotificationSettingsViewById(notificationSettingsRequest.Id)
 		if err != nil {
@@ -566,6 +631,14 @@ func (impl *NotificationConfigServiceImpl) updateNotificationSetting(notificatio
 		nsConfig.EventTypeIds = notificationSettingsRequest.EventTypeIds
 	} else if updateType == util.UpdateRecipients {
 		nsConfig.Providers = notificationSettingsRequest.Providers
+	} else if updateType == util.UpdateDelivery {
+		nsConfig.DeliveryMode = notificationSettingsRequest.DeliveryMode
+		nsConfig.DigestIntervalInMins = notificationSettingsRequest.DigestIntervalInMins
+	} else if updateType == util.UpdateCondition {
+		if err = impl.validateCondition(notificationSettingsRequest.Condition); err != nil {
+			return 0, err
+		}
+		nsConfig.Condition = notificationSettingsRequest.Condition
 	}
 	config, err := json.Marshal(nsConfig)
 	if err != nil {
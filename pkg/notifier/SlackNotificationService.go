@@ -47,17 +47,22 @@ type SlackNotificationServiceImpl struct {
 	teamService                    team.TeamService
 	slackRepository                repository.SlackNotificationRepository
 	webhookRepository              repository.WebhookNotificationRepository
+	teamsRepository                repository.TeamsNotificationRepository
+	googleChatRepository           repository.GoogleChatNotificationRepository
 	userRepository                 repository2.UserRepository
 	notificationSettingsRepository repository.NotificationSettingsRepository
 }
 
 func NewSlackNotificationServiceImpl(logger *zap.SugaredLogger, slackRepository repository.SlackNotificationRepository, webhookRepository repository.WebhookNotificationRepository, teamService team.TeamService,
-	userRepository repository2.UserRepository, notificationSettingsRepository repository.NotificationSettingsRepository) *SlackNotificationServiceImpl {
+	userRepository repository2.UserRepository, notificationSettingsRepository repository.NotificationSettingsRepository,
+	teamsRepository repository.TeamsNotificationRepository, googleChatRepository repository.GoogleChatNotificationRepository) *SlackNotificationServiceImpl {
 	return &SlackNotificationServiceImpl{
 		logger:                         logger,
 		teamService:                    teamService,
 		slackRepository:                slackRepository,
 		webhookRepository:              webhookRepository,
+		teamsRepository:                teamsRepository,
+		googleChatRepository:           googleChatRepository,
 		userRepository:                 userRepository,
 		notificationSettingsRepository: notificationSettingsRepository,
 	}
@@ -164,6 +169,30 @@ func (impl *SlackNotificationServiceImpl) RecipientListingSuggestion(value strin
 			Dest:      eventUtil.Webhook}
 		results = append(results, result)
 	}
+	teamsConfigs, err := impl.teamsRepository.FindByName(value)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all teams config", "err", err)
+		return []*beans.NotificationRecipientListingResponse{}, err
+	}
+	for _, teamsConfig := range teamsConfigs {
+		result := &beans.NotificationRecipientListingResponse{
+			ConfigId:  teamsConfig.Id,
+			Recipient: teamsConfig.ConfigName,
+			Dest:      eventUtil.Teams}
+		results = append(results, result)
+	}
+	googleChatConfigs, err := impl.googleChatRepository.FindByName(value)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all google chat config", "err", err)
+		return []*beans.NotificationRecipientListingResponse{}, err
+	}
+	for _, googleChatConfig := range googleChatConfigs {
+		result := &beans.NotificationRecipientListingResponse{
+			ConfigId:  googleChatConfig.Id,
+			Recipient: googleChatConfig.ConfigName,
+			Dest:      eventUtil.GoogleChat}
+		results = append(results, result)
+	}
 	userList, err := impl.userRepository.FetchUserMatchesByEmailIdExcludingApiTokenUser(value)
 	if err != nil && !util.IsErrNoRows(err) {
 		impl.logger.Errorw("cannot find all slack config", "err", err)
@@ -201,6 +230,10 @@ func (impl *SlackNotificationServiceImpl) RecipientListingSuggestion(value strin
 								}
 								if strings.Contains(v.(string), beans.SLACK_URL) {
 									result.Dest = eventUtil.Slack
+								} else if strings.Contains(v.(string), beans.TEAMS_URL) {
+									result.Dest = eventUtil.Teams
+								} else if strings.Contains(v.(string), beans.GOOGLE_CHAT_URL) {
+									result.Dest = eventUtil.GoogleChat
 								} else if strings.Contains(v.(string), beans.WEBHOOK_URL) {
 									result.Dest = eventUtil.Webhook
 								} else {
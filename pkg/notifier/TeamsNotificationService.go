@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/notifier/adapter"
+	"github.com/devtron-labs/devtron/pkg/notifier/beans"
+	eventUtil "github.com/devtron-labs/devtron/util/event"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+)
+
+type TeamsNotificationService interface {
+	SaveOrEditNotificationConfig(channelReq []beans.TeamsConfigDto, userId int32) ([]int, error)
+	FetchTeamsNotificationConfigById(id int) (*beans.TeamsConfigDto, error)
+	FetchAllTeamsNotificationConfig() ([]*beans.TeamsConfigDto, error)
+	FetchAllTeamsNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error)
+	DeleteNotificationConfig(deleteReq *beans.TeamsConfigDto, userId int32) error
+}
+
+type TeamsNotificationServiceImpl struct {
+	logger                         *zap.SugaredLogger
+	teamsRepository                repository.TeamsNotificationRepository
+	notificationSettingsRepository repository.NotificationSettingsRepository
+}
+
+func NewTeamsNotificationServiceImpl(logger *zap.SugaredLogger, teamsRepository repository.TeamsNotificationRepository,
+	notificationSettingsRepository repository.NotificationSettingsRepository) *TeamsNotificationServiceImpl {
+	return &TeamsNotificationServiceImpl{
+		logger:                         logger,
+		teamsRepository:                teamsRepository,
+		notificationSettingsRepository: notificationSettingsRepository,
+	}
+}
+
+func (impl *TeamsNotificationServiceImpl) SaveOrEditNotificationConfig(channelReq []beans.TeamsConfigDto, userId int32) ([]int, error) {
+	var responseIds []int
+	teamsConfigs := adapter.BuildTeamsNewConfigs(channelReq, userId)
+	for _, config := range teamsConfigs {
+		if config.Id != 0 {
+			model, err := impl.teamsRepository.FindOne(config.Id)
+			if err != nil && !util.IsErrNoRows(err) {
+				impl.logger.Errorw("err while fetching teams config", "err", err)
+				return []int{}, err
+			}
+			adapter.BuildConfigUpdateModelForTeams(config, model, userId)
+			model, uErr := impl.teamsRepository.UpdateTeamsConfig(model)
+			if uErr != nil {
+				impl.logger.Errorw("err while updating teams config", "err", uErr)
+				return []int{}, uErr
+			}
+		} else {
+			_, iErr := impl.teamsRepository.SaveTeamsConfig(config)
+			if iErr != nil {
+				impl.logger.Errorw("err while inserting teams config", "err", iErr)
+				return []int{}, iErr
+			}
+		}
+		responseIds = append(responseIds, config.Id)
+	}
+	return responseIds, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) FetchTeamsNotificationConfigById(id int) (*beans.TeamsConfigDto, error) {
+	teamsConfig, err := impl.teamsRepository.FindOne(id)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find teams config", "err", err, "id", id)
+		return nil, err
+	}
+	teamsConfigDto := adapter.AdaptTeamsConfig(*teamsConfig)
+	return &teamsConfigDto, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) FetchAllTeamsNotificationConfig() ([]*beans.TeamsConfigDto, error) {
+	var responseDto []*beans.TeamsConfigDto
+	teamsConfigs, err := impl.teamsRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all teams config", "err", err)
+		return []*beans.TeamsConfigDto{}, err
+	}
+	for _, teamsConfig := range teamsConfigs {
+		teamsConfigDto := adapter.AdaptTeamsConfig(teamsConfig)
+		responseDto = append(responseDto, &teamsConfigDto)
+	}
+	if responseDto == nil {
+		responseDto = make([]*beans.TeamsConfigDto, 0)
+	}
+	return responseDto, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) FetchAllTeamsNotificationConfigAutocomplete() ([]*beans.NotificationChannelAutoResponse, error) {
+	var responseDto []*beans.NotificationChannelAutoResponse
+	teamsConfigs, err := impl.teamsRepository.FindAll()
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("cannot find all teams config", "err", err)
+		return []*beans.NotificationChannelAutoResponse{}, err
+	}
+	for _, teamsConfig := range teamsConfigs {
+		teamsConfigDto := &beans.NotificationChannelAutoResponse{
+			Id:         teamsConfig.Id,
+			ConfigName: teamsConfig.ConfigName,
+		}
+		responseDto = append(responseDto, teamsConfigDto)
+	}
+	return responseDto, nil
+}
+
+func (impl *TeamsNotificationServiceImpl) DeleteNotificationConfig(deleteReq *beans.TeamsConfigDto, userId int32) error {
+	existingConfig, err := impl.teamsRepository.FindOne(deleteReq.Id)
+	if err != nil {
+		impl.logger.Errorw("No matching entry found for delete", "err", err, "id", deleteReq.Id)
+		return err
+	}
+	notifications, err := impl.notificationSettingsRepository.FindNotificationSettingsByConfigIdAndConfigType(deleteReq.Id, eventUtil.Teams.String())
+	if err != nil && err != pg.ErrNoRows {
+		impl.logger.Errorw("error in deleting teams config", "config", deleteReq)
+		return err
+	}
+	if len(notifications) > 0 {
+		impl.logger.Errorw("found notifications using this config, cannot delete", "config", deleteReq)
+		return fmt.Errorf(" Please delete all notifications using this config before deleting")
+	}
+
+	existingConfig.UpdatedOn = time.Now()
+	existingConfig.UpdatedBy = userId
+	//deleting teams config
+	err = impl.teamsRepository.MarkTeamsConfigDeleted(existingConfig)
+	if err != nil {
+		impl.logger.Errorw("error in deleting teams config", "err", err, "id", existingConfig.Id)
+		return err
+	}
+	return nil
+}
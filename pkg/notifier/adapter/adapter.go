@@ -224,3 +224,85 @@ func BuildConfigUpdateModelForWebhook(webhookConfig *repository.WebhookConfig, m
 	model.UpdatedOn = time.Now()
 	model.UpdatedBy = userId
 }
+
+func AdaptTeamsConfig(teamsConfig repository.TeamsConfig) beans.TeamsConfigDto {
+	teamsConfigDto := beans.TeamsConfigDto{
+		OwnerId:     teamsConfig.OwnerId,
+		WebhookUrl:  teamsConfig.WebHookUrl,
+		ConfigName:  teamsConfig.ConfigName,
+		Description: teamsConfig.Description,
+		Id:          teamsConfig.Id,
+	}
+	return teamsConfigDto
+}
+
+func BuildTeamsNewConfigs(teamsReq []beans.TeamsConfigDto, userId int32) []*repository.TeamsConfig {
+	var teamsConfigs []*repository.TeamsConfig
+	for _, c := range teamsReq {
+		teamsConfig := &repository.TeamsConfig{
+			Id:          c.Id,
+			ConfigName:  c.ConfigName,
+			WebHookUrl:  c.WebhookUrl,
+			Description: c.Description,
+			AuditLog: sql.AuditLog{
+				CreatedBy: userId,
+				CreatedOn: time.Now(),
+				UpdatedOn: time.Now(),
+				UpdatedBy: userId,
+			},
+		}
+		teamsConfig.OwnerId = userId
+		teamsConfigs = append(teamsConfigs, teamsConfig)
+	}
+	return teamsConfigs
+}
+
+func BuildConfigUpdateModelForTeams(teamsConfig *repository.TeamsConfig, model *repository.TeamsConfig, userId int32) {
+	model.WebHookUrl = teamsConfig.WebHookUrl
+	model.ConfigName = teamsConfig.ConfigName
+	model.Description = teamsConfig.Description
+	model.OwnerId = teamsConfig.OwnerId
+	model.UpdatedOn = time.Now()
+	model.UpdatedBy = userId
+}
+
+func AdaptGoogleChatConfig(googleChatConfig repository.GoogleChatConfig) beans.GoogleChatConfigDto {
+	googleChatConfigDto := beans.GoogleChatConfigDto{
+		OwnerId:     googleChatConfig.OwnerId,
+		WebhookUrl:  googleChatConfig.WebHookUrl,
+		ConfigName:  googleChatConfig.ConfigName,
+		Description: googleChatConfig.Description,
+		Id:          googleChatConfig.Id,
+	}
+	return googleChatConfigDto
+}
+
+func BuildGoogleChatNewConfigs(googleChatReq []beans.GoogleChatConfigDto, userId int32) []*repository.GoogleChatConfig {
+	var googleChatConfigs []*repository.GoogleChatConfig
+	for _, c := range googleChatReq {
+		googleChatConfig := &repository.GoogleChatConfig{
+			Id:          c.Id,
+			ConfigName:  c.ConfigName,
+			WebHookUrl:  c.WebhookUrl,
+			Description: c.Description,
+			AuditLog: sql.AuditLog{
+				CreatedBy: userId,
+				CreatedOn: time.Now(),
+				UpdatedOn: time.Now(),
+				UpdatedBy: userId,
+			},
+		}
+		googleChatConfig.OwnerId = userId
+		googleChatConfigs = append(googleChatConfigs, googleChatConfig)
+	}
+	return googleChatConfigs
+}
+
+func BuildConfigUpdateModelForGoogleChat(googleChatConfig *repository.GoogleChatConfig, model *repository.GoogleChatConfig, userId int32) {
+	model.WebHookUrl = googleChatConfig.WebHookUrl
+	model.ConfigName = googleChatConfig.ConfigName
+	model.Description = googleChatConfig.Description
+	model.OwnerId = googleChatConfig.OwnerId
+	model.UpdatedOn = time.Now()
+	model.UpdatedBy = userId
+}
@@ -1,15 +1,49 @@
 package beans
 
 import (
+	"fmt"
+	"github.com/devtron-labs/devtron/cel"
 	"github.com/devtron-labs/devtron/client/events/bean"
 	util "github.com/devtron-labs/devtron/util/event"
 )
 
 const (
-	SLACK_URL   = "https://hooks.slack.com/"
-	WEBHOOK_URL = "https://"
+	SLACK_URL       = "https://hooks.slack.com/"
+	TEAMS_URL       = ".webhook.office.com/"
+	GOOGLE_CHAT_URL = "https://chat.googleapis.com/"
+	WEBHOOK_URL     = "https://"
 )
 
+// DeliveryMode decides whether matching events are sent as they happen or batched into a periodic digest
+type DeliveryMode string
+
+const (
+	DeliveryModeImmediate DeliveryMode = "IMMEDIATE"
+	DeliveryModeDigest    DeliveryMode = "DIGEST"
+)
+
+const (
+	MinDigestIntervalInMins = 5
+	MaxDigestIntervalInMins = 24 * 60
+)
+
+// GetNotificationConditionParams declares the event attributes which can be used in the condition of a notification setting,
+// values are populated from the event at the time of notifying
+func GetNotificationConditionParams() []cel.ExpressionParam {
+	return []cel.ExpressionParam{
+		{ParamName: cel.AppName, Type: cel.ParamTypeString},
+		{ParamName: cel.EnvName, Type: cel.ParamTypeString},
+		{ParamName: cel.IsProdEnv, Type: cel.ParamTypeBool},
+		{ParamName: cel.PipelineName, Type: cel.ParamTypeString},
+		{ParamName: cel.PipelineType, Type: cel.ParamTypeString},
+		{ParamName: cel.EventType, Type: cel.ParamTypeString},
+		{ParamName: cel.TriggeredBy, Type: cel.ParamTypeString},
+		{ParamName: cel.ContainerImageTag, Type: cel.ParamTypeString},
+		{ParamName: cel.FailureStage, Type: cel.ParamTypeString},
+		{ParamName: cel.DurationInSecs, Type: cel.ParamTypeInteger},
+	}
+}
+
 type WebhookVariable string
 
 const (
@@ -45,6 +79,26 @@ type NotificationConfigRequest struct {
 	PipelineType util.PipelineType `json:"pipelineType" validate:"required"`
 	EventTypeIds []int             `json:"eventTypeIds" validate:"required"`
 	Providers    []*bean.Provider  `json:"providers"`
+
+	DeliveryMode         DeliveryMode `json:"deliveryMode,omitempty"`
+	DigestIntervalInMins int          `json:"digestIntervalInMins,omitempty"`
+	// Condition is an optional CEL expression over the event attributes, events for which it does not hold are not notified
+	Condition string `json:"condition,omitempty"`
+}
+
+// ValidateDeliveryConfig checks that a digest interval is provided whenever the events are to be batched
+func (notificationSettingsRequest *NotificationConfigRequest) ValidateDeliveryConfig() error {
+	switch notificationSettingsRequest.DeliveryMode {
+	case "", DeliveryModeImmediate:
+		return nil
+	case DeliveryModeDigest:
+		if notificationSettingsRequest.DigestIntervalInMins < MinDigestIntervalInMins || notificationSettingsRequest.DigestIntervalInMins > MaxDigestIntervalInMins {
+			return fmt.Errorf("digest interval should be between %d and %d minutes", MinDigestIntervalInMins, MaxDigestIntervalInMins)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid delivery mode %q", notificationSettingsRequest.DeliveryMode)
+	}
 }
 
 func (notificationSettingsRequest *NotificationConfigRequest) GenerateSettingCombinationsV1() []*LocalRequest {
@@ -203,6 +257,14 @@ type NSConfig struct {
 	PipelineType util.PipelineType `json:"pipelineType" validate:"required"`
 	EventTypeIds []int             `json:"eventTypeIds" validate:"required"`
 	Providers    []*bean.Provider  `json:"providers" validate:"required"`
+
+	DeliveryMode         DeliveryMode `json:"deliveryMode,omitempty"`
+	DigestIntervalInMins int          `json:"digestIntervalInMins,omitempty"`
+	Condition            string       `json:"condition,omitempty"`
+}
+
+func (config *NSConfig) IsDigest() bool {
+	return config.DeliveryMode == DeliveryModeDigest && config.DigestIntervalInMins > 0
 }
 
 type NotificationSettingRequest struct {
@@ -253,6 +315,10 @@ type NotificationSettingsResponse struct {
 	PipelineType     string             `json:"pipelineType"`
 	ProvidersConfig  []*ProvidersConfig `json:"providerConfigs"`
 	EventTypes       []int              `json:"eventTypes"`
+
+	DeliveryMode         DeliveryMode `json:"deliveryMode"`
+	DigestIntervalInMins int          `json:"digestIntervalInMins,omitempty"`
+	Condition            string       `json:"condition,omitempty"`
 }
 
 type SearchFilterResponse struct {
@@ -398,6 +464,36 @@ type WebhookConfigDto struct {
 	Id          int                    `json:"id" validate:"number"`
 }
 
+//teams
+
+type TeamsChannelConfig struct {
+	Channel         util.Channel     `json:"channel" validate:"required"`
+	TeamsConfigDtos []TeamsConfigDto `json:"configs"`
+}
+
+type TeamsConfigDto struct {
+	OwnerId     int32  `json:"userId" validate:"number"`
+	WebhookUrl  string `json:"webhookUrl" validate:"required"`
+	ConfigName  string `json:"configName" validate:"required"`
+	Description string `json:"description"`
+	Id          int    `json:"id" validate:"number"`
+}
+
+//google chat
+
+type GoogleChatChannelConfig struct {
+	Channel              util.Channel          `json:"channel" validate:"required"`
+	GoogleChatConfigDtos []GoogleChatConfigDto `json:"configs"`
+}
+
+type GoogleChatConfigDto struct {
+	OwnerId     int32  `json:"userId" validate:"number"`
+	WebhookUrl  string `json:"webhookUrl" validate:"required"`
+	ConfigName  string `json:"configName" validate:"required"`
+	Description string `json:"description"`
+	Id          int    `json:"id" validate:"number"`
+}
+
 type Config struct {
 	AppId        int               `json:"appId"`
 	EnvId        int               `json:"envId"`
@@ -38,6 +38,7 @@ import (
 	bean3 "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/build/pipeline/bean/common"
 	"github.com/devtron-labs/devtron/pkg/build/pipeline/read"
+	"github.com/devtron-labs/devtron/pkg/build/pipeline/schedule"
 	pipelineConfigBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/history"
 	"github.com/devtron-labs/devtron/pkg/pipeline/repository"
@@ -152,6 +153,7 @@ type CiPipelineConfigServiceImpl struct {
 	pipelineStageRepository       repository.PipelineStageRepository
 	globalPluginRepository        repository2.GlobalPluginRepository
 	appListingService             app.AppListingService
+	ciPipelineScheduleService     schedule.CiPipelineScheduleService
 }
 
 func NewCiPipelineConfigServiceImpl(logger *zap.SugaredLogger,
@@ -180,7 +182,8 @@ func NewCiPipelineConfigServiceImpl(logger *zap.SugaredLogger,
 	buildPipelineSwitchService BuildPipelineSwitchService,
 	pipelineStageRepository repository.PipelineStageRepository,
 	globalPluginRepository repository2.GlobalPluginRepository,
-	appListingService app.AppListingService) *CiPipelineConfigServiceImpl {
+	appListingService app.AppListingService,
+	ciPipelineScheduleService schedule.CiPipelineScheduleService) *CiPipelineConfigServiceImpl {
 	securityConfig := &SecurityConfig{}
 	err := env.Parse(securityConfig)
 	if err != nil {
@@ -215,6 +218,7 @@ func NewCiPipelineConfigServiceImpl(logger *zap.SugaredLogger,
 		pipelineStageRepository:       pipelineStageRepository,
 		globalPluginRepository:        globalPluginRepository,
 		appListingService:             appListingService,
+		ciPipelineScheduleService:     ciPipelineScheduleService,
 	}
 }
 
@@ -856,6 +860,11 @@ func (impl *CiPipelineConfigServiceImpl) GetCiPipelineById(pipelineId int) (ciPi
 	}
 	ciPipeline.PreBuildStage = preStageDetail
 	ciPipeline.PostBuildStage = postStageDetail
+	ciPipeline.Schedule, err = impl.ciPipelineScheduleService.GetSchedule(ciPipeline.Id)
+	if err != nil {
+		impl.logger.Errorw("error in getting schedule by ciPipelineId", "err", err, "ciPipelineId", ciPipeline.Id)
+		return nil, err
+	}
 	return ciPipeline, err
 }
 
@@ -1352,15 +1361,28 @@ func (impl *CiPipelineConfigServiceImpl) PatchCiPipeline(request *bean.CiPatchRe
 	ciConfig.IsJob = request.IsJob
 	// Check for clone job to not create env override again
 	ciConfig.IsCloneJob = request.IsCloneJob
+	if request.Action != bean.DELETE {
+		err = impl.validateCiPipelineSchedule(request.CiPipeline)
+		if err != nil {
+			return nil, err
+		}
+	}
 	switch request.Action {
 	case bean.CREATE:
 		res, err := impl.handlePipelineCreate(request, ciConfig)
 		if err != nil {
 			impl.logger.Errorw("error in creating ci pipeline", "err", err, "request", request, "ciConfig", ciConfig)
+			return res, err
 		}
+		err = impl.saveCiPipelineSchedule(request.CiPipeline, request.UserId)
 		return res, err
 	case bean.UPDATE_SOURCE:
-		return impl.patchCiPipelineUpdateSource(ciConfig, request.CiPipeline)
+		res, err := impl.patchCiPipelineUpdateSource(ciConfig, request.CiPipeline)
+		if err != nil {
+			return nil, err
+		}
+		err = impl.saveCiPipelineSchedule(request.CiPipeline, request.UserId)
+		return res, err
 	case bean.DELETE:
 		pipeline, err := impl.DeleteCiPipeline(request)
 		if err != nil {
@@ -1375,6 +1397,60 @@ func (impl *CiPipelineConfigServiceImpl) PatchCiPipeline(request *bean.CiPatchRe
 
 }
 
+func (impl *CiPipelineConfigServiceImpl) validateCiPipelineSchedule(ciPipeline *bean.CiPipeline) error {
+	if ciPipeline == nil || ciPipeline.Schedule.IsRemoveRequest() {
+		return nil
+	}
+	if ciPipeline.Schedule == nil {
+		if ciPipeline.Id == 0 {
+			return nil
+		}
+		// the saved schedule is retained when not sent, so the materials being saved must still support it
+		savedSchedule, err := impl.ciPipelineScheduleService.GetSchedule(ciPipeline.Id)
+		if err != nil {
+			return err
+		} else if savedSchedule == nil {
+			return nil
+		}
+		return validateScheduledCiMaterials(ciPipeline)
+	}
+	if ciPipeline.IsLinkedCi() || ciPipeline.PipelineType == common.LINKED || ciPipeline.PipelineType == common.LINKED_CD ||
+		ciPipeline.PipelineType == common.EXTERNAL {
+		errorMessage := "schedule is supported only for build and job pipelines"
+		return util.DefaultApiError().WithHttpStatusCode(http.StatusBadRequest).WithInternalMessage(errorMessage).WithUserMessage(errorMessage)
+	}
+	err := validateScheduledCiMaterials(ciPipeline)
+	if err != nil {
+		return err
+	}
+	return impl.ciPipelineScheduleService.ValidateSchedule(ciPipeline.Schedule)
+}
+
+// validateScheduledCiMaterials allows only fixed branch materials, the scheduled build is triggered on the head of the branch
+func validateScheduledCiMaterials(ciPipeline *bean.CiPipeline) error {
+	for _, ciMaterial := range ciPipeline.CiMaterial {
+		if ciMaterial.Source == nil || ciMaterial.Source.Type != constants.SOURCE_TYPE_BRANCH_FIXED {
+			errorMessage := "schedule is supported only for pipelines with fixed branch materials"
+			return util.DefaultApiError().WithHttpStatusCode(http.StatusBadRequest).WithInternalMessage(errorMessage).WithUserMessage(errorMessage)
+		}
+	}
+	return nil
+}
+
+// saveCiPipelineSchedule saves the schedule sent along with the ci pipeline, the schedule is left unchanged if not sent
+func (impl *CiPipelineConfigServiceImpl) saveCiPipelineSchedule(ciPipeline *bean.CiPipeline, userId int32) error {
+	if ciPipeline == nil || ciPipeline.Schedule == nil || ciPipeline.Id == 0 {
+		return nil
+	}
+	savedSchedule, err := impl.ciPipelineScheduleService.SaveSchedule(ciPipeline.Id, ciPipeline.Schedule, userId)
+	if err != nil {
+		impl.logger.Errorw("error in saving ci pipeline schedule", "ciPipelineId", ciPipeline.Id, "schedule", ciPipeline.Schedule, "err", err)
+		return err
+	}
+	ciPipeline.Schedule = savedSchedule
+	return nil
+}
+
 func (impl *CiPipelineConfigServiceImpl) CreateCiPipeline(createRequest *bean.CiConfigRequest) (*bean.PipelineCreateResponse, error) {
 	impl.logger.Debugw("pipeline create request received", "req", createRequest)
 
@@ -2099,6 +2175,10 @@ func (impl *CiPipelineConfigServiceImpl) DeleteCiPipeline(request *bean.CiPatchR
 		impl.logger.Errorw("error in deleting pipeline db")
 		return nil, err
 	}
+	err = impl.ciPipelineScheduleService.DeleteSchedule(pipeline.Id, request.UserId, tx)
+	if err != nil {
+		return nil, err
+	}
 
 	//delete app workflow mapping
 	appWorkflowMappings, err := impl.appWorkflowRepository.FindWFCIMappingByCIPipelineId(pipeline.Id)
@@ -17,6 +17,7 @@
 package pipeline
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/devtron-labs/common-lib/utils"
@@ -25,14 +26,20 @@ import (
 	cdWorkflowBean "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/build/artifacts/imageTagging"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
+	clusterAdapter "github.com/devtron-labs/devtron/pkg/cluster/adapter"
+	clusterBean "github.com/devtron-labs/devtron/pkg/cluster/bean"
 	repository3 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	common2 "github.com/devtron-labs/devtron/pkg/deployment/common"
 	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
+	k8sPkg "github.com/devtron-labs/devtron/pkg/k8s"
+	"github.com/devtron-labs/devtron/pkg/pipeline/executors"
 	repository2 "github.com/devtron-labs/devtron/pkg/pipeline/repository"
 	"github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus"
 	bean5 "github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus/bean"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd"
 	"github.com/devtron-labs/devtron/pkg/workflow/cd/read"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive"
+	logArchiveBean "github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
 	"github.com/devtron-labs/devtron/pkg/workflow/workflowStatusLatest"
 	"slices"
 	"strconv"
@@ -95,6 +102,10 @@ type CdHandlerImpl struct {
 	WorkflowStatusLatestService  workflowStatusLatest.WorkflowStatusLatestService
 	pipelineStageRepository      repository2.PipelineStageRepository
 	cdWorkflowRunnerReadService  read.CdWorkflowRunnerReadService
+	ciLogService                 CiLogService
+	workflowLogArchiveService    logArchive.WorkflowLogArchiveService
+	k8sCommonService             k8sPkg.K8sCommonService
+	systemWorkflowExecutor       executors.SystemWorkflowExecutor
 }
 
 func NewCdHandlerImpl(Logger *zap.SugaredLogger, userService user.UserService,
@@ -112,6 +123,10 @@ func NewCdHandlerImpl(Logger *zap.SugaredLogger, userService user.UserService,
 	WorkflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService,
 	pipelineStageRepository repository2.PipelineStageRepository,
 	cdWorkflowRunnerReadService read.CdWorkflowRunnerReadService,
+	ciLogService CiLogService,
+	workflowLogArchiveService logArchive.WorkflowLogArchiveService,
+	k8sCommonService k8sPkg.K8sCommonService,
+	systemWorkflowExecutor executors.SystemWorkflowExecutor,
 ) *CdHandlerImpl {
 	cdh := &CdHandlerImpl{
 		Logger:                       Logger,
@@ -133,6 +148,10 @@ func NewCdHandlerImpl(Logger *zap.SugaredLogger, userService user.UserService,
 		WorkflowStatusLatestService:  WorkflowStatusLatestService,
 		pipelineStageRepository:      pipelineStageRepository,
 		cdWorkflowRunnerReadService:  cdWorkflowRunnerReadService,
+		ciLogService:                 ciLogService,
+		workflowLogArchiveService:    workflowLogArchiveService,
+		k8sCommonService:             k8sCommonService,
+		systemWorkflowExecutor:       systemWorkflowExecutor,
 	}
 	config, err := types.GetCdConfig()
 	if err != nil {
@@ -163,6 +182,10 @@ func (impl *CdHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 
 	cdArtifactLocationFormat := impl.config.GetArtifactLocationFormat()
 	cdArtifactLocation := fmt.Sprintf(cdArtifactLocationFormat, savedWorkflow.CdWorkflowId, savedWorkflow.Id)
+	if impl.isFailedPodRetried(savedWorkflow, workflowName, status) {
+		impl.Logger.Infow("cd workflow pod failed, the pod is retried by the job", "wfrId", savedWorkflow.Id, "podName", podName, "message", message)
+		return savedWorkflow.Id, savedWorkflow.Status, false, message, nil
+	}
 	if impl.stateChanged(status, podStatus, message, workflowStatus.FinishedAt.Time, savedWorkflow) {
 		if !slices.Contains(cdWorkflowBean.WfrTerminalStatusList, savedWorkflow.PodStatus) {
 			savedWorkflow.Message = message
@@ -173,6 +196,7 @@ func (impl *CdHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 			impl.Logger.Warnw("cd stage already in terminal state. skipped message and finishedOn from being updated",
 				"wfId", savedWorkflow.Id, "podStatus", savedWorkflow.PodStatus, "status", savedWorkflow.Status, "message", message, "finishedOn", workflowStatus.FinishedAt.Time)
 		}
+		previousStatus := savedWorkflow.Status
 		if savedWorkflow.Status != cdWorkflowBean.WorkflowCancel {
 			savedWorkflow.Status = status
 		}
@@ -199,6 +223,23 @@ func (impl *CdHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 		if string(v1alpha1.NodeError) == savedWorkflow.Status || string(v1alpha1.NodeFailed) == savedWorkflow.Status {
 			impl.Logger.Warnw("cd stage failed for workflow", "wfId", savedWorkflow.Id)
 		}
+		uploadLogs := shouldUploadFailedWorkflowLogs(impl.config.CiCdConfig, savedWorkflow.ExecutorType, savedWorkflow.BlobStorageEnabled,
+			previousStatus, savedWorkflow.Status)
+		archiveLogs := shouldArchiveWorkflowLogs(impl.workflowLogArchiveService.IsArchiveOnCompletionEnabled(), savedWorkflow.BlobStorageEnabled,
+			previousStatus, savedWorkflow.Status)
+		if uploadLogs || archiveLogs {
+			logRequest := impl.config.GetBuildLogRequest(podName, savedWorkflow.Namespace, savedWorkflow.LogLocation)
+			go func() {
+				if uploadLogs {
+					impl.uploadFailedWorkflowLogs(savedWorkflow, logRequest)
+				}
+				if archiveLogs {
+					if err := impl.workflowLogArchiveService.ArchiveWorkflowLogs(logArchiveBean.WorkflowLogType(savedWorkflow.WorkflowType), savedWorkflow.Id); err != nil {
+						impl.Logger.Errorw("error in archiving logs of cd workflow", "wfId", savedWorkflow.Id, "err", err)
+					}
+				}
+			}()
+		}
 		return savedWorkflow.Id, savedWorkflow.Status, true, message, nil
 	}
 	return savedWorkflow.Id, status, false, message, nil
@@ -243,6 +284,57 @@ func (impl *CdHandlerImpl) extractWorkflowStatus(workflowStatus eventProcessorBe
 	return workflowStatusRes
 }
 
+// uploadFailedWorkflowLogs uploads the logs of the failed pre/post cd workflow pod, which runs in the cluster
+// of the pipeline's environment if the stage is configured to run in the environment
+func (impl *CdHandlerImpl) uploadFailedWorkflowLogs(savedWorkflow *pipelineConfig.CdWorkflowRunner, logRequest types.BuildLogRequest) {
+	var clusterConfig *k8s.ClusterConfig
+	isExt := savedWorkflow.IsExternalRun()
+	if isExt {
+		envId := savedWorkflow.CdWorkflow.Pipeline.EnvironmentId
+		env, err := impl.envRepository.FindById(envId)
+		if err != nil {
+			impl.Logger.Errorw("error in fetching environment of cd workflow", "wfId", savedWorkflow.Id, "envId", envId, "err", err)
+			return
+		}
+		clusterConfig = clusterAdapter.GetClusterBean(*env.Cluster).GetClusterConfig()
+	}
+	if err := impl.ciLogService.UploadWorkflowLogs(impl.config.BaseLogLocationPath, logRequest, clusterConfig, isExt); err != nil {
+		impl.Logger.Errorw("error in uploading logs of failed cd workflow", "wfId", savedWorkflow.Id, "err", err)
+	}
+}
+
+// isFailedPodRetried checks if the failed pod of a System executor workflow is going to be retried by the job,
+// the workflow runner is marked as failed only once the job has no retries left
+func (impl *CdHandlerImpl) isFailedPodRetried(savedWorkflow *pipelineConfig.CdWorkflowRunner, workflowName string, status string) bool {
+	if savedWorkflow.ExecutorType != cdWorkflowBean.WORKFLOW_EXECUTOR_TYPE_SYSTEM || !isFailedWorkflowStatus(status) ||
+		savedWorkflow.Status == cdWorkflowBean.WorkflowCancel {
+		return false
+	}
+	workflowStage := types.PreCdWorkflowStage
+	if savedWorkflow.WorkflowType == bean.CD_WORKFLOW_TYPE_POST {
+		workflowStage = types.PostCdWorkflowStage
+	}
+	retryPolicy, err := impl.config.GetWorkflowRetryPolicy(workflowStage)
+	if err != nil || retryPolicy.Limit == 0 {
+		return false
+	}
+	clusterId := clusterBean.DefaultClusterId
+	if savedWorkflow.Namespace != impl.config.GetDefaultNamespace() && savedWorkflow.CdWorkflow != nil && savedWorkflow.CdWorkflow.Pipeline != nil {
+		clusterId = savedWorkflow.CdWorkflow.Pipeline.Environment.ClusterId
+	}
+	restConfig, err, _ := impl.k8sCommonService.GetRestConfigByClusterId(context.Background(), clusterId)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching rest config of cluster", "wfrId", savedWorkflow.Id, "clusterId", clusterId, "err", err)
+		return false
+	}
+	isFailed, err := impl.systemWorkflowExecutor.IsWorkflowFailed(workflowName, savedWorkflow.Namespace, restConfig)
+	if err != nil {
+		impl.Logger.Errorw("error in checking the job status of cd workflow", "wfrId", savedWorkflow.Id, "err", err)
+		return false
+	}
+	return !isFailed
+}
+
 func (impl *CdHandlerImpl) stateChanged(status string, podStatus string, msg string,
 	finishedAt time.Time, savedWorkflow *pipelineConfig.CdWorkflowRunner) bool {
 	return savedWorkflow.Status != status || savedWorkflow.PodStatus != podStatus || savedWorkflow.Message != msg || savedWorkflow.FinishedOn != finishedAt
@@ -27,16 +27,22 @@ import (
 	"time"
 
 	"github.com/devtron-labs/common-lib/utils"
+	"github.com/devtron-labs/common-lib/utils/k8s"
 	"github.com/devtron-labs/common-lib/utils/workFlow"
+	"github.com/devtron-labs/devtron/internal/sql/repository/helper"
 	cdWorkflowBean "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/build/artifacts/imageTagging"
 	buildBean "github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
+	clusterAdapter "github.com/devtron-labs/devtron/pkg/cluster/adapter"
+	clusterBean "github.com/devtron-labs/devtron/pkg/cluster/bean"
 	repository2 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/adapter"
 	"github.com/devtron-labs/devtron/pkg/pipeline/constants"
 	util2 "github.com/devtron-labs/devtron/pkg/pipeline/util"
 	"github.com/devtron-labs/devtron/pkg/pipeline/workflowStatus"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive"
+	logArchiveBean "github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
 	"github.com/devtron-labs/devtron/pkg/workflow/workflowStatusLatest"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -102,6 +108,9 @@ type CiHandlerImpl struct {
 	k8sCommonService             k8sPkg.K8sCommonService
 	workFlowStageStatusService   workflowStatus.WorkFlowStageStatusService
 	workflowStatusLatestService  workflowStatusLatest.WorkflowStatusLatestService
+	ciLogService                 CiLogService
+	systemWorkflowExecutor       executors.SystemWorkflowExecutor
+	workflowLogArchiveService    logArchive.WorkflowLogArchiveService
 }
 
 func NewCiHandlerImpl(Logger *zap.SugaredLogger, ciService CiService, ciPipelineMaterialRepository pipelineConfig.CiPipelineMaterialRepository, gitSensorClient gitSensor.Client, ciWorkflowRepository pipelineConfig.CiWorkflowRepository,
@@ -110,6 +119,9 @@ func NewCiHandlerImpl(Logger *zap.SugaredLogger, ciService CiService, ciPipeline
 	imageTaggingService imageTagging.ImageTaggingService, k8sCommonService k8sPkg.K8sCommonService, appWorkflowRepository appWorkflow.AppWorkflowRepository, customTagService CustomTagService,
 	workFlowStageStatusService workflowStatus.WorkFlowStageStatusService,
 	workflowStatusLatestService workflowStatusLatest.WorkflowStatusLatestService,
+	ciLogService CiLogService,
+	workflowLogArchiveService logArchive.WorkflowLogArchiveService,
+	systemWorkflowExecutor executors.SystemWorkflowExecutor,
 ) *CiHandlerImpl {
 	cih := &CiHandlerImpl{
 		Logger:                       Logger,
@@ -133,6 +145,9 @@ func NewCiHandlerImpl(Logger *zap.SugaredLogger, ciService CiService, ciPipeline
 		k8sCommonService:             k8sCommonService,
 		workFlowStageStatusService:   workFlowStageStatusService,
 		workflowStatusLatestService:  workflowStatusLatestService,
+		ciLogService:                 ciLogService,
+		workflowLogArchiveService:    workflowLogArchiveService,
+		systemWorkflowExecutor:       systemWorkflowExecutor,
 	}
 	config, err := types.GetCiConfig()
 	if err != nil {
@@ -575,6 +590,10 @@ func (impl *CiHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 	ciArtifactLocationFormat := impl.config.GetArtifactLocationFormat()
 	ciArtifactLocation := fmt.Sprintf(ciArtifactLocationFormat, savedWorkflow.Id, savedWorkflow.Id)
 
+	if impl.isFailedPodRetried(savedWorkflow, workflowName, status) {
+		impl.Logger.Infow("ci workflow pod failed, the pod is retried by the job", "workflowId", savedWorkflow.Id, "podName", podName, "message", message)
+		return savedWorkflow.Id, false, nil
+	}
 	if impl.stateChanged(status, podStatus, message, workflowStatus.FinishedAt.Time, savedWorkflow) {
 		if !slices.Contains(cdWorkflowBean.WfrTerminalStatusList, savedWorkflow.PodStatus) {
 			savedWorkflow.Message = message
@@ -585,11 +604,12 @@ func (impl *CiHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 			impl.Logger.Warnw("cd stage already in terminal state. skipped message and finishedOn from being updated",
 				"wfId", savedWorkflow.Id, "podStatus", savedWorkflow.PodStatus, "status", savedWorkflow.Status, "message", message, "finishedOn", workflowStatus.FinishedAt.Time)
 		}
+		previousStatus := savedWorkflow.Status
 		if savedWorkflow.Status != cdWorkflowBean.WorkflowCancel {
 			savedWorkflow.Status = status
 		}
 		savedWorkflow.PodStatus = podStatus
-		if savedWorkflow.ExecutorType == cdWorkflowBean.WORKFLOW_EXECUTOR_TYPE_SYSTEM && savedWorkflow.Status == cdWorkflowBean.WorkflowCancel {
+		if !savedWorkflow.ExecutorType.IsArgoWorkflowExecutor() && savedWorkflow.Status == cdWorkflowBean.WorkflowCancel {
 			savedWorkflow.PodStatus = "Failed"
 			savedWorkflow.Message = constants.TERMINATE_MESSAGE
 		}
@@ -605,6 +625,23 @@ func (impl *CiHandlerImpl) UpdateWorkflow(workflowStatus eventProcessorBean.CiCd
 			return savedWorkflow.Id, true, err
 		}
 
+		uploadLogs := shouldUploadFailedWorkflowLogs(impl.config.CiCdConfig, savedWorkflow.ExecutorType, savedWorkflow.BlobStorageEnabled,
+			previousStatus, savedWorkflow.Status)
+		archiveLogs := shouldArchiveWorkflowLogs(impl.workflowLogArchiveService.IsArchiveOnCompletionEnabled(), savedWorkflow.BlobStorageEnabled,
+			previousStatus, savedWorkflow.Status)
+		if uploadLogs || archiveLogs {
+			logRequest := impl.config.GetBuildLogRequest(podName, savedWorkflow.Namespace, savedWorkflow.LogLocation)
+			go func() {
+				if uploadLogs {
+					impl.uploadFailedWorkflowLogs(savedWorkflow, logRequest)
+				}
+				if archiveLogs {
+					if err := impl.workflowLogArchiveService.ArchiveWorkflowLogs(logArchiveBean.CiWorkflowLog, savedWorkflow.Id); err != nil {
+						impl.Logger.Errorw("error in archiving logs of ci workflow", "workflowId", savedWorkflow.Id, "err", err)
+					}
+				}
+			}()
+		}
 		impl.sendCIFailEvent(savedWorkflow, status, message)
 		return savedWorkflow.Id, true, nil
 	}
@@ -646,6 +683,61 @@ func (impl *CiHandlerImpl) BuildPayload(ciWorkflow *pipelineConfig.CiWorkflow) *
 	return payload
 }
 
+// uploadFailedWorkflowLogs uploads the logs of the failed ci workflow pod, which runs in the cluster
+// of the selected environment for a job pipeline with an environment
+func (impl *CiHandlerImpl) uploadFailedWorkflowLogs(savedWorkflow *pipelineConfig.CiWorkflow, logRequest types.BuildLogRequest) {
+	var clusterConfig *k8s.ClusterConfig
+	isExt := savedWorkflow.IsExternalRunInJobType()
+	if isExt {
+		env, err := impl.envRepository.FindById(savedWorkflow.EnvironmentId)
+		if err != nil {
+			impl.Logger.Errorw("error in fetching environment of ci workflow", "workflowId", savedWorkflow.Id, "envId", savedWorkflow.EnvironmentId, "err", err)
+			return
+		}
+		clusterConfig = clusterAdapter.GetClusterBean(*env.Cluster).GetClusterConfig()
+	}
+	if err := impl.ciLogService.UploadWorkflowLogs(impl.config.BaseLogLocationPath, logRequest, clusterConfig, isExt); err != nil {
+		impl.Logger.Errorw("error in uploading logs of failed ci workflow", "workflowId", savedWorkflow.Id, "err", err)
+	}
+}
+
+// isFailedPodRetried checks if the failed pod of a System executor workflow is going to be retried by the job,
+// the workflow is marked as failed only once the job has no retries left
+func (impl *CiHandlerImpl) isFailedPodRetried(savedWorkflow *pipelineConfig.CiWorkflow, workflowName string, status string) bool {
+	if savedWorkflow.ExecutorType != cdWorkflowBean.WORKFLOW_EXECUTOR_TYPE_SYSTEM || !isFailedWorkflowStatus(status) ||
+		savedWorkflow.Status == cdWorkflowBean.WorkflowCancel {
+		return false
+	}
+	workflowStage := types.CiWorkflowStage
+	if savedWorkflow.CiPipeline != nil && savedWorkflow.CiPipeline.App != nil && savedWorkflow.CiPipeline.App.AppType == helper.Job {
+		workflowStage = types.JobWorkflowStage
+	}
+	retryPolicy, err := impl.config.GetWorkflowRetryPolicy(workflowStage)
+	if err != nil || retryPolicy.Limit == 0 {
+		return false
+	}
+	clusterId := clusterBean.DefaultClusterId
+	if savedWorkflow.Namespace != impl.config.GetDefaultNamespace() {
+		env, err := impl.envRepository.FindById(savedWorkflow.EnvironmentId)
+		if err != nil {
+			impl.Logger.Errorw("error in fetching environment of ci workflow", "workflowId", savedWorkflow.Id, "envId", savedWorkflow.EnvironmentId, "err", err)
+			return false
+		}
+		clusterId = env.ClusterId
+	}
+	restConfig, err, _ := impl.k8sCommonService.GetRestConfigByClusterId(context.Background(), clusterId)
+	if err != nil {
+		impl.Logger.Errorw("error in fetching rest config of cluster", "workflowId", savedWorkflow.Id, "clusterId", clusterId, "err", err)
+		return false
+	}
+	isFailed, err := impl.systemWorkflowExecutor.IsWorkflowFailed(workflowName, savedWorkflow.Namespace, restConfig)
+	if err != nil {
+		impl.Logger.Errorw("error in checking the job status of ci workflow", "workflowId", savedWorkflow.Id, "err", err)
+		return false
+	}
+	return !isFailed
+}
+
 func (impl *CiHandlerImpl) stateChanged(status string, podStatus string, msg string,
 	finishedAt time.Time, savedWorkflow *pipelineConfig.CiWorkflow) bool {
 	return savedWorkflow.Status != status || savedWorkflow.PodStatus != podStatus || savedWorkflow.Message != msg || savedWorkflow.FinishedOn != finishedAt
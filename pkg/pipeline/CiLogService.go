@@ -18,20 +18,29 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
 	"github.com/devtron-labs/common-lib/utils/k8s"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/build/pipeline/bean"
 	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	logArchiveBean "github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/helper"
 	"go.uber.org/zap"
 	"io"
 	"k8s.io/client-go/kubernetes"
 	"os"
 	"path/filepath"
+	"slices"
 )
 
 type CiLogService interface {
 	FetchRunningWorkflowLogs(ciLogRequest types.BuildLogRequest, clusterConfig *k8s.ClusterConfig, isExt bool, followLogs bool) (io.ReadCloser, func() error, error)
 	FetchLogs(baseLogLocationPathConfig string, ciLogRequest types.BuildLogRequest) (*os.File, func() error, error)
+	// UploadWorkflowLogs uploads the logs of the workflow pod to the blob storage, the pod is looked up in the
+	// given cluster if the workflow runs in an environment (isExt) and in the default cluster otherwise
+	UploadWorkflowLogs(baseLogLocationPathConfig string, logRequest types.BuildLogRequest, clusterConfig *k8s.ClusterConfig, isExt bool) error
 }
 
 type CiLogServiceImpl struct {
@@ -100,8 +109,11 @@ func (impl *CiLogServiceImpl) FetchLogs(baseLogLocationPathConfig string, logReq
 
 	_, _, err := blobStorageService.Get(request)
 	if err != nil {
-		impl.logger.Errorw("err occurred while downloading logs file", "request", request, "err", err)
-		return nil, nil, err
+		// the raw logs are deleted after archiving if configured, the logs are then extracted from the archive
+		if archiveErr := impl.fetchArchivedLogs(blobStorageService, request, tempFile); archiveErr != nil {
+			impl.logger.Errorw("err occurred while downloading logs file", "request", request, "err", err, "archiveErr", archiveErr)
+			return nil, nil, err
+		}
 	}
 
 	file, err := os.Open(tempFile)
@@ -132,3 +144,89 @@ func (impl *CiLogServiceImpl) FetchLogs(baseLogLocationPathConfig string, logReq
 	}
 	return file, cleanUpFunc, nil
 }
+
+func (impl *CiLogServiceImpl) fetchArchivedLogs(blobStorageService *blob_storage.BlobStorageServiceImpl, request *blob_storage.BlobStorageRequest, tempFile string) error {
+	archiveFile := tempFile + logArchiveBean.LogArchiveFileSuffix
+	archiveRequest := *request
+	archiveRequest.SourceKey = logArchiveBean.GetArchiveKey(request.SourceKey)
+	archiveRequest.DestinationKey = archiveFile
+	defer os.Remove(archiveFile)
+	_, _, err := blobStorageService.Get(&archiveRequest)
+	if err != nil {
+		return err
+	}
+	archive, err := os.Open(archiveFile)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	file, err := os.Create(tempFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return helper.ExtractLogArchive(archive, file)
+}
+
+func (impl *CiLogServiceImpl) UploadWorkflowLogs(baseLogLocationPathConfig string, logRequest types.BuildLogRequest, clusterConfig *k8s.ClusterConfig, isExt bool) error {
+	podLogs, cleanUp, err := impl.FetchRunningWorkflowLogs(logRequest, clusterConfig, isExt, false)
+	if err != nil {
+		impl.logger.Errorw("error in fetching workflow pod logs", "podName", logRequest.PodName, "err", err)
+		return err
+	} else if podLogs == nil {
+		return fmt.Errorf("no logs found for pod %s", logRequest.PodName)
+	}
+	defer cleanUp()
+	tempFile := filepath.Clean(filepath.Join(baseLogLocationPathConfig, logRequest.PodName+"-upload.log"))
+	file, err := os.Create(tempFile)
+	if err != nil {
+		impl.logger.Errorw("error in creating logs file", "tempFile", tempFile, "err", err)
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+		if fErr := os.Remove(tempFile); fErr != nil {
+			impl.logger.Errorw("error in cleaning up logs file", "tempFile", tempFile, "err", fErr)
+		}
+	}()
+	if _, err = io.Copy(file, podLogs); err != nil {
+		impl.logger.Errorw("error in writing pod logs to file", "podName", logRequest.PodName, "err", err)
+		return err
+	}
+	blobStorageService := blob_storage.NewBlobStorageServiceImpl(impl.logger)
+	request := &blob_storage.BlobStorageRequest{
+		StorageType:         logRequest.CloudProvider,
+		SourceKey:           tempFile,
+		DestinationKey:      logRequest.LogsFilePath,
+		AzureBlobBaseConfig: logRequest.AzureBlobConfig,
+		AwsS3BaseConfig:     logRequest.AwsS3BaseConfig,
+		GcpBlobBaseConfig:   logRequest.GcpBlobBaseConfig,
+	}
+	err = blobStorageService.PutWithCommand(request)
+	if err != nil {
+		impl.logger.Errorw("error in uploading workflow logs", "podName", logRequest.PodName, "logsFilePath", logRequest.LogsFilePath, "err", err)
+		return err
+	}
+	return nil
+}
+
+// shouldUploadFailedWorkflowLogs checks if the logs of the failed workflow pod are to be uploaded by the orchestrator.
+// With the system and tekton executors, the runner may not upload the logs if the pod fails abruptly (e.g. OOMKilled or evicted),
+// unlike argo workflows which archives the logs of the failed pods as well.
+func shouldUploadFailedWorkflowLogs(config *types.CiCdConfig, executorType cdWorkflow.WorkflowExecutorType, blobStorageEnabled bool, previousStatus, status string) bool {
+	if !config.UploadLogsOnWorkflowFailure || !blobStorageEnabled || executorType.IsArgoWorkflowExecutor() {
+		return false
+	}
+	return isFailedWorkflowStatus(status) && !isFailedWorkflowStatus(previousStatus)
+}
+
+func isFailedWorkflowStatus(workflowStatus string) bool {
+	return workflowStatus == string(v1alpha1.NodeFailed) || workflowStatus == string(v1alpha1.NodeError)
+}
+
+// shouldArchiveWorkflowLogs checks if the workflow is completed with the status update, the logs are archived once
+func shouldArchiveWorkflowLogs(archiveEnabled, blobStorageEnabled bool, previousStatus, status string) bool {
+	return archiveEnabled && blobStorageEnabled &&
+		slices.Contains(logArchiveBean.TerminalWorkflowStatuses, status) &&
+		!slices.Contains(logArchiveBean.TerminalWorkflowStatuses, previousStatus)
+}
@@ -30,6 +30,7 @@ import (
 	client "github.com/devtron-labs/devtron/api/helm-app/service"
 	helmBean "github.com/devtron-labs/devtron/api/helm-app/service/bean"
 	read4 "github.com/devtron-labs/devtron/api/helm-app/service/read"
+	"github.com/devtron-labs/devtron/cel"
 	"github.com/devtron-labs/devtron/client/argocdServer"
 	"github.com/devtron-labs/devtron/client/fluxcd"
 	"github.com/devtron-labs/devtron/internal/constants"
@@ -208,6 +209,7 @@ type CdPipelineConfigServiceImpl struct {
 	helmAppReadService                read4.HelmAppReadService
 	K8sUtil                           *k8s.K8sServiceImpl
 	fluxCDDeploymentService           fluxcd.DeploymentService
+	celEvaluatorService               cel.EvaluatorService
 }
 
 func NewCdPipelineConfigServiceImpl(logger *zap.SugaredLogger, pipelineRepository pipelineConfig.PipelineRepository,
@@ -243,7 +245,8 @@ func NewCdPipelineConfigServiceImpl(logger *zap.SugaredLogger, pipelineRepositor
 	chartReadService read3.ChartReadService,
 	helmAppReadService read4.HelmAppReadService,
 	K8sUtil *k8s.K8sServiceImpl,
-	fluxCDDeploymentService fluxcd.DeploymentService) *CdPipelineConfigServiceImpl {
+	fluxCDDeploymentService fluxcd.DeploymentService,
+	celEvaluatorService cel.EvaluatorService) *CdPipelineConfigServiceImpl {
 	return &CdPipelineConfigServiceImpl{
 		logger:                            logger,
 		pipelineRepository:                pipelineRepository,
@@ -291,6 +294,7 @@ func NewCdPipelineConfigServiceImpl(logger *zap.SugaredLogger, pipelineRepositor
 		helmAppReadService:                helmAppReadService,
 		K8sUtil:                           K8sUtil,
 		fluxCDDeploymentService:           fluxCDDeploymentService,
+		celEvaluatorService:               celEvaluatorService,
 	}
 }
 
@@ -436,6 +440,7 @@ func (impl *CdPipelineConfigServiceImpl) GetCdPipelineById(pipelineId int) (cdPi
 		PostStageConfigMapSecretNames: postStageConfigmapSecrets,
 		RunPreStageInEnv:              dbPipeline.RunPreStageInEnv,
 		RunPostStageInEnv:             dbPipeline.RunPostStageInEnv,
+		PromotionCondition:            dbPipeline.PromotionCondition,
 		CdArgoSetup:                   environment.Cluster.CdArgoSetup,
 		ParentPipelineId:              appWorkflowMapping.ParentId,
 		ParentPipelineType:            appWorkflowMapping.ParentType,
@@ -1487,7 +1492,11 @@ func (impl *CdPipelineConfigServiceImpl) PatchCdPipelines(cdPipelines *bean.CDPa
 	case bean.CD_CREATE:
 		return impl.CreateCdPipelines(pipelineRequest, ctx)
 	case bean.CD_UPDATE:
-		err := impl.updateCdPipeline(ctx, cdPipelines.Pipeline, cdPipelines.UserId)
+		err := impl.validatePromotionCondition(cdPipelines.Pipeline)
+		if err != nil {
+			return nil, err
+		}
+		err = impl.updateCdPipeline(ctx, cdPipelines.Pipeline, cdPipelines.UserId)
 		return pipelineRequest, err
 	case bean.CD_DELETE:
 		pipeline, err := impl.pipelineRepository.FindById(cdPipelines.Pipeline.Id)
@@ -2029,6 +2038,7 @@ func (impl *CdPipelineConfigServiceImpl) GetCdPipelinesForApp(appId int) (cdPipe
 			PostStageConfigMapSecretNames: dbPipeline.PostStageConfigMapSecretNames,
 			RunPreStageInEnv:              dbPipeline.RunPreStageInEnv,
 			RunPostStageInEnv:             dbPipeline.RunPostStageInEnv,
+			PromotionCondition:            dbPipeline.PromotionCondition,
 			DeploymentAppType:             dbPipeline.DeploymentAppType,
 			ReleaseMode:                   dbPipeline.GetReleaseMode(),
 			DeploymentAppCreated:          dbPipeline.DeploymentAppCreated,
@@ -2177,6 +2187,7 @@ func (impl *CdPipelineConfigServiceImpl) GetCdPipelinesByEnvironment(request res
 			PostStageConfigMapSecretNames: dbPipeline.PostStageConfigMapSecretNames,
 			RunPreStageInEnv:              dbPipeline.RunPreStageInEnv,
 			RunPostStageInEnv:             dbPipeline.RunPostStageInEnv,
+			PromotionCondition:            dbPipeline.PromotionCondition,
 			DeploymentAppType:             dbPipeline.DeploymentAppType,
 			ReleaseMode:                   dbPipeline.GetReleaseMode(),
 			ParentPipelineType:            pipelineWorkflowMapping[dbPipeline.Id].ParentType,
@@ -2558,6 +2569,10 @@ func (impl *CdPipelineConfigServiceImpl) validateCDPipelineRequest(pipelineCreat
 			}
 			return false, err
 		}
+		err := impl.validatePromotionCondition(pipeline)
+		if err != nil {
+			return false, err
+		}
 
 	}
 
@@ -2565,6 +2580,29 @@ func (impl *CdPipelineConfigServiceImpl) validateCDPipelineRequest(pipelineCreat
 
 }
 
+// validatePromotionCondition checks that the promotion condition of the pipeline, if any, is a boolean CEL expression
+func (impl *CdPipelineConfigServiceImpl) validatePromotionCondition(pipeline *bean.CDPipelineConfigObject) error {
+	if len(pipeline.PromotionCondition) == 0 {
+		return nil
+	}
+	response, err := impl.celEvaluatorService.ValidateExpression(cel.ExpressionRequest{Expression: pipeline.PromotionCondition})
+	if err != nil {
+		impl.logger.Errorw("error in validating promotion condition", "promotionCondition", pipeline.PromotionCondition, "err", err)
+		return err
+	}
+	var userMessage string
+	if !response.Valid {
+		issue := response.Issues[0]
+		userMessage = fmt.Sprintf("invalid promotion condition at line %d, column %d: %s", issue.Line, issue.Column, issue.Message)
+	} else if response.ResultType != "bool" {
+		userMessage = fmt.Sprintf("promotion condition must evaluate to bool, found %s", response.ResultType)
+	}
+	if len(userMessage) > 0 {
+		return util.NewApiError(http.StatusBadRequest, userMessage, userMessage)
+	}
+	return nil
+}
+
 func (impl *CdPipelineConfigServiceImpl) RegisterInACD(ctx context.Context, chartGitAttr *commonBean.ChartGitAttribute, userId int32) error {
 	err := impl.argoClientWrapperService.RegisterGitOpsRepoInArgoWithRetry(ctx, chartGitAttr.RepoUrl, chartGitAttr.TargetRevision, userId)
 	if err != nil {
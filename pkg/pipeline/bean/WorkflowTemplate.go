@@ -49,6 +49,13 @@ type WorkflowTemplate struct {
 	TerminationGracePeriod int
 	WorkflowType           string
 	DevtronInstanceUID     string
+	// PodLabels and PodAnnotations are added to the workflow pod, as configured in the infra profile
+	PodLabels      map[string]string
+	PodAnnotations map[string]string
+	// RetryLimit is the number of times the failed workflow pod is retried
+	RetryLimit int32
+	// Sidecars are the containers running alongside the main container for the lifetime of the workflow pod
+	Sidecars []v1.Container
 }
 
 const (
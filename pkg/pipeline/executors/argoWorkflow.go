@@ -120,6 +120,7 @@ func (impl *ArgoWorkflowExecutorImpl) ExecuteWorkflow(workflowTemplate bean.Work
 		},
 	}
 	impl.updateBlobStorageConfig(workflowTemplate, &ciCdTemplate)
+	impl.updatePodConfig(workflowTemplate, &ciCdTemplate)
 	templates = append(templates, ciCdTemplate)
 
 	objectMeta := workflowTemplate.CreateObjectMetadata()
@@ -128,10 +129,11 @@ func (impl *ArgoWorkflowExecutorImpl) ExecuteWorkflow(workflowTemplate bean.Work
 		ciCdWorkflow = argoWfApiV1.Workflow{
 			ObjectMeta: *objectMeta,
 			Spec: argoWfApiV1.WorkflowSpec{
-				ServiceAccountName: workflowTemplate.ServiceAccountName,
-				NodeSelector:       workflowTemplate.NodeSelector,
-				Tolerations:        workflowTemplate.Tolerations,
-				Entrypoint:         entryPoint,
+				ServiceAccountName:   workflowTemplate.ServiceAccountName,
+				PodPriorityClassName: workflowTemplate.PriorityClassName,
+				NodeSelector:         workflowTemplate.NodeSelector,
+				Tolerations:          workflowTemplate.Tolerations,
+				Entrypoint:           entryPoint,
 				TTLStrategy: &argoWfApiV1.TTLStrategy{
 					SecondsAfterCompletion: workflowTemplate.TTLValue,
 				},
@@ -144,6 +146,13 @@ func (impl *ArgoWorkflowExecutorImpl) ExecuteWorkflow(workflowTemplate bean.Work
 		}
 	)
 
+	if len(workflowTemplate.PodLabels) != 0 || len(workflowTemplate.PodAnnotations) != 0 {
+		ciCdWorkflow.Spec.PodMetadata = &argoWfApiV1.Metadata{
+			Labels:      workflowTemplate.PodLabels,
+			Annotations: workflowTemplate.PodAnnotations,
+		}
+	}
+
 	wfTemplate, err := json.Marshal(ciCdWorkflow)
 	if err != nil {
 		impl.logger.Errorw("error occurred while marshalling json", "err", err)
@@ -270,6 +279,22 @@ func (impl *ArgoWorkflowExecutorImpl) updateBlobStorageConfig(workflowTemplate b
 	}
 }
 
+// updatePodConfig sets the retry strategy, init containers and sidecars of the workflow template
+func (impl *ArgoWorkflowExecutorImpl) updatePodConfig(workflowTemplate bean.WorkflowTemplate, ciCdTemplate *argoWfApiV1.Template) {
+	if workflowTemplate.RetryLimit > 0 {
+		retryLimit := intstr.FromInt32(workflowTemplate.RetryLimit)
+		ciCdTemplate.RetryStrategy = &argoWfApiV1.RetryStrategy{
+			Limit: &retryLimit,
+		}
+	}
+	for _, initContainer := range workflowTemplate.InitContainers {
+		ciCdTemplate.InitContainers = append(ciCdTemplate.InitContainers, argoWfApiV1.UserContainer{Container: initContainer})
+	}
+	for _, sidecar := range workflowTemplate.Sidecars {
+		ciCdTemplate.Sidecars = append(ciCdTemplate.Sidecars, argoWfApiV1.UserContainer{Container: sidecar})
+	}
+}
+
 func (impl *ArgoWorkflowExecutorImpl) getArgoTemplates(configMaps []apiBean.ConfigSecretMap, secrets []apiBean.ConfigSecretMap, isCi bool) ([]argoWfApiV1.Template, error) {
 	var templates []argoWfApiV1.Template
 	var steps []argoWfApiV1.ParallelSteps
@@ -19,6 +19,7 @@ package executors
 import (
 	k8sApiV1 "k8s.io/api/core/v1"
 	k8sMetaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
@@ -43,4 +44,141 @@ var SecretKeySelector = &k8sApiV1.SecretKeySelector{Key: CRED_SECRET_KEY, LocalO
 const (
 	WorkflowJobBackoffLimit = 0
 	WorkflowJobFinalizer    = "foregroundDeletion"
+	// jobNameLabel is set by the job controller on the pods of a job
+	jobNameLabel = "job-name"
+	// NativeSidecarMinK8sVersion is the version from which the init containers with restart policy Always
+	// are run as sidecars by default
+	NativeSidecarMinK8sVersion = "v1.29.0"
 )
+
+const (
+	TektonApiVersion           = "tekton.dev/v1"
+	TektonPipelineRunKind      = "PipelineRun"
+	TektonTaskRunKind          = "TaskRun"
+	TektonWorkflowTaskName     = "workflow"
+	TektonInitStepNamePrefix   = "init-"
+	TektonPipelineRunPending   = "PipelineRunPending"
+	TektonPipelineRunCancelled = "Cancelled"
+	TektonConditionSucceeded   = "Succeeded"
+)
+
+var (
+	TektonPipelineRunGVR = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}
+	TektonTaskRunGVR     = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "taskruns"}
+	ConfigMapGVR         = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	SecretGVR            = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+// tektonErrorReasons are the reasons of the failed PipelineRun for which the workflow could not be started at all
+var tektonErrorReasons = map[string]bool{
+	"PipelineValidationFailed":        true,
+	"CouldntGetPipeline":              true,
+	"CouldntGetTask":                  true,
+	"PipelineInvalidGraph":            true,
+	"ParameterMissing":                true,
+	"ParameterTypeMismatch":           true,
+	"CreateRunFailed":                 true,
+	"InvalidWorkspaceBindings":        true,
+	"InvalidServiceAccountMapping":    true,
+	"PipelineRunCouldntCancel":        true,
+	"ObjectParameterMissKeys":         true,
+	"RequiredWorkspaceMarkedOptional": true,
+}
+
+// tektonCancelledReasons are the reasons of the PipelineRun/TaskRun terminated on request
+var tektonCancelledReasons = map[string]bool{
+	"Cancelled":           true,
+	"CancelledRunFinally": true,
+	"StoppedRunFinally":   true,
+	"TaskRunCancelled":    true,
+}
+
+// tekton resources are not vendored, only the fields used by the executor are declared below
+
+type tektonPipelineRunSpec struct {
+	PipelineSpec    tektonPipelineSpec     `json:"pipelineSpec"`
+	TaskRunTemplate *tektonTaskRunTemplate `json:"taskRunTemplate,omitempty"`
+	Timeouts        *tektonTimeouts        `json:"timeouts,omitempty"`
+	Status          string                 `json:"status,omitempty"`
+}
+
+type tektonPipelineSpec struct {
+	Tasks []tektonPipelineTask `json:"tasks"`
+}
+
+type tektonPipelineTask struct {
+	Name     string         `json:"name"`
+	Retries  int32          `json:"retries,omitempty"`
+	TaskSpec tektonTaskSpec `json:"taskSpec"`
+}
+
+type tektonTaskSpec struct {
+	Steps    []tektonStep `json:"steps"`
+	Sidecars []tektonStep `json:"sidecars,omitempty"`
+}
+
+type tektonStep struct {
+	Name             string                        `json:"name"`
+	Image            string                        `json:"image,omitempty"`
+	Command          []string                      `json:"command,omitempty"`
+	Args             []string                      `json:"args,omitempty"`
+	WorkingDir       string                        `json:"workingDir,omitempty"`
+	Env              []k8sApiV1.EnvVar             `json:"env,omitempty"`
+	EnvFrom          []k8sApiV1.EnvFromSource      `json:"envFrom,omitempty"`
+	VolumeMounts     []k8sApiV1.VolumeMount        `json:"volumeMounts,omitempty"`
+	ComputeResources k8sApiV1.ResourceRequirements `json:"computeResources,omitempty"`
+	SecurityContext  *k8sApiV1.SecurityContext     `json:"securityContext,omitempty"`
+	ImagePullPolicy  k8sApiV1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+}
+
+type tektonTaskRunTemplate struct {
+	ServiceAccountName string             `json:"serviceAccountName,omitempty"`
+	PodTemplate        *tektonPodTemplate `json:"podTemplate,omitempty"`
+}
+
+type tektonPodTemplate struct {
+	NodeSelector      map[string]string                   `json:"nodeSelector,omitempty"`
+	Tolerations       []k8sApiV1.Toleration               `json:"tolerations,omitempty"`
+	Affinity          *k8sApiV1.Affinity                  `json:"affinity,omitempty"`
+	SecurityContext   *k8sApiV1.PodSecurityContext        `json:"securityContext,omitempty"`
+	Volumes           []k8sApiV1.Volume                   `json:"volumes,omitempty"`
+	PriorityClassName *string                             `json:"priorityClassName,omitempty"`
+	ImagePullSecrets  []k8sApiV1.LocalObjectReference     `json:"imagePullSecrets,omitempty"`
+	HostAliases       []k8sApiV1.HostAlias                `json:"hostAliases,omitempty"`
+	DNSPolicy         *k8sApiV1.DNSPolicy                 `json:"dnsPolicy,omitempty"`
+	DNSConfig         *k8sApiV1.PodDNSConfig              `json:"dnsConfig,omitempty"`
+	SchedulerName     string                              `json:"schedulerName,omitempty"`
+	TopologySpread    []k8sApiV1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+type tektonTimeouts struct {
+	Pipeline *k8sMetaV1.Duration `json:"pipeline,omitempty"`
+}
+
+type tektonRunStatus struct {
+	Conditions      []tektonCondition      `json:"conditions,omitempty"`
+	PodName         string                 `json:"podName,omitempty"`
+	Steps           []tektonStepState      `json:"steps,omitempty"`
+	ChildReferences []tektonChildReference `json:"childReferences,omitempty"`
+}
+
+type tektonCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type tektonStepState struct {
+	Name       string                             `json:"name,omitempty"`
+	Container  string                             `json:"container,omitempty"`
+	Waiting    *k8sApiV1.ContainerStateWaiting    `json:"waiting,omitempty"`
+	Running    *k8sApiV1.ContainerStateRunning    `json:"running,omitempty"`
+	Terminated *k8sApiV1.ContainerStateTerminated `json:"terminated,omitempty"`
+}
+
+type tektonChildReference struct {
+	Kind             string `json:"kind,omitempty"`
+	Name             string `json:"name,omitempty"`
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+}
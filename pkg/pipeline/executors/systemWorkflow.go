@@ -32,12 +32,18 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/pointer"
+	"maps"
 )
 
 type SystemWorkflowExecutor interface {
 	WorkflowExecutor
+	// IsWorkflowFailed checks if the job of the workflow has failed or has no retries left, i.e. the failed pod is not going to be retried.
+	// A deleted job is reported as failed.
+	IsWorkflowFailed(workflowName string, namespace string, clusterConfig *rest.Config) (bool, error)
 }
 
 type SystemWorkflowExecutorImpl struct {
@@ -58,6 +64,11 @@ func (impl *SystemWorkflowExecutorImpl) ExecuteWorkflow(workflowTemplate bean.Wo
 		impl.logger.Errorw("error occurred while creating k8s client", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
 		return nil, err
 	}
+	err = impl.validateNativeSidecarSupport(clientset, workflowTemplate)
+	if err != nil {
+		impl.logger.Errorw("sidecars are not supported on the cluster", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return nil, err
+	}
 	ctx := context.Background()
 	createdJob, err := clientset.BatchV1().Jobs(workflowTemplate.Namespace).Create(ctx, jobTemplate, v12.CreateOptions{})
 	if err != nil {
@@ -154,6 +165,32 @@ func (impl *SystemWorkflowExecutorImpl) GetWorkflow(workflowName string, namespa
 	return templatesList, nil
 }
 
+func (impl *SystemWorkflowExecutorImpl) IsWorkflowFailed(workflowName string, namespace string, clusterConfig *rest.Config) (bool, error) {
+	_, clientset, err := impl.k8sUtil.GetK8sConfigAndClientsByRestConfig(clusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s client", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return false, err
+	}
+	job, err := clientset.BatchV1().Jobs(namespace).Get(context.Background(), workflowName, v12.GetOptions{})
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		impl.logger.Errorw("error occurred while fetching workflow job", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return false, err
+	}
+	if isJobFailed(job) {
+		return true, nil
+	}
+	// the event of the last failed pod can arrive before the job controller marks the job as failed,
+	// so the failed pods of the job are counted as well
+	pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), v12.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", jobNameLabel, workflowName)})
+	if err != nil {
+		impl.logger.Errorw("error occurred while fetching workflow job pods", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return false, err
+	}
+	return hasJobExhaustedRetries(job, pods.Items), nil
+}
+
 // This will work for
 
 func (impl *SystemWorkflowExecutorImpl) GetWorkflowStatus(workflowName string, namespace string, clusterConfig *rest.Config) (*types2.WorkflowStatus, error) {
@@ -186,6 +223,7 @@ func (impl *SystemWorkflowExecutorImpl) getJobTemplate(workflowTemplate bean.Wor
 	//setting TerminationGracePeriodSeconds in PodSpec
 	//which ensures Pod has enough time to execute cleanup on SIGTERM event
 	workflowTemplate.PodSpec.TerminationGracePeriodSeconds = pointer.Int64(int64(workflowTemplate.TerminationGracePeriod))
+	workflowTemplate.PodSpec.InitContainers = getInitContainersForSystemExecutor(workflowTemplate)
 	workflowJob := v1.Job{
 		TypeMeta: v12.TypeMeta{
 			Kind:       k8sCommonBean.JobKind,
@@ -198,12 +236,13 @@ func (impl *SystemWorkflowExecutorImpl) getJobTemplate(workflowTemplate bean.Wor
 			Finalizers: []string{WorkflowJobFinalizer},
 		},
 		Spec: v1.JobSpec{
-			BackoffLimit:            pointer.Int32Ptr(WorkflowJobBackoffLimit),
-			ActiveDeadlineSeconds:   workflowTemplate.ActiveDeadlineSeconds,
+			BackoffLimit:            pointer.Int32Ptr(getBackoffLimitForSystemExecutor(workflowTemplate)),
+			ActiveDeadlineSeconds:   getActiveDeadlineSecondsForSystemExecutor(workflowTemplate),
 			TTLSecondsAfterFinished: workflowTemplate.TTLValue,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: v12.ObjectMeta{
-					Labels: workflowLabels,
+					Labels:      getPodLabelsForSystemExecutor(workflowTemplate, workflowLabels),
+					Annotations: workflowTemplate.PodAnnotations,
 				},
 				Spec: workflowTemplate.PodSpec,
 			},
@@ -279,3 +318,102 @@ func (impl *SystemWorkflowExecutorImpl) createCmAndSecrets(template bean.Workflo
 	}
 	return nil
 }
+
+// getPodLabelsForSystemExecutor adds the pod labels configured in the infra profile to the workflow labels,
+// the workflow labels are not overridden as they are used to track the workflow
+func getPodLabelsForSystemExecutor(workflowTemplate bean.WorkflowTemplate, workflowLabels map[string]string) map[string]string {
+	if len(workflowTemplate.PodLabels) == 0 {
+		return workflowLabels
+	}
+	podLabels := maps.Clone(workflowTemplate.PodLabels)
+	maps.Copy(podLabels, workflowLabels)
+	return podLabels
+}
+
+// getBackoffLimitForSystemExecutor returns the number of retries of the workflow pod before the job is marked as failed
+func getBackoffLimitForSystemExecutor(workflowTemplate bean.WorkflowTemplate) int32 {
+	if workflowTemplate.RetryLimit > 0 {
+		return workflowTemplate.RetryLimit
+	}
+	return WorkflowJobBackoffLimit
+}
+
+// getActiveDeadlineSecondsForSystemExecutor returns the deadline of the job.
+// The deadline of the workflow pod (infra timeout) applies on each attempt,
+// so the job deadline is extended to allow all the retries to complete.
+func getActiveDeadlineSecondsForSystemExecutor(workflowTemplate bean.WorkflowTemplate) *int64 {
+	if workflowTemplate.ActiveDeadlineSeconds == nil || workflowTemplate.RetryLimit <= 0 {
+		return workflowTemplate.ActiveDeadlineSeconds
+	}
+	activeDeadlineSeconds := *workflowTemplate.ActiveDeadlineSeconds * int64(workflowTemplate.RetryLimit+1)
+	return &activeDeadlineSeconds
+}
+
+// getInitContainersForSystemExecutor adds the sidecars as native sidecars (init containers with restart policy Always),
+// so that they do not block the job completion once the main container exits
+func getInitContainersForSystemExecutor(workflowTemplate bean.WorkflowTemplate) []corev1.Container {
+	if len(workflowTemplate.Sidecars) == 0 {
+		return workflowTemplate.InitContainers
+	}
+	initContainers := make([]corev1.Container, 0, len(workflowTemplate.InitContainers)+len(workflowTemplate.Sidecars))
+	initContainers = append(initContainers, workflowTemplate.InitContainers...)
+	restartPolicyAlways := corev1.ContainerRestartPolicyAlways
+	for _, sidecar := range workflowTemplate.Sidecars {
+		sidecar.RestartPolicy = &restartPolicyAlways
+		initContainers = append(initContainers, sidecar)
+	}
+	return initContainers
+}
+
+// hasJobExhaustedRetries checks if the failed attempts of the job are more than its backoff limit
+func hasJobExhaustedRetries(job *v1.Job, pods []corev1.Pod) bool {
+	failedAttempts := job.Status.Failed
+	failedPods := int32(0)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodFailed {
+			failedPods++
+		}
+	}
+	if failedPods > failedAttempts {
+		failedAttempts = failedPods
+	}
+	backoffLimit := int32(WorkflowJobBackoffLimit)
+	if job.Spec.BackoffLimit != nil {
+		backoffLimit = *job.Spec.BackoffLimit
+	}
+	return failedAttempts > backoffLimit
+}
+
+func isJobFailed(job *v1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == v1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNativeSidecarSupport checks the server version when sidecars are configured, see getInitContainersForSystemExecutor.
+// On older versions the sidecars would run as init containers and block the workflow pod from starting.
+func (impl *SystemWorkflowExecutorImpl) validateNativeSidecarSupport(clientset kubernetes.Interface, workflowTemplate bean.WorkflowTemplate) error {
+	if len(workflowTemplate.Sidecars) == 0 {
+		return nil
+	}
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		impl.logger.Errorw("error in fetching k8s server version", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return err
+	}
+	return validateNativeSidecarVersion(serverVersion.GitVersion)
+}
+
+func validateNativeSidecarVersion(serverVersion string) error {
+	parsedVersion, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		return err
+	}
+	if parsedVersion.LessThan(version.MustParseGeneric(NativeSidecarMinK8sVersion)) {
+		return fmt.Errorf("sidecar containers of the workflow require kubernetes %s or above with the System executor, cluster version is %s", NativeSidecarMinK8sVersion, serverVersion)
+	}
+	return nil
+}
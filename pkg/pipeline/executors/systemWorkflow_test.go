@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executors
+
+import (
+	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"testing"
+)
+
+func TestSystemExecutorPodConfigurations(t *testing.T) {
+	t.Run("job deadline is extended for retries", func(t *testing.T) {
+		deadline := int64(600)
+		workflowTemplate := bean.WorkflowTemplate{RetryLimit: 2}
+		workflowTemplate.ActiveDeadlineSeconds = &deadline
+		assert.Equal(t, int64(1800), *getActiveDeadlineSecondsForSystemExecutor(workflowTemplate))
+		assert.Equal(t, int32(2), getBackoffLimitForSystemExecutor(workflowTemplate))
+	})
+	t.Run("job deadline without retries", func(t *testing.T) {
+		deadline := int64(600)
+		workflowTemplate := bean.WorkflowTemplate{}
+		workflowTemplate.ActiveDeadlineSeconds = &deadline
+		assert.Equal(t, int64(600), *getActiveDeadlineSecondsForSystemExecutor(workflowTemplate))
+		assert.Equal(t, int32(WorkflowJobBackoffLimit), getBackoffLimitForSystemExecutor(workflowTemplate))
+	})
+	t.Run("sidecars are added as native sidecars", func(t *testing.T) {
+		workflowTemplate := bean.WorkflowTemplate{Sidecars: []corev1.Container{{Name: "sidecar"}}}
+		workflowTemplate.InitContainers = []corev1.Container{{Name: "init"}}
+		initContainers := getInitContainersForSystemExecutor(workflowTemplate)
+		assert.Len(t, initContainers, 2)
+		assert.Nil(t, initContainers[0].RestartPolicy)
+		assert.Equal(t, corev1.ContainerRestartPolicyAlways, *initContainers[1].RestartPolicy)
+		assert.Nil(t, workflowTemplate.Sidecars[0].RestartPolicy)
+	})
+	t.Run("native sidecars require kubernetes 1.29", func(t *testing.T) {
+		assert.Error(t, validateNativeSidecarVersion("v1.28.5"))
+		assert.Error(t, validateNativeSidecarVersion("v1.28.9-eks-a5ec690"))
+		assert.NoError(t, validateNativeSidecarVersion("v1.29.0"))
+		assert.NoError(t, validateNativeSidecarVersion("v1.30.1-eks-1de2ab1"))
+		assert.Error(t, validateNativeSidecarVersion("unknown"))
+	})
+}
+
+func TestIsJobFailed(t *testing.T) {
+	t.Run("job with a failed pod is retried", func(t *testing.T) {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Failed: 1, Active: 1}}
+		assert.False(t, isJobFailed(job))
+	})
+	t.Run("job reached the backoff limit", func(t *testing.T) {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Failed: 3, Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"},
+		}}}
+		assert.True(t, isJobFailed(job))
+	})
+	t.Run("completed job", func(t *testing.T) {
+		job := &batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1, Conditions: []batchv1.JobCondition{
+			{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+		}}}
+		assert.False(t, isJobFailed(job))
+	})
+}
+
+func TestHasJobExhaustedRetries(t *testing.T) {
+	failedPod := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}}
+	runningPod := corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	t.Run("failed pod is retried", func(t *testing.T) {
+		job := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: pointer.Int32(2)}, Status: batchv1.JobStatus{Failed: 1, Active: 1}}
+		assert.False(t, hasJobExhaustedRetries(job, []corev1.Pod{failedPod, runningPod}))
+	})
+	t.Run("last attempt failed before the job status is updated", func(t *testing.T) {
+		job := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: pointer.Int32(2)}, Status: batchv1.JobStatus{Failed: 2}}
+		assert.True(t, hasJobExhaustedRetries(job, []corev1.Pod{failedPod, failedPod, failedPod}))
+	})
+	t.Run("failed attempts counted by the job status", func(t *testing.T) {
+		job := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: pointer.Int32(1)}, Status: batchv1.JobStatus{Failed: 2}}
+		assert.True(t, hasJobExhaustedRetries(job, nil))
+	})
+	t.Run("job without retries", func(t *testing.T) {
+		job := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: pointer.Int32(WorkflowJobBackoffLimit)}}
+		assert.True(t, hasJobExhaustedRetries(job, []corev1.Pod{failedPod}))
+	})
+}
+
+func TestGetWorkflowRetryPolicy(t *testing.T) {
+	config := &types.CiCdConfig{WorkflowRetryPolicyJson: `{"CI":{"limit":2},"POST_CD":{"limit":1}}`}
+	t.Run("pod retries without workflow re-triggers", func(t *testing.T) {
+		retryPolicy, err := config.GetWorkflowRetryPolicy(types.CiWorkflowStage)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), retryPolicy.Limit)
+		retryPolicy, err = config.GetWorkflowRetryPolicy(types.PreCdWorkflowStage)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), retryPolicy.Limit)
+	})
+	t.Run("pod retries are not stacked with workflow re-triggers", func(t *testing.T) {
+		reTriggerConfig := *config
+		reTriggerConfig.MaxCiWorkflowRetries = 1
+		retryPolicy, err := reTriggerConfig.GetWorkflowRetryPolicy(types.CiWorkflowStage)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), retryPolicy.Limit)
+		retryPolicy, err = reTriggerConfig.GetWorkflowRetryPolicy(types.PostCdWorkflowStage)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), retryPolicy.Limit)
+	})
+}
@@ -0,0 +1,429 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executors
+
+import (
+	"context"
+	"fmt"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/devtron-labs/common-lib/utils/k8s"
+	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/executors/adapter"
+	types2 "github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+	"time"
+)
+
+type TektonWorkflowExecutor interface {
+	WorkflowExecutor
+	// GetWorkflowStatusByNamePrefix returns the status of the latest pipeline run created with the workflow name prefix,
+	// used until the generated name of the pipeline run is saved with the workflow
+	GetWorkflowStatusByNamePrefix(workflowNamePrefix string, namespace string, clusterConfig *rest.Config) (*types2.WorkflowStatus, error)
+}
+
+type TektonWorkflowExecutorImpl struct {
+	logger  *zap.SugaredLogger
+	k8sUtil *k8s.K8sServiceImpl
+	// dynamicClientProvider is overridden in tests to use the fake dynamic client
+	dynamicClientProvider func(clusterConfig *rest.Config) (dynamic.Interface, error)
+}
+
+func NewTektonWorkflowExecutorImpl(logger *zap.SugaredLogger, k8sUtil *k8s.K8sServiceImpl) *TektonWorkflowExecutorImpl {
+	impl := &TektonWorkflowExecutorImpl{logger: logger, k8sUtil: k8sUtil}
+	impl.dynamicClientProvider = impl.getDynamicClient
+	return impl
+}
+
+func (impl *TektonWorkflowExecutorImpl) getDynamicClient(clusterConfig *rest.Config) (dynamic.Interface, error) {
+	httpClient, _, err := impl.k8sUtil.GetK8sConfigAndClientsByRestConfig(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return impl.k8sUtil.GetK8sDynamicClient(clusterConfig, httpClient)
+}
+
+func (impl *TektonWorkflowExecutorImpl) ExecuteWorkflow(workflowTemplate bean.WorkflowTemplate) (*unstructured.UnstructuredList, error) {
+	templatesList := &unstructured.UnstructuredList{}
+	//create pipeline run in pending state, it is started once the cm and secrets are created
+	pipelineRun, err := getPipelineRunTemplate(workflowTemplate)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating pipeline run template", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return nil, err
+	}
+	dynamicClient, err := impl.dynamicClientProvider(workflowTemplate.ClusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s dynamic client", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return nil, err
+	}
+	ctx := context.Background()
+	pipelineRunClient := dynamicClient.Resource(TektonPipelineRunGVR).Namespace(workflowTemplate.Namespace)
+	createdPipelineRun, err := pipelineRunClient.Create(ctx, pipelineRun, v12.CreateOptions{})
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating tekton pipeline run", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return nil, err
+	}
+
+	//create cm and secrets with owner reference
+	err = impl.createCmAndSecrets(ctx, dynamicClient, workflowTemplate, createdPipelineRun, templatesList)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating cm and secret", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return nil, err
+	}
+
+	//change pipeline run state to running
+	startedPipelineRun, err := pipelineRunClient.Patch(ctx, createdPipelineRun.GetName(), types.MergePatchType, []byte(`{"spec":{"status":null}}`), v12.PatchOptions{})
+	if err != nil {
+		impl.logger.Errorw("error occurred while updating pipeline run pending status", "WorkflowRunnerId", workflowTemplate.WorkflowRunnerId, "err", err)
+		return nil, err
+	}
+	templatesList.Items = append(templatesList.Items, *startedPipelineRun)
+	return templatesList, nil
+}
+
+func (impl *TektonWorkflowExecutorImpl) TerminateWorkflow(workflowName string, namespace string, clusterConfig *rest.Config) error {
+	dynamicClient, err := impl.dynamicClientProvider(clusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s dynamic client", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return err
+	}
+	cancelPatch := fmt.Sprintf(`{"spec":{"status":"%s"}}`, TektonPipelineRunCancelled)
+	_, err = dynamicClient.Resource(TektonPipelineRunGVR).Namespace(namespace).Patch(context.Background(), workflowName, types.MergePatchType, []byte(cancelPatch), v12.PatchOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = fmt.Errorf("cannot find workflow %s", workflowName)
+		}
+		impl.logger.Errorw("error occurred while cancelling workflow", "workflowName", workflowName, "namespace", namespace, "err", err)
+	}
+	return err
+}
+
+func (impl *TektonWorkflowExecutorImpl) TerminateDanglingWorkflow(workflowGenerateName string, namespace string, clusterConfig *rest.Config) error {
+	dynamicClient, err := impl.dynamicClientProvider(clusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s dynamic client", "workflowGenerateName", workflowGenerateName, "namespace", namespace, "err", err)
+		return err
+	}
+	pipelineRunClient := dynamicClient.Resource(TektonPipelineRunGVR).Namespace(namespace)
+	selectorLabel := fmt.Sprintf("%s=%s", bean.WorkflowGenerateNamePrefix, workflowGenerateName)
+	pipelineRuns, err := pipelineRunClient.List(context.Background(), v12.ListOptions{LabelSelector: selectorLabel})
+	if err != nil {
+		impl.logger.Errorw("error occurred while fetching pipeline runs for terminating dangling workflows", "namespace", namespace, "err", err)
+		return err
+	}
+	for _, pipelineRun := range pipelineRuns.Items {
+		err = pipelineRunClient.Delete(context.Background(), pipelineRun.GetName(), v12.DeleteOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				err = fmt.Errorf("cannot find pipeline run workflow %s", pipelineRun.GetName())
+			}
+			impl.logger.Errorw("error occurred while deleting pipeline run workflow", "workflowName", pipelineRun.GetName(), "namespace", namespace, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (impl *TektonWorkflowExecutorImpl) GetWorkflow(workflowName string, namespace string, clusterConfig *rest.Config) (*unstructured.UnstructuredList, error) {
+	dynamicClient, err := impl.dynamicClientProvider(clusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s dynamic client", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return nil, err
+	}
+	pipelineRun, err := dynamicClient.Resource(TektonPipelineRunGVR).Namespace(namespace).Get(context.Background(), workflowName, v12.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = fmt.Errorf("cannot find workflow %s", workflowName)
+		}
+		return nil, err
+	}
+	return &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*pipelineRun}}, nil
+}
+
+// GetWorkflowStatus maps the status of the pipeline run, and of the task run executing the workflow pod, to the argo workflow phases.
+// The not found error is returned as is, as it is used to identify the deleted workflows.
+func (impl *TektonWorkflowExecutorImpl) GetWorkflowStatus(workflowName string, namespace string, clusterConfig *rest.Config) (*types2.WorkflowStatus, error) {
+	dynamicClient, err := impl.dynamicClientProvider(clusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s dynamic client", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return nil, err
+	}
+	ctx := context.Background()
+	pipelineRun, err := dynamicClient.Resource(TektonPipelineRunGVR).Namespace(namespace).Get(ctx, workflowName, v12.GetOptions{})
+	if err != nil {
+		impl.logger.Errorw("error occurred while fetching pipeline run", "workflowName", workflowName, "namespace", namespace, "err", err)
+		return nil, err
+	}
+	return impl.getPipelineRunStatus(ctx, dynamicClient, pipelineRun)
+}
+
+func (impl *TektonWorkflowExecutorImpl) GetWorkflowStatusByNamePrefix(workflowNamePrefix string, namespace string, clusterConfig *rest.Config) (*types2.WorkflowStatus, error) {
+	dynamicClient, err := impl.dynamicClientProvider(clusterConfig)
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating k8s dynamic client", "workflowNamePrefix", workflowNamePrefix, "namespace", namespace, "err", err)
+		return nil, err
+	}
+	ctx := context.Background()
+	selectorLabel := fmt.Sprintf("%s=%s", bean.WorkflowGenerateNamePrefix, workflowNamePrefix)
+	pipelineRuns, err := dynamicClient.Resource(TektonPipelineRunGVR).Namespace(namespace).List(ctx, v12.ListOptions{LabelSelector: selectorLabel})
+	if err != nil {
+		impl.logger.Errorw("error occurred while fetching pipeline runs", "workflowNamePrefix", workflowNamePrefix, "namespace", namespace, "err", err)
+		return nil, err
+	}
+	var latestPipelineRun *unstructured.Unstructured
+	for i := range pipelineRuns.Items {
+		pipelineRun := &pipelineRuns.Items[i]
+		if latestPipelineRun == nil || pipelineRun.GetCreationTimestamp().After(latestPipelineRun.GetCreationTimestamp().Time) {
+			latestPipelineRun = pipelineRun
+		}
+	}
+	if latestPipelineRun == nil {
+		return nil, errors.NewNotFound(TektonPipelineRunGVR.GroupResource(), workflowNamePrefix)
+	}
+	return impl.getPipelineRunStatus(ctx, dynamicClient, latestPipelineRun)
+}
+
+func (impl *TektonWorkflowExecutorImpl) getPipelineRunStatus(ctx context.Context, dynamicClient dynamic.Interface, pipelineRun *unstructured.Unstructured) (*types2.WorkflowStatus, error) {
+	workflowName, namespace := pipelineRun.GetName(), pipelineRun.GetNamespace()
+	pipelineRunStatus, err := getTektonRunStatus(pipelineRun)
+	if err != nil {
+		impl.logger.Errorw("error occurred while parsing pipeline run status", "workflowName", workflowName, "err", err)
+		return nil, err
+	}
+	wfStatus := &types2.WorkflowStatus{WorkflowName: workflowName}
+	wfStatus.Status, wfStatus.Message = getWorkflowPhaseFromTektonConditions(pipelineRunStatus.Conditions)
+	for _, childReference := range pipelineRunStatus.ChildReferences {
+		if childReference.Kind != TektonTaskRunKind || childReference.PipelineTaskName != TektonWorkflowTaskName {
+			continue
+		}
+		taskRun, err := dynamicClient.Resource(TektonTaskRunGVR).Namespace(namespace).Get(ctx, childReference.Name, v12.GetOptions{})
+		if err != nil {
+			// the pipeline run status is still valid without the step details
+			impl.logger.Warnw("error occurred while fetching task run of pipeline run", "workflowName", workflowName, "taskRun", childReference.Name, "err", err)
+			continue
+		}
+		taskRunStatus, err := getTektonRunStatus(taskRun)
+		if err != nil {
+			impl.logger.Warnw("error occurred while parsing task run status", "workflowName", workflowName, "taskRun", childReference.Name, "err", err)
+			continue
+		}
+		wfStatus.PodName = taskRunStatus.PodName
+		wfStatus.PodStatus, _ = getWorkflowPhaseFromTektonConditions(taskRunStatus.Conditions)
+		wfStatus.Steps = getWorkflowStepStatuses(taskRunStatus.Steps)
+	}
+	return wfStatus, nil
+}
+
+func (impl *TektonWorkflowExecutorImpl) createCmAndSecrets(ctx context.Context, dynamicClient dynamic.Interface, workflowTemplate bean.WorkflowTemplate, pipelineRun *unstructured.Unstructured, templatesList *unstructured.UnstructuredList) error {
+	ownerReference := v12.OwnerReference{UID: pipelineRun.GetUID(), Name: pipelineRun.GetName(), Kind: TektonPipelineRunKind, APIVersion: TektonApiVersion, BlockOwnerDeletion: pointer.BoolPtr(true), Controller: pointer.BoolPtr(true)}
+	for _, configMapData := range workflowTemplate.ConfigMaps {
+		if configMapData.External {
+			continue
+		}
+		configMapSecretDto, err := adapter.GetConfigMapSecretDto(configMapData, ownerReference, false)
+		if err != nil {
+			impl.logger.Errorw("error occurred while creating config map dto", "err", err)
+			return err
+		}
+		configMap := adapter.GetConfigMapBody(configMapSecretDto)
+		impl.createOwnedObject(ctx, dynamicClient.Resource(ConfigMapGVR).Namespace(pipelineRun.GetNamespace()), &configMap, templatesList)
+	}
+	for _, secretData := range workflowTemplate.Secrets {
+		if secretData.External {
+			continue
+		}
+		configMapSecretDto, err := adapter.GetConfigMapSecretDto(secretData, ownerReference, true)
+		if err != nil {
+			impl.logger.Errorw("error occurred while creating secret dto", "err", err)
+			return err
+		}
+		secret, err := adapter.GetSecretBody(configMapSecretDto)
+		if err != nil {
+			impl.logger.Errorw("error occurred while creating secret body", "err", err)
+			return err
+		}
+		impl.createOwnedObject(ctx, dynamicClient.Resource(SecretGVR).Namespace(pipelineRun.GetNamespace()), &secret, templatesList)
+	}
+	return nil
+}
+
+// createOwnedObject creates the cm/secret owned by the pipeline run, the creation errors are ignored as done for the system executor
+func (impl *TektonWorkflowExecutorImpl) createOwnedObject(ctx context.Context, resourceClient dynamic.ResourceInterface, object runtime.Object, templatesList *unstructured.UnstructuredList) {
+	unstructuredObjMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		impl.logger.Errorw("error occurred while converting object to unstructured, but ignoring", "err", err)
+		return
+	}
+	unstructuredObj := unstructured.Unstructured{Object: unstructuredObjMap}
+	templatesList.Items = append(templatesList.Items, unstructuredObj)
+	_, err = resourceClient.Create(ctx, &unstructuredObj, v12.CreateOptions{})
+	if err != nil {
+		impl.logger.Errorw("error occurred while creating object, but ignoring", "kind", unstructuredObj.GetKind(), "name", unstructuredObj.GetName(), "err", err)
+	}
+}
+
+// getPipelineRunTemplate creates the pipeline run with an embedded pipeline of a single task executing the workflow pod.
+// The init containers run as the leading steps of the task, as tekton tasks do not support init containers.
+func getPipelineRunTemplate(workflowTemplate bean.WorkflowTemplate) (*unstructured.Unstructured, error) {
+	workflowLabels := getWorkflowLabelsForSystemExecutor(workflowTemplate)
+	steps := make([]tektonStep, 0, len(workflowTemplate.InitContainers)+len(workflowTemplate.Containers))
+	for _, initContainer := range workflowTemplate.InitContainers {
+		initStep := getTektonStep(initContainer)
+		initStep.Name = TektonInitStepNamePrefix + initStep.Name
+		steps = append(steps, initStep)
+	}
+	for _, container := range workflowTemplate.Containers {
+		steps = append(steps, getTektonStep(container))
+	}
+	sidecars := make([]tektonStep, 0, len(workflowTemplate.Sidecars))
+	for _, sidecar := range workflowTemplate.Sidecars {
+		sidecars = append(sidecars, getTektonStep(sidecar))
+	}
+	pipelineRunSpec := tektonPipelineRunSpec{
+		PipelineSpec: tektonPipelineSpec{
+			Tasks: []tektonPipelineTask{{
+				Name:     TektonWorkflowTaskName,
+				Retries:  workflowTemplate.RetryLimit,
+				TaskSpec: tektonTaskSpec{Steps: steps, Sidecars: sidecars},
+			}},
+		},
+		TaskRunTemplate: &tektonTaskRunTemplate{
+			ServiceAccountName: workflowTemplate.ServiceAccountName,
+			PodTemplate: &tektonPodTemplate{
+				NodeSelector:      workflowTemplate.NodeSelector,
+				Tolerations:       workflowTemplate.Tolerations,
+				Affinity:          workflowTemplate.Affinity,
+				SecurityContext:   workflowTemplate.SecurityContext,
+				Volumes:           workflowTemplate.Volumes,
+				ImagePullSecrets:  workflowTemplate.ImagePullSecrets,
+				HostAliases:       workflowTemplate.HostAliases,
+				DNSConfig:         workflowTemplate.DNSConfig,
+				SchedulerName:     workflowTemplate.SchedulerName,
+				TopologySpread:    workflowTemplate.TopologySpreadConstraints,
+				PriorityClassName: getPriorityClassNameForTekton(workflowTemplate),
+			},
+		},
+		Status: TektonPipelineRunPending,
+	}
+	if workflowTemplate.DNSPolicy != "" {
+		pipelineRunSpec.TaskRunTemplate.PodTemplate.DNSPolicy = &workflowTemplate.DNSPolicy
+	}
+	if activeDeadlineSeconds := getActiveDeadlineSecondsForSystemExecutor(workflowTemplate); activeDeadlineSeconds != nil {
+		pipelineRunSpec.Timeouts = &tektonTimeouts{Pipeline: &v12.Duration{Duration: time.Duration(*activeDeadlineSeconds) * time.Second}}
+	}
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&pipelineRunSpec)
+	if err != nil {
+		return nil, err
+	}
+	pipelineRun := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	pipelineRun.SetAPIVersion(TektonApiVersion)
+	pipelineRun.SetKind(TektonPipelineRunKind)
+	pipelineRun.SetGenerateName(fmt.Sprintf(WORKFLOW_GENERATE_NAME_REGEX, workflowTemplate.WorkflowNamePrefix))
+	pipelineRun.SetNamespace(workflowTemplate.Namespace)
+	// tekton propagates the pipeline run labels and annotations to the task run pods
+	pipelineRun.SetLabels(getPodLabelsForSystemExecutor(workflowTemplate, workflowLabels))
+	pipelineRun.SetAnnotations(workflowTemplate.PodAnnotations)
+	return pipelineRun, nil
+}
+
+func getTektonStep(container corev1.Container) tektonStep {
+	return tektonStep{
+		Name:             container.Name,
+		Image:            container.Image,
+		Command:          container.Command,
+		Args:             container.Args,
+		WorkingDir:       container.WorkingDir,
+		Env:              container.Env,
+		EnvFrom:          container.EnvFrom,
+		VolumeMounts:     container.VolumeMounts,
+		ComputeResources: container.Resources,
+		SecurityContext:  container.SecurityContext,
+		ImagePullPolicy:  container.ImagePullPolicy,
+	}
+}
+
+func getPriorityClassNameForTekton(workflowTemplate bean.WorkflowTemplate) *string {
+	if workflowTemplate.PriorityClassName == "" {
+		return nil
+	}
+	return &workflowTemplate.PriorityClassName
+}
+
+func getTektonRunStatus(run *unstructured.Unstructured) (*tektonRunStatus, error) {
+	runStatus := &tektonRunStatus{}
+	status, found, err := unstructured.NestedMap(run.Object, "status")
+	if err != nil || !found {
+		return runStatus, err
+	}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(status, runStatus)
+	return runStatus, err
+}
+
+// getWorkflowPhaseFromTektonConditions maps the Succeeded condition of the pipeline run/task run to the argo workflow phase
+func getWorkflowPhaseFromTektonConditions(conditions []tektonCondition) (string, string) {
+	for _, condition := range conditions {
+		if condition.Type != TektonConditionSucceeded {
+			continue
+		}
+		switch condition.Status {
+		case string(corev1.ConditionTrue):
+			return string(v1alpha1.NodeSucceeded), condition.Message
+		case string(corev1.ConditionFalse):
+			if tektonErrorReasons[condition.Reason] {
+				return string(v1alpha1.NodeError), condition.Message
+			} else if tektonCancelledReasons[condition.Reason] {
+				return string(v1alpha1.NodeFailed), "terminated"
+			}
+			return string(v1alpha1.NodeFailed), condition.Message
+		default:
+			if condition.Reason == TektonPipelineRunPending || condition.Reason == string(v1alpha1.NodePending) {
+				return string(v1alpha1.NodePending), condition.Message
+			}
+			return string(v1alpha1.NodeRunning), condition.Message
+		}
+	}
+	return string(v1alpha1.NodePending), ""
+}
+
+func getWorkflowStepStatuses(stepStates []tektonStepState) []types2.WorkflowStepStatus {
+	stepStatuses := make([]types2.WorkflowStepStatus, 0, len(stepStates))
+	for _, stepState := range stepStates {
+		stepStatus := types2.WorkflowStepStatus{Name: stepState.Name, Status: string(v1alpha1.NodePending)}
+		switch {
+		case stepState.Terminated != nil:
+			stepStatus.Status = string(v1alpha1.NodeSucceeded)
+			if stepState.Terminated.ExitCode != 0 {
+				stepStatus.Status = string(v1alpha1.NodeFailed)
+				stepStatus.Message = fmt.Sprintf("%s (exit code %d)", stepState.Terminated.Reason, stepState.Terminated.ExitCode)
+			}
+		case stepState.Running != nil:
+			stepStatus.Status = string(v1alpha1.NodeRunning)
+		case stepState.Waiting != nil:
+			stepStatus.Message = stepState.Waiting.Reason
+		}
+		stepStatuses = append(stepStatuses, stepStatus)
+	}
+	return stepStatuses
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executors
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	apiBean "github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+	k8sTesting "k8s.io/client-go/testing"
+	"testing"
+	"time"
+)
+
+const tektonTestNamespace = "devtron-ci"
+
+func getFakeTektonWorkflowExecutor(t *testing.T, objects ...runtime.Object) (*TektonWorkflowExecutorImpl, *fake.FakeDynamicClient) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	listKinds := map[schema.GroupVersionResource]string{
+		TektonPipelineRunGVR: "PipelineRunList",
+		TektonTaskRunGVR:     "TaskRunList",
+	}
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+	// the fake client does not generate the names
+	dynamicClient.PrependReactor("create", "pipelineruns", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		pipelineRun := action.(k8sTesting.CreateAction).GetObject().(*unstructured.Unstructured)
+		if pipelineRun.GetName() == "" {
+			pipelineRun.SetName(pipelineRun.GetGenerateName() + "x1y2z")
+		}
+		return false, nil, nil
+	})
+	impl := &TektonWorkflowExecutorImpl{logger: logger}
+	impl.dynamicClientProvider = func(clusterConfig *rest.Config) (dynamic.Interface, error) {
+		return dynamicClient, nil
+	}
+	return impl, dynamicClient
+}
+
+func getTektonTestObject(t *testing.T, apiVersion, kind, name string, status map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetName(name)
+	object.SetNamespace(tektonTestNamespace)
+	return object
+}
+
+func TestTektonWorkflowExecute(t *testing.T) {
+	impl, dynamicClient := getFakeTektonWorkflowExecutor(t)
+	deadline := int64(600)
+	workflowTemplate := bean.WorkflowTemplate{
+		WorkflowNamePrefix: "1-ci",
+		WorkflowType:       bean.CI_WORKFLOW_NAME,
+		Namespace:          tektonTestNamespace,
+		RetryLimit:         1,
+		Sidecars:           []corev1.Container{{Name: "docker", Image: "docker:dind"}},
+		ConfigMaps:         []apiBean.ConfigSecretMap{{Name: "cm-1", Data: json.RawMessage(`{"key":"value"}`)}},
+		PodLabels:          map[string]string{"team": "platform"},
+	}
+	workflowTemplate.ActiveDeadlineSeconds = &deadline
+	workflowTemplate.ServiceAccountName = "ci-runner"
+	workflowTemplate.NodeSelector = map[string]string{"pool": "ci"}
+	workflowTemplate.InitContainers = []corev1.Container{{Name: "setup", Image: "busybox"}}
+	workflowTemplate.Containers = []corev1.Container{{Name: "ci", Image: "ci-runner", VolumeMounts: []corev1.VolumeMount{{Name: "cm-1-vol", MountPath: "/etc/cm"}}}}
+	workflowTemplate.Volumes = []corev1.Volume{{Name: "cm-1-vol", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm-1"}}}}}
+
+	createdObjects, err := impl.ExecuteWorkflow(workflowTemplate)
+	assert.Nil(t, err)
+	assert.Len(t, createdObjects.Items, 2)
+
+	pipelineRun, err := dynamicClient.Resource(TektonPipelineRunGVR).Namespace(tektonTestNamespace).Get(context.Background(), "1-ci-x1y2z", v12.GetOptions{})
+	assert.Nil(t, err)
+	_, isPending, _ := unstructured.NestedString(pipelineRun.Object, "spec", "status")
+	assert.False(t, isPending)
+	assert.Equal(t, "1-ci", pipelineRun.GetLabels()[bean.WorkflowGenerateNamePrefix])
+	assert.Equal(t, "platform", pipelineRun.GetLabels()["team"])
+	timeout, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "timeouts", "pipeline")
+	assert.Equal(t, "20m0s", timeout)
+	serviceAccountName, _, _ := unstructured.NestedString(pipelineRun.Object, "spec", "taskRunTemplate", "serviceAccountName")
+	assert.Equal(t, "ci-runner", serviceAccountName)
+	volumes, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "taskRunTemplate", "podTemplate", "volumes")
+	assert.Len(t, volumes, 1)
+
+	tasks, _, _ := unstructured.NestedSlice(pipelineRun.Object, "spec", "pipelineSpec", "tasks")
+	assert.Len(t, tasks, 1)
+	task := tasks[0].(map[string]interface{})
+	assert.EqualValues(t, 1, task["retries"])
+	steps, _, _ := unstructured.NestedSlice(task, "taskSpec", "steps")
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "init-setup", steps[0].(map[string]interface{})["name"])
+	assert.Equal(t, "ci", steps[1].(map[string]interface{})["name"])
+	sidecars, _, _ := unstructured.NestedSlice(task, "taskSpec", "sidecars")
+	assert.Len(t, sidecars, 1)
+
+	configMap, err := dynamicClient.Resource(ConfigMapGVR).Namespace(tektonTestNamespace).Get(context.Background(), "cm-1", v12.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "1-ci-x1y2z", configMap.GetOwnerReferences()[0].Name)
+	assert.Equal(t, TektonPipelineRunKind, configMap.GetOwnerReferences()[0].Kind)
+}
+
+func TestTektonWorkflowStatus(t *testing.T) {
+	pipelineRun := getTektonTestObject(t, TektonApiVersion, TektonPipelineRunKind, "1-ci-abcde", map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "Unknown", "reason": "Running"}},
+		"childReferences": []interface{}{
+			map[string]interface{}{"kind": TektonTaskRunKind, "name": "1-ci-abcde-workflow", "pipelineTaskName": TektonWorkflowTaskName},
+		},
+	})
+	taskRun := getTektonTestObject(t, TektonApiVersion, TektonTaskRunKind, "1-ci-abcde-workflow", map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "Unknown", "reason": "Running"}},
+		"podName":    "1-ci-abcde-workflow-pod",
+		"steps": []interface{}{
+			map[string]interface{}{"name": "init-setup", "terminated": map[string]interface{}{"exitCode": int64(0), "reason": "Completed"}},
+			map[string]interface{}{"name": "ci", "running": map[string]interface{}{}},
+		},
+	})
+	pipelineRun.SetLabels(map[string]string{bean.WorkflowGenerateNamePrefix: "1-ci"})
+	pipelineRun.SetCreationTimestamp(v12.NewTime(time.Now()))
+	// pipeline run of the previous trigger of the workflow, deleted with the dangling workflows
+	previousPipelineRun := getTektonTestObject(t, TektonApiVersion, TektonPipelineRunKind, "1-ci-vwxyz", map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed"}},
+	})
+	previousPipelineRun.SetLabels(map[string]string{bean.WorkflowGenerateNamePrefix: "1-ci"})
+	previousPipelineRun.SetCreationTimestamp(v12.NewTime(time.Now().Add(-time.Hour)))
+	impl, _ := getFakeTektonWorkflowExecutor(t, pipelineRun, taskRun, previousPipelineRun)
+
+	t.Run("running workflow with step status", func(t *testing.T) {
+		wfStatus, err := impl.GetWorkflowStatus("1-ci-abcde", tektonTestNamespace, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, string(v1alpha1.NodeRunning), wfStatus.Status)
+		assert.Equal(t, string(v1alpha1.NodeRunning), wfStatus.PodStatus)
+		assert.Equal(t, "1-ci-abcde-workflow-pod", wfStatus.PodName)
+		assert.Len(t, wfStatus.Steps, 2)
+		assert.Equal(t, string(v1alpha1.NodeSucceeded), wfStatus.Steps[0].Status)
+		assert.Equal(t, string(v1alpha1.NodeRunning), wfStatus.Steps[1].Status)
+	})
+
+	t.Run("workflow status by name prefix", func(t *testing.T) {
+		wfStatus, err := impl.GetWorkflowStatusByNamePrefix("1-ci", tektonTestNamespace, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "1-ci-abcde", wfStatus.WorkflowName)
+		assert.Equal(t, string(v1alpha1.NodeRunning), wfStatus.Status)
+		assert.Equal(t, "1-ci-abcde-workflow-pod", wfStatus.PodName)
+		_, err = impl.GetWorkflowStatusByNamePrefix("2-ci", tektonTestNamespace, nil)
+		assert.True(t, errors.IsNotFound(err))
+	})
+
+	t.Run("terminate workflow", func(t *testing.T) {
+		err := impl.TerminateWorkflow("1-ci-abcde", tektonTestNamespace, nil)
+		assert.Nil(t, err)
+		workflows, err := impl.GetWorkflow("1-ci-abcde", tektonTestNamespace, nil)
+		assert.Nil(t, err)
+		status, _, _ := unstructured.NestedString(workflows.Items[0].Object, "spec", "status")
+		assert.Equal(t, TektonPipelineRunCancelled, status)
+		err = impl.TerminateWorkflow("1-ci-unknown", tektonTestNamespace, nil)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("workflow phase from conditions", func(t *testing.T) {
+		getPhase := func(status, reason string) string {
+			phase, _ := getWorkflowPhaseFromTektonConditions([]tektonCondition{{Type: TektonConditionSucceeded, Status: status, Reason: reason}})
+			return phase
+		}
+		assert.Equal(t, string(v1alpha1.NodePending), getPhase("Unknown", TektonPipelineRunPending))
+		assert.Equal(t, string(v1alpha1.NodeSucceeded), getPhase("True", "Succeeded"))
+		assert.Equal(t, string(v1alpha1.NodeFailed), getPhase("False", "Failed"))
+		assert.Equal(t, string(v1alpha1.NodeFailed), getPhase("False", "PipelineRunTimeout"))
+		assert.Equal(t, string(v1alpha1.NodeError), getPhase("False", "CouldntGetPipeline"))
+		phase, _ := getWorkflowPhaseFromTektonConditions(nil)
+		assert.Equal(t, string(v1alpha1.NodePending), phase)
+	})
+}
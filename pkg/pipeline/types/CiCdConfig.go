@@ -91,7 +91,7 @@ type CiCdConfig struct {
 	EnableBuildContext               bool                            `env:"ENABLE_BUILD_CONTEXT" envDefault:"false" description:"To Enable build context in Devtron."`
 	ImageRetryCount                  int                             `env:"IMAGE_RETRY_COUNT" envDefault:"0" description:"push artifact(image) in ci retry count "`
 	ImageRetryInterval               int                             `env:"IMAGE_RETRY_INTERVAL" envDefault:"5" description:"image retry interval takes value in seconds"` // image retry interval takes value in seconds
-	CiWorkflowExecutorType           cdWorkflow.WorkflowExecutorType `env:"CI_WORKFLOW_EXECUTOR_TYPE" envDefault:"AWF" description:"Executor type for CI(AWF,System)"`
+	CiWorkflowExecutorType           cdWorkflow.WorkflowExecutorType `env:"CI_WORKFLOW_EXECUTOR_TYPE" envDefault:"AWF" description:"Executor type for CI(AWF,System,Tekton)"`
 	BuildxK8sDriverOptions           string                          `env:"BUILDX_K8S_DRIVER_OPTIONS" envDefault:"" description:"To enable the k8s driver and pass args for k8s driver in buildx"`
 	CIAutoTriggerBatchSize           int                             `env:"CI_SUCCESS_AUTO_TRIGGER_BATCH_SIZE" envDefault:"1" description:"this is to control the no of linked pipelines should be hanled in one go when a ci-success event of an parent ci is received"`
 	SkipCreatingEcrRepo              bool                            `env:"SKIP_CREATING_ECR_REPO" envDefault:"false" description:"By disabling this ECR repo won't get created if it's not available on ECR from build configuration"`
@@ -128,7 +128,7 @@ type CiCdConfig struct {
 	CdDefaultAddressPoolSize         int                             `env:"CD_DEFAULT_ADDRESS_POOL_SIZE" description:"The subnet size to allocate from the base pool for CD"`
 	ExposeCDMetrics                  bool                            `env:"EXPOSE_CD_METRICS" envDefault:"false" description:"To expose CD metrics"`
 	UseBlobStorageConfigInCdWorkflow bool                            `env:"USE_BLOB_STORAGE_CONFIG_IN_CD_WORKFLOW" envDefault:"true" description:"To enable blob storage in pre and post cd"`
-	CdWorkflowExecutorType           cdWorkflow.WorkflowExecutorType `env:"CD_WORKFLOW_EXECUTOR_TYPE" envDefault:"AWF" description:"Executor type for Pre/Post CD(AWF,System)"`
+	CdWorkflowExecutorType           cdWorkflow.WorkflowExecutorType `env:"CD_WORKFLOW_EXECUTOR_TYPE" envDefault:"AWF" description:"Executor type for Pre/Post CD(AWF,System,Tekton)"`
 	TerminationGracePeriod           int                             `env:"TERMINATION_GRACE_PERIOD_SECS" envDefault:"180" description:"this is the time given to workflow pods to shutdown. (grace full termination time)"`
 	MaxCdWorkflowRunnerRetries       int                             `env:"MAX_CD_WORKFLOW_RUNNER_RETRIES" envDefault:"0" description:"Maximum time pre/post-cd-workflow create pod if it fails to complete"`
 
@@ -162,6 +162,10 @@ type CiCdConfig struct {
 	UseImageTagFromGitProviderForTagBasedBuild bool                         `env:"USE_IMAGE_TAG_FROM_GIT_PROVIDER_FOR_TAG_BASED_BUILD" envDefault:"false" description:"To use the same tag in container image as that of git tag"` // this is being done for https://github.com/devtron-labs/devtron/issues/4263
 	UseDockerApiToGetDigest                    bool                         `env:"USE_DOCKER_API_TO_GET_DIGEST" envDefault:"false" description:"when user do not pass the digest  then this flag controls , finding the image digest using docker API or not. if set to true we get the digest from docker API call else use docker pull command. [logic in ci-runner]"`
 	EnableWorkflowExecutionStage               bool                         `env:"ENABLE_WORKFLOW_EXECUTION_STAGE" envDefault:"true" description:"if enabled then we will display build stages separately for CI/Job/Pre-Post CD" example:"true"`
+	WorkflowRetryPolicyJson                    string                       `env:"WORKFLOW_RETRY_POLICY_JSON" envDefault:"{}" description:"Retry policy of the workflow pod per stage (CI, JOB, PRE_CD, POST_CD). The failed pod is retried up to the limit before the workflow is marked as failed. Not applied to the stages re-triggered with MAX_CI_WORKFLOW_RETRIES or MAX_CD_WORKFLOW_RUNNER_RETRIES" example:"{\"CI\":{\"limit\":1},\"POST_CD\":{\"limit\":2}}"`
+	WorkflowInitContainersJson                 string                       `env:"WORKFLOW_INIT_CONTAINERS_JSON" envDefault:"" description:"List of init containers (k8s container spec) added to the CI/Job/Pre-Post CD workflow pods"`
+	WorkflowSidecarContainersJson              string                       `env:"WORKFLOW_SIDECAR_CONTAINERS_JSON" envDefault:"" description:"List of sidecar containers (k8s container spec) added to the CI/Job/Pre-Post CD workflow pods, e.g. docker-in-docker or a cache proxy. With the System executor they are added as native sidecars (k8s 1.29+)"`
+	UploadLogsOnWorkflowFailure                bool                         `env:"UPLOAD_LOGS_ON_WORKFLOW_FAILURE" envDefault:"false" description:"Used with the System executor. If enabled, the logs of a failed workflow pod are uploaded to the blob storage by the orchestrator, as the runner may not have uploaded them"`
 }
 
 type CiConfig struct {
@@ -172,6 +176,19 @@ type CdConfig struct {
 	*CiCdConfig
 }
 
+// WorkflowRetryPolicy is the retry policy of the workflow pod of a stage
+type WorkflowRetryPolicy struct {
+	// Limit is the number of times the failed workflow pod is retried
+	Limit int32 `json:"limit"`
+}
+
+const (
+	CiWorkflowStage     = "CI"
+	JobWorkflowStage    = "JOB"
+	PreCdWorkflowStage  = "PRE_CD"
+	PostCdWorkflowStage = "POST_CD"
+)
+
 type CiVolumeMount struct {
 	Name               string `json:"name"`
 	HostMountPath      string `json:"hostMountPath"`
@@ -496,6 +513,92 @@ func (impl *CiCdConfig) WorkflowRetriesEnabled() bool {
 	}
 }
 
+// GetWorkflowRetryPolicy returns the retry policy configured for the workflow stage,
+// the workflow pod is not retried if no policy is configured for the stage.
+// The policy is not applied if the failed workflows of the stage are re-triggered by the orchestrator,
+// as every re-triggered workflow would retry its pod as well.
+func (impl *CiCdConfig) GetWorkflowRetryPolicy(workflowStage string) (WorkflowRetryPolicy, error) {
+	if impl.isWorkflowReTriggerEnabled(workflowStage) {
+		return WorkflowRetryPolicy{}, nil
+	}
+	retryPolicies := make(map[string]WorkflowRetryPolicy)
+	if len(impl.WorkflowRetryPolicyJson) > 0 {
+		err := json.Unmarshal([]byte(impl.WorkflowRetryPolicyJson), &retryPolicies)
+		if err != nil {
+			return WorkflowRetryPolicy{}, err
+		}
+	}
+	retryPolicy := retryPolicies[workflowStage]
+	if retryPolicy.Limit < 0 {
+		return WorkflowRetryPolicy{}, fmt.Errorf("invalid retry limit %d for workflow stage %s", retryPolicy.Limit, workflowStage)
+	}
+	return retryPolicy, nil
+}
+
+// isWorkflowReTriggerEnabled checks if a failed workflow of the stage is re-triggered as a new workflow,
+// see MAX_CI_WORKFLOW_RETRIES and MAX_CD_WORKFLOW_RUNNER_RETRIES
+func (impl *CiCdConfig) isWorkflowReTriggerEnabled(workflowStage string) bool {
+	switch workflowStage {
+	case CiWorkflowStage, JobWorkflowStage:
+		return impl.MaxCiWorkflowRetries > 0
+	case PreCdWorkflowStage, PostCdWorkflowStage:
+		return impl.MaxCdWorkflowRunnerRetries > 0
+	default:
+		return false
+	}
+}
+
+// GetWorkflowInitAndSidecarContainers returns the init and sidecar containers to be added in the workflow pods
+func (impl *CiCdConfig) GetWorkflowInitAndSidecarContainers() (initContainers []v12.Container, sidecars []v12.Container, err error) {
+	if len(impl.WorkflowInitContainersJson) > 0 {
+		err = json.Unmarshal([]byte(impl.WorkflowInitContainersJson), &initContainers)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(impl.WorkflowSidecarContainersJson) > 0 {
+		err = json.Unmarshal([]byte(impl.WorkflowSidecarContainersJson), &sidecars)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return initContainers, sidecars, nil
+}
+
+// GetBuildLogRequest returns the log request for the workflow pod with the blob storage configured in the orchestrator
+func (impl *CiCdConfig) GetBuildLogRequest(podName, namespace, logsFilePath string) BuildLogRequest {
+	logsBucket := impl.GetDefaultBuildLogsBucket()
+	logsBucketRegion := impl.DefaultCacheBucketRegion
+	if impl.Type == CdConfigType {
+		logsBucketRegion = impl.GetDefaultCdLogsBucketRegion()
+	}
+	return BuildLogRequest{
+		PodName:       podName,
+		Namespace:     namespace,
+		LogsFilePath:  logsFilePath,
+		CloudProvider: impl.CloudProvider,
+		AzureBlobConfig: &blob_storage.AzureBlobBaseConfig{
+			Enabled:           impl.CloudProvider == BLOB_STORAGE_AZURE,
+			AccountName:       impl.AzureAccountName,
+			BlobContainerName: impl.AzureBlobContainerCiLog,
+			AccountKey:        impl.AzureAccountKey,
+		},
+		AwsS3BaseConfig: &blob_storage.AwsS3BaseConfig{
+			AccessKey:         impl.BlobStorageS3AccessKey,
+			Passkey:           impl.BlobStorageS3SecretKey,
+			EndpointUrl:       impl.BlobStorageS3Endpoint,
+			IsInSecure:        impl.BlobStorageS3EndpointInsecure,
+			BucketName:        logsBucket,
+			Region:            logsBucketRegion,
+			VersioningEnabled: impl.BlobStorageS3BucketVersioned,
+		},
+		GcpBlobBaseConfig: &blob_storage.GcpBlobBaseConfig{
+			BucketName:             logsBucket,
+			CredentialFileJsonData: impl.BlobStorageGcpCredentialJson,
+		},
+	}
+}
+
 func (impl *CiCdConfig) GetWorkflowVolumeAndVolumeMounts() ([]v12.Volume, []v12.VolumeMount, error) {
 	var volumes []v12.Volume
 	var volumeMounts []v12.VolumeMount
@@ -394,7 +394,9 @@ func (workflowRequest *WorkflowRequest) getBlobStorageLogsPrefix() string {
 
 func (workflowRequest *WorkflowRequest) updateBlobStorageLogsKey(config *CiCdConfig) {
 	workflowRequest.BlobStorageLogsKey = fmt.Sprintf("%s/%s", workflowRequest.getDefaultBuildLogsKeyPrefix(config), workflowRequest.getBlobStorageLogsPrefix())
-	workflowRequest.InAppLoggingEnabled = config.InAppLoggingEnabled || (workflowRequest.WorkflowExecutor == cdWorkflow.WORKFLOW_EXECUTOR_TYPE_SYSTEM)
+	// logs of the workflows not executed by argo are not archived by the executor, so uploaded by the runner itself
+	workflowRequest.InAppLoggingEnabled = config.InAppLoggingEnabled || (workflowRequest.WorkflowExecutor == cdWorkflow.WORKFLOW_EXECUTOR_TYPE_SYSTEM) ||
+		(workflowRequest.WorkflowExecutor == cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON)
 }
 
 func (workflowRequest *WorkflowRequest) getWorkflowJson() ([]byte, error) {
@@ -428,9 +430,60 @@ func (workflowRequest *WorkflowRequest) AddNodeConstraintsFromConfig(workflowTem
 
 }
 
+// GetWorkflowStage returns the stage of the workflow request, used for the stage wise workflow configurations
+func (workflowRequest *WorkflowRequest) GetWorkflowStage() string {
+	switch workflowRequest.Type {
+	case bean.CI_WORKFLOW_PIPELINE_TYPE:
+		return CiWorkflowStage
+	case bean.JOB_WORKFLOW_PIPELINE_TYPE:
+		return JobWorkflowStage
+	case bean.CD_WORKFLOW_PIPELINE_TYPE:
+		if workflowRequest.IsCdStageTypePost() {
+			return PostCdWorkflowStage
+		}
+		return PreCdWorkflowStage
+	default:
+		return ""
+	}
+}
+
+// AddPodConfigurationsFromConfig adds the retry policy, init containers and sidecars configured for the workflow pods
+func (workflowRequest *WorkflowRequest) AddPodConfigurationsFromConfig(workflowTemplate *bean.WorkflowTemplate, config *CiCdConfig) error {
+	retryPolicy, err := config.GetWorkflowRetryPolicy(workflowRequest.GetWorkflowStage())
+	if err != nil {
+		return err
+	}
+	workflowTemplate.RetryLimit = retryPolicy.Limit
+	initContainers, sidecars, err := config.GetWorkflowInitAndSidecarContainers()
+	if err != nil {
+		return err
+	}
+	workflowTemplate.InitContainers = append(workflowTemplate.InitContainers, initContainers...)
+	workflowTemplate.Sidecars = append(workflowTemplate.Sidecars, sidecars...)
+	return nil
+}
+
 func (workflowRequest *WorkflowRequest) AddInfraConfigurations(workflowTemplate *bean.WorkflowTemplate, infraConfiguration *infraBean.InfraConfig) {
 	timeout := infraConfiguration.GetCiTimeoutInt()
 	workflowTemplate.SetActiveDeadlineSeconds(timeout)
+	workflowRequest.AddPodInfraConfigurations(workflowTemplate, infraConfiguration)
+}
+
+// AddPodInfraConfigurations applies the optional pod configurations of the infra profile,
+// the node constraints are retained for the configurations which are not configured
+func (workflowRequest *WorkflowRequest) AddPodInfraConfigurations(workflowTemplate *bean.WorkflowTemplate, infraConfiguration *infraBean.InfraConfig) {
+	if serviceAccount := infraConfiguration.GetCiServiceAccount(); len(serviceAccount) != 0 {
+		workflowTemplate.ServiceAccountName = serviceAccount
+	}
+	if priorityClass := infraConfiguration.GetCiPriorityClass(); len(priorityClass) != 0 {
+		workflowTemplate.PriorityClassName = priorityClass
+	}
+	if podLabels := infraConfiguration.GetCiPodLabels(); len(podLabels) != 0 {
+		workflowTemplate.PodLabels = podLabels
+	}
+	if podAnnotations := infraConfiguration.GetCiPodAnnotations(); len(podAnnotations) != 0 {
+		workflowTemplate.PodAnnotations = podAnnotations
+	}
 }
 
 func (workflowRequest *WorkflowRequest) GetGlobalCmCsNamePrefix() string {
@@ -546,7 +599,7 @@ func (workflowRequest *WorkflowRequest) GetLimitReqCpuMem(config *CiCdConfig, in
 			ReqMem:   config.CdReqMem,
 		}
 	}
-	return v1.ResourceRequirements{
+	resourceRequirements := v1.ResourceRequirements{
 		Limits: v1.ResourceList{
 			v1.ResourceCPU:    resource.MustParse(limitReqCpuMem.LimitCpu),
 			v1.ResourceMemory: resource.MustParse(limitReqCpuMem.LimitMem),
@@ -556,6 +609,16 @@ func (workflowRequest *WorkflowRequest) GetLimitReqCpuMem(config *CiCdConfig, in
 			v1.ResourceMemory: resource.MustParse(limitReqCpuMem.ReqMem),
 		},
 	}
+	if workflowRequest.Type == bean.CI_WORKFLOW_PIPELINE_TYPE || workflowRequest.Type == bean.JOB_WORKFLOW_PIPELINE_TYPE {
+		// ephemeral storage is applied only when it is configured in the infra profile
+		if limitEphemeralStorage := infraConfigurations.GetCiLimitEphemeralStorage(); len(limitEphemeralStorage) != 0 {
+			resourceRequirements.Limits[v1.ResourceEphemeralStorage] = resource.MustParse(limitEphemeralStorage)
+		}
+		if reqEphemeralStorage := infraConfigurations.GetCiReqEphemeralStorage(); len(reqEphemeralStorage) != 0 {
+			resourceRequirements.Requests[v1.ResourceEphemeralStorage] = resource.MustParse(reqEphemeralStorage)
+		}
+	}
+	return resourceRequirements
 }
 
 func (workflowRequest *WorkflowRequest) getWorkflowImage() string {
@@ -774,4 +837,10 @@ type ConfigMapSecretDto struct {
 
 type WorkflowStatus struct {
 	WorkflowName, Status, PodStatus, Message, LogLocation, PodName string
+	// Steps is the status of the individual steps of the workflow pod, populated by the executors tracking the steps
+	Steps []WorkflowStepStatus
+}
+
+type WorkflowStepStatus struct {
+	Name, Status, Message string
 }
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/terminal/recording/bean"
+)
+
+const recordingTruncatedMsg = "recording truncated, maximum recording size reached"
+
+// AsciicastWriter writes the terminal events as asciicast v2 lines, it is safe to be used concurrently
+// as the input and output of a terminal session are streamed from different go routines
+type AsciicastWriter struct {
+	lock         sync.Mutex
+	writer       io.Writer
+	startedOn    time.Time
+	maxBytes     int64
+	bytesWritten int64
+	truncated    bool
+	closed       bool
+	err          error
+}
+
+// NewAsciicastWriter writes the header and returns the writer, maxBytes <= 0 means no size limit
+func NewAsciicastWriter(writer io.Writer, header *bean.AsciicastHeader, maxBytes int64) (*AsciicastWriter, error) {
+	asciicastWriter := &AsciicastWriter{
+		writer:    writer,
+		startedOn: time.Unix(header.Timestamp, 0),
+		maxBytes:  maxBytes,
+	}
+	header.Version = bean.AsciicastVersion
+	line, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	err = asciicastWriter.writeLine(line)
+	if err != nil {
+		return nil, err
+	}
+	return asciicastWriter, nil
+}
+
+// WriteEvent records the event at the time elapsed since the start of the session, write errors are
+// retained and returned by Err as a failing recording must not break the terminal session
+func (w *AsciicastWriter) WriteEvent(eventType bean.AsciicastEventType, data string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.err != nil || w.truncated || w.closed {
+		return
+	}
+	line, err := marshalAsciicastEvent(time.Since(w.startedOn), eventType, data)
+	if err != nil {
+		w.err = err
+		return
+	}
+	if w.maxBytes > 0 && w.bytesWritten+int64(len(line))+1 > w.maxBytes {
+		w.truncated = true
+		line, err = marshalAsciicastEvent(time.Since(w.startedOn), bean.AsciicastEventMarker, recordingTruncatedMsg)
+		if err != nil {
+			w.err = err
+			return
+		}
+	}
+	w.err = w.writeLine(line)
+}
+
+// WriteResize records the terminal size change as "{COLUMNS}x{ROWS}"
+func (w *AsciicastWriter) WriteResize(width, height uint16) {
+	w.WriteEvent(bean.AsciicastEventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// Close stops recording the events, the events written after close are dropped. Returns the write error, if any
+func (w *AsciicastWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.closed = true
+	return w.err
+}
+
+func (w *AsciicastWriter) Err() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.err
+}
+
+func (w *AsciicastWriter) IsTruncated() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.truncated
+}
+
+func (w *AsciicastWriter) BytesWritten() int64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.bytesWritten
+}
+
+func (w *AsciicastWriter) writeLine(line []byte) error {
+	n, err := w.writer.Write(append(line, '\n'))
+	w.bytesWritten += int64(n)
+	return err
+}
+
+func marshalAsciicastEvent(elapsed time.Duration, eventType bean.AsciicastEventType, data string) ([]byte, error) {
+	return json.Marshal([]interface{}{float64(elapsed.Microseconds()) / 1e6, eventType, data})
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/terminal/recording/bean"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsciicastWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	header := &bean.AsciicastHeader{Width: 80, Height: 24, Timestamp: time.Now().Unix()}
+	writer, err := NewAsciicastWriter(buf, header, 0)
+	assert.Nil(t, err)
+	writer.WriteEvent(bean.AsciicastEventInput, "ls\r")
+	writer.WriteEvent(bean.AsciicastEventOutput, "file.txt\r\n")
+	writer.WriteResize(120, 40)
+	assert.Nil(t, writer.Close())
+	writer.WriteEvent(bean.AsciicastEventOutput, "dropped after close")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	assert.Len(t, lines, 4)
+	var parsedHeader bean.AsciicastHeader
+	assert.Nil(t, json.Unmarshal([]byte(lines[0]), &parsedHeader))
+	assert.Equal(t, bean.AsciicastVersion, parsedHeader.Version)
+	expectedEvents := [][]string{{"i", "ls\r"}, {"o", "file.txt\r\n"}, {"r", "120x40"}}
+	for i, expectedEvent := range expectedEvents {
+		var event []interface{}
+		assert.Nil(t, json.Unmarshal([]byte(lines[i+1]), &event))
+		assert.Len(t, event, 3)
+		assert.Equal(t, expectedEvent[0], event[1])
+		assert.Equal(t, expectedEvent[1], event[2])
+	}
+	assert.Equal(t, int64(buf.Len()), writer.BytesWritten())
+}
+
+func TestAsciicastWriterTruncation(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := NewAsciicastWriter(buf, &bean.AsciicastHeader{Timestamp: time.Now().Unix()}, 200)
+	assert.Nil(t, err)
+	writer.WriteEvent(bean.AsciicastEventOutput, strings.Repeat("a", 500))
+	writer.WriteEvent(bean.AsciicastEventOutput, "dropped after truncation")
+	assert.True(t, writer.IsTruncated())
+	assert.Contains(t, buf.String(), recordingTruncatedMsg)
+	assert.NotContains(t, buf.String(), "dropped after truncation")
+}
+
+func TestIsRecordingEnabledForCluster(t *testing.T) {
+	config := &bean.TerminalSessionRecordingConfig{}
+	assert.False(t, config.IsRecordingEnabledForCluster(1))
+	config.RecordingEnabled = true
+	assert.True(t, config.IsRecordingEnabledForCluster(1))
+	config.RecordingClusterIds = []int{2, 3}
+	assert.False(t, config.IsRecordingEnabledForCluster(1))
+	assert.True(t, config.IsRecordingEnabledForCluster(3))
+}
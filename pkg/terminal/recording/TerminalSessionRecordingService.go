@@ -0,0 +1,355 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recording
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/common-lib/async"
+	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording/bean"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSessionPageSize = 20
+	maxSessionPageSize     = 100
+	asciicastFileExtension = ".cast"
+)
+
+// SessionRecorder records a terminal session, it is safe for concurrent use
+type SessionRecorder interface {
+	RecordInput(data string)
+	RecordOutput(data string)
+	RecordResize(width, height uint16)
+	// Finish ends the session in the index and uploads the recording, only the first call is effective
+	Finish(endReason string)
+}
+
+type TerminalSessionRecordingService interface {
+	// StartSession indexes the terminal session and returns its recorder, the events are recorded only if
+	// the recording is enabled for the cluster of the session
+	StartSession(metadata *bean.TerminalSessionMetadata) (SessionRecorder, error)
+	GetSessions(filter *bean.TerminalSessionFilter) (*bean.TerminalSessionListResponse, error)
+	GetSessionById(id int) (*bean.TerminalSessionDto, error)
+	// DownloadRecording downloads the asciicast recording of the session in a local file,
+	// the returned cleanup func removes the file
+	DownloadRecording(id int) (string, func(), error)
+}
+
+type TerminalSessionRecordingServiceImpl struct {
+	logger                             *zap.SugaredLogger
+	config                             *bean.TerminalSessionRecordingConfig
+	ciConfig                           *types.CiConfig
+	blobStorageService                 *blob_storage.BlobStorageServiceImpl
+	terminalSessionRecordingRepository repository.TerminalSessionRecordingRepository
+	asyncRunnable                      *async.Runnable
+}
+
+func NewTerminalSessionRecordingServiceImpl(logger *zap.SugaredLogger,
+	terminalSessionRecordingRepository repository.TerminalSessionRecordingRepository,
+	asyncRunnable *async.Runnable) (*TerminalSessionRecordingServiceImpl, error) {
+	config := &bean.TerminalSessionRecordingConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing terminal session recording config", "err", err)
+		return nil, err
+	}
+	ciConfig, err := types.GetCiConfig()
+	if err != nil {
+		logger.Errorw("error in parsing ci config", "err", err)
+		return nil, err
+	}
+	return &TerminalSessionRecordingServiceImpl{
+		logger:                             logger,
+		config:                             config,
+		ciConfig:                           ciConfig,
+		blobStorageService:                 blob_storage.NewBlobStorageServiceImpl(logger),
+		terminalSessionRecordingRepository: terminalSessionRecordingRepository,
+		asyncRunnable:                      asyncRunnable,
+	}, nil
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) StartSession(metadata *bean.TerminalSessionMetadata) (SessionRecorder, error) {
+	startedOn := time.Now()
+	session := &repository.TerminalSessionRecording{
+		SessionId:       metadata.SessionId,
+		UserId:          metadata.UserId,
+		ClusterId:       metadata.ClusterId,
+		Namespace:       metadata.Namespace,
+		PodName:         metadata.PodName,
+		ContainerName:   metadata.ContainerName,
+		Shell:           metadata.Shell,
+		StartedOn:       startedOn,
+		RecordingStatus: bean.RecordingStatusNotRecorded,
+	}
+	session.CreateAuditLog(metadata.UserId)
+	isRecordingEnabled := impl.config.IsRecordingEnabledForCluster(metadata.ClusterId)
+	if isRecordingEnabled {
+		session.RecordingStatus = bean.RecordingStatusRecording
+		session.RecordingKey = impl.getRecordingKey(metadata, startedOn)
+	}
+	err := impl.terminalSessionRecordingRepository.Save(session)
+	if err != nil {
+		impl.logger.Errorw("error in saving terminal session", "sessionId", metadata.SessionId, "err", err)
+		return nil, err
+	}
+	recorder := &sessionRecorder{
+		service:     impl,
+		session:     session,
+		recordInput: impl.config.RecordInput,
+	}
+	if isRecordingEnabled {
+		err = recorder.open(impl.config.RecordingTempFilePath, impl.config.GetMaxRecordingSizeInBytes())
+		if err != nil {
+			// the session is not blocked on recording failures, it stays indexed with the failed recording status
+			impl.logger.Errorw("error in starting terminal session recording", "sessionId", metadata.SessionId, "err", err)
+			session.RecordingStatus = bean.RecordingStatusFailed
+			session.UpdateAuditLog(metadata.UserId)
+			err = impl.terminalSessionRecordingRepository.Update(session)
+			if err != nil {
+				impl.logger.Errorw("error in updating terminal session", "sessionId", metadata.SessionId, "err", err)
+			}
+		}
+	}
+	return recorder, nil
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) GetSessions(filter *bean.TerminalSessionFilter) (*bean.TerminalSessionListResponse, error) {
+	if filter.Size <= 0 {
+		filter.Size = defaultSessionPageSize
+	} else if filter.Size > maxSessionPageSize {
+		filter.Size = maxSessionPageSize
+	}
+	sessions, totalCount, err := impl.terminalSessionRecordingRepository.FindByFilter(filter)
+	if err != nil {
+		impl.logger.Errorw("error in getting terminal sessions", "filter", filter, "err", err)
+		return nil, err
+	}
+	response := &bean.TerminalSessionListResponse{
+		TotalCount: totalCount,
+		Sessions:   make([]*bean.TerminalSessionDto, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, adaptToTerminalSessionDto(session))
+	}
+	return response, nil
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) GetSessionById(id int) (*bean.TerminalSessionDto, error) {
+	session, err := impl.getSession(id)
+	if err != nil {
+		return nil, err
+	}
+	return adaptToTerminalSessionDto(session), nil
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) DownloadRecording(id int) (string, func(), error) {
+	session, err := impl.getSession(id)
+	if err != nil {
+		return "", nil, err
+	}
+	if session.RecordingStatus != bean.RecordingStatusUploaded {
+		errMsg := fmt.Sprintf("recording is not available for the session, recording status: %s", session.RecordingStatus)
+		return "", nil, util.NewApiError(http.StatusNotFound, errMsg, errMsg)
+	}
+	err = os.MkdirAll(impl.config.RecordingTempFilePath, os.ModePerm)
+	if err != nil {
+		impl.logger.Errorw("error in creating recording directory", "path", impl.config.RecordingTempFilePath, "err", err)
+		return "", nil, err
+	}
+	file, err := os.CreateTemp(impl.config.RecordingTempFilePath, "replay-*"+asciicastFileExtension)
+	if err != nil {
+		impl.logger.Errorw("error in creating recording file", "id", id, "err", err)
+		return "", nil, err
+	}
+	filePath := file.Name()
+	file.Close()
+	cleanUp := func() {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			impl.logger.Errorw("error in removing recording file", "filePath", filePath, "err", err)
+		}
+	}
+	_, _, err = impl.blobStorageService.Get(impl.getBlobStorageRequest(session.RecordingKey, filePath))
+	if err != nil {
+		impl.logger.Errorw("error in downloading terminal session recording", "id", id, "key", session.RecordingKey, "err", err)
+		cleanUp()
+		return "", nil, err
+	}
+	return filePath, cleanUp, nil
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) getSession(id int) (*repository.TerminalSessionRecording, error) {
+	session, err := impl.terminalSessionRecordingRepository.FindById(id)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "terminal session not found", "terminal session not found")
+	} else if err != nil {
+		impl.logger.Errorw("error in getting terminal session", "id", id, "err", err)
+		return nil, err
+	}
+	return session, nil
+}
+
+// finishSession uploads the recording, if any, and marks the session ended in the index
+func (impl *TerminalSessionRecordingServiceImpl) finishSession(recorder *sessionRecorder, endReason string) {
+	session := recorder.session
+	endedOn := time.Now()
+	session.EndedOn = &endedOn
+	session.EndReason = endReason
+	if recorder.writer != nil {
+		session.RecordingSizeInBytes = recorder.writer.BytesWritten()
+		session.RecordingStatus = bean.RecordingStatusUploaded
+		err := recorder.close()
+		if err == nil {
+			err = impl.blobStorageService.PutWithCommand(impl.getBlobStorageRequest(recorder.file.Name(), session.RecordingKey))
+		}
+		if err != nil {
+			impl.logger.Errorw("error in uploading terminal session recording", "sessionId", session.SessionId, "key", session.RecordingKey, "err", err)
+			session.RecordingStatus = bean.RecordingStatusFailed
+		}
+		err = os.Remove(recorder.file.Name())
+		if err != nil {
+			impl.logger.Errorw("error in removing recording file", "filePath", recorder.file.Name(), "err", err)
+		}
+	}
+	session.UpdateAuditLog(session.UserId)
+	err := impl.terminalSessionRecordingRepository.Update(session)
+	if err != nil {
+		impl.logger.Errorw("error in updating terminal session", "sessionId", session.SessionId, "err", err)
+	}
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) getRecordingKey(metadata *bean.TerminalSessionMetadata, startedOn time.Time) string {
+	return path.Join(impl.config.RecordingKeyPrefix, fmt.Sprintf("%d", metadata.ClusterId),
+		startedOn.UTC().Format("2006/01/02"), metadata.SessionId+asciicastFileExtension)
+}
+
+func (impl *TerminalSessionRecordingServiceImpl) getBlobStorageRequest(sourceKey, destinationKey string) *blob_storage.BlobStorageRequest {
+	logRequest := impl.ciConfig.GetBuildLogRequest("", "", "")
+	return &blob_storage.BlobStorageRequest{
+		StorageType:         logRequest.CloudProvider,
+		SourceKey:           sourceKey,
+		DestinationKey:      destinationKey,
+		AzureBlobBaseConfig: logRequest.AzureBlobConfig,
+		AwsS3BaseConfig:     logRequest.AwsS3BaseConfig,
+		GcpBlobBaseConfig:   logRequest.GcpBlobBaseConfig,
+	}
+}
+
+type sessionRecorder struct {
+	service     *TerminalSessionRecordingServiceImpl
+	session     *repository.TerminalSessionRecording
+	recordInput bool
+	// file and writer are nil if the session is not recorded
+	file       *os.File
+	writer     *AsciicastWriter
+	finishOnce sync.Once
+}
+
+func (recorder *sessionRecorder) open(recordingPath string, maxBytes int64) error {
+	err := os.MkdirAll(recordingPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(filepath.Join(recordingPath, recorder.session.SessionId+asciicastFileExtension))
+	if err != nil {
+		return err
+	}
+	header := &bean.AsciicastHeader{
+		Width:     bean.AsciicastDefaultWidth,
+		Height:    bean.AsciicastDefaultHeight,
+		Timestamp: recorder.session.StartedOn.Unix(),
+		Title:     fmt.Sprintf("%s/%s/%s", recorder.session.Namespace, recorder.session.PodName, recorder.session.ContainerName),
+		Env:       map[string]string{"SHELL": recorder.session.Shell},
+	}
+	writer, err := NewAsciicastWriter(file, header, maxBytes)
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	recorder.file = file
+	recorder.writer = writer
+	return nil
+}
+
+func (recorder *sessionRecorder) close() error {
+	writeErr := recorder.writer.Close()
+	err := recorder.file.Close()
+	if writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+func (recorder *sessionRecorder) RecordInput(data string) {
+	if recorder.writer != nil && recorder.recordInput {
+		recorder.writer.WriteEvent(bean.AsciicastEventInput, data)
+	}
+}
+
+func (recorder *sessionRecorder) RecordOutput(data string) {
+	if recorder.writer != nil {
+		recorder.writer.WriteEvent(bean.AsciicastEventOutput, data)
+	}
+}
+
+func (recorder *sessionRecorder) RecordResize(width, height uint16) {
+	if recorder.writer != nil {
+		recorder.writer.WriteResize(width, height)
+	}
+}
+
+func (recorder *sessionRecorder) Finish(endReason string) {
+	recorder.finishOnce.Do(func() {
+		recorder.service.asyncRunnable.Execute(func() {
+			recorder.service.finishSession(recorder, endReason)
+		})
+	})
+}
+
+func adaptToTerminalSessionDto(session *repository.TerminalSessionRecording) *bean.TerminalSessionDto {
+	sessionDto := &bean.TerminalSessionDto{
+		Id:                   session.Id,
+		SessionId:            session.SessionId,
+		UserId:               session.UserId,
+		ClusterId:            session.ClusterId,
+		Namespace:            session.Namespace,
+		PodName:              session.PodName,
+		ContainerName:        session.ContainerName,
+		Shell:                session.Shell,
+		StartedOn:            session.StartedOn,
+		EndedOn:              session.EndedOn,
+		EndReason:            session.EndReason,
+		RecordingStatus:      session.RecordingStatus,
+		RecordingSizeInBytes: session.RecordingSizeInBytes,
+	}
+	if session.User != nil {
+		sessionDto.EmailId = session.User.EmailId
+	}
+	return sessionDto
+}
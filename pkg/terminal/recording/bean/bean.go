@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"slices"
+	"time"
+)
+
+// CATEGORY=INFRA_SETUP
+type TerminalSessionRecordingConfig struct {
+	RecordingEnabled      bool   `env:"TERMINAL_SESSION_RECORDING_ENABLED" envDefault:"false" description:"To record the pod and cluster terminal sessions in asciicast format in the blob storage"`
+	RecordingClusterIds   []int  `env:"TERMINAL_SESSION_RECORDING_CLUSTER_IDS" envSeparator:"," description:"Comma separated ids of the clusters for which the terminal sessions are recorded, sessions of all the clusters are recorded if empty"`
+	RecordInput           bool   `env:"TERMINAL_SESSION_RECORD_INPUT" envDefault:"true" description:"To record the keystrokes along with the output of the terminal session"`
+	MaxRecordingSizeInMb  int    `env:"TERMINAL_SESSION_MAX_RECORDING_SIZE_IN_MB" envDefault:"50" description:"Maximum size of a session recording, events beyond the size are dropped"`
+	RecordingKeyPrefix    string `env:"TERMINAL_SESSION_RECORDING_KEY_PREFIX" envDefault:"terminal-sessions" description:"Prefix of the blob storage key of the session recordings"`
+	RecordingTempFilePath string `env:"TERMINAL_SESSION_RECORDING_TEMP_FILE_PATH" envDefault:"/tmp/terminal-sessions" description:"Local directory in which the recordings are written till the session ends"`
+}
+
+// IsRecordingEnabledForCluster returns true if the sessions of the cluster have to be recorded
+func (config *TerminalSessionRecordingConfig) IsRecordingEnabledForCluster(clusterId int) bool {
+	if !config.RecordingEnabled {
+		return false
+	}
+	return len(config.RecordingClusterIds) == 0 || slices.Contains(config.RecordingClusterIds, clusterId)
+}
+
+func (config *TerminalSessionRecordingConfig) GetMaxRecordingSizeInBytes() int64 {
+	return int64(config.MaxRecordingSizeInMb) * 1024 * 1024
+}
+
+type RecordingStatus string
+
+const (
+	// RecordingStatusNotRecorded is set for the sessions of the clusters for which the recording is not enabled
+	RecordingStatusNotRecorded RecordingStatus = "NOT_RECORDED"
+	RecordingStatusRecording   RecordingStatus = "RECORDING"
+	RecordingStatusUploaded    RecordingStatus = "UPLOADED"
+	RecordingStatusFailed      RecordingStatus = "FAILED"
+)
+
+// AsciicastVersion is the version of the asciicast file format, see https://docs.asciinema.org/manual/asciicast/v2/
+const AsciicastVersion = 2
+
+const (
+	AsciicastDefaultWidth  = 80
+	AsciicastDefaultHeight = 24
+)
+
+type AsciicastEventType string
+
+const (
+	AsciicastEventOutput AsciicastEventType = "o"
+	AsciicastEventInput  AsciicastEventType = "i"
+	AsciicastEventResize AsciicastEventType = "r"
+	AsciicastEventMarker AsciicastEventType = "m"
+)
+
+type AsciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// TerminalSessionMetadata identifies the terminal session to be indexed
+type TerminalSessionMetadata struct {
+	SessionId     string
+	UserId        int32
+	ClusterId     int
+	Namespace     string
+	PodName       string
+	ContainerName string
+	Shell         string
+}
+
+type TerminalSessionFilter struct {
+	UserId    int32
+	ClusterId int
+	Namespace string
+	PodName   string
+	From      *time.Time
+	To        *time.Time
+	Offset    int
+	Size      int
+}
+
+type TerminalSessionDto struct {
+	Id                   int             `json:"id"`
+	SessionId            string          `json:"sessionId"`
+	UserId               int32           `json:"userId"`
+	EmailId              string          `json:"emailId"`
+	ClusterId            int             `json:"clusterId"`
+	Namespace            string          `json:"namespace"`
+	PodName              string          `json:"podName"`
+	ContainerName        string          `json:"containerName"`
+	Shell                string          `json:"shell"`
+	StartedOn            time.Time       `json:"startedOn"`
+	EndedOn              *time.Time      `json:"endedOn,omitempty"`
+	EndReason            string          `json:"endReason,omitempty"`
+	RecordingStatus      RecordingStatus `json:"recordingStatus"`
+	RecordingSizeInBytes int64           `json:"recordingSizeInBytes"`
+}
+
+type TerminalSessionListResponse struct {
+	TotalCount int                   `json:"totalCount"`
+	Sessions   []*TerminalSessionDto `json:"sessions"`
+}
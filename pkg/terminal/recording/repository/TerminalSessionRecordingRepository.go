@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"time"
+
+	"github.com/devtron-labs/devtron/pkg/auth/user/repository"
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording/bean"
+	"github.com/go-pg/pg"
+)
+
+type TerminalSessionRecording struct {
+	TableName            struct{}             `sql:"terminal_session_recording" pg:",discard_unknown_columns"`
+	Id                   int                  `sql:"id,pk"`
+	SessionId            string               `sql:"session_id,notnull"`
+	UserId               int32                `sql:"user_id,notnull"`
+	ClusterId            int                  `sql:"cluster_id"`
+	Namespace            string               `sql:"namespace"`
+	PodName              string               `sql:"pod_name"`
+	ContainerName        string               `sql:"container_name"`
+	Shell                string               `sql:"shell"`
+	StartedOn            time.Time            `sql:"started_on,type:timestamptz"`
+	EndedOn              *time.Time           `sql:"ended_on,type:timestamptz"`
+	EndReason            string               `sql:"end_reason"`
+	RecordingStatus      bean.RecordingStatus `sql:"recording_status,notnull"`
+	RecordingKey         string               `sql:"recording_key"`
+	RecordingSizeInBytes int64                `sql:"recording_size_in_bytes"`
+	User                 *repository.UserModel
+	sql.AuditLog
+}
+
+type TerminalSessionRecordingRepository interface {
+	Save(session *TerminalSessionRecording) error
+	Update(session *TerminalSessionRecording) error
+	FindById(id int) (*TerminalSessionRecording, error)
+	// FindByFilter returns the sessions of the page along with the total count of the sessions matching the filter
+	FindByFilter(filter *bean.TerminalSessionFilter) ([]*TerminalSessionRecording, int, error)
+}
+
+type TerminalSessionRecordingRepositoryImpl struct {
+	dbConnection *pg.DB
+}
+
+func NewTerminalSessionRecordingRepositoryImpl(dbConnection *pg.DB) *TerminalSessionRecordingRepositoryImpl {
+	return &TerminalSessionRecordingRepositoryImpl{dbConnection: dbConnection}
+}
+
+func (impl *TerminalSessionRecordingRepositoryImpl) Save(session *TerminalSessionRecording) error {
+	return impl.dbConnection.Insert(session)
+}
+
+func (impl *TerminalSessionRecordingRepositoryImpl) Update(session *TerminalSessionRecording) error {
+	return impl.dbConnection.Update(session)
+}
+
+func (impl *TerminalSessionRecordingRepositoryImpl) FindById(id int) (*TerminalSessionRecording, error) {
+	session := &TerminalSessionRecording{}
+	err := impl.dbConnection.Model(session).
+		Column("terminal_session_recording.*", "User").
+		Where("terminal_session_recording.id = ?", id).
+		Select()
+	return session, err
+}
+
+func (impl *TerminalSessionRecordingRepositoryImpl) FindByFilter(filter *bean.TerminalSessionFilter) ([]*TerminalSessionRecording, int, error) {
+	var sessions []*TerminalSessionRecording
+	query := impl.dbConnection.Model(&sessions).
+		Column("terminal_session_recording.*", "User")
+	if filter.UserId > 0 {
+		query = query.Where("terminal_session_recording.user_id = ?", filter.UserId)
+	}
+	if filter.ClusterId > 0 {
+		query = query.Where("terminal_session_recording.cluster_id = ?", filter.ClusterId)
+	}
+	if len(filter.Namespace) > 0 {
+		query = query.Where("terminal_session_recording.namespace = ?", filter.Namespace)
+	}
+	if len(filter.PodName) > 0 {
+		query = query.Where("terminal_session_recording.pod_name = ?", filter.PodName)
+	}
+	if filter.From != nil {
+		query = query.Where("terminal_session_recording.started_on >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("terminal_session_recording.started_on <= ?", *filter.To)
+	}
+	count, err := query.Order("terminal_session_recording.id DESC").
+		Offset(filter.Offset).
+		Limit(filter.Size).
+		SelectAndCount()
+	return sessions, count, err
+}
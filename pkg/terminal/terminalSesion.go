@@ -30,6 +30,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/devtron-labs/common-lib/async"
@@ -45,6 +46,8 @@ import (
 	bean2 "github.com/devtron-labs/devtron/pkg/cluster/environment/bean"
 	"github.com/devtron-labs/devtron/pkg/cluster/read"
 	"github.com/devtron-labs/devtron/pkg/cluster/repository"
+	"github.com/devtron-labs/devtron/pkg/terminal/recording"
+	recordingBean "github.com/devtron-labs/devtron/pkg/terminal/recording/bean"
 	errors1 "github.com/juju/errors"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -60,6 +63,10 @@ import (
 const END_OF_TRANSMISSION = "\u0004"
 const ProcessExitedMsg = "Process exited"
 const ProcessTimedOut = "Process timedOut"
+const ProcessIdleTimedOut = "Session closed due to inactivity"
+
+// maxIdleCheckInterval is the max interval at which the idle sessions are checked
+const maxIdleCheckInterval = 30 * time.Second
 
 // PtyHandler is what remotecommand expects from a pty
 type PtyHandler interface {
@@ -81,6 +88,11 @@ type TerminalSession struct {
 	namespace         string
 	clusterId         string
 	startedOn         time.Time
+	// recorder is nil if the session could not be indexed
+	recorder    recording.SessionRecorder
+	idleTimeout time.Duration
+	// lastActiveOn is the unix nano time of the last input of the session
+	lastActiveOn *atomic.Int64
 }
 
 // TerminalMessage is the messaging protocol between ShellController and TerminalSession.
@@ -124,8 +136,15 @@ func (t TerminalSession) Read(p []byte) (int, error) {
 
 	switch msg.Op {
 	case "stdin":
+		t.markActive()
+		if t.recorder != nil {
+			t.recorder.RecordInput(msg.Data)
+		}
 		return copy(p, msg.Data), nil
 	case "resize":
+		if t.recorder != nil {
+			t.recorder.RecordResize(msg.Cols, msg.Rows)
+		}
 		t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
 		return 0, nil
 	default:
@@ -147,9 +166,26 @@ func (t TerminalSession) Write(p []byte) (int, error) {
 	if err = t.sockJSSession.Send(string(msg)); err != nil {
 		return 0, err
 	}
+	if t.recorder != nil {
+		t.recorder.RecordOutput(string(p))
+	}
 	return len(p), nil
 }
 
+func (t TerminalSession) markActive() {
+	if t.lastActiveOn != nil {
+		t.lastActiveOn.Store(time.Now().UnixNano())
+	}
+}
+
+// isIdle returns true if there is no input in the session for the idle timeout
+func (t TerminalSession) isIdle() bool {
+	if t.idleTimeout <= 0 || t.lastActiveOn == nil {
+		return false
+	}
+	return time.Since(time.Unix(0, t.lastActiveOn.Load())) >= t.idleTimeout
+}
+
 // Toast can be used to send the user any OOB messages
 // hterm puts these in the center of the terminal
 func (t TerminalSession) Toast(p string) error {
@@ -246,7 +282,32 @@ func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
 		close(terminalSession.bound)
 		delete(sm.Sessions, sessionId)
 	}
+	if terminalSession.recorder != nil {
+		terminalSession.recorder.Finish(reason)
+	}
+}
 
+// closeOnIdle closes the session once it stays idle for the idle timeout, returns when the session is closed
+func (sm *SessionMap) closeOnIdle(sessionId string) {
+	session := sm.Get(sessionId)
+	if session.idleTimeout <= 0 || session.doneChan == nil {
+		return
+	}
+	session.markActive()
+	ticker := time.NewTicker(min(session.idleTimeout, maxIdleCheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-session.doneChan:
+			return
+		case <-ticker.C:
+			if session.isIdle() {
+				log.Printf("closing idle terminal session, sessionId: %s", sessionId)
+				sm.Close(sessionId, 1, ProcessIdleTimedOut)
+				return
+			}
+		}
+	}
 }
 
 func isConnectionClosedByError(status uint32) bool {
@@ -293,6 +354,19 @@ type SocketConfig struct {
 
 var cfg *SocketConfig
 
+type TerminalSessionConfig struct {
+	IdleTimeoutInMins int `env:"TERMINAL_SESSION_IDLE_TIMEOUT_IN_MINS" envDefault:"30" description:"Pod and cluster terminal sessions without any input for this duration are closed, 0 disables the idle timeout"`
+}
+
+func GetTerminalSessionConfig() (*TerminalSessionConfig, error) {
+	config := &TerminalSessionConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 // CreateAttachHandler is called from main for /api/sockjs
 func CreateAttachHandler(path string) http.Handler {
 	if cfg == nil {
@@ -420,6 +494,7 @@ func WaitForTerminal(k8sClient kubernetes.Interface, cfg *rest.Config, request *
 	timedCtx, _ := context.WithTimeout(sessionCtx, 60*time.Second)
 	select {
 	case <-session.bound:
+		go terminalSessions.closeOnIdle(request.SessionId)
 
 		var err error
 		if isValidShell(validShells, request.Shell) {
@@ -466,12 +541,16 @@ type TerminalSessionHandlerImpl struct {
 	argoApplicationConfigService config.ArgoApplicationConfigService
 	ClusterReadService           read.ClusterReadService
 	asyncRunnable                *async.Runnable
+	recordingService             recording.TerminalSessionRecordingService
+	terminalSessionConfig        *TerminalSessionConfig
 }
 
 func NewTerminalSessionHandlerImpl(environmentService environment.EnvironmentService,
 	logger *zap.SugaredLogger, k8sUtil *k8s.K8sServiceImpl, ephemeralContainerService cluster.EphemeralContainerService,
 	argoApplicationConfigService config.ArgoApplicationConfigService,
-	ClusterReadService read.ClusterReadService, asyncRunnable *async.Runnable) *TerminalSessionHandlerImpl {
+	ClusterReadService read.ClusterReadService, asyncRunnable *async.Runnable,
+	recordingService recording.TerminalSessionRecordingService,
+	terminalSessionConfig *TerminalSessionConfig) *TerminalSessionHandlerImpl {
 	return &TerminalSessionHandlerImpl{
 		environmentService:           environmentService,
 		logger:                       logger,
@@ -480,6 +559,8 @@ func NewTerminalSessionHandlerImpl(environmentService environment.EnvironmentSer
 		argoApplicationConfigService: argoApplicationConfigService,
 		ClusterReadService:           ClusterReadService,
 		asyncRunnable:                asyncRunnable,
+		recordingService:             recordingService,
+		terminalSessionConfig:        terminalSessionConfig,
 	}
 }
 
@@ -511,6 +592,19 @@ func (impl *TerminalSessionHandlerImpl) GetTerminalSession(req *TerminalSessionR
 		return statusCode, nil, err
 	}
 	req.SessionId = sessionID
+	recorder, err := impl.recordingService.StartSession(&recordingBean.TerminalSessionMetadata{
+		SessionId:     sessionID,
+		UserId:        req.UserId,
+		ClusterId:     req.ClusterId,
+		Namespace:     req.Namespace,
+		PodName:       req.PodName,
+		ContainerName: req.ContainerName,
+		Shell:         req.Shell,
+	})
+	if err != nil {
+		// indexing failures do not block the terminal access
+		impl.logger.Errorw("error in starting terminal session recording", "sessionId", sessionID, "err", err)
+	}
 	sessionCtx, cancelFunc := context.WithCancel(context.Background())
 	terminalSessions.Set(sessionID, TerminalSession{
 		id:                sessionID,
@@ -522,6 +616,9 @@ func (impl *TerminalSessionHandlerImpl) GetTerminalSession(req *TerminalSessionR
 		podName:           req.PodName,
 		namespace:         req.Namespace,
 		clusterId:         strconv.Itoa(req.ClusterId),
+		recorder:          recorder,
+		idleTimeout:       time.Duration(impl.terminalSessionConfig.IdleTimeoutInMins) * time.Minute,
+		lastActiveOn:      &atomic.Int64{},
 	})
 	config, client, err := impl.getClientSetAndRestConfigForTerminalConn(req)
 
@@ -534,6 +631,9 @@ func (impl *TerminalSessionHandlerImpl) GetTerminalSession(req *TerminalSessionR
 
 	if err != nil {
 		impl.logger.Errorw("error in fetching config", "err", err)
+		if recorder != nil {
+			recorder.Finish(err.Error())
+		}
 		return http.StatusInternalServerError, nil, err
 	}
 	impl.asyncRunnable.Execute(func() { WaitForTerminal(client, config, req) })
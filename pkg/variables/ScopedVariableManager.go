@@ -100,7 +100,7 @@ func (impl ScopedVariableManagerImpl) GetMappedVariablesAndResolveTemplate(templ
 	}
 
 	for _, variable := range scopedVariables {
-		variableMap[variable.VariableName] = variable.VariableValue.StringValue()
+		variableMap[variable.VariableName] = variable.GetSnapshotValue()
 	}
 
 	if len(variableMap) == 0 {
@@ -135,7 +135,7 @@ func (impl ScopedVariableManagerImpl) ExtractVariablesAndResolveTemplate(scope r
 	}
 
 	for _, variable := range scopedVariables {
-		variableSnapshot[variable.VariableName] = variable.VariableValue.StringValue()
+		variableSnapshot[variable.VariableName] = variable.GetSnapshotValue()
 	}
 
 	if maskUnknownVariable {
@@ -200,7 +200,12 @@ func (impl ScopedVariableManagerImpl) GetVariableSnapshotAndResolveTemplate(temp
 		return variableSnapshotMap, template, err
 	}
 
-	scopedVariableData := parsers.GetScopedVarData(variableSnapshotMap, varNameToIsSensitive, isSuperAdmin)
+	// snapshots hold references of secrets, these are fetched from the providers only to resolve the template
+	resolvedSnapshotMap, err := impl.scopedVariableService.ResolveSecretReferences(variableSnapshotMap, isSuperAdmin)
+	if err != nil {
+		return variableSnapshotMap, template, err
+	}
+	scopedVariableData := parsers.GetScopedVarData(resolvedSnapshotMap, varNameToIsSensitive, isSuperAdmin)
 	request := parsers.VariableParserRequest{Template: template, TemplateType: templateType, Variables: scopedVariableData, IgnoreUnknownVariables: ignoreUnknown}
 
 	resolvedTemplate, err := impl.ParseTemplateWithScopedVariables(request)
@@ -253,7 +258,7 @@ func (impl ScopedVariableManagerImpl) GetMappedVariablesAndResolveTemplateBatch(
 
 	variableSnapshot := make(map[string]string)
 	for _, variable := range scopedVariables {
-		variableSnapshot[variable.VariableName] = variable.VariableValue.StringValue()
+		variableSnapshot[variable.VariableName] = variable.GetSnapshotValue()
 	}
 
 	request := parsers.VariableParserRequest{
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variables
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/devtron-labs/devtron/pkg/variables/cache"
+	"github.com/devtron-labs/devtron/pkg/variables/models"
+	"github.com/devtron-labs/devtron/pkg/variables/parsers"
+	"github.com/devtron-labs/devtron/pkg/variables/repository"
+	"github.com/devtron-labs/devtron/pkg/variables/secretProvider"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecretValue = "s3cr3t-db-password"
+
+type secretProviderStub struct {
+	secrets map[string]string
+}
+
+func (impl *secretProviderStub) GetSecret(reference *secretProvider.SecretReference) (string, error) {
+	return impl.secrets[reference.Path+"#"+reference.Key], nil
+}
+
+type secretProviderFactoryStub struct {
+	secretProvider.SecretProviderFactory
+	provider *secretProviderStub
+}
+
+func (impl *secretProviderFactoryStub) GetProvider(providerType secretProvider.SecretProviderType) (secretProvider.SecretProvider, error) {
+	return impl.provider, nil
+}
+
+func (impl *secretProviderFactoryStub) GetCacheTTL() time.Duration {
+	return 0
+}
+
+// scopedVariableServiceStub returns the secret variable as resolved by GetScopedVariables for an unmasked render
+type scopedVariableServiceStub struct {
+	ScopedVariableService
+	secretResolver *ScopedVariableServiceImpl
+}
+
+func (impl *scopedVariableServiceStub) GetScopedVariables(scope resourceQualifiers.Scope, varNames []string, unmaskSensitiveData bool) ([]*models.ScopedVariableData, error) {
+	return []*models.ScopedVariableData{
+		{VariableName: "db-password", VariableValue: &models.VariableValue{Value: testSecretValue},
+			SecretReference: models.GetSecretReferenceValue(string(secretProvider.VAULT), "db/prod#password")},
+		{VariableName: "db-user", VariableValue: &models.VariableValue{Value: "admin"}},
+	}, nil
+}
+
+func (impl *scopedVariableServiceStub) GetFormattedVariableForName(name string) string {
+	return "@{{" + name + "}}"
+}
+
+func (impl *scopedVariableServiceStub) CheckForSensitiveVariables(variableNames []string) (map[string]bool, error) {
+	return map[string]bool{"db-password": true}, nil
+}
+
+func (impl *scopedVariableServiceStub) ResolveSecretReferences(variableSnapshot map[string]string, unmaskSensitiveData bool) (map[string]string, error) {
+	return impl.secretResolver.ResolveSecretReferences(variableSnapshot, unmaskSensitiveData)
+}
+
+type variableEntityMappingServiceStub struct {
+	VariableEntityMappingService
+}
+
+func (impl *variableEntityMappingServiceStub) GetAllMappingsForEntities(entities []repository.Entity) (map[repository.Entity][]string, error) {
+	mappings := make(map[repository.Entity][]string)
+	for _, entity := range entities {
+		mappings[entity] = []string{"db-password", "db-user"}
+	}
+	return mappings, nil
+}
+
+type variableSnapshotHistoryServiceStub struct {
+	VariableSnapshotHistoryService
+	snapshots map[repository.HistoryReference]*repository.VariableSnapshotHistoryBean
+}
+
+func (impl *variableSnapshotHistoryServiceStub) GetVariableHistoryForReferences(references []repository.HistoryReference) (map[repository.HistoryReference]*repository.VariableSnapshotHistoryBean, error) {
+	return impl.snapshots, nil
+}
+
+func getScopedVariableManagerForTest(t *testing.T, snapshots map[repository.HistoryReference]*repository.VariableSnapshotHistoryBean) *ScopedVariableManagerImpl {
+	t.Setenv("SCOPED_VARIABLE_ENABLED", "true")
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	templateParser, err := parsers.NewVariableTemplateParserImpl(logger)
+	assert.NoError(t, err)
+	secretResolver := &ScopedVariableServiceImpl{
+		logger:                logger,
+		VariableCache:         &cache.VariableCacheObj{CacheLock: &sync.Mutex{}},
+		secretProviderFactory: &secretProviderFactoryStub{provider: &secretProviderStub{secrets: map[string]string{"db/prod#password": testSecretValue}}},
+	}
+	return &ScopedVariableManagerImpl{
+		logger:                         logger,
+		scopedVariableService:          &scopedVariableServiceStub{secretResolver: secretResolver},
+		variableEntityMappingService:   &variableEntityMappingServiceStub{},
+		variableSnapshotHistoryService: &variableSnapshotHistoryServiceStub{snapshots: snapshots},
+		variableTemplateParser:         templateParser,
+	}
+}
+
+func TestScopedVariableManagerImpl_SnapshotDoesNotContainSecrets(t *testing.T) {
+	secretReference := models.GetSecretReferenceValue(string(secretProvider.VAULT), "db/prod#password")
+	assertSnapshot := func(t *testing.T, resolvedTemplate string, snapshot map[string]string) {
+		assert.Contains(t, resolvedTemplate, testSecretValue)
+		assert.Equal(t, secretReference, snapshot["db-password"])
+		assert.Equal(t, "admin", snapshot["db-user"])
+		snapshotJson, err := json.Marshal(snapshot)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(snapshotJson), testSecretValue)
+	}
+	scope := resourceQualifiers.Scope{AppId: 1, EnvId: 2}
+	entity := repository.Entity{EntityType: repository.EntityTypeDeploymentTemplateAppLevel, EntityId: 1}
+
+	t.Run("mapped variables of an entity", func(t *testing.T) {
+		impl := getScopedVariableManagerForTest(t, nil)
+		resolvedTemplate, snapshot, err := impl.GetMappedVariablesAndResolveTemplate(`{"password": "@{{db-password}}", "user": "@{{db-user}}"}`, scope, entity, true)
+		assert.NoError(t, err)
+		assertSnapshot(t, resolvedTemplate, snapshot)
+	})
+
+	t.Run("variables extracted from the template", func(t *testing.T) {
+		impl := getScopedVariableManagerForTest(t, nil)
+		resolvedTemplate, snapshot, err := impl.ExtractVariablesAndResolveTemplate(scope, "password=@{{db-password}} user=@{{db-user}}", parsers.StringVariableTemplate, true, false)
+		assert.NoError(t, err)
+		assertSnapshot(t, resolvedTemplate, snapshot)
+	})
+
+	t.Run("mapped variables of a batch of entities", func(t *testing.T) {
+		impl := getScopedVariableManagerForTest(t, nil)
+		resolvedTemplate, snapshot, err := impl.GetMappedVariablesAndResolveTemplateBatch("password=@{{db-password}} user=@{{db-user}}", scope, []repository.Entity{entity})
+		assert.NoError(t, err)
+		assertSnapshot(t, resolvedTemplate, snapshot)
+	})
+
+	t.Run("secret is fetched again when the template is resolved from the snapshot", func(t *testing.T) {
+		reference := repository.HistoryReference{HistoryReferenceId: 5, HistoryReferenceType: repository.HistoryReferenceTypeDeploymentTemplate}
+		snapshotJson, err := json.Marshal(map[string]string{"db-password": secretReference, "db-user": "admin"})
+		assert.NoError(t, err)
+		impl := getScopedVariableManagerForTest(t, map[repository.HistoryReference]*repository.VariableSnapshotHistoryBean{
+			reference: {VariableSnapshot: snapshotJson, HistoryReference: reference},
+		})
+
+		snapshot, resolvedTemplate, err := impl.GetVariableSnapshotAndResolveTemplate("password=@{{db-password}}", parsers.StringVariableTemplate, reference, true, false)
+		assert.NoError(t, err)
+		assertSnapshot(t, resolvedTemplate, snapshot)
+
+		_, resolvedTemplate, err = impl.GetVariableSnapshotAndResolveTemplate("password=@{{db-password}}", parsers.StringVariableTemplate, reference, false, false)
+		assert.NoError(t, err)
+		assert.NotContains(t, resolvedTemplate, testSecretValue)
+		assert.Contains(t, resolvedTemplate, models.HiddenValue)
+	})
+}
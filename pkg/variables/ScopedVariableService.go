@@ -17,7 +17,10 @@
 package variables
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
 	"regexp"
 	"strings"
 	"sync"
@@ -25,7 +28,11 @@ import (
 	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/caarlos0/env"
 	"github.com/devtron-labs/common-lib/async"
+	"github.com/devtron-labs/common-lib/securestore"
 	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/util"
+	repository4 "github.com/devtron-labs/devtron/pkg/auth/user/repository"
 	repository3 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
 	"github.com/devtron-labs/devtron/pkg/cluster/repository"
 	"github.com/devtron-labs/devtron/pkg/devtronResource/read"
@@ -35,6 +42,7 @@ import (
 	"github.com/devtron-labs/devtron/pkg/variables/helper"
 	"github.com/devtron-labs/devtron/pkg/variables/models"
 	repository2 "github.com/devtron-labs/devtron/pkg/variables/repository"
+	"github.com/devtron-labs/devtron/pkg/variables/secretProvider"
 	"github.com/devtron-labs/devtron/pkg/variables/utils"
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
@@ -49,6 +57,16 @@ type ScopedVariableService interface {
 	GetFormattedVariableForName(name string) string
 	GetMatchedScopedVariables(varScope []*resourceQualifiers.QualifierMapping) map[int][]*resourceQualifiers.QualifierMapping
 	GetScopeWithPriority(variableIdToVariableScopes map[int][]*resourceQualifiers.QualifierMapping) map[int]int
+	// GetResolvedValuesDiff resolves the variables for the app in each of the environments, all environments of the app are compared when envIds is empty
+	GetResolvedValuesDiff(appId int, envIds []int, varNames []string, unmaskSensitiveData bool) ([]*models.VariableEnvDiff, error)
+	// GetVariableVersions returns a page of the saved versions, latest first
+	GetVariableVersions(offset int, size int) ([]*models.VariablePayloadVersion, error)
+	GetPayloadForVersion(version int) (*models.Payload, error)
+	GetVersionDiff(fromVersion, toVersion int) (*models.VariableVersionDiff, error)
+	// RollbackToVersion saves the payload of the version as the latest version of variables
+	RollbackToVersion(version int, userId int32) error
+	// ResolveSecretReferences replaces the secret references saved in variable snapshots with the secrets from their providers
+	ResolveSecretReferences(variableSnapshot map[string]string, unmaskSensitiveData bool) (map[string]string, error)
 }
 
 type ScopedVariableServiceImpl struct {
@@ -58,16 +76,28 @@ type ScopedVariableServiceImpl struct {
 	VariableNameConfig       *VariableConfig
 	VariableCache            *cache.VariableCacheObj
 	asyncRunnable            *async.Runnable
+	appRepository            app.AppRepository
+	environmentRepository    repository3.EnvironmentRepository
+	pipelineRepository       pipelineConfig.PipelineRepository
+	secretProviderFactory    secretProvider.SecretProviderFactory
+	userRepository           repository4.UserRepository
 }
 
 func NewScopedVariableServiceImpl(logger *zap.SugaredLogger, scopedVariableRepository repository2.ScopedVariableRepository, appRepository app.AppRepository, environmentRepository repository3.EnvironmentRepository, devtronResourceSearchableKeyService read.DevtronResourceSearchableKeyService, clusterRepository repository.ClusterRepository,
-	qualifierMappingService resourceQualifiers.QualifierMappingService, asyncRunnable *async.Runnable) (*ScopedVariableServiceImpl, error) {
+	qualifierMappingService resourceQualifiers.QualifierMappingService, asyncRunnable *async.Runnable,
+	pipelineRepository pipelineConfig.PipelineRepository, secretProviderFactory secretProvider.SecretProviderFactory,
+	userRepository repository4.UserRepository) (*ScopedVariableServiceImpl, error) {
 	scopedVariableService := &ScopedVariableServiceImpl{
 		logger:                   logger,
 		scopedVariableRepository: scopedVariableRepository,
 		qualifierMappingService:  qualifierMappingService,
 		VariableCache:            &cache.VariableCacheObj{CacheLock: &sync.Mutex{}},
 		asyncRunnable:            asyncRunnable,
+		appRepository:            appRepository,
+		environmentRepository:    environmentRepository,
+		pipelineRepository:       pipelineRepository,
+		secretProviderFactory:    secretProviderFactory,
+		userRepository:           userRepository,
 	}
 	cfg, err := GetVariableNameConfig()
 	if err != nil {
@@ -151,6 +181,10 @@ func (impl *ScopedVariableServiceImpl) CheckForSensitiveVariables(variableNames
 }
 
 func (impl *ScopedVariableServiceImpl) CreateVariables(payload models.Payload) error {
+	return impl.createVariables(payload, 0)
+}
+
+func (impl *ScopedVariableServiceImpl) createVariables(payload models.Payload, rolledBackFromVersion int) error {
 	err, _ := impl.isValidPayload(payload)
 	if err != nil {
 		impl.logger.Errorw("error in variable payload validation", "err", err)
@@ -199,11 +233,16 @@ func (impl *ScopedVariableServiceImpl) CreateVariables(payload models.Payload) e
 		}
 
 	}
+	err = impl.storePayloadVersion(payload, rolledBackFromVersion, auditLog, tx)
+	if err != nil {
+		return err
+	}
 	err = impl.scopedVariableRepository.CommitTx(tx)
 	if err != nil {
 		impl.logger.Errorw("error in committing transaction of variable creation", "err", err)
 		return err
 	}
+	impl.VariableCache.ResetSecrets()
 	loadVariableCache(impl.VariableNameConfig, impl)
 	return nil
 }
@@ -228,10 +267,40 @@ func (impl *ScopedVariableServiceImpl) storeVariableData(scopeIdToVarData map[in
 	return nil
 }
 
+func (impl *ScopedVariableServiceImpl) storePayloadVersion(payload models.Payload, rolledBackFromVersion int, auditLog sql.AuditLog, tx *pg.Tx) error {
+	payloadJson, err := json.Marshal(payload)
+	if err != nil {
+		impl.logger.Errorw("error in marshalling variable payload", "err", err)
+		return err
+	}
+	latestVersion, err := impl.scopedVariableRepository.GetLatestVariablePayloadVersion(tx)
+	if err != nil {
+		impl.logger.Errorw("error in getting latest variable payload version", "err", err)
+		return err
+	}
+	payloadVersion := &repository2.VariablePayloadVersion{
+		Version:               latestVersion + 1,
+		Payload:               securestore.ToEncryptedString(string(payloadJson)),
+		VariableCount:         len(payload.Variables),
+		RolledBackFromVersion: rolledBackFromVersion,
+		AuditLog:              auditLog,
+	}
+	err = impl.scopedVariableRepository.CreateVariablePayloadVersion(payloadVersion, tx)
+	if err != nil {
+		impl.logger.Errorw("error in saving variable payload version", "version", payloadVersion.Version, "err", err)
+		return err
+	}
+	return nil
+}
+
 func (impl *ScopedVariableServiceImpl) storeVariableDefinitions(payload models.Payload, auditLog sql.AuditLog, tx *pg.Tx) (map[string]int, error) {
 	variableDefinitions := make([]*repository2.VariableDefinition, 0, len(payload.Variables))
 	for _, variable := range payload.Variables {
-		variableDefinition := repository2.CreateFromDefinition(variable.Definition, auditLog)
+		variableDefinition, err := repository2.CreateFromDefinition(variable.Definition, auditLog)
+		if err != nil {
+			impl.logger.Errorw("error in creating variable definition", "varName", variable.Definition.VarName, "err", err)
+			return nil, err
+		}
 		variableDefinitions = append(variableDefinitions, variableDefinition)
 	}
 	varDef, err := impl.scopedVariableRepository.CreateVariableDefinition(variableDefinitions, tx)
@@ -458,9 +527,22 @@ func (impl *ScopedVariableServiceImpl) GetScopedVariables(scope resourceQualifie
 
 		var varValue *models.VariableValue
 		var isRedacted bool
+		var secretReference string
 		if !unmaskSensitiveData && variableIdToDefinition[varId].VarType == models.PRIVATE {
 			varValue = &models.VariableValue{Value: models.HiddenValue}
 			isRedacted = true
+		} else if len(variableIdToDefinition[varId].SecretProvider) > 0 {
+			// secret is fetched only when the value is unmasked i.e. at render time
+			reference, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("secret reference of variable %s is not a string", variableIdToDefinition[varId].Name)
+			}
+			secretReference = models.GetSecretReferenceValue(variableIdToDefinition[varId].SecretProvider, reference)
+			secretValue, err := impl.resolveSecretValue(variableIdToDefinition[varId].Name, variableIdToDefinition[varId].SecretProvider, reference)
+			if err != nil {
+				return nil, err
+			}
+			varValue = &models.VariableValue{Value: secretValue}
 		} else {
 			varValue = &models.VariableValue{Value: value}
 		}
@@ -468,7 +550,8 @@ func (impl *ScopedVariableServiceImpl) GetScopedVariables(scope resourceQualifie
 			VariableName:     variableIdToDefinition[varId].Name,
 			ShortDescription: variableIdToDefinition[varId].ShortDescription,
 			VariableValue:    varValue,
-			IsRedacted:       isRedacted}
+			IsRedacted:       isRedacted,
+			SecretReference:  secretReference}
 
 		scopedVariableDataObj = append(scopedVariableDataObj, scopedVariableData)
 	}
@@ -499,6 +582,54 @@ func (impl *ScopedVariableServiceImpl) GetScopedVariables(scope resourceQualifie
 	return usedScopedVariableDataObj, err
 }
 
+// ResolveSecretReferences replaces the secret references saved in a variable snapshot with the secrets fetched from their providers,
+// references are replaced with the hidden value when sensitive data is not to be unmasked
+func (impl *ScopedVariableServiceImpl) ResolveSecretReferences(variableSnapshot map[string]string, unmaskSensitiveData bool) (map[string]string, error) {
+	resolvedSnapshot := make(map[string]string, len(variableSnapshot))
+	for varName, value := range variableSnapshot {
+		provider, reference, ok := models.ParseSecretReferenceValue(value)
+		if !ok {
+			resolvedSnapshot[varName] = value
+			continue
+		}
+		if !unmaskSensitiveData {
+			resolvedSnapshot[varName] = models.HiddenValue
+			continue
+		}
+		secretValue, err := impl.resolveSecretValue(varName, provider, reference)
+		if err != nil {
+			return nil, err
+		}
+		resolvedSnapshot[varName] = secretValue
+	}
+	return resolvedSnapshot, nil
+}
+
+// resolveSecretValue fetches the secret referenced by the value of the variable from its provider, resolved values are cached for the configured ttl
+func (impl *ScopedVariableServiceImpl) resolveSecretValue(varName string, providerType string, reference string) (string, error) {
+	cacheKey := fmt.Sprintf("%s:%s", providerType, reference)
+	if secretValue, found := impl.VariableCache.GetSecret(cacheKey); found {
+		return secretValue, nil
+	}
+	secretReference, err := secretProvider.ParseSecretReference(reference)
+	if err != nil {
+		impl.logger.Errorw("error in parsing secret reference", "varName", varName, "err", err)
+		return "", err
+	}
+	provider, err := impl.secretProviderFactory.GetProvider(secretProvider.SecretProviderType(providerType))
+	if err != nil {
+		impl.logger.Errorw("error in getting secret provider", "varName", varName, "provider", providerType, "err", err)
+		return "", err
+	}
+	secretValue, err := provider.GetSecret(secretReference)
+	if err != nil {
+		impl.logger.Errorw("error in resolving secret of variable", "varName", varName, "provider", providerType, "err", err)
+		return "", err
+	}
+	impl.VariableCache.SetSecret(cacheKey, secretValue, impl.secretProviderFactory.GetCacheTTL())
+	return secretValue, nil
+}
+
 func resolveExpressionWithVariableValues(expr string, varNameToData map[string]*models.ScopedVariableData) (string, error) {
 	// regex to find  variable placeholder and extracts a variable name which is alphanumeric
 	// and can contain hyphen, underscore and whitespaces. white spaces will be trimmed on lookup
@@ -593,12 +724,19 @@ func (impl *ScopedVariableServiceImpl) GetJsonForVariables() (*models.Payload, e
 	}
 
 	for _, data := range dataForJson {
+		schema, err := data.GetSchema()
+		if err != nil {
+			impl.logger.Errorw("error in parsing variable schema", "varName", data.Name, "err", err)
+			return nil, err
+		}
 		definition := models.Definition{
 			VarName:          data.Name,
 			DataType:         data.DataType,
 			VarType:          data.VarType,
 			Description:      data.Description,
 			ShortDescription: data.ShortDescription,
+			Schema:           schema,
+			SecretProvider:   data.SecretProvider,
 		}
 		attributes := make([]models.AttributeValue, 0)
 
@@ -690,3 +828,266 @@ func (impl *ScopedVariableServiceImpl) getVariableScopeData(scopeIds []int) (map
 	}
 	return scopeIdVsVarDataMap, nil
 }
+
+func (impl *ScopedVariableServiceImpl) GetResolvedValuesDiff(appId int, envIds []int, varNames []string, unmaskSensitiveData bool) ([]*models.VariableEnvDiff, error) {
+	appDetail, err := impl.appRepository.FindById(appId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching app", "appId", appId, "err", err)
+		return nil, err
+	}
+	if len(envIds) == 0 {
+		pipelines, err := impl.pipelineRepository.FindActiveByAppId(appId)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in fetching cd pipelines of app", "appId", appId, "err", err)
+			return nil, err
+		}
+		for _, pipeline := range pipelines {
+			envIds = append(envIds, pipeline.EnvironmentId)
+		}
+	}
+	variableDiffs := make([]*models.VariableEnvDiff, 0)
+	if len(envIds) == 0 {
+		return variableDiffs, nil
+	}
+	envIdPtrs := make([]*int, 0, len(envIds))
+	for i := range envIds {
+		envIdPtrs = append(envIdPtrs, &envIds[i])
+	}
+	envs, err := impl.environmentRepository.FindByIds(envIdPtrs)
+	if err != nil {
+		impl.logger.Errorw("error in fetching environments", "envIds", envIds, "err", err)
+		return nil, err
+	}
+	envClusterInfos, err := impl.environmentRepository.FindEnvClusterInfosByIds(envIds)
+	if err != nil {
+		impl.logger.Errorw("error in fetching cluster of environments", "envIds", envIds, "err", err)
+		return nil, err
+	}
+	envIdToClusterName := make(map[int]string, len(envClusterInfos))
+	for _, envClusterInfo := range envClusterInfos {
+		envIdToClusterName[envClusterInfo.Id] = envClusterInfo.ClusterName
+	}
+
+	variableNameToDiff := make(map[string]*models.VariableEnvDiff)
+	for envIndex, env := range envs {
+		scope := resourceQualifiers.Scope{
+			AppId:     appId,
+			EnvId:     env.Id,
+			ClusterId: env.ClusterId,
+			SystemMetadata: &resourceQualifiers.SystemMetadata{
+				EnvironmentName: env.Name,
+				ClusterName:     envIdToClusterName[env.Id],
+				Namespace:       env.Namespace,
+				AppName:         appDetail.AppName,
+			},
+		}
+		scopedVariables, err := impl.GetScopedVariables(scope, varNames, unmaskSensitiveData)
+		if err != nil {
+			impl.logger.Errorw("error in resolving variables for env", "appId", appId, "envId", env.Id, "err", err)
+			return nil, err
+		}
+		resolvedVariableNames := make(map[string]bool, len(scopedVariables))
+		for _, scopedVariable := range scopedVariables {
+			variableDiff, ok := variableNameToDiff[scopedVariable.VariableName]
+			if !ok {
+				// environments compared before this one had no value for the variable
+				variableDiff = &models.VariableEnvDiff{VariableName: scopedVariable.VariableName}
+				for _, previousEnv := range envs[:envIndex] {
+					variableDiff.Values = append(variableDiff.Values, getUndefinedResolvedValue(previousEnv, envIdToClusterName))
+				}
+				variableNameToDiff[scopedVariable.VariableName] = variableDiff
+				variableDiffs = append(variableDiffs, variableDiff)
+			}
+			resolvedValue := &models.VariableResolvedValue{
+				EnvId:         env.Id,
+				EnvName:       env.Name,
+				ClusterName:   envIdToClusterName[env.Id],
+				VariableValue: scopedVariable.VariableValue,
+				IsRedacted:    scopedVariable.IsRedacted,
+				IsUndefined:   scopedVariable.VariableValue == nil,
+			}
+			variableDiff.Values = append(variableDiff.Values, resolvedValue)
+			resolvedVariableNames[scopedVariable.VariableName] = true
+		}
+		for _, variableDiff := range variableDiffs {
+			if !resolvedVariableNames[variableDiff.VariableName] {
+				variableDiff.Values = append(variableDiff.Values, getUndefinedResolvedValue(env, envIdToClusterName))
+			}
+		}
+	}
+	for _, variableDiff := range variableDiffs {
+		variableDiff.IsDrifted = isDrifted(variableDiff.Values)
+	}
+	return variableDiffs, nil
+}
+
+func getUndefinedResolvedValue(env *repository3.Environment, envIdToClusterName map[int]string) *models.VariableResolvedValue {
+	return &models.VariableResolvedValue{
+		EnvId:       env.Id,
+		EnvName:     env.Name,
+		ClusterName: envIdToClusterName[env.Id],
+		IsUndefined: true,
+	}
+}
+
+// isDrifted compares the resolved values, redacted values are compared only on whether they are defined
+func isDrifted(values []*models.VariableResolvedValue) bool {
+	for _, value := range values[1:] {
+		first := values[0]
+		if value.IsUndefined != first.IsUndefined {
+			return true
+		}
+		if value.IsUndefined || value.IsRedacted || first.IsRedacted {
+			continue
+		}
+		if !reflect.DeepEqual(value.VariableValue.Value, first.VariableValue.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (impl *ScopedVariableServiceImpl) GetVariableVersions(offset int, size int) ([]*models.VariablePayloadVersion, error) {
+	payloadVersions, err := impl.scopedVariableRepository.GetVariablePayloadVersions(offset, size)
+	if err != nil {
+		impl.logger.Errorw("error in getting variable payload versions", "offset", offset, "size", size, "err", err)
+		return nil, err
+	}
+	userIds := make([]int32, 0, len(payloadVersions))
+	for _, payloadVersion := range payloadVersions {
+		if !slices.Contains(userIds, payloadVersion.CreatedBy) {
+			userIds = append(userIds, payloadVersion.CreatedBy)
+		}
+	}
+	userIdToEmail := make(map[int32]string)
+	if len(userIds) > 0 {
+		users, err := impl.userRepository.GetByIds(userIds)
+		if err != nil && !util.IsErrNoRows(err) {
+			impl.logger.Errorw("error in getting users of variable payload versions", "userIds", userIds, "err", err)
+			return nil, err
+		}
+		for _, user := range users {
+			userIdToEmail[user.Id] = user.EmailId
+		}
+	}
+	versions := make([]*models.VariablePayloadVersion, 0, len(payloadVersions))
+	for _, payloadVersion := range payloadVersions {
+		versions = append(versions, &models.VariablePayloadVersion{
+			Version:               payloadVersion.Version,
+			VariableCount:         payloadVersion.VariableCount,
+			CreatedBy:             payloadVersion.CreatedBy,
+			CreatedByEmail:        userIdToEmail[payloadVersion.CreatedBy],
+			CreatedOn:             payloadVersion.CreatedOn,
+			RolledBackFromVersion: payloadVersion.RolledBackFromVersion,
+		})
+	}
+	return versions, nil
+}
+
+func (impl *ScopedVariableServiceImpl) GetPayloadForVersion(version int) (*models.Payload, error) {
+	payloadVersion, err := impl.scopedVariableRepository.GetVariablePayloadVersion(version)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, fmt.Sprintf("version %d of variables not found", version), err.Error())
+	} else if err != nil {
+		impl.logger.Errorw("error in getting variable payload version", "version", version, "err", err)
+		return nil, err
+	}
+	payload := &models.Payload{}
+	// numbers are kept as json.Number, the same as in the create request, so that the payload can be saved again as is
+	decoder := json.NewDecoder(strings.NewReader(payloadVersion.Payload.String()))
+	decoder.UseNumber()
+	err = decoder.Decode(payload)
+	if err != nil {
+		impl.logger.Errorw("error in decoding variable payload version", "version", version, "err", err)
+		return nil, err
+	}
+	return payload, nil
+}
+
+func (impl *ScopedVariableServiceImpl) GetVersionDiff(fromVersion, toVersion int) (*models.VariableVersionDiff, error) {
+	fromPayload, err := impl.GetPayloadForVersion(fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	toPayload, err := impl.GetPayloadForVersion(toVersion)
+	if err != nil {
+		return nil, err
+	}
+	fromVariables := make(map[string]*models.Variables, len(fromPayload.Variables))
+	for _, variable := range fromPayload.Variables {
+		fromVariables[variable.Definition.VarName] = variable
+	}
+	diff := &models.VariableVersionDiff{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     make([]*models.VariableChange, 0),
+	}
+	for _, toVariable := range toPayload.Variables {
+		varName := toVariable.Definition.VarName
+		fromVariable, ok := fromVariables[varName]
+		if !ok {
+			diff.Changes = append(diff.Changes, &models.VariableChange{VariableName: varName, ChangeType: models.VariableAdded, To: toVariable})
+			continue
+		}
+		delete(fromVariables, varName)
+		if changedFields := getChangedFields(fromVariable, toVariable); len(changedFields) > 0 {
+			diff.Changes = append(diff.Changes, &models.VariableChange{VariableName: varName, ChangeType: models.VariableModified, ChangedFields: changedFields, From: fromVariable, To: toVariable})
+		}
+	}
+	// iterating over the from payload to keep the order of deleted variables stable
+	for _, fromVariable := range fromPayload.Variables {
+		if _, ok := fromVariables[fromVariable.Definition.VarName]; ok {
+			diff.Changes = append(diff.Changes, &models.VariableChange{VariableName: fromVariable.Definition.VarName, ChangeType: models.VariableDeleted, From: fromVariable})
+		}
+	}
+	return diff, nil
+}
+
+func getChangedFields(from, to *models.Variables) []string {
+	changedFields := make([]string, 0)
+	fromDefinition, toDefinition := from.Definition, to.Definition
+	if fromDefinition.DataType != toDefinition.DataType {
+		changedFields = append(changedFields, "dataType")
+	}
+	if fromDefinition.VarType != toDefinition.VarType {
+		changedFields = append(changedFields, "varType")
+	}
+	if fromDefinition.Description != toDefinition.Description {
+		changedFields = append(changedFields, "description")
+	}
+	if fromDefinition.ShortDescription != toDefinition.ShortDescription {
+		changedFields = append(changedFields, "shortDescription")
+	}
+	if !reflect.DeepEqual(fromDefinition.Schema, toDefinition.Schema) {
+		changedFields = append(changedFields, "schema")
+	}
+	if fromDefinition.SecretProvider != toDefinition.SecretProvider {
+		changedFields = append(changedFields, "secretProvider")
+	}
+	if !reflect.DeepEqual(from.AttributeValues, to.AttributeValues) {
+		changedFields = append(changedFields, "values")
+	}
+	return changedFields
+}
+
+func (impl *ScopedVariableServiceImpl) RollbackToVersion(version int, userId int32) error {
+	payload, err := impl.GetPayloadForVersion(version)
+	if err != nil {
+		return err
+	}
+	currentVersion, err := impl.scopedVariableRepository.GetCurrentVariablePayloadVersion()
+	if err != nil {
+		impl.logger.Errorw("error in getting current variable payload version", "err", err)
+		return err
+	}
+	if currentVersion == version {
+		return util.NewApiError(http.StatusBadRequest, fmt.Sprintf("version %d is already the current version of variables", version), "rollback to the current version")
+	}
+	payload.UserId = userId
+	err = impl.createVariables(*payload, version)
+	if err != nil {
+		impl.logger.Errorw("error in rolling back variables", "version", version, "err", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variables
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/devtron-labs/common-lib/securestore"
+	"github.com/devtron-labs/devtron/internal/sql/repository/app"
+	"github.com/devtron-labs/devtron/internal/util"
+	repository3 "github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
+	"github.com/devtron-labs/devtron/pkg/variables/cache"
+	"github.com/devtron-labs/devtron/pkg/variables/models"
+	"github.com/devtron-labs/devtron/pkg/variables/repository"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateValuesAgainstSchema(t *testing.T) {
+	portSchema := map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 65535}
+	tests := []struct {
+		name        string
+		schema      map[string]interface{}
+		values      []interface{}
+		wantErr     bool
+		errContains string
+	}{
+		{name: "no schema", values: []interface{}{"anything"}},
+		{name: "values match the schema", schema: portSchema, values: []interface{}{80, 8080}},
+		{name: "value of wrong type", schema: portSchema, values: []interface{}{80, "http"}, wantErr: true, errContains: "does not match its schema"},
+		{name: "value out of range", schema: portSchema, values: []interface{}{70000}, wantErr: true, errContains: "Must be less than or equal to 65535"},
+		{
+			name:    "object value with missing required property",
+			schema:  map[string]interface{}{"type": "object", "required": []interface{}{"host"}, "properties": map[string]interface{}{"host": map[string]interface{}{"type": "string"}}},
+			values:  []interface{}{map[string]interface{}{"port": 80}},
+			wantErr: true, errContains: "host is required",
+		},
+		{name: "invalid schema", schema: map[string]interface{}{"type": "not-a-type"}, values: []interface{}{80}, wantErr: true, errContains: "invalid schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variable := &models.Variables{Definition: models.Definition{VarName: "port", Schema: tt.schema}}
+			for _, value := range tt.values {
+				variable.AttributeValues = append(variable.AttributeValues, models.AttributeValue{
+					VariableValue: models.VariableValue{Value: value},
+					AttributeType: models.Global,
+				})
+			}
+			err := validateValuesAgainstSchema(variable)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.IsType(t, models.ValidationError{}, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+}
+
+type appRepositoryStub struct {
+	app.AppRepository
+	apps map[int]*app.App
+}
+
+func (impl *appRepositoryStub) FindById(id int) (*app.App, error) {
+	return impl.apps[id], nil
+}
+
+type environmentRepositoryStub struct {
+	repository3.EnvironmentRepository
+	envs         []*repository3.Environment
+	clusterNames map[int]string
+}
+
+func (impl *environmentRepositoryStub) FindByIds(ids []*int) ([]*repository3.Environment, error) {
+	return impl.envs, nil
+}
+
+func (impl *environmentRepositoryStub) FindEnvClusterInfosByIds(envIds []int) ([]*repository3.EnvCluserInfo, error) {
+	envClusterInfos := make([]*repository3.EnvCluserInfo, 0, len(envIds))
+	for _, envId := range envIds {
+		envClusterInfos = append(envClusterInfos, &repository3.EnvCluserInfo{Id: envId, ClusterName: impl.clusterNames[envId]})
+	}
+	return envClusterInfos, nil
+}
+
+func TestScopedVariableServiceImpl_GetResolvedValuesDiff(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	variableCache := &cache.VariableCacheObj{CacheLock: &sync.Mutex{}}
+	// no user defined variables, only the system variables are resolved
+	variableCache.SetData([]*repository.VariableDefinition{})
+	impl := &ScopedVariableServiceImpl{
+		logger:        logger,
+		VariableCache: variableCache,
+		appRepository: &appRepositoryStub{apps: map[int]*app.App{1: {Id: 1, AppName: "payments"}}},
+		environmentRepository: &environmentRepositoryStub{
+			envs: []*repository3.Environment{
+				{Id: 10, Name: "prod", ClusterId: 2},
+				{Id: 20, Name: "dev", ClusterId: 2, Namespace: "dev-ns"},
+			},
+			clusterNames: map[int]string{10: "default", 20: "default"},
+		},
+	}
+	varNames := []string{string(resourceQualifiers.DevtronNamespace), string(resourceQualifiers.DevtronClusterName),
+		string(resourceQualifiers.DevtronEnvName), string(resourceQualifiers.DevtronAppName)}
+
+	variableDiffs, err := impl.GetResolvedValuesDiff(1, []int{10, 20}, varNames, false)
+	assert.NoError(t, err)
+	diffByName := make(map[string]*models.VariableEnvDiff)
+	for _, variableDiff := range variableDiffs {
+		assert.Len(t, variableDiff.Values, 2, variableDiff.VariableName)
+		assert.Equal(t, 10, variableDiff.Values[0].EnvId, variableDiff.VariableName)
+		assert.Equal(t, 20, variableDiff.Values[1].EnvId, variableDiff.VariableName)
+		diffByName[variableDiff.VariableName] = variableDiff
+	}
+	assert.Len(t, diffByName, 4)
+
+	appNameDiff := diffByName[string(resourceQualifiers.DevtronAppName)]
+	assert.False(t, appNameDiff.IsDrifted)
+	assert.Equal(t, "payments", appNameDiff.Values[1].VariableValue.Value)
+	assert.False(t, diffByName[string(resourceQualifiers.DevtronClusterName)].IsDrifted)
+
+	envNameDiff := diffByName[string(resourceQualifiers.DevtronEnvName)]
+	assert.True(t, envNameDiff.IsDrifted)
+	assert.Equal(t, "prod", envNameDiff.Values[0].VariableValue.Value)
+	assert.Equal(t, "dev", envNameDiff.Values[1].VariableValue.Value)
+
+	// prod has no namespace, its value is reported undefined even though it was compared before dev
+	namespaceDiff := diffByName[string(resourceQualifiers.DevtronNamespace)]
+	assert.True(t, namespaceDiff.IsDrifted)
+	assert.True(t, namespaceDiff.Values[0].IsUndefined)
+	assert.Equal(t, "default", namespaceDiff.Values[0].ClusterName)
+	assert.False(t, namespaceDiff.Values[1].IsUndefined)
+	assert.Equal(t, "dev-ns", namespaceDiff.Values[1].VariableValue.Value)
+}
+
+func TestIsDrifted(t *testing.T) {
+	value := func(v interface{}) *models.VariableResolvedValue {
+		return &models.VariableResolvedValue{VariableValue: &models.VariableValue{Value: v}}
+	}
+	undefined := &models.VariableResolvedValue{IsUndefined: true}
+	redacted := &models.VariableResolvedValue{VariableValue: &models.VariableValue{Value: "*****"}, IsRedacted: true}
+	tests := []struct {
+		name   string
+		values []*models.VariableResolvedValue
+		want   bool
+	}{
+		{name: "single env", values: []*models.VariableResolvedValue{value("a")}, want: false},
+		{name: "same values", values: []*models.VariableResolvedValue{value("a"), value("a"), value("a")}, want: false},
+		{name: "different values", values: []*models.VariableResolvedValue{value("a"), value("a"), value("b")}, want: true},
+		{name: "same complex values", values: []*models.VariableResolvedValue{value(map[string]interface{}{"k": 1.0}), value(map[string]interface{}{"k": 1.0})}, want: false},
+		{name: "defined in one env only", values: []*models.VariableResolvedValue{value("a"), undefined}, want: true},
+		{name: "undefined everywhere", values: []*models.VariableResolvedValue{undefined, undefined}, want: false},
+		{name: "redacted values are compared on definition only", values: []*models.VariableResolvedValue{redacted, redacted}, want: false},
+		{name: "redacted value undefined in one env", values: []*models.VariableResolvedValue{redacted, undefined}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDrifted(tt.values))
+		})
+	}
+}
+
+// payloadVersionRepositoryStub serves saved payload versions, any other call of the repository panics
+type payloadVersionRepositoryStub struct {
+	repository.ScopedVariableRepository
+	payloads map[int]models.Payload
+}
+
+func (impl *payloadVersionRepositoryStub) GetVariablePayloadVersion(version int) (*repository.VariablePayloadVersion, error) {
+	payload, ok := impl.payloads[version]
+	if !ok {
+		return nil, pg.ErrNoRows
+	}
+	payloadJson, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &repository.VariablePayloadVersion{Version: version, Payload: securestore.EncryptedString(payloadJson), VariableCount: len(payload.Variables)}, nil
+}
+
+func (impl *payloadVersionRepositoryStub) GetCurrentVariablePayloadVersion() (int, error) {
+	currentVersion := 0
+	for version := range impl.payloads {
+		if version > currentVersion {
+			currentVersion = version
+		}
+	}
+	return currentVersion, nil
+}
+
+func newGlobalVariable(name, description string, value interface{}) *models.Variables {
+	return &models.Variables{
+		Definition:      models.Definition{VarName: name, DataType: models.PRIMITIVE_TYPE, VarType: models.PUBLIC, Description: description},
+		AttributeValues: []models.AttributeValue{{VariableValue: models.VariableValue{Value: value}, AttributeType: models.Global}},
+	}
+}
+
+func newPayloadVersionTestService(t *testing.T) *ScopedVariableServiceImpl {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	return &ScopedVariableServiceImpl{
+		logger: logger,
+		scopedVariableRepository: &payloadVersionRepositoryStub{payloads: map[int]models.Payload{
+			1: {Variables: []*models.Variables{
+				newGlobalVariable("replicas", "", "2"),
+				newGlobalVariable("region", "deploy region", "us-east-1"),
+				newGlobalVariable("timeout", "", "30"),
+			}},
+			2: {Variables: []*models.Variables{
+				newGlobalVariable("replicas", "", "3"),
+				newGlobalVariable("region", "region of the deployment", "us-east-1"),
+				newGlobalVariable("logLevel", "", "info"),
+			}},
+		}},
+	}
+}
+
+func TestScopedVariableServiceImpl_GetVersionDiff(t *testing.T) {
+	impl := newPayloadVersionTestService(t)
+
+	diff, err := impl.GetVersionDiff(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, diff.FromVersion)
+	assert.Equal(t, 2, diff.ToVersion)
+	if assert.Len(t, diff.Changes, 4) {
+		assert.Equal(t, "replicas", diff.Changes[0].VariableName)
+		assert.Equal(t, models.VariableModified, diff.Changes[0].ChangeType)
+		assert.Equal(t, []string{"values"}, diff.Changes[0].ChangedFields)
+		assert.Equal(t, "2", diff.Changes[0].From.AttributeValues[0].VariableValue.Value)
+		assert.Equal(t, "3", diff.Changes[0].To.AttributeValues[0].VariableValue.Value)
+
+		assert.Equal(t, "region", diff.Changes[1].VariableName)
+		assert.Equal(t, models.VariableModified, diff.Changes[1].ChangeType)
+		assert.Equal(t, []string{"description"}, diff.Changes[1].ChangedFields)
+
+		assert.Equal(t, "logLevel", diff.Changes[2].VariableName)
+		assert.Equal(t, models.VariableAdded, diff.Changes[2].ChangeType)
+		assert.Nil(t, diff.Changes[2].From)
+
+		assert.Equal(t, "timeout", diff.Changes[3].VariableName)
+		assert.Equal(t, models.VariableDeleted, diff.Changes[3].ChangeType)
+		assert.Nil(t, diff.Changes[3].To)
+	}
+
+	diff, err = impl.GetVersionDiff(2, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+
+	for _, versions := range [][2]int{{1, 5}, {5, 1}} {
+		_, err = impl.GetVersionDiff(versions[0], versions[1])
+		apiErr, ok := err.(*util.ApiError)
+		if assert.True(t, ok, "missing version must be an api error") {
+			assert.Equal(t, http.StatusNotFound, apiErr.HttpStatusCode)
+		}
+	}
+}
+
+func TestScopedVariableServiceImpl_RollbackToVersion(t *testing.T) {
+	tests := []struct {
+		name           string
+		version        int
+		wantStatusCode int
+	}{
+		{name: "missing version", version: 5, wantStatusCode: http.StatusNotFound},
+		{name: "current version", version: 2, wantStatusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the stub panics if the variables are saved, so these calls must return before saving
+			err := newPayloadVersionTestService(t).RollbackToVersion(tt.version, 2)
+			apiErr, ok := err.(*util.ApiError)
+			if assert.True(t, ok, "expected an api error, got %v", err) {
+				assert.Equal(t, tt.wantStatusCode, apiErr.HttpStatusCode)
+			}
+		})
+	}
+}
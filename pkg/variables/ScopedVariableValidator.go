@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"github.com/devtron-labs/devtron/pkg/variables/helper"
 	"github.com/devtron-labs/devtron/pkg/variables/models"
+	"github.com/devtron-labs/devtron/pkg/variables/secretProvider"
 	"github.com/devtron-labs/devtron/pkg/variables/utils"
+	"github.com/xeipuuv/gojsonschema"
 	"golang.org/x/exp/slices"
 	"regexp"
 	"strings"
@@ -44,6 +46,12 @@ func (impl *ScopedVariableServiceImpl) isValidPayload(payload models.Payload) (e
 			return models.ValidationError{Err: fmt.Errorf("%s does not match the required format (Alphanumeric, 64 characters max, no hyphen/underscore at start/end)", variable.Definition.VarName)}, false
 		}
 		variableNamesList = append(variableNamesList, variable.Definition.VarName)
+		if err := validateValuesAgainstSchema(variable); err != nil {
+			return err, false
+		}
+		if err := impl.validateSecretReferences(variable); err != nil {
+			return err, false
+		}
 		uniqueVariableMap := make(map[string]interface{})
 		for _, attributeValue := range variable.AttributeValues {
 
@@ -73,6 +81,71 @@ func (impl *ScopedVariableServiceImpl) isValidPayload(payload models.Payload) (e
 	return nil, true
 }
 
+// validateValuesAgainstSchema validates the values of all the categories of the variable against the schema declared in its definition
+func validateValuesAgainstSchema(variable *models.Variables) error {
+	if len(variable.Definition.Schema) == 0 {
+		return nil
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(variable.Definition.Schema))
+	if err != nil {
+		return models.ValidationError{Err: fmt.Errorf("invalid schema for variable %s: %s", variable.Definition.VarName, err.Error())}
+	}
+	for _, attributeValue := range variable.AttributeValues {
+		result, err := schema.Validate(gojsonschema.NewGoLoader(attributeValue.VariableValue.Value))
+		if err != nil {
+			return models.ValidationError{Err: fmt.Errorf("value of variable %s could not be validated: %s", variable.Definition.VarName, err.Error())}
+		}
+		if !result.Valid() {
+			schemaErrors := make([]string, 0, len(result.Errors()))
+			for _, resultError := range result.Errors() {
+				schemaErrors = append(schemaErrors, resultError.Description())
+			}
+			return models.ValidationError{Err: fmt.Errorf("value of variable %s for category %s%s does not match its schema: %s",
+				variable.Definition.VarName, attributeValue.AttributeType, getSelectorString(attributeValue.AttributeParams), strings.Join(schemaErrors, ", "))}
+		}
+	}
+	return nil
+}
+
+// validateSecretReferences validates that the values of a variable sourced from a secret provider are references of secrets in a configured provider
+func (impl *ScopedVariableServiceImpl) validateSecretReferences(variable *models.Variables) error {
+	definition := variable.Definition
+	if len(definition.SecretProvider) == 0 {
+		return nil
+	}
+	if !impl.secretProviderFactory.IsConfigured(secretProvider.SecretProviderType(definition.SecretProvider)) {
+		return models.ValidationError{Err: fmt.Errorf("secret provider %s of variable %s is not configured", definition.SecretProvider, definition.VarName)}
+	}
+	if !definition.VarType.IsTypeSensitive() {
+		return models.ValidationError{Err: fmt.Errorf("variable %s sourced from a secret provider must be sensitive", definition.VarName)}
+	}
+	if len(definition.Schema) > 0 {
+		return models.ValidationError{Err: fmt.Errorf("schema is not supported for variable %s sourced from a secret provider", definition.VarName)}
+	}
+	for _, attributeValue := range variable.AttributeValues {
+		reference, ok := attributeValue.VariableValue.Value.(string)
+		if !ok {
+			return models.ValidationError{Err: fmt.Errorf("value of variable %s must be a secret reference", definition.VarName)}
+		}
+		if _, err := secretProvider.ParseSecretReference(reference); err != nil {
+			return models.ValidationError{Err: fmt.Errorf("invalid secret reference for variable %s: %s", definition.VarName, err.Error())}
+		}
+	}
+	return nil
+}
+
+func getSelectorString(attributeParams map[models.IdentifierType]string) string {
+	if len(attributeParams) == 0 {
+		return ""
+	}
+	selectors := make([]string, 0, len(attributeParams))
+	for key, value := range attributeParams {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", key, value))
+	}
+	slices.Sort(selectors)
+	return fmt.Sprintf(" (%s)", strings.Join(selectors, ","))
+}
+
 func complexTypeValidator(payload models.Payload) bool {
 	for _, variable := range payload.Variables {
 		variableType := variable.Definition.DataType
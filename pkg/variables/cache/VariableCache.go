@@ -19,12 +19,21 @@ package cache
 import (
 	"github.com/devtron-labs/devtron/pkg/variables/repository"
 	"sync"
+	"time"
 )
 
 type VariableCacheObj struct {
 	definitions []*repository.VariableDefinition
 	loaded      bool
 	CacheLock   *sync.Mutex
+	// secrets holds values resolved from external secret providers, keyed on provider and reference
+	secrets    map[string]*secretCacheEntry
+	secretLock sync.RWMutex
+}
+
+type secretCacheEntry struct {
+	value     string
+	expiresAt time.Time
 }
 
 func (cache *VariableCacheObj) TakeLock() {
@@ -54,3 +63,32 @@ func (cache *VariableCacheObj) GetData() []*repository.VariableDefinition {
 	}
 	return nil
 }
+
+// GetSecret returns the cached secret value for the key, expired entries are treated as missing
+func (cache *VariableCacheObj) GetSecret(key string) (string, bool) {
+	cache.secretLock.RLock()
+	defer cache.secretLock.RUnlock()
+	entry, ok := cache.secrets[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (cache *VariableCacheObj) SetSecret(key string, value string, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	cache.secretLock.Lock()
+	defer cache.secretLock.Unlock()
+	if cache.secrets == nil {
+		cache.secrets = make(map[string]*secretCacheEntry)
+	}
+	cache.secrets[key] = &secretCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+func (cache *VariableCacheObj) ResetSecrets() {
+	cache.secretLock.Lock()
+	defer cache.secretLock.Unlock()
+	cache.secrets = nil
+}
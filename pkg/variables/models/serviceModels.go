@@ -17,6 +17,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/devtron-labs/devtron/pkg/resourceQualifiers"
 )
 
@@ -25,6 +27,16 @@ type ScopedVariableData struct {
 	ShortDescription string         `json:"shortDescription"`
 	VariableValue    *VariableValue `json:"variableValue,omitempty"`
 	IsRedacted       bool           `json:"isRedacted"`
+	// SecretReference is set for values resolved from a secret provider, it is stored in snapshots in place of the secret
+	SecretReference string `json:"-"`
+}
+
+// GetSnapshotValue returns the value to be saved in the variable snapshot, secrets are never part of it
+func (data *ScopedVariableData) GetSnapshotValue() string {
+	if len(data.SecretReference) > 0 {
+		return data.SecretReference
+	}
+	return data.VariableValue.StringValue()
 }
 
 type VariableScopeMapping struct {
@@ -36,3 +48,54 @@ type VariableScope struct {
 	*resourceQualifiers.ResourceMappingSelection
 	Data string
 }
+
+// VariableEnvDiff is the value of a variable resolved for each of the compared environments
+type VariableEnvDiff struct {
+	VariableName string                   `json:"variableName"`
+	IsDrifted    bool                     `json:"isDrifted"`
+	Values       []*VariableResolvedValue `json:"values"`
+}
+
+type VariableResolvedValue struct {
+	EnvId         int            `json:"envId"`
+	EnvName       string         `json:"envName"`
+	ClusterName   string         `json:"clusterName"`
+	VariableValue *VariableValue `json:"variableValue,omitempty"`
+	IsRedacted    bool           `json:"isRedacted"`
+	// IsUndefined is set when no value of the variable applies to the environment
+	IsUndefined bool `json:"isUndefined"`
+}
+
+type VariablePayloadVersion struct {
+	Version        int       `json:"version"`
+	VariableCount  int       `json:"variableCount"`
+	CreatedBy      int32     `json:"-"`
+	CreatedByEmail string    `json:"createdBy"`
+	CreatedOn      time.Time `json:"createdOn"`
+	// RolledBackFromVersion is the version whose payload was restored to create this version, 0 if it was not a rollback
+	RolledBackFromVersion int `json:"rolledBackFromVersion,omitempty"`
+}
+
+type VariableChangeType string
+
+const (
+	VariableAdded    VariableChangeType = "added"
+	VariableDeleted  VariableChangeType = "deleted"
+	VariableModified VariableChangeType = "modified"
+)
+
+type VariableVersionDiff struct {
+	FromVersion int               `json:"fromVersion"`
+	ToVersion   int               `json:"toVersion"`
+	Changes     []*VariableChange `json:"changes"`
+}
+
+// VariableChange is the change in a variable between two versions, unchanged variables are not part of the diff
+type VariableChange struct {
+	VariableName string             `json:"variableName"`
+	ChangeType   VariableChangeType `json:"changeType"`
+	// ChangedFields are the fields of the definition along with values which differ in the two versions
+	ChangedFields []string   `json:"changedFields,omitempty"`
+	From          *Variables `json:"from,omitempty"`
+	To            *Variables `json:"to,omitempty"`
+}
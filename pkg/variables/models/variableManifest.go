@@ -32,6 +32,10 @@ type VariableSpec struct {
 	IsSensitive      bool                `json:"isSensitive"`
 	Name             string              `json:"name" validate:"required"`
 	Values           []VariableValueSpec `json:"values" validate:"dive"`
+	// Schema supports the json schema keywords like type, enum, pattern, minimum and maximum, values of all categories are validated against it
+	Schema map[string]interface{} `json:"schema,omitempty"`
+	// SecretProvider marks the values as references of secrets (<path>#<key>) which are resolved from the provider at render time
+	SecretProvider string `json:"secretProvider,omitempty"`
 }
 
 type VariableValueSpec struct {
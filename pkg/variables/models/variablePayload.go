@@ -19,6 +19,7 @@ package models
 import (
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 type Payload struct {
@@ -41,6 +42,10 @@ type Definition struct {
 	VarType          VariableType `json:"varType" validate:"oneof=private public"`
 	Description      string       `json:"description" validate:"max=300"`
 	ShortDescription string       `json:"shortDescription"`
+	// Schema is a JSON schema which every value of the variable must satisfy
+	Schema map[string]interface{} `json:"schema,omitempty"`
+	// SecretProvider is set for variables whose values are references of secrets in an external store (vault, file)
+	SecretProvider string `json:"secretProvider,omitempty"`
 }
 
 type VariableType string
@@ -61,6 +66,22 @@ const (
 const HiddenValue = "hidden-value"
 const UndefinedValue = "undefined-variable-value"
 
+// SecretReferencePrefix prefixes the provider reference saved in snapshots for values of secret provider variables
+const SecretReferencePrefix = "secret-reference:"
+
+func GetSecretReferenceValue(provider string, reference string) string {
+	return SecretReferencePrefix + provider + ":" + reference
+}
+
+// ParseSecretReferenceValue returns the provider and the reference of a value created by GetSecretReferenceValue
+func ParseSecretReferenceValue(value string) (provider string, reference string, ok bool) {
+	if !strings.HasPrefix(value, SecretReferencePrefix) {
+		return "", "", false
+	}
+	provider, reference, ok = strings.Cut(strings.TrimPrefix(value, SecretReferencePrefix), ":")
+	return provider, reference, ok
+}
+
 func (variableType VariableType) IsTypeSensitive() bool {
 	if variableType == PRIVATE {
 		return true
@@ -100,7 +100,7 @@ func GetVariableMapForUsedVariables(scopedVariables []*models.ScopedVariableData
 	variableMap := make(map[string]string)
 	for _, variable := range scopedVariables {
 		if slices.Contains(usedVars, variable.VariableName) {
-			variableMap[variable.VariableName] = variable.VariableValue.StringValue()
+			variableMap[variable.VariableName] = variable.GetSnapshotValue()
 		}
 	}
 	return variableMap
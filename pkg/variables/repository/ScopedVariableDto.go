@@ -17,6 +17,9 @@
 package repository
 
 import (
+	"encoding/json"
+
+	"github.com/devtron-labs/common-lib/securestore"
 	"github.com/devtron-labs/devtron/pkg/sql"
 	"github.com/devtron-labs/devtron/pkg/variables/models"
 )
@@ -30,6 +33,8 @@ type VariableDefinition struct {
 	Active           bool                `sql:"active"`
 	Description      string              `sql:"description"`
 	ShortDescription string              `json:"short_description"`
+	Schema           string              `sql:"schema"`
+	SecretProvider   string              `sql:"secret_provider"`
 	sql.AuditLog
 }
 
@@ -41,14 +46,43 @@ type VariableData struct {
 	sql.AuditLog
 }
 
-func CreateFromDefinition(definition models.Definition, auditLog sql.AuditLog) *VariableDefinition {
+// VariablePayloadVersion is the complete variable payload saved on every change, Payload is the json of models.Payload
+// and is stored encrypted as it holds the values of the sensitive variables as well
+type VariablePayloadVersion struct {
+	tableName             struct{}                    `sql:"variable_payload_version" pg:",discard_unknown_columns"`
+	Id                    int                         `sql:"id,pk"`
+	Version               int                         `sql:"version"`
+	Payload               securestore.EncryptedString `sql:"payload"`
+	VariableCount         int                         `sql:"variable_count,notnull"`
+	RolledBackFromVersion int                         `sql:"rolled_back_from_version"`
+	sql.AuditLog
+}
+
+func CreateFromDefinition(definition models.Definition, auditLog sql.AuditLog) (*VariableDefinition, error) {
 	varDefinition := &VariableDefinition{}
 	varDefinition.Name = definition.VarName
 	varDefinition.DataType = definition.DataType
 	varDefinition.VarType = definition.VarType
 	varDefinition.Description = definition.Description
 	varDefinition.ShortDescription = definition.ShortDescription
+	varDefinition.SecretProvider = definition.SecretProvider
 	varDefinition.Active = true
 	varDefinition.AuditLog = auditLog
-	return varDefinition
+	if len(definition.Schema) > 0 {
+		schema, err := json.Marshal(definition.Schema)
+		if err != nil {
+			return nil, err
+		}
+		varDefinition.Schema = string(schema)
+	}
+	return varDefinition, nil
+}
+
+func (definition *VariableDefinition) GetSchema() (map[string]interface{}, error) {
+	if len(definition.Schema) == 0 {
+		return nil, nil
+	}
+	schema := make(map[string]interface{})
+	err := json.Unmarshal([]byte(definition.Schema), &schema)
+	return schema, err
 }
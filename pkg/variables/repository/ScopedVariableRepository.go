@@ -17,7 +17,9 @@
 package repository
 
 import (
+	"github.com/devtron-labs/common-lib/securestore"
 	"github.com/devtron-labs/devtron/pkg/sql"
+	globalUtil "github.com/devtron-labs/devtron/util"
 	"github.com/go-pg/pg"
 	"go.uber.org/zap"
 )
@@ -41,19 +43,29 @@ type ScopedVariableRepository interface {
 	DeleteVariables(auditLog sql.AuditLog, tx *pg.Tx) error
 
 	GetVariableTypeForVariableNames(variableNames []string) ([]*VariableDefinition, error)
+
+	// Versions
+	CreateVariablePayloadVersion(payloadVersion *VariablePayloadVersion, tx *pg.Tx) error
+	GetLatestVariablePayloadVersion(tx *pg.Tx) (int, error)
+	GetCurrentVariablePayloadVersion() (int, error)
+	GetVariablePayloadVersions(offset int, limit int) ([]*VariablePayloadVersion, error)
+	GetVariablePayloadVersion(version int) (*VariablePayloadVersion, error)
 }
 
 type ScopedVariableRepositoryImpl struct {
 	dbConnection *pg.DB
 	*sql.TransactionUtilImpl
-	logger *zap.SugaredLogger
+	logger             *zap.SugaredLogger
+	GlobalEnvVariables *globalUtil.GlobalEnvVariables
 }
 
-func NewScopedVariableRepository(dbConnection *pg.DB, logger *zap.SugaredLogger, TransactionUtilImpl *sql.TransactionUtilImpl) *ScopedVariableRepositoryImpl {
+func NewScopedVariableRepository(dbConnection *pg.DB, logger *zap.SugaredLogger, TransactionUtilImpl *sql.TransactionUtilImpl,
+	variables *globalUtil.EnvironmentVariables) *ScopedVariableRepositoryImpl {
 	return &ScopedVariableRepositoryImpl{
 		dbConnection:        dbConnection,
 		logger:              logger,
 		TransactionUtilImpl: TransactionUtilImpl,
+		GlobalEnvVariables:  variables.GlobalEnvVariables,
 	}
 }
 
@@ -82,7 +94,7 @@ func (impl *ScopedVariableRepositoryImpl) GetAllVariableMetadata() ([]*VariableD
 	variableDefinition := make([]*VariableDefinition, 0)
 	err := impl.
 		dbConnection.Model(&variableDefinition).
-		Column("id", "name", "data_type", "var_type", "short_description").
+		Column("id", "name", "data_type", "var_type", "short_description", "secret_provider").
 		Where("active = ?", true).
 		Select()
 	if err == pg.ErrNoRows {
@@ -159,3 +171,52 @@ func (impl *ScopedVariableRepositoryImpl) DeleteVariables(auditLog sql.AuditLog,
 		Update()
 	return err
 }
+
+func (impl *ScopedVariableRepositoryImpl) CreateVariablePayloadVersion(payloadVersion *VariablePayloadVersion, tx *pg.Tx) error {
+	if impl.GlobalEnvVariables.EnablePasswordEncryption {
+		payload, err := securestore.EncryptString(payloadVersion.Payload.String())
+		if err != nil {
+			return err
+		}
+		payloadVersion.Payload = payload
+	}
+	return tx.Insert(payloadVersion)
+}
+
+func (impl *ScopedVariableRepositoryImpl) GetLatestVariablePayloadVersion(tx *pg.Tx) (int, error) {
+	var version int
+	_, err := tx.Query(pg.Scan(&version), "SELECT COALESCE(MAX(version), 0) FROM variable_payload_version")
+	return version, err
+}
+
+// GetCurrentVariablePayloadVersion returns the latest version, 0 if no version has been saved
+func (impl *ScopedVariableRepositoryImpl) GetCurrentVariablePayloadVersion() (int, error) {
+	var version int
+	_, err := impl.dbConnection.Query(pg.Scan(&version), "SELECT COALESCE(MAX(version), 0) FROM variable_payload_version")
+	return version, err
+}
+
+// GetVariablePayloadVersions returns a page of the versions without their payload, latest first
+func (impl *ScopedVariableRepositoryImpl) GetVariablePayloadVersions(offset int, limit int) ([]*VariablePayloadVersion, error) {
+	payloadVersions := make([]*VariablePayloadVersion, 0)
+	err := impl.dbConnection.
+		Model(&payloadVersions).
+		Column("id", "version", "variable_count", "rolled_back_from_version", "created_on", "created_by").
+		Order("version DESC").
+		Offset(offset).
+		Limit(limit).
+		Select()
+	if err == pg.ErrNoRows {
+		err = nil
+	}
+	return payloadVersions, err
+}
+
+func (impl *ScopedVariableRepositoryImpl) GetVariablePayloadVersion(version int) (*VariablePayloadVersion, error) {
+	payloadVersion := &VariablePayloadVersion{}
+	err := impl.dbConnection.
+		Model(payloadVersion).
+		Where("version = ?", version).
+		Select()
+	return payloadVersion, err
+}
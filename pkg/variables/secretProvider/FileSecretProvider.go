@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secretProvider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FileSecretProvider reads secrets from files mounted under a base directory, the whole content
+// of the file is the value unless a key is referenced, in which case the file is parsed as a yaml/json object
+type FileSecretProvider struct {
+	baseDir string
+}
+
+func NewFileSecretProvider(baseDir string) *FileSecretProvider {
+	return &FileSecretProvider{baseDir: filepath.Clean(baseDir)}
+}
+
+func (impl *FileSecretProvider) GetSecret(reference *SecretReference) (string, error) {
+	filePath := filepath.Join(impl.baseDir, filepath.Clean(string(filepath.Separator)+reference.Path))
+	if !strings.HasPrefix(filePath, impl.baseDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("secret path %q is outside the secret directory", reference.Path)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error in reading secret %q: %w", reference.Path, err)
+	}
+	if len(reference.Key) == 0 {
+		return strings.TrimRight(string(content), "\n"), nil
+	}
+	data := make(map[string]interface{})
+	err = yaml.Unmarshal(content, &data)
+	if err != nil {
+		return "", fmt.Errorf("secret %q is not a yaml/json object: %w", reference.Path, err)
+	}
+	return getSecretKeyValue(data, reference)
+}
+
+func getSecretKeyValue(data map[string]interface{}, reference *SecretReference) (string, error) {
+	value, ok := data[reference.Key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %q", reference.Key, reference.Path)
+	}
+	if stringValue, ok := value.(string); ok {
+		return stringValue, nil
+	}
+	marshalledValue, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(marshalledValue), nil
+}
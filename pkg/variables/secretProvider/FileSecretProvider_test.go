@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secretProvider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSecretProvider(t *testing.T) {
+	baseDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(baseDir, "db-password"), []byte("s3cr3t\n"), 0600)
+	assert.Nil(t, err)
+	err = os.WriteFile(filepath.Join(baseDir, "payments.yaml"), []byte("apiKey: abc\nport: 5432\n"), 0600)
+	assert.Nil(t, err)
+	provider := NewFileSecretProvider(baseDir)
+
+	t.Run("whole file content", func(t *testing.T) {
+		reference, err := ParseSecretReference("db-password")
+		assert.Nil(t, err)
+		value, err := provider.GetSecret(reference)
+		assert.Nil(t, err)
+		assert.Equal(t, "s3cr3t", value)
+	})
+
+	t.Run("key in file", func(t *testing.T) {
+		reference, err := ParseSecretReference("payments.yaml#apiKey")
+		assert.Nil(t, err)
+		value, err := provider.GetSecret(reference)
+		assert.Nil(t, err)
+		assert.Equal(t, "abc", value)
+
+		reference, err = ParseSecretReference("payments.yaml#port")
+		assert.Nil(t, err)
+		value, err = provider.GetSecret(reference)
+		assert.Nil(t, err)
+		assert.Equal(t, "5432", value)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		reference, err := ParseSecretReference("payments.yaml#user")
+		assert.Nil(t, err)
+		_, err = provider.GetSecret(reference)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("path traversal stays inside base dir", func(t *testing.T) {
+		reference, err := ParseSecretReference("../../etc/passwd")
+		assert.Nil(t, err)
+		_, err = provider.GetSecret(reference)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("empty reference", func(t *testing.T) {
+		_, err := ParseSecretReference(" ")
+		assert.NotNil(t, err)
+		_, err = ParseSecretReference("#key")
+		assert.NotNil(t, err)
+	})
+}
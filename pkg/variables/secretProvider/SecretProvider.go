@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secretProvider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/env"
+	"go.uber.org/zap"
+)
+
+// SecretProvider fetches the value of a secret from an external store
+type SecretProvider interface {
+	GetSecret(reference *SecretReference) (string, error)
+}
+
+type SecretProviderFactory interface {
+	// GetProvider returns an error if the provider type is unknown or is not configured
+	GetProvider(providerType SecretProviderType) (SecretProvider, error)
+	IsConfigured(providerType SecretProviderType) bool
+	GetCacheTTL() time.Duration
+}
+
+type SecretProviderFactoryImpl struct {
+	logger    *zap.SugaredLogger
+	config    *SecretProviderConfig
+	providers map[SecretProviderType]SecretProvider
+}
+
+func NewSecretProviderFactoryImpl(logger *zap.SugaredLogger) (*SecretProviderFactoryImpl, error) {
+	config := &SecretProviderConfig{}
+	err := env.Parse(config)
+	if err != nil {
+		logger.Errorw("error in parsing secret provider config", "err", err)
+		return nil, err
+	}
+	providers := make(map[SecretProviderType]SecretProvider)
+	if len(config.VaultAddress) > 0 {
+		providers[VAULT] = NewVaultSecretProvider(config)
+	}
+	if len(config.FileSecretBaseDir) > 0 {
+		providers[FILE] = NewFileSecretProvider(config.FileSecretBaseDir)
+	}
+	return &SecretProviderFactoryImpl{
+		logger:    logger,
+		config:    config,
+		providers: providers,
+	}, nil
+}
+
+func (impl *SecretProviderFactoryImpl) GetProvider(providerType SecretProviderType) (SecretProvider, error) {
+	provider, ok := impl.providers[providerType]
+	if !ok {
+		return nil, fmt.Errorf("secret provider %q is not configured", providerType)
+	}
+	return provider, nil
+}
+
+func (impl *SecretProviderFactoryImpl) IsConfigured(providerType SecretProviderType) bool {
+	_, ok := impl.providers[providerType]
+	return ok
+}
+
+func (impl *SecretProviderFactoryImpl) GetCacheTTL() time.Duration {
+	return time.Duration(impl.config.SecretCacheTTLSecs) * time.Second
+}
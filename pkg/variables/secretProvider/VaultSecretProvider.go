@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secretProvider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultSecretProvider reads secrets from the KV secrets engine of HashiCorp Vault, both KV v1 and v2 responses are supported.
+// A key is mandatory in the reference as a vault secret is always a set of key value pairs.
+type VaultSecretProvider struct {
+	address    string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+type vaultSecretResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+func NewVaultSecretProvider(config *SecretProviderConfig) *VaultSecretProvider {
+	return &VaultSecretProvider{
+		address:    strings.TrimSuffix(config.VaultAddress, "/"),
+		token:      config.VaultToken,
+		namespace:  config.VaultNamespace,
+		httpClient: &http.Client{Timeout: time.Duration(config.VaultRequestTimeoutSecs) * time.Second},
+	}
+}
+
+func (impl *VaultSecretProvider) GetSecret(reference *SecretReference) (string, error) {
+	if len(reference.Key) == 0 {
+		return "", fmt.Errorf("key is required in vault secret reference %q", reference.Path)
+	}
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", impl.address, strings.TrimPrefix(reference.Path, "/")), nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("X-Vault-Token", impl.token)
+	if len(impl.namespace) > 0 {
+		request.Header.Set("X-Vault-Namespace", impl.namespace)
+	}
+	response, err := impl.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error in reading secret %q from vault: %w", reference.Path, err)
+	}
+	defer response.Body.Close()
+	secretResponse := &vaultSecretResponse{}
+	err = json.NewDecoder(response.Body).Decode(secretResponse)
+	if err != nil && response.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("error in decoding secret %q from vault: %w", reference.Path, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error in reading secret %q from vault, status: %d, errors: %s", reference.Path, response.StatusCode, strings.Join(secretResponse.Errors, ", "))
+	}
+	data := secretResponse.Data
+	// KV v2 nests the secret data along with its metadata
+	if nestedData, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nestedData
+		}
+	}
+	return getSecretKeyValue(data, reference)
+}
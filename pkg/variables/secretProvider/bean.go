@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secretProvider
+
+import (
+	"fmt"
+	"strings"
+)
+
+type SecretProviderType string
+
+const (
+	VAULT SecretProviderType = "vault"
+	FILE  SecretProviderType = "file"
+)
+
+// referenceKeySeparator separates the secret path from the key inside the secret, e.g. secret/data/payments#db-password
+const referenceKeySeparator = "#"
+
+type SecretProviderConfig struct {
+	SecretCacheTTLSecs      int    `env:"SCOPED_VARIABLE_SECRET_CACHE_TTL_SECS" envDefault:"300" description:"Duration in seconds for which values of scoped variables resolved from external secret providers are cached, 0 disables caching"`
+	VaultAddress            string `env:"SCOPED_VARIABLE_VAULT_ADDR" envDefault:"" description:"Address of the HashiCorp Vault server, vault provider is enabled for scoped variables when set"`
+	VaultToken              string `env:"SCOPED_VARIABLE_VAULT_TOKEN" envDefault:"" description:"Token used to read secrets from HashiCorp Vault"`
+	VaultNamespace          string `env:"SCOPED_VARIABLE_VAULT_NAMESPACE" envDefault:"" description:"Vault enterprise namespace to read the secrets from"`
+	VaultRequestTimeoutSecs int    `env:"SCOPED_VARIABLE_VAULT_REQUEST_TIMEOUT_SECS" envDefault:"10" description:"Timeout in seconds for requests made to HashiCorp Vault"`
+	FileSecretBaseDir       string `env:"SCOPED_VARIABLE_FILE_SECRET_DIR" envDefault:"" description:"Directory of mounted secret files, file provider is enabled for scoped variables when set"`
+}
+
+// SecretReference points to a secret in a provider, Key is optional for the file provider
+type SecretReference struct {
+	Path string
+	Key  string
+}
+
+// ParseSecretReference parses references of format <path>#<key>
+func ParseSecretReference(reference string) (*SecretReference, error) {
+	reference = strings.TrimSpace(reference)
+	if len(reference) == 0 {
+		return nil, fmt.Errorf("secret reference cannot be empty")
+	}
+	path, key, _ := strings.Cut(reference, referenceKeySeparator)
+	if len(path) == 0 {
+		return nil, fmt.Errorf("secret reference %q does not have a path", reference)
+	}
+	return &SecretReference{Path: path, Key: key}, nil
+}
@@ -44,10 +44,13 @@ func ManifestToPayload(manifest models.ScopedVariableManifest, userId int32) mod
 				VarType:          models.PUBLIC,
 				Description:      spec.Notes,
 				ShortDescription: spec.ShortDescription,
+				Schema:           spec.Schema,
+				SecretProvider:   spec.SecretProvider,
 			},
 			AttributeValues: attributes,
 		}
-		if spec.IsSensitive {
+		// values sourced from a secret provider are always treated as sensitive
+		if spec.IsSensitive || len(spec.SecretProvider) > 0 {
 			variable.Definition.VarType = models.PRIVATE
 		}
 		variableList = append(variableList, &variable)
@@ -72,6 +75,8 @@ func PayloadToManifest(payload models.Payload) models.ScopedVariableManifest {
 			ShortDescription: variable.Definition.ShortDescription,
 			Values:           make([]models.VariableValueSpec, 0),
 			IsSensitive:      variable.Definition.VarType.IsTypeSensitive(),
+			Schema:           variable.Definition.Schema,
+			SecretProvider:   variable.Definition.SecretProvider,
 		}
 		for _, attribute := range variable.AttributeValues {
 			valueSpec := models.VariableValueSpec{
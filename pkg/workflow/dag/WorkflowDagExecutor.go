@@ -39,6 +39,7 @@ import (
 	client "github.com/devtron-labs/devtron/client/events"
 	"github.com/devtron-labs/devtron/internal/sql/constants"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/adapter/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
 	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow"
 	cdWorkflow2 "github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
 	"github.com/devtron-labs/devtron/pkg/app/status"
@@ -56,6 +57,7 @@ import (
 	triggerBean "github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/bean"
 	"github.com/devtron-labs/devtron/pkg/deployment/trigger/devtronApps/userDeploymentRequest/service"
 	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
+	"github.com/devtron-labs/devtron/pkg/eventProcessor/celEvaluator"
 	"github.com/devtron-labs/devtron/pkg/executor"
 	"github.com/devtron-labs/devtron/pkg/fluxApplication"
 	bean8 "github.com/devtron-labs/devtron/pkg/fluxApplication/bean"
@@ -160,6 +162,7 @@ type WorkflowDagExecutorImpl struct {
 	ciHandlerService            trigger.HandlerService
 	workflowTriggerAuditService auditService.WorkflowTriggerAuditService
 	fluxApplicationService      fluxApplication.FluxApplicationService
+	promotionConditionEvaluator celEvaluator.PromotionConditionEvaluator
 }
 
 func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pipelineConfig.PipelineRepository,
@@ -196,6 +199,7 @@ func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pi
 	ciHandlerService trigger.HandlerService,
 	workflowTriggerAuditService auditService.WorkflowTriggerAuditService,
 	fluxApplicationService fluxApplication.FluxApplicationService,
+	promotionConditionEvaluator celEvaluator.PromotionConditionEvaluator,
 ) *WorkflowDagExecutorImpl {
 	wde := &WorkflowDagExecutorImpl{logger: Logger,
 		pipelineRepository:            pipelineRepository,
@@ -231,7 +235,8 @@ func NewWorkflowDagExecutorImpl(Logger *zap.SugaredLogger, pipelineRepository pi
 		workflowService:               workflowService,
 		ciHandlerService:              ciHandlerService,
 		workflowTriggerAuditService:   workflowTriggerAuditService,
-		fluxApplicationService:        fluxApplicationService}
+		fluxApplicationService:        fluxApplicationService,
+		promotionConditionEvaluator:   promotionConditionEvaluator}
 	config, err := types.GetCdConfig()
 	if err != nil {
 		return nil
@@ -320,7 +325,7 @@ func (impl *WorkflowDagExecutorImpl) UpdateCiWorkflowStatusFailure(timeoutForFai
 						// skip this and process for next ci workflow
 					}
 				}
-				if ciWorkflow.ExecutorType == cdWorkflow2.WORKFLOW_EXECUTOR_TYPE_SYSTEM {
+				if !ciWorkflow.ExecutorType.IsArgoWorkflowExecutor() {
 					if wf.Status == string(v1alpha1.WorkflowFailed) {
 						isPodDeleted = true
 					}
@@ -730,6 +735,42 @@ func (impl *WorkflowDagExecutorImpl) triggerIfAutoStageCdPipeline(request trigge
 	return nil
 }
 
+// isPromotionAllowed evaluates the promotion condition of the child pipeline before it is auto triggered,
+// the blocked reason is recorded in the timeline of the parent deployment
+func (impl *WorkflowDagExecutorImpl) isPromotionAllowed(pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact, parentCdWorkflowId int) (bool, error) {
+	// an automatic pre stage of a manual pipeline does not promote the artifact, only the deployment trigger type is checked
+	if len(pipeline.PromotionCondition) == 0 || pipeline.TriggerType != pipelineConfig.TRIGGER_TYPE_AUTOMATIC {
+		return true, nil
+	}
+	isPromotionAllowed, blockedReason, err := impl.promotionConditionEvaluator.EvaluatePromotionCondition(pipeline, artifact)
+	if err != nil {
+		impl.savePromotionBlockedTimeline(pipeline, parentCdWorkflowId, fmt.Sprintf("condition '%s' could not be evaluated, %s", pipeline.PromotionCondition, err.Error()))
+		return false, err
+	}
+	if isPromotionAllowed {
+		return true, nil
+	}
+	impl.logger.Infow("auto promotion blocked by promotion condition", "pipelineId", pipeline.Id, "artifactId", artifact.Id, "reason", blockedReason)
+	impl.savePromotionBlockedTimeline(pipeline, parentCdWorkflowId, blockedReason)
+	return false, nil
+}
+
+// savePromotionBlockedTimeline records the reason the child pipeline was not auto triggered on the parent deployment,
+// blocking is not affected by the timeline so errors are only logged
+func (impl *WorkflowDagExecutorImpl) savePromotionBlockedTimeline(pipeline *pipelineConfig.Pipeline, parentCdWorkflowId int, blockedReason string) {
+	parentRunner, err := impl.cdWorkflowRepository.FindByWorkflowIdAndRunnerType(context.Background(), parentCdWorkflowId, bean.CD_WORKFLOW_TYPE_DEPLOY)
+	if err != nil {
+		impl.logger.Errorw("error in fetching parent deployment runner for promotion timeline", "cdWorkflowId", parentCdWorkflowId, "err", err)
+		return
+	}
+	timeline := impl.pipelineStatusTimelineService.NewDevtronAppPipelineStatusTimelineDbObject(parentRunner.Id, timelineStatus.TIMELINE_STATUS_PROMOTION_BLOCKED,
+		fmt.Sprintf(timelineStatus.TIMELINE_DESCRIPTION_PROMOTION_BLOCKED, pipeline.Name, blockedReason), bean7.SYSTEM_USER_ID)
+	err = impl.pipelineStatusTimelineService.SaveTimeline(timeline, nil)
+	if err != nil {
+		impl.logger.Errorw("error in saving promotion blocked timeline", "timeline", timeline, "err", err)
+	}
+}
+
 func (impl *WorkflowDagExecutorImpl) getPipelineStage(pipelineId int, stageType repository4.PipelineStageType) (*repository4.PipelineStage, error) {
 	stage, err := impl.pipelineStageService.GetCdStageByCdPipelineIdAndStageType(pipelineId, stageType, false)
 	if err != nil && err != pg.ErrNoRows {
@@ -904,6 +945,15 @@ func (impl *WorkflowDagExecutorImpl) HandlePostStageSuccessEvent(triggerContext
 		//finding ci artifact by ciPipelineID and pipelineId
 		//TODO : confirm values for applyAuth, async & triggeredBy
 
+		isPromotionAllowed, err := impl.isPromotionAllowed(pipeline, ciArtifact, cdWorkflowId)
+		if err != nil {
+			// skipping only this pipeline, the sibling pipelines are still triggered
+			impl.logger.Errorw("error in evaluating promotion condition, skipping trigger", "err", err, "pipelineId", pipeline.Id)
+			continue
+		}
+		if !isPromotionAllowed {
+			continue
+		}
 		triggerRequest := triggerBean.CdTriggerRequest{
 			CdWf:           nil,
 			Pipeline:       pipeline,
@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dag
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devtron-labs/devtron/api/bean"
+	"github.com/devtron-labs/devtron/internal/sql/repository"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/timelineStatus"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/app/status"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+)
+
+type promotionConditionEvaluatorStub struct {
+	isPromotionAllowed bool
+	blockedReason      string
+	err                error
+	evaluatedCount     int
+}
+
+func (impl *promotionConditionEvaluatorStub) EvaluatePromotionCondition(pipeline *pipelineConfig.Pipeline, artifact *repository.CiArtifact) (bool, string, error) {
+	impl.evaluatedCount++
+	return impl.isPromotionAllowed, impl.blockedReason, impl.err
+}
+
+// timelineServiceStub records the timelines saved by the promotion gate
+type timelineServiceStub struct {
+	status.PipelineStatusTimelineService
+	savedTimelines []*pipelineConfig.PipelineStatusTimeline
+}
+
+func (impl *timelineServiceStub) NewDevtronAppPipelineStatusTimelineDbObject(cdWorkflowRunnerId int, timelineStatus timelineStatus.TimelineStatus, timelineDescription string, userId int32) *pipelineConfig.PipelineStatusTimeline {
+	return &pipelineConfig.PipelineStatusTimeline{CdWorkflowRunnerId: cdWorkflowRunnerId, Status: timelineStatus, StatusDetail: timelineDescription}
+}
+
+func (impl *timelineServiceStub) SaveTimeline(timeline *pipelineConfig.PipelineStatusTimeline, tx *pg.Tx) error {
+	impl.savedTimelines = append(impl.savedTimelines, timeline)
+	return nil
+}
+
+type cdWorkflowRepositoryStub struct {
+	pipelineConfig.CdWorkflowRepository
+	deployRunners map[int]pipelineConfig.CdWorkflowRunner
+}
+
+func (impl *cdWorkflowRepositoryStub) FindByWorkflowIdAndRunnerType(ctx context.Context, wfId int, runnerType bean.WorkflowType) (pipelineConfig.CdWorkflowRunner, error) {
+	if runner, ok := impl.deployRunners[wfId]; ok && runnerType == bean.CD_WORKFLOW_TYPE_DEPLOY {
+		return runner, nil
+	}
+	return pipelineConfig.CdWorkflowRunner{}, pg.ErrNoRows
+}
+
+func TestWorkflowDagExecutorImpl_isPromotionAllowed(t *testing.T) {
+	logger, err := util.NewSugardLogger()
+	assert.NoError(t, err)
+	artifact := &repository.CiArtifact{Id: 7}
+
+	t.Run("condition not satisfied blocks auto promotion and records the reason", func(t *testing.T) {
+		evaluator := &promotionConditionEvaluatorStub{isPromotionAllowed: false, blockedReason: "condition 'isProdEnv' not satisfied"}
+		timelineService := &timelineServiceStub{}
+		cdWorkflowRepository := &cdWorkflowRepositoryStub{deployRunners: map[int]pipelineConfig.CdWorkflowRunner{11: {Id: 21}}}
+		impl := &WorkflowDagExecutorImpl{logger: logger, promotionConditionEvaluator: evaluator,
+			pipelineStatusTimelineService: timelineService, cdWorkflowRepository: cdWorkflowRepository}
+		pipeline := &pipelineConfig.Pipeline{Id: 3, Name: "prod", TriggerType: pipelineConfig.TRIGGER_TYPE_AUTOMATIC, PromotionCondition: "isProdEnv"}
+
+		isPromotionAllowed, err := impl.isPromotionAllowed(pipeline, artifact, 11)
+		assert.NoError(t, err)
+		assert.False(t, isPromotionAllowed)
+		assert.Equal(t, 1, evaluator.evaluatedCount)
+		if assert.Len(t, timelineService.savedTimelines, 1) {
+			assert.Equal(t, 21, timelineService.savedTimelines[0].CdWorkflowRunnerId)
+			assert.Equal(t, timelineStatus.TIMELINE_STATUS_PROMOTION_BLOCKED, timelineService.savedTimelines[0].Status)
+			assert.Contains(t, timelineService.savedTimelines[0].StatusDetail, "condition 'isProdEnv' not satisfied")
+		}
+	})
+
+	t.Run("condition satisfied allows auto promotion", func(t *testing.T) {
+		evaluator := &promotionConditionEvaluatorStub{isPromotionAllowed: true}
+		timelineService := &timelineServiceStub{}
+		impl := &WorkflowDagExecutorImpl{logger: logger, promotionConditionEvaluator: evaluator, pipelineStatusTimelineService: timelineService}
+		pipeline := &pipelineConfig.Pipeline{Id: 3, Name: "prod", TriggerType: pipelineConfig.TRIGGER_TYPE_AUTOMATIC, PromotionCondition: "isProdEnv"}
+
+		isPromotionAllowed, err := impl.isPromotionAllowed(pipeline, artifact, 11)
+		assert.NoError(t, err)
+		assert.True(t, isPromotionAllowed)
+		assert.Equal(t, 1, evaluator.evaluatedCount)
+		assert.Empty(t, timelineService.savedTimelines)
+	})
+
+	t.Run("evaluation error blocks auto promotion and records the error", func(t *testing.T) {
+		evaluator := &promotionConditionEvaluatorStub{err: errors.New("error in fetching environment")}
+		timelineService := &timelineServiceStub{}
+		cdWorkflowRepository := &cdWorkflowRepositoryStub{deployRunners: map[int]pipelineConfig.CdWorkflowRunner{11: {Id: 21}}}
+		impl := &WorkflowDagExecutorImpl{logger: logger, promotionConditionEvaluator: evaluator,
+			pipelineStatusTimelineService: timelineService, cdWorkflowRepository: cdWorkflowRepository}
+		pipeline := &pipelineConfig.Pipeline{Id: 3, Name: "prod", TriggerType: pipelineConfig.TRIGGER_TYPE_AUTOMATIC, PromotionCondition: "isProdEnv"}
+
+		isPromotionAllowed, err := impl.isPromotionAllowed(pipeline, artifact, 11)
+		assert.Error(t, err)
+		assert.False(t, isPromotionAllowed)
+		if assert.Len(t, timelineService.savedTimelines, 1) {
+			assert.Equal(t, timelineStatus.TIMELINE_STATUS_PROMOTION_BLOCKED, timelineService.savedTimelines[0].Status)
+			assert.Contains(t, timelineService.savedTimelines[0].StatusDetail, "could not be evaluated, error in fetching environment")
+		}
+	})
+
+	t.Run("manual pipeline with an automatic pre stage is not gated", func(t *testing.T) {
+		evaluator := &promotionConditionEvaluatorStub{isPromotionAllowed: false}
+		timelineService := &timelineServiceStub{}
+		impl := &WorkflowDagExecutorImpl{logger: logger, promotionConditionEvaluator: evaluator, pipelineStatusTimelineService: timelineService}
+		pipeline := &pipelineConfig.Pipeline{Id: 3, Name: "prod", TriggerType: pipelineConfig.TRIGGER_TYPE_MANUAL, PreTriggerType: pipelineConfig.TRIGGER_TYPE_AUTOMATIC, PromotionCondition: "isProdEnv"}
+
+		isPromotionAllowed, err := impl.isPromotionAllowed(pipeline, artifact, 11)
+		assert.NoError(t, err)
+		assert.True(t, isPromotionAllowed)
+		assert.Zero(t, evaluator.evaluatedCount)
+		assert.Empty(t, timelineService.savedTimelines)
+	})
+
+	t.Run("manual pipeline is not gated", func(t *testing.T) {
+		evaluator := &promotionConditionEvaluatorStub{isPromotionAllowed: false}
+		impl := &WorkflowDagExecutorImpl{logger: logger, promotionConditionEvaluator: evaluator}
+		pipeline := &pipelineConfig.Pipeline{Id: 3, Name: "prod", TriggerType: pipelineConfig.TRIGGER_TYPE_MANUAL, PreTriggerType: pipelineConfig.TRIGGER_TYPE_MANUAL, PromotionCondition: "isProdEnv"}
+
+		isPromotionAllowed, err := impl.isPromotionAllowed(pipeline, artifact, 11)
+		assert.NoError(t, err)
+		assert.True(t, isPromotionAllowed)
+		assert.Zero(t, evaluator.evaluatedCount)
+	})
+}
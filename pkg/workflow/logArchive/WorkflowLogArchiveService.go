@@ -0,0 +1,553 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logArchive
+
+import (
+	"errors"
+	"fmt"
+	"github.com/caarlos0/env"
+	"github.com/devtron-labs/devtron/internal/util"
+	userBean "github.com/devtron-labs/devtron/pkg/auth/user/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/helper"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/repository"
+	cron2 "github.com/devtron-labs/devtron/util/cron"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+type WorkflowLogArchiveService interface {
+	// IsArchiveOnCompletionEnabled the workflow handlers archive the logs as soon as the workflow completes if enabled
+	IsArchiveOnCompletionEnabled() bool
+	// ArchiveWorkflowLogs compresses the logs of a completed workflow with a line index and uploads the archive
+	// next to the raw logs in the blob storage. It is a no-op for an already archived workflow.
+	ArchiveWorkflowLogs(workflowType bean.WorkflowLogType, workflowId int) error
+	// GetLogLines returns a range of lines of the logs of a completed workflow, the logs are archived if not done already
+	GetLogLines(workflowType bean.WorkflowLogType, workflowId int, offset, limit int) (*bean.LogLinesResponse, error)
+	SearchLogs(workflowType bean.WorkflowLogType, workflowId int, query *bean.LogSearchQuery) (*bean.LogSearchResponse, error)
+	// SearchPipelineLogs searches the logs of the last completed workflows of the pipeline, latest first
+	SearchPipelineLogs(workflowType bean.WorkflowLogType, pipelineId int, query *bean.LogSearchQuery) ([]*bean.LogSearchResponse, error)
+	GetWorkflowAppId(workflowType bean.WorkflowLogType, workflowId int) (int, error)
+	GetPipelineAppId(workflowType bean.WorkflowLogType, pipelineId int) (int, error)
+
+	GetRetentionPolicies() ([]*bean.LogRetentionPolicyDto, error)
+	// SaveRetentionPolicy creates the policy of the project and environment, or updates it if one exists
+	SaveRetentionPolicy(policy *bean.LogRetentionPolicyDto) (*bean.LogRetentionPolicyDto, error)
+	DeleteRetentionPolicy(id int, userId int32) error
+	// CleanupExpiredLogs deletes the logs, and the artifacts if configured, of the workflows older than the retention
+	// days of the policy applicable to them from the blob storage
+	CleanupExpiredLogs()
+}
+
+type WorkflowLogArchiveConfig struct {
+	ArchiveEnabled            bool   `env:"WORKFLOW_LOG_ARCHIVE_ENABLED" envDefault:"false" description:"Archive the logs of ci/cd workflows compressed with a line index as soon as they complete, else the logs are archived on the first range read or search"`
+	LinesPerBlock             int    `env:"WORKFLOW_LOG_ARCHIVE_LINES_PER_BLOCK" envDefault:"1000" description:"Number of log lines compressed together in the archive, a range read decompresses only the blocks of the range"`
+	DeleteRawLogs             bool   `env:"WORKFLOW_LOG_ARCHIVE_DELETE_RAW_LOGS" envDefault:"false" description:"Delete the raw log file from the blob storage once the archive is uploaded, the logs are then served from the archive"`
+	SearchMaxBuilds           int    `env:"WORKFLOW_LOG_SEARCH_MAX_BUILDS" envDefault:"20" description:"Maximum number of latest workflows of a pipeline searched in a pipeline level log search"`
+	SearchMaxMatches          int    `env:"WORKFLOW_LOG_SEARCH_MAX_MATCHES" envDefault:"500" description:"Maximum number of matching lines returned per workflow in a log search"`
+	RetentionCleanupEnabled   bool   `env:"WORKFLOW_LOG_RETENTION_CLEANUP_ENABLED" envDefault:"false" description:"Enable the periodic deletion of the workflow logs and artifacts expired as per the log retention policies"`
+	RetentionCleanupCron      string `env:"WORKFLOW_LOG_RETENTION_CLEANUP_CRON" envDefault:"0 2 * * *" description:"Cron schedule of the workflow log retention cleanup"`
+	RetentionCleanupBatchSize int    `env:"WORKFLOW_LOG_RETENTION_CLEANUP_BATCH_SIZE" envDefault:"100" description:"Number of workflows fetched in a batch by the workflow log retention cleanup"`
+	AzureEnvironment          string `env:"WORKFLOW_LOG_ARCHIVE_AZURE_ENVIRONMENT" envDefault:"AzurePublicCloud" description:"Azure cloud of the azure blob storage account, its storage endpoint is used to delete the workflow logs (AzurePublicCloud/AzureChinaCloud/AzureUSGovernmentCloud)"`
+}
+
+func GetWorkflowLogArchiveConfig() (*WorkflowLogArchiveConfig, error) {
+	cfg := &WorkflowLogArchiveConfig{}
+	err := env.Parse(cfg)
+	if err != nil {
+		fmt.Println("failed to parse workflow log archive config: " + err.Error())
+		return nil, err
+	}
+	return cfg, nil
+}
+
+type WorkflowLogArchiveServiceImpl struct {
+	logger                       *zap.SugaredLogger
+	cron                         *cron.Cron
+	config                       *WorkflowLogArchiveConfig
+	ciConfig                     *types.CiConfig
+	cdConfig                     *types.CdConfig
+	workflowLogArchiveRepository repository.WorkflowLogArchiveRepository
+	workflowLogBlobStore         WorkflowLogBlobStore
+}
+
+func NewWorkflowLogArchiveServiceImpl(logger *zap.SugaredLogger, cfg *WorkflowLogArchiveConfig, cronLogger *cron2.CronLoggerImpl,
+	workflowLogArchiveRepository repository.WorkflowLogArchiveRepository,
+	workflowLogBlobStore WorkflowLogBlobStore) (*WorkflowLogArchiveServiceImpl, error) {
+	ciConfig, err := types.GetCiConfig()
+	if err != nil {
+		return nil, err
+	}
+	cdConfig, err := types.GetCdConfig()
+	if err != nil {
+		return nil, err
+	}
+	impl := &WorkflowLogArchiveServiceImpl{
+		logger:                       logger,
+		config:                       cfg,
+		ciConfig:                     ciConfig,
+		cdConfig:                     cdConfig,
+		workflowLogArchiveRepository: workflowLogArchiveRepository,
+		workflowLogBlobStore:         workflowLogBlobStore,
+	}
+	if !cfg.RetentionCleanupEnabled {
+		return impl, nil
+	}
+	impl.cron = cron.New(
+		cron.WithChain(cron.Recover(cronLogger)))
+	impl.cron.Start()
+	_, err = impl.cron.AddFunc(cfg.RetentionCleanupCron, impl.CleanupExpiredLogs)
+	if err != nil {
+		logger.Errorw("error while configure cron job for workflow log retention cleanup", "err", err)
+		return impl, nil
+	}
+	return impl, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) IsArchiveOnCompletionEnabled() bool {
+	return impl.config.ArchiveEnabled
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) ArchiveWorkflowLogs(workflowType bean.WorkflowLogType, workflowId int) error {
+	metadata, err := impl.getWorkflowLogMetadata(workflowType, workflowId)
+	if err != nil {
+		return err
+	}
+	_, err = impl.getOrCreateArchive(workflowType, metadata)
+	return err
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) GetLogLines(workflowType bean.WorkflowLogType, workflowId int, offset, limit int) (*bean.LogLinesResponse, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, util.NewApiError(http.StatusBadRequest, "offset must not be negative and limit must be positive", "invalid line range")
+	}
+	metadata, err := impl.getWorkflowLogMetadata(workflowType, workflowId)
+	if err != nil {
+		return nil, err
+	}
+	response := &bean.LogLinesResponse{WorkflowId: workflowId, Offset: offset}
+	err = impl.readArchive(workflowType, metadata, func(archive *os.File, index *bean.LogArchiveIndex) error {
+		lines, err := helper.ReadLogLines(archive, index, offset, limit)
+		if err != nil {
+			return err
+		}
+		response.TotalLines = index.TotalLines
+		response.Lines = lines
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) SearchLogs(workflowType bean.WorkflowLogType, workflowId int, query *bean.LogSearchQuery) (*bean.LogSearchResponse, error) {
+	err := impl.validateSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := impl.getWorkflowLogMetadata(workflowType, workflowId)
+	if err != nil {
+		return nil, err
+	}
+	return impl.searchWorkflowLogs(workflowType, metadata, query)
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) SearchPipelineLogs(workflowType bean.WorkflowLogType, pipelineId int, query *bean.LogSearchQuery) ([]*bean.LogSearchResponse, error) {
+	err := impl.validateSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if query.LastBuilds <= 0 || query.LastBuilds > impl.config.SearchMaxBuilds {
+		query.LastBuilds = impl.config.SearchMaxBuilds
+	}
+	workflows, err := impl.workflowLogArchiveRepository.GetLatestWorkflowLogMetadata(workflowType, pipelineId, query.LastBuilds)
+	if err != nil {
+		impl.logger.Errorw("error in getting latest workflows of pipeline", "workflowType", workflowType, "pipelineId", pipelineId, "err", err)
+		return nil, err
+	}
+	responses := make([]*bean.LogSearchResponse, 0, len(workflows))
+	for _, metadata := range workflows {
+		response, err := impl.searchWorkflowLogs(workflowType, metadata, query)
+		if err != nil {
+			// the logs of a workflow can be expired or missing, the search continues with the other workflows
+			impl.logger.Warnw("error in searching workflow logs", "workflowType", workflowType, "workflowId", metadata.WorkflowId, "err", err)
+			response = impl.newLogSearchResponse(metadata)
+			response.Error = getErrorMessage(err)
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) GetWorkflowAppId(workflowType bean.WorkflowLogType, workflowId int) (int, error) {
+	metadata, err := impl.getWorkflowLogMetadata(workflowType, workflowId)
+	if err != nil {
+		return 0, err
+	}
+	return metadata.AppId, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) GetPipelineAppId(workflowType bean.WorkflowLogType, pipelineId int) (int, error) {
+	appId, err := impl.workflowLogArchiveRepository.GetPipelineAppId(workflowType, pipelineId)
+	if util.IsErrNoRows(err) {
+		return 0, util.NewApiError(http.StatusNotFound, "pipeline not found", err.Error())
+	} else if err != nil {
+		impl.logger.Errorw("error in getting app of pipeline", "workflowType", workflowType, "pipelineId", pipelineId, "err", err)
+		return 0, err
+	}
+	return appId, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) GetRetentionPolicies() ([]*bean.LogRetentionPolicyDto, error) {
+	models, err := impl.workflowLogArchiveRepository.FindAllActivePolicies()
+	if err != nil {
+		impl.logger.Errorw("error in getting log retention policies", "err", err)
+		return nil, err
+	}
+	policies := make([]*bean.LogRetentionPolicyDto, 0, len(models))
+	for _, model := range models {
+		policies = append(policies, toRetentionPolicyDto(model))
+	}
+	return policies, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) SaveRetentionPolicy(policy *bean.LogRetentionPolicyDto) (*bean.LogRetentionPolicyDto, error) {
+	model, err := impl.workflowLogArchiveRepository.FindActivePolicyByScope(policy.TeamId, policy.EnvironmentId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting log retention policy", "teamId", policy.TeamId, "environmentId", policy.EnvironmentId, "err", err)
+		return nil, err
+	}
+	model.TeamId = policy.TeamId
+	model.EnvironmentId = policy.EnvironmentId
+	model.RetentionDays = policy.RetentionDays
+	model.DeleteArtifacts = policy.DeleteArtifacts
+	model.Active = true
+	model.UpdateAuditLog(policy.UserId)
+	if model.Id == 0 {
+		model.CreateAuditLog(policy.UserId)
+		err = impl.workflowLogArchiveRepository.SavePolicy(model)
+	} else {
+		err = impl.workflowLogArchiveRepository.UpdatePolicy(model)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving log retention policy", "policy", policy, "err", err)
+		return nil, err
+	}
+	return toRetentionPolicyDto(model), nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) DeleteRetentionPolicy(id int, userId int32) error {
+	model, err := impl.workflowLogArchiveRepository.FindActivePolicyById(id)
+	if util.IsErrNoRows(err) {
+		return util.NewApiError(http.StatusNotFound, "log retention policy not found", err.Error())
+	} else if err != nil {
+		impl.logger.Errorw("error in getting log retention policy", "id", id, "err", err)
+		return err
+	}
+	model.Active = false
+	model.UpdateAuditLog(userId)
+	err = impl.workflowLogArchiveRepository.UpdatePolicy(model)
+	if err != nil {
+		impl.logger.Errorw("error in deleting log retention policy", "id", id, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) CleanupExpiredLogs() {
+	policies, err := impl.GetRetentionPolicies()
+	if err != nil || len(policies) == 0 {
+		return
+	}
+	// the workflows finished before the shortest retention are the only candidates for expiry
+	minRetentionDays := slices.MinFunc(policies, func(a, b *bean.LogRetentionPolicyDto) int {
+		return a.RetentionDays - b.RetentionDays
+	}).RetentionDays
+	now := time.Now()
+	for _, workflowType := range []bean.WorkflowLogType{bean.CiWorkflowLog, bean.PreCdWorkflowLog, bean.PostCdWorkflowLog} {
+		finishedBefore := now.AddDate(0, 0, -minRetentionDays)
+		expiredCount := 0
+		afterWorkflowId := 0
+		for {
+			workflows, err := impl.workflowLogArchiveRepository.GetUnexpiredWorkflowLogMetadata(workflowType, finishedBefore, afterWorkflowId, impl.config.RetentionCleanupBatchSize)
+			if err != nil {
+				impl.logger.Errorw("error in getting workflows for log retention cleanup", "workflowType", workflowType, "err", err)
+				break
+			}
+			for _, metadata := range workflows {
+				afterWorkflowId = metadata.WorkflowId
+				policy := bean.GetApplicableRetentionPolicy(policies, metadata.TeamId, metadata.EnvironmentId)
+				if policy == nil || metadata.FinishedOn.After(now.AddDate(0, 0, -policy.RetentionDays)) {
+					continue
+				}
+				err = impl.expireWorkflowLogs(workflowType, metadata, policy)
+				if err != nil {
+					impl.logger.Errorw("error in deleting expired workflow logs", "workflowType", workflowType, "workflowId", metadata.WorkflowId, "err", err)
+					continue
+				}
+				expiredCount++
+			}
+			if len(workflows) < impl.config.RetentionCleanupBatchSize {
+				break
+			}
+		}
+		impl.logger.Infow("workflow log retention cleanup completed", "workflowType", workflowType, "expiredCount", expiredCount)
+	}
+}
+
+// expireWorkflowLogs deletes the raw logs, the archive and the artifacts (as per the policy) of the workflow
+func (impl *WorkflowLogArchiveServiceImpl) expireWorkflowLogs(workflowType bean.WorkflowLogType, metadata *repository.WorkflowLogMetadata, policy *bean.LogRetentionPolicyDto) error {
+	archive, err := impl.workflowLogArchiveRepository.FindByWorkflow(workflowType, metadata.WorkflowId)
+	if err != nil && !util.IsErrNoRows(err) {
+		return err
+	}
+	if isLogStoredInBlob(metadata) && !impl.isExternalBlobStorage(workflowType, metadata) {
+		logRequest := impl.getBuildLogRequest(workflowType, metadata)
+		keys := []string{metadata.LogKey, bean.GetArchiveKey(metadata.LogKey)}
+		if policy.DeleteArtifacts && len(metadata.ArtifactKey) > 0 {
+			keys = append(keys, metadata.ArtifactKey)
+		}
+		for _, key := range keys {
+			err = impl.workflowLogBlobStore.Delete(logRequest, key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	archive.WorkflowId = metadata.WorkflowId
+	archive.WorkflowType = workflowType
+	archive.PipelineId = metadata.PipelineId
+	archive.LogKey = metadata.LogKey
+	archive.LineIndex = nil
+	archive.Status = bean.LogArchiveStatusExpired
+	archive.ExpiredOn = time.Now()
+	return impl.saveArchive(archive)
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) getWorkflowLogMetadata(workflowType bean.WorkflowLogType, workflowId int) (*repository.WorkflowLogMetadata, error) {
+	if !workflowType.IsValid() {
+		return nil, util.NewApiError(http.StatusBadRequest, fmt.Sprintf("invalid workflow type %s", workflowType), "invalid workflow type")
+	}
+	metadata, err := impl.workflowLogArchiveRepository.GetWorkflowLogMetadata(workflowType, workflowId)
+	if util.IsErrNoRows(err) {
+		return nil, util.NewApiError(http.StatusNotFound, "workflow not found", err.Error())
+	} else if err != nil {
+		impl.logger.Errorw("error in getting workflow log metadata", "workflowType", workflowType, "workflowId", workflowId, "err", err)
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) validateSearchQuery(query *bean.LogSearchQuery) error {
+	if _, err := helper.GetLogLineMatcher(query); err != nil {
+		return util.NewApiError(http.StatusBadRequest, err.Error(), err.Error())
+	}
+	if query.MaxMatches <= 0 || query.MaxMatches > impl.config.SearchMaxMatches {
+		query.MaxMatches = impl.config.SearchMaxMatches
+	}
+	return nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) searchWorkflowLogs(workflowType bean.WorkflowLogType, metadata *repository.WorkflowLogMetadata, query *bean.LogSearchQuery) (*bean.LogSearchResponse, error) {
+	response := impl.newLogSearchResponse(metadata)
+	err := impl.readArchive(workflowType, metadata, func(archive *os.File, index *bean.LogArchiveIndex) error {
+		matches, truncated, err := helper.SearchLogArchive(archive, index, query)
+		if err != nil {
+			return err
+		}
+		response.TotalLines = index.TotalLines
+		response.Matches = matches
+		response.Truncated = truncated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) newLogSearchResponse(metadata *repository.WorkflowLogMetadata) *bean.LogSearchResponse {
+	return &bean.LogSearchResponse{
+		WorkflowId: metadata.WorkflowId,
+		Status:     metadata.Status,
+		FinishedOn: metadata.FinishedOn,
+		Matches:    make([]*bean.LogLine, 0),
+	}
+}
+
+// readArchive downloads the archive of the workflow logs to a temp file, archiving the logs first if required
+func (impl *WorkflowLogArchiveServiceImpl) readArchive(workflowType bean.WorkflowLogType, metadata *repository.WorkflowLogMetadata,
+	read func(archive *os.File, index *bean.LogArchiveIndex) error) error {
+	archive, err := impl.getOrCreateArchive(workflowType, metadata)
+	if err != nil {
+		return err
+	}
+	tempFile := impl.getTempFilePath(workflowType, metadata.WorkflowId, bean.LogArchiveFileSuffix)
+	defer impl.removeTempFile(tempFile)
+	err = impl.workflowLogBlobStore.Download(impl.getBuildLogRequest(workflowType, metadata), archive.ArchiveKey, tempFile)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(tempFile)
+	if err != nil {
+		impl.logger.Errorw("error in opening log archive", "tempFile", tempFile, "err", err)
+		return err
+	}
+	defer file.Close()
+	return read(file, archive.LineIndex)
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) getOrCreateArchive(workflowType bean.WorkflowLogType, metadata *repository.WorkflowLogMetadata) (*repository.WorkflowLogArchive, error) {
+	archive, err := impl.workflowLogArchiveRepository.FindByWorkflow(workflowType, metadata.WorkflowId)
+	if err != nil && !util.IsErrNoRows(err) {
+		impl.logger.Errorw("error in getting workflow log archive", "workflowType", workflowType, "workflowId", metadata.WorkflowId, "err", err)
+		return nil, err
+	}
+	switch archive.Status {
+	case bean.LogArchiveStatusArchived:
+		return archive, nil
+	case bean.LogArchiveStatusExpired:
+		return nil, util.NewApiError(http.StatusGone, "logs of the workflow are deleted as per the log retention policy", "workflow logs expired")
+	}
+	if !slices.Contains(bean.TerminalWorkflowStatuses, metadata.Status) {
+		return nil, util.NewApiError(http.StatusBadRequest, "logs can be searched once the workflow completes", "workflow not completed")
+	} else if !isLogStoredInBlob(metadata) || impl.isExternalBlobStorage(workflowType, metadata) {
+		return nil, util.NewApiError(http.StatusNotFound, "logs of the workflow are not stored in the blob storage configured in devtron", "workflow logs not found")
+	}
+	logRequest := impl.getBuildLogRequest(workflowType, metadata)
+	rawLogFile := impl.getTempFilePath(workflowType, metadata.WorkflowId, ".log")
+	defer impl.removeTempFile(rawLogFile)
+	err = impl.workflowLogBlobStore.Download(logRequest, metadata.LogKey, rawLogFile)
+	if err != nil {
+		return nil, util.NewApiError(http.StatusNotFound, "logs of the workflow are not found in the blob storage", err.Error())
+	}
+	archiveFile := impl.getTempFilePath(workflowType, metadata.WorkflowId, bean.LogArchiveFileSuffix)
+	defer impl.removeTempFile(archiveFile)
+	index, err := impl.writeArchive(rawLogFile, archiveFile)
+	if err != nil {
+		impl.logger.Errorw("error in compressing workflow logs", "workflowType", workflowType, "workflowId", metadata.WorkflowId, "err", err)
+		return nil, err
+	}
+	archiveKey := bean.GetArchiveKey(metadata.LogKey)
+	err = impl.workflowLogBlobStore.Upload(logRequest, archiveFile, archiveKey)
+	if err != nil {
+		return nil, err
+	}
+	archive.WorkflowId = metadata.WorkflowId
+	archive.WorkflowType = workflowType
+	archive.PipelineId = metadata.PipelineId
+	archive.LogKey = metadata.LogKey
+	archive.ArchiveKey = archiveKey
+	archive.LineIndex = index
+	archive.Status = bean.LogArchiveStatusArchived
+	err = impl.saveArchive(archive)
+	if err != nil {
+		return nil, err
+	}
+	if impl.config.DeleteRawLogs {
+		// the logs are served from the archive once the raw logs are deleted
+		err = impl.workflowLogBlobStore.Delete(logRequest, metadata.LogKey)
+		if err != nil {
+			impl.logger.Errorw("error in deleting raw workflow logs", "workflowType", workflowType, "workflowId", metadata.WorkflowId, "err", err)
+		}
+	}
+	return archive, nil
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) writeArchive(rawLogFile, archiveFile string) (*bean.LogArchiveIndex, error) {
+	src, err := os.Open(rawLogFile)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	dst, err := os.Create(archiveFile)
+	if err != nil {
+		return nil, err
+	}
+	defer dst.Close()
+	return helper.WriteLogArchive(src, dst, impl.config.LinesPerBlock)
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) saveArchive(archive *repository.WorkflowLogArchive) error {
+	archive.UpdateAuditLog(userBean.SystemUserId)
+	var err error
+	if archive.Id == 0 {
+		archive.CreateAuditLog(userBean.SystemUserId)
+		err = impl.workflowLogArchiveRepository.Save(archive)
+	} else {
+		err = impl.workflowLogArchiveRepository.Update(archive)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in saving workflow log archive", "workflowType", archive.WorkflowType, "workflowId", archive.WorkflowId, "err", err)
+	}
+	return err
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) getBuildLogRequest(workflowType bean.WorkflowLogType, metadata *repository.WorkflowLogMetadata) types.BuildLogRequest {
+	if workflowType.IsCdStage() {
+		return impl.cdConfig.GetBuildLogRequest(metadata.PodName, metadata.Namespace, metadata.LogKey)
+	}
+	return impl.ciConfig.GetBuildLogRequest(metadata.PodName, metadata.Namespace, metadata.LogKey)
+}
+
+// isExternalBlobStorage workflows running in an external cluster may upload the logs to the blob storage of that cluster
+func (impl *WorkflowLogArchiveServiceImpl) isExternalBlobStorage(workflowType bean.WorkflowLogType, metadata *repository.WorkflowLogMetadata) bool {
+	if workflowType.IsCdStage() {
+		return metadata.IsExternalRun && !impl.cdConfig.UseBlobStorageConfigInCdWorkflow
+	}
+	return metadata.IsExternalRun && !impl.ciConfig.UseBlobStorageConfigInCiWorkflow
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) getTempFilePath(workflowType bean.WorkflowLogType, workflowId int, suffix string) string {
+	fileName := fmt.Sprintf("%s-%d-%d%s", workflowType, workflowId, time.Now().UnixNano(), suffix)
+	return filepath.Clean(filepath.Join(impl.ciConfig.BaseLogLocationPath, fileName))
+}
+
+func (impl *WorkflowLogArchiveServiceImpl) removeTempFile(tempFile string) {
+	if err := os.Remove(tempFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		impl.logger.Errorw("error in cleaning up temp file", "tempFile", tempFile, "err", err)
+	}
+}
+
+func isLogStoredInBlob(metadata *repository.WorkflowLogMetadata) bool {
+	return metadata.BlobStorageEnabled && len(metadata.LogKey) > 0
+}
+
+func getErrorMessage(err error) string {
+	var apiErr *util.ApiError
+	if errors.As(err, &apiErr) && apiErr.UserMessage != nil {
+		return fmt.Sprint(apiErr.UserMessage)
+	}
+	return err.Error()
+}
+
+func toRetentionPolicyDto(model *repository.LogRetentionPolicy) *bean.LogRetentionPolicyDto {
+	return &bean.LogRetentionPolicyDto{
+		Id:              model.Id,
+		TeamId:          model.TeamId,
+		EnvironmentId:   model.EnvironmentId,
+		RetentionDays:   model.RetentionDays,
+		DeleteArtifacts: model.DeleteArtifacts,
+	}
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logArchive
+
+import (
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/repository"
+	"github.com/go-pg/pg"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+type workflowLogBlobStoreStub struct {
+	objects     map[string][]byte
+	uploadCount int
+	deletedKeys []string
+	deleteErr   error
+}
+
+func (impl *workflowLogBlobStoreStub) Download(logRequest types.BuildLogRequest, key string, destinationFile string) error {
+	content, ok := impl.objects[key]
+	if !ok {
+		return errors.New("object not found")
+	}
+	return os.WriteFile(destinationFile, content, 0644)
+}
+
+func (impl *workflowLogBlobStoreStub) Upload(logRequest types.BuildLogRequest, sourceFile string, key string) error {
+	content, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return err
+	}
+	impl.uploadCount++
+	impl.objects[key] = content
+	return nil
+}
+
+func (impl *workflowLogBlobStoreStub) Delete(logRequest types.BuildLogRequest, key string) error {
+	if impl.deleteErr != nil {
+		return impl.deleteErr
+	}
+	impl.deletedKeys = append(impl.deletedKeys, key)
+	delete(impl.objects, key)
+	return nil
+}
+
+type workflowLogArchiveRepositoryStub struct {
+	repository.WorkflowLogArchiveRepository
+	metadata []*repository.WorkflowLogMetadata
+	archives map[int]*repository.WorkflowLogArchive
+	policies []*repository.LogRetentionPolicy
+}
+
+func (impl *workflowLogArchiveRepositoryStub) Save(model *repository.WorkflowLogArchive) error {
+	model.Id = len(impl.archives) + 1
+	impl.archives[model.WorkflowId] = model
+	return nil
+}
+
+func (impl *workflowLogArchiveRepositoryStub) Update(model *repository.WorkflowLogArchive) error {
+	impl.archives[model.WorkflowId] = model
+	return nil
+}
+
+func (impl *workflowLogArchiveRepositoryStub) FindByWorkflow(workflowType bean.WorkflowLogType, workflowId int) (*repository.WorkflowLogArchive, error) {
+	archive, ok := impl.archives[workflowId]
+	if !ok {
+		return &repository.WorkflowLogArchive{}, pg.ErrNoRows
+	}
+	// a copy, as a read from the db
+	archiveCopy := *archive
+	return &archiveCopy, nil
+}
+
+func (impl *workflowLogArchiveRepositoryStub) GetWorkflowLogMetadata(workflowType bean.WorkflowLogType, workflowId int) (*repository.WorkflowLogMetadata, error) {
+	for _, metadata := range impl.metadata {
+		if metadata.WorkflowId == workflowId {
+			return metadata, nil
+		}
+	}
+	return nil, pg.ErrNoRows
+}
+
+func (impl *workflowLogArchiveRepositoryStub) GetUnexpiredWorkflowLogMetadata(workflowType bean.WorkflowLogType, finishedBefore time.Time, afterWorkflowId int, limit int) ([]*repository.WorkflowLogMetadata, error) {
+	if workflowType != bean.CiWorkflowLog {
+		return nil, nil
+	}
+	sort.Slice(impl.metadata, func(i, j int) bool {
+		return impl.metadata[i].WorkflowId < impl.metadata[j].WorkflowId
+	})
+	workflows := make([]*repository.WorkflowLogMetadata, 0)
+	for _, metadata := range impl.metadata {
+		archive, ok := impl.archives[metadata.WorkflowId]
+		if metadata.WorkflowId <= afterWorkflowId || !metadata.FinishedOn.Before(finishedBefore) ||
+			(ok && archive.Status == bean.LogArchiveStatusExpired) {
+			continue
+		}
+		workflows = append(workflows, metadata)
+		if len(workflows) == limit {
+			break
+		}
+	}
+	return workflows, nil
+}
+
+func (impl *workflowLogArchiveRepositoryStub) FindAllActivePolicies() ([]*repository.LogRetentionPolicy, error) {
+	return impl.policies, nil
+}
+
+func getWorkflowLogArchiveService(t *testing.T, config *WorkflowLogArchiveConfig, repositoryStub *workflowLogArchiveRepositoryStub,
+	blobStoreStub *workflowLogBlobStoreStub) *WorkflowLogArchiveServiceImpl {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	ciCdConfig := &types.CiCdConfig{BaseLogLocationPath: t.TempDir(), CloudProvider: types.BLOB_STORAGE_S3}
+	if repositoryStub.archives == nil {
+		repositoryStub.archives = make(map[int]*repository.WorkflowLogArchive)
+	}
+	return &WorkflowLogArchiveServiceImpl{
+		logger:                       logger,
+		config:                       config,
+		ciConfig:                     &types.CiConfig{CiCdConfig: ciCdConfig},
+		cdConfig:                     &types.CdConfig{CiCdConfig: ciCdConfig},
+		workflowLogArchiveRepository: repositoryStub,
+		workflowLogBlobStore:         blobStoreStub,
+	}
+}
+
+func getCompletedWorkflowLogMetadata(workflowId int) *repository.WorkflowLogMetadata {
+	return &repository.WorkflowLogMetadata{
+		WorkflowId:         workflowId,
+		PipelineId:         1,
+		Status:             cdWorkflow.WorkflowSucceeded,
+		LogKey:             "ci-logs/build-1/main.log",
+		BlobStorageEnabled: true,
+	}
+}
+
+func TestGetLogLines(t *testing.T) {
+	rawLogs := []byte("line 1\nline 2\nline 3\nline 4\nline 5\n")
+
+	t.Run("logs are archived on the first read and served from the archive", func(t *testing.T) {
+		metadata := getCompletedWorkflowLogMetadata(1)
+		repositoryStub := &workflowLogArchiveRepositoryStub{metadata: []*repository.WorkflowLogMetadata{metadata}}
+		blobStoreStub := &workflowLogBlobStoreStub{objects: map[string][]byte{metadata.LogKey: rawLogs}}
+		impl := getWorkflowLogArchiveService(t, &WorkflowLogArchiveConfig{LinesPerBlock: 2}, repositoryStub, blobStoreStub)
+
+		response, err := impl.GetLogLines(bean.CiWorkflowLog, 1, 1, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, response.TotalLines)
+		assert.Equal(t, []*bean.LogLine{{Number: 2, Content: "line 2"}, {Number: 3, Content: "line 3"}}, response.Lines)
+		archive := repositoryStub.archives[1]
+		assert.Equal(t, bean.LogArchiveStatusArchived, archive.Status)
+		assert.Equal(t, bean.GetArchiveKey(metadata.LogKey), archive.ArchiveKey)
+		assert.Contains(t, blobStoreStub.objects, archive.ArchiveKey)
+		// the raw logs are retained unless configured
+		assert.Empty(t, blobStoreStub.deletedKeys)
+
+		response, err = impl.GetLogLines(bean.CiWorkflowLog, 1, 4, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, []*bean.LogLine{{Number: 5, Content: "line 5"}}, response.Lines)
+		assert.Equal(t, 1, blobStoreStub.uploadCount)
+	})
+
+	t.Run("raw logs are deleted once archived if configured", func(t *testing.T) {
+		metadata := getCompletedWorkflowLogMetadata(1)
+		repositoryStub := &workflowLogArchiveRepositoryStub{metadata: []*repository.WorkflowLogMetadata{metadata}}
+		blobStoreStub := &workflowLogBlobStoreStub{objects: map[string][]byte{metadata.LogKey: rawLogs}}
+		impl := getWorkflowLogArchiveService(t, &WorkflowLogArchiveConfig{LinesPerBlock: 2, DeleteRawLogs: true}, repositoryStub, blobStoreStub)
+
+		assert.Nil(t, impl.ArchiveWorkflowLogs(bean.CiWorkflowLog, 1))
+		assert.Equal(t, []string{metadata.LogKey}, blobStoreStub.deletedKeys)
+		response, err := impl.GetLogLines(bean.CiWorkflowLog, 1, 0, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "line 1", response.Lines[0].Content)
+	})
+
+	t.Run("logs of running, expired and unknown workflows are not served", func(t *testing.T) {
+		running := getCompletedWorkflowLogMetadata(1)
+		running.Status = cdWorkflow.WorkflowInProgress
+		expired := getCompletedWorkflowLogMetadata(2)
+		repositoryStub := &workflowLogArchiveRepositoryStub{
+			metadata: []*repository.WorkflowLogMetadata{running, expired},
+			archives: map[int]*repository.WorkflowLogArchive{2: {Id: 1, WorkflowId: 2, Status: bean.LogArchiveStatusExpired}},
+		}
+		impl := getWorkflowLogArchiveService(t, &WorkflowLogArchiveConfig{LinesPerBlock: 2}, repositoryStub, &workflowLogBlobStoreStub{objects: map[string][]byte{}})
+
+		for workflowId, expectedCode := range map[int]int{1: http.StatusBadRequest, 2: http.StatusGone, 3: http.StatusNotFound} {
+			_, err := impl.GetLogLines(bean.CiWorkflowLog, workflowId, 0, 10)
+			var apiErr *util.ApiError
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, expectedCode, apiErr.HttpStatusCode, "workflowId %d", workflowId)
+		}
+	})
+}
+
+func TestCleanupExpiredLogs(t *testing.T) {
+	now := time.Now()
+	getMetadata := func(workflowId, teamId, finishedDaysAgo int) *repository.WorkflowLogMetadata {
+		metadata := getCompletedWorkflowLogMetadata(workflowId)
+		metadata.TeamId = teamId
+		metadata.FinishedOn = now.AddDate(0, 0, -finishedDaysAgo)
+		metadata.LogKey = fmt.Sprintf("ci-logs/build-%d/main.log", workflowId)
+		metadata.ArtifactKey = fmt.Sprintf("ci-artifacts/build-%d/artifacts.zip", workflowId)
+		return metadata
+	}
+	policies := []*repository.LogRetentionPolicy{
+		{Id: 1, RetentionDays: 30, Active: true},
+		{Id: 2, TeamId: 2, RetentionDays: 7, DeleteArtifacts: true, Active: true},
+	}
+
+	t.Run("logs older than the applicable policy are deleted", func(t *testing.T) {
+		withinRetention := getMetadata(1, 1, 10)
+		expiredWithArtifacts := getMetadata(2, 2, 10)
+		expired := getMetadata(3, 1, 40)
+		expiredExternal := getMetadata(4, 1, 40)
+		expiredExternal.IsExternalRun = true
+		archived := getMetadata(5, 1, 40)
+		repositoryStub := &workflowLogArchiveRepositoryStub{
+			metadata: []*repository.WorkflowLogMetadata{withinRetention, expiredWithArtifacts, expired, expiredExternal, archived},
+			archives: map[int]*repository.WorkflowLogArchive{5: {Id: 1, WorkflowId: 5, Status: bean.LogArchiveStatusArchived, LineIndex: &bean.LogArchiveIndex{}}},
+			policies: policies,
+		}
+		blobStoreStub := &workflowLogBlobStoreStub{objects: map[string][]byte{}}
+		// a batch of a single workflow pages through all the workflows
+		impl := getWorkflowLogArchiveService(t, &WorkflowLogArchiveConfig{RetentionCleanupBatchSize: 1}, repositoryStub, blobStoreStub)
+		impl.CleanupExpiredLogs()
+
+		assert.Equal(t, []string{
+			expiredWithArtifacts.LogKey, bean.GetArchiveKey(expiredWithArtifacts.LogKey), expiredWithArtifacts.ArtifactKey,
+			expired.LogKey, bean.GetArchiveKey(expired.LogKey),
+			archived.LogKey, bean.GetArchiveKey(archived.LogKey),
+		}, blobStoreStub.deletedKeys)
+		assert.NotContains(t, repositoryStub.archives, 1)
+		for _, workflowId := range []int{2, 3, 4, 5} {
+			archive := repositoryStub.archives[workflowId]
+			assert.Equal(t, bean.LogArchiveStatusExpired, archive.Status, "workflowId %d", workflowId)
+			assert.Nil(t, archive.LineIndex)
+			assert.False(t, archive.ExpiredOn.IsZero())
+		}
+		assert.Equal(t, 1, repositoryStub.archives[5].Id)
+	})
+
+	t.Run("logs are not marked expired if the delete fails", func(t *testing.T) {
+		repositoryStub := &workflowLogArchiveRepositoryStub{
+			metadata: []*repository.WorkflowLogMetadata{getMetadata(1, 1, 40)},
+			policies: policies,
+		}
+		blobStoreStub := &workflowLogBlobStoreStub{objects: map[string][]byte{}, deleteErr: errors.New("access denied")}
+		impl := getWorkflowLogArchiveService(t, &WorkflowLogArchiveConfig{RetentionCleanupBatchSize: 10}, repositoryStub, blobStoreStub)
+		impl.CleanupExpiredLogs()
+		assert.Empty(t, repositoryStub.archives)
+	})
+
+	t.Run("nothing is deleted without a policy", func(t *testing.T) {
+		repositoryStub := &workflowLogArchiveRepositoryStub{metadata: []*repository.WorkflowLogMetadata{getMetadata(1, 1, 400)}}
+		blobStoreStub := &workflowLogBlobStoreStub{objects: map[string][]byte{}}
+		impl := getWorkflowLogArchiveService(t, &WorkflowLogArchiveConfig{RetentionCleanupBatchSize: 10}, repositoryStub, blobStoreStub)
+		impl.CleanupExpiredLogs()
+		assert.Empty(t, blobStoreStub.deletedKeys)
+		assert.Empty(t, repositoryStub.archives)
+	})
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logArchive
+
+import (
+	"cloud.google.com/go/storage"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
+	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"go.uber.org/zap"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"net/url"
+)
+
+// WorkflowLogBlobStore reads and writes the workflow logs in the blob storage configured in the orchestrator.
+// Download and Upload go through the common-lib blob storage service, which has no delete support,
+// so the objects are deleted with the sdk clients built as common-lib builds them.
+type WorkflowLogBlobStore interface {
+	Download(logRequest types.BuildLogRequest, key string, destinationFile string) error
+	Upload(logRequest types.BuildLogRequest, sourceFile string, key string) error
+	// Delete removes the object of the key along with all its versions, an object not found is not an error
+	Delete(logRequest types.BuildLogRequest, key string) error
+}
+
+type WorkflowLogBlobStoreImpl struct {
+	logger             *zap.SugaredLogger
+	config             *WorkflowLogArchiveConfig
+	blobStorageService *blob_storage.BlobStorageServiceImpl
+}
+
+func NewWorkflowLogBlobStoreImpl(logger *zap.SugaredLogger, config *WorkflowLogArchiveConfig) *WorkflowLogBlobStoreImpl {
+	return &WorkflowLogBlobStoreImpl{
+		logger:             logger,
+		config:             config,
+		blobStorageService: blob_storage.NewBlobStorageServiceImpl(logger),
+	}
+}
+
+func (impl *WorkflowLogBlobStoreImpl) Download(logRequest types.BuildLogRequest, key string, destinationFile string) error {
+	request := impl.getBlobStorageRequest(logRequest, key, destinationFile)
+	_, _, err := impl.blobStorageService.Get(request)
+	if err != nil {
+		impl.logger.Errorw("error in downloading file from blob storage", "key", key, "err", err)
+	}
+	return err
+}
+
+func (impl *WorkflowLogBlobStoreImpl) Upload(logRequest types.BuildLogRequest, sourceFile string, key string) error {
+	request := impl.getBlobStorageRequest(logRequest, sourceFile, key)
+	err := impl.blobStorageService.PutWithCommand(request)
+	if err != nil {
+		impl.logger.Errorw("error in uploading file to blob storage", "key", key, "err", err)
+	}
+	return err
+}
+
+func (impl *WorkflowLogBlobStoreImpl) Delete(logRequest types.BuildLogRequest, key string) error {
+	var err error
+	switch logRequest.CloudProvider {
+	case blob_storage.BLOB_STORAGE_S3:
+		err = impl.deleteS3Object(logRequest.AwsS3BaseConfig, key)
+	case blob_storage.BLOB_STORAGE_AZURE:
+		err = impl.deleteAzureBlob(logRequest.AzureBlobConfig, key)
+	case blob_storage.BLOB_STORAGE_GCP:
+		err = impl.deleteGcpObject(logRequest.GcpBlobBaseConfig, key)
+	default:
+		return fmt.Errorf("blob-storage %s not supported", logRequest.CloudProvider)
+	}
+	if err != nil {
+		impl.logger.Errorw("error in deleting file from blob storage", "key", key, "err", err)
+	}
+	return err
+}
+
+func (impl *WorkflowLogBlobStoreImpl) getBlobStorageRequest(logRequest types.BuildLogRequest, sourceKey, destinationKey string) *blob_storage.BlobStorageRequest {
+	return &blob_storage.BlobStorageRequest{
+		StorageType:         logRequest.CloudProvider,
+		SourceKey:           sourceKey,
+		DestinationKey:      destinationKey,
+		AzureBlobBaseConfig: logRequest.AzureBlobConfig,
+		AwsS3BaseConfig:     logRequest.AwsS3BaseConfig,
+		GcpBlobBaseConfig:   logRequest.GcpBlobBaseConfig,
+	}
+}
+
+// deleteS3Object deletes every version and delete marker of the key, a delete without the version id
+// only adds a delete marker in a versioned bucket and the logs are retained
+func (impl *WorkflowLogBlobStoreImpl) deleteS3Object(config *blob_storage.AwsS3BaseConfig, key string) error {
+	ctx := context.Background()
+	s3BasicsClient, err := blob_storage.GetS3BucketBasicsClient(ctx, config.Region, config.AccessKey, config.Passkey, config.EndpointUrl)
+	if err != nil {
+		return err
+	}
+	listInput := &s3v2.ListObjectVersionsInput{
+		Bucket: &config.BucketName,
+		Prefix: &key,
+	}
+	for {
+		output, err := s3BasicsClient.S3Client.ListObjectVersions(ctx, listInput)
+		if err != nil {
+			return err
+		}
+		for _, versionId := range getS3ObjectVersionIds(output, key) {
+			// a non versioned bucket lists the object with the "null" version id, deleting a missing version is a success
+			_, err = s3BasicsClient.S3Client.DeleteObject(ctx, &s3v2.DeleteObjectInput{
+				Bucket:    &config.BucketName,
+				Key:       &key,
+				VersionId: &versionId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if output.IsTruncated == nil || !*output.IsTruncated {
+			return nil
+		}
+		listInput.KeyMarker = output.NextKeyMarker
+		listInput.VersionIdMarker = output.NextVersionIdMarker
+	}
+}
+
+// getS3ObjectVersionIds returns the ids of the versions and delete markers of the key,
+// the listing is by prefix and includes the other keys starting with the key (e.g. the archive of the raw logs)
+func getS3ObjectVersionIds(output *s3v2.ListObjectVersionsOutput, key string) []string {
+	versionIds := make([]string, 0, len(output.Versions)+len(output.DeleteMarkers))
+	for _, version := range output.Versions {
+		if isS3ObjectVersionOfKey(version.Key, version.VersionId, key) {
+			versionIds = append(versionIds, *version.VersionId)
+		}
+	}
+	for _, marker := range output.DeleteMarkers {
+		if isS3ObjectVersionOfKey(marker.Key, marker.VersionId, key) {
+			versionIds = append(versionIds, *marker.VersionId)
+		}
+	}
+	return versionIds
+}
+
+func isS3ObjectVersionOfKey(objectKey, versionId *string, key string) bool {
+	return objectKey != nil && *objectKey == key && versionId != nil
+}
+
+func (impl *WorkflowLogBlobStoreImpl) deleteAzureBlob(config *blob_storage.AzureBlobBaseConfig, key string) error {
+	azureEnvironment, err := azure.EnvironmentFromName(impl.config.AzureEnvironment)
+	if err != nil {
+		return err
+	}
+	var credential azblob.Credential
+	if len(config.AccountKey) > 0 {
+		credential, err = azblob.NewSharedKeyCredential(config.AccountName, config.AccountKey)
+	} else {
+		credential, err = getAzureTokenCredential(azureEnvironment)
+	}
+	if err != nil {
+		return err
+	}
+	containerUrl, err := url.Parse(getAzureContainerUrl(azureEnvironment, config))
+	if err != nil {
+		return err
+	}
+	blobUrl := azblob.NewContainerURL(*containerUrl, azblob.NewPipeline(credential, azblob.PipelineOptions{})).NewBlobURL(key)
+	_, err = blobUrl.Delete(context.Background(), azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{})
+	var storageErr azblob.StorageError
+	if errors.As(err, &storageErr) && storageErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+		return nil
+	}
+	return err
+}
+
+// getAzureContainerUrl uses the storage endpoint of the configured azure cloud, e.g. blob.core.windows.net for the public cloud
+func getAzureContainerUrl(azureEnvironment azure.Environment, config *blob_storage.AzureBlobBaseConfig) string {
+	return fmt.Sprintf("https://%s.blob.%s/%s", config.AccountName, azureEnvironment.StorageEndpointSuffix, config.BlobContainerName)
+}
+
+// getAzureTokenCredential uses the managed identity of the orchestrator when the account key is not configured
+func getAzureTokenCredential(azureEnvironment azure.Environment) (azblob.Credential, error) {
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	token, err := adal.NewServicePrincipalTokenFromMSI(msiEndpoint, azureEnvironment.ResourceIdentifiers.Storage)
+	if err != nil {
+		return nil, err
+	}
+	err = token.Refresh()
+	if err != nil {
+		return nil, err
+	}
+	return azblob.NewTokenCredential(token.Token().AccessToken, nil), nil
+}
+
+// deleteGcpObject deletes every generation of the key, the live generation is only made noncurrent in a versioned bucket
+func (impl *WorkflowLogBlobStoreImpl) deleteGcpObject(config *blob_storage.GcpBlobBaseConfig, key string) error {
+	ctx := context.Background()
+	storageClient, err := newGcpStorageClient(ctx, config)
+	if err != nil {
+		return err
+	}
+	defer storageClient.Close()
+	bucket := storageClient.Bucket(config.BucketName)
+	objects := bucket.Objects(ctx, &storage.Query{Prefix: key, Versions: true})
+	for {
+		objectAttrs, err := objects.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		// the listing is by prefix and includes the other keys starting with the key
+		if objectAttrs.Name != key {
+			continue
+		}
+		err = bucket.Object(key).Generation(objectAttrs.Generation).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return err
+		}
+	}
+}
+
+// newGcpStorageClient falls back to the application default credentials without the credential json, as common-lib does
+func newGcpStorageClient(ctx context.Context, config *blob_storage.GcpBlobBaseConfig) (*storage.Client, error) {
+	if len(config.CredentialFileJsonData) > 0 {
+		return storage.NewClient(ctx, option.WithCredentialsJSON([]byte(config.CredentialFileJsonData)))
+	}
+	return storage.NewClient(ctx)
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logArchive
+
+import (
+	"github.com/Azure/go-autorest/autorest/azure"
+	s3v2 "github.com/aws/aws-sdk-go-v2/service/s3"
+	s3Types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	blob_storage "github.com/devtron-labs/common-lib/blob-storage"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetS3ObjectVersionIds(t *testing.T) {
+	key := "ci-logs/build-1/main.log"
+	archiveKey := key + ".archive.gz"
+	versionId := func(id string) *string { return &id }
+	output := &s3v2.ListObjectVersionsOutput{
+		Versions: []s3Types.ObjectVersion{
+			{Key: &key, VersionId: versionId("v2")},
+			{Key: &key, VersionId: versionId("v1")},
+			// the archive is listed by the prefix of the raw logs key
+			{Key: &archiveKey, VersionId: versionId("v3")},
+		},
+		DeleteMarkers: []s3Types.DeleteMarkerEntry{
+			{Key: &key, VersionId: versionId("v4")},
+		},
+	}
+	assert.Equal(t, []string{"v2", "v1", "v4"}, getS3ObjectVersionIds(output, key))
+	assert.Equal(t, []string{"v3"}, getS3ObjectVersionIds(output, archiveKey))
+}
+
+func TestGetAzureContainerUrl(t *testing.T) {
+	config := &blob_storage.AzureBlobBaseConfig{AccountName: "devtron", BlobContainerName: "ci-logs"}
+	for environmentName, expectedUrl := range map[string]string{
+		"AzurePublicCloud": "https://devtron.blob.core.windows.net/ci-logs",
+		"AzureChinaCloud":  "https://devtron.blob.core.chinacloudapi.cn/ci-logs",
+	} {
+		azureEnvironment, err := azure.EnvironmentFromName(environmentName)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedUrl, getAzureContainerUrl(azureEnvironment, config))
+	}
+}
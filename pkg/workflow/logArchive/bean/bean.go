@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"time"
+)
+
+type WorkflowLogType string
+
+const (
+	CiWorkflowLog     WorkflowLogType = "CI"
+	PreCdWorkflowLog  WorkflowLogType = cdWorkflow.WorkflowTypePre
+	PostCdWorkflowLog WorkflowLogType = cdWorkflow.WorkflowTypePost
+)
+
+func (t WorkflowLogType) IsValid() bool {
+	return t == CiWorkflowLog || t == PreCdWorkflowLog || t == PostCdWorkflowLog
+}
+
+func (t WorkflowLogType) IsCdStage() bool {
+	return t == PreCdWorkflowLog || t == PostCdWorkflowLog
+}
+
+type LogArchiveStatus string
+
+const (
+	// LogArchiveStatusArchived the logs are stored compressed with the line index
+	LogArchiveStatusArchived LogArchiveStatus = "ARCHIVED"
+	// LogArchiveStatusExpired the logs (and artifacts) are deleted as per the retention policy
+	LogArchiveStatusExpired LogArchiveStatus = "EXPIRED"
+)
+
+const (
+	// LogArchiveFileSuffix is appended to the key of the raw log file for the key of the archive
+	LogArchiveFileSuffix = ".archive.gz"
+	// MinLinesPerBlock bounds the index size for huge logs
+	MinLinesPerBlock = 100
+)
+
+// TerminalWorkflowStatuses are the statuses after which the logs of the workflow are not updated
+var TerminalWorkflowStatuses = []string{
+	cdWorkflow.WorkflowSucceeded,
+	cdWorkflow.WorkflowFailed,
+	string(v1alpha1.NodeError),
+	cdWorkflow.WorkflowAborted,
+	cdWorkflow.WorkflowTimedOut,
+	cdWorkflow.WorkflowCancel,
+}
+
+func GetArchiveKey(logKey string) string {
+	return logKey + LogArchiveFileSuffix
+}
+
+// LogArchiveIndex locates the lines in the archive, which is a concatenation of independent gzip members (blocks)
+type LogArchiveIndex struct {
+	LinesPerBlock  int               `json:"linesPerBlock"`
+	TotalLines     int               `json:"totalLines"`
+	RawSize        int64             `json:"rawSize"`
+	CompressedSize int64             `json:"compressedSize"`
+	Blocks         []LogArchiveBlock `json:"blocks"`
+}
+
+type LogArchiveBlock struct {
+	Offset    int64 `json:"offset"`
+	Length    int64 `json:"length"`
+	FirstLine int   `json:"firstLine"`
+	LineCount int   `json:"lineCount"`
+}
+
+type LogLine struct {
+	// Number is the 1 based line number in the log file
+	Number  int    `json:"number"`
+	Content string `json:"content"`
+}
+
+type LogLinesResponse struct {
+	WorkflowId int        `json:"workflowId"`
+	TotalLines int        `json:"totalLines"`
+	Offset     int        `json:"offset"`
+	Lines      []*LogLine `json:"lines"`
+}
+
+type LogSearchQuery struct {
+	Pattern    string `json:"pattern" validate:"required"`
+	IsRegex    bool   `json:"isRegex"`
+	IgnoreCase bool   `json:"ignoreCase"`
+	MaxMatches int    `json:"maxMatches"`
+	// LastBuilds is the number of latest workflows of the pipeline searched, used in the pipeline level search
+	LastBuilds int `json:"lastBuilds"`
+}
+
+type LogSearchResponse struct {
+	WorkflowId int        `json:"workflowId"`
+	Status     string     `json:"status,omitempty"`
+	FinishedOn time.Time  `json:"finishedOn,omitempty"`
+	TotalLines int        `json:"totalLines"`
+	Matches    []*LogLine `json:"matches"`
+	// Truncated is set when the matches are more than the max matches
+	Truncated bool `json:"truncated"`
+	// Error is set in the pipeline level search when the logs of a workflow could not be searched
+	Error string `json:"error,omitempty"`
+}
+
+type LogRetentionPolicyDto struct {
+	Id int `json:"id"`
+	// TeamId 0 applies the policy to all the projects
+	TeamId int `json:"teamId"`
+	// EnvironmentId 0 applies the policy to all the environments, ci builds are matched only by such policies
+	EnvironmentId   int   `json:"environmentId"`
+	RetentionDays   int   `json:"retentionDays" validate:"min=1"`
+	DeleteArtifacts bool  `json:"deleteArtifacts"`
+	UserId          int32 `json:"-"`
+}
+
+// specificity of the policy, the most specific policy applicable to a workflow is used
+func (policy *LogRetentionPolicyDto) specificity() int {
+	specificity := 0
+	if policy.EnvironmentId != 0 {
+		specificity += 2
+	}
+	if policy.TeamId != 0 {
+		specificity += 1
+	}
+	return specificity
+}
+
+func (policy *LogRetentionPolicyDto) isApplicable(teamId, environmentId int) bool {
+	return (policy.TeamId == 0 || policy.TeamId == teamId) &&
+		(policy.EnvironmentId == 0 || policy.EnvironmentId == environmentId)
+}
+
+// GetApplicableRetentionPolicy returns the most specific policy for the project and environment of the workflow,
+// an environment level policy takes precedence over a project level policy. Returns nil if no policy applies.
+func GetApplicableRetentionPolicy(policies []*LogRetentionPolicyDto, teamId, environmentId int) *LogRetentionPolicyDto {
+	var applicablePolicy *LogRetentionPolicyDto
+	for _, policy := range policies {
+		if !policy.isApplicable(teamId, environmentId) {
+			continue
+		}
+		if applicablePolicy == nil || policy.specificity() > applicablePolicy.specificity() {
+			applicablePolicy = policy
+		}
+	}
+	return applicablePolicy
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bean
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetApplicableRetentionPolicy(t *testing.T) {
+	global := &LogRetentionPolicyDto{Id: 1, RetentionDays: 90}
+	team := &LogRetentionPolicyDto{Id: 2, TeamId: 5, RetentionDays: 30}
+	env := &LogRetentionPolicyDto{Id: 3, EnvironmentId: 7, RetentionDays: 14}
+	teamEnv := &LogRetentionPolicyDto{Id: 4, TeamId: 5, EnvironmentId: 7, RetentionDays: 7}
+	policies := []*LogRetentionPolicyDto{global, team, env, teamEnv}
+	assert.Equal(t, teamEnv, GetApplicableRetentionPolicy(policies, 5, 7))
+	assert.Equal(t, env, GetApplicableRetentionPolicy(policies, 6, 7))
+	assert.Equal(t, team, GetApplicableRetentionPolicy(policies, 5, 0))
+	assert.Equal(t, global, GetApplicableRetentionPolicy(policies, 6, 8))
+	assert.Nil(t, GetApplicableRetentionPolicy([]*LogRetentionPolicyDto{team}, 6, 0))
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// WriteLogArchive compresses the log in independent gzip members of linesPerBlock lines each.
+// The concatenated members are a valid gzip stream, so the archive can be decompressed as a whole,
+// while a range of lines is read by decompressing only the blocks containing it.
+func WriteLogArchive(src io.Reader, dst io.Writer, linesPerBlock int) (*bean.LogArchiveIndex, error) {
+	if linesPerBlock < bean.MinLinesPerBlock {
+		linesPerBlock = bean.MinLinesPerBlock
+	}
+	index := &bean.LogArchiveIndex{LinesPerBlock: linesPerBlock, Blocks: make([]bean.LogArchiveBlock, 0)}
+	archiveWriter := &countingWriter{writer: dst}
+	reader := bufio.NewReader(src)
+	var blockWriter *gzip.Writer
+	var block bean.LogArchiveBlock
+	closeBlock := func() error {
+		if blockWriter == nil {
+			return nil
+		}
+		if err := blockWriter.Close(); err != nil {
+			return err
+		}
+		block.Length = archiveWriter.written - block.Offset
+		index.Blocks = append(index.Blocks, block)
+		blockWriter = nil
+		return nil
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if blockWriter == nil {
+				block = bean.LogArchiveBlock{Offset: archiveWriter.written, FirstLine: index.TotalLines + 1}
+				blockWriter = gzip.NewWriter(archiveWriter)
+			}
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			if _, wErr := blockWriter.Write([]byte(line)); wErr != nil {
+				return nil, wErr
+			}
+			index.TotalLines++
+			index.RawSize += int64(len(line))
+			block.LineCount++
+			if block.LineCount == linesPerBlock {
+				if cErr := closeBlock(); cErr != nil {
+					return nil, cErr
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if err := closeBlock(); err != nil {
+		return nil, err
+	}
+	index.CompressedSize = archiveWriter.written
+	return index, nil
+}
+
+// ExtractLogArchive decompresses the whole archive into the raw log
+func ExtractLogArchive(archive io.Reader, dst io.Writer) error {
+	reader, err := gzip.NewReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	_, err = io.Copy(dst, reader)
+	return err
+}
+
+func readBlockLines(archive io.ReaderAt, block bean.LogArchiveBlock, process func(line *bean.LogLine) bool) error {
+	reader, err := gzip.NewReader(io.NewSectionReader(archive, block.Offset, block.Length))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	// a block is a single gzip member
+	reader.Multistream(false)
+	bufReader := bufio.NewReader(reader)
+	lineNumber := block.FirstLine
+	for {
+		line, err := bufReader.ReadString('\n')
+		if len(line) > 0 {
+			if !process(&bean.LogLine{Number: lineNumber, Content: strings.TrimSuffix(line, "\n")}) {
+				return nil
+			}
+			lineNumber++
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// ReadLogLines returns the lines [offset, offset+limit) of the archived log, offset being 0 based
+func ReadLogLines(archive io.ReaderAt, index *bean.LogArchiveIndex, offset, limit int) ([]*bean.LogLine, error) {
+	lines := make([]*bean.LogLine, 0)
+	if offset < 0 || limit <= 0 {
+		return lines, fmt.Errorf("invalid line range, offset %d limit %d", offset, limit)
+	}
+	firstLine, lastLine := offset+1, offset+limit
+	for _, block := range index.Blocks {
+		blockLastLine := block.FirstLine + block.LineCount - 1
+		if blockLastLine < firstLine {
+			continue
+		} else if block.FirstLine > lastLine {
+			break
+		}
+		err := readBlockLines(archive, block, func(line *bean.LogLine) bool {
+			if line.Number >= firstLine {
+				lines = append(lines, line)
+			}
+			return line.Number < lastLine
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// SearchLogArchive returns the lines matching the query, the search stops after max matches
+func SearchLogArchive(archive io.ReaderAt, index *bean.LogArchiveIndex, query *bean.LogSearchQuery) ([]*bean.LogLine, bool, error) {
+	matcher, err := GetLogLineMatcher(query)
+	if err != nil {
+		return nil, false, err
+	}
+	matches := make([]*bean.LogLine, 0)
+	truncated := false
+	for _, block := range index.Blocks {
+		err = readBlockLines(archive, block, func(line *bean.LogLine) bool {
+			if !matcher(line.Content) {
+				return true
+			}
+			if query.MaxMatches > 0 && len(matches) == query.MaxMatches {
+				truncated = true
+				return false
+			}
+			matches = append(matches, line)
+			return true
+		})
+		if err != nil {
+			return nil, false, err
+		} else if truncated {
+			break
+		}
+	}
+	return matches, truncated, nil
+}
+
+func GetLogLineMatcher(query *bean.LogSearchQuery) (func(line string) bool, error) {
+	if len(query.Pattern) == 0 {
+		return nil, errors.New("search pattern is required")
+	}
+	if query.IsRegex {
+		pattern := query.Pattern
+		if query.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search pattern: %w", err)
+		}
+		return regex.MatchString, nil
+	}
+	if query.IgnoreCase {
+		pattern := strings.ToLower(query.Pattern)
+		return func(line string) bool {
+			return strings.Contains(strings.ToLower(line), pattern)
+		}, nil
+	}
+	return func(line string) bool {
+		return strings.Contains(line, query.Pattern)
+	}, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func getTestLog(lines int) string {
+	builder := strings.Builder{}
+	for i := 1; i <= lines; i++ {
+		builder.WriteString(fmt.Sprintf("step %d: building layer %d\n", i, i%7))
+	}
+	return builder.String()
+}
+
+func TestLogArchive(t *testing.T) {
+	rawLog := getTestLog(450)
+	archive := &bytes.Buffer{}
+	index, err := WriteLogArchive(strings.NewReader(rawLog), archive, bean.MinLinesPerBlock)
+	assert.Nil(t, err)
+	assert.Equal(t, 450, index.TotalLines)
+	assert.Equal(t, int64(len(rawLog)), index.RawSize)
+	assert.Len(t, index.Blocks, 5)
+	assert.Equal(t, 401, index.Blocks[4].FirstLine)
+	assert.Equal(t, 50, index.Blocks[4].LineCount)
+	archiveReader := bytes.NewReader(archive.Bytes())
+
+	t.Run("extract whole archive", func(t *testing.T) {
+		extracted := &bytes.Buffer{}
+		assert.Nil(t, ExtractLogArchive(bytes.NewReader(archive.Bytes()), extracted))
+		assert.Equal(t, rawLog, extracted.String())
+	})
+
+	t.Run("read lines across blocks", func(t *testing.T) {
+		lines, err := ReadLogLines(archiveReader, index, 195, 10)
+		assert.Nil(t, err)
+		assert.Len(t, lines, 10)
+		assert.Equal(t, 196, lines[0].Number)
+		assert.Equal(t, "step 196: building layer 0", lines[0].Content)
+		assert.Equal(t, 205, lines[9].Number)
+	})
+
+	t.Run("read lines beyond the log", func(t *testing.T) {
+		lines, err := ReadLogLines(archiveReader, index, 440, 100)
+		assert.Nil(t, err)
+		assert.Len(t, lines, 10)
+		lines, err = ReadLogLines(archiveReader, index, 500, 100)
+		assert.Nil(t, err)
+		assert.Len(t, lines, 0)
+	})
+
+	t.Run("search with max matches", func(t *testing.T) {
+		matches, truncated, err := SearchLogArchive(archiveReader, index, &bean.LogSearchQuery{Pattern: "LAYER 3", IgnoreCase: true, MaxMatches: 100})
+		assert.Nil(t, err)
+		assert.False(t, truncated)
+		assert.Len(t, matches, 64)
+		assert.Equal(t, 3, matches[0].Number)
+		matches, truncated, err = SearchLogArchive(archiveReader, index, &bean.LogSearchQuery{Pattern: `^step 4\d\d:`, IsRegex: true, MaxMatches: 20})
+		assert.Nil(t, err)
+		assert.True(t, truncated)
+		assert.Len(t, matches, 20)
+		assert.Equal(t, 400, matches[0].Number)
+	})
+
+	t.Run("invalid search pattern", func(t *testing.T) {
+		_, _, err := SearchLogArchive(archiveReader, index, &bean.LogSearchQuery{Pattern: "layer (", IsRegex: true})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package repository
+
+import (
+	"github.com/devtron-labs/devtron/pkg/sql"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive/bean"
+	"github.com/go-pg/pg"
+	"go.uber.org/zap"
+	"time"
+)
+
+// WorkflowLogArchive tracks the archived logs of a ci workflow or a pre/post cd workflow runner
+type WorkflowLogArchive struct {
+	tableName    struct{}              `sql:"workflow_log_archive" pg:",discard_unknown_columns"`
+	Id           int                   `sql:"id,pk"`
+	WorkflowId   int                   `sql:"workflow_id,notnull"`
+	WorkflowType bean.WorkflowLogType  `sql:"workflow_type,notnull"`
+	PipelineId   int                   `sql:"pipeline_id,notnull"`
+	LogKey       string                `sql:"log_key,notnull"`
+	ArchiveKey   string                `sql:"archive_key"`
+	LineIndex    *bean.LogArchiveIndex `sql:"line_index"`
+	Status       bean.LogArchiveStatus `sql:"status,notnull"`
+	ExpiredOn    time.Time             `sql:"expired_on,type:timestamptz"`
+	sql.AuditLog
+}
+
+type LogRetentionPolicy struct {
+	tableName       struct{} `sql:"log_retention_policy" pg:",discard_unknown_columns"`
+	Id              int      `sql:"id,pk"`
+	TeamId          int      `sql:"team_id,notnull"`
+	EnvironmentId   int      `sql:"environment_id,notnull"`
+	RetentionDays   int      `sql:"retention_days,notnull"`
+	DeleteArtifacts bool     `sql:"delete_artifacts,notnull"`
+	Active          bool     `sql:"active,notnull"`
+	sql.AuditLog
+}
+
+// WorkflowLogMetadata is the log location and the scope of a workflow, read from the ci_workflow/cd_workflow_runner
+type WorkflowLogMetadata struct {
+	WorkflowId         int       `sql:"workflow_id"`
+	PipelineId         int       `sql:"pipeline_id"`
+	AppId              int       `sql:"app_id"`
+	TeamId             int       `sql:"team_id"`
+	EnvironmentId      int       `sql:"environment_id"`
+	Namespace          string    `sql:"namespace"`
+	PodName            string    `sql:"pod_name"`
+	Status             string    `sql:"status"`
+	FinishedOn         time.Time `sql:"finished_on"`
+	LogKey             string    `sql:"log_key"`
+	ArtifactKey        string    `sql:"artifact_key"`
+	BlobStorageEnabled bool      `sql:"blob_storage_enabled"`
+	IsExternalRun      bool      `sql:"is_external_run"`
+}
+
+type WorkflowLogArchiveRepository interface {
+	Save(model *WorkflowLogArchive) error
+	Update(model *WorkflowLogArchive) error
+	FindByWorkflow(workflowType bean.WorkflowLogType, workflowId int) (*WorkflowLogArchive, error)
+
+	GetWorkflowLogMetadata(workflowType bean.WorkflowLogType, workflowId int) (*WorkflowLogMetadata, error)
+	// GetLatestWorkflowLogMetadata returns the latest completed workflows of the pipeline, latest first
+	GetLatestWorkflowLogMetadata(workflowType bean.WorkflowLogType, pipelineId int, limit int) ([]*WorkflowLogMetadata, error)
+	// GetUnexpiredWorkflowLogMetadata returns the completed workflows finished before the given time, whose logs are not expired yet,
+	// in the order of the workflow id starting after the given id
+	GetUnexpiredWorkflowLogMetadata(workflowType bean.WorkflowLogType, finishedBefore time.Time, afterWorkflowId int, limit int) ([]*WorkflowLogMetadata, error)
+	GetPipelineAppId(workflowType bean.WorkflowLogType, pipelineId int) (int, error)
+
+	FindAllActivePolicies() ([]*LogRetentionPolicy, error)
+	FindActivePolicyById(id int) (*LogRetentionPolicy, error)
+	FindActivePolicyByScope(teamId, environmentId int) (*LogRetentionPolicy, error)
+	SavePolicy(model *LogRetentionPolicy) error
+	UpdatePolicy(model *LogRetentionPolicy) error
+}
+
+type WorkflowLogArchiveRepositoryImpl struct {
+	dbConnection *pg.DB
+	logger       *zap.SugaredLogger
+}
+
+func NewWorkflowLogArchiveRepositoryImpl(dbConnection *pg.DB, logger *zap.SugaredLogger) *WorkflowLogArchiveRepositoryImpl {
+	return &WorkflowLogArchiveRepositoryImpl{
+		dbConnection: dbConnection,
+		logger:       logger,
+	}
+}
+
+const (
+	ciWorkflowLogMetadataQuery = `SELECT cw.id AS workflow_id, cw.ci_pipeline_id AS pipeline_id, cp.app_id, a.team_id,
+       COALESCE(cw.environment_id, 0) AS environment_id, cw.namespace, cw.pod_name, cw.status, cw.finished_on,
+       cw.log_file_path AS log_key, cw.ci_artifact_location AS artifact_key, cw.blob_storage_enabled,
+       COALESCE(cw.environment_id, 0) != 0 AS is_external_run
+FROM ci_workflow cw
+    INNER JOIN ci_pipeline cp ON cp.id = cw.ci_pipeline_id
+    INNER JOIN app a ON a.id = cp.app_id
+    LEFT JOIN workflow_log_archive wla ON wla.workflow_id = cw.id AND wla.workflow_type = ?0 `
+
+	cdWorkflowLogMetadataQuery = `SELECT cwr.id AS workflow_id, cw.pipeline_id, p.app_id, a.team_id,
+       p.environment_id, cwr.namespace, cwr.pod_name, cwr.status, cwr.finished_on,
+       cwr.log_file_path AS log_key, cwr.cd_artifact_location AS artifact_key, cwr.blob_storage_enabled,
+       CASE WHEN cwr.workflow_type = 'PRE' THEN p.run_pre_stage_in_env ELSE p.run_post_stage_in_env END AS is_external_run
+FROM cd_workflow_runner cwr
+    INNER JOIN cd_workflow cw ON cw.id = cwr.cd_workflow_id
+    INNER JOIN pipeline p ON p.id = cw.pipeline_id
+    INNER JOIN app a ON a.id = p.app_id
+    LEFT JOIN workflow_log_archive wla ON wla.workflow_id = cwr.id AND wla.workflow_type = ?0 `
+)
+
+// getWorkflowLogMetadataQuery the workflow type is the first query param
+func getWorkflowLogMetadataQuery(workflowType bean.WorkflowLogType) (query string, workflowAlias string, pipelineIdColumn string) {
+	if workflowType.IsCdStage() {
+		return cdWorkflowLogMetadataQuery + "WHERE cwr.workflow_type = ?0 ", "cwr", "cw.pipeline_id"
+	}
+	return ciWorkflowLogMetadataQuery + "WHERE true ", "cw", "cw.ci_pipeline_id"
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) Save(model *WorkflowLogArchive) error {
+	return impl.dbConnection.Insert(model)
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) Update(model *WorkflowLogArchive) error {
+	return impl.dbConnection.Update(model)
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) FindByWorkflow(workflowType bean.WorkflowLogType, workflowId int) (*WorkflowLogArchive, error) {
+	model := &WorkflowLogArchive{}
+	err := impl.dbConnection.Model(model).
+		Where("workflow_type = ?", workflowType).
+		Where("workflow_id = ?", workflowId).
+		Select()
+	return model, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) GetWorkflowLogMetadata(workflowType bean.WorkflowLogType, workflowId int) (*WorkflowLogMetadata, error) {
+	query, workflowAlias, _ := getWorkflowLogMetadataQuery(workflowType)
+	query += "AND " + workflowAlias + ".id = ?1;"
+	metadata := &WorkflowLogMetadata{}
+	_, err := impl.dbConnection.QueryOne(metadata, query, workflowType, workflowId)
+	return metadata, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) GetLatestWorkflowLogMetadata(workflowType bean.WorkflowLogType, pipelineId int, limit int) ([]*WorkflowLogMetadata, error) {
+	query, workflowAlias, pipelineIdColumn := getWorkflowLogMetadataQuery(workflowType)
+	query += "AND " + pipelineIdColumn + " = ?1 AND " + workflowAlias + ".status IN (?2) ORDER BY " + workflowAlias + ".id DESC LIMIT ?3;"
+	var metadata []*WorkflowLogMetadata
+	_, err := impl.dbConnection.Query(&metadata, query, workflowType, pipelineId, pg.In(bean.TerminalWorkflowStatuses), limit)
+	return metadata, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) GetUnexpiredWorkflowLogMetadata(workflowType bean.WorkflowLogType, finishedBefore time.Time, afterWorkflowId int, limit int) ([]*WorkflowLogMetadata, error) {
+	query, workflowAlias, _ := getWorkflowLogMetadataQuery(workflowType)
+	query += "AND " + workflowAlias + ".id > ?1 AND " + workflowAlias + ".status IN (?2) AND " + workflowAlias + ".finished_on < ?3 " +
+		"AND (wla.id IS NULL OR wla.status != ?4) ORDER BY " + workflowAlias + ".id LIMIT ?5;"
+	var metadata []*WorkflowLogMetadata
+	_, err := impl.dbConnection.Query(&metadata, query, workflowType, afterWorkflowId, pg.In(bean.TerminalWorkflowStatuses), finishedBefore, bean.LogArchiveStatusExpired, limit)
+	return metadata, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) GetPipelineAppId(workflowType bean.WorkflowLogType, pipelineId int) (int, error) {
+	query := "SELECT app_id FROM ci_pipeline WHERE id = ? AND deleted = false;"
+	if workflowType.IsCdStage() {
+		query = "SELECT app_id FROM pipeline WHERE id = ? AND deleted = false;"
+	}
+	var appId int
+	_, err := impl.dbConnection.QueryOne(pg.Scan(&appId), query, pipelineId)
+	return appId, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) FindAllActivePolicies() ([]*LogRetentionPolicy, error) {
+	var models []*LogRetentionPolicy
+	err := impl.dbConnection.Model(&models).
+		Where("active = ?", true).
+		Order("id").
+		Select()
+	return models, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) FindActivePolicyById(id int) (*LogRetentionPolicy, error) {
+	model := &LogRetentionPolicy{}
+	err := impl.dbConnection.Model(model).
+		Where("id = ?", id).
+		Where("active = ?", true).
+		Select()
+	return model, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) FindActivePolicyByScope(teamId, environmentId int) (*LogRetentionPolicy, error) {
+	model := &LogRetentionPolicy{}
+	err := impl.dbConnection.Model(model).
+		Where("team_id = ?", teamId).
+		Where("environment_id = ?", environmentId).
+		Where("active = ?", true).
+		Select()
+	return model, err
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) SavePolicy(model *LogRetentionPolicy) error {
+	return impl.dbConnection.Insert(model)
+}
+
+func (impl *WorkflowLogArchiveRepositoryImpl) UpdatePolicy(model *LogRetentionPolicy) error {
+	return impl.dbConnection.Update(model)
+}
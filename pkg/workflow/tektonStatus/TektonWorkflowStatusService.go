@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tektonStatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	clusterBean "github.com/devtron-labs/devtron/pkg/cluster/bean"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
+	k8sPkg "github.com/devtron-labs/devtron/pkg/k8s"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/executors"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"go.uber.org/zap"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// TektonWorkflowStatusService publishes the status of the workflows executed by tekton on the workflow status update topics.
+// The status of argo workflows and system executor jobs is published by kubewatch, which does not watch the tekton pipeline runs.
+type TektonWorkflowStatusService interface {
+	SyncActiveWorkflowsStatus()
+}
+
+type TektonWorkflowStatusServiceImpl struct {
+	logger                 *zap.SugaredLogger
+	ciCdConfig             *types.CiCdConfig
+	ciWorkflowRepository   pipelineConfig.CiWorkflowRepository
+	cdWorkflowRepository   pipelineConfig.CdWorkflowRepository
+	envRepository          repository.EnvironmentRepository
+	k8sCommonService       k8sPkg.K8sCommonService
+	tektonWorkflowExecutor executors.TektonWorkflowExecutor
+	// publish is overridden in tests to capture the published status
+	publish func(topic string, msg string) error
+}
+
+func NewTektonWorkflowStatusServiceImpl(logger *zap.SugaredLogger, ciCdConfig *types.CiCdConfig,
+	ciWorkflowRepository pipelineConfig.CiWorkflowRepository, cdWorkflowRepository pipelineConfig.CdWorkflowRepository,
+	envRepository repository.EnvironmentRepository, k8sCommonService k8sPkg.K8sCommonService,
+	tektonWorkflowExecutor executors.TektonWorkflowExecutor, pubSubClient *pubsub.PubSubClientServiceImpl) *TektonWorkflowStatusServiceImpl {
+	return &TektonWorkflowStatusServiceImpl{
+		logger:                 logger,
+		ciCdConfig:             ciCdConfig,
+		ciWorkflowRepository:   ciWorkflowRepository,
+		cdWorkflowRepository:   cdWorkflowRepository,
+		envRepository:          envRepository,
+		k8sCommonService:       k8sCommonService,
+		tektonWorkflowExecutor: tektonWorkflowExecutor,
+		publish:                pubSubClient.Publish,
+	}
+}
+
+// activeWorkflowStatuses are the statuses of the workflows which are yet to complete
+var activeWorkflowStatuses = []string{cdWorkflow.WorkflowStarting, string(v1alpha1.NodePending), string(v1alpha1.NodeRunning)}
+
+func (impl *TektonWorkflowStatusServiceImpl) SyncActiveWorkflowsStatus() {
+	ciWorkflows, err := impl.ciWorkflowRepository.FindByExecutorTypeAndStatusesIn(cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON, activeWorkflowStatuses)
+	if err != nil {
+		impl.logger.Errorw("error in fetching active tekton ci workflows", "err", err)
+	}
+	for _, ciWorkflow := range ciWorkflows {
+		impl.syncCiWorkflowStatus(ciWorkflow)
+	}
+	cdWorkflowRunners, err := impl.cdWorkflowRepository.FindPreOrPostCdWorkflowRunnersByExecutorTypeAndStatusesIn(cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON, activeWorkflowStatuses)
+	if err != nil {
+		impl.logger.Errorw("error in fetching active tekton cd workflow runners", "err", err)
+	}
+	for _, cdWorkflowRunner := range cdWorkflowRunners {
+		impl.syncCdWorkflowRunnerStatus(cdWorkflowRunner)
+	}
+}
+
+func (impl *TektonWorkflowStatusServiceImpl) syncCiWorkflowStatus(ciWorkflow *pipelineConfig.CiWorkflow) {
+	clusterId := clusterBean.DefaultClusterId
+	if ciWorkflow.Namespace != impl.ciCdConfig.CiDefaultNamespace {
+		env, err := impl.envRepository.FindById(ciWorkflow.EnvironmentId)
+		if err != nil {
+			impl.logger.Errorw("error in fetching environment of ci workflow", "workflowId", ciWorkflow.Id, "envId", ciWorkflow.EnvironmentId, "err", err)
+			return
+		}
+		clusterId = env.ClusterId
+	}
+	wfStatus, err := impl.getWorkflowStatus(ciWorkflow.Id, ciWorkflow.Name, ciWorkflow.Status, ciWorkflow.Namespace, clusterId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching status of tekton ci workflow", "workflowId", ciWorkflow.Id, "err", err)
+		return
+	}
+	ciCdStatus := getCiCdStatus(wfStatus, pipelineBean.CI_WORKFLOW_NAME)
+	if !isWorkflowStatusChanged(ciCdStatus, ciWorkflow.Status, ciWorkflow.PodStatus, ciWorkflow.Message) {
+		return
+	}
+	impl.publishWorkflowStatus(pubsub.WORKFLOW_STATUS_UPDATE_TOPIC, ciCdStatus)
+}
+
+func (impl *TektonWorkflowStatusServiceImpl) syncCdWorkflowRunnerStatus(runner *pipelineConfig.CdWorkflowRunner) {
+	clusterId := clusterBean.DefaultClusterId
+	if runner.Namespace != impl.ciCdConfig.CdDefaultNamespace && runner.CdWorkflow != nil && runner.CdWorkflow.Pipeline != nil {
+		clusterId = runner.CdWorkflow.Pipeline.Environment.ClusterId
+	}
+	wfStatus, err := impl.getWorkflowStatus(runner.Id, runner.Name, runner.Status, runner.Namespace, clusterId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching status of tekton cd workflow runner", "wfrId", runner.Id, "err", err)
+		return
+	}
+	ciCdStatus := getCiCdStatus(wfStatus, pipelineBean.CD_WORKFLOW_NAME)
+	if !isWorkflowStatusChanged(ciCdStatus, runner.Status, runner.PodStatus, runner.Message) {
+		return
+	}
+	impl.publishWorkflowStatus(pubsub.CD_WORKFLOW_STATUS_UPDATE, ciCdStatus)
+}
+
+// getWorkflowStatus fetches the status of the pipeline run of the workflow.
+// The generated name of the pipeline run is saved with the first status update, till then the workflow is in Starting status
+// and the pipeline run is looked up by the workflow name prefix set on the pipeline run at trigger.
+func (impl *TektonWorkflowStatusServiceImpl) getWorkflowStatus(workflowId int, workflowName, status, namespace string, clusterId int) (*types.WorkflowStatus, error) {
+	restConfig, err := impl.getRestConfig(clusterId)
+	if err != nil {
+		return nil, err
+	}
+	if status == cdWorkflow.WorkflowStarting {
+		workflowNamePrefix := fmt.Sprintf("%d-%s", workflowId, workflowName)
+		return impl.tektonWorkflowExecutor.GetWorkflowStatusByNamePrefix(workflowNamePrefix, namespace, restConfig)
+	}
+	return impl.tektonWorkflowExecutor.GetWorkflowStatus(workflowName, namespace, restConfig)
+}
+
+func (impl *TektonWorkflowStatusServiceImpl) getRestConfig(clusterId int) (*rest.Config, error) {
+	restConfig, err, _ := impl.k8sCommonService.GetRestConfigByClusterId(context.Background(), clusterId)
+	if err != nil {
+		impl.logger.Errorw("error in fetching rest config of cluster", "clusterId", clusterId, "err", err)
+		return nil, err
+	}
+	return restConfig, nil
+}
+
+func (impl *TektonWorkflowStatusServiceImpl) publishWorkflowStatus(topic string, ciCdStatus eventProcessorBean.CiCdStatus) {
+	payload, err := json.Marshal(ciCdStatus)
+	if err != nil {
+		impl.logger.Errorw("error in marshalling tekton workflow status", "topic", topic, "err", err)
+		return
+	}
+	err = impl.publish(topic, string(payload))
+	if err != nil {
+		impl.logger.Errorw("error in publishing tekton workflow status", "topic", topic, "payload", string(payload), "err", err)
+	}
+}
+
+// getCiCdStatus maps the tekton workflow status to the argo workflow status published by kubewatch,
+// with the task run pod as the node of the workflow template
+func getCiCdStatus(wfStatus *types.WorkflowStatus, templateName string) eventProcessorBean.CiCdStatus {
+	ciCdStatus := eventProcessorBean.NewCiCdStatus()
+	ciCdStatus.Phase = v1alpha1.WorkflowPhase(wfStatus.Status)
+	ciCdStatus.Message = wfStatus.Message
+	if ciCdStatus.Phase.Completed() {
+		// the completion time is not tracked in the workflow status, the workflow is no more synced once completed
+		ciCdStatus.FinishedAt = v1.Now()
+	}
+	node := v1alpha1.NodeStatus{
+		Name:         wfStatus.WorkflowName,
+		TemplateName: templateName,
+		Phase:        v1alpha1.NodePhase(wfStatus.Status),
+		Message:      getNodeMessage(wfStatus),
+	}
+	nodeId := wfStatus.WorkflowName
+	if len(wfStatus.PodName) > 0 {
+		nodeId = wfStatus.PodName
+		node.BoundaryID = wfStatus.WorkflowName
+		node.Phase = v1alpha1.NodePhase(wfStatus.PodStatus)
+	}
+	node.ID = nodeId
+	ciCdStatus.Nodes = v1alpha1.Nodes{nodeId: node}
+	return ciCdStatus
+}
+
+// getNodeMessage returns the message of the failed step, as argo does for the failed pod
+func getNodeMessage(wfStatus *types.WorkflowStatus) string {
+	for _, step := range wfStatus.Steps {
+		if step.Status == string(v1alpha1.NodeFailed) {
+			return fmt.Sprintf("step %s failed: %s", step.Name, step.Message)
+		}
+	}
+	return wfStatus.Message
+}
+
+func isWorkflowStatusChanged(ciCdStatus eventProcessorBean.CiCdStatus, status, podStatus, message string) bool {
+	if string(ciCdStatus.Phase) != status {
+		return true
+	}
+	for _, node := range ciCdStatus.Nodes {
+		if string(node.Phase) != podStatus || node.Message != message {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tektonStatus
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	pubsub "github.com/devtron-labs/common-lib/pubsub-lib"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig"
+	"github.com/devtron-labs/devtron/internal/sql/repository/pipelineConfig/bean/workflow/cdWorkflow"
+	"github.com/devtron-labs/devtron/internal/util"
+	clusterBean "github.com/devtron-labs/devtron/pkg/cluster/bean"
+	"github.com/devtron-labs/devtron/pkg/cluster/environment/repository"
+	eventProcessorBean "github.com/devtron-labs/devtron/pkg/eventProcessor/bean"
+	k8sPkg "github.com/devtron-labs/devtron/pkg/k8s"
+	pipelineBean "github.com/devtron-labs/devtron/pkg/pipeline/bean"
+	"github.com/devtron-labs/devtron/pkg/pipeline/executors"
+	"github.com/devtron-labs/devtron/pkg/pipeline/types"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/rest"
+	"testing"
+)
+
+type ciWorkflowRepositoryStub struct {
+	pipelineConfig.CiWorkflowRepository
+	ciWorkflows []*pipelineConfig.CiWorkflow
+}
+
+func (impl *ciWorkflowRepositoryStub) FindByExecutorTypeAndStatusesIn(executorType cdWorkflow.WorkflowExecutorType, activeStatuses []string) ([]*pipelineConfig.CiWorkflow, error) {
+	return impl.ciWorkflows, nil
+}
+
+type cdWorkflowRepositoryStub struct {
+	pipelineConfig.CdWorkflowRepository
+	runners []*pipelineConfig.CdWorkflowRunner
+}
+
+func (impl *cdWorkflowRepositoryStub) FindPreOrPostCdWorkflowRunnersByExecutorTypeAndStatusesIn(executorType cdWorkflow.WorkflowExecutorType, activeStatuses []string) ([]*pipelineConfig.CdWorkflowRunner, error) {
+	return impl.runners, nil
+}
+
+type envRepositoryStub struct {
+	repository.EnvironmentRepository
+}
+
+func (impl *envRepositoryStub) FindById(id int) (*repository.Environment, error) {
+	return &repository.Environment{Id: id, ClusterId: 2}, nil
+}
+
+type k8sCommonServiceStub struct {
+	k8sPkg.K8sCommonService
+	clusterIds []int
+}
+
+func (impl *k8sCommonServiceStub) GetRestConfigByClusterId(ctx context.Context, clusterId int) (*rest.Config, error, *clusterBean.ClusterBean) {
+	impl.clusterIds = append(impl.clusterIds, clusterId)
+	return &rest.Config{}, nil, nil
+}
+
+type tektonWorkflowExecutorStub struct {
+	executors.TektonWorkflowExecutor
+	statusByName   map[string]*types.WorkflowStatus
+	statusByPrefix map[string]*types.WorkflowStatus
+}
+
+func (impl *tektonWorkflowExecutorStub) GetWorkflowStatus(workflowName string, namespace string, clusterConfig *rest.Config) (*types.WorkflowStatus, error) {
+	return impl.statusByName[workflowName], nil
+}
+
+func (impl *tektonWorkflowExecutorStub) GetWorkflowStatusByNamePrefix(workflowNamePrefix string, namespace string, clusterConfig *rest.Config) (*types.WorkflowStatus, error) {
+	return impl.statusByPrefix[workflowNamePrefix], nil
+}
+
+type publishedStatus struct {
+	topic  string
+	status eventProcessorBean.CiCdStatus
+}
+
+func getTektonWorkflowStatusService(t *testing.T, ciWorkflows []*pipelineConfig.CiWorkflow, runners []*pipelineConfig.CdWorkflowRunner,
+	executorStub *tektonWorkflowExecutorStub) (*TektonWorkflowStatusServiceImpl, *k8sCommonServiceStub, *[]publishedStatus) {
+	logger, err := util.NewSugardLogger()
+	assert.Nil(t, err)
+	k8sCommonService := &k8sCommonServiceStub{}
+	impl := NewTektonWorkflowStatusServiceImpl(logger, &types.CiCdConfig{CiDefaultNamespace: "devtron-ci", CdDefaultNamespace: "devtron-cd"},
+		&ciWorkflowRepositoryStub{ciWorkflows: ciWorkflows}, &cdWorkflowRepositoryStub{runners: runners}, &envRepositoryStub{},
+		k8sCommonService, executorStub, &pubsub.PubSubClientServiceImpl{})
+	published := make([]publishedStatus, 0)
+	impl.publish = func(topic string, msg string) error {
+		status := eventProcessorBean.NewCiCdStatus()
+		assert.Nil(t, json.Unmarshal([]byte(msg), &status))
+		published = append(published, publishedStatus{topic: topic, status: status})
+		return nil
+	}
+	return impl, k8sCommonService, &published
+}
+
+func TestSyncActiveWorkflowsStatus(t *testing.T) {
+	t.Run("ci workflow status is published as the kubewatch event", func(t *testing.T) {
+		ciWorkflows := []*pipelineConfig.CiWorkflow{
+			// status not synced yet, the pipeline run is looked up by the name prefix
+			{Id: 1, Name: "build-3", Status: cdWorkflow.WorkflowStarting, Namespace: "devtron-ci", ExecutorType: cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON},
+			// running in the namespace of an environment
+			{Id: 2, Name: "2-build-3-abcde", Status: string(v1alpha1.NodeRunning), PodStatus: string(v1alpha1.NodeRunning), Namespace: "ci-jobs", EnvironmentId: 5, ExecutorType: cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON},
+		}
+		executorStub := &tektonWorkflowExecutorStub{
+			statusByPrefix: map[string]*types.WorkflowStatus{
+				"1-build-3": {WorkflowName: "1-build-3-vwxyz", Status: string(v1alpha1.NodeRunning), PodStatus: string(v1alpha1.NodeRunning), PodName: "1-build-3-vwxyz-workflow-pod"},
+			},
+			statusByName: map[string]*types.WorkflowStatus{
+				"2-build-3-abcde": {WorkflowName: "2-build-3-abcde", Status: string(v1alpha1.NodeFailed), PodStatus: string(v1alpha1.NodeFailed), PodName: "2-build-3-abcde-workflow-pod",
+					Message: "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 0",
+					Steps: []types.WorkflowStepStatus{
+						{Name: "init-setup", Status: string(v1alpha1.NodeSucceeded)},
+						{Name: "ci", Status: string(v1alpha1.NodeFailed), Message: "Error (exit code 1)"},
+					}},
+			},
+		}
+		impl, k8sCommonService, published := getTektonWorkflowStatusService(t, ciWorkflows, nil, executorStub)
+		impl.SyncActiveWorkflowsStatus()
+
+		assert.Equal(t, []int{clusterBean.DefaultClusterId, 2}, k8sCommonService.clusterIds)
+		assert.Len(t, *published, 2)
+		for _, event := range *published {
+			assert.Equal(t, pubsub.WORKFLOW_STATUS_UPDATE_TOPIC, event.topic)
+		}
+		node := (*published)[0].status.Nodes["1-build-3-vwxyz-workflow-pod"]
+		assert.Equal(t, pipelineBean.CI_WORKFLOW_NAME, node.TemplateName)
+		assert.Equal(t, "1-build-3-vwxyz", node.BoundaryID)
+		assert.Equal(t, v1alpha1.NodeRunning, node.Phase)
+
+		failedStatus := (*published)[1].status
+		assert.Equal(t, v1alpha1.WorkflowFailed, failedStatus.Phase)
+		assert.False(t, failedStatus.FinishedAt.IsZero())
+		assert.True(t, (*published)[0].status.FinishedAt.IsZero())
+		node = failedStatus.Nodes["2-build-3-abcde-workflow-pod"]
+		assert.Equal(t, v1alpha1.NodeFailed, node.Phase)
+		assert.Equal(t, "step ci failed: Error (exit code 1)", node.Message)
+	})
+
+	t.Run("unchanged status is not published", func(t *testing.T) {
+		ciWorkflows := []*pipelineConfig.CiWorkflow{
+			{Id: 2, Name: "2-build-3-abcde", Status: string(v1alpha1.NodeRunning), PodStatus: string(v1alpha1.NodeRunning), Message: "Tasks Completed: 0", Namespace: "devtron-ci", ExecutorType: cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON},
+		}
+		executorStub := &tektonWorkflowExecutorStub{statusByName: map[string]*types.WorkflowStatus{
+			"2-build-3-abcde": {WorkflowName: "2-build-3-abcde", Status: string(v1alpha1.NodeRunning), PodStatus: string(v1alpha1.NodeRunning), PodName: "2-build-3-abcde-workflow-pod", Message: "Tasks Completed: 0"},
+		}}
+		impl, _, published := getTektonWorkflowStatusService(t, ciWorkflows, nil, executorStub)
+		impl.SyncActiveWorkflowsStatus()
+		assert.Len(t, *published, 0)
+	})
+
+	t.Run("cd workflow runner status", func(t *testing.T) {
+		runners := []*pipelineConfig.CdWorkflowRunner{
+			{Id: 7, Name: "pipeline-1", Status: cdWorkflow.WorkflowStarting, PodStatus: string(v1alpha1.NodePending), Namespace: "prod", ExecutorType: cdWorkflow.WORKFLOW_EXECUTOR_TYPE_TEKTON,
+				CdWorkflow: &pipelineConfig.CdWorkflow{Pipeline: &pipelineConfig.Pipeline{Environment: repository.Environment{ClusterId: 3}}}},
+		}
+		executorStub := &tektonWorkflowExecutorStub{statusByPrefix: map[string]*types.WorkflowStatus{
+			// the task run is yet to be created
+			"7-pipeline-1": {WorkflowName: "7-pipeline-1-abcde", Status: string(v1alpha1.NodePending)},
+		}}
+		impl, k8sCommonService, published := getTektonWorkflowStatusService(t, nil, runners, executorStub)
+		impl.SyncActiveWorkflowsStatus()
+
+		assert.Equal(t, []int{3}, k8sCommonService.clusterIds)
+		assert.Len(t, *published, 1)
+		assert.Equal(t, pubsub.CD_WORKFLOW_STATUS_UPDATE, (*published)[0].topic)
+		node := (*published)[0].status.Nodes["7-pipeline-1-abcde"]
+		assert.Equal(t, pipelineBean.CD_WORKFLOW_NAME, node.TemplateName)
+		assert.Empty(t, node.BoundaryID)
+		assert.Equal(t, v1alpha1.NodePending, node.Phase)
+	})
+}
@@ -18,7 +18,9 @@ package workflow
 
 import (
 	"github.com/devtron-labs/devtron/pkg/workflow/cd"
+	"github.com/devtron-labs/devtron/pkg/workflow/logArchive"
 	"github.com/devtron-labs/devtron/pkg/workflow/status"
+	"github.com/devtron-labs/devtron/pkg/workflow/tektonStatus"
 	"github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/hook"
 	"github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/repository"
 	"github.com/devtron-labs/devtron/pkg/workflow/trigger/audit/service"
@@ -28,7 +30,9 @@ import (
 
 var WorkflowWireSet = wire.NewSet(
 	cd.CdWorkflowWireSet,
+	logArchive.WorkflowLogArchiveWireSet,
 	status.WorkflowStatusWireSet,
+	tektonStatus.TektonWorkflowStatusWireSet,
 	workflowStatusLatest.WorkflowStatusLatestWireSet,
 	hook.NewTriggerAuditHookImpl,
 	wire.Bind(new(hook.TriggerAuditHook), new(*hook.TriggerAuditHookImpl)),
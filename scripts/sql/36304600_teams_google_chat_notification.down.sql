@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."google_chat_config";
+DROP SEQUENCE IF EXISTS public.id_seq_google_chat_config;
+
+DROP TABLE IF EXISTS "public"."teams_config";
+DROP SEQUENCE IF EXISTS public.id_seq_teams_config;
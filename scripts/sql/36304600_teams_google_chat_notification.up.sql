@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+-- Microsoft Teams and Google Chat notification channel configs
+CREATE SEQUENCE IF NOT EXISTS id_seq_teams_config;
+
+CREATE TABLE IF NOT EXISTS "public"."teams_config" (
+    "id"           integer NOT NULL DEFAULT nextval('id_seq_teams_config'::regclass),
+    "web_hook_url" text NOT NULL,
+    "config_name"  varchar(250) NOT NULL,
+    "description"  text,
+    "owner_id"     int4,
+    "deleted"      bool NOT NULL DEFAULT FALSE,
+    "created_on"   timestamptz NOT NULL,
+    "created_by"   int4 NOT NULL,
+    "updated_on"   timestamptz NOT NULL,
+    "updated_by"   int4 NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_google_chat_config;
+
+CREATE TABLE IF NOT EXISTS "public"."google_chat_config" (
+    "id"           integer NOT NULL DEFAULT nextval('id_seq_google_chat_config'::regclass),
+    "web_hook_url" text NOT NULL,
+    "config_name"  varchar(250) NOT NULL,
+    "description"  text,
+    "owner_id"     int4,
+    "deleted"      bool NOT NULL DEFAULT FALSE,
+    "created_on"   timestamptz NOT NULL,
+    "created_by"   int4 NOT NULL,
+    "updated_on"   timestamptz NOT NULL,
+    "updated_by"   int4 NOT NULL,
+    PRIMARY KEY ("id")
+);
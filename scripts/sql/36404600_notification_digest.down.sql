@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DELETE FROM notification_templates WHERE event_type_id = 10;
+DELETE FROM event WHERE id = 10;
+
+DROP TABLE IF EXISTS "public"."notification_digest_event";
+DROP SEQUENCE IF EXISTS id_seq_notification_digest_event;
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+-- events held back for notification settings delivered as a periodic digest, the events of a digest are claimed by
+-- one instance before being sent and events left in SENDING by a stopped instance are picked up again
+CREATE SEQUENCE IF NOT EXISTS id_seq_notification_digest_event;
+
+CREATE TABLE IF NOT EXISTS "public"."notification_digest_event" (
+    "id"             integer NOT NULL DEFAULT nextval('id_seq_notification_digest_event'::regclass),
+    "view_id"        integer NOT NULL,
+    "event_type_id"  integer NOT NULL,
+    "pipeline_type"  varchar(50),
+    "pipeline_id"    integer,
+    "app_id"         integer,
+    "env_id"         integer,
+    "event"          text NOT NULL,
+    "status"         varchar(20) NOT NULL DEFAULT 'PENDING',
+    "claimed_on"     timestamptz,
+    "flushed_on"     timestamptz,
+    "created_on"     timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_digest_event_status ON public.notification_digest_event (status, view_id);
+
+INSERT INTO public.event (id, event_type, description) VALUES (10, 'DIGEST', 'summary of events batched for a notification setting');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('slack', 'CI', 10, 'CI digest slack template', '{
+    "text": ":bell: Build pipeline digest | {{digestTotal}} events",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":bell: *Build pipeline digest* - {{digestTotal}} events\n{{digestWindow}}"
+            }
+        },
+        {
+            "type": "divider"
+        }
+        {{#digestItems}}
+        ,{
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*{{appName}}*\n{{pipelineName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*{{status}}* x{{count}}\nlast at {{lastEventTime}}"
+                }
+            ]
+        }
+        {{/digestItems}}
+    ]
+}'),
+('slack', 'CD', 10, 'CD digest slack template', '{
+    "text": ":bell: Deployment pipeline digest | {{digestTotal}} events",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":bell: *Deployment pipeline digest* - {{digestTotal}} events\n{{digestWindow}}"
+            }
+        },
+        {
+            "type": "divider"
+        }
+        {{#digestItems}}
+        ,{
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*{{appName}}* · {{envName}}\n{{pipelineName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*{{status}}* x{{count}}\nlast at {{lastEventTime}}"
+                }
+            ]
+        }
+        {{/digestItems}}
+    ]
+}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+SELECT channel_type, node_type, 10, node_type || ' digest ' || channel_type || ' template', '{
+    "from": "{{fromEmail}}",
+    "to": "{{toEmail}}",
+    "subject": "🔔 ' || CASE WHEN node_type = 'CI' THEN 'Build' ELSE 'Deployment' END || ' pipeline digest | {{digestTotal}} events",
+    "html": "<table cellpadding=0 style=\"font-family: Arial, Verdana, Helvetica; width: 600px; border:1px solid #D0D4D9; border-radius: 8px; padding: 16px 20px; margin: 20px auto;\"><tr><td colspan=\"3\"><div style=\"font-size: 16px; line-height:24px; font-weight:600; color: #000a14;\">' || CASE WHEN node_type = 'CI' THEN 'Build' ELSE 'Deployment' END || ' pipeline digest: {{digestTotal}} events</div><div style=\"font-size: 13px; color: #3B444C; padding-bottom: 16px;\">{{digestWindow}}</div></td></tr><tr><td style=\"color: #3B444C; font-size: 13px;\">Application</td><td style=\"color: #3B444C; font-size: 13px;\">Pipeline</td><td style=\"color: #3B444C; font-size: 13px;\">Status</td></tr>{{#digestItems}}<tr><td style=\"color: #000a14; font-size: 14px;\">{{appName}} {{envName}}</td><td style=\"color: #000a14; font-size: 14px;\">{{pipelineName}}</td><td style=\"color: #000a14; font-size: 14px;\">{{status}} x{{count}}</td></tr>{{/digestItems}}</table>"
+}'
+FROM (VALUES ('ses', 'CI'), ('ses', 'CD'), ('smtp', 'CI'), ('smtp', 'CD')) AS t(channel_type, node_type);
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+SELECT 'webhook', node_type, 10, node_type || ' digest webhook template', '{
+    "eventType": "DIGEST",
+    "pipelineType": "' || node_type || '",
+    "digestTotal": "{{digestTotal}}",
+    "digestWindow": "{{digestWindow}}",
+    "summary": "{{#digestItems}}{{appName}} {{envName}} {{pipelineName}}: {{status}} x{{count}}, last at {{lastEventTime}}\n{{/digestItems}}"
+}'
+FROM (VALUES ('CI'), ('CD')) AS t(node_type);
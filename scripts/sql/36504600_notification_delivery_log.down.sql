@@ -0,0 +1,6 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."notification_delivery_log";
+DROP SEQUENCE IF EXISTS id_seq_notification_delivery_log;
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+-- delivery log of notifications, failed deliveries are retried with backoff and dead lettered after the last attempt
+CREATE SEQUENCE IF NOT EXISTS id_seq_notification_delivery_log;
+
+CREATE TABLE IF NOT EXISTS "public"."notification_delivery_log" (
+    "id"             integer NOT NULL DEFAULT nextval('id_seq_notification_delivery_log'::regclass),
+    "correlation_id" varchar(50),
+    "event_type_id"  integer NOT NULL,
+    "pipeline_type"  varchar(50),
+    "pipeline_id"    integer,
+    "app_id"         integer,
+    "env_id"         integer,
+    "channel"        varchar(50) NOT NULL,
+    "config_id"      integer,
+    "destination"    text,
+    "payload"        text NOT NULL,
+    "payload_hash"   varchar(64) NOT NULL,
+    "status"         varchar(50) NOT NULL,
+    "attempts"       integer NOT NULL DEFAULT 0,
+    "last_error"     text,
+    "next_retry_on"  timestamptz,
+    "created_on"     timestamptz NOT NULL,
+    "created_by"     int4 NOT NULL,
+    "updated_on"     timestamptz NOT NULL,
+    "updated_by"     int4 NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_delivery_log_status ON public.notification_delivery_log (status, next_retry_on);
@@ -0,0 +1,5 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."pipeline" DROP COLUMN IF EXISTS "promotion_condition";
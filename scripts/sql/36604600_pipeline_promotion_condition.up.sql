@@ -0,0 +1,5 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."pipeline" ADD COLUMN IF NOT EXISTS "promotion_condition" text;
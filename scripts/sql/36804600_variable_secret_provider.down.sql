@@ -0,0 +1,5 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."variable_definition" DROP COLUMN IF EXISTS "secret_provider";
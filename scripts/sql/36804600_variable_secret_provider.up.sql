@@ -0,0 +1,5 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."variable_definition" ADD COLUMN IF NOT EXISTS "secret_provider" varchar(50);
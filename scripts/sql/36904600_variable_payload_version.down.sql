@@ -0,0 +1,7 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."variable_payload_version";
+
+DROP SEQUENCE IF EXISTS id_seq_variable_payload_version;
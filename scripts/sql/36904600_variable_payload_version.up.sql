@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_variable_payload_version;
+
+CREATE TABLE IF NOT EXISTS "public"."variable_payload_version"
+(
+    "id"                       integer NOT NULL DEFAULT nextval('id_seq_variable_payload_version'::regclass),
+    "version"                  integer NOT NULL,
+    "payload"                  text    NOT NULL,
+    "variable_count"           integer NOT NULL,
+    "rolled_back_from_version" integer,
+    "created_on"               timestamptz NOT NULL,
+    "created_by"               integer NOT NULL,
+    "updated_on"               timestamptz NOT NULL,
+    "updated_by"               integer NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("version")
+);
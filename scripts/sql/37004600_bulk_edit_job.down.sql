@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."bulk_edit_job_item";
+
+DROP SEQUENCE IF EXISTS id_seq_bulk_edit_job_item;
+
+DROP TABLE IF EXISTS "public"."bulk_edit_job";
+
+DROP SEQUENCE IF EXISTS id_seq_bulk_edit_job;
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_bulk_edit_job;
+
+CREATE TABLE IF NOT EXISTS "public"."bulk_edit_job"
+(
+    "id"             integer     NOT NULL DEFAULT nextval('id_seq_bulk_edit_job'::regclass),
+    "spec"           text        NOT NULL,
+    "status"         varchar(50) NOT NULL,
+    "batch_size"     integer     NOT NULL,
+    "scheduled_at"   timestamptz NOT NULL,
+    "started_on"     timestamptz,
+    "finished_on"    timestamptz,
+    "is_super_admin" bool        NOT NULL DEFAULT false,
+    "user_email_id"  varchar(250),
+    -- running jobs whose instance stopped refreshing the heartbeat are picked up again by another instance
+    "heartbeat_on"   timestamptz,
+    "claim_count"    integer     NOT NULL DEFAULT 0,
+    "created_on"     timestamptz NOT NULL,
+    "created_by"     integer     NOT NULL,
+    "updated_on"     timestamptz NOT NULL,
+    "updated_by"     integer     NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_bulk_edit_job_status_scheduled_at ON "public"."bulk_edit_job" ("status", "scheduled_at");
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_bulk_edit_job_item;
+
+CREATE TABLE IF NOT EXISTS "public"."bulk_edit_job_item"
+(
+    "id"          integer      NOT NULL DEFAULT nextval('id_seq_bulk_edit_job_item'::regclass),
+    "job_id"      integer      NOT NULL,
+    "app_id"      integer      NOT NULL,
+    "app_name"    varchar(250) NOT NULL,
+    "status"      varchar(50)  NOT NULL,
+    "response"    text,
+    "started_on"  timestamptz,
+    "finished_on" timestamptz,
+    "created_on"  timestamptz  NOT NULL,
+    "created_by"  integer      NOT NULL,
+    "updated_on"  timestamptz  NOT NULL,
+    "updated_by"  integer      NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "bulk_edit_job_item_job_id_fkey" FOREIGN KEY ("job_id") REFERENCES "public"."bulk_edit_job" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_bulk_edit_job_item_job_id ON "public"."bulk_edit_job_item" ("job_id");
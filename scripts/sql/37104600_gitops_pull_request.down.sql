@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."gitops_pull_request";
+
+DROP SEQUENCE IF EXISTS id_seq_gitops_pull_request;
+
+ALTER TABLE "public"."environment" DROP COLUMN IF EXISTS "gitops_pull_request_enabled";
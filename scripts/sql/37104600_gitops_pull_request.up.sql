@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."environment" ADD COLUMN IF NOT EXISTS "gitops_pull_request_enabled" bool NOT NULL DEFAULT false;
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_pull_request;
+
+CREATE TABLE IF NOT EXISTS "public"."gitops_pull_request"
+(
+    "id"                    integer      NOT NULL DEFAULT nextval('id_seq_gitops_pull_request'::regclass),
+    "cd_workflow_runner_id" integer      NOT NULL,
+    "pipeline_override_id"  integer      NOT NULL,
+    "app_id"                integer      NOT NULL,
+    "env_id"                integer      NOT NULL,
+    "repo_url"              text         NOT NULL,
+    "repo_name"             varchar(250) NOT NULL,
+    "pull_request_number"   integer      NOT NULL,
+    "pull_request_url"      text,
+    "source_branch"         varchar(250) NOT NULL,
+    "target_branch"         varchar(250) NOT NULL,
+    "status"                varchar(50)  NOT NULL,
+    "merge_commit_hash"     varchar(250),
+    "message"               text,
+    "created_on"            timestamptz  NOT NULL,
+    "created_by"            integer      NOT NULL,
+    "updated_on"            timestamptz  NOT NULL,
+    "updated_by"            integer      NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_gitops_pull_request_status ON "public"."gitops_pull_request" ("status");
+
+CREATE INDEX IF NOT EXISTS idx_gitops_pull_request_cd_workflow_runner_id ON "public"."gitops_pull_request" ("cd_workflow_runner_id");
@@ -0,0 +1,8 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."gitops_config"
+    DROP COLUMN IF EXISTS "signing_key_type",
+    DROP COLUMN IF EXISTS "signing_key",
+    DROP COLUMN IF EXISTS "signing_key_passphrase";
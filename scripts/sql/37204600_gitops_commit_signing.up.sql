@@ -0,0 +1,8 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE "public"."gitops_config"
+    ADD COLUMN IF NOT EXISTS "signing_key_type" varchar(10),
+    ADD COLUMN IF NOT EXISTS "signing_key" text,
+    ADD COLUMN IF NOT EXISTS "signing_key_passphrase" text;
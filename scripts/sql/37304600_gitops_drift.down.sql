@@ -0,0 +1,9 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DELETE FROM notification_templates WHERE event_type_id = 11;
+DELETE FROM event WHERE id = 11;
+
+DROP TABLE IF EXISTS "public"."gitops_drift";
+DROP SEQUENCE IF EXISTS id_seq_gitops_drift;
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_gitops_drift;
+
+CREATE TABLE IF NOT EXISTS "public"."gitops_drift"
+(
+    "id"                   integer      NOT NULL DEFAULT nextval('id_seq_gitops_drift'::regclass),
+    "pipeline_id"          integer      NOT NULL,
+    "app_id"               integer      NOT NULL,
+    "env_id"               integer      NOT NULL,
+    "pipeline_override_id" integer      NOT NULL,
+    "deployed_commit_hash" varchar(250),
+    "head_commit_hash"     varchar(250),
+    "status"               varchar(50)  NOT NULL,
+    "git_drifted"          bool         NOT NULL DEFAULT false,
+    "live_drifted"         bool         NOT NULL DEFAULT false,
+    "git_diff"             text,
+    "live_diff"            text,
+    "message"              text,
+    "detected_on"          timestamptz,
+    "checked_on"           timestamptz  NOT NULL,
+    "created_on"           timestamptz  NOT NULL,
+    "created_by"           integer      NOT NULL,
+    "updated_on"           timestamptz  NOT NULL,
+    "updated_by"           integer      NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_gitops_drift_pipeline_id ON "public"."gitops_drift" ("pipeline_id");
+
+CREATE INDEX IF NOT EXISTS idx_gitops_drift_app_id_env_id ON "public"."gitops_drift" ("app_id", "env_id");
+
+INSERT INTO public.event (id, event_type, description) VALUES (11, 'GITOPS_DRIFT', 'out-of-band change in the gitops repository or the live cluster of a cd pipeline');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+VALUES ('slack', 'CD', 11, 'CD gitops drift slack template', '{
+    "text": ":warning: Out-of-band change detected | {{appName}} | {{envName}}",
+    "blocks": [{
+            "type": "section",
+            "text": {
+                "type": "mrkdwn",
+                "text": ":warning: *Out-of-band change detected*\n{{eventTime}}"
+            }
+        },
+        {
+            "type": "section",
+            "fields": [{
+                    "type": "mrkdwn",
+                    "text": "*Application*\n{{appName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Environment*\n{{envName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Pipeline*\n{{pipelineName}}"
+                },
+                {
+                    "type": "mrkdwn",
+                    "text": "*Changed in*\n{{driftedIn}}"
+                }
+            ]
+        },
+        {
+            "type": "actions",
+            "elements": [{
+                "type": "button",
+                "text": {
+                    "type": "plain_text",
+                    "text": "App details"
+                },
+                "url": "{{& appDetailsLink}}"
+            }]
+        }
+    ]
+}');
+
+INSERT INTO "public"."notification_templates" (channel_type, node_type, event_type_id, template_name, template_payload)
+SELECT channel_type, 'CD', 11, 'CD gitops drift ' || channel_type || ' template', '{
+    "from": "{{fromEmail}}",
+    "to": "{{toEmail}}",
+    "subject": "⚠️ Out-of-band change detected | {{appName}} | {{envName}}",
+    "html": "<table cellpadding=0 style=\"font-family: Arial, Verdana, Helvetica; width: 600px; border:1px solid #D0D4D9; border-radius: 8px; padding: 16px 20px; margin: 20px auto;\"><tr><td colspan=\"2\"><div style=\"font-size: 16px; line-height:24px; font-weight:600; color: #000a14;\">Out-of-band change detected</div><div style=\"font-size: 13px; color: #3B444C; padding-bottom: 16px;\">{{eventTime}}</div></td></tr><tr><td style=\"color: #3B444C; font-size: 13px;\">Application</td><td style=\"color: #3B444C; font-size: 13px;\">Environment</td></tr><tr><td style=\"color: #000a14; font-size: 14px; padding-bottom: 12px;\">{{appName}}</td><td style=\"color: #000a14; font-size: 14px; padding-bottom: 12px;\">{{envName}}</td></tr><tr><td style=\"color: #3B444C; font-size: 13px;\">Pipeline</td><td style=\"color: #3B444C; font-size: 13px;\">Changed in</td></tr><tr><td style=\"color: #000a14; font-size: 14px; padding-bottom: 16px;\">{{pipelineName}}</td><td style=\"color: #000a14; font-size: 14px; padding-bottom: 16px;\">{{driftedIn}}</td></tr><tr><td colspan=\"2\"><a href=\"{{& appDetailsLink}}\" style=\"font-size: 13px; color: #0066CC;\">View app details</a></td></tr></table>"
+}'
+FROM (VALUES ('ses'), ('smtp')) AS t(channel_type);
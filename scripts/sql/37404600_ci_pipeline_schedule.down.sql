@@ -0,0 +1,6 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."ci_pipeline_schedule";
+DROP SEQUENCE IF EXISTS id_seq_ci_pipeline_schedule;
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_ci_pipeline_schedule;
+
+CREATE TABLE IF NOT EXISTS "public"."ci_pipeline_schedule"
+(
+    "id"                    integer      NOT NULL DEFAULT nextval('id_seq_ci_pipeline_schedule'::regclass),
+    "ci_pipeline_id"        integer      NOT NULL,
+    "cron_expression"       varchar(250) NOT NULL,
+    "timezone"              varchar(100) NOT NULL DEFAULT 'UTC',
+    "skip_if_running"       bool         NOT NULL DEFAULT false,
+    "skip_if_no_new_commit" bool         NOT NULL DEFAULT false,
+    "active"                bool         NOT NULL DEFAULT true,
+    "next_trigger_on"       timestamptz,
+    "last_triggered_on"     timestamptz,
+    "created_on"            timestamptz  NOT NULL,
+    "created_by"            integer      NOT NULL,
+    "updated_on"            timestamptz  NOT NULL,
+    "updated_by"            integer      NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "ci_pipeline_schedule_ci_pipeline_id_fkey" FOREIGN KEY ("ci_pipeline_id") REFERENCES "public"."ci_pipeline" ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_ci_pipeline_schedule_ci_pipeline_id ON "public"."ci_pipeline_schedule" ("ci_pipeline_id");
+
+CREATE INDEX IF NOT EXISTS idx_ci_pipeline_schedule_next_trigger_on ON "public"."ci_pipeline_schedule" ("next_trigger_on") WHERE "active" = true;
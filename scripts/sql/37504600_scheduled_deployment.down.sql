@@ -0,0 +1,6 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."scheduled_deployment";
+DROP SEQUENCE IF EXISTS id_seq_scheduled_deployment;
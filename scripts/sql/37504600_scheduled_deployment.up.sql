@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_scheduled_deployment;
+
+CREATE TABLE IF NOT EXISTS "public"."scheduled_deployment"
+(
+    "id"                         integer      NOT NULL DEFAULT nextval('id_seq_scheduled_deployment'::regclass),
+    "app_id"                     integer      NOT NULL,
+    "env_id"                     integer      NOT NULL,
+    "pipeline_id"                integer      NOT NULL,
+    "cd_workflow_type"           varchar(20)  NOT NULL,
+    "ci_artifact_id"             integer,
+    "use_latest_artifact"        bool         NOT NULL DEFAULT false,
+    "schedule_type"              varchar(20)  NOT NULL,
+    "cron_expression"            varchar(250),
+    "timezone"                   varchar(100),
+    "status"                     varchar(20)  NOT NULL,
+    "next_trigger_on"            timestamptz,
+    "last_triggered_on"          timestamptz,
+    "last_cd_workflow_runner_id" integer,
+    "last_ci_artifact_id"        integer,
+    "message"                    text,
+    "created_on"                 timestamptz  NOT NULL,
+    "created_by"                 integer      NOT NULL,
+    "updated_on"                 timestamptz  NOT NULL,
+    "updated_by"                 integer      NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "scheduled_deployment_pipeline_id_fkey" FOREIGN KEY ("pipeline_id") REFERENCES "public"."pipeline" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_deployment_app_id_pipeline_id ON "public"."scheduled_deployment" ("app_id", "pipeline_id");
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_deployment_next_trigger_on ON "public"."scheduled_deployment" ("next_trigger_on") WHERE "status" = 'Scheduled';
@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS "public"."log_retention_policy";
+
+DROP SEQUENCE IF EXISTS id_seq_log_retention_policy;
+
+DROP TABLE IF EXISTS "public"."workflow_log_archive";
+
+DROP SEQUENCE IF EXISTS id_seq_workflow_log_archive;
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_workflow_log_archive;
+
+CREATE TABLE IF NOT EXISTS "public"."workflow_log_archive"
+(
+    "id"            integer      NOT NULL DEFAULT nextval('id_seq_workflow_log_archive'::regclass),
+    "workflow_id"   integer      NOT NULL,
+    "workflow_type" varchar(20)  NOT NULL,
+    "pipeline_id"   integer      NOT NULL,
+    "log_key"       text         NOT NULL,
+    "archive_key"   text,
+    "line_index"    jsonb,
+    "status"        varchar(20)  NOT NULL,
+    "expired_on"    timestamptz,
+    "created_on"    timestamptz  NOT NULL,
+    "created_by"    integer      NOT NULL,
+    "updated_on"    timestamptz  NOT NULL,
+    "updated_by"    integer      NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_workflow_log_archive_workflow ON "public"."workflow_log_archive" ("workflow_id", "workflow_type");
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_log_retention_policy;
+
+CREATE TABLE IF NOT EXISTS "public"."log_retention_policy"
+(
+    "id"               integer      NOT NULL DEFAULT nextval('id_seq_log_retention_policy'::regclass),
+    "team_id"          integer      NOT NULL DEFAULT 0,
+    "environment_id"   integer      NOT NULL DEFAULT 0,
+    "retention_days"   integer      NOT NULL,
+    "delete_artifacts" bool         NOT NULL DEFAULT false,
+    "active"           bool         NOT NULL DEFAULT true,
+    "created_on"       timestamptz  NOT NULL,
+    "created_by"       integer      NOT NULL,
+    "updated_on"       timestamptz  NOT NULL,
+    "updated_by"       integer      NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_unique_log_retention_policy_scope ON "public"."log_retention_policy" ("team_id", "environment_id") WHERE "active" = true;
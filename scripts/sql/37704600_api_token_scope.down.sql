@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE api_token
+    DROP COLUMN IF EXISTS scope,
+    DROP COLUMN IF EXISTS previous_version,
+    DROP COLUMN IF EXISTS previous_version_expire_at_in_ms,
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS last_used_by_ip;
@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE api_token
+    ADD COLUMN IF NOT EXISTS scope                            jsonb,
+    ADD COLUMN IF NOT EXISTS previous_version                 integer,
+    ADD COLUMN IF NOT EXISTS previous_version_expire_at_in_ms bigint,
+    ADD COLUMN IF NOT EXISTS last_used_at                     timestamptz,
+    ADD COLUMN IF NOT EXISTS last_used_by_ip                  text;
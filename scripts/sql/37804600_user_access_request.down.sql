@@ -0,0 +1,8 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS public.user_role_assignment_audit;
+DROP SEQUENCE IF EXISTS id_seq_user_role_assignment_audit;
+DROP TABLE IF EXISTS public.user_access_request;
+DROP SEQUENCE IF EXISTS id_seq_user_access_request;
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_user_access_request;
+
+CREATE TABLE IF NOT EXISTS public.user_access_request
+(
+    "id"               integer     NOT NULL DEFAULT nextval('id_seq_user_access_request'::regclass),
+    "user_id"          integer     NOT NULL,
+    "role_filters"     jsonb       NOT NULL,
+    "duration_in_mins" integer     NOT NULL,
+    "justification"    text        NOT NULL,
+    "status"           varchar(20) NOT NULL,
+    "granted_role_ids" integer[],
+    "actioned_by"      integer,
+    "action_comment"   text,
+    "approved_on"      timestamptz,
+    "expires_on"       timestamptz,
+    "revoked_on"       timestamptz,
+    "claimed_on"       timestamptz,
+    "created_on"       timestamptz NOT NULL,
+    "created_by"       integer     NOT NULL,
+    "updated_on"       timestamptz NOT NULL,
+    "updated_by"       integer     NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "user_access_request_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_access_request_user_id ON public.user_access_request (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_access_request_status_expires_on ON public.user_access_request (status, expires_on);
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_user_role_assignment_audit;
+
+CREATE TABLE IF NOT EXISTS public.user_role_assignment_audit
+(
+    "id"                integer     NOT NULL DEFAULT nextval('id_seq_user_role_assignment_audit'::regclass),
+    "user_id"           integer     NOT NULL,
+    "role_id"           integer     NOT NULL,
+    "role"              text        NOT NULL,
+    "action"            varchar(20) NOT NULL,
+    "access_request_id" integer,
+    "reason"            text,
+    "performed_by"      integer     NOT NULL,
+    "performed_on"      timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_role_assignment_audit_user_id ON public.user_role_assignment_audit (user_id);
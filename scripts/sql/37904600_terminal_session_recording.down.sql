@@ -0,0 +1,6 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP TABLE IF EXISTS public.terminal_session_recording;
+DROP SEQUENCE IF EXISTS id_seq_terminal_session_recording;
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS id_seq_terminal_session_recording;
+
+CREATE TABLE IF NOT EXISTS public.terminal_session_recording
+(
+    "id"                      integer     NOT NULL DEFAULT nextval('id_seq_terminal_session_recording'::regclass),
+    "session_id"              varchar(50) NOT NULL,
+    "user_id"                 integer     NOT NULL,
+    "cluster_id"              integer,
+    "namespace"               varchar(250),
+    "pod_name"                varchar(250),
+    "container_name"          varchar(250),
+    "shell"                   varchar(50),
+    "started_on"              timestamptz NOT NULL,
+    "ended_on"                timestamptz,
+    "end_reason"              text,
+    "recording_status"        varchar(20) NOT NULL,
+    "recording_key"           text,
+    "recording_size_in_bytes" bigint,
+    "created_on"              timestamptz NOT NULL,
+    "created_by"              integer     NOT NULL,
+    "updated_on"              timestamptz NOT NULL,
+    "updated_by"              integer     NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_terminal_session_recording_user_id ON public.terminal_session_recording (user_id);
+CREATE INDEX IF NOT EXISTS idx_terminal_session_recording_cluster_id_started_on ON public.terminal_session_recording (cluster_id, started_on);
@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+DROP INDEX IF EXISTS idx_kubernetes_resource_history_updated_by;
+DROP INDEX IF EXISTS idx_kubernetes_resource_history_cluster_resource;
+
+ALTER TABLE kubernetes_resource_history DROP COLUMN IF EXISTS manifest_diff;
+ALTER TABLE kubernetes_resource_history DROP COLUMN IF EXISTS manifest_after;
+ALTER TABLE kubernetes_resource_history DROP COLUMN IF EXISTS manifest_before;
+ALTER TABLE kubernetes_resource_history DROP COLUMN IF EXISTS cluster_id;
@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+ALTER TABLE kubernetes_resource_history ADD COLUMN IF NOT EXISTS cluster_id integer;
+ALTER TABLE kubernetes_resource_history ADD COLUMN IF NOT EXISTS manifest_before text;
+ALTER TABLE kubernetes_resource_history ADD COLUMN IF NOT EXISTS manifest_after text;
+ALTER TABLE kubernetes_resource_history ADD COLUMN IF NOT EXISTS manifest_diff text;
+ALTER TABLE kubernetes_resource_history ALTER COLUMN namespace TYPE VARCHAR(250);
+ALTER TABLE kubernetes_resource_history ALTER COLUMN resource_name TYPE VARCHAR(250);
+ALTER TABLE kubernetes_resource_history ALTER COLUMN "group" TYPE VARCHAR(250);
+
+CREATE INDEX IF NOT EXISTS idx_kubernetes_resource_history_cluster_resource ON public.kubernetes_resource_history (cluster_id, kind, namespace, resource_name);
+CREATE INDEX IF NOT EXISTS idx_kubernetes_resource_history_updated_by ON public.kubernetes_resource_history (updated_by);
@@ -0,0 +1,6 @@
+/*
+ * Copyright (c) 2024. Devtron Inc.
+ */
+
+-- labels of the cluster, exposed to the promotion conditions of the pipelines deploying to it
+ALTER TABLE "public"."cluster" ADD COLUMN IF NOT EXISTS "labels" jsonb;
@@ -21,6 +21,8 @@ type EventType int
 const Trigger EventType = 1
 const Success EventType = 2
 const Fail EventType = 3
+const Digest EventType = 10
+const GitOpsDrift EventType = 11
 
 type PipelineType string
 
@@ -32,10 +34,12 @@ type Level string
 type Channel string
 
 const (
-	Slack   Channel = "slack"
-	SES     Channel = "ses"
-	SMTP    Channel = "smtp"
-	Webhook Channel = "webhook"
+	Slack      Channel = "slack"
+	SES        Channel = "ses"
+	SMTP       Channel = "smtp"
+	Webhook    Channel = "webhook"
+	Teams      Channel = "teams"
+	GoogleChat Channel = "googleChat"
 )
 
 func (c Channel) String() string {
@@ -47,4 +51,6 @@ type UpdateType string
 const (
 	UpdateEvents     UpdateType = "events"
 	UpdateRecipients UpdateType = "recipients"
+	UpdateDelivery   UpdateType = "delivery"
+	UpdateCondition  UpdateType = "condition"
 )